@@ -0,0 +1,100 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+)
+
+// defaultLimit is the maximum number of entries returned when the client doesn't specify a limit.
+const defaultLimit = 100
+
+var _ ServerInterface = (*Wrapper)(nil)
+var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
+
+// Wrapper implements the generated interface from oapi-codegen
+type Wrapper struct {
+	Log audit.Log
+}
+
+// ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
+func (w *Wrapper) ResolveStatusCode(err error) int {
+	return core.ResolveStatusCode(err, map[error]int{})
+}
+
+// Preprocess is called just before the API operation itself is invoked.
+func (w *Wrapper) Preprocess(operationID string, context echo.Context) {
+	context.Set(core.StatusCodeResolverContextKey, w)
+	context.Set(core.OperationIDContextKey, operationID)
+	context.Set(core.ModuleNameContextKey, audit.ModuleName)
+}
+
+// Routes registers the routes of the audit log API.
+func (w *Wrapper) Routes(router core.EchoRouter) {
+	RegisterHandlers(router, w)
+}
+
+// ListAuditLog returns the entries of the audit log matching the given parameters.
+func (w *Wrapper) ListAuditLog(ctx echo.Context, params ListAuditLogParams) error {
+	query := audit.Query{Since: params.Since, Until: params.Until, Limit: defaultLimit}
+	if params.Actor != nil {
+		query.Actor = *params.Actor
+	}
+	if params.Operation != nil {
+		query.Operation = *params.Operation
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return core.InvalidInputError("limit must be at least 1")
+		}
+		query.Limit = *params.Limit
+	}
+	if params.Before != nil {
+		if *params.Before < 1 {
+			return core.InvalidInputError("before must be at least 1")
+		}
+		query.Before = uint32(*params.Before)
+	}
+	entries, err := w.Log.Find(query)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+	return ctx.JSON(http.StatusOK, entries)
+}
+
+// VerifyAuditLog verifies the integrity of the audit log. A broken hash chain is reported in the result, not as error.
+func (w *Wrapper) VerifyAuditLog(ctx echo.Context) error {
+	count, err := w.Log.Verify()
+	if errors.Is(err, audit.ErrIntegrityViolation) {
+		reason := err.Error()
+		return ctx.JSON(http.StatusOK, VerificationResult{Valid: false, Reason: &reason})
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, VerificationResult{Valid: true, Entries: count})
+}
@@ -0,0 +1,141 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_Preprocess(t *testing.T) {
+	w := &Wrapper{}
+	ctrl := gomock.NewController(t)
+	ctx := mock.NewMockContext(ctrl)
+	ctx.EXPECT().Set(core.StatusCodeResolverContextKey, w)
+	ctx.EXPECT().Set(core.OperationIDContextKey, "foo")
+	ctx.EXPECT().Set(core.ModuleNameContextKey, "Audit")
+
+	w.Preprocess("foo", ctx)
+}
+
+func TestWrapper_ListAuditLog(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		actor := "admin"
+		operation := "createDID"
+		since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		limit := 10
+		before := 20
+		entries := []audit.Entry{{Sequence: 1, Actor: actor, Operation: operation}}
+		ctx.log.EXPECT().Find(audit.Query{Actor: actor, Operation: operation, Since: &since, Before: 20, Limit: limit}).Return(entries, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, entries)
+
+		err := ctx.client.ListAuditLog(ctx.echo, ListAuditLogParams{Actor: &actor, Operation: &operation, Since: &since, Before: &before, Limit: &limit})
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - no entries", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.log.EXPECT().Find(audit.Query{Limit: defaultLimit}).Return(nil, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, []audit.Entry{})
+
+		err := ctx.client.ListAuditLog(ctx.echo, ListAuditLogParams{})
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - invalid limit", func(t *testing.T) {
+		ctx := newMockContext(t)
+		limit := 0
+
+		err := ctx.client.ListAuditLog(ctx.echo, ListAuditLogParams{Limit: &limit})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("error - invalid before", func(t *testing.T) {
+		ctx := newMockContext(t)
+		before := 0
+
+		err := ctx.client.ListAuditLog(ctx.echo, ListAuditLogParams{Before: &before})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("error", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.log.EXPECT().Find(gomock.Any()).Return(nil, errors.New("failed"))
+
+		err := ctx.client.ListAuditLog(ctx.echo, ListAuditLogParams{})
+
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestWrapper_VerifyAuditLog(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.log.EXPECT().Verify().Return(3, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, VerificationResult{Valid: true, Entries: 3})
+
+		err := ctx.client.VerifyAuditLog(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.log.EXPECT().Verify().Return(0, fmt.Errorf("%w: entry 2 has been altered", audit.ErrIntegrityViolation))
+		reason := "audit log integrity violation: entry 2 has been altered"
+		ctx.echo.EXPECT().JSON(http.StatusOK, VerificationResult{Valid: false, Reason: &reason})
+
+		err := ctx.client.VerifyAuditLog(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.log.EXPECT().Verify().Return(0, errors.New("failed"))
+
+		err := ctx.client.VerifyAuditLog(ctx.echo)
+
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+type mockContext struct {
+	echo   *mock.MockContext
+	log    *audit.MockLog
+	client *Wrapper
+}
+
+func newMockContext(t *testing.T) mockContext {
+	ctrl := gomock.NewController(t)
+	log := audit.NewMockLog(ctrl)
+	return mockContext{
+		echo:   mock.NewMockContext(ctrl),
+		log:    log,
+		client: &Wrapper{Log: log},
+	}
+}
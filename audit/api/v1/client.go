@@ -0,0 +1,85 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nuts-foundation/nuts-node/core"
+)
+
+// HTTPClient holds the server address and other basic settings for the http client
+type HTTPClient struct {
+	core.ClientConfig
+}
+
+func (hb HTTPClient) client() ClientInterface {
+	response, err := NewClientWithResponses(hb.GetAddress(), WithHTTPClient(core.MustCreateHTTPClient(hb.ClientConfig)))
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
+// List returns the entries of the audit log matching the given parameters.
+func (hb HTTPClient) List(params ListAuditLogParams) ([]AuditEntry, error) {
+	response, err := hb.client().ListAuditLog(context.Background(), &params)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var result []AuditEntry
+	if err = readResponse(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Verify verifies the integrity of the audit log.
+func (hb HTTPClient) Verify() (*VerificationResult, error) {
+	response, err := hb.client().VerifyAuditLog(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var result VerificationResult
+	if err = readResponse(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func readResponse(reader io.Reader, target interface{}) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	if err = json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w, %s", err, string(data))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPClient_List(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		entries := []AuditEntry{{Sequence: 1, Actor: "admin", Operation: "createDID"}}
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: entries})
+		defer s.Close()
+		c := getClient(s.URL)
+		actor := "admin"
+
+		result, err := c.List(ListAuditLogParams{Actor: &actor})
+
+		if !assert.NoError(t, err) || !assert.Len(t, result, 1) {
+			return
+		}
+		assert.Equal(t, "createDID", result[0].Operation)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: ""})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		_, err := c.List(ListAuditLogParams{})
+
+		assert.Error(t, err)
+	})
+	t.Run("error - invalid response", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: "not a list"})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		_, err := c.List(ListAuditLogParams{})
+
+		assert.ErrorContains(t, err, "unable to unmarshal response")
+	})
+}
+
+func TestHTTPClient_Verify(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: VerificationResult{Valid: true, Entries: 2}})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		result, err := c.Verify()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, result.Valid)
+		assert.Equal(t, 2, result.Entries)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: ""})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		_, err := c.Verify()
+
+		assert.Error(t, err)
+	})
+}
+
+func getClient(url string) *HTTPClient {
+	return &HTTPClient{
+		ClientConfig: core.ClientConfig{
+			Address: url, Timeout: time.Second,
+		},
+	}
+}
@@ -0,0 +1,351 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package audit provides a tamper-evident log of the state-changing operations performed on the node.
+package audit
+
+import (
+	"context"
+	crypto2 "crypto"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// ModuleName contains the name of this module
+const ModuleName = "Audit"
+
+const (
+	// storeName contains the name of the KV store containing the audit log
+	storeName = "audit"
+	// entriesShelf has the sequence number of an entry as key and the Entry as value
+	entriesShelf = "entries"
+	// headShelf contains the sequence number and hash of the last entry under headKey
+	headShelf = "head"
+	// timestampShelf indexes the entries by time: it has the timestamp and sequence number of an entry as key (see timestampKey)
+	timestampShelf = "timestamps"
+)
+
+// SigningKID contains the KID of the node key used to sign the entries of the audit log.
+const SigningKID = "audit-log-signing-key"
+
+var headKey = stoabs.BytesKey("head")
+
+// errStopRange is used to stop ranging over a shelf once the wanted key has been found.
+var errStopRange = errors.New("stop")
+
+var _ Log = (*Engine)(nil)
+
+// head refers to the last entry in the audit log.
+type head struct {
+	Sequence  uint32          `json:"sequence"`
+	Hash      hash.SHA256Hash `json:"hash"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Engine is the audit log engine. Entries are chained by including the hash of the previous entry in the hash of an entry,
+// so altering or removing entries breaks the chain, which is detected by Verify.
+// The hash of every entry is signed with a node key, so the chain can't be recomputed by someone with only access to the store.
+type Engine struct {
+	storeProvider storage.Provider
+	keyStore      crypto.KeyStore
+	signingKey    crypto.Key
+	db            stoabs.KVStore
+	mux           sync.Mutex
+	now           func() time.Time
+}
+
+// New creates a new audit log engine, which stores its entries in a store of the given provider
+// and signs them with a key from the given key store.
+func New(storeProvider storage.Provider, keyStore crypto.KeyStore) *Engine {
+	return &Engine{
+		storeProvider: storeProvider,
+		keyStore:      keyStore,
+		now:           time.Now,
+	}
+}
+
+// Name returns the name of the engine.
+func (e *Engine) Name() string {
+	return ModuleName
+}
+
+// Configure opens the store containing the audit log.
+func (e *Engine) Configure(_ core.ServerConfig) error {
+	var err error
+	e.db, err = e.storeProvider.GetKVStore(storeName, storage.PersistentStorageClass)
+	return err
+}
+
+// Start loads the key used to sign entries, generating it if it doesn't exist yet.
+// This can't be done in Configure, since the key store might not have been configured yet.
+func (e *Engine) Start() error {
+	var err error
+	if e.keyStore.Exists(SigningKID) {
+		e.signingKey, err = e.keyStore.Resolve(SigningKID)
+	} else {
+		e.signingKey, err = e.keyStore.New(func(_ crypto2.PublicKey) (string, error) {
+			return SigningKID, nil
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to load audit log signing key: %w", err)
+	}
+	return nil
+}
+
+// Shutdown closes the store containing the audit log.
+func (e *Engine) Shutdown() error {
+	if e.db != nil {
+		return e.db.Close(context.Background())
+	}
+	return nil
+}
+
+// Record appends the given entry to the audit log.
+func (e *Engine) Record(entry Entry) error {
+	// Entries are recorded one at a time, to make sure each entry refers to the actual previous entry
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	return e.db.Write(context.Background(), func(tx stoabs.WriteTx) error {
+		headWriter, err := tx.GetShelfWriter(headShelf)
+		if err != nil {
+			return err
+		}
+		current, err := readHead(headWriter)
+		if err != nil {
+			return err
+		}
+		entry.Sequence = current.Sequence + 1
+		entry.Timestamp = e.now().UTC()
+		// Timestamps never decrease (e.g. when the clock is set back), so the sequence numbers of entries can be found by time
+		if entry.Timestamp.Before(current.Timestamp) {
+			entry.Timestamp = current.Timestamp
+		}
+		entry.PreviousHash = current.Hash
+		if entry.Hash, err = entry.calculateHash(); err != nil {
+			return err
+		}
+		headers := map[string]interface{}{jws.KeyIDKey: SigningKID}
+		if entry.Signature, err = crypto.SignDetachedJWS(entry.Hash.Slice(), headers, e.signingKey.Signer()); err != nil {
+			return fmt.Errorf("unable to sign audit log entry: %w", err)
+		}
+
+		entriesWriter, err := tx.GetShelfWriter(entriesShelf)
+		if err != nil {
+			return err
+		}
+		data, _ := json.Marshal(entry)
+		if err = entriesWriter.Put(stoabs.Uint32Key(entry.Sequence), data); err != nil {
+			return err
+		}
+		timestampWriter, err := tx.GetShelfWriter(timestampShelf)
+		if err != nil {
+			return err
+		}
+		if err = timestampWriter.Put(timestampKey(entry.Timestamp, entry.Sequence), stoabs.Uint32Key(entry.Sequence).Bytes()); err != nil {
+			return err
+		}
+		data, _ = json.Marshal(head{Sequence: entry.Sequence, Hash: entry.Hash, Timestamp: entry.Timestamp})
+		return headWriter.Put(headKey, data)
+	}, stoabs.WithWriteLock())
+}
+
+// Find returns the entries matching the given query. Entries are read from the most recent one backwards,
+// until Limit entries match. Since and Until are looked up in the time index, so only the entries in that range are read.
+func (e *Engine) Find(query Query) ([]Entry, error) {
+	var result []Entry
+	err := e.db.Read(context.Background(), func(tx stoabs.ReadTx) error {
+		current, err := readHead(tx.GetShelfReader(headShelf))
+		if err != nil {
+			return err
+		}
+		// Entries in the range [from, to) are read
+		from, to := uint32(1), current.Sequence+1
+		timestamps := tx.GetShelfReader(timestampShelf)
+		if query.Since != nil {
+			if from, err = firstSequenceAt(timestamps, *query.Since, to); err != nil {
+				return err
+			}
+		}
+		if query.Until != nil {
+			if to, err = firstSequenceAt(timestamps, *query.Until, to); err != nil {
+				return err
+			}
+		}
+		if query.Before > 0 && query.Before < to {
+			to = query.Before
+		}
+		entries := tx.GetShelfReader(entriesShelf)
+		for sequence := to; sequence > from; sequence-- {
+			data, err := entries.Get(stoabs.Uint32Key(sequence - 1))
+			if err != nil {
+				return err
+			}
+			if data == nil {
+				// Missing entry, reported by Verify
+				continue
+			}
+			var entry Entry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("%w: unable to parse entry: %s", ErrIntegrityViolation, err)
+			}
+			if query.matches(entry) {
+				result = append(result, entry)
+				if query.Limit > 0 && len(result) == query.Limit {
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Entries were collected most recent first
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// Verify recomputes the hash chain of the audit log and checks it against the stored hashes.
+func (e *Engine) Verify() (int, error) {
+	count := 0
+	previous := hash.EmptyHash()
+	current, err := e.walk(func(entry Entry) error {
+		count++
+		if entry.Sequence != uint32(count) {
+			return fmt.Errorf("%w: entry %d is missing", ErrIntegrityViolation, count)
+		}
+		if !entry.PreviousHash.Equals(previous) {
+			return fmt.Errorf("%w: entry %d doesn't refer to the previous entry", ErrIntegrityViolation, entry.Sequence)
+		}
+		calculated, err := entry.calculateHash()
+		if err != nil {
+			return err
+		}
+		if !calculated.Equals(entry.Hash) {
+			return fmt.Errorf("%w: entry %d has been altered", ErrIntegrityViolation, entry.Sequence)
+		}
+		if !e.verifySignature(entry) {
+			return fmt.Errorf("%w: entry %d has an invalid signature", ErrIntegrityViolation, entry.Sequence)
+		}
+		previous = entry.Hash
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	// Entries removed from the end of the log can only be detected using the head
+	if current.Sequence != uint32(count) || !current.Hash.Equals(previous) {
+		return 0, fmt.Errorf("%w: log ends at entry %d, expected %d", ErrIntegrityViolation, count, current.Sequence)
+	}
+	// The other way around, the head could have been reset to hide the entries after it
+	var next []byte
+	err = e.db.ReadShelf(context.Background(), entriesShelf, func(reader stoabs.Reader) error {
+		next, err = reader.Get(stoabs.Uint32Key(current.Sequence + 1))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if next != nil {
+		return 0, fmt.Errorf("%w: log continues after entry %d", ErrIntegrityViolation, current.Sequence)
+	}
+	return count, nil
+}
+
+// verifySignature checks whether the hash of the entry is signed with the signing key of the audit log.
+func (e *Engine) verifySignature(entry Entry) bool {
+	kid, alg, err := crypto.JWTKidAlg(entry.Signature)
+	if err != nil || kid != SigningKID {
+		return false
+	}
+	_, err = jws.Verify([]byte(entry.Signature), alg, e.signingKey.Public(), jws.WithDetachedPayload(entry.Hash.Slice()))
+	return err == nil
+}
+
+// walk calls the given function for each entry in the audit log, ordered by sequence number.
+// It returns the head of the audit log, which refers to the last entry.
+func (e *Engine) walk(fn func(entry Entry) error) (head, error) {
+	var current head
+	err := e.db.Read(context.Background(), func(tx stoabs.ReadTx) error {
+		var err error
+		if current, err = readHead(tx.GetShelfReader(headShelf)); err != nil {
+			return err
+		}
+		reader := tx.GetShelfReader(entriesShelf)
+		return reader.Range(stoabs.Uint32Key(1), stoabs.Uint32Key(current.Sequence+1), func(_ stoabs.Key, value []byte) error {
+			var entry Entry
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return fmt.Errorf("%w: unable to parse entry: %s", ErrIntegrityViolation, err)
+			}
+			return fn(entry)
+		}, false)
+	})
+	return current, err
+}
+
+// firstSequenceAt returns the sequence number of the first entry recorded at or after the given time,
+// or notFound if there is no such entry.
+func firstSequenceAt(reader stoabs.Reader, at time.Time, notFound uint32) (uint32, error) {
+	result := notFound
+	err := reader.Range(timestampKey(at, 0), timestampKey(time.Unix(0, math.MaxInt64), math.MaxUint32), func(key stoabs.Key, _ []byte) error {
+		result = binary.BigEndian.Uint32(key.Bytes()[8:])
+		return errStopRange
+	}, false)
+	if err != nil && !errors.Is(err, errStopRange) {
+		return 0, err
+	}
+	return result, nil
+}
+
+// timestampKey returns the key of an entry in the time index: its timestamp (in nanoseconds since the Unix epoch)
+// followed by its sequence number, both big-endian so keys are ordered by time.
+func timestampKey(timestamp time.Time, sequence uint32) stoabs.BytesKey {
+	nanos := timestamp.UnixNano()
+	if nanos < 0 {
+		nanos = 0
+	}
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint64(key, uint64(nanos))
+	binary.BigEndian.PutUint32(key[8:], sequence)
+	return key
+}
+
+func readHead(reader stoabs.Reader) (head, error) {
+	var result head
+	data, err := reader.Get(headKey)
+	if err != nil || data == nil {
+		return result, err
+	}
+	return result, json.Unmarshal(data, &result)
+}
@@ -0,0 +1,338 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEngine(t *testing.T) *Engine {
+	storageEngine := storage.NewTestStorageEngine(io.TestDirectory(t))
+	engine := New(storageEngine.GetProvider(ModuleName), crypto.NewTestCryptoInstance())
+	if err := engine.Configure(core.ServerConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = engine.Shutdown()
+		_ = storageEngine.Shutdown()
+	})
+	return engine
+}
+
+// fillTestEngine records 3 entries, 1 minute apart
+func fillTestEngine(t *testing.T, engine *Engine, start time.Time) {
+	now := start
+	engine.now = func() time.Time {
+		return now
+	}
+	for _, entry := range []Entry{
+		{Actor: "alice", Operation: "createDID", Module: "VDR"},
+		{Actor: "bob", Operation: "issueVC", Module: "VCR"},
+		{Actor: "alice", Operation: "issueVC", Module: "VCR"},
+	} {
+		if err := engine.Record(entry); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Minute)
+	}
+}
+
+func TestEngine_Name(t *testing.T) {
+	assert.Equal(t, "Audit", New(nil, nil).Name())
+}
+
+func TestEngine_Start(t *testing.T) {
+	t.Run("generates signing key", func(t *testing.T) {
+		keyStore := crypto.NewTestCryptoInstance()
+		engine := New(nil, keyStore)
+
+		err := engine.Start()
+
+		assert.NoError(t, err)
+		assert.True(t, keyStore.Exists(SigningKID))
+	})
+	t.Run("uses existing signing key", func(t *testing.T) {
+		keyStore := crypto.NewTestCryptoInstance()
+		key, _ := keyStore.New(crypto.StringNamingFunc(SigningKID))
+		engine := New(nil, keyStore)
+
+		err := engine.Start()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, key.Public(), engine.signingKey.Public())
+	})
+	t.Run("error - unable to generate signing key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyStore := crypto.NewMockKeyStore(ctrl)
+		keyStore.EXPECT().Exists(SigningKID).Return(false)
+		keyStore.EXPECT().New(gomock.Any()).Return(nil, errors.New("failed"))
+		engine := New(nil, keyStore)
+
+		err := engine.Start()
+
+		assert.EqualError(t, err, "unable to load audit log signing key: failed")
+	})
+}
+
+func TestEngine_Record(t *testing.T) {
+	engine := newTestEngine(t)
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	fillTestEngine(t, engine, now)
+
+	entries, err := engine.Find(Query{})
+
+	if !assert.NoError(t, err) || !assert.Len(t, entries, 3) {
+		return
+	}
+	assert.Equal(t, uint32(1), entries[0].Sequence)
+	assert.Equal(t, now, entries[0].Timestamp)
+	assert.Equal(t, "alice", entries[0].Actor)
+	assert.True(t, entries[0].PreviousHash.Empty())
+	assert.False(t, entries[0].Hash.Empty())
+	assert.Equal(t, uint32(3), entries[2].Sequence)
+	assert.Equal(t, entries[1].Hash, entries[2].PreviousHash)
+	t.Run("timestamp doesn't decrease when the clock is set back", func(t *testing.T) {
+		engine.now = func() time.Time {
+			return now
+		}
+
+		err := engine.Record(Entry{Operation: "afterClockChange"})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		entries, _ := engine.Find(Query{Limit: 1})
+		assert.Equal(t, now.Add(2*time.Minute), entries[0].Timestamp)
+	})
+}
+
+func TestEngine_Find(t *testing.T) {
+	engine := newTestEngine(t)
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	fillTestEngine(t, engine, start)
+	sequences := func(entries []Entry) []uint32 {
+		var result []uint32
+		for _, entry := range entries {
+			result = append(result, entry.Sequence)
+		}
+		return result
+	}
+	since := start.Add(time.Minute)
+	until := start.Add(2 * time.Minute)
+	afterLast := start.Add(time.Hour)
+
+	testCases := []struct {
+		name     string
+		query    Query
+		expected []uint32
+	}{
+		{"all", Query{}, []uint32{1, 2, 3}},
+		{"actor", Query{Actor: "alice"}, []uint32{1, 3}},
+		{"operation", Query{Operation: "issueVC"}, []uint32{2, 3}},
+		{"actor and operation", Query{Actor: "alice", Operation: "issueVC"}, []uint32{3}},
+		{"since", Query{Since: &since}, []uint32{2, 3}},
+		{"until", Query{Until: &until}, []uint32{1, 2}},
+		{"since and until", Query{Since: &since, Until: &until}, []uint32{2}},
+		{"since after last entry", Query{Since: &afterLast}, nil},
+		{"limit returns most recent", Query{Limit: 2}, []uint32{2, 3}},
+		{"limit with filter", Query{Actor: "alice", Limit: 1}, []uint32{3}},
+		{"before", Query{Before: 3}, []uint32{1, 2}},
+		{"before and limit", Query{Before: 3, Limit: 1}, []uint32{2}},
+		{"before and since", Query{Before: 3, Since: &since}, []uint32{2}},
+		{"no match", Query{Actor: "eve"}, nil},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entries, err := engine.Find(testCase.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, sequences(entries))
+		})
+	}
+}
+
+func TestEngine_Verify(t *testing.T) {
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	readEntry := func(t *testing.T, engine *Engine, sequence uint32) Entry {
+		var entry Entry
+		_ = engine.db.ReadShelf(context.Background(), entriesShelf, func(reader stoabs.Reader) error {
+			data, _ := reader.Get(stoabs.Uint32Key(sequence))
+			return json.Unmarshal(data, &entry)
+		})
+		return entry
+	}
+	writeEntry := func(t *testing.T, engine *Engine, entry Entry) {
+		data, _ := json.Marshal(entry)
+		err := engine.db.WriteShelf(context.Background(), entriesShelf, func(writer stoabs.Writer) error {
+			return writer.Put(stoabs.Uint32Key(entry.Sequence), data)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+
+		count, err := engine.Verify()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+	t.Run("ok - empty", func(t *testing.T) {
+		engine := newTestEngine(t)
+
+		count, err := engine.Verify()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("entry altered", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		entry := readEntry(t, engine, 2)
+		entry.Actor = "eve"
+		writeEntry(t, engine, entry)
+
+		_, err := engine.Verify()
+
+		assert.ErrorIs(t, err, ErrIntegrityViolation)
+		assert.EqualError(t, err, "audit log integrity violation: entry 2 has been altered")
+	})
+	t.Run("entry altered and rehashed", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		entry := readEntry(t, engine, 2)
+		entry.Actor = "eve"
+		entry.Hash, _ = entry.calculateHash()
+		writeEntry(t, engine, entry)
+
+		_, err := engine.Verify()
+
+		assert.EqualError(t, err, "audit log integrity violation: entry 2 has an invalid signature")
+	})
+	t.Run("entry altered, rehashed and signed with another key", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		entry := readEntry(t, engine, 2)
+		entry.Actor = "eve"
+		entry.Hash, _ = entry.calculateHash()
+		otherKey := crypto.NewTestKey(SigningKID)
+		entry.Signature, _ = crypto.SignDetachedJWS(entry.Hash.Slice(), map[string]interface{}{"kid": SigningKID}, otherKey.Signer())
+		writeEntry(t, engine, entry)
+
+		_, err := engine.Verify()
+
+		assert.EqualError(t, err, "audit log integrity violation: entry 2 has an invalid signature")
+	})
+	t.Run("entry removed", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		_ = engine.db.WriteShelf(context.Background(), entriesShelf, func(writer stoabs.Writer) error {
+			return writer.Delete(stoabs.Uint32Key(2))
+		})
+
+		_, err := engine.Verify()
+
+		assert.EqualError(t, err, "audit log integrity violation: entry 2 is missing")
+	})
+	t.Run("last entry removed", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		_ = engine.db.WriteShelf(context.Background(), entriesShelf, func(writer stoabs.Writer) error {
+			return writer.Delete(stoabs.Uint32Key(3))
+		})
+
+		_, err := engine.Verify()
+
+		assert.EqualError(t, err, "audit log integrity violation: log ends at entry 2, expected 3")
+	})
+	t.Run("head reset", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		entry := readEntry(t, engine, 2)
+		_ = engine.db.WriteShelf(context.Background(), headShelf, func(writer stoabs.Writer) error {
+			data, _ := json.Marshal(head{Sequence: 2, Hash: entry.Hash})
+			return writer.Put(headKey, data)
+		})
+
+		_, err := engine.Verify()
+
+		assert.EqualError(t, err, "audit log integrity violation: log continues after entry 2")
+	})
+	t.Run("first entry doesn't start the chain", func(t *testing.T) {
+		engine := newTestEngine(t)
+		fillTestEngine(t, engine, start)
+		entry := readEntry(t, engine, 1)
+		entry.PreviousHash = hash.SHA256Sum([]byte("other"))
+		writeEntry(t, engine, entry)
+
+		_, err := engine.Verify()
+
+		assert.EqualError(t, err, "audit log integrity violation: entry 1 doesn't refer to the previous entry")
+	})
+}
+
+func TestEngine_survivesRestart(t *testing.T) {
+	testDirectory := io.TestDirectory(t)
+	keyStore := crypto.NewTestCryptoInstance()
+	newEngine := func() (*Engine, storage.Engine) {
+		storageEngine := storage.NewTestStorageEngine(testDirectory)
+		engine := New(storageEngine.GetProvider(ModuleName), keyStore)
+		if err := engine.Configure(core.ServerConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.Start(); err != nil {
+			t.Fatal(err)
+		}
+		return engine, storageEngine
+	}
+	engine, storageEngine := newEngine()
+	_ = engine.Record(Entry{Operation: "first"})
+	_ = engine.Shutdown()
+	_ = storageEngine.Shutdown()
+
+	engine, storageEngine = newEngine()
+	defer storageEngine.Shutdown()
+	defer engine.Shutdown()
+	_ = engine.Record(Entry{Operation: "second"})
+	count, err := engine.Verify()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
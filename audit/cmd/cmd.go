@@ -0,0 +1,158 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	api "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/spf13/cobra"
+)
+
+// Cmd contains sub-commands for the remote client
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit log commands",
+	}
+	cmd.AddCommand(listCmd())
+	cmd.AddCommand(verifyCmd())
+	return cmd
+}
+
+func listCmd() *cobra.Command {
+	var actor, operation, since, until string
+	var limit, before int
+	result := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the entries of the audit log.",
+		Long: "Lists the entries of the audit log, which records the state-changing operations performed on the node. " +
+			"Each line contains the sequence number, time, actor, operation, HTTP status and subject (or request path) of an entry.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := api.ListAuditLogParams{}
+			if actor != "" {
+				params.Actor = &actor
+			}
+			if operation != "" {
+				params.Operation = &operation
+			}
+			if limit > 0 {
+				params.Limit = &limit
+			}
+			if before > 0 {
+				params.Before = &before
+			}
+			var err error
+			if params.Since, err = parseTime(since); err != nil {
+				return err
+			}
+			if params.Until, err = parseTime(until); err != nil {
+				return err
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			entries, err := httpClient(clientConfig).List(params)
+			if err != nil {
+				return fmt.Errorf("unable to list audit log: %w", err)
+			}
+			for _, entry := range entries {
+				cmd.Println(formatEntry(entry))
+			}
+			return nil
+		},
+	}
+	result.Flags().StringVar(&actor, "actor", "", "Only list entries of the given actor (the subject of the API token used).")
+	result.Flags().StringVar(&operation, "operation", "", "Only list entries of the given operation (e.g. createDID).")
+	result.Flags().StringVar(&since, "since", "", "Only list entries recorded at or after the given time (RFC3339).")
+	result.Flags().StringVar(&until, "until", "", "Only list entries recorded before the given time (RFC3339).")
+	result.Flags().IntVar(&limit, "limit", 0, "Maximum number of entries to list (default 100). If more entries match, the most recent ones are listed.")
+	result.Flags().IntVar(&before, "before", 0, "Only list entries with a lower sequence number, to list the entries preceding the listed ones.")
+	return result
+}
+
+func verifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the integrity of the audit log.",
+		Long: "Verifies the integrity of the audit log by recomputing the hash chain of its entries and checking their signatures. " +
+			"Fails if an entry has been altered, removed or inserted.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			result, err := httpClient(clientConfig).Verify()
+			if err != nil {
+				return fmt.Errorf("unable to verify audit log: %w", err)
+			}
+			if !result.Valid {
+				reason := audit.ErrIntegrityViolation.Error()
+				if result.Reason != nil {
+					reason = *result.Reason
+				}
+				return errors.New(reason)
+			}
+			cmd.Printf("Audit log is intact (%d entries)\n", result.Entries)
+			return nil
+		},
+	}
+}
+
+func formatEntry(entry api.AuditEntry) string {
+	actor := entry.Actor
+	if actor == "" {
+		actor = "-"
+	}
+	status := "-"
+	if entry.Status != 0 {
+		status = fmt.Sprintf("%d", entry.Status)
+	}
+	subject := entry.Subject
+	if subject == "" {
+		subject = entry.Path
+	}
+	return strings.Join([]string{
+		fmt.Sprintf("%d", entry.Sequence),
+		entry.Timestamp.Format(time.RFC3339),
+		actor,
+		entry.Operation,
+		status,
+		subject,
+	}, "\t")
+}
+
+func parseTime(input string) (*time.Time, error) {
+	if input == "" {
+		return nil, nil
+	}
+	result, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time (expected RFC3339): %s", input)
+	}
+	return &result, nil
+}
+
+func httpClient(config core.ClientConfig) api.HTTPClient {
+	return api.HTTPClient{
+		ClientConfig: config,
+	}
+}
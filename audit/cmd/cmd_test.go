@@ -0,0 +1,139 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	api "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	"github.com/nuts-foundation/nuts-node/core"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmd(t *testing.T) {
+	var query url.Values
+	newCmdWithServer := func(t *testing.T, handler http2.Handler) (*cobra.Command, *bytes.Buffer) {
+		s := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			query = request.URL.Query()
+			handler.ServeHTTP(writer, request)
+		}))
+		assert.NoError(t, os.Setenv("NUTS_ADDRESS", s.URL), "unable to set the NUTS_ADDRESS env var")
+		t.Cleanup(func() {
+			s.Close()
+			assert.NoError(t, os.Unsetenv("NUTS_ADDRESS"))
+		})
+		cmd := Cmd()
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		return cmd, buf
+	}
+
+	t.Run("list", func(t *testing.T) {
+		timestamp := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		entries := []api.AuditEntry{
+			{Sequence: 1, Timestamp: timestamp, Actor: "admin", Operation: "createDID", Status: 200, Path: "/internal/vdr/v1/did"},
+			{Sequence: 2, Timestamp: timestamp, Actor: "system", Operation: "deactivateExpiredDID", Subject: "did:nuts:123"},
+		}
+
+		t.Run("ok", func(t *testing.T) {
+			cmd, buf := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: entries})
+			cmd.SetArgs([]string{"list"})
+
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "1\t2023-01-01T12:00:00Z\tadmin\tcreateDID\t200\t/internal/vdr/v1/did\n"+
+				"2\t2023-01-01T12:00:00Z\tsystem\tdeactivateExpiredDID\t-\tdid:nuts:123\n", buf.String())
+			assert.Empty(t, query)
+		})
+		t.Run("ok - with filters", func(t *testing.T) {
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: []api.AuditEntry{}})
+			cmd.SetArgs([]string{"list", "--actor", "admin", "--operation", "createDID",
+				"--since", "2023-01-01T00:00:00Z", "--until", "2023-02-01T00:00:00Z", "--limit", "5", "--before", "40"})
+
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "admin", query.Get("actor"))
+			assert.Equal(t, "createDID", query.Get("operation"))
+			assert.Equal(t, "2023-01-01T00:00:00Z", query.Get("since"))
+			assert.Equal(t, "2023-02-01T00:00:00Z", query.Get("until"))
+			assert.Equal(t, "5", query.Get("limit"))
+			assert.Equal(t, "40", query.Get("before"))
+		})
+		t.Run("error - invalid time", func(t *testing.T) {
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK})
+			cmd.SetArgs([]string{"list", "--since", "yesterday"})
+
+			err := cmd.Execute()
+
+			assert.EqualError(t, err, "invalid time (expected RFC3339): yesterday")
+		})
+		t.Run("error - server error", func(t *testing.T) {
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: "b00m!"})
+			cmd.SetArgs([]string{"list"})
+
+			err := cmd.Execute()
+
+			assert.ErrorContains(t, err, "unable to list audit log")
+		})
+	})
+	t.Run("verify", func(t *testing.T) {
+		t.Run("valid", func(t *testing.T) {
+			cmd, buf := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: api.VerificationResult{Valid: true, Entries: 5}})
+			cmd.SetArgs([]string{"verify"})
+
+			err := cmd.Execute()
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Audit log is intact (5 entries)\n", buf.String())
+		})
+		t.Run("invalid", func(t *testing.T) {
+			reason := "audit log integrity violation: entry 2 has been altered"
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: api.VerificationResult{Valid: false, Reason: &reason}})
+			cmd.SetArgs([]string{"verify"})
+
+			err := cmd.Execute()
+
+			assert.EqualError(t, err, reason)
+		})
+		t.Run("error - server error", func(t *testing.T) {
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: "b00m!"})
+			cmd.SetArgs([]string{"verify"})
+
+			err := cmd.Execute()
+
+			assert.ErrorContains(t, err, "unable to verify audit log")
+		})
+	})
+}
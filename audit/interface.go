@@ -0,0 +1,35 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+// Recorder records entries in the audit log.
+type Recorder interface {
+	// Record appends the given entry to the audit log. Its sequence number, timestamp and hashes are set by the audit log.
+	Record(entry Entry) error
+}
+
+// Log is a tamper-evident, append-only audit log.
+type Log interface {
+	Recorder
+	// Find returns the entries matching the given query, ordered by sequence number.
+	Find(query Query) ([]Entry, error)
+	// Verify checks the integrity of the audit log by recomputing the hash chain and checking the signatures of its entries.
+	// It returns the number of verified entries. If the chain is broken, an error wrapping ErrIntegrityViolation is returned.
+	Verify() (int, error)
+}
@@ -0,0 +1,107 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+)
+
+// ErrIntegrityViolation is returned when the hash chain of the audit log is broken, indicating it has been tampered with.
+var ErrIntegrityViolation = errors.New("audit log integrity violation")
+
+// SystemActor is the actor of entries recorded for operations the node performs by itself (e.g. scheduled tasks).
+const SystemActor = "system"
+
+// Entry is a single entry in the audit log.
+type Entry struct {
+	// Sequence is the position of the entry in the audit log, starting at 1.
+	Sequence uint32 `json:"sequence"`
+	// Timestamp is the time at which the entry was recorded.
+	Timestamp time.Time `json:"timestamp"`
+	// Actor identifies who performed the operation: the subject of the API token used, or SystemActor.
+	// It is empty when the operation was invoked without authentication.
+	Actor string `json:"actor,omitempty"`
+	// Module is the name of the engine that performed the operation.
+	Module string `json:"module,omitempty"`
+	// Operation is the name of the performed operation (e.g. the API operation ID).
+	Operation string `json:"operation"`
+	// Subject identifies the entity the operation was performed on (e.g. a DID), if known.
+	Subject string `json:"subject,omitempty"`
+	// Method is the HTTP method of the request, if the operation was invoked through the HTTP API.
+	Method string `json:"method,omitempty"`
+	// Path is the HTTP request path, if the operation was invoked through the HTTP API.
+	Path string `json:"path,omitempty"`
+	// RemoteIP is the IP address of the HTTP client, if the operation was invoked through the HTTP API.
+	RemoteIP string `json:"remoteIP,omitempty"`
+	// Status is the HTTP status code of the response, if the operation was invoked through the HTTP API.
+	Status int `json:"status,omitempty"`
+	// PreviousHash is the hash of the previous entry, or an empty hash for the first entry.
+	PreviousHash hash.SHA256Hash `json:"previousHash"`
+	// Hash is the SHA-256 hash over the entry (excluding this field), which includes the hash of the previous entry.
+	Hash hash.SHA256Hash `json:"hash"`
+	// Signature is a JWS with detached payload over Hash, signed with the signing key of the audit log (see SigningKID).
+	Signature string `json:"signature"`
+}
+
+// calculateHash returns the hash over all fields of the entry, except Hash and Signature.
+func (e Entry) calculateHash() (hash.SHA256Hash, error) {
+	e.Hash = hash.EmptyHash()
+	e.Signature = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return hash.EmptyHash(), err
+	}
+	return hash.SHA256Sum(data), nil
+}
+
+// Query specifies which entries to return from the audit log. Empty fields match all entries.
+type Query struct {
+	// Actor only matches entries recorded for the given actor.
+	Actor string
+	// Operation only matches entries of the given operation.
+	Operation string
+	// Since only matches entries recorded at or after the given time.
+	Since *time.Time
+	// Until only matches entries recorded before the given time.
+	Until *time.Time
+	// Before only matches entries with a lower sequence number, to page through the audit log from the most recent entries backwards.
+	Before uint32
+	// Limit specifies the maximum number of entries to return. If more entries match, the most recent ones are returned.
+	Limit int
+}
+
+func (q Query) matches(entry Entry) bool {
+	if q.Actor != "" && entry.Actor != q.Actor {
+		return false
+	}
+	if q.Operation != "" && entry.Operation != q.Operation {
+		return false
+	}
+	if q.Since != nil && entry.Timestamp.Before(*q.Since) {
+		return false
+	}
+	if q.Until != nil && !entry.Timestamp.Before(*q.Until) {
+		return false
+	}
+	return true
+}
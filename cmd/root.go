@@ -29,6 +29,9 @@ import (
 	"os"
 	"runtime/pprof"
 
+	"github.com/nuts-foundation/nuts-node/audit"
+	auditAPI "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	auditCmd "github.com/nuts-foundation/nuts-node/audit/cmd"
 	"github.com/nuts-foundation/nuts-node/auth"
 	authIrmaAPI "github.com/nuts-foundation/nuts-node/auth/api/irma"
 	authAPI "github.com/nuts-foundation/nuts-node/auth/api/v1"
@@ -55,7 +58,11 @@ import (
 	"github.com/nuts-foundation/nuts-node/vdr"
 	vdrAPI "github.com/nuts-foundation/nuts-node/vdr/api/v1"
 	vdrCmd "github.com/nuts-foundation/nuts-node/vdr/cmd"
+	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
+	"github.com/nuts-foundation/nuts-node/vdr/didkey"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
 	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/expiry"
 	"github.com/nuts-foundation/nuts-node/vdr/store"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -179,42 +186,65 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 
 	// Create instances
 	cryptoInstance := crypto.NewCryptoInstance()
-	httpServerInstance := httpEngine.New(shutdownCallback, cryptoInstance)
 	jsonld := jsonld.NewJSONLDInstance()
 	storageInstance := storage.New()
+	auditInstance := audit.New(storageInstance.GetProvider(audit.ModuleName), cryptoInstance)
+	httpServerInstance := httpEngine.New(shutdownCallback, cryptoInstance, auditInstance)
 	didStore := store.NewStore(storageInstance.GetProvider(vdr.ModuleName))
 	keyResolver := doc.KeyResolver{Store: didStore}
 	docResolver := doc.Resolver{Store: didStore}
 	docFinder := doc.Finder{Store: didStore}
 	eventManager := events.NewManager()
+	webHost := didweb.NewHost(cryptoInstance, storageInstance.GetProvider(vdr.ModuleName))
+	webResolver := didweb.NewResolver()
+	webResolver.Local = webHost
+	// DID documents managed through the VDR are either did:nuts documents or did:web documents hosted by this node
+	managedMethods := doc.NewMethodResolvers()
+	managedMethods.Register(didweb.MethodName, webHost)
+	managedDocResolver := doc.Resolver{Store: didStore, Methods: managedMethods}
+	// Credentials may be issued by DIDs of other methods than did:nuts, so the VCR resolves those as well.
+	// Other engines (e.g. the network) only deal with did:nuts DIDs.
+	methodResolvers := doc.NewMethodResolvers()
+	methodResolvers.Register(didkey.MethodName, didkey.NewResolver())
+	methodResolvers.Register(didjwk.MethodName, didjwk.NewResolver())
+	methodResolvers.Register(didweb.MethodName, webResolver)
+	credentialKeyResolver := doc.KeyResolver{Store: didStore, Methods: methodResolvers}
+	credentialDocResolver := doc.Resolver{Store: didStore, Methods: methodResolvers}
 	networkInstance := network.NewNetworkInstance(network.DefaultConfig(), keyResolver, cryptoInstance, cryptoInstance, docResolver, docFinder, eventManager, storageInstance.GetProvider(network.ModuleName))
-	vdrInstance := vdr.NewVDR(vdr.DefaultConfig(), cryptoInstance, networkInstance, didStore, eventManager)
-	credentialInstance := vcr.NewVCRInstance(cryptoInstance, docResolver, keyResolver, networkInstance, jsonld, eventManager, storageInstance)
+	vdrInstance := vdr.NewVDR(vdr.DefaultConfig(), cryptoInstance, networkInstance, didStore, eventManager, webHost, webResolver)
+	credentialInstance := vcr.NewVCRInstance(cryptoInstance, credentialDocResolver, credentialKeyResolver, networkInstance, jsonld, eventManager, storageInstance)
 	didmanInstance := didman.NewDidmanInstance(docResolver, didStore, vdrInstance, credentialInstance, jsonld)
 	authInstance := auth.NewAuthInstance(auth.DefaultConfig(), didStore, credentialInstance, cryptoInstance, didmanInstance, jsonld)
 	statusEngine := status.NewStatusEngine(system)
 	metricsEngine := core.NewMetricsEngine()
 
+	docManipulator := &doc.Manipulator{
+		KeyCreator:   cryptoInstance,
+		Updater:      vdrInstance,
+		Resolver:     managedDocResolver,
+		KeyLifecycle: cryptoInstance,
+	}
+	expiryScheduler := expiry.NewScheduler(storageInstance.GetProvider(vdr.ModuleName), managedDocResolver, docManipulator, cryptoInstance, auditInstance)
+
 	// Register HTTP routes
 	system.RegisterRoutes(&core.LandingPage{})
-	system.RegisterRoutes(&cryptoAPI.Wrapper{C: cryptoInstance})
+	system.RegisterRoutes(&cryptoAPI.Wrapper{C: cryptoInstance, DocManipulator: docManipulator})
 	system.RegisterRoutes(&networkAPI.Wrapper{Service: networkInstance})
-	system.RegisterRoutes(&vdrAPI.Wrapper{VDR: vdrInstance, DocResolver: docResolver, DocManipulator: &doc.Manipulator{
-		KeyCreator: cryptoInstance,
-		Updater:    vdrInstance,
-		Resolver:   docResolver,
-	}})
+	system.RegisterRoutes(&vdrAPI.Wrapper{VDR: vdrInstance, DocResolver: managedDocResolver, DocManipulator: docManipulator, DocFinder: docFinder, ExpiryScheduler: expiryScheduler})
+	system.RegisterRoutes(webHost)
 	system.RegisterRoutes(&credAPIv2.Wrapper{VCR: credentialInstance, ContextManager: jsonld})
 	system.RegisterRoutes(statusEngine.(core.Routable))
 	system.RegisterRoutes(metricsEngine.(core.Routable))
 	system.RegisterRoutes(&authAPI.Wrapper{Auth: authInstance, CredentialResolver: credentialInstance})
 	system.RegisterRoutes(&authIrmaAPI.Wrapper{Auth: authInstance})
 	system.RegisterRoutes(&didmanAPI.Wrapper{Didman: didmanInstance})
+	system.RegisterRoutes(&auditAPI.Wrapper{Log: auditInstance})
 
 	// Register engines
 	system.RegisterEngine(jsonld)
 	system.RegisterEngine(eventManager)
 	system.RegisterEngine(storageInstance)
+	system.RegisterEngine(auditInstance)
 	system.RegisterEngine(didStore)
 	system.RegisterEngine(statusEngine)
 	system.RegisterEngine(metricsEngine)
@@ -223,6 +253,7 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	system.RegisterEngine(credentialInstance)
 	system.RegisterEngine(networkInstance)
 	system.RegisterEngine(vdrInstance)
+	system.RegisterEngine(expiryScheduler)
 	system.RegisterEngine(authInstance)
 	system.RegisterEngine(didmanInstance)
 	// HTTP engine MUST be registered last, because when started it dispatches HTTP calls to the registered routes.
@@ -249,6 +280,7 @@ func addSubCommands(system *core.System, root *cobra.Command) {
 		vcrCmd.Cmd(),
 		vdrCmd.Cmd(),
 		didmanCmd.Cmd(),
+		auditCmd.Cmd(),
 	}
 	clientFlags := core.ClientConfigFlags()
 	for _, clientCommand := range clientCommands {
@@ -257,15 +289,22 @@ func addSubCommands(system *core.System, root *cobra.Command) {
 	root.AddCommand(clientCommands...)
 
 	// Register server commands
+	cryptoCommand := cryptoCmd.ServerCmd()
 	serverCommands := []*cobra.Command{
 		createServerCommand(system),
 		createPrintConfigCommand(system),
-		cryptoCmd.ServerCmd(),
+		cryptoCommand,
 		httpCmd.ServerCmd(),
 	}
 	flagSet := serverConfigFlags()
 	registerFlags(serverCommands, flagSet)
 
+	// Crypto has both server and client commands, client commands are added after registering the server flags.
+	for _, clientCommand := range cryptoCmd.ClientCmds() {
+		clientCommand.PersistentFlags().AddFlagSet(clientFlags)
+		cryptoCommand.AddCommand(clientCommand)
+	}
+
 	root.AddCommand(serverCommands...)
 }
 
@@ -286,6 +325,7 @@ func serverConfigFlags() *pflag.FlagSet {
 	set.AddFlagSet(storageCmd.FlagSet())
 	set.AddFlagSet(networkCmd.FlagSet())
 	set.AddFlagSet(vdrCmd.FlagSet())
+	set.AddFlagSet(vcrCmd.FlagSet())
 	set.AddFlagSet(jsonld.FlagSet())
 	set.AddFlagSet(authCmd.FlagSet())
 	set.AddFlagSet(eventsCmd.FlagSet())
@@ -168,5 +168,5 @@ func Test_CreateSystem(t *testing.T) {
 	system.VisitEngines(func(engine core.Engine) {
 		numEngines++
 	})
-	assert.Equal(t, 13, numEngines)
+	assert.Equal(t, 15, numEngines)
 }
@@ -23,8 +23,10 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
 )
 
 var _ ServerInterface = (*Wrapper)(nil)
@@ -32,13 +34,22 @@ var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
 
 // Wrapper implements the generated interface from oapi-codegen
 type Wrapper struct {
-	C crypto.JWTSigner
+	C crypto.KeyStore
+	// DocManipulator is used to replace the verification method of a rotated key in its DID document
+	DocManipulator types.DocManipulator
 }
 
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
 func (w *Wrapper) ResolveStatusCode(err error) int {
 	return core.ResolveStatusCode(err, map[error]int{
-		crypto.ErrPrivateKeyNotFound: http.StatusBadRequest,
+		crypto.ErrPrivateKeyNotFound:     http.StatusBadRequest,
+		crypto.ErrPrivateKeyRetired:      http.StatusBadRequest,
+		crypto.ErrPrivateKeyNotActive:    http.StatusBadRequest,
+		did.ErrInvalidDID:                http.StatusBadRequest,
+		types.ErrNotFound:                http.StatusNotFound,
+		types.ErrKeyNotFound:             http.StatusNotFound,
+		types.ErrDIDNotManagedByThisNode: http.StatusForbidden,
+		types.ErrDeactivated:             http.StatusConflict,
 	})
 }
 
@@ -88,3 +99,34 @@ func (w *Wrapper) SignJwt(ctx echo.Context) error {
 
 	return ctx.String(http.StatusOK, sig)
 }
+
+// ListKeys handles api calls for listing the keys in the key store
+func (w *Wrapper) ListKeys(ctx echo.Context) error {
+	result := make([]KeyMetadata, 0)
+	for _, kid := range w.C.List() {
+		metadata, err := w.C.Metadata(kid)
+		if err != nil {
+			return err
+		}
+		result = append(result, *metadata)
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// RotateKey handles api calls for rotating the key of a verification method
+func (w *Wrapper) RotateKey(ctx echo.Context, kidStr string) error {
+	kid, err := did.ParseDIDURL(kidStr)
+	if err != nil {
+		return core.InvalidInputError("given kid could not be parsed: %w", err)
+	}
+	id := *kid
+	id.Fragment = ""
+	id.Path = ""
+	id.Query = ""
+
+	method, err := w.DocManipulator.RotateVerificationMethod(id, *kid)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, *method)
+}
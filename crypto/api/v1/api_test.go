@@ -28,8 +28,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/mock"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
 )
 
 func TestWrapper_Preprocess(t *testing.T) {
@@ -140,22 +142,97 @@ func TestWrapper_SignJwt(t *testing.T) {
 	})
 }
 
+func TestWrapper_ListKeys(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+		metadata := crypto.KeyMetadata{KID: "kid", State: crypto.RetiredKeyState}
+		ctx.keyStore.EXPECT().List().Return([]string{"kid"})
+		ctx.keyStore.EXPECT().Metadata("kid").Return(&metadata, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, []KeyMetadata{metadata})
+
+		err := ctx.client.ListKeys(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - no keys", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+		ctx.keyStore.EXPECT().List().Return(nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, []KeyMetadata{})
+
+		err := ctx.client.ListKeys(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - metadata fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+		ctx.keyStore.EXPECT().List().Return([]string{"kid"})
+		ctx.keyStore.EXPECT().Metadata("kid").Return(nil, errors.New("b00m!"))
+
+		err := ctx.client.ListKeys(ctx.echo)
+
+		assert.EqualError(t, err, "b00m!")
+	})
+}
+
+func TestWrapper_RotateKey(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:123")
+	keyID, _ := did.ParseDIDURL("did:nuts:123#abc")
+	newKeyID, _ := did.ParseDIDURL("did:nuts:123#def")
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+		method := &did.VerificationMethod{ID: *newKeyID}
+		ctx.docManipulator.EXPECT().RotateVerificationMethod(*id, *keyID).Return(method, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, *method)
+
+		err := ctx.client.RotateKey(ctx.echo, keyID.String())
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - invalid kid", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+
+		err := ctx.client.RotateKey(ctx.echo, "not a DID")
+
+		assert.ErrorContains(t, err, "given kid could not be parsed")
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+	t.Run("error - rotation fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+		ctx.docManipulator.EXPECT().RotateVerificationMethod(*id, *keyID).Return(nil, types.ErrKeyNotFound)
+
+		err := ctx.client.RotateKey(ctx.echo, keyID.String())
+
+		assert.ErrorIs(t, err, types.ErrKeyNotFound)
+		assert.Equal(t, http.StatusNotFound, ctx.client.ResolveStatusCode(err))
+	})
+}
+
 type mockContext struct {
-	ctrl     *gomock.Controller
-	echo     *mock.MockContext
-	keyStore *crypto.MockKeyStore
-	client   *Wrapper
+	ctrl           *gomock.Controller
+	echo           *mock.MockContext
+	keyStore       *crypto.MockKeyStore
+	docManipulator *types.MockDocManipulator
+	client         *Wrapper
 }
 
 func newMockContext(t *testing.T) mockContext {
 	ctrl := gomock.NewController(t)
 	keyStore := crypto.NewMockKeyStore(ctrl)
-	client := &Wrapper{C: keyStore}
+	docManipulator := types.NewMockDocManipulator(ctrl)
+	client := &Wrapper{C: keyStore, DocManipulator: docManipulator}
 
 	return mockContext{
-		ctrl:     ctrl,
-		echo:     mock.NewMockContext(ctrl),
-		keyStore: keyStore,
-		client:   client,
+		ctrl:           ctrl,
+		echo:           mock.NewMockContext(ctrl),
+		keyStore:       keyStore,
+		docManipulator: docManipulator,
+		client:         client,
 	}
 }
@@ -0,0 +1,86 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+)
+
+// HTTPClient holds the server address and other basic settings for the http client
+type HTTPClient struct {
+	core.ClientConfig
+}
+
+func (hb HTTPClient) client() ClientInterface {
+	response, err := NewClientWithResponses(hb.GetAddress(), WithHTTPClient(core.MustCreateHTTPClient(hb.ClientConfig)))
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
+// ListKeys returns the keys in the key store of the node, including their lifecycle state.
+func (hb HTTPClient) ListKeys() ([]KeyMetadata, error) {
+	response, err := hb.client().ListKeys(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var result []KeyMetadata
+	if err = readResponse(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RotateKey replaces the verification method of the given key with a newly generated one and returns it.
+func (hb HTTPClient) RotateKey(kid string) (*did.VerificationMethod, error) {
+	response, err := hb.client().RotateKey(context.Background(), kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	result := did.VerificationMethod{}
+	if err = readResponse(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func readResponse(reader io.Reader, target interface{}) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	if err = json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w, %s", err, string(data))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPClient_ListKeys(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		keys := []KeyMetadata{{KID: "did:nuts:123#abc", State: crypto.ActiveKeyState}}
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: keys})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		result, err := c.ListKeys()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, keys, result)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: ""})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		_, err := c.ListKeys()
+
+		assert.Error(t, err)
+	})
+	t.Run("error - invalid response", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: "not a list"})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		_, err := c.ListKeys()
+
+		assert.ErrorContains(t, err, "unable to unmarshal response")
+	})
+}
+
+func TestHTTPClient_RotateKey(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		id, _ := did.ParseDIDURL("did:nuts:123#new")
+		controller, _ := did.ParseDID("did:nuts:123")
+		method := did.VerificationMethod{ID: *id, Controller: *controller}
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: method})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		result, err := c.RotateKey("did:nuts:123#abc")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "did:nuts:123#new", result.ID.String())
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusNotFound, ResponseData: ""})
+		defer s.Close()
+		c := getClient(s.URL)
+
+		_, err := c.RotateKey("did:nuts:123#abc")
+
+		assert.Error(t, err)
+	})
+}
+
+func getClient(url string) *HTTPClient {
+	return &HTTPClient{
+		ClientConfig: core.ClientConfig{
+			Address: url, Timeout: time.Second,
+		},
+	}
+}
@@ -42,6 +42,14 @@ func (t *testServerInterface) SignJwt(_ echo.Context) error {
 	return t.err
 }
 
+func (t *testServerInterface) ListKeys(_ echo.Context) error {
+	return t.err
+}
+
+func (t *testServerInterface) RotateKey(_ echo.Context, _ string) error {
+	return t.err
+}
+
 var siws = []*ServerInterfaceWrapper{
 	serverInterfaceWrapper(nil), serverInterfaceWrapper(errors.New("Server error")),
 }
@@ -67,6 +75,8 @@ func TestRegisterHandlers(t *testing.T) {
 		echo := core.NewMockEchoRouter(ctrl)
 
 		echo.EXPECT().POST("/internal/crypto/v1/sign_jwt", gomock.Any())
+		echo.EXPECT().GET("/internal/crypto/v1/keys", gomock.Any())
+		echo.EXPECT().POST("/internal/crypto/v1/keys/:kid/rotate", gomock.Any())
 
 		RegisterHandlers(echo, &testServerInterface{})
 	})
@@ -19,9 +19,18 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+
 	"github.com/nuts-foundation/nuts-node/core"
 	cryptoEngine "github.com/nuts-foundation/nuts-node/crypto"
+	api "github.com/nuts-foundation/nuts-node/crypto/api/v1"
 	"github.com/nuts-foundation/nuts-node/crypto/storage"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -32,11 +41,14 @@ func FlagSet() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("crypto", pflag.ContinueOnError)
 
 	defs := cryptoEngine.DefaultCryptoConfig()
-	flags.String("crypto.storage", defs.Storage, fmt.Sprintf("Storage to use, 'fs' for file system, vaultkv for Vault KV store, default: %s.", defs.Storage))
+	flags.String("crypto.storage", defs.Storage, fmt.Sprintf("Storage to use, 'fs' for file system, vaultkv for Vault KV store, pkcs11 for a PKCS#11 token (HSM), default: %s.", defs.Storage))
 	flags.String("crypto.vault.token", defs.Vault.Token, "The Vault token. If set it overwrites the VAULT_TOKEN env var.")
 	flags.String("crypto.vault.address", defs.Vault.Address, "The Vault address. If set it overwrites the VAULT_ADDR env var.")
 	flags.Duration("crypto.vault.timeout", defs.Vault.Timeout, "Timeout of client calls to Vault, in Golang time.Duration string format (e.g. 5s).")
 	flags.String("crypto.vault.pathprefix", defs.Vault.PathPrefix, fmt.Sprintf("The Vault path prefix. default: %s.", defs.Vault.PathPrefix))
+	flags.String("crypto.pkcs11.library", defs.PKCS11.Library, "Path to the PKCS#11 library (shared object) of the HSM, required when crypto.storage is pkcs11.")
+	flags.String("crypto.pkcs11.tokenlabel", defs.PKCS11.TokenLabel, "Label of the PKCS#11 token the private keys are stored in.")
+	flags.String("crypto.pkcs11.pin", defs.PKCS11.Pin, "User PIN of the PKCS#11 token.")
 
 	return flags
 }
@@ -48,9 +60,67 @@ func ServerCmd() *cobra.Command {
 		Short: "crypto commands",
 	}
 	cmd.AddCommand(fs2VaultCommand())
+	cmd.AddCommand(exportCommand())
+	cmd.AddCommand(importCommand())
 	return cmd
 }
 
+// ClientCmds returns the crypto CLI commands that are executed against a remote Nuts node (e.g. over HTTP).
+// They're added to the crypto command returned by ServerCmd.
+func ClientCmds() []*cobra.Command {
+	return []*cobra.Command{listKeysCommand(), rotateKeyCommand()}
+}
+
+func listKeysCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the private keys in the key store of the Nuts node and their lifecycle state (active, retired or destroyed).",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			keys, err := httpClient(clientConfig).ListKeys()
+			if err != nil {
+				return fmt.Errorf("unable to list keys: %w", err)
+			}
+			for _, key := range keys {
+				if key.Successor != "" {
+					cmd.Printf("%s\t%s\t(successor: %s)\n", key.KID, key.State, key.Successor)
+				} else {
+					cmd.Printf("%s\t%s\n", key.KID, key.State)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func rotateKeyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate [kid]",
+		Short: "Replaces the key of a verification method in its DID document with a newly generated key.",
+		Long: "Replaces the key of a verification method in its DID document with a newly generated key, " +
+			"which is added to the same verification relationships. The old key is retired: it can't be used for signing anymore, " +
+			"but can still be used to decrypt data. The kid must be the ID of the verification method.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			method, err := httpClient(clientConfig).RotateKey(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to rotate key: %w", err)
+			}
+			bytes, _ := json.MarshalIndent(method, "", "  ")
+			cmd.Println(string(bytes))
+			return nil
+		},
+	}
+}
+
+func httpClient(config core.ClientConfig) api.HTTPClient {
+	return api.HTTPClient{
+		ClientConfig: config,
+	}
+}
+
 func fs2VaultCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "fs2vault [directory]",
@@ -91,6 +161,136 @@ func fs2VaultCommand() *cobra.Command {
 	return cmd
 }
 
+func exportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export [file]",
+		Short: "Exports all private keys from the configured storage backend into a passphrase-encrypted archive file.",
+		Long: "Exports all private keys (and their metadata) from the configured storage backend into a passphrase-encrypted archive file, " +
+			"which can be imported into another storage backend using the import command. The passphrase is read from standard input. " +
+			"Keys that can't be extracted from the storage backend (e.g. keys in an HSM) can't be exported. " +
+			"Can only be run on the local Nuts node, from the directory where nuts.yaml resides.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instance, err := LoadCryptoModule(cmd)
+			if err != nil {
+				return err
+			}
+			passphrase, err := readPassphrase(cmd, true)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Exporting keys...")
+			var keys []storage.ArchivedKey
+			for _, kid := range instance.Storage.ListPrivateKeys() {
+				privateKey, err := instance.Storage.GetPrivateKey(kid)
+				if err != nil {
+					return fmt.Errorf("unable to retrieve private key (kid=%s): %w", kid, err)
+				}
+				metadata, err := instance.Storage.GetKeyMetadata(kid)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return fmt.Errorf("unable to retrieve key metadata (kid=%s): %w", kid, err)
+				}
+				keys = append(keys, storage.ArchivedKey{KID: kid, PrivateKey: privateKey, Metadata: metadata})
+				cmd.Println("  Exported:", kid)
+			}
+
+			buf := new(bytes.Buffer)
+			if err = storage.WriteKeyArchive(buf, passphrase, keys); err != nil {
+				return err
+			}
+			if err = os.WriteFile(args[0], buf.Bytes(), 0600); err != nil {
+				return fmt.Errorf("unable to write key archive: %w", err)
+			}
+			cmd.Printf("Done! Exported %d key(s) to %s\n", len(keys), args[0])
+			return nil
+		},
+	}
+}
+
+func importCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Imports private keys from a passphrase-encrypted archive file into the configured storage backend.",
+		Long: "Imports private keys (and their metadata) from an archive file created by the export command into the configured storage backend. " +
+			"The passphrase is read from standard input. Keys that already exist in the storage backend are skipped. " +
+			"Can only be run on the local Nuts node, from the directory where nuts.yaml resides.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instance, err := LoadCryptoModule(cmd)
+			if err != nil {
+				return err
+			}
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to open key archive: %w", err)
+			}
+			defer file.Close()
+			passphrase, err := readPassphrase(cmd, false)
+			if err != nil {
+				return err
+			}
+			keys, err := storage.ReadKeyArchive(file, passphrase)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Importing keys...")
+			imported := 0
+			for _, key := range keys {
+				if instance.Storage.PrivateKeyExists(key.KID) {
+					cmd.Println("  Skipped (already exists):", key.KID)
+					continue
+				}
+				if err = instance.Storage.SavePrivateKey(key.KID, key.PrivateKey); err != nil {
+					return fmt.Errorf("unable to store private key (kid=%s): %w", key.KID, err)
+				}
+				if key.Metadata != nil {
+					if err = instance.Storage.SaveKeyMetadata(key.KID, key.Metadata); err != nil {
+						return fmt.Errorf("unable to store key metadata (kid=%s): %w", key.KID, err)
+					}
+				}
+				imported++
+				cmd.Println("  Imported:", key.KID)
+			}
+			cmd.Printf("Done! Imported %d key(s)\n", imported)
+			return nil
+		},
+	}
+}
+
+// readPassphrase reads the archive passphrase from the command's input. If confirm is true, it must be entered twice.
+func readPassphrase(cmd *cobra.Command, confirm bool) ([]byte, error) {
+	reader := bufio.NewReader(cmd.InOrStdin())
+	cmd.Print("Enter passphrase: ")
+	passphrase, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase is empty")
+	}
+	if confirm {
+		cmd.Print("Confirm passphrase: ")
+		confirmation, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(passphrase, confirmation) {
+			return nil, errors.New("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return nil, fmt.Errorf("unable to read passphrase: %w", err)
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
 // LoadCryptoModule creates a Crypto module instance and configures it using the given server root command.
 func LoadCryptoModule(cmd *cobra.Command) (*cryptoEngine.Crypto, error) {
 	cfg := core.NewServerConfig()
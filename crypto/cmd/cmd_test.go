@@ -23,8 +23,12 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
+	cryptoEngine "github.com/nuts-foundation/nuts-node/crypto"
+	api "github.com/nuts-foundation/nuts-node/crypto/api/v1"
 	"github.com/nuts-foundation/nuts-node/crypto/storage"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
@@ -104,8 +108,9 @@ func Test_fs2VaultCommand(t *testing.T) {
 
 	outBuf := new(bytes.Buffer)
 	cryptoCmd := ServerCmd()
-	cryptoCmd.Commands()[0].Flags().AddFlagSet(core.FlagSet())
-	cryptoCmd.Commands()[0].Flags().AddFlagSet(FlagSet())
+	fs2VaultCmd, _, _ := cryptoCmd.Find([]string{"fs2vault"})
+	fs2VaultCmd.Flags().AddFlagSet(core.FlagSet())
+	fs2VaultCmd.Flags().AddFlagSet(FlagSet())
 	cryptoCmd.SetOut(outBuf)
 	cryptoCmd.SetArgs([]string{"fs2vault", testDirectory})
 
@@ -122,3 +127,146 @@ func Test_fs2VaultCommand(t *testing.T) {
 	assert.Contains(t, output, "pk1")
 	assert.Contains(t, output, "pk2")
 }
+
+func TestClientCmds(t *testing.T) {
+	newCmdWithServer := func(t *testing.T, handler http2.Handler) (*cobra.Command, *bytes.Buffer) {
+		s := httptest.NewServer(handler)
+		assert.NoError(t, os.Setenv("NUTS_ADDRESS", s.URL), "unable to set the NUTS_ADDRESS env var")
+		t.Cleanup(func() {
+			s.Close()
+			assert.NoError(t, os.Unsetenv("NUTS_ADDRESS"))
+		})
+		cmd := ServerCmd()
+		for _, clientCmd := range ClientCmds() {
+			clientCmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			cmd.AddCommand(clientCmd)
+		}
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		return cmd, buf
+	}
+
+	t.Run("list", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			keys := []api.KeyMetadata{
+				{KID: "did:nuts:123#abc", State: cryptoEngine.RetiredKeyState, Successor: "did:nuts:123#def"},
+				{KID: "did:nuts:123#def", State: cryptoEngine.ActiveKeyState},
+			}
+			cmd, buf := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: keys})
+			cmd.SetArgs([]string{"list"})
+
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "did:nuts:123#abc\tretired\t(successor: did:nuts:123#def)\ndid:nuts:123#def\tactive\n", buf.String())
+		})
+		t.Run("error - server error", func(t *testing.T) {
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: "b00m!"})
+			cmd.SetArgs([]string{"list"})
+
+			err := cmd.Execute()
+
+			assert.ErrorContains(t, err, "unable to list keys")
+		})
+	})
+	t.Run("rotate", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			id, _ := did.ParseDIDURL("did:nuts:123#def")
+			controller, _ := did.ParseDID("did:nuts:123")
+			cmd, buf := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: did.VerificationMethod{ID: *id, Controller: *controller}})
+			cmd.SetArgs([]string{"rotate", "did:nuts:123#abc"})
+
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "did:nuts:123#def")
+		})
+		t.Run("error - server error", func(t *testing.T) {
+			cmd, _ := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusNotFound, ResponseData: "not found"})
+			cmd.SetArgs([]string{"rotate", "did:nuts:123#abc"})
+
+			err := cmd.Execute()
+
+			assert.ErrorContains(t, err, "unable to rotate key")
+		})
+	})
+}
+
+func Test_exportImportCommands(t *testing.T) {
+	newCmd := func(t *testing.T, datadir string, input string) (*cobra.Command, *bytes.Buffer) {
+		t.Setenv("NUTS_DATADIR", datadir)
+		cmd := ServerCmd()
+		for _, subCmd := range cmd.Commands() {
+			subCmd.Flags().AddFlagSet(core.FlagSet())
+			subCmd.Flags().AddFlagSet(FlagSet())
+		}
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		cmd.SetIn(strings.NewReader(input))
+		return cmd, outBuf
+	}
+	sourceDir := io.TestDirectory(t)
+	source, _ := storage.NewFileSystemBackend(sourceDir + "/crypto")
+	pk1, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pk2, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_ = source.SavePrivateKey("pk1", pk1)
+	_ = source.SaveKeyMetadata("pk1", []byte(`{"state":"retired"}`))
+	_ = source.SavePrivateKey("pk2", pk2)
+	archiveFile := io.TestDirectory(t) + "/keys.archive"
+
+	t.Run("export", func(t *testing.T) {
+		cmd, outBuf := newCmd(t, sourceDir, "secret\nsecret\n")
+		cmd.SetArgs([]string{"export", archiveFile})
+
+		err := cmd.Execute()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		output := outBuf.String()
+		assert.Contains(t, output, "Exported: pk1")
+		assert.Contains(t, output, "Exported: pk2")
+		assert.FileExists(t, archiveFile)
+	})
+	t.Run("import", func(t *testing.T) {
+		targetDir := io.TestDirectory(t)
+		target, _ := storage.NewFileSystemBackend(targetDir + "/crypto")
+		_ = target.SavePrivateKey("pk2", pk2)
+		cmd, outBuf := newCmd(t, targetDir, "secret\n")
+		cmd.SetArgs([]string{"import", archiveFile})
+
+		err := cmd.Execute()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		output := outBuf.String()
+		assert.Contains(t, output, "Imported: pk1")
+		assert.Contains(t, output, "Skipped (already exists): pk2")
+		importedKey, _ := target.GetPrivateKey("pk1")
+		assert.Equal(t, pk1, importedKey)
+		metadata, _ := target.GetKeyMetadata("pk1")
+		assert.Equal(t, []byte(`{"state":"retired"}`), metadata)
+	})
+	t.Run("import - incorrect passphrase", func(t *testing.T) {
+		cmd, _ := newCmd(t, io.TestDirectory(t), "incorrect\n")
+		cmd.SetArgs([]string{"import", archiveFile})
+
+		err := cmd.Execute()
+
+		assert.EqualError(t, err, "unable to decrypt key archive (incorrect passphrase?)")
+	})
+	t.Run("export - passphrases do not match", func(t *testing.T) {
+		cmd, _ := newCmd(t, sourceDir, "secret\nother\n")
+		cmd.SetArgs([]string{"export", io.TestDirectory(t) + "/keys.archive"})
+
+		err := cmd.Execute()
+
+		assert.EqualError(t, err, "passphrases do not match")
+	})
+}
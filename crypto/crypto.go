@@ -25,6 +25,8 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
+
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/log"
 	"github.com/nuts-foundation/nuts-node/crypto/storage"
@@ -41,8 +43,9 @@ var kidPattern = regexp.MustCompile(`^[\da-zA-Z_\- :#.]+$`)
 
 // Config holds the values for the crypto engine
 type Config struct {
-	Storage string              `koanf:"storage"`
-	Vault   storage.VaultConfig `koanf:"vault"`
+	Storage string               `koanf:"storage"`
+	Vault   storage.VaultConfig  `koanf:"vault"`
+	PKCS11  storage.PKCS11Config `koanf:"pkcs11"`
 }
 
 // DefaultCryptoConfig returns a Config with sane defaults
@@ -50,6 +53,7 @@ func DefaultCryptoConfig() Config {
 	return Config{
 		Storage: "fs",
 		Vault:   storage.DefaultVaultConfig(),
+		PKCS11:  storage.DefaultPKCS11Config(),
 	}
 }
 
@@ -90,6 +94,13 @@ func (client *Crypto) setupVaultBackend(_ core.ServerConfig) error {
 	return err
 }
 
+func (client *Crypto) setupPKCS11Backend(_ core.ServerConfig) error {
+	log.Logger().Debug("Setting up PKCS#11 backend for storage of private key material.")
+	var err error
+	client.Storage, err = storage.NewPKCS11Storage(client.config.PKCS11)
+	return err
+}
+
 // List returns the KIDs of the private keys that are present in the key store.
 func (client *Crypto) List() []string {
 	return client.Storage.ListPrivateKeys()
@@ -102,6 +113,8 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 		return client.setupFSBackend(config)
 	case "vaultkv":
 		return client.setupVaultBackend(config)
+	case "pkcs11":
+		return client.setupPKCS11Backend(config)
 	case "":
 		if config.Strictmode {
 			return errors.New("backend must be explicitly set in strict mode")
@@ -109,7 +122,7 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 		// default to file system and run this setup again
 		return client.setupFSBackend(config)
 	default:
-		return errors.New("invalid config for crypto.storage. Available options are: vaultkv, fs")
+		return errors.New("invalid config for crypto.storage. Available options are: vaultkv, pkcs11, fs")
 	}
 }
 
@@ -118,6 +131,9 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 // If a key is overwritten is handled by the storage implementation.
 // (it's considered bad practise to reuse a kid for different keys)
 func (client *Crypto) New(namingFunc KIDNamingFunc) (Key, error) {
+	if generator, ok := client.Storage.(storage.KeyGenerator); ok {
+		return client.generateInStorage(generator, namingFunc)
+	}
 	keyPair, kid, err := generateKeyPairAndKID(namingFunc)
 	if err != nil {
 		return nil, err
@@ -128,12 +144,40 @@ func (client *Crypto) New(namingFunc KIDNamingFunc) (Key, error) {
 	if err = client.Storage.SavePrivateKey(kid, keyPair); err != nil {
 		return nil, fmt.Errorf("could not create new keypair: could not save private key: %w", err)
 	}
+	if err = client.saveMetadata(newKeyMetadata(kid)); err != nil {
+		return nil, fmt.Errorf("could not create new keypair: %w", err)
+	}
 	return keySelector{
 		privateKey: keyPair,
 		kid:        kid,
 	}, nil
 }
 
+// generateInStorage lets the storage backend generate the key pair, for backends that don't allow private keys
+// to be generated outside of it (e.g. an HSM).
+func (client *Crypto) generateInStorage(generator storage.KeyGenerator, namingFunc KIDNamingFunc) (Key, error) {
+	signer, kid, err := generator.GeneratePrivateKey(func(key crypto.PublicKey) (string, error) {
+		kid, err := namingFunc(key)
+		if err != nil {
+			return "", err
+		}
+		return kid, validateKID(kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create new keypair: %w", err)
+	}
+	if err = client.saveMetadata(newKeyMetadata(kid)); err != nil {
+		return nil, fmt.Errorf("could not create new keypair: %w", err)
+	}
+	log.Logger().
+		WithField(core.LogFieldKeyID, kid).
+		Info("Generated new key pair in storage backend")
+	return keySelector{
+		privateKey: signer,
+		kid:        kid,
+	}, nil
+}
+
 func generateKeyPairAndKID(namingFunc KIDNamingFunc) (*ecdsa.PrivateKey, string, error) {
 	keyPair, err := generateECKeyPair()
 	if err != nil {
@@ -162,6 +206,9 @@ func (client *Crypto) Exists(kid string) bool {
 	return client.Storage.PrivateKeyExists(kid)
 }
 
+// Resolve returns the Key for the given KID. The key can also be resolved when it has been retired (e.g. to get its public key),
+// but its signer then returns ErrPrivateKeyRetired. The lifecycle state is checked on every signature,
+// so a key that is retired after it has been resolved can't be used for signing either.
 func (client *Crypto) Resolve(kid string) (Key, error) {
 	if err := validateKID(kid); err != nil {
 		return nil, err
@@ -174,11 +221,25 @@ func (client *Crypto) Resolve(kid string) (Key, error) {
 		return nil, err
 	}
 	return keySelector{
-		privateKey: keypair,
+		privateKey: lifecycleSigner{Signer: keypair, kid: kid, client: client},
 		kid:        kid,
 	}, nil
 }
 
+// lifecycleSigner wraps the signer of a key to refuse signing when the key isn't active (anymore).
+type lifecycleSigner struct {
+	crypto.Signer
+	kid    string
+	client *Crypto
+}
+
+func (s lifecycleSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := s.client.ensureSigningAllowed(s.kid); err != nil {
+		return nil, err
+	}
+	return s.Signer.Sign(rand, digest, opts)
+}
+
 type keySelector struct {
 	privateKey crypto.Signer
 	kid        string
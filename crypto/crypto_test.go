@@ -93,6 +93,40 @@ func TestCrypto_New(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "could not create new keypair: could not save private key: foo", err.Error())
 	})
+
+	t.Run("key generated by storage backend", func(t *testing.T) {
+		client := &Crypto{Storage: &generatingStorage{Storage: NewMemoryStorage()}}
+
+		t.Run("ok", func(t *testing.T) {
+			key, err := client.New(StringNamingFunc("kid"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, "kid", key.KID())
+			assert.IsType(t, opaqueKey{}, key.Signer())
+			assert.True(t, client.Exists("kid"))
+		})
+		t.Run("error - invalid KID", func(t *testing.T) {
+			key, err := client.New(StringNamingFunc("../certificate"))
+
+			assert.ErrorContains(t, err, "invalid key ID")
+			assert.Nil(t, key)
+		})
+	})
+}
+
+// generatingStorage is a storage.KeyGenerator that generates keys which don't expose their private key material.
+type generatingStorage struct {
+	storage.Storage
+}
+
+func (g *generatingStorage) GeneratePrivateKey(namingFunc func(key crypto.PublicKey) (string, error)) (crypto.Signer, string, error) {
+	key, _ := generateECKeyPair()
+	kid, err := namingFunc(key.Public())
+	if err != nil {
+		return nil, "", err
+	}
+	signer := opaqueKey{key: key}
+	return signer, kid, g.Storage.SavePrivateKey(kid, signer)
 }
 
 func TestCrypto_Resolve(t *testing.T) {
@@ -107,7 +141,29 @@ func TestCrypto_Resolve(t *testing.T) {
 			return
 		}
 
-		assert.Equal(t, key, resolvedKey)
+		assert.Equal(t, key.KID(), resolvedKey.KID())
+		assert.Equal(t, key.Public(), resolvedKey.Public())
+		_, err = SignJWTWithSigner(resolvedKey.Signer(), map[string]interface{}{"iss": "nuts"}, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("retired key can't sign", func(t *testing.T) {
+		client := createCrypto(t)
+		_, _ = client.New(StringNamingFunc("retired"))
+		resolvedKey, _ := client.Resolve("retired")
+		// retired after it has been resolved
+		_ = client.Retire("retired", "")
+
+		_, err := SignJWTWithSigner(resolvedKey.Signer(), map[string]interface{}{"iss": "nuts"}, nil)
+		assert.ErrorIs(t, err, ErrPrivateKeyRetired)
+
+		resolvedKey, err = client.Resolve("retired")
+		if !assert.NoError(t, err, "retired key should still resolve") {
+			return
+		}
+		assert.NotNil(t, resolvedKey.Public())
+		_, err = SignJWS([]byte("payload"), nil, resolvedKey.Signer())
+		assert.ErrorIs(t, err, ErrPrivateKeyRetired)
 	})
 
 	t.Run("error - invalid kid", func(t *testing.T) {
@@ -153,7 +209,7 @@ func TestCrypto_Configure(t *testing.T) {
 		client := createCrypto(t)
 		client.config.Storage = "unknown"
 		err := client.Configure(cfg)
-		assert.EqualError(t, err, "invalid config for crypto.storage. Available options are: vaultkv, fs", "expected error")
+		assert.EqualError(t, err, "invalid config for crypto.storage. Available options are: vaultkv, pkcs11, fs", "expected error")
 	})
 }
 
@@ -39,6 +39,31 @@ func TestCrypto_Decrypt(t *testing.T) {
 
 		assert.Equal(t, "hello!", string(plainText))
 	})
+	t.Run("ok - key is retired", func(t *testing.T) {
+		client := createCrypto(t)
+		kid := "kid"
+		key, _ := client.New(StringNamingFunc(kid))
+		_ = client.Retire(kid, "")
+		cipherText, _ := EciesEncrypt(key.Public().(*ecdsa.PublicKey), []byte("hello!"))
+
+		plainText, err := client.Decrypt(kid, cipherText)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello!", string(plainText))
+	})
+	t.Run("ok - key generated by storage backend", func(t *testing.T) {
+		client := &Crypto{Storage: &generatingStorage{Storage: NewMemoryStorage()}}
+		key, _ := client.New(StringNamingFunc("kid"))
+		pubKey := key.Public().(*ecdsa.PublicKey)
+
+		cipherText, err := EciesEncrypt(pubKey, []byte("hello!"))
+		assert.NoError(t, err)
+
+		plainText, err := client.Decrypt("kid", cipherText)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "hello!", string(plainText))
+	})
 	t.Run("error - invalid kid", func(t *testing.T) {
 		client := createCrypto(t)
 
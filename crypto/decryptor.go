@@ -36,6 +36,8 @@ func (client *Crypto) Decrypt(kid string, cipherText []byte) ([]byte, error) {
 	switch privateKey := key.(type) {
 	case *ecdsa.PrivateKey:
 		return EciesDecrypt(privateKey, cipherText)
+	case keyAgreer:
+		return eciesDecryptUsingKeyAgreement(privateKey, cipherText)
 	default:
 		return nil, errors.New("unsupported decryption key")
 	}
@@ -19,12 +19,25 @@
 package crypto
 
 import (
+	"crypto"
+	"crypto/cipher"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
 	"crypto/rand"
+	"encoding/binary"
+	"errors"
 
 	ecies "github.com/nuts-foundation/crypto-ecies"
 )
 
+// keyAgreer is implemented by private keys of which the key material isn't accessible (e.g. keys that reside in an HSM),
+// but which can perform ECDH key agreement. ECDH returns the X coordinate of the shared point.
+type keyAgreer interface {
+	crypto.Signer
+	ECDH(publicKey *ecdsa.PublicKey) ([]byte, error)
+}
+
 // EciesDecrypt decrypts the `cipherText` using the Elliptic Curve Integrated Encryption Scheme
 func EciesDecrypt(privateKey *ecdsa.PrivateKey, cipherText []byte) ([]byte, error) {
 	key := ecies.ImportECDSA(privateKey)
@@ -38,3 +51,68 @@ func EciesEncrypt(publicKey *ecdsa.PublicKey, plainText []byte) ([]byte, error)
 
 	return ecies.Encrypt(rand.Reader, key, plainText, nil, nil)
 }
+
+// eciesDecryptUsingKeyAgreement decrypts the `cipherText` like EciesDecrypt does, but lets the key perform the ECDH key agreement
+// instead of requiring access to the private key. It mirrors the decryption of the ECIES library (without shared information).
+func eciesDecryptUsingKeyAgreement(key keyAgreer, cipherText []byte) ([]byte, error) {
+	publicKey, ok := key.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("unsupported decryption key")
+	}
+	params := ecies.ParamsFromCurve(publicKey.Curve)
+	if params == nil {
+		return nil, ecies.ErrUnsupportedECIESParameters
+	}
+	hashLen := params.Hash().Size()
+	ephemeralKeyLen := 2*((publicKey.Curve.Params().BitSize+7)/8) + 1
+	if len(cipherText) < ephemeralKeyLen+params.BlockSize+hashLen {
+		return nil, ecies.ErrInvalidMessage
+	}
+	x, y := elliptic.Unmarshal(publicKey.Curve, cipherText[:ephemeralKeyLen])
+	if x == nil {
+		return nil, ecies.ErrInvalidPublicKey
+	}
+	sharedX, err := key.ECDH(&ecdsa.PublicKey{Curve: publicKey.Curve, X: x, Y: y})
+	if err != nil {
+		return nil, err
+	}
+	// The ECIES library left-pads the shared secret to the length of the derived key material
+	if len(sharedX) > 2*params.KeyLen {
+		return nil, ecies.ErrSharedKeyTooBig
+	}
+	z := make([]byte, 2*params.KeyLen)
+	copy(z[len(z)-len(sharedX):], sharedX)
+
+	encryptionKey, macKey := eciesDeriveKeys(params, z)
+	message := cipherText[ephemeralKeyLen : len(cipherText)-hashLen]
+	mac := hmac.New(params.Hash, macKey)
+	mac.Write(message)
+	if !hmac.Equal(mac.Sum(nil), cipherText[len(cipherText)-hashLen:]) {
+		return nil, ecies.ErrInvalidMessage
+	}
+	block, err := params.Cipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	plainText := make([]byte, len(message)-params.BlockSize)
+	cipher.NewCTR(block, message[:params.BlockSize]).XORKeyStream(plainText, message[params.BlockSize:])
+	return plainText, nil
+}
+
+// eciesDeriveKeys derives the encryption and MAC keys from the shared secret using the NIST SP 800-56 Concatenation KDF.
+func eciesDeriveKeys(params *ecies.ECIESParams, z []byte) ([]byte, []byte) {
+	hash := params.Hash()
+	keyMaterial := make([]byte, 0, 2*params.KeyLen+hash.Size())
+	counter := make([]byte, 4)
+	for i := uint32(1); len(keyMaterial) < 2*params.KeyLen; i++ {
+		binary.BigEndian.PutUint32(counter, i)
+		hash.Reset()
+		hash.Write(counter)
+		hash.Write(z)
+		keyMaterial = hash.Sum(keyMaterial)
+	}
+	encryptionKey := keyMaterial[:params.KeyLen]
+	hash.Reset()
+	hash.Write(keyMaterial[params.KeyLen : 2*params.KeyLen])
+	return encryptionKey, hash.Sum(nil)
+}
@@ -19,8 +19,12 @@
 package crypto
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"io"
 	"testing"
 
+	ecies "github.com/nuts-foundation/crypto-ecies"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,3 +53,48 @@ func TestEciesDecrypt(t *testing.T) {
 
 	assert.Equal(t, []byte("hello world"), plainText)
 }
+
+func TestEciesDecryptUsingKeyAgreement(t *testing.T) {
+	key, _ := generateECKeyPair()
+	cipherText, _ := EciesEncrypt(&key.PublicKey, []byte("hello world"))
+
+	t.Run("ok", func(t *testing.T) {
+		plainText, err := eciesDecryptUsingKeyAgreement(opaqueKey{key}, cipherText)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("hello world"), plainText)
+	})
+	t.Run("error - tampered cipher text", func(t *testing.T) {
+		tampered := append([]byte{}, cipherText...)
+		tampered[len(tampered)-1] ^= 0xFF
+
+		plainText, err := eciesDecryptUsingKeyAgreement(opaqueKey{key}, tampered)
+
+		assert.ErrorIs(t, err, ecies.ErrInvalidMessage)
+		assert.Nil(t, plainText)
+	})
+	t.Run("error - cipher text too short", func(t *testing.T) {
+		plainText, err := eciesDecryptUsingKeyAgreement(opaqueKey{key}, cipherText[:20])
+
+		assert.ErrorIs(t, err, ecies.ErrInvalidMessage)
+		assert.Nil(t, plainText)
+	})
+}
+
+// opaqueKey mimics a private key that doesn't expose its key material (e.g. an HSM key), but supports signing and ECDH.
+type opaqueKey struct {
+	key *ecdsa.PrivateKey
+}
+
+func (o opaqueKey) Public() crypto.PublicKey {
+	return o.key.Public()
+}
+
+func (o opaqueKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return o.key.Sign(rand, digest, opts)
+}
+
+func (o opaqueKey) ECDH(publicKey *ecdsa.PublicKey) ([]byte, error) {
+	x, _ := publicKey.Curve.ScalarMult(publicKey.X, publicKey.Y, o.key.D.Bytes())
+	return x.Bytes(), nil
+}
@@ -21,11 +21,18 @@ package crypto
 import (
 	"crypto"
 	"errors"
+	"time"
 )
 
 // ErrPrivateKeyNotFound is returned when the private key doesn't exist
 var ErrPrivateKeyNotFound = errors.New("private key not found")
 
+// ErrPrivateKeyRetired is returned when a retired (or destroyed) private key is used for signing
+var ErrPrivateKeyRetired = errors.New("private key is retired")
+
+// ErrPrivateKeyNotActive is returned when a lifecycle operation requires the key to be in another state
+var ErrPrivateKeyNotActive = errors.New("private key is not in the required state")
+
 // KIDNamingFunc is a function passed to New() which generates the kid for the pub/priv key
 type KIDNamingFunc func(key crypto.PublicKey) (string, error)
 
@@ -42,6 +49,7 @@ type KeyResolver interface {
 	// If an error occurs, false is also returned
 	Exists(kid string) bool
 	// Resolve returns a Key for the given KID. ErrPrivateKeyNotFound is returned for an unknown KID.
+	// If the key is retired, its signer returns ErrPrivateKeyRetired.
 	Resolve(kid string) (Key, error)
 	// List returns the KIDs of the private keys that are present in the KeyStore.
 	List() []string
@@ -53,6 +61,49 @@ type KeyStore interface {
 	KeyCreator
 	KeyResolver
 	JWTSigner
+	KeyLifecycle
+}
+
+// KeyState indicates the state of a key in its lifecycle.
+type KeyState string
+
+const (
+	// ActiveKeyState indicates the key can be used for all operations.
+	ActiveKeyState KeyState = "active"
+	// RetiredKeyState indicates the key has been superseded: it can't be used for signing anymore, but can still be used
+	// to decrypt data encrypted for it.
+	RetiredKeyState KeyState = "retired"
+	// DestroyedKeyState indicates the private key has been removed from storage.
+	DestroyedKeyState KeyState = "destroyed"
+)
+
+// KeyMetadata holds the lifecycle information of a key.
+type KeyMetadata struct {
+	// KID contains the ID of the key.
+	KID string `json:"kid"`
+	// State contains the current state of the key.
+	State KeyState `json:"state"`
+	// CreatedAt contains the time the key was created. It's empty for keys created before key lifecycle was introduced.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// RetiredAt contains the time the key was retired.
+	RetiredAt *time.Time `json:"retiredAt,omitempty"`
+	// DestroyedAt contains the time the private key was destroyed.
+	DestroyedAt *time.Time `json:"destroyedAt,omitempty"`
+	// Successor contains the ID of the key that replaced this key when it was retired, if any.
+	Successor string `json:"successor,omitempty"`
+}
+
+// KeyLifecycle is the interface for managing the lifecycle (active, retired, destroyed) of keys.
+type KeyLifecycle interface {
+	// Metadata returns the lifecycle metadata of the key. Keys without metadata are considered active.
+	// ErrPrivateKeyNotFound is returned for an unknown KID.
+	Metadata(kid string) (*KeyMetadata, error)
+	// Retire marks the active key as retired, optionally recording the KID of the key that replaces it.
+	// ErrPrivateKeyNotActive is returned if the key isn't active.
+	Retire(kid string, successor string) error
+	// Destroy removes the private key of a retired key from storage. Its metadata is retained.
+	// ErrPrivateKeyNotActive is returned if the key isn't retired.
+	Destroy(kid string) error
 }
 
 // Decrypter is the interface to support decryption
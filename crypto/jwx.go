@@ -53,6 +53,9 @@ func (client *Crypto) SignJWT(claims map[string]interface{}, kid string) (token
 	if err = validateKID(kid); err != nil {
 		return "", err
 	}
+	if err = client.ensureSigningAllowed(kid); err != nil {
+		return "", err
+	}
 	privateKey, err := client.Storage.GetPrivateKey(kid)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -60,16 +63,12 @@ func (client *Crypto) SignJWT(claims map[string]interface{}, kid string) (token
 		}
 		return "", err
 	}
-	key, err := jwkKey(privateKey)
+	alg, err := signingAlgorithm(privateKey)
 	if err != nil {
 		return "", err
 	}
 
-	if err = key.Set(jwk.KeyIDKey, kid); err != nil {
-		return "", err
-	}
-
-	token, err = SignJWT(key, claims, nil)
+	token, err = signJWT(privateKey, alg, claims, map[string]interface{}{jws.KeyIDKey: kid})
 	return
 }
 
@@ -93,8 +92,47 @@ func jwkKey(signer crypto.Signer) (key jwk.Key, err error) {
 	return
 }
 
+// signingAlgorithm determines the JWA signature algorithm for the given signer. If the private key material isn't
+// accessible (e.g. when it resides in an HSM), the algorithm is derived from the public key.
+func signingAlgorithm(signer crypto.Signer) (jwa.SignatureAlgorithm, error) {
+	key, err := jwkKey(signer)
+	if err == nil {
+		return jwa.SignatureAlgorithm(key.Algorithm()), nil
+	}
+	if signer == nil {
+		return "", err
+	}
+	switch publicKey := signer.Public().(type) {
+	case *rsa.PublicKey:
+		return jwa.PS256, nil
+	case *ecdsa.PublicKey:
+		return ecAlgUsingPublicKey(*publicKey)
+	default:
+		return "", err
+	}
+}
+
 // SignJWT signs claims with the signer and returns the compacted token. The headers param can be used to add additional headers
 func SignJWT(key jwk.Key, claims map[string]interface{}, headers map[string]interface{}) (token string, err error) {
+	var alg jwa.SignatureAlgorithm
+	if key != nil {
+		alg = jwa.SignatureAlgorithm(key.Algorithm())
+	}
+	return signJWT(key, alg, claims, headers)
+}
+
+// SignJWTWithSigner signs claims with the given signer (which might not expose its private key material, e.g. when it resides in an HSM)
+// and returns the compacted token. The headers param can be used to add additional headers.
+func SignJWTWithSigner(signer crypto.Signer, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+	alg, err := signingAlgorithm(signer)
+	if err != nil {
+		return "", err
+	}
+	return signJWT(signer, alg, claims, headers)
+}
+
+// signJWT signs claims with the given key, which is either a jwk.Key or a crypto.Signer.
+func signJWT(key interface{}, alg jwa.SignatureAlgorithm, claims map[string]interface{}, headers map[string]interface{}) (token string, err error) {
 	var sig []byte
 	t := jwt.New()
 
@@ -105,7 +143,7 @@ func SignJWT(key jwk.Key, claims map[string]interface{}, headers map[string]inte
 	}
 	hdr := convertHeaders(headers)
 
-	sig, err = jwt.Sign(t, jwa.SignatureAlgorithm(key.Algorithm()), key, jwt.WithHeaders(hdr))
+	sig, err = jwt.Sign(t, alg, key, jwt.WithHeaders(hdr))
 	token = string(sig)
 
 	return
@@ -172,7 +210,7 @@ func signJWS(payload []byte, protectedHeaders map[string]interface{}, privateKey
 			return "", fmt.Errorf("unable to set header %s: %w", key, err)
 		}
 	}
-	privateKeyAsJWK, err := jwkKey(privateKey)
+	algo, err := signingAlgorithm(privateKey)
 	if err != nil {
 		return "", err
 	}
@@ -187,8 +225,6 @@ func signJWS(payload []byte, protectedHeaders map[string]interface{}, privateKey
 			return "", errors.New("refusing to sign JWS with private key in JWK header")
 		}
 	}
-	algo := jwa.SignatureAlgorithm(privateKeyAsJWK.Algorithm())
-
 	var (
 		data []byte
 	)
@@ -115,6 +115,25 @@ func TestSignJWT(t *testing.T) {
 	})
 }
 
+func TestSignJWTWithSigner(t *testing.T) {
+	ecKey := test.GenerateECKey()
+
+	tokenString, err := SignJWTWithSigner(ecKey, map[string]interface{}{"iss": "nuts"}, map[string]interface{}{"typ": "test+jwt"})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	token, err := ParseJWT(tokenString, func(kid string) (crypto.PublicKey, error) {
+		return ecKey.Public(), nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "nuts", token.Issuer())
+	message, _ := jws.ParseString(tokenString)
+	assert.Equal(t, "test+jwt", message.Signatures()[0].ProtectedHeaders().Type())
+}
+
 func TestParseJWT(t *testing.T) {
 	t.Run("unsupported algorithm", func(t *testing.T) {
 		rsaKey := test.GenerateRSAKey()
@@ -168,6 +187,38 @@ func TestCrypto_SignJWT(t *testing.T) {
 		assert.Equal(t, "nuts", token.Issuer())
 	})
 
+	t.Run("error - key is retired", func(t *testing.T) {
+		client := createCrypto(t)
+		kid := "retired"
+		_, _ = client.New(StringNamingFunc(kid))
+		_ = client.Retire(kid, "")
+
+		tokenString, err := client.SignJWT(map[string]interface{}{"iss": "nuts"}, kid)
+
+		assert.ErrorIs(t, err, ErrPrivateKeyRetired)
+		assert.Empty(t, tokenString)
+	})
+
+	t.Run("creates valid JWT with key generated by storage backend", func(t *testing.T) {
+		client := &Crypto{Storage: &generatingStorage{Storage: NewMemoryStorage()}}
+		key, _ := client.New(StringNamingFunc(kid))
+
+		tokenString, err := client.SignJWT(map[string]interface{}{"iss": "nuts"}, kid)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		token, err := ParseJWT(tokenString, func(kid string) (crypto.PublicKey, error) {
+			return key.Public(), nil
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		actualKID, _, _ := JWTKidAlg(tokenString)
+		assert.Equal(t, kid, actualKID)
+		assert.Equal(t, "nuts", token.Issuer())
+	})
+
 	t.Run("returns error for not found", func(t *testing.T) {
 		_, err := client.SignJWT(map[string]interface{}{"iss": "nuts"}, "unknown")
 
@@ -0,0 +1,138 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/crypto/storage"
+)
+
+// Metadata returns the lifecycle metadata of the key.
+func (client *Crypto) Metadata(kid string) (*KeyMetadata, error) {
+	if err := validateKID(kid); err != nil {
+		return nil, err
+	}
+	metadata, err := client.readMetadata(kid)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		// Keys created before key lifecycle was introduced don't have metadata
+		if !client.Storage.PrivateKeyExists(kid) {
+			return nil, ErrPrivateKeyNotFound
+		}
+		metadata = &KeyMetadata{KID: kid, State: ActiveKeyState}
+	}
+	return metadata, nil
+}
+
+// Retire marks the key as retired, after which it can't be used for signing anymore.
+func (client *Crypto) Retire(kid string, successor string) error {
+	metadata, err := client.Metadata(kid)
+	if err != nil {
+		return err
+	}
+	if metadata.State != ActiveKeyState {
+		return fmt.Errorf("unable to retire key (kid=%s, state=%s): %w", kid, metadata.State, ErrPrivateKeyNotActive)
+	}
+	now := time.Now()
+	metadata.State = RetiredKeyState
+	metadata.RetiredAt = &now
+	metadata.Successor = successor
+	if err = client.saveMetadata(*metadata); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldKeyID, kid).
+		Infof("Retired key (successor=%s)", successor)
+	return nil
+}
+
+// Destroy removes the private key of a retired key from storage.
+func (client *Crypto) Destroy(kid string) error {
+	metadata, err := client.Metadata(kid)
+	if err != nil {
+		return err
+	}
+	if metadata.State != RetiredKeyState {
+		return fmt.Errorf("unable to destroy key (kid=%s, state=%s): %w", kid, metadata.State, ErrPrivateKeyNotActive)
+	}
+	err = client.Storage.DeletePrivateKey(kid)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("unable to delete private key: %w", err)
+	}
+	now := time.Now()
+	metadata.State = DestroyedKeyState
+	metadata.DestroyedAt = &now
+	if err = client.saveMetadata(*metadata); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldKeyID, kid).
+		Info("Destroyed private key")
+	return nil
+}
+
+// readMetadata reads the metadata of the key from storage. It returns nil if there is none.
+func (client *Crypto) readMetadata(kid string) (*KeyMetadata, error) {
+	data, err := client.Storage.GetKeyMetadata(kid)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key metadata: %w", err)
+	}
+	result := KeyMetadata{}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid key metadata (kid=%s): %w", kid, err)
+	}
+	result.KID = kid
+	return &result, nil
+}
+
+func (client *Crypto) saveMetadata(metadata KeyMetadata) error {
+	data, _ := json.Marshal(metadata)
+	if err := client.Storage.SaveKeyMetadata(metadata.KID, data); err != nil {
+		return fmt.Errorf("unable to save key metadata: %w", err)
+	}
+	return nil
+}
+
+// ensureSigningAllowed returns ErrPrivateKeyRetired if the key has been retired or destroyed.
+func (client *Crypto) ensureSigningAllowed(kid string) error {
+	metadata, err := client.readMetadata(kid)
+	if err != nil {
+		return err
+	}
+	if metadata != nil && metadata.State != ActiveKeyState {
+		return ErrPrivateKeyRetired
+	}
+	return nil
+}
+
+func newKeyMetadata(kid string) KeyMetadata {
+	now := time.Now()
+	return KeyMetadata{KID: kid, State: ActiveKeyState, CreatedAt: &now}
+}
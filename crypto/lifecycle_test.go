@@ -0,0 +1,169 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/nuts-node/crypto/storage"
+	"github.com/nuts-foundation/nuts-node/crypto/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrypto_Metadata(t *testing.T) {
+	t.Run("ok - key created with metadata", func(t *testing.T) {
+		client := createCrypto(t)
+		_, _ = client.New(StringNamingFunc("kid"))
+
+		metadata, err := client.Metadata("kid")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "kid", metadata.KID)
+		assert.Equal(t, ActiveKeyState, metadata.State)
+		assert.NotNil(t, metadata.CreatedAt)
+		assert.Nil(t, metadata.RetiredAt)
+	})
+	t.Run("ok - key without metadata is active", func(t *testing.T) {
+		client := createCrypto(t)
+		_ = client.Storage.SavePrivateKey("kid", test.GenerateECKey())
+
+		metadata, err := client.Metadata("kid")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, ActiveKeyState, metadata.State)
+		assert.Nil(t, metadata.CreatedAt)
+	})
+	t.Run("error - unknown key", func(t *testing.T) {
+		client := createCrypto(t)
+
+		metadata, err := client.Metadata("unknown")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotFound)
+		assert.Nil(t, metadata)
+	})
+	t.Run("error - invalid KID", func(t *testing.T) {
+		client := createCrypto(t)
+
+		_, err := client.Metadata("../certificate")
+
+		assert.ErrorContains(t, err, "invalid key ID")
+	})
+	t.Run("error - invalid metadata", func(t *testing.T) {
+		client := createCrypto(t)
+		_ = client.Storage.SaveKeyMetadata("kid", []byte("not JSON"))
+
+		_, err := client.Metadata("kid")
+
+		assert.ErrorContains(t, err, "invalid key metadata (kid=kid)")
+	})
+	t.Run("error - storage error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		storageMock := storage.NewMockStorage(ctrl)
+		storageMock.EXPECT().GetKeyMetadata("kid").Return(nil, errors.New("failed"))
+		client := &Crypto{Storage: storageMock}
+
+		_, err := client.Metadata("kid")
+
+		assert.EqualError(t, err, "unable to read key metadata: failed")
+	})
+}
+
+func TestCrypto_Retire(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		client := createCrypto(t)
+		_, _ = client.New(StringNamingFunc("kid"))
+
+		err := client.Retire("kid", "successor")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		metadata, _ := client.Metadata("kid")
+		assert.Equal(t, RetiredKeyState, metadata.State)
+		assert.NotNil(t, metadata.CreatedAt)
+		assert.NotNil(t, metadata.RetiredAt)
+		assert.Equal(t, "successor", metadata.Successor)
+		assert.True(t, client.Exists("kid"), "private key should be retained")
+	})
+	t.Run("error - already retired", func(t *testing.T) {
+		client := createCrypto(t)
+		_, _ = client.New(StringNamingFunc("kid"))
+		_ = client.Retire("kid", "")
+
+		err := client.Retire("kid", "")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotActive)
+	})
+	t.Run("error - unknown key", func(t *testing.T) {
+		client := createCrypto(t)
+
+		err := client.Retire("unknown", "")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotFound)
+	})
+}
+
+func TestCrypto_Destroy(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		client := createCrypto(t)
+		_, _ = client.New(StringNamingFunc("kid"))
+		_ = client.Retire("kid", "")
+
+		err := client.Destroy("kid")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.False(t, client.Exists("kid"))
+		metadata, err := client.Metadata("kid")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, DestroyedKeyState, metadata.State)
+		assert.NotNil(t, metadata.DestroyedAt)
+	})
+	t.Run("error - key is active", func(t *testing.T) {
+		client := createCrypto(t)
+		_, _ = client.New(StringNamingFunc("kid"))
+
+		err := client.Destroy("kid")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotActive)
+		assert.True(t, client.Exists("kid"))
+	})
+	t.Run("error - storage error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		storageMock := storage.NewMockStorage(ctrl)
+		storageMock.EXPECT().GetKeyMetadata("kid").Return([]byte(`{"state":"retired"}`), nil)
+		storageMock.EXPECT().DeletePrivateKey("kid").Return(errors.New("failed"))
+		client := &Crypto{Storage: storageMock}
+
+		err := client.Destroy("kid")
+
+		assert.EqualError(t, err, "unable to delete private key: failed")
+	})
+}
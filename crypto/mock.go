@@ -153,6 +153,20 @@ func (mr *MockKeyStoreMockRecorder) Decrypt(kid, ciphertext interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockKeyStore)(nil).Decrypt), kid, ciphertext)
 }
 
+// Destroy mocks base method.
+func (m *MockKeyStore) Destroy(kid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", kid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockKeyStoreMockRecorder) Destroy(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockKeyStore)(nil).Destroy), kid)
+}
+
 // Exists mocks base method.
 func (m *MockKeyStore) Exists(kid string) bool {
 	m.ctrl.T.Helper()
@@ -181,6 +195,21 @@ func (mr *MockKeyStoreMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyStore)(nil).List))
 }
 
+// Metadata mocks base method.
+func (m *MockKeyStore) Metadata(kid string) (*KeyMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata", kid)
+	ret0, _ := ret[0].(*KeyMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockKeyStoreMockRecorder) Metadata(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockKeyStore)(nil).Metadata), kid)
+}
+
 // New mocks base method.
 func (m *MockKeyStore) New(namingFunc KIDNamingFunc) (Key, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +240,20 @@ func (mr *MockKeyStoreMockRecorder) Resolve(kid interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockKeyStore)(nil).Resolve), kid)
 }
 
+// Retire mocks base method.
+func (m *MockKeyStore) Retire(kid, successor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retire", kid, successor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retire indicates an expected call of Retire.
+func (mr *MockKeyStoreMockRecorder) Retire(kid, successor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retire", reflect.TypeOf((*MockKeyStore)(nil).Retire), kid, successor)
+}
+
 // SignJWT mocks base method.
 func (m *MockKeyStore) SignJWT(claims map[string]interface{}, kid string) (string, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +269,72 @@ func (mr *MockKeyStoreMockRecorder) SignJWT(claims, kid interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignJWT", reflect.TypeOf((*MockKeyStore)(nil).SignJWT), claims, kid)
 }
 
+// MockKeyLifecycle is a mock of KeyLifecycle interface.
+type MockKeyLifecycle struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyLifecycleMockRecorder
+}
+
+// MockKeyLifecycleMockRecorder is the mock recorder for MockKeyLifecycle.
+type MockKeyLifecycleMockRecorder struct {
+	mock *MockKeyLifecycle
+}
+
+// NewMockKeyLifecycle creates a new mock instance.
+func NewMockKeyLifecycle(ctrl *gomock.Controller) *MockKeyLifecycle {
+	mock := &MockKeyLifecycle{ctrl: ctrl}
+	mock.recorder = &MockKeyLifecycleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyLifecycle) EXPECT() *MockKeyLifecycleMockRecorder {
+	return m.recorder
+}
+
+// Destroy mocks base method.
+func (m *MockKeyLifecycle) Destroy(kid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", kid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockKeyLifecycleMockRecorder) Destroy(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockKeyLifecycle)(nil).Destroy), kid)
+}
+
+// Metadata mocks base method.
+func (m *MockKeyLifecycle) Metadata(kid string) (*KeyMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata", kid)
+	ret0, _ := ret[0].(*KeyMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockKeyLifecycleMockRecorder) Metadata(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockKeyLifecycle)(nil).Metadata), kid)
+}
+
+// Retire mocks base method.
+func (m *MockKeyLifecycle) Retire(kid, successor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retire", kid, successor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retire indicates an expected call of Retire.
+func (mr *MockKeyLifecycleMockRecorder) Retire(kid, successor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retire", reflect.TypeOf((*MockKeyLifecycle)(nil).Retire), kid, successor)
+}
+
 // MockDecrypter is a mock of Decrypter interface.
 type MockDecrypter struct {
 	ctrl     *gomock.Controller
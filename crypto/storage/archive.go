@@ -0,0 +1,192 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nuts-foundation/nuts-node/crypto/util"
+	"golang.org/x/crypto/scrypt"
+)
+
+const keyArchiveVersion = 1
+const keyArchiveKDF = "scrypt"
+
+// scrypt parameters used for new archives, as recommended for interactive logins (2017).
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	// scryptMaxN, scryptMaxR, scryptMaxP and scryptMaxCost limit the cost of reading an archive with tampered parameters.
+	scryptMaxN = 1 << 20
+	scryptMaxR = 32
+	scryptMaxP = 16
+	// scryptMaxCost limits the combined cost (128*N*R*P), which is the amount of memory (in bytes) scrypt touches.
+	// It allows for N=2^20 with the default R and P.
+	scryptMaxCost = 128 * scryptMaxN * scryptR * scryptP
+)
+
+// ArchivedKey is a private key contained in a key archive, including its (serialized) metadata.
+type ArchivedKey struct {
+	// KID is the ID of the key.
+	KID string
+	// PrivateKey is the private key.
+	PrivateKey crypto.PrivateKey
+	// Metadata is the metadata stored alongside the private key. It's nil if the key has no metadata.
+	Metadata []byte
+}
+
+// keyArchive is the (unencrypted) JSON representation of the file. The keys are contained in the encrypted content.
+type keyArchive struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type archivedKeyEntry struct {
+	KID        string `json:"kid"`
+	PrivateKey string `json:"privateKey"`
+	Metadata   []byte `json:"metadata,omitempty"`
+}
+
+// WriteKeyArchive writes the keys to the writer as archive, encrypted (AES-256-GCM) with a key derived from the passphrase.
+func WriteKeyArchive(writer io.Writer, passphrase []byte, keys []ArchivedKey) error {
+	if len(passphrase) == 0 {
+		return errors.New("passphrase is empty")
+	}
+	entries := make([]archivedKeyEntry, 0, len(keys))
+	for _, key := range keys {
+		privateKeyPEM, err := util.PrivateKeyToPem(key.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("unable to export private key (kid=%s): %w", key.KID, err)
+		}
+		entries = append(entries, archivedKeyEntry{KID: key.KID, PrivateKey: privateKeyPEM, Metadata: key.Metadata})
+	}
+	plaintext, _ := json.Marshal(entries)
+
+	archive := keyArchive{
+		Version: keyArchiveVersion,
+		KDF:     keyArchiveKDF,
+		Salt:    make([]byte, 16),
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+	}
+	if _, err := rand.Read(archive.Salt); err != nil {
+		return err
+	}
+	aead, err := archive.cipher(passphrase)
+	if err != nil {
+		return err
+	}
+	archive.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(archive.Nonce); err != nil {
+		return err
+	}
+	archive.Ciphertext = aead.Seal(nil, archive.Nonce, plaintext, nil)
+
+	data, _ := json.MarshalIndent(archive, "", "  ")
+	_, err = writer.Write(data)
+	return err
+}
+
+// ReadKeyArchive reads the keys from an archive written by WriteKeyArchive, decrypting it using the passphrase.
+func ReadKeyArchive(reader io.Reader, passphrase []byte) ([]ArchivedKey, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	archive := keyArchive{}
+	if err = json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("invalid key archive: %w", err)
+	}
+	if archive.Version != keyArchiveVersion || archive.KDF != keyArchiveKDF {
+		return nil, fmt.Errorf("unsupported key archive (version=%d, kdf=%s)", archive.Version, archive.KDF)
+	}
+	if err = archive.validateKDFParameters(); err != nil {
+		return nil, err
+	}
+	aead, err := archive.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(archive.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid key archive: invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, archive.Nonce, archive.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt key archive (incorrect passphrase?)")
+	}
+	var entries []archivedKeyEntry
+	if err = json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("invalid key archive: %w", err)
+	}
+	result := make([]ArchivedKey, 0, len(entries))
+	for _, entry := range entries {
+		privateKey, err := util.PemToPrivateKey([]byte(entry.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key in key archive (kid=%s): %w", entry.KID, err)
+		}
+		result = append(result, ArchivedKey{KID: entry.KID, PrivateKey: privateKey, Metadata: entry.Metadata})
+	}
+	return result, nil
+}
+
+// validateKDFParameters checks the scrypt parameters before they're used to derive the key,
+// to prevent an archive with tampered parameters from consuming excessive memory or CPU time.
+func (archive keyArchive) validateKDFParameters() error {
+	if archive.N > scryptMaxN {
+		return errors.New("invalid key archive: scrypt cost parameter too high")
+	}
+	if archive.R < 1 || archive.R > scryptMaxR {
+		return fmt.Errorf("invalid key archive: scrypt block size parameter out of range (r=%d)", archive.R)
+	}
+	if archive.P < 1 || archive.P > scryptMaxP {
+		return fmt.Errorf("invalid key archive: scrypt parallelization parameter out of range (p=%d)", archive.P)
+	}
+	if cost := int64(128) * int64(archive.N) * int64(archive.R) * int64(archive.P); cost > scryptMaxCost {
+		return fmt.Errorf("invalid key archive: scrypt parameters too costly (n=%d, r=%d, p=%d)", archive.N, archive.R, archive.P)
+	}
+	return nil
+}
+
+func (archive keyArchive) cipher(passphrase []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, archive.Salt, archive.N, archive.R, archive.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key archive encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
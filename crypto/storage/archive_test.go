@@ -0,0 +1,116 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyArchive(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keys := []ArchivedKey{
+		{KID: "did:nuts:123#abc", PrivateKey: privateKey, Metadata: []byte(`{"state":"active"}`)},
+		{KID: "did:nuts:123#def", PrivateKey: privateKey},
+	}
+	passphrase := []byte("correct horse battery staple")
+
+	t.Run("ok - roundtrip", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+
+		err := WriteKeyArchive(buf, passphrase, keys)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NotContains(t, buf.String(), "PRIVATE KEY")
+		result, err := ReadKeyArchive(buf, passphrase)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, result, 2)
+		assert.Equal(t, "did:nuts:123#abc", result[0].KID)
+		assert.Equal(t, privateKey, result[0].PrivateKey)
+		assert.Equal(t, []byte(`{"state":"active"}`), result[0].Metadata)
+		assert.Nil(t, result[1].Metadata)
+	})
+	t.Run("error - incorrect passphrase", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		_ = WriteKeyArchive(buf, passphrase, keys)
+
+		result, err := ReadKeyArchive(buf, []byte("incorrect"))
+
+		assert.EqualError(t, err, "unable to decrypt key archive (incorrect passphrase?)")
+		assert.Nil(t, result)
+	})
+	t.Run("error - empty passphrase", func(t *testing.T) {
+		err := WriteKeyArchive(new(bytes.Buffer), nil, keys)
+
+		assert.EqualError(t, err, "passphrase is empty")
+	})
+	t.Run("error - key can't be exported", func(t *testing.T) {
+		err := WriteKeyArchive(new(bytes.Buffer), passphrase, []ArchivedKey{{KID: "kid", PrivateKey: "not a key"}})
+
+		assert.ErrorContains(t, err, "unable to export private key (kid=kid)")
+	})
+	t.Run("error - invalid archive", func(t *testing.T) {
+		_, err := ReadKeyArchive(bytes.NewReader([]byte("not JSON")), passphrase)
+
+		assert.ErrorContains(t, err, "invalid key archive")
+	})
+	t.Run("error - unsupported version", func(t *testing.T) {
+		data, _ := json.Marshal(keyArchive{Version: 2, KDF: keyArchiveKDF})
+
+		_, err := ReadKeyArchive(bytes.NewReader(data), passphrase)
+
+		assert.EqualError(t, err, "unsupported key archive (version=2, kdf=scrypt)")
+	})
+	t.Run("error - scrypt cost too high", func(t *testing.T) {
+		data, _ := json.Marshal(keyArchive{Version: keyArchiveVersion, KDF: keyArchiveKDF, N: 1 << 30})
+
+		_, err := ReadKeyArchive(bytes.NewReader(data), passphrase)
+
+		assert.EqualError(t, err, "invalid key archive: scrypt cost parameter too high")
+	})
+	t.Run("error - scrypt parameters out of range", func(t *testing.T) {
+		testCases := []struct {
+			n, r, p int
+			err     string
+		}{
+			{n: scryptN, r: 1 << 20, p: scryptP, err: "invalid key archive: scrypt block size parameter out of range (r=1048576)"},
+			{n: scryptN, r: 0, p: scryptP, err: "invalid key archive: scrypt block size parameter out of range (r=0)"},
+			{n: scryptN, r: scryptR, p: 1 << 20, err: "invalid key archive: scrypt parallelization parameter out of range (p=1048576)"},
+			{n: scryptN, r: scryptR, p: -1, err: "invalid key archive: scrypt parallelization parameter out of range (p=-1)"},
+			{n: scryptMaxN, r: scryptMaxR, p: scryptMaxP, err: "invalid key archive: scrypt parameters too costly (n=1048576, r=32, p=16)"},
+		}
+		for _, testCase := range testCases {
+			data, _ := json.Marshal(keyArchive{Version: keyArchiveVersion, KDF: keyArchiveKDF, N: testCase.n, R: testCase.r, P: testCase.p})
+
+			_, err := ReadKeyArchive(bytes.NewReader(data), passphrase)
+
+			assert.EqualError(t, err, testCase.err)
+		}
+	})
+}
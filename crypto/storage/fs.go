@@ -32,7 +32,8 @@ import (
 type entryType string
 
 const (
-	privateKeyEntry entryType = "private.pem"
+	privateKeyEntry  entryType = "private.pem"
+	keyMetadataEntry entryType = "metadata.json"
 )
 
 type fileOpenError struct {
@@ -113,6 +114,26 @@ func (fsc *fileSystemBackend) SavePrivateKey(kid string, key crypto.PrivateKey)
 	return err
 }
 
+// DeletePrivateKey removes the private key file of the given key from disk.
+func (fsc *fileSystemBackend) DeletePrivateKey(kid string) error {
+	filePath := fsc.getEntryPath(kid, privateKeyEntry)
+	err := os.Remove(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &fileOpenError{kid: kid, filePath: filePath, err: ErrNotFound}
+	}
+	return err
+}
+
+// GetKeyMetadata loads the metadata of the given key from disk. Files are postfixed with '_metadata.json'.
+func (fsc *fileSystemBackend) GetKeyMetadata(kid string) ([]byte, error) {
+	return fsc.readEntry(kid, keyMetadataEntry)
+}
+
+// SaveKeyMetadata saves the metadata of the given key to disk. Files are postfixed with '_metadata.json'.
+func (fsc *fileSystemBackend) SaveKeyMetadata(kid string, metadata []byte) error {
+	return os.WriteFile(fsc.getEntryPath(kid, keyMetadataEntry), metadata, 0600)
+}
+
 func (fsc *fileSystemBackend) ListPrivateKeys() []string {
 	var result []string
 	_ = filepath.Walk(fsc.fspath, func(path string, info fs.FileInfo, err error) error {
@@ -96,6 +96,53 @@ func Test_fs_KeyExistsFor(t *testing.T) {
 	})
 }
 
+func Test_fs_DeletePrivateKey(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		storage, _ := NewFileSystemBackend(io.TestDirectory(t))
+		kid := "kid"
+		_ = storage.SavePrivateKey(kid, test.GenerateECKey())
+		_ = storage.SaveKeyMetadata(kid, []byte("{}"))
+
+		err := storage.DeletePrivateKey(kid)
+
+		assert.NoError(t, err)
+		assert.False(t, storage.PrivateKeyExists(kid))
+		metadata, _ := storage.GetKeyMetadata(kid)
+		assert.Equal(t, []byte("{}"), metadata, "metadata should be retained")
+	})
+	t.Run("non-existing entry", func(t *testing.T) {
+		storage, _ := NewFileSystemBackend(io.TestDirectory(t))
+
+		err := storage.DeletePrivateKey("unknown")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func Test_fs_KeyMetadata(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		storage, _ := NewFileSystemBackend(io.TestDirectory(t))
+		kid := "kid"
+
+		err := storage.SaveKeyMetadata(kid, []byte("{}"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		metadata, err := storage.GetKeyMetadata(kid)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("{}"), metadata)
+	})
+	t.Run("non-existing entry", func(t *testing.T) {
+		storage, _ := NewFileSystemBackend(io.TestDirectory(t))
+
+		metadata, err := storage.GetKeyMetadata("unknown")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, metadata)
+	})
+}
+
 func Test_fs_ListPrivateKeys(t *testing.T) {
 	storage, _ := NewFileSystemBackend(io.TestDirectory(t))
 	backend := storage.(*fileSystemBackend)
@@ -34,6 +34,35 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// DeletePrivateKey mocks base method.
+func (m *MockStorage) DeletePrivateKey(kid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateKey", kid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateKey indicates an expected call of DeletePrivateKey.
+func (mr *MockStorageMockRecorder) DeletePrivateKey(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateKey", reflect.TypeOf((*MockStorage)(nil).DeletePrivateKey), kid)
+}
+
+// GetKeyMetadata mocks base method.
+func (m *MockStorage) GetKeyMetadata(kid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyMetadata", kid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyMetadata indicates an expected call of GetKeyMetadata.
+func (mr *MockStorageMockRecorder) GetKeyMetadata(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyMetadata", reflect.TypeOf((*MockStorage)(nil).GetKeyMetadata), kid)
+}
+
 // GetPrivateKey mocks base method.
 func (m *MockStorage) GetPrivateKey(kid string) (crypto.Signer, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +106,20 @@ func (mr *MockStorageMockRecorder) PrivateKeyExists(kid interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateKeyExists", reflect.TypeOf((*MockStorage)(nil).PrivateKeyExists), kid)
 }
 
+// SaveKeyMetadata mocks base method.
+func (m *MockStorage) SaveKeyMetadata(kid string, metadata []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveKeyMetadata", kid, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveKeyMetadata indicates an expected call of SaveKeyMetadata.
+func (mr *MockStorageMockRecorder) SaveKeyMetadata(kid, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveKeyMetadata", reflect.TypeOf((*MockStorage)(nil).SaveKeyMetadata), kid, metadata)
+}
+
 // SavePrivateKey mocks base method.
 func (m *MockStorage) SavePrivateKey(kid string, key crypto.PrivateKey) error {
 	m.ctrl.T.Helper()
@@ -90,3 +133,42 @@ func (mr *MockStorageMockRecorder) SavePrivateKey(kid, key interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePrivateKey", reflect.TypeOf((*MockStorage)(nil).SavePrivateKey), kid, key)
 }
+
+// MockKeyGenerator is a mock of KeyGenerator interface.
+type MockKeyGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyGeneratorMockRecorder
+}
+
+// MockKeyGeneratorMockRecorder is the mock recorder for MockKeyGenerator.
+type MockKeyGeneratorMockRecorder struct {
+	mock *MockKeyGenerator
+}
+
+// NewMockKeyGenerator creates a new mock instance.
+func NewMockKeyGenerator(ctrl *gomock.Controller) *MockKeyGenerator {
+	mock := &MockKeyGenerator{ctrl: ctrl}
+	mock.recorder = &MockKeyGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyGenerator) EXPECT() *MockKeyGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GeneratePrivateKey mocks base method.
+func (m *MockKeyGenerator) GeneratePrivateKey(namingFunc func(crypto.PublicKey) (string, error)) (crypto.Signer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePrivateKey", namingFunc)
+	ret0, _ := ret[0].(crypto.Signer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GeneratePrivateKey indicates an expected call of GeneratePrivateKey.
+func (mr *MockKeyGeneratorMockRecorder) GeneratePrivateKey(namingFunc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePrivateKey", reflect.TypeOf((*MockKeyGenerator)(nil).GeneratePrivateKey), namingFunc)
+}
@@ -0,0 +1,93 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// PKCS11Config contains the config options to configure the PKCS#11 (HSM) storage backend.
+type PKCS11Config struct {
+	// Library is the path to the PKCS#11 module (shared library) provided by the HSM vendor.
+	Library string `koanf:"library"`
+	// TokenLabel is the label of the token (slot) the keys are stored in.
+	TokenLabel string `koanf:"tokenlabel"`
+	// Pin is the user PIN used to log in to the token.
+	Pin string `koanf:"pin"`
+}
+
+// DefaultPKCS11Config returns an empty PKCS11Config, since there are no sane defaults for HSM connections.
+func DefaultPKCS11Config() PKCS11Config {
+	return PKCS11Config{}
+}
+
+func (c PKCS11Config) validate() error {
+	if c.Library == "" {
+		return errors.New("PKCS#11 library not configured")
+	}
+	if c.TokenLabel == "" {
+		return errors.New("PKCS#11 token label not configured")
+	}
+	return nil
+}
+
+// p256OID is the DER encoded OID of the NIST P-256 curve, used as CKA_EC_PARAMS.
+var p256OID = mustMarshalASN1(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+
+func mustMarshalASN1(val interface{}) []byte {
+	result, err := asn1.Marshal(val)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// parseECPoint parses a CKA_EC_POINT value into a P-256 public key. Although the standard prescribes the point to be
+// wrapped in a DER OCTET STRING, some tokens return the raw uncompressed point, so both are supported.
+func parseECPoint(value []byte) (*ecdsa.PublicKey, error) {
+	curve := elliptic.P256()
+	var point []byte
+	if rest, err := asn1.Unmarshal(value, &point); err != nil || len(rest) > 0 {
+		point = value
+	}
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		return nil, errors.New("invalid EC point")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// rawToASN1Signature converts a PKCS#11 ECDSA signature (r and s concatenated) to the ASN.1 format returned by crypto.Signer.
+func rawToASN1Signature(signature []byte) ([]byte, error) {
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return nil, fmt.Errorf("invalid ECDSA signature length: %d", len(signature))
+	}
+	half := len(signature) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(signature[:half]),
+		S: new(big.Int).SetBytes(signature[half:]),
+	})
+}
@@ -0,0 +1,443 @@
+//go:build cgo
+
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+)
+
+// keyMetadataApplication is the CKA_APPLICATION of data objects holding key metadata.
+const keyMetadataApplication = "nuts-key-metadata"
+
+// pkcs11Storage stores private keys in a PKCS#11 token (e.g. an HSM). Private keys are generated inside the token and
+// marked as sensitive and non-extractable, so they never leave it. Keys are looked up by their CKA_LABEL, which holds the KID.
+type pkcs11Storage struct {
+	ctx *pkcs11.Ctx
+	// session is the single (logged in) session used for all operations. PKCS#11 sessions may not be used concurrently,
+	// so access is guarded by mux.
+	session pkcs11.SessionHandle
+	mux     *sync.Mutex
+}
+
+// NewPKCS11Storage creates a new storage backend which stores private keys in the PKCS#11 token identified by config.TokenLabel.
+func NewPKCS11Storage(config PKCS11Config) (Storage, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	ctx := pkcs11.New(config.Library)
+	if ctx == nil {
+		return nil, fmt.Errorf("unable to load PKCS#11 library: %s", config.Library)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("unable to initialize PKCS#11 library: %w", err)
+	}
+	session, err := openPKCS11Session(ctx, config)
+	if err != nil {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	log.Logger().Infof("Connected to PKCS#11 token (label=%s).", config.TokenLabel)
+	return &pkcs11Storage{ctx: ctx, session: session, mux: &sync.Mutex{}}, nil
+}
+
+func openPKCS11Session(ctx *pkcs11.Ctx, config PKCS11Config) (pkcs11.SessionHandle, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("unable to list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		tokenInfo, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("unable to read PKCS#11 token info (slot=%d): %w", slot, err)
+		}
+		// Token labels are padded with spaces
+		if strings.TrimRight(tokenInfo.Label, " \x00") != config.TokenLabel {
+			continue
+		}
+		session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err != nil {
+			return 0, fmt.Errorf("unable to open PKCS#11 session: %w", err)
+		}
+		err = ctx.Login(session, pkcs11.CKU_USER, config.Pin)
+		if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+			_ = ctx.CloseSession(session)
+			return 0, fmt.Errorf("unable to log in to PKCS#11 token: %w", err)
+		}
+		return session, nil
+	}
+	return 0, fmt.Errorf("PKCS#11 token not found (label=%s)", config.TokenLabel)
+}
+
+func (p pkcs11Storage) GetPrivateKey(kid string) (crypto.Signer, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.getPrivateKey(kid)
+}
+
+func (p pkcs11Storage) getPrivateKey(kid string) (crypto.Signer, error) {
+	privateKey, err := p.findObject(pkcs11.CKO_PRIVATE_KEY, kid)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyHandle, err := p.findObject(pkcs11.CKO_PUBLIC_KEY, kid)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := p.readPublicKey(publicKeyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read public key from PKCS#11 token (kid=%s): %w", kid, err)
+	}
+	return &pkcs11Signer{storage: p, handle: privateKey, publicKey: publicKey}, nil
+}
+
+func (p pkcs11Storage) PrivateKeyExists(kid string) bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	_, err := p.findObject(pkcs11.CKO_PRIVATE_KEY, kid)
+	return err == nil
+}
+
+// SavePrivateKey imports the given private key into the token. Keys should preferably be generated in the token
+// (using GeneratePrivateKey), but importing is required to migrate keys from other storage backends.
+func (p pkcs11Storage) SavePrivateKey(kid string, key crypto.PrivateKey) error {
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return errors.New("PKCS#11 storage only supports ECDSA P-256 keys")
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if _, err := p.findObject(pkcs11.CKO_PRIVATE_KEY, kid); err == nil {
+		return fmt.Errorf("private key already exists in PKCS#11 token (kid=%s)", kid)
+	}
+	ecPoint := mustMarshalASN1(elliptic.Marshal(ecKey.Curve, ecKey.X, ecKey.Y))
+	publicKey, err := p.ctx.CreateObject(p.session, append(publicKeyTemplate(kid),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, ecPoint),
+	))
+	if err != nil {
+		return fmt.Errorf("unable to import public key into PKCS#11 token: %w", err)
+	}
+	_, err = p.ctx.CreateObject(p.session, append(privateKeyTemplate(kid),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, p256OID),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, ecKey.D.FillBytes(make([]byte, 32))),
+	))
+	if err != nil {
+		_ = p.ctx.DestroyObject(p.session, publicKey)
+		return fmt.Errorf("unable to import private key into PKCS#11 token: %w", err)
+	}
+	return nil
+}
+
+func (p pkcs11Storage) ListPrivateKeys() []string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	handles, err := p.findObjects([]*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY)})
+	if err != nil {
+		log.Logger().
+			WithError(err).
+			Error("Could not list private keys in PKCS#11 token")
+		return nil
+	}
+	var result []string
+	for _, handle := range handles {
+		attributes, err := p.ctx.GetAttributeValue(p.session, handle, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_LABEL, nil)})
+		if err != nil || len(attributes) != 1 || len(attributes[0].Value) == 0 {
+			continue
+		}
+		result = append(result, string(attributes[0].Value))
+	}
+	return result
+}
+
+// DeletePrivateKey destroys the private and public key objects in the token.
+func (p pkcs11Storage) DeletePrivateKey(kid string) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	privateKey, err := p.findObject(pkcs11.CKO_PRIVATE_KEY, kid)
+	if err != nil {
+		return err
+	}
+	if err = p.ctx.DestroyObject(p.session, privateKey); err != nil {
+		return fmt.Errorf("unable to delete private key from PKCS#11 token: %w", err)
+	}
+	if publicKey, err := p.findObject(pkcs11.CKO_PUBLIC_KEY, kid); err == nil {
+		_ = p.ctx.DestroyObject(p.session, publicKey)
+	}
+	return nil
+}
+
+// GetKeyMetadata reads the key's metadata from the token, where it's stored as data object labeled with the KID.
+func (p pkcs11Storage) GetKeyMetadata(kid string) ([]byte, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	handle, err := p.findMetadataObject(kid)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := p.ctx.GetAttributeValue(p.session, handle, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key metadata from PKCS#11 token: %w", err)
+	}
+	if len(attributes) != 1 {
+		return nil, ErrNotFound
+	}
+	return attributes[0].Value, nil
+}
+
+// SaveKeyMetadata stores the key's metadata in the token as data object labeled with the KID, replacing the existing one.
+func (p pkcs11Storage) SaveKeyMetadata(kid string, metadata []byte) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	existing, err := p.findMetadataObject(kid)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	_, err = p.ctx.CreateObject(p.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_DATA),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_APPLICATION, keyMetadataApplication),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, kid),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, metadata),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write key metadata to PKCS#11 token: %w", err)
+	}
+	if existing != 0 {
+		_ = p.ctx.DestroyObject(p.session, existing)
+	}
+	return nil
+}
+
+func (p pkcs11Storage) findMetadataObject(kid string) (pkcs11.ObjectHandle, error) {
+	handles, err := p.findObjects([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_DATA),
+		pkcs11.NewAttribute(pkcs11.CKA_APPLICATION, keyMetadataApplication),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, kid),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to search PKCS#11 token: %w", err)
+	}
+	if len(handles) == 0 {
+		return 0, ErrNotFound
+	}
+	return handles[0], nil
+}
+
+// GeneratePrivateKey generates a P-256 key pair inside the token. Since the KID is derived from the public key,
+// the key pair is generated under a temporary label which is replaced by the KID afterwards.
+func (p pkcs11Storage) GeneratePrivateKey(namingFunc func(key crypto.PublicKey) (string, error)) (crypto.Signer, string, error) {
+	tmpLabel, err := randomLabel()
+	if err != nil {
+		return nil, "", err
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	publicKeyHandle, privateKeyHandle, err := p.ctx.GenerateKeyPair(p.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		append(publicKeyTemplate(tmpLabel), pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, p256OID)),
+		privateKeyTemplate(tmpLabel),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to generate key pair in PKCS#11 token: %w", err)
+	}
+	kid, publicKey, err := p.labelKeyPair(publicKeyHandle, privateKeyHandle, namingFunc)
+	if err != nil {
+		_ = p.ctx.DestroyObject(p.session, privateKeyHandle)
+		_ = p.ctx.DestroyObject(p.session, publicKeyHandle)
+		return nil, "", err
+	}
+	return &pkcs11Signer{storage: p, handle: privateKeyHandle, publicKey: publicKey}, kid, nil
+}
+
+func (p pkcs11Storage) labelKeyPair(publicKeyHandle, privateKeyHandle pkcs11.ObjectHandle, namingFunc func(key crypto.PublicKey) (string, error)) (string, *ecdsa.PublicKey, error) {
+	publicKey, err := p.readPublicKey(publicKeyHandle)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to read generated public key from PKCS#11 token: %w", err)
+	}
+	kid, err := namingFunc(publicKey)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := p.findObject(pkcs11.CKO_PRIVATE_KEY, kid); err == nil {
+		return "", nil, fmt.Errorf("private key already exists in PKCS#11 token (kid=%s)", kid)
+	}
+	label := []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_LABEL, kid)}
+	if err = p.ctx.SetAttributeValue(p.session, publicKeyHandle, label); err == nil {
+		err = p.ctx.SetAttributeValue(p.session, privateKeyHandle, label)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to label key pair in PKCS#11 token: %w", err)
+	}
+	return kid, publicKey, nil
+}
+
+func (p pkcs11Storage) readPublicKey(handle pkcs11.ObjectHandle) (*ecdsa.PublicKey, error) {
+	attributes, err := p.ctx.GetAttributeValue(p.session, handle, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) != 1 {
+		return nil, errors.New("missing CKA_EC_POINT")
+	}
+	return parseECPoint(attributes[0].Value)
+}
+
+// findObject finds the object of the given class with the given label. It returns ErrNotFound if it doesn't exist.
+func (p pkcs11Storage) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	handles, err := p.findObjects([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to search PKCS#11 token: %w", err)
+	}
+	if len(handles) == 0 {
+		return 0, ErrNotFound
+	}
+	return handles[0], nil
+}
+
+func (p pkcs11Storage) findObjects(template []*pkcs11.Attribute) ([]pkcs11.ObjectHandle, error) {
+	if err := p.ctx.FindObjectsInit(p.session, template); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = p.ctx.FindObjectsFinal(p.session)
+	}()
+	var result []pkcs11.ObjectHandle
+	for {
+		handles, _, err := p.ctx.FindObjects(p.session, 100)
+		if err != nil {
+			return nil, err
+		}
+		if len(handles) == 0 {
+			return result, nil
+		}
+		result = append(result, handles...)
+	}
+}
+
+func (p pkcs11Storage) sign(handle pkcs11.ObjectHandle, digest []byte) ([]byte, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if err := p.ctx.SignInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, handle); err != nil {
+		return nil, fmt.Errorf("unable to sign using PKCS#11 token: %w", err)
+	}
+	signature, err := p.ctx.Sign(p.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign using PKCS#11 token: %w", err)
+	}
+	return rawToASN1Signature(signature)
+}
+
+func (p pkcs11Storage) deriveSharedSecret(handle pkcs11.ObjectHandle, publicKey *ecdsa.PublicKey) ([]byte, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	params := pkcs11.NewECDH1DeriveParams(pkcs11.CKD_NULL, nil, elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y))
+	secretHandle, err := p.ctx.DeriveKey(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDH1_DERIVE, params)}, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_GENERIC_SECRET),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, (publicKey.Curve.Params().BitSize+7)/8),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive shared secret using PKCS#11 token: %w", err)
+	}
+	defer func() {
+		_ = p.ctx.DestroyObject(p.session, secretHandle)
+	}()
+	attributes, err := p.ctx.GetAttributeValue(p.session, secretHandle, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read shared secret from PKCS#11 token: %w", err)
+	}
+	if len(attributes) != 1 {
+		return nil, errors.New("unable to read shared secret from PKCS#11 token")
+	}
+	return attributes[0].Value, nil
+}
+
+func publicKeyTemplate(label string) []*pkcs11.Attribute {
+	return []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+	}
+}
+
+func privateKeyTemplate(label string) []*pkcs11.Attribute {
+	return []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DERIVE, true),
+	}
+}
+
+func randomLabel() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tmp-%x", buf), nil
+}
+
+// pkcs11Signer is a crypto.Signer for a private key that resides in a PKCS#11 token.
+// Besides signing, it supports ECDH key agreement which is required for ECIES decryption.
+type pkcs11Signer struct {
+	storage   pkcs11Storage
+	handle    pkcs11.ObjectHandle
+	publicKey *ecdsa.PublicKey
+}
+
+func (s pkcs11Signer) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+func (s pkcs11Signer) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return s.storage.sign(s.handle, digest)
+}
+
+// ECDH performs an ECDH key agreement with the given public key, returning the X coordinate of the shared point.
+func (s pkcs11Signer) ECDH(publicKey *ecdsa.PublicKey) ([]byte, error) {
+	return s.storage.deriveSharedSecret(s.handle, publicKey)
+}
@@ -0,0 +1,28 @@
+//go:build !cgo
+
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import "errors"
+
+// NewPKCS11Storage returns an error, since loading a PKCS#11 library requires the node to be built with CGO enabled.
+func NewPKCS11Storage(_ PKCS11Config) (Storage, error) {
+	return nil, errors.New("PKCS#11 storage is not supported: the node was built without CGO")
+}
@@ -0,0 +1,181 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPKCS11Storage runs against a real PKCS#11 token, e.g. SoftHSM. It requires a token to be initialized beforehand:
+//
+//	softhsm2-util --init-token --free --label nuts-test --pin 1234 --so-pin 1234
+//	NUTS_TEST_PKCS11_LIBRARY=/usr/lib/softhsm/libsofthsm2.so go test ./crypto/storage/...
+func TestPKCS11Storage(t *testing.T) {
+	library := os.Getenv("NUTS_TEST_PKCS11_LIBRARY")
+	if library == "" {
+		t.Skip("NUTS_TEST_PKCS11_LIBRARY not set, skipping PKCS#11 tests")
+	}
+	config := PKCS11Config{Library: library, TokenLabel: "nuts-test", Pin: "1234"}
+	store, err := NewPKCS11Storage(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	kidPrefix := fmt.Sprintf("did:nuts:%d", os.Getpid())
+
+	t.Run("generate, sign and list", func(t *testing.T) {
+		kid := kidPrefix + "#generated"
+		signer, actualKID, err := store.(KeyGenerator).GeneratePrivateKey(func(_ crypto.PublicKey) (string, error) {
+			return kid, nil
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, kid, actualKID)
+		assert.True(t, store.PrivateKeyExists(kid))
+		assert.Contains(t, store.ListPrivateKeys(), kid)
+
+		resolved, err := store.GetPrivateKey(kid)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, signer.Public(), resolved.Public())
+		_, isPrivateKey := resolved.(*ecdsa.PrivateKey)
+		assert.False(t, isPrivateKey, "private key must not be exported")
+
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := resolved.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, ecdsa.VerifyASN1(resolved.Public().(*ecdsa.PublicKey), digest[:], signature))
+	})
+	t.Run("ECDH key agreement", func(t *testing.T) {
+		kid := kidPrefix + "#ecdh"
+		signer, _, err := store.(KeyGenerator).GeneratePrivateKey(func(_ crypto.PublicKey) (string, error) {
+			return kid, nil
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		publicKey := signer.Public().(*ecdsa.PublicKey)
+		expected, _ := elliptic.P256().ScalarMult(publicKey.X, publicKey.Y, other.D.Bytes())
+
+		shared, err := signer.(interface {
+			ECDH(publicKey *ecdsa.PublicKey) ([]byte, error)
+		}).ECDH(&other.PublicKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expected.FillBytes(make([]byte, 32)), shared)
+	})
+	t.Run("import existing key", func(t *testing.T) {
+		kid := kidPrefix + "#imported"
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+		err := store.SavePrivateKey(kid, privateKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		resolved, err := store.GetPrivateKey(kid)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, &privateKey.PublicKey, resolved.Public())
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := store.GetPrivateKey(kidPrefix + "#unknown")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.False(t, store.PrivateKeyExists(kidPrefix+"#unknown"))
+	})
+}
+
+func TestNewPKCS11Storage(t *testing.T) {
+	t.Run("error - library not configured", func(t *testing.T) {
+		store, err := NewPKCS11Storage(PKCS11Config{TokenLabel: "nuts"})
+
+		assert.EqualError(t, err, "PKCS#11 library not configured")
+		assert.Nil(t, store)
+	})
+	t.Run("error - token label not configured", func(t *testing.T) {
+		store, err := NewPKCS11Storage(PKCS11Config{Library: "libsofthsm2.so"})
+
+		assert.EqualError(t, err, "PKCS#11 token label not configured")
+		assert.Nil(t, store)
+	})
+}
+
+func Test_parseECPoint(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	point := elliptic.Marshal(elliptic.P256(), privateKey.X, privateKey.Y)
+
+	t.Run("DER encoded", func(t *testing.T) {
+		encoded, _ := asn1.Marshal(point)
+
+		publicKey, err := parseECPoint(encoded)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("raw", func(t *testing.T) {
+		publicKey, err := parseECPoint(point)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		publicKey, err := parseECPoint([]byte{1, 2, 3})
+
+		assert.EqualError(t, err, "invalid EC point")
+		assert.Nil(t, publicKey)
+	})
+}
+
+func Test_rawToASN1Signature(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		digest := sha256.Sum256([]byte("hello"))
+		r, s, _ := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+		raw := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+		signature, err := rawToASN1Signature(raw)
+
+		assert.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&privateKey.PublicKey, digest[:], signature))
+	})
+	t.Run("error - invalid length", func(t *testing.T) {
+		signature, err := rawToASN1Signature([]byte{1, 2, 3})
+
+		assert.EqualError(t, err, "invalid ECDSA signature length: 3")
+		assert.Nil(t, signature)
+	})
+}
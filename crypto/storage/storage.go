@@ -42,6 +42,22 @@ type Storage interface {
 	SavePrivateKey(kid string, key crypto.PrivateKey) error
 	// ListPrivateKeys returns the KIDs of the private keys that are present.
 	ListPrivateKeys() []string
+	// DeletePrivateKey removes the private key indicated with the kid from the storage backend.
+	// It returns ErrNotFound if the private key does not exist. Metadata stored alongside the key is retained.
+	DeletePrivateKey(kid string) error
+	// GetKeyMetadata returns the (serialized) metadata stored alongside the key indicated with the kid.
+	// It returns ErrNotFound if no metadata was stored for the key.
+	GetKeyMetadata(kid string) ([]byte, error)
+	// SaveKeyMetadata stores the (serialized) metadata alongside the key indicated with the kid, replacing existing metadata.
+	SaveKeyMetadata(kid string, metadata []byte) error
+}
+
+// KeyGenerator is implemented by storage backends that generate private keys themselves (e.g. inside an HSM),
+// instead of storing key pairs generated by the node. Keys generated this way might not be exportable.
+type KeyGenerator interface {
+	// GeneratePrivateKey generates a new key pair in the storage backend and stores it under the KID returned by namingFunc.
+	// It returns the handler of the private key as an implementation of crypto.Signer and the KID.
+	GeneratePrivateKey(namingFunc func(key crypto.PublicKey) (string, error)) (crypto.Signer, string, error)
 }
 
 // PublicKeyEntry is a public key entry also containing the period it's valid for.
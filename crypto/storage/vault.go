@@ -29,8 +29,10 @@ import (
 )
 
 const privateKeyPathName = "nuts-private-keys"
+const keyMetadataPathName = "nuts-private-key-metadata"
 const defaultPathPrefix = "kv"
 const keyName = "key"
+const metadataName = "metadata"
 
 // VaultConfig contains the config options to configure the vaultKVStorage backend
 type VaultConfig struct {
@@ -57,6 +59,7 @@ type logicaler interface {
 	Read(path string) (*vault.Secret, error)
 	Write(path string, data map[string]interface{}) (*vault.Secret, error)
 	ReadWithData(path string, data map[string][]string) (*vault.Secret, error)
+	Delete(path string) (*vault.Secret, error)
 }
 
 type vaultKVStorage struct {
@@ -150,7 +153,7 @@ func (v vaultKVStorage) storeValue(path, key string, value string) error {
 func (v vaultKVStorage) PrivateKeyExists(kid string) bool {
 	path := privateKeyPath(v.config.PathPrefix, kid)
 	result, err := v.client.Read(path)
-	if err != nil {
+	if err != nil || result == nil {
 		return false
 	}
 	_, ok := result.Data[keyName]
@@ -181,6 +184,40 @@ func (v vaultKVStorage) ListPrivateKeys() []string {
 	return result
 }
 
+func (v vaultKVStorage) DeletePrivateKey(kid string) error {
+	if !v.PrivateKeyExists(kid) {
+		return ErrNotFound
+	}
+	_, err := v.client.Delete(privateKeyPath(v.config.PathPrefix, kid))
+	if err != nil {
+		return fmt.Errorf("unable to delete private key from vault: %w", err)
+	}
+	return nil
+}
+
+func (v vaultKVStorage) GetKeyMetadata(kid string) ([]byte, error) {
+	result, err := v.client.Read(keyMetadataPath(v.config.PathPrefix, kid))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key metadata from vault: %w", err)
+	}
+	if result == nil {
+		return nil, ErrNotFound
+	}
+	value, ok := result.Data[metadataName].(string)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return []byte(value), nil
+}
+
+func (v vaultKVStorage) SaveKeyMetadata(kid string, metadata []byte) error {
+	_, err := v.client.Write(keyMetadataPath(v.config.PathPrefix, kid), map[string]interface{}{metadataName: string(metadata)})
+	if err != nil {
+		return fmt.Errorf("unable to write key metadata to vault: %w", err)
+	}
+	return nil
+}
+
 // privateKeyPath cleans the kid by removing optional slashes and dots and constructs the key path
 // This prevents “dot-dot-slash” aka “directory traversal” attacks.
 func privateKeyPath(prefix, kid string) string {
@@ -188,6 +225,13 @@ func privateKeyPath(prefix, kid string) string {
 	return filepath.Clean(path)
 }
 
+// keyMetadataPath constructs the path of the key's metadata, which is stored next to (not under) the private keys
+// to keep it out of private key listings.
+func keyMetadataPath(prefix, kid string) string {
+	path := fmt.Sprintf("%s/%s/%s", prefix, keyMetadataPathName, filepath.Base(kid))
+	return filepath.Clean(path)
+}
+
 func privateKeyListPath(prefix string) string {
 	path := fmt.Sprintf("%s/%s", prefix, privateKeyPathName)
 	return filepath.Clean(path)
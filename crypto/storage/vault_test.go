@@ -65,6 +65,14 @@ func (m mockVaultClient) Write(path string, data map[string]interface{}) (*vault
 	}, nil
 }
 
+func (m mockVaultClient) Delete(path string) (*vault.Secret, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	delete(m.store, path)
+	return nil, nil
+}
+
 func TestVaultKVStorage(t *testing.T) {
 	var privateKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	const kid = "did:nuts:123#abc"
@@ -124,6 +132,64 @@ func TestVaultKVStorage(t *testing.T) {
 	})
 }
 
+func TestVaultKVStorage_DeletePrivateKey(t *testing.T) {
+	var privateKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	const kid = "did:nuts:123#abc"
+
+	t.Run("ok", func(t *testing.T) {
+		vaultStorage := vaultKVStorage{config: DefaultVaultConfig(), client: mockVaultClient{store: map[string]map[string]interface{}{}}}
+		_ = vaultStorage.SavePrivateKey(kid, privateKey)
+
+		err := vaultStorage.DeletePrivateKey(kid)
+
+		assert.NoError(t, err)
+		assert.False(t, vaultStorage.PrivateKeyExists(kid))
+	})
+	t.Run("error - key not found", func(t *testing.T) {
+		vaultStorage := vaultKVStorage{config: DefaultVaultConfig(), client: mockVaultClient{store: map[string]map[string]interface{}{}}}
+
+		err := vaultStorage.DeletePrivateKey(kid)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestVaultKVStorage_KeyMetadata(t *testing.T) {
+	const kid = "did:nuts:123#abc"
+
+	t.Run("ok", func(t *testing.T) {
+		store := map[string]map[string]interface{}{}
+		vaultStorage := vaultKVStorage{config: DefaultVaultConfig(), client: mockVaultClient{store: store}}
+
+		err := vaultStorage.SaveKeyMetadata(kid, []byte("{}"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		metadata, err := vaultStorage.GetKeyMetadata(kid)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("{}"), metadata)
+		assert.Contains(t, store, "kv/nuts-private-key-metadata/did:nuts:123#abc")
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		vaultStorage := vaultKVStorage{config: DefaultVaultConfig(), client: mockVaultClient{store: map[string]map[string]interface{}{}}}
+
+		metadata, err := vaultStorage.GetKeyMetadata(kid)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, metadata)
+	})
+	t.Run("error - vault error", func(t *testing.T) {
+		vaultError := errors.New("vault error")
+		vaultStorage := vaultKVStorage{client: mockVaultClient{err: vaultError}}
+
+		_, err := vaultStorage.GetKeyMetadata(kid)
+		assert.ErrorIs(t, err, vaultError)
+		err = vaultStorage.SaveKeyMetadata(kid, []byte("{}"))
+		assert.ErrorIs(t, err, vaultError)
+	})
+}
+
 func TestVaultKVStorage_ListPrivateKeys(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		writer.Write([]byte("{\"request_id\":\"d728876e-ea1e-8a58-f297-dcd4cd0a41bb\",\"lease_id\":\"\",\"renewable\":false,\"lease_duration\":0,\"data\":{\"keys\":[\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#45KSfeG71ZMh9NjGzSWFfcMsmu5587J93prf8Io1wf4\",\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#6Cc91cQQze7txdcEor_zkM4YSwX0kH1wsiMyeV9nedA\",\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#MaNou-G07aPD7oheretmI2C_VElG1XaHiqh89SlfkWQ\",\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#alt3OIpy21VxDlWao0jRumIyXi3qHBPG-ir5q8zdv8w\",\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#wumme98rwUOQVle-sT_MP3pRg_oqblvlanv3zYR2scc\",\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#yBLHNjVq_WM3qzsRQ_zi2yOcedjY9FfVfByp3HgEbR8\",\"did:nuts:8AB7Jf8KYgNHC52sfyTTK2f2yGnDoSHkgzDgeqvrUBLo#yREqK5id7I6SP1Iq7teThin2o53w17tb9sgEXZBIcDo\"]},\"wrap_info\":null,\"warnings\":null,\"auth\":null}"))
@@ -48,21 +48,24 @@ func ErrorNamingFunc(err error) KIDNamingFunc {
 }
 
 func NewMemoryStorage() storage.Storage {
-	return memoryStorage{}
+	return memoryStorage{keys: map[string]crypto.PrivateKey{}, metadata: map[string][]byte{}}
 }
 
-type memoryStorage map[string]crypto.PrivateKey
+type memoryStorage struct {
+	keys     map[string]crypto.PrivateKey
+	metadata map[string][]byte
+}
 
 func (m memoryStorage) ListPrivateKeys() []string {
 	var result []string
-	for key := range m {
+	for key := range m.keys {
 		result = append(result, key)
 	}
 	return result
 }
 
 func (m memoryStorage) GetPrivateKey(kid string) (crypto.Signer, error) {
-	pk, ok := m[kid]
+	pk, ok := m.keys[kid]
 	if !ok {
 		return nil, ErrPrivateKeyNotFound
 	}
@@ -70,12 +73,33 @@ func (m memoryStorage) GetPrivateKey(kid string) (crypto.Signer, error) {
 }
 
 func (m memoryStorage) PrivateKeyExists(kid string) bool {
-	_, ok := m[kid]
+	_, ok := m.keys[kid]
 	return ok
 }
 
 func (m memoryStorage) SavePrivateKey(kid string, key crypto.PrivateKey) error {
-	m[kid] = key
+	m.keys[kid] = key
+	return nil
+}
+
+func (m memoryStorage) DeletePrivateKey(kid string) error {
+	if _, ok := m.keys[kid]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(m.keys, kid)
+	return nil
+}
+
+func (m memoryStorage) GetKeyMetadata(kid string) ([]byte, error) {
+	metadata, ok := m.metadata[kid]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return metadata, nil
+}
+
+func (m memoryStorage) SaveKeyMetadata(kid string, metadata []byte) error {
+	m.metadata[kid] = metadata
 	return nil
 }
 
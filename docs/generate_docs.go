@@ -47,7 +47,7 @@ func (sl stringSlice) contains(s string) bool {
 }
 
 // serverCommands lists the commands that use the server config. The options server commands are only printed once, because the list is quite long.
-var serverCommands stringSlice = []string{"nuts status", "nuts config", "nuts server", "nuts crypto fs2vault"}
+var serverCommands stringSlice = []string{"nuts status", "nuts config", "nuts server", "nuts crypto fs2vault", "nuts crypto export", "nuts crypto import"}
 
 func generateDocs() {
 	system := cmd.CreateSystem(func() {})
@@ -73,7 +73,9 @@ func generateCLICommands(system *core.System) {
 	_, _ = writer.WriteString("The following options apply to the server commands below:" + newline + newline)
 
 	_, _ = io.WriteString(writer, newline+"::"+newline+newline)
-	writeCommandOptions(writer, cmd.CreateCommand(system).Commands()[0])
+	// Commands are sorted by name, so look up a server command instead of relying on its position
+	configCommand, _, _ := cmd.CreateCommand(system).Find([]string{"config"})
+	writeCommandOptions(writer, configCommand)
 
 	err := GenerateCommandDocs(cmd.CreateCommand(system), writer, func(cmd *cobra.Command) bool {
 		return serverCommands.contains(cmd.CommandPath()) && cmd.CommandPath() != "nuts"
@@ -20,6 +20,9 @@
 package events
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"path"
 	"time"
 
@@ -88,6 +91,16 @@ func (m *manager) Configure(config core.ServerConfig) error {
 		Storage:   nats.FileStorage,
 	}, []nats.SubOpt{}, true)
 
+	// register Credentials stream
+	m.streams[CredentialsStream] = newStream(&nats.StreamConfig{
+		Name:      CredentialsStream,
+		Subjects:  []string{"CREDENTIALS.*"},
+		Retention: nats.LimitsPolicy,
+		MaxAge:    168 * time.Hour, // week
+		Discard:   nats.DiscardOld,
+		Storage:   nats.FileStorage,
+	}, []nats.SubOpt{}, true)
+
 	return nil
 }
 
@@ -96,6 +109,27 @@ func (m *manager) GetStream(streamName string) Stream {
 	return s
 }
 
+func (m *manager) Publish(streamName string, subject string, payload interface{}) error {
+	s, ok := m.streams[streamName].(*stream)
+	if !ok {
+		return fmt.Errorf("unknown stream: %s", streamName)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, js, err := m.pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	// make sure the stream exists, so the event is retained for consumers that subscribe later on
+	if err = s.create(js); err != nil {
+		return err
+	}
+	_, err = js.PublishAsync(subject, data)
+	return err
+}
+
 func (m *manager) Start() error {
 	server, err := natsServer.NewServer(&natsServer.Options{
 		JetStream: true,
@@ -60,3 +60,17 @@ func (mr *MockEventMockRecorder) Pool() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pool", reflect.TypeOf((*MockEvent)(nil).Pool))
 }
+
+// Publish mocks base method.
+func (m *MockEvent) Publish(streamName, subject string, payload interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", streamName, subject, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventMockRecorder) Publish(streamName, subject, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEvent)(nil).Publish), streamName, subject, payload)
+}
@@ -76,8 +76,8 @@ func TestManager_Configure(t *testing.T) {
 	t.Run("streams are not created at startup", func(t *testing.T) {
 		eventManager := createManager(t)
 		_, js, _ := eventManager.Pool().Acquire(context.Background())
-		// 2 streams registered in own administration
-		assert.Len(t, eventManager.streams, 2)
+		// 3 streams registered in own administration
+		assert.Len(t, eventManager.streams, 3)
 
 		_, err := js.StreamInfo(eventManager.streams[TransactionsStream].Config().Name)
 
@@ -100,6 +100,30 @@ func TestManager_Configure(t *testing.T) {
 	})
 }
 
+func TestManager_Publish(t *testing.T) {
+	t.Run("ok - stream is created", func(t *testing.T) {
+		eventManager := createManager(t)
+
+		err := eventManager.Publish(CredentialsStream, CredentialRevokedSubject, CredentialRevokedEvent{CredentialID: "did:nuts:123#1"})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, js, _ := eventManager.Pool().Acquire(context.Background())
+		test.WaitFor(t, func() (bool, error) {
+			info, err := js.StreamInfo(CredentialsStream)
+			return err == nil && info.State.Msgs == 1, nil
+		}, time.Second, "timeout waiting for message to be stored")
+	})
+	t.Run("error - unknown stream", func(t *testing.T) {
+		eventManager := createManager(t)
+
+		err := eventManager.Publish("unknown", "unknown.subject", "")
+
+		assert.EqualError(t, err, "unknown stream: unknown")
+	})
+}
+
 func createManager(t *testing.T) *manager {
 	testDir := io.TestDirectory(t)
 	eventManager := NewManager().(*manager)
@@ -26,4 +26,7 @@ type Event interface {
 	GetStream(streamName string) Stream
 	// Pool returns the NATS connection-pool
 	Pool() ConnectionPool
+	// Publish publishes the JSON encoded payload on the given subject of a predefined stream.
+	// The stream is created on the NATS server if it doesn't exist yet.
+	Publish(streamName string, subject string, payload interface{}) error
 }
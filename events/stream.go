@@ -33,6 +33,8 @@ const (
 	DataStream = "DATA"
 	// ReprocessStream is the stream name used to rebuild the VDR/VCR
 	ReprocessStream = "REPROCESS"
+	// CredentialsStream is the stream name on which credential related events (revocation, expiry and trust changes) are stored
+	CredentialsStream = "CREDENTIALS"
 )
 
 // Stream contains configuration for a NATS stream both on the server and client side
@@ -21,3 +21,15 @@ package events
 // TransactionsSubject defines the NATS subject used for transactions
 // Payload: TransactionWithPayload
 const TransactionsSubject = "TRANSACTIONS.tx"
+
+// CredentialRevokedSubject defines the NATS subject used when a revocation of a credential is registered
+// Payload: CredentialRevokedEvent
+const CredentialRevokedSubject = "CREDENTIALS.revoked"
+
+// CredentialExpiringSubject defines the NATS subject used when a credential issued by this node is about to expire
+// Payload: CredentialExpiringEvent
+const CredentialExpiringSubject = "CREDENTIALS.expiring"
+
+// TrustChangedSubject defines the NATS subject used when an issuer is trusted or untrusted for a credential type
+// Payload: TrustChangedEvent
+const TrustChangedSubject = "CREDENTIALS.trust"
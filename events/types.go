@@ -22,6 +22,7 @@ package events
 import (
 	"encoding/base64"
 	"encoding/json"
+	"time"
 
 	"github.com/nuts-foundation/nuts-node/network/dag"
 )
@@ -62,3 +63,35 @@ func (t *TransactionWithPayload) UnmarshalJSON(bytes []byte) error {
 
 	return err
 }
+
+// CredentialRevokedEvent is published when the revocation of a credential is registered.
+type CredentialRevokedEvent struct {
+	// CredentialID contains the ID of the revoked credential
+	CredentialID string `json:"credentialID"`
+	// Issuer contains the DID of the issuer of the revoked credential
+	Issuer string `json:"issuer"`
+	// Reason contains the (optional) reason of revocation
+	Reason string `json:"reason,omitempty"`
+	// Date contains the date of revocation
+	Date time.Time `json:"date"`
+}
+
+// CredentialExpiringEvent is published when a credential issued by this node is about to expire.
+type CredentialExpiringEvent struct {
+	// CredentialID contains the ID of the expiring credential
+	CredentialID string `json:"credentialID"`
+	// Issuer contains the DID of the issuer of the expiring credential
+	Issuer string `json:"issuer"`
+	// ExpirationDate contains the date at which the credential expires
+	ExpirationDate time.Time `json:"expirationDate"`
+}
+
+// TrustChangedEvent is published when an issuer is trusted or untrusted for a credential type.
+type TrustChangedEvent struct {
+	// CredentialType contains the credential type for which the trust changed
+	CredentialType string `json:"credentialType"`
+	// Issuer contains the DID of the issuer that was trusted or untrusted
+	Issuer string `json:"issuer"`
+	// Trusted is true if the issuer is now trusted, false if it's untrusted
+	Trusted bool `json:"trusted"`
+}
@@ -0,0 +1,153 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/http/log"
+)
+
+// auditedPath is the path under which API operations are recorded in the audit log.
+const auditedPath = "/internal"
+
+// subjectParams contains the path parameters that identify the subject of an operation, in order of preference:
+// the DID an operation is performed on takes precedence over e.g. the verification method (kid) of that DID.
+var subjectParams = []string{"did", "id", "kid"}
+
+// createRoutes lists the operations that create an entity (e.g. a DID or credential), identified by the id property
+// of the response body, which is recorded as subject.
+var createRoutes = []struct {
+	method string
+	path   string
+}{
+	{http.MethodPost, "/internal/vdr/v1/did"},
+	{http.MethodPost, "/internal/vcr/v2/issuer/vc"},
+}
+
+// auditMiddleware records state-changing requests to the internal API in the audit log.
+// Operations that only read data are derived from the scopes they require (see requiredScopes), so read operations
+// using POST (e.g. searching credentials) aren't recorded.
+// Requests are recorded after they've been handled, so the actor (set by the authentication middleware),
+// operation (set by the API wrapper) and response status are known. Failed operations are recorded as well,
+// but requests rejected by the authentication middleware aren't: these would allow anyone to fill the audit log.
+func auditMiddleware(recorder audit.Recorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !matchesPath(req.URL.Path, auditedPath) || isReadOperation(req.Method, c.Path(), req.URL.Path) {
+				return next(c)
+			}
+			var responseBody *bytes.Buffer
+			if isCreateRoute(req.Method, c.Path()) {
+				responseBody = captureResponseBody(c)
+			}
+			err := next(c)
+			entry := audit.Entry{
+				Actor:     contextString(c, core.UserContextKey),
+				Module:    contextString(c, core.ModuleNameContextKey),
+				Operation: contextString(c, core.OperationIDContextKey),
+				Subject:   subject(c),
+				Method:    req.Method,
+				Path:      req.URL.Path,
+				RemoteIP:  c.RealIP(),
+				Status:    responseStatus(c.Response(), err),
+			}
+			if entry.Actor == "" && entry.Operation == "" {
+				// Request didn't pass authentication, or didn't reach an API operation (e.g. unknown path)
+				return err
+			}
+			if entry.Operation == "" {
+				// Authenticated request that was refused before reaching the API operation (e.g. insufficient scope)
+				entry.Operation = req.Method + " " + req.URL.Path
+			}
+			if responseBody != nil && err == nil {
+				entry.Subject = createdID(responseBody.Bytes())
+			}
+			if recordErr := recorder.Record(entry); recordErr != nil {
+				log.Logger().
+					WithError(recordErr).
+					WithField("operation", entry.Operation).
+					Error("Unable to record request in audit log")
+			}
+			return err
+		}
+	}
+}
+
+func isCreateRoute(method string, routePath string) bool {
+	for _, route := range createRoutes {
+		if route.method == method && route.path == routePath {
+			return true
+		}
+	}
+	return false
+}
+
+// captureResponseBody makes the response body written by the handler available in the returned buffer.
+func captureResponseBody(c echo.Context) *bytes.Buffer {
+	body := new(bytes.Buffer)
+	response := c.Response()
+	response.Writer = &capturingResponseWriter{
+		Writer:         io.MultiWriter(response.Writer, body),
+		ResponseWriter: response.Writer,
+	}
+	return body
+}
+
+// createdID returns the id property of the given (JSON) response body, e.g. the ID of a created DID document or credential.
+func createdID(responseBody []byte) string {
+	var created struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(responseBody, &created)
+	return created.ID
+}
+
+// capturingResponseWriter writes the response to Writer, which also writes it to the original ResponseWriter.
+type capturingResponseWriter struct {
+	io.Writer
+	http.ResponseWriter
+}
+
+func (w *capturingResponseWriter) Write(data []byte) (int, error) {
+	return w.Writer.Write(data)
+}
+
+// subject returns the value of the first path parameter in subjectParams the request has.
+// Path parameters have already been decoded by decodeURIPath.
+func subject(c echo.Context) string {
+	for _, name := range subjectParams {
+		if value := c.Param(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func contextString(c echo.Context, key string) string {
+	value, _ := c.Get(key).(string)
+	return value
+}
@@ -0,0 +1,167 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_auditMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := audit.NewMockRecorder(ctrl)
+
+	e := echo.New()
+	e.HTTPErrorHandler = core.CreateHTTPErrorHandler()
+	e.Use(decodeURIPath)
+	e.Use(auditMiddleware(recorder))
+	// Stub for the authentication middleware, which sets the user
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("Authorization") == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized)
+			}
+			c.Set(core.UserContextKey, "admin")
+			return next(c)
+		}
+	})
+	handler := func(c echo.Context) error {
+		// API wrappers set the operation ID and module name
+		c.Set(core.OperationIDContextKey, "testOperation")
+		c.Set(core.ModuleNameContextKey, "Test")
+		return c.NoContent(http.StatusNoContent)
+	}
+	e.POST("/internal/test/:id", handler)
+	e.DELETE("/internal/test/did/:did/key/:kid", handler)
+	e.POST("/internal/vcr/v2/search", handler)
+	e.POST("/internal/vdr/v1/did", func(c echo.Context) error {
+		c.Set(core.OperationIDContextKey, "createDID")
+		return c.JSON(http.StatusOK, map[string]interface{}{"id": "did:nuts:created"})
+	})
+	// Stub for an operation refused before reaching the API wrapper (e.g. by the scope middleware)
+	e.POST("/internal/test/forbidden", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden)
+	})
+	e.GET("/internal/test/:id", handler)
+	e.POST("/public/test", handler)
+
+	doRequest := func(method string, path string, withToken bool) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		if withToken {
+			request.Header.Set("Authorization", "Bearer token")
+		}
+		response := httptest.NewRecorder()
+		e.ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("records state-changing request", func(t *testing.T) {
+		var entry audit.Entry
+		recorder.EXPECT().Record(gomock.Any()).DoAndReturn(func(e audit.Entry) error {
+			entry = e
+			return nil
+		})
+
+		response := doRequest(http.MethodPost, "/internal/test/did%3Anuts%3A123", true)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Equal(t, "admin", entry.Actor)
+		assert.Equal(t, "Test", entry.Module)
+		assert.Equal(t, "testOperation", entry.Operation)
+		assert.Equal(t, http.MethodPost, entry.Method)
+		assert.Equal(t, "/internal/test/did:nuts:123", entry.Path)
+		assert.Equal(t, "did:nuts:123", entry.Subject)
+		assert.Equal(t, http.StatusNoContent, entry.Status)
+		assert.NotEmpty(t, entry.RemoteIP)
+	})
+	t.Run("subject is the DID the operation is performed on", func(t *testing.T) {
+		var entry audit.Entry
+		recorder.EXPECT().Record(gomock.Any()).DoAndReturn(func(e audit.Entry) error {
+			entry = e
+			return nil
+		})
+
+		doRequest(http.MethodDelete, "/internal/test/did/did%3Anuts%3A123/key/did%3Anuts%3A123%23key-1", true)
+
+		assert.Equal(t, "did:nuts:123", entry.Subject)
+	})
+	t.Run("subject of create operation is taken from the response", func(t *testing.T) {
+		var entry audit.Entry
+		recorder.EXPECT().Record(gomock.Any()).DoAndReturn(func(e audit.Entry) error {
+			entry = e
+			return nil
+		})
+
+		response := doRequest(http.MethodPost, "/internal/vdr/v1/did", true)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), "did:nuts:created")
+		assert.Equal(t, "createDID", entry.Operation)
+		assert.Equal(t, "did:nuts:created", entry.Subject)
+	})
+	t.Run("records authenticated request refused before reaching the operation", func(t *testing.T) {
+		var entry audit.Entry
+		recorder.EXPECT().Record(gomock.Any()).DoAndReturn(func(e audit.Entry) error {
+			entry = e
+			return nil
+		})
+
+		response := doRequest(http.MethodPost, "/internal/test/forbidden", true)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Equal(t, "admin", entry.Actor)
+		assert.Equal(t, "POST /internal/test/forbidden", entry.Operation)
+		assert.Equal(t, http.StatusForbidden, entry.Status)
+	})
+	t.Run("unauthenticated request isn't recorded", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/internal/test/1", false)
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("read operation using POST isn't recorded", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/internal/vcr/v2/search", true)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("request succeeds when recording fails", func(t *testing.T) {
+		recorder.EXPECT().Record(gomock.Any()).Return(errors.New("failed"))
+
+		response := doRequest(http.MethodPost, "/internal/test/1", true)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("read request isn't recorded", func(t *testing.T) {
+		response := doRequest(http.MethodGet, "/internal/test/1", true)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("public API isn't recorded", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/public/test", true)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -38,7 +39,13 @@ func FlagSet() *pflag.FlagSet {
 	flags.String("http.default.address", defs.Address, "Address and port the server will be listening to")
 	flags.StringSlice("http.default.cors.origin", defs.CORS.Origin, "When set, enables CORS from the specified origins on the default HTTP interface.")
 	flags.String("http.default.tls", string(defs.TLSMode), fmt.Sprintf("Whether to enable TLS for the default interface, options are '%s', '%s', '%s'. Leaving it empty is synonymous to '%s',", http.TLSDisabledMode, http.TLSServerCertMode, http.TLServerClientCertMode, http.TLSDisabledMode))
-	flags.String("http.default.auth.type", string(defs.Auth.Type), fmt.Sprintf("Whether to enable authentication for the default interface, specify '%s' for bearer token authentication.", http.BearerTokenAuth))
+	flags.String("http.default.auth.type", string(defs.Auth.Type), fmt.Sprintf("Whether to enable authentication for the default interface, specify '%s' for bearer token authentication or '%s' for authentication using an external OpenID Connect identity provider.", http.BearerTokenAuth, http.OIDCAuth))
+	flags.String("http.default.auth.oidc.issuer", defs.Auth.OIDC.Issuer, "Identifier (URL) of the OpenID Connect identity provider that issues the tokens, required when auth.type is 'oidc'.")
+	flags.String("http.default.auth.oidc.audience", defs.Auth.OIDC.Audience, "Audience that must be present in tokens issued by the OpenID Connect identity provider, required when auth.type is 'oidc'.")
+	flags.String("http.default.auth.oidc.jwksurl", defs.Auth.OIDC.JWKSURL, "URL of the JWKS containing the signing keys of the OpenID Connect identity provider. If not set, it's discovered from the issuer's configuration.")
+	flags.String("http.default.auth.oidc.userclaim", defs.Auth.OIDC.UserClaim, "Claim of the token issued by the OpenID Connect identity provider that contains the user name, used for logging.")
+	flags.Duration("http.default.auth.oidc.refreshinterval", defs.Auth.OIDC.RefreshInterval, "Interval at which the JWKS of the OpenID Connect identity provider is refreshed. If 0, the cache headers of the JWKS response are used.")
+	flags.Bool("http.default.auth.oidc.allowunscoped", defs.Auth.OIDC.AllowUnscoped, "When set, tokens issued by the OpenID Connect identity provider without scope claim grant access to all APIs. Otherwise, they don't grant access to any API.")
 
 	return flags
 }
@@ -54,18 +61,31 @@ func ServerCmd() *cobra.Command {
 }
 
 func createTokenCommand() *cobra.Command {
-	return &cobra.Command{
+	result := &cobra.Command{
 		Use:   "gen-token [user name] [days valid]",
 		Short: "Generates an access token for administrative operations.",
-		Args:  cobra.ExactArgs(2),
+		Long: "Generates an access token for administrative operations. By default the token grants access to all APIs. " +
+			"Use --scope to restrict the token to specific APIs, e.g. 'vdr:read' (read DID documents), 'vcr:issue' (issue and revoke credentials), " +
+			"'crypto:sign' (sign JWTs), 'vdr:*' (all operations on the VDR API) or '*' (all APIs).",
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			daysValid, err := strconv.Atoi(args[1])
 			if err != nil {
 				return err
 			}
 			user := args[0]
+			scopes, _ := cmd.Flags().GetStringSlice("scope")
+			for _, scope := range scopes {
+				if err := http.ValidateScope(scope); err != nil {
+					return err
+				}
+			}
 
-			cmd.Println(fmt.Sprintf("Generating API token for user %s, valid for %d days...", user, daysValid))
+			if len(scopes) > 0 {
+				cmd.Println(fmt.Sprintf("Generating API token for user %s with scopes %s, valid for %d days...", user, strings.Join(scopes, ", "), daysValid))
+			} else {
+				cmd.Println(fmt.Sprintf("Generating API token for user %s, valid for %d days...", user, daysValid))
+			}
 
 			instance, err := cryptoCmd.LoadCryptoModule(cmd)
 			if err != nil {
@@ -81,10 +101,14 @@ func createTokenCommand() *cobra.Command {
 					return err
 				}
 			}
-			token, err := instance.SignJWT(map[string]interface{}{
+			claims := map[string]interface{}{
 				jwt.SubjectKey:    user,
 				jwt.ExpirationKey: time.Now().AddDate(0, 0, daysValid),
-			}, http.AdminTokenSigningKID)
+			}
+			if len(scopes) > 0 {
+				claims[http.ScopeClaim] = strings.Join(scopes, " ")
+			}
+			token, err := instance.SignJWT(claims, http.AdminTokenSigningKID)
 			if err != nil {
 				return err
 			}
@@ -99,4 +123,6 @@ func createTokenCommand() *cobra.Command {
 			return nil
 		},
 	}
+	result.Flags().StringSlice("scope", nil, "Scopes granted to the token (e.g. vdr:read,vcr:issue). When not set, the token grants access to all APIs.")
+	return result
 }
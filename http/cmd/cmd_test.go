@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"github.com/lestrrat-go/jwx/jwt"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/http"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	"os"
 	"regexp"
@@ -29,6 +30,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -67,3 +69,65 @@ func TestGenToken(t *testing.T) {
 	assert.Greater(t, parsedToken.Expiration(), time.Now().AddDate(0, 0, daysValid-1))
 	assert.Equal(t, "admin", parsedToken.Subject())
 }
+
+func TestGenToken_Scopes(t *testing.T) {
+	testDirectory := io.TestDirectory(t)
+	os.Setenv("NUTS_DATADIR", testDirectory)
+	defer os.Unsetenv("NUTS_DATADIR")
+
+	newCmd := func(args ...string) (*cobra.Command, *bytes.Buffer) {
+		outBuf := new(bytes.Buffer)
+		cmd := ServerCmd()
+		cmd.Commands()[0].Flags().AddFlagSet(core.FlagSet())
+		cmd.Commands()[0].Flags().AddFlagSet(FlagSet())
+		cmd.SetOut(outBuf)
+		cmd.SetArgs(append([]string{"gen-token", "admin", "1"}, args...))
+		return cmd, outBuf
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		cmd, outBuf := newCmd("--scope", "vdr:read,vcr:issue")
+
+		err := cmd.Execute()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		matches := regexp.MustCompile("Token:\n\n(.*)\n").FindStringSubmatch(outBuf.String())
+		if !assert.Len(t, matches, 2) {
+			return
+		}
+		parsedToken, err := jwt.Parse([]byte(matches[1]))
+		if !assert.NoError(t, err) {
+			return
+		}
+		scope, _ := parsedToken.Get(http.ScopeClaim)
+		assert.Equal(t, "vdr:read vcr:issue", scope)
+	})
+	t.Run("without scopes no claim is added", func(t *testing.T) {
+		cmd, outBuf := newCmd()
+
+		err := cmd.Execute()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		matches := regexp.MustCompile("Token:\n\n(.*)\n").FindStringSubmatch(outBuf.String())
+		if !assert.Len(t, matches, 2) {
+			return
+		}
+		parsedToken, err := jwt.Parse([]byte(matches[1]))
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, exists := parsedToken.Get(http.ScopeClaim)
+		assert.False(t, exists)
+	})
+	t.Run("invalid scope", func(t *testing.T) {
+		cmd, _ := newCmd("--scope", "vdr")
+
+		err := cmd.Execute()
+
+		assert.EqualError(t, err, "invalid scope (expected <api>:<access>, <api>:* or *): vdr")
+	})
+}
@@ -18,11 +18,19 @@
 
 package http
 
+import "time"
+
 // DefaultConfig returns the default configuration for the HTTP engine.
 func DefaultConfig() Config {
 	return Config{
 		InterfaceConfig: InterfaceConfig{
 			Address: ":1323",
+			Auth: AuthConfig{
+				OIDC: OIDCConfig{
+					UserClaim:       oidcDefaultUserClaim,
+					RefreshInterval: time.Hour,
+				},
+			},
 		},
 		AltBinds: map[string]InterfaceConfig{},
 	}
@@ -68,12 +76,32 @@ type AuthType string
 const (
 	// BearerTokenAuth specifies that a bearer token is required.
 	BearerTokenAuth AuthType = "token"
+	// OIDCAuth specifies that a bearer token issued by an external OpenID Connect identity provider is required.
+	OIDCAuth AuthType = "oidc"
 )
 
 // AuthConfig contains the configuration for authentication for an HTTP interface.
 type AuthConfig struct {
 	// Type specifies the type of authentication required for the interface.
 	Type AuthType `koanf:"type"`
+	// OIDC contains the configuration for OpenID Connect authentication, used when Type is OIDCAuth.
+	OIDC OIDCConfig `koanf:"oidc"`
+}
+
+// OIDCConfig contains the configuration for authenticating users with tokens issued by an external OpenID Connect identity provider.
+type OIDCConfig struct {
+	// Issuer specifies the identifier (URL) of the identity provider, which must match the iss claim of the tokens.
+	Issuer string `koanf:"issuer"`
+	// Audience specifies the value that must be present in the aud claim of the tokens.
+	Audience string `koanf:"audience"`
+	// JWKSURL specifies the URL of the identity provider's JWKS. If not set, it's discovered from the issuer's configuration.
+	JWKSURL string `koanf:"jwksurl"`
+	// UserClaim specifies the claim containing the name of the user, used for logging.
+	UserClaim string `koanf:"userclaim"`
+	// RefreshInterval specifies how often the JWKS is refreshed. If not set, the JWKS response's cache headers are used.
+	RefreshInterval time.Duration `koanf:"refreshinterval"`
+	// AllowUnscoped specifies whether tokens without scope claim grant access to all APIs. If not set, they don't grant access to any API.
+	AllowUnscoped bool `koanf:"allowunscoped"`
 }
 
 // CORSConfig contains configuration for Cross Origin Resource Sharing.
@@ -27,6 +27,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
 	cryptoEngine "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/http/log"
@@ -42,9 +43,11 @@ const AdminTokenSigningKID = "admin-token-signing-key"
 const moduleName = "HTTP"
 
 // New returns a new HTTP engine. The callback is called when an HTTP interface shuts down unexpectedly.
-func New(serverShutdownCb func(), signingKeyResolver cryptoEngine.KeyResolver) *Engine {
+// State-changing requests to the internal API are recorded using the given audit recorder, if not nil.
+func New(serverShutdownCb func(), signingKeyResolver cryptoEngine.KeyResolver, auditRecorder audit.Recorder) *Engine {
 	return &Engine{
 		signingKeyResolver: signingKeyResolver,
+		auditRecorder:      auditRecorder,
 		serverShutdownCb:   serverShutdownCb,
 		config:             DefaultConfig(),
 	}
@@ -54,8 +57,12 @@ func New(serverShutdownCb func(), signingKeyResolver cryptoEngine.KeyResolver) *
 type Engine struct {
 	server             *MultiEcho
 	signingKeyResolver cryptoEngine.KeyResolver
+	auditRecorder      audit.Recorder
 	serverShutdownCb   func()
 	config             Config
+	// ctx is used for background processes (e.g. refreshing the JWKS of the OIDC identity provider), cancelled on shutdown.
+	ctx       context.Context
+	ctxCancel func()
 }
 
 // Router returns the router of the HTTP engine, which can be used by other engines to register HTTP handlers.
@@ -69,6 +76,7 @@ func (h *Engine) Configure(serverConfig core.ServerConfig) error {
 	// Echo returns "Bad Request (400)" by default, but we use this for incorrect use of API parameters.
 	// "Unauthorized (401)" is a better fit.
 	middleware.ErrJWTMissing = echo.NewHTTPError(http.StatusUnauthorized, "missing or malformed jwt")
+	h.ctx, h.ctxCancel = context.WithCancel(context.Background())
 
 	var tlsConfig *tls.Config
 	var err error
@@ -192,6 +200,9 @@ func (h *Engine) Start() error {
 
 // Shutdown shuts down the HTTP engine.
 func (h *Engine) Shutdown() error {
+	if h.ctxCancel != nil {
+		h.ctxCancel()
+	}
 	return h.server.Shutdown(context.Background())
 }
 
@@ -241,6 +252,9 @@ func (h Engine) applyGlobalMiddleware(echoServer core.EchoRouter, serverConfig c
 		return matchesPath(c.Request().RequestURI, "/metrics") || matchesPath(c.Request().RequestURI, "/status")
 	}
 	echoServer.Use(loggerMiddleware(loggerConfig{Skipper: skipper, logger: log.Logger()}))
+	if h.auditRecorder != nil {
+		echoServer.Use(auditMiddleware(h.auditRecorder))
+	}
 
 	// Always enabled in strict mode
 	if serverConfig.Strictmode || serverConfig.InternalRateLimiter {
@@ -293,7 +307,8 @@ func (h Engine) applyBindMiddleware(echoServer EchoServer, path string, excludeP
 	}
 
 	// Auth
-	if cfg.Auth.Type == BearerTokenAuth {
+	switch cfg.Auth.Type {
+	case BearerTokenAuth:
 		log.Logger().Infof("Enabling token authentication for HTTP interface: %s%s", address, path)
 		echoServer.Use(middleware.JWTWithConfig(middleware.JWTConfig{
 			KeyFunc: func(_ *jwt.Token) (interface{}, error) {
@@ -303,17 +318,37 @@ func (h Engine) applyBindMiddleware(echoServer EchoServer, path string, excludeP
 				}
 				return nil, err
 			},
-			Skipper: skipper,
-			SuccessHandler: func(c echo.Context) {
-				// Replace user in context, which now contains the validated JWT token, with the name of the user.
-				// This is easier for logging.
-				token := c.Get(core.UserContextKey).(*jwt.Token)
-				c.Set(core.UserContextKey, token.Claims.(jwt.MapClaims)["sub"])
-			},
-			ContextKey:    core.UserContextKey,
-			SigningMethod: jwa.ES256.String(),
+			Skipper:        skipper,
+			SuccessHandler: tokenSuccessHandler,
+			ContextKey:     core.UserContextKey,
+			SigningMethod:  jwa.ES256.String(),
 		}))
+		echoServer.Use(scopeMiddleware(skipper))
+	case OIDCAuth:
+		log.Logger().Infof("Enabling OIDC authentication (issuer: %s) for HTTP interface: %s%s", cfg.Auth.OIDC.Issuer, address, path)
+		verifier, err := newOIDCTokenVerifier(h.ctx, cfg.Auth.OIDC)
+		if err != nil {
+			return err
+		}
+		echoServer.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+			ParseTokenFunc: verifier.Parse,
+			Skipper:        skipper,
+			ContextKey:     core.UserContextKey,
+		}))
+		echoServer.Use(scopeMiddleware(skipper))
 	}
 
 	return nil
 }
+
+// tokenSuccessHandler is called when a request's API token is valid. It replaces the user in the context,
+// which contains the validated JWT token, with the name of the user. This is easier for logging.
+// If the token contains scopes, they're set in the context as well to be enforced by the scope middleware.
+func tokenSuccessHandler(c echo.Context) {
+	token := c.Get(core.UserContextKey).(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	c.Set(core.UserContextKey, claims["sub"])
+	if scope, ok := claims[ScopeClaim]; ok {
+		c.Set(scopesContextKey, parseScopeClaim(scope))
+	}
+}
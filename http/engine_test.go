@@ -47,7 +47,7 @@ func TestEngine_Configure(t *testing.T) {
 	noop := func() {}
 
 	t.Run("ok, no TLS (default)", func(t *testing.T) {
-		engine := New(noop, nil)
+		engine := New(noop, nil, nil)
 		engine.config.InterfaceConfig.Address = fmt.Sprintf(":%d", test.FreeTCPPort())
 		engine.config.InterfaceConfig.TLSMode = ""
 
@@ -67,7 +67,7 @@ func TestEngine_Configure(t *testing.T) {
 		assert.NoError(t, err)
 	})
 	t.Run("ok, no TLS (explicitly disabled)", func(t *testing.T) {
-		engine := New(noop, nil)
+		engine := New(noop, nil, nil)
 		engine.config.InterfaceConfig.Address = fmt.Sprintf(":%d", test.FreeTCPPort())
 		engine.config.InterfaceConfig.TLSMode = TLSDisabledMode
 
@@ -94,7 +94,7 @@ func TestEngine_Configure(t *testing.T) {
 		tlsConfig, _ := serverCfg.TLS.Load()
 
 		t.Run("error - invalid TLS mode", func(t *testing.T) {
-			engine := New(noop, nil)
+			engine := New(noop, nil, nil)
 			engine.config.InterfaceConfig.TLSMode = "oopsies"
 
 			err := engine.Configure(*core.NewServerConfig())
@@ -103,7 +103,7 @@ func TestEngine_Configure(t *testing.T) {
 		})
 
 		t.Run("error - TLS not configured (default interface)", func(t *testing.T) {
-			engine := New(noop, nil)
+			engine := New(noop, nil, nil)
 			engine.config.InterfaceConfig.TLSMode = TLSServerCertMode
 
 			err := engine.Configure(*core.NewServerConfig())
@@ -111,7 +111,7 @@ func TestEngine_Configure(t *testing.T) {
 			assert.EqualError(t, err, "TLS must be enabled (without offloading) to enable it on HTTP endpoints")
 		})
 		t.Run("error - TLS not configured (alt interface)", func(t *testing.T) {
-			engine := New(noop, nil)
+			engine := New(noop, nil, nil)
 			engine.config.AltBinds["alt"] = InterfaceConfig{
 				TLSMode: TLSServerCertMode,
 				Address: fmt.Sprintf(":%d", test.FreeTCPPort()),
@@ -122,7 +122,7 @@ func TestEngine_Configure(t *testing.T) {
 			assert.EqualError(t, err, "TLS must be enabled (without offloading) to enable it on HTTP endpoints")
 		})
 		t.Run("server certificate", func(t *testing.T) {
-			engine := New(noop, nil)
+			engine := New(noop, nil, nil)
 			engine.config.InterfaceConfig.Address = fmt.Sprintf(":%d", test.FreeTCPPort())
 			engine.config.InterfaceConfig.TLSMode = TLSServerCertMode
 
@@ -144,7 +144,7 @@ func TestEngine_Configure(t *testing.T) {
 			assert.NoError(t, err)
 		})
 		t.Run("server and client certificate", func(t *testing.T) {
-			engine := New(noop, nil)
+			engine := New(noop, nil, nil)
 			engine.config.InterfaceConfig.Address = fmt.Sprintf(":%d", test.FreeTCPPort())
 			engine.config.InterfaceConfig.TLSMode = TLServerClientCertMode
 
@@ -174,7 +174,7 @@ func TestEngine_Configure(t *testing.T) {
 	t.Run("middleware", func(t *testing.T) {
 		t.Run("CORS", func(t *testing.T) {
 			t.Run("enabled", func(t *testing.T) {
-				engine := New(noop, nil)
+				engine := New(noop, nil, nil)
 				engine.config.InterfaceConfig = InterfaceConfig{
 					Address: fmt.Sprintf(":%d", test.FreeTCPPort()),
 					CORS: CORSConfig{
@@ -198,7 +198,7 @@ func TestEngine_Configure(t *testing.T) {
 				assert.NoError(t, err)
 			})
 			t.Run("strict mode - wildcard not allowed", func(t *testing.T) {
-				engine := New(noop, nil)
+				engine := New(noop, nil, nil)
 				engine.config.InterfaceConfig.CORS.Origin = []string{"*"}
 
 				err := engine.Configure(core.TestServerConfig(core.ServerConfig{Strictmode: true}))
@@ -206,7 +206,7 @@ func TestEngine_Configure(t *testing.T) {
 				assert.EqualError(t, err, "wildcard CORS origin is not allowed in strict mode")
 			})
 			t.Run("non-strict mode - wildcard allowed", func(t *testing.T) {
-				engine := New(noop, nil)
+				engine := New(noop, nil, nil)
 				engine.config.InterfaceConfig.CORS.Origin = []string{"*"}
 
 				err := engine.Configure(*core.NewServerConfig())
@@ -215,7 +215,7 @@ func TestEngine_Configure(t *testing.T) {
 			})
 
 			t.Run("not enabled in alt bind", func(t *testing.T) {
-				engine := New(noop, nil)
+				engine := New(noop, nil, nil)
 				engine.config.InterfaceConfig = InterfaceConfig{
 					Address: fmt.Sprintf(":%d", test.FreeTCPPort()),
 					CORS: CORSConfig{
@@ -273,7 +273,7 @@ func TestEngine_Configure(t *testing.T) {
 				}, nil).AnyTimes()
 				defer ctrl.Finish()
 
-				engine := New(noop, keyResolver)
+				engine := New(noop, keyResolver, nil)
 				engine.config.InterfaceConfig = InterfaceConfig{
 					Address: fmt.Sprintf(":%d", test.FreeTCPPort()),
 				}
@@ -379,7 +379,7 @@ func TestEngine_ApplyGlobalMiddleware(t *testing.T) {
 
 	t.Run("global middleware not applied to /status and /metrics", func(t *testing.T) {
 		log.Logger()
-		engine := New(noop, nil)
+		engine := New(noop, nil, nil)
 		engine.config.InterfaceConfig.Address = fmt.Sprintf("localhost:%d", test.FreeTCPPort())
 
 		err := engine.Configure(*core.NewServerConfig())
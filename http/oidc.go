@@ -0,0 +1,197 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/http/log"
+)
+
+// oidcDiscoveryPath is the path (relative to the issuer) of the OpenID Provider configuration document.
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+// oidcForcedRefreshInterval is the minimum interval between refreshing the JWKS when a token is signed with an unknown key,
+// which happens when the identity provider rotates its keys. It prevents invalid tokens from triggering excessive requests.
+const oidcForcedRefreshInterval = time.Minute
+
+// oidcDefaultUserClaim is the claim used as user name when none is configured.
+const oidcDefaultUserClaim = "sub"
+
+// oidcHTTPTimeout is the timeout for requests to the identity provider.
+const oidcHTTPTimeout = 10 * time.Second
+
+// oidcTokenVerifier verifies access tokens issued by an external OpenID Connect identity provider.
+// The identity provider's signing keys are read from its JWKS, which is cached and periodically refreshed.
+type oidcTokenVerifier struct {
+	config     OIDCConfig
+	httpClient *http.Client
+	keySets    *jwk.AutoRefresh
+	// mux guards jwksURL and lastForcedRefresh
+	mux               sync.Mutex
+	jwksURL           string
+	lastForcedRefresh time.Time
+}
+
+func newOIDCTokenVerifier(ctx context.Context, config OIDCConfig) (*oidcTokenVerifier, error) {
+	if config.Issuer == "" {
+		return nil, errors.New("OIDC authentication requires an issuer")
+	}
+	if config.Audience == "" {
+		return nil, errors.New("OIDC authentication requires an audience")
+	}
+	if config.UserClaim == "" {
+		config.UserClaim = oidcDefaultUserClaim
+	}
+	return &oidcTokenVerifier{
+		config:     config,
+		httpClient: &http.Client{Timeout: oidcHTTPTimeout},
+		keySets:    jwk.NewAutoRefresh(ctx),
+		jwksURL:    config.JWKSURL,
+	}, nil
+}
+
+// Parse parses and validates the given token, and returns the name of the user it was issued to (read from the configured claim).
+// It has the signature of echo's JWT middleware ParseTokenFunc, which sets the returned user in the context.
+func (v *oidcTokenVerifier) Parse(auth string, c echo.Context) (interface{}, error) {
+	ctx := c.Request().Context()
+	jwksURL, err := v.resolveJWKSURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keySet, err := v.keySets.Fetch(ctx, jwksURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch JWKS of OIDC issuer: %w", err)
+	}
+	keySet, err = v.refreshOnUnknownKey(ctx, jwksURL, keySet, auth)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseString(auth,
+		jwt.WithKeySet(keySet),
+		jwt.InferAlgorithmFromKey(true),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(v.config.Issuer),
+		jwt.WithAudience(v.config.Audience),
+		jwt.WithAcceptableSkew(5*time.Second),
+	)
+	if err != nil {
+		return nil, err
+	}
+	user, _ := token.Get(v.config.UserClaim)
+	userStr, _ := user.(string)
+	if userStr == "" {
+		return nil, fmt.Errorf("token does not contain user claim: %s", v.config.UserClaim)
+	}
+	// Like API tokens issued by the node, tokens with a scope claim are restricted to the granted scopes (see scopeMiddleware).
+	// Unlike those, tokens without scope claim don't grant any scope unless configured otherwise,
+	// since the identity provider might issue tokens for the audience to users that shouldn't have access.
+	if scope, ok := token.Get(ScopeClaim); ok {
+		c.Set(scopesContextKey, parseScopeClaim(scope))
+	} else if !v.config.AllowUnscoped {
+		c.Set(scopesContextKey, []string{})
+	}
+	return userStr, nil
+}
+
+// refreshOnUnknownKey refreshes the JWKS when the token is signed with a key (identified by kid) that isn't in the cached set,
+// which is the case when the identity provider rotated its keys.
+func (v *oidcTokenVerifier) refreshOnUnknownKey(ctx context.Context, jwksURL string, keySet jwk.Set, auth string) (jwk.Set, error) {
+	kid := tokenKeyID(auth)
+	if kid == "" {
+		return keySet, nil
+	}
+	if _, found := keySet.LookupKeyID(kid); found {
+		return keySet, nil
+	}
+	v.mux.Lock()
+	if time.Since(v.lastForcedRefresh) < oidcForcedRefreshInterval {
+		v.mux.Unlock()
+		return keySet, nil
+	}
+	v.lastForcedRefresh = time.Now()
+	v.mux.Unlock()
+	log.Logger().Infof("OIDC token signed with unknown key (kid=%s), refreshing JWKS", kid)
+	refreshed, err := v.keySets.Refresh(ctx, jwksURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to refresh JWKS of OIDC issuer: %w", err)
+	}
+	return refreshed, nil
+}
+
+// resolveJWKSURL returns the configured JWKS URL, or discovers it from the issuer's OpenID Provider configuration.
+// Discovery is done lazily (on first use), so the node can start when the identity provider is unavailable.
+func (v *oidcTokenVerifier) resolveJWKSURL(ctx context.Context) (string, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	if v.jwksURL == "" {
+		discoveryURL := strings.TrimSuffix(v.config.Issuer, "/") + oidcDiscoveryPath
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+		if err != nil {
+			return "", err
+		}
+		response, err := v.httpClient.Do(request)
+		if err != nil {
+			return "", fmt.Errorf("unable to discover OIDC issuer configuration: %w", err)
+		}
+		defer response.Body.Close()
+		if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+			return "", fmt.Errorf("unable to discover OIDC issuer configuration: %w", err)
+		}
+		var configuration struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err = json.NewDecoder(response.Body).Decode(&configuration); err != nil {
+			return "", fmt.Errorf("invalid OIDC issuer configuration: %w", err)
+		}
+		if configuration.JWKSURI == "" {
+			return "", errors.New("invalid OIDC issuer configuration: jwks_uri is missing")
+		}
+		v.jwksURL = configuration.JWKSURI
+	}
+	if !v.keySets.IsRegistered(v.jwksURL) {
+		options := []jwk.AutoRefreshOption{jwk.WithHTTPClient(v.httpClient), jwk.WithMinRefreshInterval(oidcForcedRefreshInterval)}
+		if v.config.RefreshInterval > 0 {
+			options = append(options, jwk.WithRefreshInterval(v.config.RefreshInterval))
+		}
+		v.keySets.Configure(v.jwksURL, options...)
+	}
+	return v.jwksURL, nil
+}
+
+// tokenKeyID returns the key ID (kid) from the header of the given token, or an empty string if it can't be read.
+func tokenKeyID(token string) string {
+	message, err := jws.ParseString(token)
+	if err != nil || len(message.Signatures()) == 0 {
+		return ""
+	}
+	return message.Signatures()[0].ProtectedHeaders().KeyID()
+}
@@ -0,0 +1,270 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockIdentityProvider is a minimal OpenID Connect identity provider, serving its configuration and JWKS.
+type mockIdentityProvider struct {
+	server        *httptest.Server
+	keys          jwk.Set
+	jwksRequests  int32
+	signingKey    jwk.Key
+	signingKeyKID string
+}
+
+func newMockIdentityProvider(t *testing.T) *mockIdentityProvider {
+	idp := &mockIdentityProvider{keys: jwk.NewSet()}
+	idp.rotateKey(t, "key-1")
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(writer http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(writer).Encode(map[string]string{
+			"issuer":   idp.server.URL,
+			"jwks_uri": idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(writer http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&idp.jwksRequests, 1)
+		_ = json.NewEncoder(writer).Encode(idp.keys)
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// rotateKey generates a new signing key, which replaces the current key in the JWKS.
+func (idp *mockIdentityProvider) rotateKey(t *testing.T, kid string) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	idp.signingKey, _ = jwk.New(privateKey)
+	_ = idp.signingKey.Set(jwk.KeyIDKey, kid)
+	publicKey, _ := jwk.New(privateKey.Public())
+	_ = publicKey.Set(jwk.KeyIDKey, kid)
+	idp.keys = jwk.NewSet()
+	idp.keys.Add(publicKey)
+	idp.signingKeyKID = kid
+}
+
+func (idp *mockIdentityProvider) issueToken(t *testing.T, claims map[string]interface{}) string {
+	token := jwt.New()
+	_ = token.Set(jwt.IssuerKey, idp.server.URL)
+	_ = token.Set(jwt.AudienceKey, "nuts-node")
+	_ = token.Set(jwt.SubjectKey, "user-123")
+	_ = token.Set(jwt.ExpirationKey, time.Now().Add(time.Hour))
+	_ = token.Set(ScopeClaim, "openid test:read")
+	for key, value := range claims {
+		if value == nil {
+			_ = token.Remove(key)
+		} else {
+			_ = token.Set(key, value)
+		}
+	}
+	signed, err := jwt.Sign(token, jwa.ES256, idp.signingKey)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return string(signed)
+}
+
+func Test_oidcTokenVerifier(t *testing.T) {
+	idp := newMockIdentityProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newServer := func(t *testing.T, config OIDCConfig) *echo.Echo {
+		verifier, err := newOIDCTokenVerifier(ctx, config)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		e := echo.New()
+		e.HTTPErrorHandler = core.CreateHTTPErrorHandler()
+		e.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+			ParseTokenFunc: verifier.Parse,
+			ContextKey:     core.UserContextKey,
+		}))
+		e.Use(scopeMiddleware(func(_ echo.Context) bool {
+			return false
+		}))
+		e.GET("/internal/test", func(c echo.Context) error {
+			return c.String(http.StatusOK, c.Get(core.UserContextKey).(string))
+		})
+		return e
+	}
+	doRequest := func(e *echo.Echo, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/internal/test", nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		response := httptest.NewRecorder()
+		e.ServeHTTP(response, request)
+		return response
+	}
+	defaultConfig := func() OIDCConfig {
+		return OIDCConfig{Issuer: idp.server.URL, Audience: "nuts-node"}
+	}
+
+	t.Run("ok - JWKS discovered from issuer", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, nil))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "user-123", response.Body.String())
+	})
+	t.Run("ok - configured JWKS URL and user claim", func(t *testing.T) {
+		config := defaultConfig()
+		config.JWKSURL = idp.server.URL + "/jwks"
+		config.UserClaim = "preferred_username"
+		e := newServer(t, config)
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{"preferred_username": "jdoe"}))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "jdoe", response.Body.String())
+	})
+	t.Run("ok - scope granted", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{ScopeClaim: "openid test:read"}))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("token without scope claim doesn't grant any scope", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{ScopeClaim: nil}))
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+	t.Run("ok - token without scope claim grants all scopes when allowed", func(t *testing.T) {
+		config := defaultConfig()
+		config.AllowUnscoped = true
+		e := newServer(t, config)
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{ScopeClaim: nil}))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("insufficient scope", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{ScopeClaim: []string{"openid", "vdr:read"}}))
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Contains(t, response.Body.String(), "insufficient scope, operation requires: test:read")
+	})
+	t.Run("JWKS is cached", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+		token := idp.issueToken(t, nil)
+		doRequest(e, token)
+		requests := atomic.LoadInt32(&idp.jwksRequests)
+
+		response := doRequest(e, token)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, requests, atomic.LoadInt32(&idp.jwksRequests))
+	})
+	t.Run("JWKS is refreshed when the identity provider rotated its key", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+		doRequest(e, idp.issueToken(t, nil))
+		idp.rotateKey(t, "key-2")
+
+		response := doRequest(e, idp.issueToken(t, nil))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("invalid audience", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{jwt.AudienceKey: "other"}))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("invalid issuer", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{jwt.IssuerKey: "https://example.com"}))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("expired token", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+
+		response := doRequest(e, idp.issueToken(t, map[string]interface{}{jwt.ExpirationKey: time.Now().Add(-time.Minute)}))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("signed with unknown key", func(t *testing.T) {
+		e := newServer(t, defaultConfig())
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		otherKey, _ := jwk.New(privateKey)
+		_ = otherKey.Set(jwk.KeyIDKey, idp.signingKeyKID)
+		token := jwt.New()
+		_ = token.Set(jwt.IssuerKey, idp.server.URL)
+		_ = token.Set(jwt.AudienceKey, "nuts-node")
+		_ = token.Set(jwt.SubjectKey, "user-123")
+		signed, _ := jwt.Sign(token, jwa.ES256, otherKey)
+
+		response := doRequest(e, string(signed))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("missing user claim", func(t *testing.T) {
+		config := defaultConfig()
+		config.UserClaim = "preferred_username"
+		e := newServer(t, config)
+
+		response := doRequest(e, idp.issueToken(t, nil))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("identity provider unavailable", func(t *testing.T) {
+		config := defaultConfig()
+		config.Issuer = "http://localhost:1"
+		e := newServer(t, config)
+
+		response := doRequest(e, idp.issueToken(t, nil))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := newOIDCTokenVerifier(ctx, OIDCConfig{Audience: "nuts-node"})
+		assert.EqualError(t, err, "OIDC authentication requires an issuer")
+		_, err = newOIDCTokenVerifier(ctx, OIDCConfig{Issuer: idp.server.URL})
+		assert.EqualError(t, err, "OIDC authentication requires an audience")
+	})
+}
@@ -47,26 +47,29 @@ func loggerMiddleware(config loggerConfig) echo.MiddlewareFunc {
 			err = next(c)
 			req := c.Request()
 			res := c.Response()
-
-			status := res.Status
-			if err != nil {
-				switch errWithStatus := err.(type) {
-				case *echo.HTTPError:
-					status = errWithStatus.Code
-				case core.HTTPStatusCodeError:
-					status = errWithStatus.StatusCode()
-				default:
-					status = http.StatusInternalServerError
-				}
-			}
-
 			config.logger.WithFields(logrus.Fields{
 				"remote_ip": c.RealIP(),
 				"method":    req.Method,
 				"uri":       req.RequestURI,
-				"status":    status,
+				"status":    responseStatus(res, err),
 			}).Info("HTTP request")
 			return
 		}
 	}
 }
+
+// responseStatus returns the HTTP status code of the response, taking into account the error returned by the handler,
+// since it hasn't been written to the response yet.
+func responseStatus(res *echo.Response, err error) int {
+	if err == nil {
+		return res.Status
+	}
+	switch errWithStatus := err.(type) {
+	case *echo.HTTPError:
+		return errWithStatus.Code
+	case core.HTTPStatusCodeError:
+		return errWithStatus.StatusCode()
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// ScopeClaim is the name of the claim in the API token that contains the space-separated scopes granted to the token.
+// API tokens issued by the node without this claim are granted access to all APIs. Tokens issued by an OpenID Connect
+// identity provider without this claim aren't granted any scope, unless OIDCConfig.AllowUnscoped is set.
+const ScopeClaim = "scope"
+
+// scopesContextKey is the key of the echo context property containing the scopes granted to the API token.
+const scopesContextKey = "scopes"
+
+// scopeWildcard grants all scopes, or all scopes of an API when used as "<api>:*".
+const scopeWildcard = "*"
+
+var scopePattern = regexp.MustCompile(`^(\*|[a-z0-9-]+:(\*|[a-z0-9-]+))$`)
+
+// routeScope specifies the scopes required to invoke an API operation.
+type routeScope struct {
+	method string
+	path   string
+	scopes []string
+}
+
+// routeScopes lists the API operations that require other scopes than the one derived from their path and method
+// (see requiredScopes). Sensitive operations get their own scope, operations that sign data with the node's keys
+// (e.g. creating a presentation) require crypto:sign in addition to the write scope of their API,
+// and operations that only read data using a POST request (e.g. searching) require the read scope.
+var routeScopes = []routeScope{
+	{http.MethodPost, "/internal/crypto/v1/sign_jwt", []string{"crypto:sign"}},
+	{http.MethodPost, "/internal/vcr/v2/issuer/vc", []string{"vcr:issue"}},
+	{http.MethodDelete, "/internal/vcr/v2/issuer/vc/:id", []string{"vcr:issue"}},
+	{http.MethodPost, "/internal/vcr/v2/holder/vp", []string{"vcr:write", "crypto:sign"}},
+	{http.MethodPost, "/internal/vcr/v2/verifier/trust", []string{"vcr:trust"}},
+	{http.MethodDelete, "/internal/vcr/v2/verifier/trust", []string{"vcr:trust"}},
+	{http.MethodPost, "/internal/vcr/v2/verifier/trust/list", []string{"vcr:trust"}},
+	{http.MethodPost, "/internal/vcr/v2/verifier/trust/policy", []string{"vcr:trust"}},
+	{http.MethodDelete, "/internal/vcr/v2/verifier/trust/policy/:id", []string{"vcr:trust"}},
+	{http.MethodPost, "/internal/vcr/v2/search", []string{"vcr:read"}},
+	{http.MethodPost, "/internal/vcr/v2/verifier/vc", []string{"vcr:read"}},
+	{http.MethodPost, "/internal/vcr/v2/verifier/vp", []string{"vcr:read"}},
+	{http.MethodPost, "/internal/auth/v1/jwt-grant", []string{"auth:write", "crypto:sign"}},
+	{http.MethodPost, "/internal/auth/v1/request-access-token", []string{"auth:write", "crypto:sign"}},
+	{http.MethodPost, "/internal/auth/v1/accesstoken/introspect", []string{"auth:read"}},
+	{http.MethodPut, "/internal/auth/v1/signature/verify", []string{"auth:read"}},
+}
+
+// ValidateScope checks whether the given scope is well-formed: "<api>:<access>", "<api>:*" or "*".
+func ValidateScope(scope string) error {
+	if !scopePattern.MatchString(scope) {
+		return fmt.Errorf("invalid scope (expected <api>:<access>, <api>:* or *): %s", scope)
+	}
+	return nil
+}
+
+// requiredScopes returns the scopes required to invoke the operation at the given route path (e.g. /internal/vdr/v1/did/:did)
+// and request path. Unless listed in routeScopes, the scope is derived from the API (the first path segment after
+// /internal, or the first segment for other paths) and the method: "<api>:read" for reading, "<api>:write" otherwise.
+// It returns an empty slice if no scope is required.
+func requiredScopes(method string, routePath string, requestPath string) []string {
+	for _, curr := range routeScopes {
+		if curr.method == method && curr.path == routePath {
+			return curr.scopes
+		}
+	}
+	segments := strings.Split(strings.Trim(requestPath, "/"), "/")
+	api := segments[0]
+	if api == "internal" && len(segments) > 1 {
+		api = segments[1]
+	}
+	if api == "" {
+		return []string{}
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return []string{api + ":read"}
+	default:
+		return []string{api + ":write"}
+	}
+}
+
+// isReadOperation returns whether the operation at the given route path and request path only reads data,
+// which is the case when it only requires read scopes.
+func isReadOperation(method string, routePath string, requestPath string) bool {
+	required := requiredScopes(method, routePath, requestPath)
+	for _, scope := range required {
+		if !strings.HasSuffix(scope, ":read") {
+			return false
+		}
+	}
+	return len(required) > 0
+}
+
+// parseScopeClaim returns the scopes contained in the given scope claim: either a space-separated string,
+// or a list of strings (as used by some identity providers).
+func parseScopeClaim(claim interface{}) []string {
+	switch scopes := claim.(type) {
+	case string:
+		return strings.Fields(scopes)
+	case []interface{}:
+		result := make([]string, 0, len(scopes))
+		for _, scope := range scopes {
+			if scopeStr, ok := scope.(string); ok {
+				result = append(result, scopeStr)
+			}
+		}
+		return result
+	case []string:
+		return scopes
+	default:
+		return []string{}
+	}
+}
+
+// hasScope checks whether the required scope is granted, either directly or through a wildcard.
+func hasScope(granted []string, required string) bool {
+	api := strings.Split(required, ":")[0]
+	for _, scope := range granted {
+		if scope == required || scope == scopeWildcard || scope == api+":"+scopeWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeMiddleware rejects requests of which the API token doesn't grant the scopes required by the invoked operation.
+// It must be applied after the token authentication middleware, which sets the granted scopes.
+func scopeMiddleware(skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+			granted, ok := c.Get(scopesContextKey).([]string)
+			if !ok {
+				// Token without scopes, which grants access to all APIs
+				return next(c)
+			}
+			required := requiredScopes(c.Request().Method, c.Path(), c.Request().URL.Path)
+			for _, scope := range required {
+				if !hasScope(granted, scope) {
+					return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("insufficient scope, operation requires: %s", strings.Join(required, " ")))
+				}
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScope(t *testing.T) {
+	for _, scope := range []string{"vdr:read", "vcr:issue", "crypto:sign", "network-v2:write", "vdr:*", "*"} {
+		assert.NoError(t, ValidateScope(scope), scope)
+	}
+	for _, scope := range []string{"", "vdr", "vdr:", ":read", "VDR:read", "vdr:read:all", "vdr read", "*:read"} {
+		assert.Error(t, ValidateScope(scope), scope)
+	}
+}
+
+func Test_requiredScopes(t *testing.T) {
+	testCases := []struct {
+		method    string
+		routePath string
+		path      string
+		expected  []string
+	}{
+		{http.MethodGet, "/internal/vdr/v1/did/:did", "/internal/vdr/v1/did/did:nuts:123", []string{"vdr:read"}},
+		{http.MethodPut, "/internal/vdr/v1/did/:did", "/internal/vdr/v1/did/did:nuts:123", []string{"vdr:write"}},
+		{http.MethodPost, "/internal/crypto/v1/sign_jwt", "/internal/crypto/v1/sign_jwt", []string{"crypto:sign"}},
+		{http.MethodPost, "/internal/vcr/v2/issuer/vc", "/internal/vcr/v2/issuer/vc", []string{"vcr:issue"}},
+		{http.MethodPost, "/internal/vcr/v2/holder/vp", "/internal/vcr/v2/holder/vp", []string{"vcr:write", "crypto:sign"}},
+		{http.MethodPost, "/internal/auth/v1/jwt-grant", "/internal/auth/v1/jwt-grant", []string{"auth:write", "crypto:sign"}},
+		{http.MethodPost, "/internal/auth/v1/request-access-token", "/internal/auth/v1/request-access-token", []string{"auth:write", "crypto:sign"}},
+		{http.MethodGet, "/internal/vcr/v2/issuer/vc/search", "/internal/vcr/v2/issuer/vc/search", []string{"vcr:read"}},
+		{http.MethodDelete, "/internal/vcr/v2/verifier/trust", "/internal/vcr/v2/verifier/trust", []string{"vcr:trust"}},
+		{http.MethodPost, "/internal/vcr/v2/search", "/internal/vcr/v2/search", []string{"vcr:read"}},
+		{http.MethodGet, "/status", "/status", []string{"status:read"}},
+		{http.MethodGet, "/", "/", []string{}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.method+" "+testCase.path, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, requiredScopes(testCase.method, testCase.routePath, testCase.path))
+		})
+	}
+}
+
+func Test_isReadOperation(t *testing.T) {
+	assert.True(t, isReadOperation(http.MethodGet, "/internal/vdr/v1/did/:did", "/internal/vdr/v1/did/did:nuts:123"))
+	assert.True(t, isReadOperation(http.MethodPost, "/internal/vcr/v2/verifier/vp", "/internal/vcr/v2/verifier/vp"))
+	assert.False(t, isReadOperation(http.MethodPost, "/internal/vdr/v1/did", "/internal/vdr/v1/did"))
+	assert.False(t, isReadOperation(http.MethodPost, "/internal/vcr/v2/holder/vp", "/internal/vcr/v2/holder/vp"))
+	assert.False(t, isReadOperation(http.MethodGet, "/", "/"))
+}
+
+func Test_parseScopeClaim(t *testing.T) {
+	assert.Equal(t, []string{"vdr:read", "vcr:read"}, parseScopeClaim(" vdr:read  vcr:read"))
+	assert.Equal(t, []string{"vdr:read", "vcr:read"}, parseScopeClaim([]interface{}{"vdr:read", 1, "vcr:read"}))
+	assert.Equal(t, []string{}, parseScopeClaim(1))
+}
+
+func Test_hasScope(t *testing.T) {
+	assert.True(t, hasScope([]string{"vdr:read"}, "vdr:read"))
+	assert.True(t, hasScope([]string{"vcr:issue", "vdr:*"}, "vdr:write"))
+	assert.True(t, hasScope([]string{"*"}, "crypto:sign"))
+	assert.False(t, hasScope([]string{"vdr:read"}, "vdr:write"))
+	assert.False(t, hasScope([]string{"vdr:*"}, "vcr:read"))
+	assert.False(t, hasScope(nil, "vdr:read"))
+}
+
+func Test_scopeMiddleware(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = core.CreateHTTPErrorHandler()
+	// Stub for the authentication middleware, which calls the token success handler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims := jwt.MapClaims{"sub": "admin"}
+			if scope := c.Request().Header.Get("X-Scope"); scope != "" {
+				claims[ScopeClaim] = strings.TrimSpace(scope)
+			}
+			c.Set(core.UserContextKey, &jwt.Token{Claims: claims})
+			tokenSuccessHandler(c)
+			return next(c)
+		}
+	})
+	e.Use(scopeMiddleware(func(c echo.Context) bool {
+		return strings.HasPrefix(c.Request().URL.Path, "/public")
+	}))
+	handler := func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}
+	e.GET("/internal/vdr/v1/did/:did", handler)
+	e.PUT("/internal/vdr/v1/did/:did", handler)
+	e.POST("/internal/crypto/v1/sign_jwt", handler)
+	e.POST("/internal/vcr/v2/holder/vp", handler)
+	e.POST("/public/test", handler)
+
+	doRequest := func(method string, path string, scope string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		request.Header.Set("X-Scope", scope)
+		response := httptest.NewRecorder()
+		e.ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("token without scopes has full access", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/internal/crypto/v1/sign_jwt", "")
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("scope granted", func(t *testing.T) {
+		response := doRequest(http.MethodGet, "/internal/vdr/v1/did/did:nuts:123", "vcr:read vdr:read")
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("scope granted through wildcard", func(t *testing.T) {
+		response := doRequest(http.MethodPut, "/internal/vdr/v1/did/did:nuts:123", "vdr:*")
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("insufficient scope", func(t *testing.T) {
+		response := doRequest(http.MethodPut, "/internal/vdr/v1/did/did:nuts:123", "vdr:read")
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Contains(t, response.Body.String(), "insufficient scope, operation requires: vdr:write")
+	})
+	t.Run("operation with specific scope", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/internal/crypto/v1/sign_jwt", "crypto:write")
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Contains(t, response.Body.String(), "crypto:sign")
+	})
+	t.Run("operation requiring multiple scopes", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/internal/vcr/v2/holder/vp", "vcr:write")
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Contains(t, response.Body.String(), "insufficient scope, operation requires: vcr:write crypto:sign")
+
+		response = doRequest(http.MethodPost, "/internal/vcr/v2/holder/vp", "vcr:write crypto:sign")
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("skipped path", func(t *testing.T) {
+		response := doRequest(http.MethodPost, "/public/test", "vdr:read")
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
+
+func Test_tokenSuccessHandler(t *testing.T) {
+	t.Run("empty scope claim grants no scopes", func(t *testing.T) {
+		ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+		ctx.Set(core.UserContextKey, &jwt.Token{Claims: jwt.MapClaims{"sub": "admin", ScopeClaim: ""}})
+
+		tokenSuccessHandler(ctx)
+
+		assert.Equal(t, "admin", ctx.Get(core.UserContextKey))
+		assert.Equal(t, []string{}, ctx.Get(scopesContextKey))
+	})
+}
@@ -19,6 +19,8 @@
 package v1
 
 import (
+	"errors"
+	"net"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -29,13 +31,29 @@ import (
 	"github.com/nuts-foundation/nuts-node/network/transport"
 )
 
+var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
+
 // Wrapper implements the ServerInterface for the network API.
 type Wrapper struct {
 	Service network.Transactions
 }
 
+// ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
+func (a *Wrapper) ResolveStatusCode(err error) int {
+	return core.ResolveStatusCode(err, map[error]int{
+		transport.ErrPeerNotFound:     http.StatusNotFound,
+		transport.ErrPeerRuleNotFound: http.StatusNotFound,
+		transport.ErrPeerNotAllowed:   http.StatusBadRequest,
+		dag.ErrDAGNotEmpty:            http.StatusConflict,
+		dag.ErrImportInProgress:       http.StatusConflict,
+		dag.ErrInvalidSnapshot:        http.StatusBadRequest,
+		dag.ErrEventNotFound:          http.StatusNotFound,
+	})
+}
+
 // Preprocess is called just before the API operation itself is invoked.
 func (a *Wrapper) Preprocess(operationID string, context echo.Context) {
+	context.Set(core.StatusCodeResolverContextKey, a)
 	context.Set(core.OperationIDContextKey, operationID)
 	context.Set(core.ModuleNameContextKey, network.ModuleName)
 }
@@ -95,6 +113,24 @@ func (a Wrapper) GetTransactionPayload(ctx echo.Context, hashAsString string) er
 	return err
 }
 
+// ExportSnapshot streams a snapshot of the DAG to the client
+func (a Wrapper) ExportSnapshot(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderContentType, "application/octet-stream")
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="dag-snapshot.gz"`)
+	ctx.Response().WriteHeader(http.StatusOK)
+	_, err := a.Service.ExportSnapshot(ctx.Request().Context(), ctx.Response())
+	return err
+}
+
+// ImportSnapshot imports a snapshot of the DAG supplied by the client
+func (a Wrapper) ImportSnapshot(ctx echo.Context) error {
+	count, err := a.Service.ImportSnapshot(ctx.Request().Context(), ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, ImportSnapshotResult{Transactions: count})
+}
+
 // GetPeerDiagnostics returns the diagnostics of the node's peers
 func (a Wrapper) GetPeerDiagnostics(ctx echo.Context) error {
 	diagnostics := a.Service.PeerDiagnostics()
@@ -135,21 +171,245 @@ func (a Wrapper) ListEvents(ctx echo.Context) error {
 			return err
 		}
 		for _, event := range events {
-			eventError := event.Error
-			eventType := event.Type
-			eventSubscriber.Events = append(eventSubscriber.Events, Event{
-				Error:       &eventError,
-				Hash:        event.Hash.String(),
-				Retries:     event.Retries,
-				Transaction: event.Transaction.Ref().String(),
-				Type:        &eventType,
-			})
+			eventSubscriber.Events = append(eventSubscriber.Events, toEvent(event))
 		}
 		response = append(response, eventSubscriber)
 	}
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// ListSubscribers lists the subscribers of DAG events with their counters
+func (a Wrapper) ListSubscribers(ctx echo.Context) error {
+	response := make([]Subscriber, 0)
+	for _, notifier := range a.Service.Subscribers() {
+		stats, err := notifier.Stats()
+		if err != nil {
+			return err
+		}
+		response = append(response, Subscriber{
+			Name:       notifier.Name(),
+			Persistent: stats.Persistent,
+			Pending:    stats.Pending,
+			Failed:     stats.Failed,
+			Notified:   int(stats.Notified),
+			Finished:   int(stats.Finished),
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetSubscriberEvent returns an event of a subscriber that hasn't finished yet
+func (a Wrapper) GetSubscriberEvent(ctx echo.Context, name string, ref string) error {
+	_, event, err := a.resolveSubscriberEvent(name, ref)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, toEvent(*event))
+}
+
+// SkipSubscriberEvent marks an event of a subscriber as finished without processing it
+func (a Wrapper) SkipSubscriberEvent(ctx echo.Context, name string, ref string) error {
+	notifier, event, err := a.resolveSubscriberEvent(name, ref)
+	if err != nil {
+		return err
+	}
+	if err = notifier.Finished(event.Hash); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// RetrySubscriberEvent notifies the subscriber of the event again
+func (a Wrapper) RetrySubscriberEvent(ctx echo.Context, name string, ref string) error {
+	notifier, event, err := a.resolveSubscriberEvent(name, ref)
+	if err != nil {
+		return err
+	}
+	result, err := retryEvents(notifier, []hash2.SHA256Hash{event.Hash})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// RetryFailedEvents notifies the subscriber of all its failed events again
+func (a Wrapper) RetryFailedEvents(ctx echo.Context, name string) error {
+	notifier := a.findSubscriber(name)
+	if notifier == nil {
+		return core.NotFoundError("subscriber not found: %s", name)
+	}
+	events, err := notifier.GetFailedEvents()
+	if err != nil {
+		return err
+	}
+	hashes := make([]hash2.SHA256Hash, len(events))
+	for i, event := range events {
+		hashes[i] = event.Hash
+	}
+	result, err := retryEvents(notifier, hashes)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// retryEvents retries the given events of the notifier. Events that fail again are returned in the result with their error.
+func retryEvents(notifier dag.Notifier, hashes []hash2.SHA256Hash) (*RetryEventsResult, error) {
+	result := RetryEventsResult{Failed: make([]Event, 0)}
+	for _, hash := range hashes {
+		retryErr := notifier.Retry(hash)
+		if retryErr == nil {
+			result.Succeeded++
+			continue
+		}
+		if errors.Is(retryErr, dag.ErrEventNotFound) {
+			// finished in the meantime
+			continue
+		}
+		// read the event again, since the retry updated its error and number of retries
+		event, err := notifier.GetEvent(hash)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			result.Failed = append(result.Failed, toEvent(*event))
+		}
+	}
+	return &result, nil
+}
+
+// resolveSubscriberEvent looks up the subscriber and its event that hasn't finished yet.
+// It returns dag.ErrEventNotFound if the event doesn't exist.
+func (a Wrapper) resolveSubscriberEvent(name string, ref string) (dag.Notifier, *dag.Event, error) {
+	notifier := a.findSubscriber(name)
+	if notifier == nil {
+		return nil, nil, core.NotFoundError("subscriber not found: %s", name)
+	}
+	hash, err := parseHash(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	event, err := notifier.GetEvent(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if event == nil {
+		return nil, nil, dag.ErrEventNotFound
+	}
+	return notifier, event, nil
+}
+
+func (a Wrapper) findSubscriber(name string) dag.Notifier {
+	for _, notifier := range a.Service.Subscribers() {
+		if notifier.Name() == name {
+			return notifier
+		}
+	}
+	return nil
+}
+
+func toEvent(event dag.Event) Event {
+	eventError := event.Error
+	eventType := event.Type
+	return Event{
+		Error:       &eventError,
+		Hash:        event.Hash.String(),
+		Retries:     event.Retries,
+		Transaction: event.Transaction.Ref().String(),
+		Type:        &eventType,
+	}
+}
+
+// ListPeers lists the peers the node is connected to
+func (a Wrapper) ListPeers(ctx echo.Context) error {
+	results := make([]ConnectedPeer, 0)
+	for _, peer := range a.Service.Peers() {
+		result := ConnectedPeer{
+			Id:      peer.ID.String(),
+			Address: peer.Address,
+		}
+		if !peer.NodeDID.Empty() {
+			nodeDID := peer.NodeDID.String()
+			result.NodeDID = &nodeDID
+		}
+		if peer.CertificateSubject != "" {
+			certificateSubject := peer.CertificateSubject
+			result.CertificateSubject = &certificateSubject
+		}
+		results = append(results, result)
+	}
+	return ctx.JSON(http.StatusOK, results)
+}
+
+// AddPeer connects to a peer
+func (a Wrapper) AddPeer(ctx echo.Context) error {
+	var request AddPeerRequest
+	if err := ctx.Bind(&request); err != nil {
+		return core.InvalidInputError("invalid request: %w", err)
+	}
+	if _, _, err := net.SplitHostPort(request.Address); err != nil {
+		return core.InvalidInputError("invalid address (expected <host>:<port>): %w", err)
+	}
+	if err := a.Service.AddPeer(request.Address); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+// DisconnectPeer disconnects a peer
+func (a Wrapper) DisconnectPeer(ctx echo.Context, peerID string) error {
+	if err := a.Service.DisconnectPeer(transport.PeerID(peerID)); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListPeerRules lists the rules that determine which peers the node may connect to
+func (a Wrapper) ListPeerRules(ctx echo.Context) error {
+	results := a.Service.PeerRules()
+	if results == nil {
+		results = make([]PeerRule, 0)
+	}
+	return ctx.JSON(http.StatusOK, results)
+}
+
+// AddPeerRule adds a rule that allows or denies peers
+func (a Wrapper) AddPeerRule(ctx echo.Context) error {
+	var request AddPeerRuleRequest
+	if err := ctx.Bind(&request); err != nil {
+		return core.InvalidInputError("invalid request: %w", err)
+	}
+	rule := transport.PeerRule{Action: transport.PeerRuleAction(request.Action)}
+	if request.Address != nil {
+		rule.Address = *request.Address
+	}
+	if request.NodeDID != nil {
+		rule.NodeDID = *request.NodeDID
+	}
+	if request.CertificateSubject != nil {
+		rule.CertificateSubject = *request.CertificateSubject
+	}
+	if request.Reason != nil {
+		rule.Reason = *request.Reason
+	}
+	if err := rule.Validate(); err != nil {
+		return core.InvalidInputError("invalid peer rule: %w", err)
+	}
+	result, err := a.Service.AddPeerRule(rule)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// RemovePeerRule removes a peer rule
+func (a Wrapper) RemovePeerRule(ctx echo.Context, id string) error {
+	if err := a.Service.RemovePeerRule(id); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
 func toInt(v *int, def int64) int64 {
 	if v == nil {
 		return def
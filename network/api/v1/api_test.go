@@ -18,8 +18,11 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -27,6 +30,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/mock"
 	"github.com/nuts-foundation/nuts-node/network/transport"
@@ -389,6 +393,7 @@ func TestWrapper_Preprocess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	w := &Wrapper{}
 	ctx := mock.NewMockContext(ctrl)
+	ctx.EXPECT().Set(core.StatusCodeResolverContextKey, w)
 	ctx.EXPECT().Set(core.OperationIDContextKey, "foo")
 	ctx.EXPECT().Set(core.ModuleNameContextKey, "Network")
 
@@ -456,3 +461,431 @@ func TestWrapper_ListEvents(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestWrapper_ListPeers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNetwork := network.NewMockTransactions(ctrl)
+	ctx := mock.NewMockContext(ctrl)
+	w := &Wrapper{Service: mockNetwork}
+	nodeDID, _ := did.ParseDID("did:nuts:peer")
+	mockNetwork.EXPECT().Peers().Return([]transport.Peer{
+		{ID: "1", Address: "foo:5555", NodeDID: *nodeDID, CertificateSubject: "CN=foo"},
+		{ID: "2", Address: "bar:5555"},
+	})
+	var actual []ConnectedPeer
+	ctx.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(_ int, result interface{}) error {
+		actual = result.([]ConnectedPeer)
+		return nil
+	})
+
+	err := w.ListPeers(ctx)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, actual, 2) {
+		return
+	}
+	assert.Equal(t, "1", actual[0].Id)
+	assert.Equal(t, "foo:5555", actual[0].Address)
+	assert.Equal(t, "did:nuts:peer", *actual[0].NodeDID)
+	assert.Equal(t, "CN=foo", *actual[0].CertificateSubject)
+	assert.Nil(t, actual[1].NodeDID)
+	assert.Nil(t, actual[1].CertificateSubject)
+}
+
+func TestWrapper_AddPeer(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		ctx.EXPECT().Bind(gomock.Any()).DoAndReturn(func(target interface{}) error {
+			target.(*AddPeerRequest).Address = "foo:5555"
+			return nil
+		})
+		mockNetwork.EXPECT().AddPeer("foo:5555").Return(nil)
+		ctx.EXPECT().NoContent(http.StatusAccepted)
+
+		err := w.AddPeer(ctx)
+
+		assert.NoError(t, err)
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: network.NewMockTransactions(ctrl)}
+		ctx.EXPECT().Bind(gomock.Any()).DoAndReturn(func(target interface{}) error {
+			target.(*AddPeerRequest).Address = "foo"
+			return nil
+		})
+
+		err := w.AddPeer(ctx)
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+		assert.ErrorContains(t, err, "invalid address")
+	})
+	t.Run("peer not allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		ctx.EXPECT().Bind(gomock.Any()).DoAndReturn(func(target interface{}) error {
+			target.(*AddPeerRequest).Address = "foo:5555"
+			return nil
+		})
+		mockNetwork.EXPECT().AddPeer("foo:5555").Return(transport.ErrPeerNotAllowed)
+
+		err := w.AddPeer(ctx)
+
+		assert.ErrorIs(t, err, transport.ErrPeerNotAllowed)
+		assert.Equal(t, http.StatusBadRequest, w.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_DisconnectPeer(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		mockNetwork.EXPECT().DisconnectPeer(transport.PeerID("1")).Return(nil)
+		ctx.EXPECT().NoContent(http.StatusNoContent)
+
+		err := w.DisconnectPeer(ctx, "1")
+
+		assert.NoError(t, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		mockNetwork.EXPECT().DisconnectPeer(transport.PeerID("1")).Return(transport.ErrPeerNotFound)
+
+		err := w.DisconnectPeer(mock.NewMockContext(ctrl), "1")
+
+		assert.ErrorIs(t, err, transport.ErrPeerNotFound)
+		assert.Equal(t, http.StatusNotFound, w.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ListPeerRules(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		rules := []PeerRule{{ID: "1", Action: transport.DenyPeerAction, Address: "foo"}}
+		mockNetwork.EXPECT().PeerRules().Return(rules)
+		ctx.EXPECT().JSON(http.StatusOK, rules)
+
+		err := w.ListPeerRules(ctx)
+
+		assert.NoError(t, err)
+	})
+	t.Run("no rules", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		mockNetwork.EXPECT().PeerRules().Return(nil)
+		ctx.EXPECT().JSON(http.StatusOK, []PeerRule{})
+
+		err := w.ListPeerRules(ctx)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestWrapper_AddPeerRule(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		ctx.EXPECT().Bind(gomock.Any()).DoAndReturn(func(target interface{}) error {
+			nodeDID := "did:nuts:peer"
+			reason := "misbehaving"
+			*target.(*AddPeerRuleRequest) = AddPeerRuleRequest{Action: Deny, NodeDID: &nodeDID, Reason: &reason}
+			return nil
+		})
+		expected := transport.PeerRule{ID: "1", Action: transport.DenyPeerAction, NodeDID: "did:nuts:peer", Reason: "misbehaving"}
+		mockNetwork.EXPECT().AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, NodeDID: "did:nuts:peer", Reason: "misbehaving"}).Return(&expected, nil)
+		ctx.EXPECT().JSON(http.StatusOK, &expected)
+
+		err := w.AddPeerRule(ctx)
+
+		assert.NoError(t, err)
+	})
+	t.Run("invalid rule", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: network.NewMockTransactions(ctrl)}
+		ctx.EXPECT().Bind(gomock.Any()).DoAndReturn(func(target interface{}) error {
+			*target.(*AddPeerRuleRequest) = AddPeerRuleRequest{Action: Allow}
+			return nil
+		})
+
+		err := w.AddPeerRule(ctx)
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+		assert.ErrorContains(t, err, "invalid peer rule")
+	})
+}
+
+func TestWrapper_RemovePeerRule(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		mockNetwork.EXPECT().RemovePeerRule("1").Return(nil)
+		ctx.EXPECT().NoContent(http.StatusNoContent)
+
+		err := w.RemovePeerRule(ctx, "1")
+
+		assert.NoError(t, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		mockNetwork.EXPECT().RemovePeerRule("1").Return(transport.ErrPeerRuleNotFound)
+
+		err := w.RemovePeerRule(mock.NewMockContext(ctrl), "1")
+
+		assert.Equal(t, http.StatusNotFound, w.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ExportSnapshot(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		var networkClient = network.NewMockTransactions(mockCtrl)
+		e, wrapper := initMockEcho(networkClient)
+		networkClient.EXPECT().ExportSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, writer io.Writer) (int, error) {
+			_, _ = writer.Write([]byte("snapshot"))
+			return 1, nil
+		})
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(echo.GET, "/", nil), rec)
+
+		err := wrapper.ExportSnapshot(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/octet-stream", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "snapshot", rec.Body.String())
+	})
+	t.Run("error", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		var networkClient = network.NewMockTransactions(mockCtrl)
+		e, wrapper := initMockEcho(networkClient)
+		networkClient.EXPECT().ExportSnapshot(gomock.Any(), gomock.Any()).Return(0, errors.New("failed"))
+
+		c := e.NewContext(httptest.NewRequest(echo.GET, "/", nil), httptest.NewRecorder())
+
+		err := wrapper.ExportSnapshot(c)
+
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestWrapper_ImportSnapshot(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		var networkClient = network.NewMockTransactions(mockCtrl)
+		e, wrapper := initMockEcho(networkClient)
+		networkClient.EXPECT().ImportSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, reader io.Reader) (int, error) {
+			data, _ := io.ReadAll(reader)
+			assert.Equal(t, "snapshot", string(data))
+			return 5, nil
+		})
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(echo.POST, "/", strings.NewReader("snapshot")), rec)
+
+		err := wrapper.ImportSnapshot(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"transactions": 5}`, rec.Body.String())
+	})
+	t.Run("DAG not empty", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		var networkClient = network.NewMockTransactions(mockCtrl)
+		e, wrapper := initMockEcho(networkClient)
+		networkClient.EXPECT().ImportSnapshot(gomock.Any(), gomock.Any()).Return(0, dag.ErrDAGNotEmpty)
+
+		c := e.NewContext(httptest.NewRequest(echo.POST, "/", strings.NewReader("snapshot")), httptest.NewRecorder())
+
+		err := wrapper.ImportSnapshot(c)
+
+		assert.ErrorIs(t, err, dag.ErrDAGNotEmpty)
+		assert.Equal(t, http.StatusConflict, (&Wrapper{}).ResolveStatusCode(err))
+	})
+	t.Run("invalid snapshot", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		var networkClient = network.NewMockTransactions(mockCtrl)
+		e, wrapper := initMockEcho(networkClient)
+		networkClient.EXPECT().ImportSnapshot(gomock.Any(), gomock.Any()).Return(0, fmt.Errorf("%w: broken", dag.ErrInvalidSnapshot))
+
+		c := e.NewContext(httptest.NewRequest(echo.POST, "/", strings.NewReader("snapshot")), httptest.NewRecorder())
+
+		err := wrapper.ImportSnapshot(c)
+
+		assert.ErrorIs(t, err, dag.ErrInvalidSnapshot)
+		assert.Equal(t, http.StatusBadRequest, (&Wrapper{}).ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ListSubscribers(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		ctx := mock.NewMockContext(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		subscriberMock := dag.NewMockNotifier(ctrl)
+		mockNetwork.EXPECT().Subscribers().Return([]dag.Notifier{subscriberMock})
+		subscriberMock.EXPECT().Name().Return("test")
+		subscriberMock.EXPECT().Stats().Return(dag.NotifierStats{Persistent: true, Pending: 3, Failed: 1, Notified: 10, Finished: 7}, nil)
+		ctx.EXPECT().JSON(http.StatusOK, []Subscriber{{Name: "test", Persistent: true, Pending: 3, Failed: 1, Notified: 10, Finished: 7}})
+
+		err := w.ListSubscribers(ctx)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		w := &Wrapper{Service: mockNetwork}
+		subscriberMock := dag.NewMockNotifier(ctrl)
+		mockNetwork.EXPECT().Subscribers().Return([]dag.Notifier{subscriberMock})
+		subscriberMock.EXPECT().Stats().Return(dag.NotifierStats{}, errors.New("failed"))
+
+		err := w.ListSubscribers(mock.NewMockContext(ctrl))
+
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestWrapper_SubscriberEvents(t *testing.T) {
+	tx, _, _ := dag.CreateTestTransaction(0)
+	failedEvent := dag.Event{
+		Error:       "error",
+		Hash:        tx.Ref(),
+		Retries:     10,
+		Transaction: tx,
+		Type:        dag.TransactionEventType,
+	}
+	ref := tx.Ref().String()
+	setup := func(t *testing.T) (*Wrapper, *dag.MockNotifier, *mock.MockContext) {
+		ctrl := gomock.NewController(t)
+		mockNetwork := network.NewMockTransactions(ctrl)
+		subscriberMock := dag.NewMockNotifier(ctrl)
+		mockNetwork.EXPECT().Subscribers().Return([]dag.Notifier{subscriberMock})
+		subscriberMock.EXPECT().Name().Return("test").AnyTimes()
+		return &Wrapper{Service: mockNetwork}, subscriberMock, mock.NewMockContext(ctrl)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(&failedEvent, nil)
+		ctx.EXPECT().JSON(http.StatusOK, toEvent(failedEvent))
+
+		err := w.GetSubscriberEvent(ctx, "test", ref)
+
+		assert.NoError(t, err)
+	})
+	t.Run("get - subscriber not found", func(t *testing.T) {
+		w, _, ctx := setup(t)
+
+		err := w.GetSubscriberEvent(ctx, "other", ref)
+
+		assert.EqualError(t, err, "subscriber not found: other")
+		assert.Equal(t, http.StatusNotFound, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("get - event not found", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(nil, nil)
+
+		err := w.GetSubscriberEvent(ctx, "test", ref)
+
+		assert.ErrorIs(t, err, dag.ErrEventNotFound)
+		assert.Equal(t, http.StatusNotFound, w.ResolveStatusCode(err))
+	})
+	t.Run("get - invalid hash", func(t *testing.T) {
+		w, _, ctx := setup(t)
+
+		err := w.GetSubscriberEvent(ctx, "test", "1234")
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("skip", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(&failedEvent, nil)
+		subscriberMock.EXPECT().Finished(tx.Ref()).Return(nil)
+		ctx.EXPECT().NoContent(http.StatusNoContent)
+
+		err := w.SkipSubscriberEvent(ctx, "test", ref)
+
+		assert.NoError(t, err)
+	})
+	t.Run("skip - event not found", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(nil, nil)
+
+		err := w.SkipSubscriberEvent(ctx, "test", ref)
+
+		assert.ErrorIs(t, err, dag.ErrEventNotFound)
+	})
+	t.Run("retry - succeeded", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(&failedEvent, nil)
+		subscriberMock.EXPECT().Retry(tx.Ref()).Return(nil)
+		ctx.EXPECT().JSON(http.StatusOK, &RetryEventsResult{Succeeded: 1, Failed: []Event{}})
+
+		err := w.RetrySubscriberEvent(ctx, "test", ref)
+
+		assert.NoError(t, err)
+	})
+	t.Run("retry - failed again", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		retriedEvent := failedEvent
+		retriedEvent.Retries = 11
+		retriedEvent.Error = "failed again"
+		gomock.InOrder(
+			subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(&failedEvent, nil),
+			subscriberMock.EXPECT().Retry(tx.Ref()).Return(errors.New("failed again")),
+			subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(&retriedEvent, nil),
+		)
+		ctx.EXPECT().JSON(http.StatusOK, &RetryEventsResult{Succeeded: 0, Failed: []Event{toEvent(retriedEvent)}})
+
+		err := w.RetrySubscriberEvent(ctx, "test", ref)
+
+		assert.NoError(t, err)
+	})
+	t.Run("retry all", func(t *testing.T) {
+		w, subscriberMock, ctx := setup(t)
+		tx2, _, _ := dag.CreateTestTransaction(1)
+		finishedEvent := dag.Event{Hash: tx2.Ref(), Transaction: tx2, Retries: 10}
+		subscriberMock.EXPECT().GetFailedEvents().Return([]dag.Event{failedEvent, finishedEvent}, nil)
+		subscriberMock.EXPECT().Retry(tx.Ref()).Return(errors.New("failed again"))
+		subscriberMock.EXPECT().GetEvent(tx.Ref()).Return(&failedEvent, nil)
+		// finished in the meantime
+		subscriberMock.EXPECT().Retry(tx2.Ref()).Return(dag.ErrEventNotFound)
+		ctx.EXPECT().JSON(http.StatusOK, &RetryEventsResult{Succeeded: 0, Failed: []Event{toEvent(failedEvent)}})
+
+		err := w.RetryFailedEvents(ctx, "test")
+
+		assert.NoError(t, err)
+	})
+	t.Run("retry all - subscriber not found", func(t *testing.T) {
+		w, _, ctx := setup(t)
+
+		err := w.RetryFailedEvents(ctx, "other")
+
+		assert.EqualError(t, err, "subscriber not found: other")
+	})
+}
@@ -125,6 +125,160 @@ func (hb HTTPClient) Reprocess(contentType string) error {
 	return nil
 }
 
+// ListPeers returns the peers the node is connected to.
+func (hb HTTPClient) ListPeers() ([]ConnectedPeer, error) {
+	response, err := hb.client().ListPeers(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ConnectedPeer, 0)
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AddPeer instructs the node to connect to the peer at the given address.
+func (hb HTTPClient) AddPeer(address string) error {
+	response, err := hb.client().AddPeer(context.Background(), AddPeerJSONRequestBody{Address: address})
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusAccepted, response)
+}
+
+// DisconnectPeer instructs the node to disconnect the peer with the given ID.
+func (hb HTTPClient) DisconnectPeer(peerID transport.PeerID) error {
+	response, err := hb.client().DisconnectPeer(context.Background(), peerID.String())
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// ListPeerRules returns the rules that determine which peers the node may connect to.
+func (hb HTTPClient) ListPeerRules() ([]PeerRule, error) {
+	response, err := hb.client().ListPeerRules(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PeerRule, 0)
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AddPeerRule adds a rule that allows or denies peers.
+func (hb HTTPClient) AddPeerRule(request AddPeerRuleRequest) (*PeerRule, error) {
+	response, err := hb.client().AddPeerRule(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	var result PeerRule
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemovePeerRule removes the peer rule with the given ID.
+func (hb HTTPClient) RemovePeerRule(id string) error {
+	response, err := hb.client().RemovePeerRule(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// ExportSnapshot downloads a snapshot of the DAG and writes it to the given writer.
+func (hb HTTPClient) ExportSnapshot(writer io.Writer) error {
+	response, err := hb.client().ExportSnapshot(context.Background())
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, response.Body)
+	return err
+}
+
+// ImportSnapshot uploads a snapshot of the DAG read from the given reader. It returns the number of imported transactions.
+func (hb HTTPClient) ImportSnapshot(reader io.Reader) (int, error) {
+	response, err := hb.client().ImportSnapshotWithBody(context.Background(), "application/octet-stream", reader)
+	if err != nil {
+		return 0, err
+	}
+	var result ImportSnapshotResult
+	if err = readJSON(response, &result); err != nil {
+		return 0, err
+	}
+	return result.Transactions, nil
+}
+
+// ListSubscribers returns the subscribers of DAG events with their counters.
+func (hb HTTPClient) ListSubscribers() ([]Subscriber, error) {
+	response, err := hb.client().ListSubscribers(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Subscriber, 0)
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSubscriberEvent returns the event of a subscriber that hasn't finished yet.
+func (hb HTTPClient) GetSubscriberEvent(name string, ref hash.SHA256Hash) (*Event, error) {
+	response, err := hb.client().GetSubscriberEvent(context.Background(), name, ref.String())
+	if err != nil {
+		return nil, err
+	}
+	var result Event
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RetrySubscriberEvent notifies the subscriber of the event again.
+func (hb HTTPClient) RetrySubscriberEvent(name string, ref hash.SHA256Hash) (*RetryEventsResult, error) {
+	response, err := hb.client().RetrySubscriberEvent(context.Background(), name, ref.String())
+	if err != nil {
+		return nil, err
+	}
+	var result RetryEventsResult
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RetryFailedEvents notifies the subscriber of all its failed events again.
+func (hb HTTPClient) RetryFailedEvents(name string) (*RetryEventsResult, error) {
+	response, err := hb.client().RetryFailedEvents(context.Background(), name)
+	if err != nil {
+		return nil, err
+	}
+	var result RetryEventsResult
+	if err = readJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SkipSubscriberEvent marks the event of a subscriber as finished without processing it.
+func (hb HTTPClient) SkipSubscriberEvent(name string, ref hash.SHA256Hash) error {
+	response, err := hb.client().SkipSubscriberEvent(context.Background(), name, ref.String())
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
 func (hb HTTPClient) client() ClientInterface {
 	response, err := NewClientWithResponses(hb.GetAddress(), WithHTTPClient(core.MustCreateHTTPClient(hb.ClientConfig)))
 	if err != nil {
@@ -146,3 +300,14 @@ func testAndParseTransactionResponse(response *http.Response) (dag.Transaction,
 	}
 	return dag.ParseTransaction(responseData)
 }
+
+func readJSON(response *http.Response, target interface{}) error {
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
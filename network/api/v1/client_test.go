@@ -19,10 +19,12 @@
 package v1
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/nuts-foundation/nuts-node/core"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -133,6 +135,213 @@ func TestHTTPClient_Reprocess(t *testing.T) {
 	})
 }
 
+func TestHTTPClient_ListPeers(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		expected := []ConnectedPeer{{Id: "1", Address: "foo:5555"}}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).ListPeers()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("server error (500)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusInternalServerError})
+		actual, err := getClient(s).ListPeers()
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_AddPeer(t *testing.T) {
+	t.Run("202", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusAccepted})
+		err := getClient(s).AddPeer("foo:5555")
+		assert.NoError(t, err)
+	})
+	t.Run("bad request (400)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusBadRequest})
+		err := getClient(s).AddPeer("foo")
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_DisconnectPeer(t *testing.T) {
+	t.Run("204", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNoContent})
+		err := getClient(s).DisconnectPeer("1")
+		assert.NoError(t, err)
+	})
+	t.Run("not found (404)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNotFound})
+		err := getClient(s).DisconnectPeer("1")
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_ListPeerRules(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		expected := []PeerRule{{ID: "1", Action: transport.DenyPeerAction, Address: "foo"}}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).ListPeerRules()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("server error (500)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusInternalServerError})
+		actual, err := getClient(s).ListPeerRules()
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_AddPeerRule(t *testing.T) {
+	address := "foo"
+	t.Run("200", func(t *testing.T) {
+		expected := PeerRule{ID: "1", Action: transport.DenyPeerAction, Address: address}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).AddPeerRule(AddPeerRuleRequest{Action: Deny, Address: &address})
+		assert.NoError(t, err)
+		assert.Equal(t, &expected, actual)
+	})
+	t.Run("bad request (400)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusBadRequest})
+		actual, err := getClient(s).AddPeerRule(AddPeerRuleRequest{Action: Deny})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_RemovePeerRule(t *testing.T) {
+	t.Run("204", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNoContent})
+		err := getClient(s).RemovePeerRule("1")
+		assert.NoError(t, err)
+	})
+	t.Run("not found (404)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNotFound})
+		err := getClient(s).RemovePeerRule("1")
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_ExportSnapshot(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: []byte("snapshot")})
+		buf := &bytes.Buffer{}
+		err := getClient(s).ExportSnapshot(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "snapshot", buf.String())
+	})
+	t.Run("server error (500)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusInternalServerError})
+		buf := &bytes.Buffer{}
+		err := getClient(s).ExportSnapshot(buf)
+		assert.Error(t, err)
+		assert.Empty(t, buf.Bytes())
+	})
+}
+
+func TestHTTPClient_ImportSnapshot(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: []byte(`{"transactions": 5}`)})
+		count, err := getClient(s).ImportSnapshot(strings.NewReader("snapshot"))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, count)
+	})
+	t.Run("conflict (409)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusConflict})
+		count, err := getClient(s).ImportSnapshot(strings.NewReader("snapshot"))
+		assert.Error(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestHTTPClient_ListSubscribers(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		expected := []Subscriber{{Name: "vdr", Persistent: true, Pending: 1, Failed: 1, Notified: 20, Finished: 19}}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).ListSubscribers()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("server error (500)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusInternalServerError})
+		actual, err := getClient(s).ListSubscribers()
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_GetSubscriberEvent(t *testing.T) {
+	ref := hash.SHA256Sum([]byte{1})
+	t.Run("200", func(t *testing.T) {
+		expected := Event{Hash: ref.String(), Transaction: ref.String(), Retries: 10}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).GetSubscriberEvent("vdr", ref)
+		assert.NoError(t, err)
+		assert.Equal(t, &expected, actual)
+	})
+	t.Run("not found (404)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNotFound})
+		actual, err := getClient(s).GetSubscriberEvent("vdr", ref)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_RetrySubscriberEvent(t *testing.T) {
+	ref := hash.SHA256Sum([]byte{1})
+	t.Run("200", func(t *testing.T) {
+		expected := RetryEventsResult{Succeeded: 1, Failed: []Event{}}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).RetrySubscriberEvent("vdr", ref)
+		assert.NoError(t, err)
+		assert.Equal(t, &expected, actual)
+	})
+	t.Run("not found (404)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNotFound})
+		actual, err := getClient(s).RetrySubscriberEvent("vdr", ref)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_RetryFailedEvents(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		expected := RetryEventsResult{Succeeded: 2, Failed: []Event{}}
+		expectedData, _ := json.Marshal(expected)
+		s := httptest.NewServer(handler{statusCode: http.StatusOK, responseData: expectedData})
+		actual, err := getClient(s).RetryFailedEvents("vdr")
+		assert.NoError(t, err)
+		assert.Equal(t, &expected, actual)
+	})
+	t.Run("not found (404)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNotFound})
+		actual, err := getClient(s).RetryFailedEvents("vdr")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestHTTPClient_SkipSubscriberEvent(t *testing.T) {
+	ref := hash.SHA256Sum([]byte{1})
+	t.Run("204", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNoContent})
+		err := getClient(s).SkipSubscriberEvent("vdr", ref)
+		assert.NoError(t, err)
+	})
+	t.Run("not found (404)", func(t *testing.T) {
+		s := httptest.NewServer(handler{statusCode: http.StatusNotFound})
+		err := getClient(s).SkipSubscriberEvent("vdr", ref)
+		assert.Error(t, err)
+	})
+}
+
 func getClient(s *httptest.Server) HTTPClient {
 	return HTTPClient{
 		ClientConfig: core.ClientConfig{
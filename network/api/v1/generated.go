@@ -4,9 +4,11 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -20,6 +22,54 @@ const (
 	JwtBearerAuthScopes = "jwtBearerAuth.Scopes"
 )
 
+// Defines values for AddPeerRuleRequestAction.
+const (
+	Allow AddPeerRuleRequestAction = "allow"
+	Deny  AddPeerRuleRequestAction = "deny"
+)
+
+// AddPeerRequest defines model for AddPeerRequest.
+type AddPeerRequest struct {
+	// Address of the peer, in the format <host>:<port>.
+	Address string `json:"address"`
+}
+
+// A rule that allows or denies peers. At least one of address, nodeDID and certificateSubject must be specified. A peer must match all specified criteria.
+type AddPeerRuleRequest struct {
+	// Whether matching peers are allowed or denied.
+	Action AddPeerRuleRequestAction `json:"action"`
+
+	// Address of the peer. If it doesn't contain a port, it matches any port on the host. Inbound peers are matched on host only, since they connect from an ephemeral port. Host names aren't resolved, so use an IP address to match inbound peers.
+	Address *string `json:"address,omitempty"`
+
+	// Subject (distinguished name) of the peer's TLS certificate.
+	CertificateSubject *string `json:"certificateSubject,omitempty"`
+
+	// Node DID of the peer. Peers that don't send their node DID never match, so to reliably deny a peer use its address or certificate subject.
+	NodeDID *string `json:"nodeDID,omitempty"`
+
+	// Description of why the rule is added.
+	Reason *string `json:"reason,omitempty"`
+}
+
+// Whether matching peers are allowed or denied.
+type AddPeerRuleRequestAction string
+
+// A peer the node is connected to.
+type ConnectedPeer struct {
+	// Address of the peer.
+	Address string `json:"address"`
+
+	// Subject of the peer's TLS certificate, if TLS is enabled.
+	CertificateSubject *string `json:"certificateSubject,omitempty"`
+
+	// ID of the peer.
+	Id string `json:"id"`
+
+	// The peer's node DID, if it was authenticated.
+	NodeDID *string `json:"nodeDID,omitempty"`
+}
+
 // Non-completed event. An event represents a transaction that is of interest to a specific part of the Nuts node.
 type Event struct {
 	// Lists the last error if the event processing failed due to an error.
@@ -46,6 +96,42 @@ type EventSubscriber struct {
 	Name string `json:"name"`
 }
 
+// ImportSnapshotResult defines model for ImportSnapshotResult.
+type ImportSnapshotResult struct {
+	// Number of transactions that were imported.
+	Transactions int `json:"transactions"`
+}
+
+// Result of retrying events
+type RetryEventsResult struct {
+	// Events that failed again, with their error.
+	Failed []Event `json:"failed"`
+
+	// Number of events that were processed successfully.
+	Succeeded int `json:"succeeded"`
+}
+
+// Subscriber of DAG events, with its counters
+type Subscriber struct {
+	// Number of events that failed, because the subscriber failed to process them too many times.
+	Failed int `json:"failed"`
+
+	// Number of events that finished since the node started.
+	Finished int `json:"finished"`
+
+	// Name of the subscriber
+	Name string `json:"name"`
+
+	// Number of times the subscriber was notified since the node started, including retries.
+	Notified int `json:"notified"`
+
+	// Number of events that haven't finished yet, including failed events.
+	Pending int `json:"pending"`
+
+	// Whether the events of the subscriber are stored. Only events of persistent subscribers can be inspected, retried and skipped.
+	Persistent bool `json:"persistent"`
+}
+
 // RenderGraphParams defines parameters for RenderGraph.
 type RenderGraphParams struct {
 	// Lamport Clock value from where to start rendering (inclusive). If omitted, rendering starts at the root.
@@ -55,12 +141,24 @@ type RenderGraphParams struct {
 	End *int `form:"end,omitempty" json:"end,omitempty"`
 }
 
+// AddPeerRuleJSONBody defines parameters for AddPeerRule.
+type AddPeerRuleJSONBody = AddPeerRuleRequest
+
+// AddPeerJSONBody defines parameters for AddPeer.
+type AddPeerJSONBody = AddPeerRequest
+
 // ReprocessParams defines parameters for Reprocess.
 type ReprocessParams struct {
 	// the transaction content-type that must be reprocessed
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 }
 
+// AddPeerRuleJSONRequestBody defines body for AddPeerRule for application/json ContentType.
+type AddPeerRuleJSONRequestBody = AddPeerRuleJSONBody
+
+// AddPeerJSONRequestBody defines body for AddPeer for application/json ContentType.
+type AddPeerJSONRequestBody = AddPeerJSONBody
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -143,9 +241,52 @@ type ClientInterface interface {
 	// ListEvents request
 	ListEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListPeerRules request
+	ListPeerRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPeerRule request with any body
+	AddPeerRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddPeerRule(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemovePeerRule request
+	RemovePeerRule(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPeers request
+	ListPeers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPeer request with any body
+	AddPeerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddPeer(ctx context.Context, body AddPeerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DisconnectPeer request
+	DisconnectPeer(ctx context.Context, peerID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Reprocess request
 	Reprocess(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ExportSnapshot request
+	ExportSnapshot(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportSnapshot request with any body
+	ImportSnapshotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSubscribers request
+	ListSubscribers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RetryFailedEvents request
+	RetryFailedEvents(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SkipSubscriberEvent request
+	SkipSubscriberEvent(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSubscriberEvent request
+	GetSubscriberEvent(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RetrySubscriberEvent request
+	RetrySubscriberEvent(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListTransactions request
 	ListTransactions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -192,6 +333,102 @@ func (c *Client) ListEvents(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListPeerRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPeerRulesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPeerRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPeerRuleRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPeerRule(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPeerRuleRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemovePeerRule(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemovePeerRuleRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListPeers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPeersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPeerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPeerRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPeer(ctx context.Context, body AddPeerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPeerRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DisconnectPeer(ctx context.Context, peerID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDisconnectPeerRequest(c.Server, peerID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Reprocess(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewReprocessRequest(c.Server, params)
 	if err != nil {
@@ -204,6 +441,90 @@ func (c *Client) Reprocess(ctx context.Context, params *ReprocessParams, reqEdit
 	return c.Client.Do(req)
 }
 
+func (c *Client) ExportSnapshot(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportSnapshotRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportSnapshotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportSnapshotRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSubscribers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSubscribersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RetryFailedEvents(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRetryFailedEventsRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SkipSubscriberEvent(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSkipSubscriberEventRequest(c.Server, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscriberEvent(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscriberEventRequest(c.Server, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RetrySubscriberEvent(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRetrySubscriberEventRequest(c.Server, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListTransactions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListTransactionsRequest(c.Server)
 	if err != nil {
@@ -357,8 +678,8 @@ func NewListEventsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewReprocessRequest generates requests for Reprocess
-func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request, error) {
+// NewListPeerRulesRequest generates requests for ListPeerRules
+func NewListPeerRulesRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -366,7 +687,7 @@ func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/network/v1/reprocess")
+	operationPath := fmt.Sprintf("/internal/network/v1/peerrules")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -376,36 +697,27 @@ func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request,
 		return nil, err
 	}
 
-	queryValues := queryURL.Query()
-
-	if params.Type != nil {
-
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
-
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL.RawQuery = queryValues.Encode()
+	return req, nil
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+// NewAddPeerRuleRequest calls the generic AddPeerRule builder with application/json body
+func NewAddPeerRuleRequest(server string, body AddPeerRuleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPeerRuleRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewListTransactionsRequest generates requests for ListTransactions
-func NewListTransactionsRequest(server string) (*http.Request, error) {
+// NewAddPeerRuleRequestWithBody generates requests for AddPeerRule with any type of body
+func NewAddPeerRuleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -413,7 +725,7 @@ func NewListTransactionsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/network/v1/transaction")
+	operationPath := fmt.Sprintf("/internal/network/v1/peerrules")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -423,21 +735,23 @@ func NewListTransactionsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetTransactionRequest generates requests for GetTransaction
-func NewGetTransactionRequest(server string, ref string) (*http.Request, error) {
+// NewRemovePeerRuleRequest generates requests for RemovePeerRule
+func NewRemovePeerRuleRequest(server string, id string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
 	if err != nil {
 		return nil, err
 	}
@@ -447,7 +761,7 @@ func NewGetTransactionRequest(server string, ref string) (*http.Request, error)
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/network/v1/transaction/%s", pathParam0)
+	operationPath := fmt.Sprintf("/internal/network/v1/peerrules/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -457,7 +771,7 @@ func NewGetTransactionRequest(server string, ref string) (*http.Request, error)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -465,23 +779,16 @@ func NewGetTransactionRequest(server string, ref string) (*http.Request, error)
 	return req, nil
 }
 
-// NewGetTransactionPayloadRequest generates requests for GetTransactionPayload
-func NewGetTransactionPayloadRequest(server string, ref string) (*http.Request, error) {
+// NewListPeersRequest generates requests for ListPeers
+func NewListPeersRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/network/v1/transaction/%s/payload", pathParam0)
+	operationPath := fmt.Sprintf("/internal/network/v1/peers")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -499,320 +806,1495 @@ func NewGetTransactionPayloadRequest(server string, ref string) (*http.Request,
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewAddPeerRequest calls the generic AddPeer builder with application/json body
+func NewAddPeerRequest(server string, body AddPeerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPeerRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPeerRequestWithBody generates requests for AddPeer with any type of body
+func NewAddPeerRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/peers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDisconnectPeerRequest generates requests for DisconnectPeer
+func NewDisconnectPeerRequest(server string, peerID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "peerID", runtime.ParamLocationPath, peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/peers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReprocessRequest generates requests for Reprocess
+func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/reprocess")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Type != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportSnapshotRequest generates requests for ExportSnapshot
+func NewExportSnapshotRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/snapshot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportSnapshotRequestWithBody generates requests for ImportSnapshot with any type of body
+func NewImportSnapshotRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/snapshot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListSubscribersRequest generates requests for ListSubscribers
+func NewListSubscribersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/subscribers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRetryFailedEventsRequest generates requests for RetryFailedEvents
+func NewRetryFailedEventsRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/subscribers/%s/events/retry", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSkipSubscriberEventRequest generates requests for SkipSubscriberEvent
+func NewSkipSubscriberEventRequest(server string, name string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/subscribers/%s/events/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSubscriberEventRequest generates requests for GetSubscriberEvent
+func NewGetSubscriberEventRequest(server string, name string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/subscribers/%s/events/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRetrySubscriberEventRequest generates requests for RetrySubscriberEvent
+func NewRetrySubscriberEventRequest(server string, name string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/subscribers/%s/events/%s/retry", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTransactionsRequest generates requests for ListTransactions
+func NewListTransactionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/transaction")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionRequest generates requests for GetTransaction
+func NewGetTransactionRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/transaction/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionPayloadRequest generates requests for GetTransactionPayload
+func NewGetTransactionPayloadRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/transaction/%s/payload", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
 			return err
 		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// RenderGraph request
+	RenderGraphWithResponse(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*RenderGraphResponse, error)
+
+	// GetPeerDiagnostics request
+	GetPeerDiagnosticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPeerDiagnosticsResponse, error)
+
+	// ListEvents request
+	ListEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEventsResponse, error)
+
+	// ListPeerRules request
+	ListPeerRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeerRulesResponse, error)
+
+	// AddPeerRule request with any body
+	AddPeerRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error)
+
+	AddPeerRuleWithResponse(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error)
+
+	// RemovePeerRule request
+	RemovePeerRuleWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RemovePeerRuleResponse, error)
+
+	// ListPeers request
+	ListPeersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeersResponse, error)
+
+	// AddPeer request with any body
+	AddPeerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPeerResponse, error)
+
+	AddPeerWithResponse(ctx context.Context, body AddPeerJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPeerResponse, error)
+
+	// DisconnectPeer request
+	DisconnectPeerWithResponse(ctx context.Context, peerID string, reqEditors ...RequestEditorFn) (*DisconnectPeerResponse, error)
+
+	// Reprocess request
+	ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error)
+
+	// ExportSnapshot request
+	ExportSnapshotWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ExportSnapshotResponse, error)
+
+	// ImportSnapshot request with any body
+	ImportSnapshotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportSnapshotResponse, error)
+
+	// ListSubscribers request
+	ListSubscribersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSubscribersResponse, error)
+
+	// RetryFailedEvents request
+	RetryFailedEventsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*RetryFailedEventsResponse, error)
+
+	// SkipSubscriberEvent request
+	SkipSubscriberEventWithResponse(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*SkipSubscriberEventResponse, error)
+
+	// GetSubscriberEvent request
+	GetSubscriberEventWithResponse(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*GetSubscriberEventResponse, error)
+
+	// RetrySubscriberEvent request
+	RetrySubscriberEventWithResponse(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*RetrySubscriberEventResponse, error)
+
+	// ListTransactions request
+	ListTransactionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error)
+
+	// GetTransaction request
+	GetTransactionWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error)
+
+	// GetTransactionPayload request
+	GetTransactionPayloadWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionPayloadResponse, error)
+}
+
+type RenderGraphResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RenderGraphResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RenderGraphResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPeerDiagnosticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		AdditionalProperties map[string]PeerDiagnostics `json:"-"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPeerDiagnosticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPeerDiagnosticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]EventSubscriber
+}
+
+// Status returns HTTPResponse.Status
+func (r ListEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPeerRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PeerRule
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPeerRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPeerRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPeerRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PeerRule
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPeerRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPeerRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemovePeerRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RemovePeerRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemovePeerRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPeersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ConnectedPeer
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPeersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPeersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPeerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPeerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPeerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DisconnectPeerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DisconnectPeerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DisconnectPeerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprocessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprocessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprocessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportSnapshotResult
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSubscribersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Subscriber
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSubscribersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSubscribersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RetryFailedEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RetryEventsResult
+}
+
+// Status returns HTTPResponse.Status
+func (r RetryFailedEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RetryFailedEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SkipSubscriberEventResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SkipSubscriberEventResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SkipSubscriberEventResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscriberEventResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Event
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscriberEventResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscriberEventResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RetrySubscriberEventResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RetryEventsResult
+}
+
+// Status returns HTTPResponse.Status
+func (r RetrySubscriberEventResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RetrySubscriberEventResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]string
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionPayloadResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionPayloadResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionPayloadResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// RenderGraphWithResponse request returning *RenderGraphResponse
+func (c *ClientWithResponses) RenderGraphWithResponse(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*RenderGraphResponse, error) {
+	rsp, err := c.RenderGraph(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenderGraphResponse(rsp)
+}
+
+// GetPeerDiagnosticsWithResponse request returning *GetPeerDiagnosticsResponse
+func (c *ClientWithResponses) GetPeerDiagnosticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPeerDiagnosticsResponse, error) {
+	rsp, err := c.GetPeerDiagnostics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPeerDiagnosticsResponse(rsp)
+}
+
+// ListEventsWithResponse request returning *ListEventsResponse
+func (c *ClientWithResponses) ListEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEventsResponse, error) {
+	rsp, err := c.ListEvents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListEventsResponse(rsp)
+}
+
+// ListPeerRulesWithResponse request returning *ListPeerRulesResponse
+func (c *ClientWithResponses) ListPeerRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeerRulesResponse, error) {
+	rsp, err := c.ListPeerRules(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPeerRulesResponse(rsp)
+}
+
+// AddPeerRuleWithBodyWithResponse request with arbitrary body returning *AddPeerRuleResponse
+func (c *ClientWithResponses) AddPeerRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error) {
+	rsp, err := c.AddPeerRuleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPeerRuleResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPeerRuleWithResponse(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error) {
+	rsp, err := c.AddPeerRule(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPeerRuleResponse(rsp)
+}
+
+// RemovePeerRuleWithResponse request returning *RemovePeerRuleResponse
+func (c *ClientWithResponses) RemovePeerRuleWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RemovePeerRuleResponse, error) {
+	rsp, err := c.RemovePeerRule(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemovePeerRuleResponse(rsp)
+}
+
+// ListPeersWithResponse request returning *ListPeersResponse
+func (c *ClientWithResponses) ListPeersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeersResponse, error) {
+	rsp, err := c.ListPeers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPeersResponse(rsp)
+}
+
+// AddPeerWithBodyWithResponse request with arbitrary body returning *AddPeerResponse
+func (c *ClientWithResponses) AddPeerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPeerResponse, error) {
+	rsp, err := c.AddPeerWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPeerResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPeerWithResponse(ctx context.Context, body AddPeerJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPeerResponse, error) {
+	rsp, err := c.AddPeer(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPeerResponse(rsp)
+}
+
+// DisconnectPeerWithResponse request returning *DisconnectPeerResponse
+func (c *ClientWithResponses) DisconnectPeerWithResponse(ctx context.Context, peerID string, reqEditors ...RequestEditorFn) (*DisconnectPeerResponse, error) {
+	rsp, err := c.DisconnectPeer(ctx, peerID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDisconnectPeerResponse(rsp)
+}
+
+// ReprocessWithResponse request returning *ReprocessResponse
+func (c *ClientWithResponses) ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error) {
+	rsp, err := c.Reprocess(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessResponse(rsp)
+}
+
+// ExportSnapshotWithResponse request returning *ExportSnapshotResponse
+func (c *ClientWithResponses) ExportSnapshotWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ExportSnapshotResponse, error) {
+	rsp, err := c.ExportSnapshot(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportSnapshotResponse(rsp)
+}
+
+// ImportSnapshotWithBodyWithResponse request with arbitrary body returning *ImportSnapshotResponse
+func (c *ClientWithResponses) ImportSnapshotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportSnapshotResponse, error) {
+	rsp, err := c.ImportSnapshotWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportSnapshotResponse(rsp)
+}
+
+// ListSubscribersWithResponse request returning *ListSubscribersResponse
+func (c *ClientWithResponses) ListSubscribersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSubscribersResponse, error) {
+	rsp, err := c.ListSubscribers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSubscribersResponse(rsp)
+}
+
+// RetryFailedEventsWithResponse request returning *RetryFailedEventsResponse
+func (c *ClientWithResponses) RetryFailedEventsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*RetryFailedEventsResponse, error) {
+	rsp, err := c.RetryFailedEvents(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetryFailedEventsResponse(rsp)
+}
+
+// SkipSubscriberEventWithResponse request returning *SkipSubscriberEventResponse
+func (c *ClientWithResponses) SkipSubscriberEventWithResponse(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*SkipSubscriberEventResponse, error) {
+	rsp, err := c.SkipSubscriberEvent(ctx, name, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSkipSubscriberEventResponse(rsp)
+}
+
+// GetSubscriberEventWithResponse request returning *GetSubscriberEventResponse
+func (c *ClientWithResponses) GetSubscriberEventWithResponse(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*GetSubscriberEventResponse, error) {
+	rsp, err := c.GetSubscriberEvent(ctx, name, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscriberEventResponse(rsp)
+}
+
+// RetrySubscriberEventWithResponse request returning *RetrySubscriberEventResponse
+func (c *ClientWithResponses) RetrySubscriberEventWithResponse(ctx context.Context, name string, ref string, reqEditors ...RequestEditorFn) (*RetrySubscriberEventResponse, error) {
+	rsp, err := c.RetrySubscriberEvent(ctx, name, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetrySubscriberEventResponse(rsp)
+}
+
+// ListTransactionsWithResponse request returning *ListTransactionsResponse
+func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
+	rsp, err := c.ListTransactions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTransactionsResponse(rsp)
+}
+
+// GetTransactionWithResponse request returning *GetTransactionResponse
+func (c *ClientWithResponses) GetTransactionWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error) {
+	rsp, err := c.GetTransaction(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionResponse(rsp)
+}
+
+// GetTransactionPayloadWithResponse request returning *GetTransactionPayloadResponse
+func (c *ClientWithResponses) GetTransactionPayloadWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionPayloadResponse, error) {
+	rsp, err := c.GetTransactionPayload(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionPayloadResponse(rsp)
+}
+
+// ParseRenderGraphResponse parses an HTTP response from a RenderGraphWithResponse call
+func ParseRenderGraphResponse(rsp *http.Response) (*RenderGraphResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenderGraphResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetPeerDiagnosticsResponse parses an HTTP response from a GetPeerDiagnosticsWithResponse call
+func ParseGetPeerDiagnosticsResponse(rsp *http.Response) (*GetPeerDiagnosticsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPeerDiagnosticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			AdditionalProperties map[string]PeerDiagnostics `json:"-"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return response, nil
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+// ParseListEventsResponse parses an HTTP response from a ListEventsWithResponse call
+func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	response := &ListEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// RenderGraph request
-	RenderGraphWithResponse(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*RenderGraphResponse, error)
-
-	// GetPeerDiagnostics request
-	GetPeerDiagnosticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPeerDiagnosticsResponse, error)
-
-	// ListEvents request
-	ListEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEventsResponse, error)
-
-	// Reprocess request
-	ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error)
-
-	// ListTransactions request
-	ListTransactionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []EventSubscriber
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-	// GetTransaction request
-	GetTransactionWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error)
+	}
 
-	// GetTransactionPayload request
-	GetTransactionPayloadWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionPayloadResponse, error)
+	return response, nil
 }
 
-type RenderGraphResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+// ParseListPeerRulesResponse parses an HTTP response from a ListPeerRulesWithResponse call
+func ParseListPeerRulesResponse(rsp *http.Response) (*ListPeerRulesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r RenderGraphResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &ListPeerRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r RenderGraphResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PeerRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return 0
+
+	return response, nil
 }
 
-type GetPeerDiagnosticsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		AdditionalProperties map[string]PeerDiagnostics `json:"-"`
+// ParseAddPeerRuleResponse parses an HTTP response from a AddPeerRuleWithResponse call
+func ParseAddPeerRuleResponse(rsp *http.Response) (*AddPeerRuleResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-}
 
-// Status returns HTTPResponse.Status
-func (r GetPeerDiagnosticsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &AddPeerRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetPeerDiagnosticsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PeerRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return 0
-}
 
-type ListEventsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]EventSubscriber
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ListEventsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseRemovePeerRuleResponse parses an HTTP response from a RemovePeerRuleWithResponse call
+func ParseRemovePeerRuleResponse(rsp *http.Response) (*RemovePeerRuleResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListEventsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &RemovePeerRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type ReprocessResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ReprocessResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseListPeersResponse parses an HTTP response from a ListPeersWithResponse call
+func ParseListPeersResponse(rsp *http.Response) (*ListPeersResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ReprocessResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &ListPeersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type ListTransactionsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]string
-}
-
-// Status returns HTTPResponse.Status
-func (r ListTransactionsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ConnectedPeer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListTransactionsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type GetTransactionResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTransactionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseAddPeerResponse parses an HTTP response from a AddPeerWithResponse call
+func ParseAddPeerResponse(rsp *http.Response) (*AddPeerResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTransactionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &AddPeerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetTransactionPayloadResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTransactionPayloadResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseDisconnectPeerResponse parses an HTTP response from a DisconnectPeerWithResponse call
+func ParseDisconnectPeerResponse(rsp *http.Response) (*DisconnectPeerResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTransactionPayloadResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &DisconnectPeerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
+
+	return response, nil
 }
 
-// RenderGraphWithResponse request returning *RenderGraphResponse
-func (c *ClientWithResponses) RenderGraphWithResponse(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*RenderGraphResponse, error) {
-	rsp, err := c.RenderGraph(ctx, params, reqEditors...)
+// ParseReprocessResponse parses an HTTP response from a ReprocessWithResponse call
+func ParseReprocessResponse(rsp *http.Response) (*ReprocessResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseRenderGraphResponse(rsp)
-}
 
-// GetPeerDiagnosticsWithResponse request returning *GetPeerDiagnosticsResponse
-func (c *ClientWithResponses) GetPeerDiagnosticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPeerDiagnosticsResponse, error) {
-	rsp, err := c.GetPeerDiagnostics(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ReprocessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetPeerDiagnosticsResponse(rsp)
+
+	return response, nil
 }
 
-// ListEventsWithResponse request returning *ListEventsResponse
-func (c *ClientWithResponses) ListEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEventsResponse, error) {
-	rsp, err := c.ListEvents(ctx, reqEditors...)
+// ParseExportSnapshotResponse parses an HTTP response from a ExportSnapshotWithResponse call
+func ParseExportSnapshotResponse(rsp *http.Response) (*ExportSnapshotResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListEventsResponse(rsp)
-}
 
-// ReprocessWithResponse request returning *ReprocessResponse
-func (c *ClientWithResponses) ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error) {
-	rsp, err := c.Reprocess(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ExportSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseReprocessResponse(rsp)
+
+	return response, nil
 }
 
-// ListTransactionsWithResponse request returning *ListTransactionsResponse
-func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
-	rsp, err := c.ListTransactions(ctx, reqEditors...)
+// ParseImportSnapshotResponse parses an HTTP response from a ImportSnapshotWithResponse call
+func ParseImportSnapshotResponse(rsp *http.Response) (*ImportSnapshotResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListTransactionsResponse(rsp)
-}
 
-// GetTransactionWithResponse request returning *GetTransactionResponse
-func (c *ClientWithResponses) GetTransactionWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error) {
-	rsp, err := c.GetTransaction(ctx, ref, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ImportSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetTransactionResponse(rsp)
-}
 
-// GetTransactionPayloadWithResponse request returning *GetTransactionPayloadResponse
-func (c *ClientWithResponses) GetTransactionPayloadWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionPayloadResponse, error) {
-	rsp, err := c.GetTransactionPayload(ctx, ref, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportSnapshotResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return ParseGetTransactionPayloadResponse(rsp)
+
+	return response, nil
 }
 
-// ParseRenderGraphResponse parses an HTTP response from a RenderGraphWithResponse call
-func ParseRenderGraphResponse(rsp *http.Response) (*RenderGraphResponse, error) {
+// ParseListSubscribersResponse parses an HTTP response from a ListSubscribersWithResponse call
+func ParseListSubscribersResponse(rsp *http.Response) (*ListSubscribersResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RenderGraphResponse{
+	response := &ListSubscribersResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Subscriber
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
 	return response, nil
 }
 
-// ParseGetPeerDiagnosticsResponse parses an HTTP response from a GetPeerDiagnosticsWithResponse call
-func ParseGetPeerDiagnosticsResponse(rsp *http.Response) (*GetPeerDiagnosticsResponse, error) {
+// ParseRetryFailedEventsResponse parses an HTTP response from a RetryFailedEventsWithResponse call
+func ParseRetryFailedEventsResponse(rsp *http.Response) (*RetryFailedEventsResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetPeerDiagnosticsResponse{
+	response := &RetryFailedEventsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			AdditionalProperties map[string]PeerDiagnostics `json:"-"`
-		}
+		var dest RetryEventsResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -823,22 +2305,38 @@ func ParseGetPeerDiagnosticsResponse(rsp *http.Response) (*GetPeerDiagnosticsRes
 	return response, nil
 }
 
-// ParseListEventsResponse parses an HTTP response from a ListEventsWithResponse call
-func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
+// ParseSkipSubscriberEventResponse parses an HTTP response from a SkipSubscriberEventWithResponse call
+func ParseSkipSubscriberEventResponse(rsp *http.Response) (*SkipSubscriberEventResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListEventsResponse{
+	response := &SkipSubscriberEventResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetSubscriberEventResponse parses an HTTP response from a GetSubscriberEventWithResponse call
+func ParseGetSubscriberEventResponse(rsp *http.Response) (*GetSubscriberEventResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSubscriberEventResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []EventSubscriber
+		var dest Event
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -849,19 +2347,29 @@ func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
 	return response, nil
 }
 
-// ParseReprocessResponse parses an HTTP response from a ReprocessWithResponse call
-func ParseReprocessResponse(rsp *http.Response) (*ReprocessResponse, error) {
+// ParseRetrySubscriberEventResponse parses an HTTP response from a RetrySubscriberEventWithResponse call
+func ParseRetrySubscriberEventResponse(rsp *http.Response) (*RetrySubscriberEventResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReprocessResponse{
+	response := &RetrySubscriberEventResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RetryEventsResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
 	return response, nil
 }
 
@@ -934,9 +2442,48 @@ type ServerInterface interface {
 	// Lists the state of the internal events
 	// (GET /internal/network/v1/events)
 	ListEvents(ctx echo.Context) error
+	// Lists the peer rules
+	// (GET /internal/network/v1/peerrules)
+	ListPeerRules(ctx echo.Context) error
+	// Adds a peer rule
+	// (POST /internal/network/v1/peerrules)
+	AddPeerRule(ctx echo.Context) error
+	// Removes a peer rule
+	// (DELETE /internal/network/v1/peerrules/{id})
+	RemovePeerRule(ctx echo.Context, id string) error
+	// Lists the peers the node is connected to
+	// (GET /internal/network/v1/peers)
+	ListPeers(ctx echo.Context) error
+	// Connects to a peer
+	// (POST /internal/network/v1/peers)
+	AddPeer(ctx echo.Context) error
+	// Disconnects a peer
+	// (DELETE /internal/network/v1/peers/{peerID})
+	DisconnectPeer(ctx echo.Context, peerID string) error
 	// Reprocess all transactions of the given type, verify and process
 	// (POST /internal/network/v1/reprocess)
 	Reprocess(ctx echo.Context, params ReprocessParams) error
+	// Exports a snapshot of the DAG
+	// (GET /internal/network/v1/snapshot)
+	ExportSnapshot(ctx echo.Context) error
+	// Imports a snapshot of the DAG
+	// (POST /internal/network/v1/snapshot)
+	ImportSnapshot(ctx echo.Context) error
+	// Lists the subscribers of DAG events
+	// (GET /internal/network/v1/subscribers)
+	ListSubscribers(ctx echo.Context) error
+	// Retries all failed events of a subscriber
+	// (POST /internal/network/v1/subscribers/{name}/events/retry)
+	RetryFailedEvents(ctx echo.Context, name string) error
+	// Skips an event of a subscriber
+	// (DELETE /internal/network/v1/subscribers/{name}/events/{ref})
+	SkipSubscriberEvent(ctx echo.Context, name string, ref string) error
+	// Gets an event of a subscriber that hasn't finished yet
+	// (GET /internal/network/v1/subscribers/{name}/events/{ref})
+	GetSubscriberEvent(ctx echo.Context, name string, ref string) error
+	// Retries an event of a subscriber
+	// (POST /internal/network/v1/subscribers/{name}/events/{ref}/retry)
+	RetrySubscriberEvent(ctx echo.Context, name string, ref string) error
 	// Lists the transactions on the DAG
 	// (GET /internal/network/v1/transaction)
 	ListTransactions(ctx echo.Context) error
@@ -1002,6 +2549,86 @@ func (w *ServerInterfaceWrapper) ListEvents(ctx echo.Context) error {
 	return err
 }
 
+// ListPeerRules converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPeerRules(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListPeerRules(ctx)
+	return err
+}
+
+// AddPeerRule converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPeerRule(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPeerRule(ctx)
+	return err
+}
+
+// RemovePeerRule converts echo context to params.
+func (w *ServerInterfaceWrapper) RemovePeerRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RemovePeerRule(ctx, id)
+	return err
+}
+
+// ListPeers converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPeers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListPeers(ctx)
+	return err
+}
+
+// AddPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPeer(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPeer(ctx)
+	return err
+}
+
+// DisconnectPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) DisconnectPeer(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "peerID" -------------
+	var peerID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "peerID", runtime.ParamLocationPath, ctx.Param("peerID"), &peerID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter peerID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DisconnectPeer(ctx, peerID)
+	return err
+}
+
 // Reprocess converts echo context to params.
 func (w *ServerInterfaceWrapper) Reprocess(ctx echo.Context) error {
 	var err error
@@ -1022,6 +2649,135 @@ func (w *ServerInterfaceWrapper) Reprocess(ctx echo.Context) error {
 	return err
 }
 
+// ExportSnapshot converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportSnapshot(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ExportSnapshot(ctx)
+	return err
+}
+
+// ImportSnapshot converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportSnapshot(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ImportSnapshot(ctx)
+	return err
+}
+
+// ListSubscribers converts echo context to params.
+func (w *ServerInterfaceWrapper) ListSubscribers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListSubscribers(ctx)
+	return err
+}
+
+// RetryFailedEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) RetryFailedEvents(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RetryFailedEvents(ctx, name)
+	return err
+}
+
+// SkipSubscriberEvent converts echo context to params.
+func (w *ServerInterfaceWrapper) SkipSubscriberEvent(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "ref" -------------
+	var ref string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ref", runtime.ParamLocationPath, ctx.Param("ref"), &ref)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ref: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SkipSubscriberEvent(ctx, name, ref)
+	return err
+}
+
+// GetSubscriberEvent converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriberEvent(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "ref" -------------
+	var ref string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ref", runtime.ParamLocationPath, ctx.Param("ref"), &ref)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ref: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetSubscriberEvent(ctx, name, ref)
+	return err
+}
+
+// RetrySubscriberEvent converts echo context to params.
+func (w *ServerInterfaceWrapper) RetrySubscriberEvent(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "ref" -------------
+	var ref string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ref", runtime.ParamLocationPath, ctx.Param("ref"), &ref)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ref: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RetrySubscriberEvent(ctx, name, ref)
+	return err
+}
+
 // ListTransactions converts echo context to params.
 func (w *ServerInterfaceWrapper) ListTransactions(ctx echo.Context) error {
 	var err error
@@ -1121,10 +2877,62 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		si.(Preprocessor).Preprocess("ListEvents", context)
 		return wrapper.ListEvents(context)
 	})
+	router.GET(baseURL+"/internal/network/v1/peerrules", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ListPeerRules", context)
+		return wrapper.ListPeerRules(context)
+	})
+	router.POST(baseURL+"/internal/network/v1/peerrules", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("AddPeerRule", context)
+		return wrapper.AddPeerRule(context)
+	})
+	router.DELETE(baseURL+"/internal/network/v1/peerrules/:id", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("RemovePeerRule", context)
+		return wrapper.RemovePeerRule(context)
+	})
+	router.GET(baseURL+"/internal/network/v1/peers", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ListPeers", context)
+		return wrapper.ListPeers(context)
+	})
+	router.POST(baseURL+"/internal/network/v1/peers", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("AddPeer", context)
+		return wrapper.AddPeer(context)
+	})
+	router.DELETE(baseURL+"/internal/network/v1/peers/:peerID", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("DisconnectPeer", context)
+		return wrapper.DisconnectPeer(context)
+	})
 	router.POST(baseURL+"/internal/network/v1/reprocess", func(context echo.Context) error {
 		si.(Preprocessor).Preprocess("Reprocess", context)
 		return wrapper.Reprocess(context)
 	})
+	router.GET(baseURL+"/internal/network/v1/snapshot", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ExportSnapshot", context)
+		return wrapper.ExportSnapshot(context)
+	})
+	router.POST(baseURL+"/internal/network/v1/snapshot", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ImportSnapshot", context)
+		return wrapper.ImportSnapshot(context)
+	})
+	router.GET(baseURL+"/internal/network/v1/subscribers", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ListSubscribers", context)
+		return wrapper.ListSubscribers(context)
+	})
+	router.POST(baseURL+"/internal/network/v1/subscribers/:name/events/retry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("RetryFailedEvents", context)
+		return wrapper.RetryFailedEvents(context)
+	})
+	router.DELETE(baseURL+"/internal/network/v1/subscribers/:name/events/:ref", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("SkipSubscriberEvent", context)
+		return wrapper.SkipSubscriberEvent(context)
+	})
+	router.GET(baseURL+"/internal/network/v1/subscribers/:name/events/:ref", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("GetSubscriberEvent", context)
+		return wrapper.GetSubscriberEvent(context)
+	})
+	router.POST(baseURL+"/internal/network/v1/subscribers/:name/events/:ref/retry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("RetrySubscriberEvent", context)
+		return wrapper.RetrySubscriberEvent(context)
+	})
 	router.GET(baseURL+"/internal/network/v1/transaction", func(context echo.Context) error {
 		si.(Preprocessor).Preprocess("ListTransactions", context)
 		return wrapper.ListTransactions(context)
@@ -44,3 +44,6 @@ func (p PeerDiagnostics) MarshalJSON() ([]byte, error) {
 	cp.Uptime = cp.Uptime / time.Second
 	return json.Marshal(cp)
 }
+
+// PeerRule defines the type for a rule that allows or denies peers.
+type PeerRule = transport.PeerRule
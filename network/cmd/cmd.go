@@ -20,8 +20,10 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/network/transport"
@@ -51,6 +53,9 @@ func FlagSet() *pflag.FlagSet {
 	flagSet.IntSlice("network.protocols", defs.Protocols, "Specifies the list of network protocols to enable on the server. They are specified by version (1, 2). If not set, all protocols are enabled.")
 	flagSet.Int("network.v2.gossipinterval", defs.ProtocolV2.GossipInterval, "Interval (in milliseconds) that specifies how often the node should gossip its new hashes to other nodes.")
 	flagSet.Int("network.v2.diagnosticsinterval", defs.ProtocolV2.DiagnosticsInterval, "Interval (in milliseconds) that specifies how often the node should broadcast its diagnostic information to other nodes (specify 0 to disable).")
+	flagSet.Int("network.v2.messageratelimit", defs.ProtocolV2.MessageRateLimit, "Maximum number of messages per second a peer may send. Messages exceeding the limit are dropped and count as misbehaviour (specify 0 to disable).")
+	flagSet.Int("network.v2.maxrangequerysize", defs.ProtocolV2.MaxRangeQuerySize, "Maximum number of Lamport clock values a transaction range query from a peer may span. Larger queries count as misbehaviour.")
+	flagSet.Int("network.v2.peerscorethreshold", defs.ProtocolV2.PeerScoreThreshold, "Misbehaviour score at which a peer is disconnected. Peers are penalized for e.g. sending invalid transactions or exceeding the message rate limit (specify 0 to disable).")
 	return flagSet
 }
 
@@ -64,7 +69,10 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(getCommand())
 	cmd.AddCommand(payloadCommand())
 	cmd.AddCommand(peersCommand())
+	cmd.AddCommand(subscribersCommand())
 	cmd.AddCommand(reprocessCommand())
+	cmd.AddCommand(exportCommand())
+	cmd.AddCommand(importCommand())
 	return cmd
 }
 
@@ -156,9 +164,11 @@ func listCommand() *cobra.Command {
 }
 
 func peersCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "peers",
 		Short: "Get diagnostic information of the node's peers",
+		Long: "Get diagnostic information of the node's peers. " +
+			"Use the subcommands to manage the node's peers, e.g. to connect to or block a peer.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientConfig := core.NewClientConfigForCommand(cmd)
 			peers, err := httpClient(clientConfig).GetPeerDiagnostics()
@@ -181,12 +191,296 @@ func peersCommand() *cobra.Command {
 				cmd.Printf("  Uptime:            %s\n", peers[peer].Uptime)
 				cmd.Printf("  Number of DAG TXs: %d\n", peers[peer].NumberOfTransactions)
 				cmd.Printf("  Peers:             %v\n", peers[peer].Peers)
+				if score := peers[peer].Score; score != nil {
+					cmd.Printf("  Score:             %.1f (invalid TXs: %d, oversized range queries: %d, throttled messages: %d, message rate: %.1f/s)\n",
+						score.Score, score.InvalidTransactions, score.OversizedRangeQueries, score.ThrottledMessages, score.MessageRate)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.AddCommand(listPeersCommand())
+	cmd.AddCommand(addPeerCommand())
+	cmd.AddCommand(disconnectPeerCommand())
+	cmd.AddCommand(addPeerRuleCommand("block", v1.Deny, "Blocks peers, closing existing connections and refusing new ones"))
+	cmd.AddCommand(addPeerRuleCommand("allow", v1.Allow, "Allows peers. When there are allow rules, the node only connects to peers matching one of them"))
+	cmd.AddCommand(listPeerRulesCommand())
+	cmd.AddCommand(removePeerRuleCommand())
+	return cmd
+}
+
+func listPeersCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the peers the node is connected to",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			peers, err := httpClient(clientConfig).ListPeers()
+			if err != nil {
+				return fmt.Errorf("unable to list peers: %w", err)
+			}
+			sort.Slice(peers, func(i, j int) bool {
+				return peers[i].Id < peers[j].Id
+			})
+			const format = "%-40s %-30s %-50s %s\n"
+			cmd.Printf(format, "ID", "Address", "Node DID", "Certificate subject")
+			for _, peer := range peers {
+				cmd.Printf(format, peer.Id, peer.Address, valueOrEmpty(peer.NodeDID), valueOrEmpty(peer.CertificateSubject))
+			}
+			return nil
+		},
+	}
+}
+
+func addPeerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [address]",
+		Short: "Connects to the peer at the given address (<host>:<port>). The node reconnects to it after a restart, until it's disconnected.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).AddPeer(args[0]); err != nil {
+				return fmt.Errorf("unable to add peer: %w", err)
+			}
+			cmd.Printf("Connecting to peer: %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func disconnectPeerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disconnect [peer]",
+		Short: "Disconnects the peer(s) matching the given peer ID, address, node DID or certificate subject. Use 'block' to prevent them from reconnecting.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := httpClient(core.NewClientConfigForCommand(cmd))
+			peers, err := client.ListPeers()
+			if err != nil {
+				return fmt.Errorf("unable to list peers: %w", err)
+			}
+			disconnected := 0
+			for _, peer := range peers {
+				if peer.Id != args[0] && peer.Address != args[0] && valueOrEmpty(peer.NodeDID) != args[0] && valueOrEmpty(peer.CertificateSubject) != args[0] {
+					continue
+				}
+				if err := client.DisconnectPeer(transport.PeerID(peer.Id)); err != nil {
+					return fmt.Errorf("unable to disconnect peer %s: %w", peer.Id, err)
+				}
+				cmd.Printf("Disconnected peer: %s (%s)\n", peer.Id, peer.Address)
+				disconnected++
+			}
+			if disconnected == 0 {
+				return fmt.Errorf("no connected peer matches: %s", args[0])
+			}
+			return nil
+		},
+	}
+}
+
+func addPeerRuleCommand(use string, action v1.AddPeerRuleRequestAction, short string) *cobra.Command {
+	request := v1.AddPeerRuleRequest{Action: action}
+	var address, nodeDID, subject, reason string
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short + ". Peers are matched on address, node DID and/or certificate subject (all specified criteria must match).",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request.Address = optionalString(address)
+			request.NodeDID = optionalString(nodeDID)
+			request.CertificateSubject = optionalString(subject)
+			request.Reason = optionalString(reason)
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			rule, err := httpClient(clientConfig).AddPeerRule(request)
+			if err != nil {
+				return fmt.Errorf("unable to add peer rule: %w", err)
+			}
+			cmd.Printf("Added peer rule: %s\n", rule)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&address, "peeraddress", "", "Address of the peer. If it doesn't contain a port, it matches any port on the host. "+
+		"Inbound peers are matched on host only. Host names aren't resolved, so use an IP address to match inbound peers.")
+	cmd.Flags().StringVar(&nodeDID, "nodedid", "", "Node DID of the peer. Note that peers that don't send their node DID never match.")
+	cmd.Flags().StringVar(&subject, "subject", "", "Subject of the peer's TLS certificate (e.g. 'CN=nuts.example.com,O=Example').")
+	cmd.Flags().StringVar(&reason, "reason", "", "Description of why the rule is added.")
+	return cmd
+}
+
+func listPeerRulesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rules",
+		Short: "Lists the rules that determine which peers the node may connect to",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			rules, err := httpClient(clientConfig).ListPeerRules()
+			if err != nil {
+				return fmt.Errorf("unable to list peer rules: %w", err)
+			}
+			for _, rule := range rules {
+				cmd.Printf("%s\t%s\t%s\n", rule.CreatedAt.Format(time.RFC3339), rule, rule.Reason)
+			}
+			return nil
+		},
+	}
+}
+
+func removePeerRuleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-rule [id]",
+		Short: "Removes the peer rule with the given ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).RemovePeerRule(args[0]); err != nil {
+				return fmt.Errorf("unable to remove peer rule: %w", err)
+			}
+			cmd.Printf("Removed peer rule: %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func subscribersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscribers",
+		Short: "Manage the subscribers of DAG events",
+		Long: "Manage the subscribers of DAG events (e.g. the VDR and VCR). " +
+			"Use the subcommands to inspect events the subscribers failed to process, and to retry or skip them.",
+	}
+	cmd.AddCommand(listSubscribersCommand())
+	cmd.AddCommand(subscriberEventCommand())
+	cmd.AddCommand(retrySubscriberEventsCommand())
+	cmd.AddCommand(skipSubscriberEventCommand())
+	return cmd
+}
+
+func listSubscribersCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the subscribers of DAG events and the number of events they processed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			subscribers, err := httpClient(clientConfig).ListSubscribers()
+			if err != nil {
+				return fmt.Errorf("unable to list subscribers: %w", err)
+			}
+			const format = "%-30v %-10v %-10v %-10v %-10v %v\n"
+			cmd.Printf(format, "Name", "Persistent", "Pending", "Failed", "Notified", "Finished")
+			for _, subscriber := range subscribers {
+				cmd.Printf(format, subscriber.Name, subscriber.Persistent, subscriber.Pending, subscriber.Failed, subscriber.Notified, subscriber.Finished)
+			}
+			return nil
+		},
+	}
+}
+
+func subscriberEventCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "event [subscriber] [ref]",
+		Short: "Shows an event the subscriber hasn't finished, including the last error if processing failed",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := hash2.ParseHex(args[1])
+			if err != nil {
+				return err
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			event, err := httpClient(clientConfig).GetSubscriberEvent(args[0], ref)
+			if err != nil {
+				return fmt.Errorf("unable to get event: %w", err)
 			}
+			printEvent(cmd, *event)
 			return nil
 		},
 	}
 }
 
+func retrySubscriberEventsCommand() *cobra.Command {
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "retry [subscriber] [ref]",
+		Short: "Notifies the subscriber of the event again. Use --all to retry all events the subscriber failed to process.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := httpClient(core.NewClientConfigForCommand(cmd))
+			var result *v1.RetryEventsResult
+			if all {
+				var err error
+				if result, err = client.RetryFailedEvents(args[0]); err != nil {
+					return fmt.Errorf("unable to retry events: %w", err)
+				}
+			} else {
+				ref, err := hash2.ParseHex(args[1])
+				if err != nil {
+					return err
+				}
+				if result, err = client.RetrySubscriberEvent(args[0], ref); err != nil {
+					return fmt.Errorf("unable to retry event: %w", err)
+				}
+			}
+			cmd.Printf("Succeeded: %d, failed: %d\n", result.Succeeded, len(result.Failed))
+			for _, event := range result.Failed {
+				cmd.Println()
+				printEvent(cmd, event)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Retry all events the subscriber failed to process.")
+	return cmd
+}
+
+func skipSubscriberEventCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "skip [subscriber] [ref]",
+		Short: "Marks the event as finished without processing it, so the subscriber stops retrying it",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := hash2.ParseHex(args[1])
+			if err != nil {
+				return err
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).SkipSubscriberEvent(args[0], ref); err != nil {
+				return fmt.Errorf("unable to skip event: %w", err)
+			}
+			cmd.Printf("Skipped event: %s\n", ref)
+			return nil
+		},
+	}
+}
+
+func printEvent(cmd *cobra.Command, event v1.Event) {
+	cmd.Printf("Event %s:\n", event.Hash)
+	cmd.Printf("  Type:        %s\n", valueOrEmpty(event.Type))
+	cmd.Printf("  Transaction: %s\n", event.Transaction)
+	cmd.Printf("  Retries:     %d\n", event.Retries)
+	cmd.Printf("  Error:       %s\n", valueOrEmpty(event.Error))
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 func reprocessCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "reprocess [contentType]",
@@ -204,6 +498,61 @@ func reprocessCommand() *cobra.Command {
 	}
 }
 
+func exportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export [file]",
+		Short: "Exports a snapshot of the DAG to a file",
+		Long: "Exports all transactions and the payloads of public transactions to a gzip compressed snapshot file, " +
+			"which can be imported on a new node to speed up bootstrapping. " +
+			"Exporting a large DAG might take longer than the default client timeout, use --timeout to increase it.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			err = httpClient(clientConfig).ExportSnapshot(file)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				// don't leave an incomplete snapshot behind
+				_ = os.Remove(args[0])
+				return fmt.Errorf("unable to export snapshot: %w", err)
+			}
+			cmd.Printf("Snapshot exported to %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func importCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Imports a snapshot of the DAG from a file",
+		Long: "Imports a snapshot file created by 'network export'. The snapshot can only be imported when the node's DAG is empty. " +
+			"Every transaction is verified before it is added. While importing, the node doesn't accept transactions from its peers. " +
+			"If the import fails, it can be retried: transactions that are already present are skipped. " +
+			"Importing a large DAG might take longer than the default client timeout, use --timeout to increase it.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			count, err := httpClient(clientConfig).ImportSnapshot(file)
+			if err != nil {
+				return fmt.Errorf("unable to import snapshot: %w", err)
+			}
+			cmd.Printf("Imported %d transactions\n", count)
+			return nil
+		},
+	}
+}
+
 // Sorts the transactions by provided flag or by time.
 func sortTransactions(transactions []dag.Transaction, sortFlag string) {
 	sort.Slice(transactions, func(i, j int) bool {
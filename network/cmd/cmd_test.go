@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -33,7 +34,9 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network/dag"
+	"github.com/nuts-foundation/nuts-node/network/transport"
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -232,6 +235,25 @@ foo
 		assert.NoError(t, err)
 	})
 
+	t.Run("with peer score", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		score := &transport.PeerScore{Score: 12.5, InvalidTransactions: 1, ThrottledMessages: 2, MessageRate: 0.4}
+		handler := http2.Handler{StatusCode: http.StatusOK, ResponseData: map[string]v1.PeerDiagnostics{"foo": {Score: score}}}
+		s := httptest.NewServer(handler)
+		os.Setenv("NUTS_ADDRESS", s.URL)
+		defer os.Unsetenv("NUTS_ADDRESS")
+		defer s.Close()
+
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		cmd.SetArgs([]string{"peers"})
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "  Score:             12.5 (invalid TXs: 1, oversized range queries: 0, throttled messages: 2, message rate: 0.4/s)")
+	})
+
 	t.Run("it handles an http error", func(t *testing.T) {
 		cmd := Cmd()
 		cmd.SetArgs([]string{"peers"})
@@ -276,3 +298,336 @@ func TestCmd_Reprocess(t *testing.T) {
 		assert.EqualError(t, cmd.Execute(), "unable to reprocess transactions: Post \"http:///internal/network/v1/reprocess?type=application%2Fdid%2Bjson\": http: no Host in request URL")
 	})
 }
+
+func TestCmd_PeerManagement(t *testing.T) {
+	setup := func(t *testing.T, handler http.Handler) (*cobra.Command, *bytes.Buffer) {
+		cmd := Cmd()
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		s := httptest.NewServer(handler)
+		os.Setenv("NUTS_ADDRESS", s.URL)
+		t.Cleanup(func() {
+			os.Unsetenv("NUTS_ADDRESS")
+			s.Close()
+		})
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		return cmd, outBuf
+	}
+	nodeDID := "did:nuts:peer"
+	connectedPeers := []v1.ConnectedPeer{
+		{Id: "2", Address: "bar:5555"},
+		{Id: "1", Address: "foo:5555", NodeDID: &nodeDID},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: connectedPeers})
+		cmd.SetArgs([]string{"peers", "list"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(outBuf.String()), "\n")
+		if !assert.Len(t, lines, 3) {
+			return
+		}
+		assert.Contains(t, lines[0], "Node DID")
+		assert.Regexp(t, `^1\s+foo:5555\s+did:nuts:peer`, lines[1])
+		assert.Regexp(t, `^2\s+bar:5555`, lines[2])
+	})
+	t.Run("add", func(t *testing.T) {
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusAccepted})
+		cmd.SetArgs([]string{"peers", "add", "foo:5555"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Connecting to peer: foo:5555")
+	})
+	t.Run("add - error", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusBadRequest})
+		cmd.SetArgs([]string{"peers", "add", "foo"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to add peer")
+	})
+	t.Run("disconnect", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/internal/network/v1/peers", http2.Handler{StatusCode: http.StatusOK, ResponseData: connectedPeers})
+		var disconnected []string
+		mux.HandleFunc("/internal/network/v1/peers/", func(writer http.ResponseWriter, request *http.Request) {
+			disconnected = append(disconnected, strings.TrimPrefix(request.URL.Path, "/internal/network/v1/peers/"))
+			writer.WriteHeader(http.StatusNoContent)
+		})
+		cmd, outBuf := setup(t, mux)
+		cmd.SetArgs([]string{"peers", "disconnect", nodeDID})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, disconnected)
+		assert.Contains(t, outBuf.String(), "Disconnected peer: 1 (foo:5555)")
+	})
+	t.Run("disconnect - no matching peer", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: connectedPeers})
+		cmd.SetArgs([]string{"peers", "disconnect", "did:nuts:other"})
+
+		err := cmd.Execute()
+
+		assert.EqualError(t, err, "no connected peer matches: did:nuts:other")
+	})
+	t.Run("block", func(t *testing.T) {
+		rule := v1.PeerRule{ID: "1", Action: transport.DenyPeerAction, NodeDID: nodeDID}
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: rule})
+		cmd.SetArgs([]string{"peers", "block", "--nodedid", nodeDID, "--reason", "misbehaving"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Added peer rule: deny (id=1, nodeDID=did:nuts:peer)")
+	})
+	t.Run("block by address", func(t *testing.T) {
+		rule := v1.PeerRule{ID: "1", Action: transport.DenyPeerAction, Address: "10.0.0.1"}
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: rule})
+		cmd.SetArgs([]string{"peers", "block", "--peeraddress", "10.0.0.1"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Added peer rule: deny (id=1, address=10.0.0.1)")
+	})
+	t.Run("allow - error", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusBadRequest})
+		cmd.SetArgs([]string{"peers", "allow"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to add peer rule")
+	})
+	t.Run("rules", func(t *testing.T) {
+		rules := []v1.PeerRule{{ID: "1", Action: transport.DenyPeerAction, Address: "foo", Reason: "misbehaving", CreatedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}}
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: rules})
+		cmd.SetArgs([]string{"peers", "rules"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2022-01-01T00:00:00Z\tdeny (id=1, address=foo)\tmisbehaving", strings.TrimSpace(outBuf.String()))
+	})
+	t.Run("remove-rule", func(t *testing.T) {
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusNoContent})
+		cmd.SetArgs([]string{"peers", "remove-rule", "1"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Removed peer rule: 1")
+	})
+	t.Run("remove-rule - not found", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusNotFound})
+		cmd.SetArgs([]string{"peers", "remove-rule", "1"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to remove peer rule")
+	})
+}
+
+func TestCmd_Snapshot(t *testing.T) {
+	setup := func(t *testing.T, handler http.Handler) (*cobra.Command, *bytes.Buffer) {
+		cmd := Cmd()
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		s := httptest.NewServer(handler)
+		os.Setenv("NUTS_ADDRESS", s.URL)
+		t.Cleanup(func() {
+			os.Unsetenv("NUTS_ADDRESS")
+			s.Close()
+		})
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		return cmd, outBuf
+	}
+	t.Run("export", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "snapshot.gz")
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: "snapshot"})
+		cmd.SetArgs([]string{"export", file})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Snapshot exported to "+file)
+		data, _ := os.ReadFile(file)
+		assert.Equal(t, "snapshot", string(data))
+	})
+	t.Run("export - error removes file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "snapshot.gz")
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusInternalServerError})
+		cmd.SetArgs([]string{"export", file})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to export snapshot")
+		assert.NoFileExists(t, file)
+	})
+	t.Run("import", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "snapshot.gz")
+		_ = os.WriteFile(file, []byte("snapshot"), 0600)
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: v1.ImportSnapshotResult{Transactions: 5}})
+		cmd.SetArgs([]string{"import", file})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Imported 5 transactions")
+	})
+	t.Run("import - DAG not empty", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "snapshot.gz")
+		_ = os.WriteFile(file, []byte("snapshot"), 0600)
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusConflict})
+		cmd.SetArgs([]string{"import", file})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to import snapshot")
+	})
+	t.Run("import - file does not exist", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusOK})
+		cmd.SetArgs([]string{"import", filepath.Join(t.TempDir(), "missing.gz")})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestCmd_Subscribers(t *testing.T) {
+	setup := func(t *testing.T, handler http.Handler) (*cobra.Command, *bytes.Buffer) {
+		cmd := Cmd()
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		s := httptest.NewServer(handler)
+		os.Setenv("NUTS_ADDRESS", s.URL)
+		t.Cleanup(func() {
+			os.Unsetenv("NUTS_ADDRESS")
+			s.Close()
+		})
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		return cmd, outBuf
+	}
+	ref := hash.SHA256Sum([]byte{1})
+	eventError := "DID document not found"
+	failedEvent := v1.Event{Hash: ref.String(), Transaction: ref.String(), Retries: 100, Error: &eventError}
+
+	t.Run("list", func(t *testing.T) {
+		subscribers := []v1.Subscriber{{Name: "vdr", Persistent: true, Pending: 2, Failed: 1, Notified: 20, Finished: 18}}
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: subscribers})
+		cmd.SetArgs([]string{"subscribers", "list"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(outBuf.String()), "\n")
+		if !assert.Len(t, lines, 2) {
+			return
+		}
+		assert.Regexp(t, `^Name\s+Persistent\s+Pending\s+Failed\s+Notified\s+Finished`, lines[0])
+		assert.Regexp(t, `^vdr\s+true\s+2\s+1\s+20\s+18`, lines[1])
+	})
+	t.Run("list - error", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusInternalServerError})
+		cmd.SetArgs([]string{"subscribers", "list"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to list subscribers")
+	})
+	t.Run("event", func(t *testing.T) {
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: failedEvent})
+		cmd.SetArgs([]string{"subscribers", "event", "vdr", ref.String()})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Event "+ref.String())
+		assert.Contains(t, outBuf.String(), "Retries:     100")
+		assert.Contains(t, outBuf.String(), "Error:       DID document not found")
+	})
+	t.Run("event - invalid ref", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusOK})
+		cmd.SetArgs([]string{"subscribers", "event", "vdr", "invalid"})
+
+		err := cmd.Execute()
+
+		assert.Error(t, err)
+	})
+	t.Run("event - not found", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusNotFound})
+		cmd.SetArgs([]string{"subscribers", "event", "vdr", ref.String()})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to get event")
+	})
+	t.Run("retry", func(t *testing.T) {
+		var path string
+		cmd, outBuf := setup(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			path = request.URL.Path
+			http2.Handler{StatusCode: http.StatusOK, ResponseData: v1.RetryEventsResult{Succeeded: 1, Failed: []v1.Event{}}}.ServeHTTP(writer, request)
+		}))
+		cmd.SetArgs([]string{"subscribers", "retry", "vdr", ref.String()})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/internal/network/v1/subscribers/vdr/events/"+ref.String()+"/retry", path)
+		assert.Contains(t, outBuf.String(), "Succeeded: 1, failed: 0")
+	})
+	t.Run("retry --all", func(t *testing.T) {
+		var path string
+		cmd, outBuf := setup(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			path = request.URL.Path
+			http2.Handler{StatusCode: http.StatusOK, ResponseData: v1.RetryEventsResult{Succeeded: 2, Failed: []v1.Event{failedEvent}}}.ServeHTTP(writer, request)
+		}))
+		cmd.SetArgs([]string{"subscribers", "retry", "--all", "vdr"})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/internal/network/v1/subscribers/vdr/events/retry", path)
+		assert.Contains(t, outBuf.String(), "Succeeded: 2, failed: 1")
+		assert.Contains(t, outBuf.String(), "Error:       DID document not found")
+	})
+	t.Run("retry - missing ref", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusOK})
+		cmd.SetArgs([]string{"subscribers", "retry", "vdr"})
+
+		err := cmd.Execute()
+
+		assert.Error(t, err)
+	})
+	t.Run("retry - error", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusNotFound})
+		cmd.SetArgs([]string{"subscribers", "retry", "--all", "vdr"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to retry events")
+	})
+	t.Run("skip", func(t *testing.T) {
+		cmd, outBuf := setup(t, http2.Handler{StatusCode: http.StatusNoContent})
+		cmd.SetArgs([]string{"subscribers", "skip", "vdr", ref.String()})
+
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+		assert.Contains(t, outBuf.String(), "Skipped event: "+ref.String())
+	})
+	t.Run("skip - error", func(t *testing.T) {
+		cmd, _ := setup(t, http2.Handler{StatusCode: http.StatusNotFound})
+		cmd.SetArgs([]string{"subscribers", "skip", "vdr", ref.String()})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to skip event")
+	})
+}
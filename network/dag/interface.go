@@ -25,6 +25,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network/dag/tree"
+	"io"
 	"math"
 )
 
@@ -38,16 +39,37 @@ type State interface {
 
 	// WritePayload writes contents for the specified payload, identified by the given hash.
 	// It also calls observers and therefore requires the transaction.
+	// If the payload is rejected by ValidatePayload, the transaction is marked as rejected (see IsPayloadRejected) and observers aren't notified.
 	WritePayload(ctx context.Context, transaction Transaction, payloadHash hash.SHA256Hash, data []byte) error
 	// IsPayloadPresent checks whether the contents for the given transaction are present.
 	IsPayloadPresent(ctx context.Context, payloadHash hash.SHA256Hash) (bool, error)
 	// ReadPayload reads the contents for the specified payload, identified by the given hash. If contents can't be found,
 	// nil is returned. If something (else) goes wrong an error is returned.
 	ReadPayload(ctx context.Context, payloadHash hash.SHA256Hash) ([]byte, error)
+	// RegisterPayloadType registers a payload type that is accepted by the node. The validator (which may be nil)
+	// is called for every payload of that type before it's stored. Once a payload type has been registered,
+	// payloads of unregistered types are rejected. It returns an error if the payload type was already registered.
+	RegisterPayloadType(payloadType string, validator PayloadValidator) error
+	// ValidatePayload checks whether the payload type is registered and the payload is accepted by its validator.
+	// It returns an error wrapping ErrPayloadRejected if it isn't.
+	ValidatePayload(payloadType string, payload []byte) error
+	// IsPayloadTypeAccepted checks whether payloads of the given type are accepted (disregarding the payload itself).
+	IsPayloadTypeAccepted(payloadType string) bool
+	// IsPayloadRejected checks whether the payload of the given transaction was rejected by ValidatePayload.
+	// The transaction of a rejected payload is on the DAG, but its payload isn't processed.
+	// Payloads of unknown types aren't stored either: these transactions are replicated without payload.
+	IsPayloadRejected(ctx context.Context, transactionRef hash.SHA256Hash) (bool, error)
 	// Add a transaction to the DAG. If it can't be added an error is returned.
 	// If the transaction already exists, nothing is added and no observers are notified.
 	// The payload may be passed as well. Allowing for better notification of observers
+	// If the payload is rejected by ValidatePayload, the transaction is marked as rejected and no payload event is emitted.
+	// A public transaction of an unknown payload type may be added without payload.
+	// It returns ErrImportInProgress while a snapshot is being imported.
 	Add(ctx context.Context, transactions Transaction, payload []byte) error
+	// ImportSnapshot imports a snapshot written by WriteSnapshot into the DAG, which must be empty (or contain the transactions of an unfinished import).
+	// While importing, Add returns ErrImportInProgress. It returns ErrDAGNotEmpty if the DAG already contains transactions
+	// and ErrInvalidSnapshot if the snapshot is invalid. It returns the number of imported transactions.
+	ImportSnapshot(ctx context.Context, reader io.Reader) (int, error)
 	// FindBetweenLC finds all transactions which lamport clock value lies between startInclusive and endExclusive.
 	// They are returned in order: first sorted on lamport clock value, then on transaction reference (byte order).
 	FindBetweenLC(ctx context.Context, startInclusive uint32, endExclusive uint32) ([]Transaction, error)
@@ -6,6 +6,7 @@ package dag
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -126,6 +127,21 @@ func (mr *MockStateMockRecorder) IBLT(ctx, reqClock interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IBLT", reflect.TypeOf((*MockState)(nil).IBLT), ctx, reqClock)
 }
 
+// ImportSnapshot mocks base method.
+func (m *MockState) ImportSnapshot(ctx context.Context, reader io.Reader) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSnapshot", ctx, reader)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSnapshot indicates an expected call of ImportSnapshot.
+func (mr *MockStateMockRecorder) ImportSnapshot(ctx, reader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSnapshot", reflect.TypeOf((*MockState)(nil).ImportSnapshot), ctx, reader)
+}
+
 // IsPayloadPresent mocks base method.
 func (m *MockState) IsPayloadPresent(ctx context.Context, payloadHash hash.SHA256Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +157,35 @@ func (mr *MockStateMockRecorder) IsPayloadPresent(ctx, payloadHash interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPayloadPresent", reflect.TypeOf((*MockState)(nil).IsPayloadPresent), ctx, payloadHash)
 }
 
+// IsPayloadRejected mocks base method.
+func (m *MockState) IsPayloadRejected(ctx context.Context, transactionRef hash.SHA256Hash) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPayloadRejected", ctx, transactionRef)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPayloadRejected indicates an expected call of IsPayloadRejected.
+func (mr *MockStateMockRecorder) IsPayloadRejected(ctx, transactionRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPayloadRejected", reflect.TypeOf((*MockState)(nil).IsPayloadRejected), ctx, transactionRef)
+}
+
+// IsPayloadTypeAccepted mocks base method.
+func (m *MockState) IsPayloadTypeAccepted(payloadType string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPayloadTypeAccepted", payloadType)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPayloadTypeAccepted indicates an expected call of IsPayloadTypeAccepted.
+func (mr *MockStateMockRecorder) IsPayloadTypeAccepted(payloadType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPayloadTypeAccepted", reflect.TypeOf((*MockState)(nil).IsPayloadTypeAccepted), payloadType)
+}
+
 // IsPresent mocks base method.
 func (m *MockState) IsPresent(arg0 context.Context, arg1 hash.SHA256Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -219,6 +264,20 @@ func (mr *MockStateMockRecorder) ReadPayload(ctx, payloadHash interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPayload", reflect.TypeOf((*MockState)(nil).ReadPayload), ctx, payloadHash)
 }
 
+// RegisterPayloadType mocks base method.
+func (m *MockState) RegisterPayloadType(payloadType string, validator PayloadValidator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPayloadType", payloadType, validator)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterPayloadType indicates an expected call of RegisterPayloadType.
+func (mr *MockStateMockRecorder) RegisterPayloadType(payloadType, validator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPayloadType", reflect.TypeOf((*MockState)(nil).RegisterPayloadType), payloadType, validator)
+}
+
 // Shutdown mocks base method.
 func (m *MockState) Shutdown() error {
 	m.ctrl.T.Helper()
@@ -247,6 +306,20 @@ func (mr *MockStateMockRecorder) Start() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockState)(nil).Start))
 }
 
+// ValidatePayload mocks base method.
+func (m *MockState) ValidatePayload(payloadType string, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePayload", payloadType, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePayload indicates an expected call of ValidatePayload.
+func (mr *MockStateMockRecorder) ValidatePayload(payloadType, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePayload", reflect.TypeOf((*MockState)(nil).ValidatePayload), payloadType, payload)
+}
+
 // Verify mocks base method.
 func (m *MockState) Verify(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -29,6 +29,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network/log"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
 	"time"
 )
 
@@ -42,6 +43,9 @@ const (
 	PayloadEventType = "payload"
 )
 
+// ErrEventNotFound is returned when an event doesn't exist (anymore) for a notifier.
+var ErrEventNotFound = errors.New("event not found")
+
 // Notifier defines methods for a persistent retry mechanism.
 // Storing the event in the DB is separated from notifying the subscribers.
 // The event is sent to subscribers after the transaction is committed to prevent timing issues.
@@ -65,10 +69,32 @@ type Notifier interface {
 	// GetFailedEvents retrieves the hashes of failed events.
 	// If the notifier is not persistent it'll always return 0.
 	GetFailedEvents() ([]Event, error)
+	// GetEvent retrieves the event with the given hash that hasn't finished yet.
+	// It returns nil if the event doesn't exist, or if the notifier is not persistent.
+	GetEvent(hash hash.SHA256Hash) (*Event, error)
+	// Retry notifies the receiver of the event with the given hash immediately, regardless of scheduled retries.
+	// It returns ErrEventNotFound if the event doesn't exist, or an error if the receiver failed again.
+	Retry(hash hash.SHA256Hash) error
+	// Stats returns the counters of the notifier.
+	Stats() (NotifierStats, error)
 	// Close cancels all running events. It does not remove them from the DB
 	Close() error
 }
 
+// NotifierStats contains the counters of a notifier.
+type NotifierStats struct {
+	// Persistent indicates whether events are stored, which is required for retrying them after a restart.
+	Persistent bool
+	// Pending contains the number of events that haven't finished yet, including failed events.
+	Pending int
+	// Failed contains the number of events that are considered failed, because they have been retried too many times.
+	Failed int
+	// Notified contains the number of times the receiver was called since the node started, including retries.
+	Notified uint64
+	// Finished contains the number of events that finished since the node started.
+	Finished uint64
+}
+
 // ReceiverFn is the function type that needs to be registered for a notifier
 // Returns true if event is received and done, false otherwise
 type ReceiverFn func(event Event) (bool, error)
@@ -178,6 +204,8 @@ func NewNotifier(name string, receiverFn ReceiverFn, options ...NotifierOption)
 		cancel:     cancel,
 		receiver:   receiverFn,
 		retryDelay: defaultRetryDelay,
+		notified:   atomic.NewUint64(0),
+		finished:   atomic.NewUint64(0),
 	}
 
 	for _, option := range options {
@@ -197,6 +225,8 @@ type notifier struct {
 	filters         []NotificationFilter
 	notifiedCounter prometheus.Counter
 	finishedCounter prometheus.Counter
+	notified        *atomic.Uint64
+	finished        *atomic.Uint64
 }
 
 func (p notifier) Name() string {
@@ -249,6 +279,54 @@ func (p *notifier) GetFailedEvents() (events []Event, err error) {
 	return
 }
 
+func (p *notifier) GetEvent(hash hash.SHA256Hash) (event *Event, err error) {
+	if !p.isPersistent() {
+		return nil, nil
+	}
+	err = p.db.ReadShelf(p.ctx, p.shelfName(), func(reader stoabs.Reader) error {
+		event, err = p.readEvent(reader, hash)
+		return err
+	})
+	return
+}
+
+func (p *notifier) Retry(hash hash.SHA256Hash) error {
+	event, err := p.GetEvent(hash)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return ErrEventNotFound
+	}
+	return p.notifyNow(*event)
+}
+
+func (p *notifier) Stats() (NotifierStats, error) {
+	stats := NotifierStats{
+		Persistent: p.isPersistent(),
+		Notified:   p.notified.Load(),
+		Finished:   p.finished.Load(),
+	}
+	if !p.isPersistent() {
+		return stats, nil
+	}
+	err := p.db.ReadShelf(p.ctx, p.shelfName(), func(reader stoabs.Reader) error {
+		return reader.Iterate(func(k stoabs.Key, data []byte) error {
+			if data != nil {
+				event := Event{}
+				_ = json.Unmarshal(data, &event)
+
+				stats.Pending++
+				if event.Retries >= retriesFailedThreshold {
+					stats.Failed++
+				}
+			}
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	return stats, err
+}
+
 func (p *notifier) Save(tx stoabs.WriteTx, event Event) error {
 	// non-persistent job
 	if p.db == nil {
@@ -351,14 +429,15 @@ func (p *notifier) notifyNow(event Event) error {
 		}
 	}
 
-	if finished, err := p.receiver(*dbEvent); err != nil {
+	finished, receiverErr := p.receiver(*dbEvent)
+	if receiverErr != nil {
 		log.Logger().
-			WithError(err).
+			WithError(receiverErr).
 			WithField(core.LogFieldTransactionRef, dbEvent.Hash.String()).
 			WithField(core.LogFieldEventSubscriber, p.name).
 			Errorf("Retry failed")
 
-		dbEvent.Error = err.Error()
+		dbEvent.Error = receiverErr.Error()
 	} else if finished {
 		return p.Finished(dbEvent.Hash)
 	}
@@ -373,6 +452,9 @@ func (p *notifier) notifyNow(event Event) error {
 	}
 
 	// has to return an error since `retry.Do` needs to retry until it's marked as finished
+	if receiverErr != nil {
+		return fmt.Errorf("event handling by receiver failed, but might be retried (count=%d, max=%d): %w", dbEvent.Retries, maxRetries, receiverErr)
+	}
 	return fmt.Errorf("event handling by receiver failed, but might be retried (count=%d, max=%d)", dbEvent.Retries, maxRetries)
 }
 
@@ -419,12 +501,14 @@ func (p *notifier) Close() error {
 }
 
 func (p *notifier) incFinished() {
+	p.finished.Inc()
 	if p.finishedCounter != nil {
 		p.finishedCounter.Inc()
 	}
 }
 
 func (p *notifier) incNotified() {
+	p.notified.Inc()
 	if p.notifiedCounter != nil {
 		p.notifiedCounter.Inc()
 	}
@@ -63,6 +63,21 @@ func (mr *MockNotifierMockRecorder) Finished(hash interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finished", reflect.TypeOf((*MockNotifier)(nil).Finished), hash)
 }
 
+// GetEvent mocks base method.
+func (m *MockNotifier) GetEvent(hash hash.SHA256Hash) (*Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", hash)
+	ret0, _ := ret[0].(*Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockNotifierMockRecorder) GetEvent(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockNotifier)(nil).GetEvent), hash)
+}
+
 // GetFailedEvents mocks base method.
 func (m *MockNotifier) GetFailedEvents() ([]Event, error) {
 	m.ctrl.T.Helper()
@@ -104,6 +119,20 @@ func (mr *MockNotifierMockRecorder) Notify(event interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), event)
 }
 
+// Retry mocks base method.
+func (m *MockNotifier) Retry(hash hash.SHA256Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockNotifierMockRecorder) Retry(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockNotifier)(nil).Retry), hash)
+}
+
 // Run mocks base method.
 func (m *MockNotifier) Run() error {
 	m.ctrl.T.Helper()
@@ -131,3 +160,18 @@ func (mr *MockNotifierMockRecorder) Save(tx, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockNotifier)(nil).Save), tx, event)
 }
+
+// Stats mocks base method.
+func (m *MockNotifier) Stats() (NotifierStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(NotifierStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockNotifierMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockNotifier)(nil).Stats))
+}
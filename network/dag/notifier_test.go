@@ -489,6 +489,125 @@ func TestNotifier_VariousFlows(t *testing.T) {
 	})
 }
 
+func TestNotifier_GetEvent(t *testing.T) {
+	ctx := context.Background()
+	transaction, _, _ := CreateTestTransaction(0)
+	event := Event{Hash: transaction.Ref(), Transaction: transaction, Retries: 3, Error: "failed"}
+
+	t.Run("ok", func(t *testing.T) {
+		kvStore, _ := bbolt.CreateBBoltStore(path.Join(io.TestDirectory(t), "test.db"))
+		s := NewNotifier(t.Name(), dummyFunc, WithPersistency(kvStore))
+		_ = kvStore.Write(ctx, func(tx stoabs.WriteTx) error {
+			return s.Save(tx, event)
+		})
+
+		actual, err := s.GetEvent(event.Hash)
+
+		if !assert.NoError(t, err) || !assert.NotNil(t, actual) {
+			return
+		}
+		assert.Equal(t, 3, actual.Retries)
+		assert.Equal(t, "failed", actual.Error)
+	})
+	t.Run("not found", func(t *testing.T) {
+		kvStore, _ := bbolt.CreateBBoltStore(path.Join(io.TestDirectory(t), "test.db"))
+		s := NewNotifier(t.Name(), dummyFunc, WithPersistency(kvStore))
+
+		actual, err := s.GetEvent(event.Hash)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+	t.Run("not persistent", func(t *testing.T) {
+		s := NewNotifier(t.Name(), dummyFunc)
+
+		actual, err := s.GetEvent(event.Hash)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestNotifier_Retry(t *testing.T) {
+	ctx := context.Background()
+	transaction, _, _ := CreateTestTransaction(0)
+	event := Event{Hash: transaction.Ref(), Transaction: transaction, Retries: retriesFailedThreshold}
+	setup := func(t *testing.T, receiver ReceiverFn) Notifier {
+		kvStore, _ := bbolt.CreateBBoltStore(path.Join(io.TestDirectory(t), "test.db"))
+		s := NewNotifier(t.Name(), receiver, WithPersistency(kvStore))
+		t.Cleanup(func() {
+			_ = s.Close()
+		})
+		_ = kvStore.Write(ctx, func(tx stoabs.WriteTx) error {
+			return s.Save(tx, event)
+		})
+		return s
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		counter := callbackCounter{}
+		s := setup(t, counter.callbackFinished)
+
+		err := s.Retry(event.Hash)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, counter.read())
+		actual, _ := s.GetEvent(event.Hash)
+		assert.Nil(t, actual)
+	})
+	t.Run("receiver fails again", func(t *testing.T) {
+		counter := callbackCounter{}
+		s := setup(t, counter.callbackFailure)
+
+		err := s.Retry(event.Hash)
+
+		assert.EqualError(t, err, "event handling by receiver failed, but might be retried (count=11, max=100): error")
+		actual, _ := s.GetEvent(event.Hash)
+		if assert.NotNil(t, actual) {
+			assert.Equal(t, retriesFailedThreshold+1, actual.Retries)
+			assert.Equal(t, "error", actual.Error)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		s := setup(t, dummyFunc)
+
+		err := s.Retry(hash.EmptyHash())
+
+		assert.ErrorIs(t, err, ErrEventNotFound)
+	})
+}
+
+func TestNotifier_Stats(t *testing.T) {
+	ctx := context.Background()
+	tx1, _, _ := CreateTestTransaction(1)
+	tx2, _, _ := CreateTestTransaction(2)
+
+	t.Run("persistent", func(t *testing.T) {
+		kvStore, _ := bbolt.CreateBBoltStore(path.Join(io.TestDirectory(t), "test.db"))
+		counter := callbackCounter{}
+		s := NewNotifier(t.Name(), counter.callbackFinished, WithPersistency(kvStore))
+		_ = kvStore.Write(ctx, func(tx stoabs.WriteTx) error {
+			_ = s.Save(tx, Event{Hash: tx1.Ref(), Transaction: tx1, Retries: 1})
+			return s.Save(tx, Event{Hash: tx2.Ref(), Transaction: tx2, Retries: retriesFailedThreshold})
+		})
+		_ = s.Retry(tx1.Ref())
+
+		stats, err := s.Stats()
+
+		assert.NoError(t, err)
+		assert.Equal(t, NotifierStats{Persistent: true, Pending: 1, Failed: 1, Notified: 1, Finished: 1}, stats)
+	})
+	t.Run("not persistent", func(t *testing.T) {
+		s := NewNotifier(t.Name(), dummyFunc)
+		s.Notify(Event{Hash: tx1.Ref(), Transaction: tx1})
+
+		stats, err := s.Stats()
+
+		assert.NoError(t, err)
+		assert.Equal(t, NotifierStats{Notified: 1, Finished: 1}, stats)
+	})
+}
+
 func dummyFunc(_ Event) (bool, error) {
 	return true, nil
 }
@@ -0,0 +1,107 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrPayloadRejected is returned when a payload isn't accepted because its type is unknown or it's malformed.
+var ErrPayloadRejected = errors.New("payload rejected")
+
+// ErrUnknownPayloadType is returned when a payload is rejected because its type isn't registered.
+var ErrUnknownPayloadType = fmt.Errorf("%w: unknown payload type", ErrPayloadRejected)
+
+const (
+	rejectReasonUnknownType = "unknown_type"
+	rejectReasonInvalid     = "invalid"
+	// otherPayloadType is used as metric label for unknown payload types, to avoid unbounded label values.
+	otherPayloadType = "other"
+)
+
+// PayloadValidator checks whether a payload is well-formed before it's processed. It returns an error if it isn't.
+type PayloadValidator func(payload []byte) error
+
+// payloadTypeRegistry holds the payload types that are accepted by the node and their validators.
+// As long as no payload types are registered, all payloads are accepted.
+type payloadTypeRegistry struct {
+	validators    map[string]PayloadValidator
+	mux           sync.RWMutex
+	rejectedCount *prometheus.CounterVec
+}
+
+func newPayloadTypeRegistry() *payloadTypeRegistry {
+	registry := &payloadTypeRegistry{
+		validators: map[string]PayloadValidator{},
+		rejectedCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "nuts",
+			Subsystem: "dag",
+			Name:      "payloads_rejected_total",
+			Help:      "Number of transaction payloads that were rejected per payload type and reason.",
+		}, []string{"payload_type", "reason"}),
+	}
+	_ = prometheus.Register(registry.rejectedCount)
+	return registry
+}
+
+func (r *payloadTypeRegistry) register(payloadType string, validator PayloadValidator) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, exists := r.validators[payloadType]; exists {
+		return fmt.Errorf("payload type already registered: %s", payloadType)
+	}
+	r.validators[payloadType] = validator
+	return nil
+}
+
+// accepts returns whether payloads of the given type are accepted, without validating a payload.
+func (r *payloadTypeRegistry) accepts(payloadType string) bool {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	if len(r.validators) == 0 {
+		return true
+	}
+	_, exists := r.validators[payloadType]
+	return exists
+}
+
+func (r *payloadTypeRegistry) validate(payloadType string, payload []byte) error {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	if len(r.validators) == 0 {
+		return nil
+	}
+	validator, exists := r.validators[payloadType]
+	if !exists {
+		r.rejectedCount.WithLabelValues(otherPayloadType, rejectReasonUnknownType).Inc()
+		return fmt.Errorf("%w: %s", ErrUnknownPayloadType, payloadType)
+	}
+	if validator == nil {
+		return nil
+	}
+	if err := validator(payload); err != nil {
+		r.rejectedCount.WithLabelValues(payloadType, rejectReasonInvalid).Inc()
+		return fmt.Errorf("%w: invalid %s payload: %s", ErrPayloadRejected, payloadType, err)
+	}
+	return nil
+}
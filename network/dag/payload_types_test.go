@@ -0,0 +1,88 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dag
+
+import (
+	"errors"
+	"testing"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadTypeRegistry(t *testing.T) {
+	validator := func(payload []byte) error {
+		if string(payload) != "valid" {
+			return errors.New("malformed")
+		}
+		return nil
+	}
+
+	t.Run("everything accepted when nothing registered", func(t *testing.T) {
+		registry := newPayloadTypeRegistry()
+
+		assert.NoError(t, registry.validate("foo/bar", []byte("garbage")))
+	})
+	t.Run("valid payload", func(t *testing.T) {
+		registry := newPayloadTypeRegistry()
+		_ = registry.register("foo/bar", validator)
+
+		assert.NoError(t, registry.validate("foo/bar", []byte("valid")))
+	})
+	t.Run("no validator", func(t *testing.T) {
+		registry := newPayloadTypeRegistry()
+		_ = registry.register("foo/bar", nil)
+
+		assert.NoError(t, registry.validate("foo/bar", []byte("garbage")))
+	})
+	t.Run("invalid payload", func(t *testing.T) {
+		registry := newPayloadTypeRegistry()
+		_ = registry.register("foo/bar", validator)
+
+		err := registry.validate("foo/bar", []byte("garbage"))
+
+		assert.ErrorIs(t, err, ErrPayloadRejected)
+		assert.EqualError(t, err, "payload rejected: invalid foo/bar payload: malformed")
+		assertRejectedMetric(t, registry, "foo/bar", rejectReasonInvalid, 1)
+	})
+	t.Run("unknown payload type", func(t *testing.T) {
+		registry := newPayloadTypeRegistry()
+		_ = registry.register("foo/bar", validator)
+
+		err := registry.validate("foo/baz", []byte("valid"))
+
+		assert.ErrorIs(t, err, ErrPayloadRejected)
+		assert.EqualError(t, err, "payload rejected: unknown payload type: foo/baz")
+		assertRejectedMetric(t, registry, otherPayloadType, rejectReasonUnknownType, 1)
+	})
+	t.Run("already registered", func(t *testing.T) {
+		registry := newPayloadTypeRegistry()
+		_ = registry.register("foo/bar", validator)
+
+		err := registry.register("foo/bar", nil)
+
+		assert.EqualError(t, err, "payload type already registered: foo/bar")
+	})
+}
+
+func assertRejectedMetric(t *testing.T, registry *payloadTypeRegistry, payloadType string, reason string, count float64) {
+	metric := &io_prometheus_client.Metric{}
+	_ = registry.rejectedCount.WithLabelValues(payloadType, reason).Write(metric)
+	assert.Equal(t, count, *metric.Counter.Value)
+}
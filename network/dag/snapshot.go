@@ -0,0 +1,227 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dag
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/network/log"
+)
+
+// ErrDAGNotEmpty is returned when a snapshot is imported into a DAG that already contains transactions.
+var ErrDAGNotEmpty = errors.New("DAG is not empty")
+
+// ErrImportInProgress is returned when a transaction is added to the DAG while a snapshot is being imported.
+var ErrImportInProgress = errors.New("DAG snapshot import in progress")
+
+// ErrInvalidSnapshot is returned when a snapshot can't be imported because it is malformed, incomplete or contains invalid transactions.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+const snapshotVersion = 1
+
+// snapshotImportKey is the key of the metadata property that marks a snapshot import as unfinished
+const snapshotImportKey = "snapshot_import"
+
+// snapshotHeader is the first entry of a snapshot.
+type snapshotHeader struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// snapshotEntry is an entry of a snapshot, containing either a transaction (and its payload) or the footer.
+type snapshotEntry struct {
+	// Transaction holds the transaction in JWS compact serialization.
+	Transaction string `json:"transaction,omitempty"`
+	// Payload holds the transaction's payload. It's absent for private transactions.
+	Payload []byte `json:"payload,omitempty"`
+	// Footer marks the end of the snapshot.
+	Footer *snapshotFooter `json:"footer,omitempty"`
+}
+
+// snapshotFooter is the last entry of a snapshot, used to verify the snapshot is complete.
+type snapshotFooter struct {
+	// Count holds the number of transactions in the snapshot.
+	Count int `json:"count"`
+	// XOR holds the XOR of the references of all transactions in the snapshot.
+	XOR hash.SHA256Hash `json:"xor"`
+}
+
+// WriteSnapshot writes all transactions on the DAG and their payloads to the writer as gzip-compressed archive.
+// Payloads of private transactions are not exported, since they're only meant for the participants.
+// Neither are payloads of unknown types, since they aren't stored (see State.IsPayloadRejected).
+// The snapshot doesn't need to be trusted by the importing node: the transactions are signed and verified when imported.
+// It returns the number of exported transactions.
+func WriteSnapshot(ctx context.Context, state State, writer io.Writer) (int, error) {
+	gzipWriter := gzip.NewWriter(writer)
+	encoder := json.NewEncoder(gzipWriter)
+	if err := encoder.Encode(snapshotHeader{Version: snapshotVersion, CreatedAt: time.Now().UTC()}); err != nil {
+		return 0, err
+	}
+	footer := snapshotFooter{XOR: hash.EmptyHash()}
+	// Lamport clock values are contiguous, so an empty page marks the end of the DAG
+	for start := uint32(0); ; start += PageSize {
+		transactions, err := state.FindBetweenLC(ctx, start, start+PageSize)
+		if err != nil {
+			return footer.Count, err
+		}
+		if len(transactions) == 0 {
+			break
+		}
+		for _, transaction := range transactions {
+			entry := snapshotEntry{Transaction: string(transaction.Data())}
+			if len(transaction.PAL()) == 0 {
+				entry.Payload, err = state.ReadPayload(ctx, transaction.PayloadHash())
+				if err != nil {
+					return footer.Count, err
+				}
+				if entry.Payload == nil {
+					// payloads of unknown types aren't stored
+					rejected, err := state.IsPayloadRejected(ctx, transaction.Ref())
+					if err != nil {
+						return footer.Count, err
+					}
+					if !rejected {
+						return footer.Count, fmt.Errorf("transaction is missing payload (tx=%s)", transaction.Ref())
+					}
+				}
+			}
+			if err = encoder.Encode(entry); err != nil {
+				return footer.Count, err
+			}
+			footer.Count++
+			footer.XOR = footer.XOR.Xor(transaction.Ref())
+		}
+	}
+	if err := encoder.Encode(snapshotEntry{Footer: &footer}); err != nil {
+		return footer.Count, err
+	}
+	return footer.Count, gzipWriter.Close()
+}
+
+// ImportSnapshot imports a snapshot written by WriteSnapshot into the DAG, which must be empty.
+// While importing, no other transactions can be added to the DAG (e.g. received through gossip): Add returns ErrImportInProgress.
+// Every transaction is verified when it's added to the DAG, after which the integrity of the DAG is verified.
+// If the import fails halfway, the transactions imported until then remain on the DAG. The import is then marked as unfinished,
+// so it can be retried on the non-empty DAG: transactions that are already present are skipped.
+// It returns the number of imported transactions.
+func (s *state) ImportSnapshot(ctx context.Context, reader io.Reader) (int, error) {
+	if !s.importing.CompareAndSwap(false, true) {
+		return 0, ErrImportInProgress
+	}
+	defer s.importing.Store(false)
+
+	// Check (and mark) the DAG while holding the write lock: transactions added concurrently are either committed before, or refused.
+	err := s.db.Write(ctx, func(tx stoabs.WriteTx) error {
+		writer, err := tx.GetShelfWriter(metadataShelf)
+		if err != nil {
+			return err
+		}
+		unfinished, err := writer.Get(stoabs.BytesKey(snapshotImportKey))
+		if err != nil {
+			return err
+		}
+		if unfinished != nil {
+			log.Logger().Info("Resuming unfinished DAG snapshot import")
+		} else {
+			head, err := s.graph.getHead(tx)
+			if err != nil {
+				return err
+			}
+			if !head.Empty() {
+				return ErrDAGNotEmpty
+			}
+		}
+		return writer.Put(stoabs.BytesKey(snapshotImportKey), []byte{1})
+	}, stoabs.WithWriteLock())
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.readSnapshot(ctx, reader)
+	if err != nil {
+		return count, err
+	}
+	return count, s.db.WriteShelf(ctx, metadataShelf, func(writer stoabs.Writer) error {
+		return writer.Delete(stoabs.BytesKey(snapshotImportKey))
+	})
+}
+
+func (s *state) readSnapshot(ctx context.Context, reader io.Reader) (int, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err)
+	}
+	decoder := json.NewDecoder(gzipReader)
+	var header snapshotHeader
+	if err = decoder.Decode(&header); err != nil {
+		return 0, fmt.Errorf("%w: unable to read header: %s", ErrInvalidSnapshot, err)
+	}
+	if header.Version != snapshotVersion {
+		return 0, fmt.Errorf("%w: unsupported version: %d", ErrInvalidSnapshot, header.Version)
+	}
+
+	count := 0
+	xor := hash.EmptyHash()
+	for {
+		var entry snapshotEntry
+		if err = decoder.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return count, fmt.Errorf("%w: snapshot is incomplete (imported %d transactions)", ErrInvalidSnapshot, count)
+			}
+			return count, fmt.Errorf("%w: unable to read entry: %s", ErrInvalidSnapshot, err)
+		}
+		if entry.Footer != nil {
+			if entry.Footer.Count != count || !entry.Footer.XOR.Equals(xor) {
+				return count, fmt.Errorf("%w: imported transactions don't match footer (count=%d, expected=%d)", ErrInvalidSnapshot, count, entry.Footer.Count)
+			}
+			break
+		}
+		transaction, err := ParseTransaction([]byte(entry.Transaction))
+		if err != nil {
+			return count, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err)
+		}
+		if len(transaction.PAL()) == 0 && len(entry.Payload) == 0 && s.IsPayloadTypeAccepted(transaction.PayloadType()) {
+			return count, fmt.Errorf("%w: transaction is missing payload (tx=%s)", ErrInvalidSnapshot, transaction.Ref())
+		}
+		if err = s.add(ctx, transaction, entry.Payload, true); err != nil {
+			if errors.As(err, &VerificationError{}) {
+				return count, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err)
+			}
+			return count, fmt.Errorf("unable to add transaction (tx=%s): %w", transaction.Ref(), err)
+		}
+		count++
+		xor = xor.Xor(transaction.Ref())
+		if count%1000 == 0 {
+			log.Logger().Infof("Imported %d transactions from snapshot", count)
+		}
+	}
+
+	if err = s.Verify(ctx); err != nil {
+		return count, fmt.Errorf("DAG verification failed after importing snapshot: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,246 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dag
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	signatureVerifier := NewTransactionSignatureVerifier(nil)
+	tx1 := CreateTestTransactionWithJWK(1)
+	tx2 := CreateTestTransactionWithJWK(2, tx1)
+	tx3 := CreateTestTransactionWithJWK(3, tx1)
+	privateTX := CreateSignedTestTransaction(4, time.Now(), [][]byte{{1, 2, 3}}, "application/did+json", true, tx2, tx3)
+	createSource := func(t *testing.T) State {
+		source := createState(t, signatureVerifier)
+		for i, tx := range []Transaction{tx1, tx2, tx3} {
+			if !assert.NoError(t, source.Add(ctx, tx, testPayload(uint32(i+1)))) {
+				t.FailNow()
+			}
+		}
+		if !assert.NoError(t, source.Add(ctx, privateTX, nil)) {
+			t.FailNow()
+		}
+		return source
+	}
+	writeSnapshot := func(t *testing.T, entries ...interface{}) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		writer := gzip.NewWriter(buf)
+		encoder := json.NewEncoder(writer)
+		for _, entry := range entries {
+			_ = encoder.Encode(entry)
+		}
+		_ = writer.Close()
+		return buf
+	}
+	header := snapshotHeader{Version: snapshotVersion}
+
+	t.Run("export and import", func(t *testing.T) {
+		source := createSource(t)
+		buf := new(bytes.Buffer)
+
+		exported, err := WriteSnapshot(ctx, source, buf)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 4, exported)
+
+		target := createState(t, signatureVerifier)
+		imported, err := target.ImportSnapshot(ctx, buf)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 4, imported)
+		sourceXOR, sourceClock := source.XOR(ctx, math.MaxUint32)
+		targetXOR, targetClock := target.XOR(ctx, math.MaxUint32)
+		assert.Equal(t, sourceXOR, targetXOR)
+		assert.Equal(t, sourceClock, targetClock)
+		payload, _ := target.ReadPayload(ctx, tx2.PayloadHash())
+		assert.Equal(t, testPayload(2), payload)
+		// private transaction payloads aren't exported
+		present, _ := target.IsPayloadPresent(ctx, privateTX.PayloadHash())
+		assert.False(t, present)
+	})
+	t.Run("empty DAG", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+
+		exported, err := WriteSnapshot(ctx, createState(t), buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, exported)
+
+		imported, err := createState(t).ImportSnapshot(ctx, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, imported)
+	})
+	t.Run("error - DAG not empty", func(t *testing.T) {
+		source := createSource(t)
+		buf := new(bytes.Buffer)
+		_, _ = WriteSnapshot(ctx, source, buf)
+
+		_, err := source.ImportSnapshot(ctx, buf)
+
+		assert.ErrorIs(t, err, ErrDAGNotEmpty)
+	})
+	t.Run("error - not a snapshot", func(t *testing.T) {
+		_, err := createState(t).ImportSnapshot(ctx, strings.NewReader("not a snapshot"))
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+	})
+	t.Run("error - unsupported version", func(t *testing.T) {
+		_, err := createState(t).ImportSnapshot(ctx, writeSnapshot(t, snapshotHeader{Version: 2}))
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "unsupported version: 2")
+	})
+	t.Run("error - incomplete", func(t *testing.T) {
+		target := createState(t, signatureVerifier)
+		snapshot := writeSnapshot(t, header, snapshotEntry{Transaction: string(tx1.Data()), Payload: testPayload(1)})
+
+		imported, err := target.ImportSnapshot(ctx, snapshot)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "snapshot is incomplete")
+		assert.Equal(t, 1, imported)
+	})
+	t.Run("unfinished import can be retried", func(t *testing.T) {
+		source := createSource(t)
+		buf := new(bytes.Buffer)
+		_, _ = WriteSnapshot(ctx, source, buf)
+		target := createState(t, signatureVerifier)
+		_, err := target.ImportSnapshot(ctx, writeSnapshot(t, header, snapshotEntry{Transaction: string(tx1.Data()), Payload: testPayload(1)}))
+		if !assert.ErrorIs(t, err, ErrInvalidSnapshot) {
+			return
+		}
+
+		imported, err := target.ImportSnapshot(ctx, buf)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 4, imported)
+		sourceXOR, _ := source.XOR(ctx, math.MaxUint32)
+		targetXOR, _ := target.XOR(ctx, math.MaxUint32)
+		assert.Equal(t, sourceXOR, targetXOR)
+		// finished imports can't be resumed
+		_, err = target.ImportSnapshot(ctx, writeSnapshot(t, header))
+		assert.ErrorIs(t, err, ErrDAGNotEmpty)
+	})
+	t.Run("transactions can't be added while importing", func(t *testing.T) {
+		target := createState(t, signatureVerifier)
+		target.(*state).importing.Store(true)
+
+		err := target.Add(ctx, tx1, testPayload(1))
+		assert.ErrorIs(t, err, ErrImportInProgress)
+		_, err = target.ImportSnapshot(ctx, writeSnapshot(t, header))
+		assert.ErrorIs(t, err, ErrImportInProgress)
+
+		target.(*state).importing.Store(false)
+		assert.NoError(t, target.Add(ctx, tx1, testPayload(1)))
+	})
+	t.Run("error - footer mismatch", func(t *testing.T) {
+		snapshot := writeSnapshot(t, header,
+			snapshotEntry{Transaction: string(tx1.Data()), Payload: testPayload(1)},
+			snapshotEntry{Footer: &snapshotFooter{Count: 2, XOR: tx1.Ref()}})
+
+		_, err := createState(t, signatureVerifier).ImportSnapshot(ctx, snapshot)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "imported transactions don't match footer")
+	})
+	t.Run("error - missing payload", func(t *testing.T) {
+		snapshot := writeSnapshot(t, header, snapshotEntry{Transaction: string(tx1.Data())})
+
+		_, err := createState(t, signatureVerifier).ImportSnapshot(ctx, snapshot)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "transaction is missing payload")
+	})
+	t.Run("payload of unknown type isn't exported", func(t *testing.T) {
+		source := createState(t, signatureVerifier)
+		_ = source.RegisterPayloadType("other/type", nil)
+		_ = source.Add(ctx, tx1, testPayload(1))
+		buf := new(bytes.Buffer)
+
+		exported, err := WriteSnapshot(ctx, source, buf)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 1, exported)
+
+		t.Run("imported by node that rejects it", func(t *testing.T) {
+			target := createState(t, signatureVerifier)
+			_ = target.RegisterPayloadType("other/type", nil)
+
+			imported, err := target.ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()))
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, imported)
+			present, _ := target.IsPayloadPresent(ctx, tx1.PayloadHash())
+			assert.False(t, present)
+			rejected, _ := target.IsPayloadRejected(ctx, tx1.Ref())
+			assert.True(t, rejected)
+		})
+		t.Run("error - imported by node that accepts it", func(t *testing.T) {
+			_, err := createState(t, signatureVerifier).ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()))
+
+			assert.ErrorIs(t, err, ErrInvalidSnapshot)
+			assert.ErrorContains(t, err, "transaction is missing payload")
+		})
+	})
+	t.Run("error - invalid signature", func(t *testing.T) {
+		data := string(tx1.Data())
+		tampered := data[:strings.LastIndex(data, ".")+1] + "AAAA"
+		snapshot := writeSnapshot(t, header, snapshotEntry{Transaction: tampered, Payload: testPayload(1)})
+
+		imported, err := createState(t, signatureVerifier).ImportSnapshot(ctx, snapshot)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "transaction verification failed")
+		assert.Equal(t, 0, imported)
+	})
+	t.Run("error - payload doesn't match", func(t *testing.T) {
+		snapshot := writeSnapshot(t, header, snapshotEntry{Transaction: string(tx1.Data()), Payload: []byte("other")})
+
+		_, err := createState(t, signatureVerifier).ImportSnapshot(ctx, snapshot)
+
+		assert.Error(t, err)
+	})
+}
+
+// testPayload returns the payload of a transaction created with CreateTestTransactionWithJWK.
+func testPayload(num uint32) []byte {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, num)
+	return payload
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network/dag/tree"
 	"github.com/nuts-foundation/nuts-node/network/log"
+	"go.uber.org/atomic"
 )
 
 const (
@@ -41,6 +42,8 @@ const (
 	IbltNumBuckets = 1024
 	xorShelf       = "xorBucket"
 	ibltShelf      = "ibltBucket"
+	// rejectedPayloadsShelf holds the references of transactions of which the payload was rejected, and the reason why.
+	rejectedPayloadsShelf = "rejected_payloads"
 )
 
 // State has references to the DAG and the payload store.
@@ -48,6 +51,7 @@ type state struct {
 	db                  stoabs.KVStore
 	graph               *dag
 	payloadStore        PayloadStore
+	payloadTypes        *payloadTypeRegistry
 	txVerifiers         []Verifier
 	notifiers           map[string]Notifier
 	xorTree             *treeStore
@@ -56,6 +60,8 @@ type state struct {
 	eventsNotifyCount   prometheus.Counter
 	eventsFinishedCount prometheus.Counter
 	notifiersMux        *sync.RWMutex
+	// importing indicates a snapshot is being imported, during which other transactions can't be added.
+	importing *atomic.Bool
 }
 
 func (s *state) Migrate() error {
@@ -71,11 +77,13 @@ func NewState(db stoabs.KVStore, verifiers ...Verifier) (State, error) {
 		db:           db,
 		graph:        graph,
 		payloadStore: payloadStore,
+		payloadTypes: newPayloadTypeRegistry(),
 		txVerifiers:  verifiers,
 		notifiers:    map[string]Notifier{},
 		xorTree:      newTreeStore(xorShelf, tree.New(tree.NewXor(), PageSize)),
 		ibltTree:     newTreeStore(ibltShelf, tree.New(tree.NewIblt(IbltNumBuckets), PageSize)),
 		notifiersMux: &sync.RWMutex{},
+		importing:    atomic.NewBool(false),
 	}
 	err := newState.initPrometheusCounters()
 	if err != nil && err.Error() != (prometheus.AlreadyRegisteredError{}).Error() { // No unwrap on prometheus.AlreadyRegisteredError
@@ -131,6 +139,12 @@ func (s *state) initPrometheusCounters() error {
 }
 
 func (s *state) Add(ctx context.Context, transaction Transaction, payload []byte) error {
+	return s.add(ctx, transaction, payload, false)
+}
+
+// add adds the transaction to the DAG. Unless it's called for importing a snapshot (imported is true),
+// it returns ErrImportInProgress while a snapshot is being imported.
+func (s *state) add(ctx context.Context, transaction Transaction, payload []byte, imported bool) error {
 	txEvent := Event{
 		Type:        TransactionEventType,
 		Hash:        transaction.Ref(),
@@ -149,6 +163,10 @@ func (s *state) Add(ctx context.Context, transaction Transaction, payload []byte
 	emitPayloadEvent := false
 
 	return s.db.Write(ctx, func(tx stoabs.WriteTx) error {
+		// Checked while holding the write lock, see ImportSnapshot
+		if !imported && s.importing.Load() {
+			return ErrImportInProgress
+		}
 		present := s.graph.isPresent(tx, transaction.Ref())
 		if present {
 			return nil
@@ -160,15 +178,23 @@ func (s *state) Add(ctx context.Context, transaction Transaction, payload []byte
 			return err
 		}
 		if payload != nil {
-			emitPayloadEvent = true
 			payloadHash := hash.SHA256Sum(payload)
 			if !transaction.PayloadHash().Equals(payloadHash) {
 				return errors.New("tx.PayloadHash does not match hash of payload")
 			}
-			if err := s.payloadStore.writePayload(tx, payloadHash, payload); err != nil {
+			accepted, err := s.writePayload(tx, transaction, payloadHash, payload)
+			if err != nil {
 				return err
 			}
-			if err := s.saveEvent(tx, payloadEvent); err != nil {
+			if accepted {
+				emitPayloadEvent = true
+				if err := s.saveEvent(tx, payloadEvent); err != nil {
+					return err
+				}
+			}
+		} else if len(transaction.PAL()) == 0 && !s.payloadTypes.accepts(transaction.PayloadType()) {
+			// Public transactions of unknown payload types are replicated without payload, see IsPayloadRejected
+			if err := s.rejectPayload(tx, transaction, s.payloadTypes.validate(transaction.PayloadType(), nil)); err != nil {
 				return err
 			}
 		}
@@ -225,7 +251,7 @@ func (s *state) loadTrees(ctx context.Context) {
 func (s *state) verifyTX(tx stoabs.ReadTx, transaction Transaction) error {
 	for _, verifier := range s.txVerifiers {
 		if err := verifier(tx, transaction); err != nil {
-			return fmt.Errorf("transaction verification failed (tx=%s): %w", transaction.Ref(), err)
+			return VerificationError{Ref: transaction.Ref(), Err: err}
 		}
 	}
 	return nil
@@ -271,16 +297,72 @@ func (s *state) WritePayload(ctx context.Context, transaction Transaction, paylo
 		Transaction: transaction,
 		Payload:     data,
 	}
+	emitPayloadEvent := false
 	return s.db.Write(ctx, func(tx stoabs.WriteTx) error {
-		if err := s.saveEvent(tx, event); err != nil {
+		accepted, err := s.writePayload(tx, transaction, payloadHash, data)
+		if err != nil || !accepted {
 			return err
 		}
-		return s.payloadStore.writePayload(tx, payloadHash, data)
+		emitPayloadEvent = true
+		return s.saveEvent(tx, event)
 	}, stoabs.AfterCommit(func() {
-		s.notify(event)
+		if emitPayloadEvent {
+			s.notify(event)
+		}
 	}), stoabs.WithWriteLock())
 }
 
+// writePayload stores the payload if it's accepted by its payload type's validator, and returns whether it's accepted.
+// If the payload is rejected, the transaction is marked as rejected. Payloads of unknown types aren't stored,
+// but malformed payloads of known types are: other nodes can't tell they're malformed without receiving them.
+func (s *state) writePayload(tx stoabs.WriteTx, transaction Transaction, payloadHash hash.SHA256Hash, payload []byte) (bool, error) {
+	validationErr := s.payloadTypes.validate(transaction.PayloadType(), payload)
+	if validationErr == nil {
+		return true, s.payloadStore.writePayload(tx, payloadHash, payload)
+	}
+	if err := s.rejectPayload(tx, transaction, validationErr); err != nil {
+		return false, err
+	}
+	if errors.Is(validationErr, ErrUnknownPayloadType) {
+		return false, nil
+	}
+	return false, s.payloadStore.writePayload(tx, payloadHash, payload)
+}
+
+// rejectPayload marks the payload of the transaction as rejected for the given reason.
+func (s *state) rejectPayload(tx stoabs.WriteTx, transaction Transaction, reason error) error {
+	log.Logger().
+		WithError(reason).
+		WithField(core.LogFieldTransactionRef, transaction.Ref()).
+		Warn("Transaction payload rejected, it won't be processed")
+	writer, err := tx.GetShelfWriter(rejectedPayloadsShelf)
+	if err != nil {
+		return err
+	}
+	return writer.Put(stoabs.NewHashKey(transaction.Ref()), []byte(reason.Error()))
+}
+
+func (s *state) IsPayloadRejected(ctx context.Context, transactionRef hash.SHA256Hash) (rejected bool, err error) {
+	err = s.db.ReadShelf(ctx, rejectedPayloadsShelf, func(reader stoabs.Reader) error {
+		reason, err := reader.Get(stoabs.NewHashKey(transactionRef))
+		rejected = reason != nil
+		return err
+	})
+	return
+}
+
+func (s *state) IsPayloadTypeAccepted(payloadType string) bool {
+	return s.payloadTypes.accepts(payloadType)
+}
+
+func (s *state) RegisterPayloadType(payloadType string, validator PayloadValidator) error {
+	return s.payloadTypes.register(payloadType, validator)
+}
+
+func (s *state) ValidatePayload(payloadType string, payload []byte) error {
+	return s.payloadTypes.validate(payloadType, payload)
+}
+
 func (s *state) ReadPayload(ctx context.Context, hash hash.SHA256Hash) (payload []byte, err error) {
 	_ = s.db.Read(ctx, func(tx stoabs.ReadTx) error {
 		payload, err = s.payloadStore.readPayload(tx, hash)
@@ -189,6 +189,27 @@ func TestState_WritePayload(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, received.Load())
 	})
+	t.Run("payload of unknown type is rejected without storing it", func(t *testing.T) {
+		txState := createState(t)
+		_ = txState.RegisterPayloadType("other/type", nil)
+		var received atomic.Bool
+		_, _ = txState.Notifier(t.Name(), func(event Event) (bool, error) {
+			received.Toggle()
+			return true, nil
+		}, WithSelectionFilter(func(event Event) bool {
+			return event.Type == PayloadEventType
+		}))
+		tx := CreateTestTransactionWithJWK(1)
+
+		err := txState.WritePayload(context.Background(), tx, tx.PayloadHash(), []byte{1})
+
+		assert.NoError(t, err)
+		present, _ := txState.IsPayloadPresent(context.Background(), tx.PayloadHash())
+		assert.False(t, present)
+		rejected, _ := txState.IsPayloadRejected(context.Background(), tx.Ref())
+		assert.True(t, rejected)
+		assert.False(t, received.Load())
+	})
 }
 
 func TestState_Add(t *testing.T) {
@@ -277,6 +298,92 @@ func TestState_Add(t *testing.T) {
 		assert.EqualError(t, err, "tx.PayloadHash does not match hash of payload")
 	})
 
+	t.Run("malformed payload is stored without payload event", func(t *testing.T) {
+		ctx := context.Background()
+		s := createState(t)
+		_ = s.RegisterPayloadType("application/did+json", func(_ []byte) error {
+			return errors.New("malformed")
+		})
+		var payloadReceived atomic.Bool
+		var txEvent atomic.Value
+		_, _ = s.Notifier(t.Name(), func(event Event) (bool, error) {
+			if event.Type == PayloadEventType {
+				payloadReceived.Toggle()
+			} else {
+				txEvent.Store(event)
+			}
+			return true, nil
+		})
+		tx := CreateTestTransactionWithJWK(1)
+
+		err := s.Add(ctx, tx, testPayload(1))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		present, _ := s.IsPresent(ctx, tx.Ref())
+		assert.True(t, present)
+		payloadPresent, _ := s.IsPayloadPresent(ctx, tx.PayloadHash())
+		assert.True(t, payloadPresent)
+		rejected, _ := s.IsPayloadRejected(ctx, tx.Ref())
+		assert.True(t, rejected)
+		test.WaitFor(t, func() (bool, error) {
+			return txEvent.Load() != nil, nil
+		}, time.Second, "timeout while waiting for event")
+		assert.False(t, payloadReceived.Load())
+	})
+
+	t.Run("payload of unknown type isn't stored", func(t *testing.T) {
+		ctx := context.Background()
+		s := createState(t)
+		_ = s.RegisterPayloadType("other/type", nil)
+		tx := CreateTestTransactionWithJWK(1)
+
+		err := s.Add(ctx, tx, testPayload(1))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		present, _ := s.IsPresent(ctx, tx.Ref())
+		assert.True(t, present)
+		payloadPresent, _ := s.IsPayloadPresent(ctx, tx.PayloadHash())
+		assert.False(t, payloadPresent)
+		rejected, _ := s.IsPayloadRejected(ctx, tx.Ref())
+		assert.True(t, rejected)
+	})
+
+	t.Run("public transaction of unknown type without payload is marked as rejected", func(t *testing.T) {
+		ctx := context.Background()
+		s := createState(t)
+		_ = s.RegisterPayloadType("other/type", nil)
+		tx := CreateTestTransactionWithJWK(1)
+
+		err := s.Add(ctx, tx, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		rejected, _ := s.IsPayloadRejected(ctx, tx.Ref())
+		assert.True(t, rejected)
+	})
+
+	t.Run("accepted payload isn't marked as rejected", func(t *testing.T) {
+		ctx := context.Background()
+		s := createState(t)
+		_ = s.RegisterPayloadType("application/did+json", nil)
+		tx := CreateTestTransactionWithJWK(1)
+
+		err := s.Add(ctx, tx, testPayload(1))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		rejected, _ := s.IsPayloadRejected(ctx, tx.Ref())
+		assert.False(t, rejected)
+		assert.True(t, s.IsPayloadTypeAccepted("application/did+json"))
+		assert.False(t, s.IsPayloadTypeAccepted("other/type"))
+	})
+
 	t.Run("afterCommit is not called for duplicate TX", func(t *testing.T) {
 		ctx := context.Background()
 		s := createState(t).(*state)
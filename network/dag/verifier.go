@@ -26,6 +26,7 @@ import (
 
 	"github.com/lestrrat-go/jwx/jwa"
 	"github.com/lestrrat-go/jwx/jws"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/vdr/types"
 )
 
@@ -36,6 +37,23 @@ var ErrPreviousTransactionMissing = errors.New("transaction is referring to non-
 // ErrInvalidLamportClockValue indicates the lamport clock value for the transaction is wrong.
 var ErrInvalidLamportClockValue = errors.New("transaction has an invalid lamport clock value")
 
+// VerificationError indicates a transaction failed verification by one of the DAG's verifiers, e.g. because its signature is invalid.
+type VerificationError struct {
+	// Ref holds the reference of the transaction that failed verification.
+	Ref hash.SHA256Hash
+	// Err holds the error returned by the verifier.
+	Err error
+}
+
+func (e VerificationError) Error() string {
+	return fmt.Sprintf("transaction verification failed (tx=%s): %s", e.Ref, e.Err)
+}
+
+// Unwrap returns the error returned by the verifier.
+func (e VerificationError) Unwrap() error {
+	return e.Err
+}
+
 // Verifier defines the API of a DAG verifier, used to check the validity of a transaction.
 type Verifier func(tx stoabs.ReadTx, transaction Transaction) error
 
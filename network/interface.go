@@ -19,6 +19,9 @@
 package network
 
 import (
+	"context"
+	"io"
+
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network/dag"
 	"github.com/nuts-foundation/nuts-node/network/transport"
@@ -33,6 +36,11 @@ type Transactions interface {
 	// A filter can be passed as option with the WithSelectionFilter function.
 	// The events for the receiver can be made persistent by passing the network.WithPersistency() option.
 	Subscribe(name string, receiver dag.ReceiverFn, filters ...SubscriberOption) error
+	// RegisterPayloadType registers a payload type that is accepted by the node. The validator (which may be nil) is called
+	// for every payload of that type, so malformed payloads aren't created locally and subscribers aren't notified of them.
+	// Once a payload type has been registered, payloads of unregistered types are rejected as well.
+	// It must be called during the configuration step.
+	RegisterPayloadType(payloadType string, validator dag.PayloadValidator) error
 	// Subscribers returns the list of notifiers on the DAG that emit events to subscribers.
 	Subscribers() []dag.Notifier
 	// GetTransactionPayload retrieves the transaction Payload for the given transaction. If the transaction or Payload is not found
@@ -47,6 +55,26 @@ type Transactions interface {
 	ListTransactionsInRange(startInclusive uint32, endExclusive uint32) ([]dag.Transaction, error)
 	// PeerDiagnostics returns a map containing diagnostic information of the node's peers. The key contains the remote peer's ID.
 	PeerDiagnostics() map[transport.PeerID]transport.Diagnostics
+	// Peers returns the peers the node is currently connected to.
+	Peers() []transport.Peer
+	// AddPeer connects to the peer at the given address (<host>:<port>). The node reconnects to it after a restart.
+	AddPeer(address string) error
+	// DisconnectPeer closes the connection to the peer with the given ID. It returns transport.ErrPeerNotFound if the peer isn't connected.
+	DisconnectPeer(peerID transport.PeerID) error
+	// PeerRules returns the rules that determine which peers the node may connect to.
+	PeerRules() []transport.PeerRule
+	// AddPeerRule adds a rule that allows or denies peers. Connections to peers that are no longer allowed are closed.
+	AddPeerRule(rule transport.PeerRule) (*transport.PeerRule, error)
+	// RemovePeerRule removes the peer rule with the given ID. It returns transport.ErrPeerRuleNotFound if the rule doesn't exist.
+	RemovePeerRule(id string) error
+	// ExportSnapshot writes all transactions on the DAG and their payloads to the writer as archive,
+	// which can be imported into an empty node using ImportSnapshot. It returns the number of exported transactions.
+	ExportSnapshot(ctx context.Context, writer io.Writer) (int, error)
+	// ImportSnapshot imports an archive created by ExportSnapshot into the DAG, verifying every transaction.
+	// No other transactions are added to the DAG while importing. An unfinished (failed) import can be retried.
+	// It returns dag.ErrDAGNotEmpty if the DAG already contains transactions and dag.ErrInvalidSnapshot if the archive is invalid.
+	// It returns the number of imported transactions.
+	ImportSnapshot(ctx context.Context, reader io.Reader) (int, error)
 	// Reprocess walks the DAG and publishes all transactions matching the contentType via Nats
 	// This is an async process and will not return any feedback
 	Reprocess(contentType string)
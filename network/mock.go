@@ -5,6 +5,8 @@
 package network
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +38,35 @@ func (m *MockTransactions) EXPECT() *MockTransactionsMockRecorder {
 	return m.recorder
 }
 
+// AddPeer mocks base method.
+func (m *MockTransactions) AddPeer(address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeer", address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPeer indicates an expected call of AddPeer.
+func (mr *MockTransactionsMockRecorder) AddPeer(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeer", reflect.TypeOf((*MockTransactions)(nil).AddPeer), address)
+}
+
+// AddPeerRule mocks base method.
+func (m *MockTransactions) AddPeerRule(rule transport.PeerRule) (*transport.PeerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeerRule", rule)
+	ret0, _ := ret[0].(*transport.PeerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPeerRule indicates an expected call of AddPeerRule.
+func (mr *MockTransactionsMockRecorder) AddPeerRule(rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeerRule", reflect.TypeOf((*MockTransactions)(nil).AddPeerRule), rule)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockTransactions) CreateTransaction(spec Template) (dag.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +82,35 @@ func (mr *MockTransactionsMockRecorder) CreateTransaction(spec interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactions)(nil).CreateTransaction), spec)
 }
 
+// DisconnectPeer mocks base method.
+func (m *MockTransactions) DisconnectPeer(peerID transport.PeerID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisconnectPeer", peerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisconnectPeer indicates an expected call of DisconnectPeer.
+func (mr *MockTransactionsMockRecorder) DisconnectPeer(peerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectPeer", reflect.TypeOf((*MockTransactions)(nil).DisconnectPeer), peerID)
+}
+
+// ExportSnapshot mocks base method.
+func (m *MockTransactions) ExportSnapshot(ctx context.Context, writer io.Writer) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSnapshot", ctx, writer)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportSnapshot indicates an expected call of ExportSnapshot.
+func (mr *MockTransactionsMockRecorder) ExportSnapshot(ctx, writer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSnapshot", reflect.TypeOf((*MockTransactions)(nil).ExportSnapshot), ctx, writer)
+}
+
 // GetTransaction mocks base method.
 func (m *MockTransactions) GetTransaction(transactionRef hash.SHA256Hash) (dag.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +141,21 @@ func (mr *MockTransactionsMockRecorder) GetTransactionPayload(transactionRef int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionPayload", reflect.TypeOf((*MockTransactions)(nil).GetTransactionPayload), transactionRef)
 }
 
+// ImportSnapshot mocks base method.
+func (m *MockTransactions) ImportSnapshot(ctx context.Context, reader io.Reader) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSnapshot", ctx, reader)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSnapshot indicates an expected call of ImportSnapshot.
+func (mr *MockTransactionsMockRecorder) ImportSnapshot(ctx, reader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSnapshot", reflect.TypeOf((*MockTransactions)(nil).ImportSnapshot), ctx, reader)
+}
+
 // ListTransactionsInRange mocks base method.
 func (m *MockTransactions) ListTransactionsInRange(startInclusive, endExclusive uint32) ([]dag.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +185,62 @@ func (mr *MockTransactionsMockRecorder) PeerDiagnostics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerDiagnostics", reflect.TypeOf((*MockTransactions)(nil).PeerDiagnostics))
 }
 
+// PeerRules mocks base method.
+func (m *MockTransactions) PeerRules() []transport.PeerRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerRules")
+	ret0, _ := ret[0].([]transport.PeerRule)
+	return ret0
+}
+
+// PeerRules indicates an expected call of PeerRules.
+func (mr *MockTransactionsMockRecorder) PeerRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerRules", reflect.TypeOf((*MockTransactions)(nil).PeerRules))
+}
+
+// Peers mocks base method.
+func (m *MockTransactions) Peers() []transport.Peer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peers")
+	ret0, _ := ret[0].([]transport.Peer)
+	return ret0
+}
+
+// Peers indicates an expected call of Peers.
+func (mr *MockTransactionsMockRecorder) Peers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockTransactions)(nil).Peers))
+}
+
+// RegisterPayloadType mocks base method.
+func (m *MockTransactions) RegisterPayloadType(payloadType string, validator dag.PayloadValidator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPayloadType", payloadType, validator)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterPayloadType indicates an expected call of RegisterPayloadType.
+func (mr *MockTransactionsMockRecorder) RegisterPayloadType(payloadType, validator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPayloadType", reflect.TypeOf((*MockTransactions)(nil).RegisterPayloadType), payloadType, validator)
+}
+
+// RemovePeerRule mocks base method.
+func (m *MockTransactions) RemovePeerRule(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePeerRule", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePeerRule indicates an expected call of RemovePeerRule.
+func (mr *MockTransactionsMockRecorder) RemovePeerRule(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeerRule", reflect.TypeOf((*MockTransactions)(nil).RemovePeerRule), id)
+}
+
 // Reprocess mocks base method.
 func (m *MockTransactions) Reprocess(contentType string) {
 	m.ctrl.T.Helper()
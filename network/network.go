@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -406,6 +407,11 @@ func (n *Network) Subscribe(name string, subscriber dag.ReceiverFn, options ...S
 	return err
 }
 
+// RegisterPayloadType registers a payload type that is accepted by the node, with an optional validator for its payloads.
+func (n *Network) RegisterPayloadType(payloadType string, validator dag.PayloadValidator) error {
+	return n.state.RegisterPayloadType(payloadType, validator)
+}
+
 func (n *Network) Subscribers() []dag.Notifier {
 	if n.state != nil {
 		return n.state.Notifiers()
@@ -437,6 +443,30 @@ func (n *Network) ListTransactionsInRange(startInclusive uint32, endExclusive ui
 	return n.state.FindBetweenLC(context.Background(), startInclusive, endExclusive)
 }
 
+// ExportSnapshot writes all transactions on the DAG and their payloads to the writer as archive.
+func (n *Network) ExportSnapshot(ctx context.Context, writer io.Writer) (int, error) {
+	count, err := dag.WriteSnapshot(ctx, n.state, writer)
+	if err != nil {
+		return count, err
+	}
+	log.Logger().Infof("Exported DAG snapshot (transactions=%d)", count)
+	return count, nil
+}
+
+// ImportSnapshot imports an archive created by ExportSnapshot into the (empty) DAG.
+func (n *Network) ImportSnapshot(ctx context.Context, reader io.Reader) (int, error) {
+	log.Logger().Info("Importing DAG snapshot")
+	count, err := n.state.ImportSnapshot(ctx, reader)
+	if err != nil {
+		log.Logger().
+			WithError(err).
+			Errorf("Failed to import DAG snapshot (imported transactions=%d)", count)
+		return count, err
+	}
+	log.Logger().Infof("Imported DAG snapshot (transactions=%d)", count)
+	return count, nil
+}
+
 // CreateTransaction creates a new transaction from the given template.
 func (n *Network) CreateTransaction(template Template) (dag.Transaction, error) {
 	payloadHash := hash.SHA256Sum(template.Payload)
@@ -448,6 +478,11 @@ func (n *Network) CreateTransaction(template Template) (dag.Transaction, error)
 		WithField(core.LogFieldKeyID, template.Key.KID()).
 		Debug("Creating transaction")
 
+	// Assert the payload would be accepted by the DAG (and by other nodes)
+	if err := n.state.ValidatePayload(template.Type, template.Payload); err != nil {
+		return nil, err
+	}
+
 	// Assert that all additional prevs are present and its Payload is there
 	ctx := context.Background()
 	for _, prev := range template.AdditionalPrevs {
@@ -610,6 +645,36 @@ func (n *Network) PeerDiagnostics() map[transport.PeerID]transport.Diagnostics {
 	return result
 }
 
+// Peers returns the peers the node is currently connected to.
+func (n *Network) Peers() []transport.Peer {
+	return n.connectionManager.Peers()
+}
+
+// AddPeer connects to the peer at the given address (<host>:<port>). The node reconnects to it after a restart.
+func (n *Network) AddPeer(address string) error {
+	return n.connectionManager.AddPeer(address)
+}
+
+// DisconnectPeer closes the connection to the peer with the given ID.
+func (n *Network) DisconnectPeer(peerID transport.PeerID) error {
+	return n.connectionManager.Disconnect(peerID)
+}
+
+// PeerRules returns the rules that determine which peers the node may connect to.
+func (n *Network) PeerRules() []transport.PeerRule {
+	return n.connectionManager.PeerRules()
+}
+
+// AddPeerRule adds a rule that allows or denies peers.
+func (n *Network) AddPeerRule(rule transport.PeerRule) (*transport.PeerRule, error) {
+	return n.connectionManager.AddPeerRule(rule)
+}
+
+// RemovePeerRule removes the peer rule with the given ID.
+func (n *Network) RemovePeerRule(id string) error {
+	return n.connectionManager.RemovePeerRule(id)
+}
+
 func (n *Network) Reprocess(contentType string) {
 	batchSize := uint32(1000)
 
@@ -637,6 +702,7 @@ func (n *Network) Reprocess(contentType string) {
 			}
 
 			for _, tx := range txs {
+				lastLC = tx.Clock()
 				if tx.PayloadType() == contentType {
 					// add to Nats
 					subject := fmt.Sprintf("%s.%s", events.ReprocessStream, contentType)
@@ -649,6 +715,10 @@ func (n *Network) Reprocess(contentType string) {
 							Error("Failed to publish transaction")
 						return
 					}
+					if payload == nil {
+						// payload was rejected or (private transactions) isn't received (yet)
+						continue
+					}
 					twp := events.TransactionWithPayload{
 						Transaction: tx,
 						Payload:     payload,
@@ -668,7 +738,6 @@ func (n *Network) Reprocess(contentType string) {
 						return
 					}
 				}
-				lastLC = tx.Clock()
 			}
 
 			// give some time for Update transactions that require all read transactions to be closed
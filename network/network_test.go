@@ -19,6 +19,7 @@
 package network
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -27,6 +28,7 @@ import (
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"math"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -87,6 +89,40 @@ func TestNetwork_ListTransactionsInRange(t *testing.T) {
 	})
 }
 
+func TestNetwork_RegisterPayloadType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cxt := createNetwork(t, ctrl)
+	cxt.state.EXPECT().RegisterPayloadType(payloadType, nil).Return(nil)
+
+	err := cxt.network.RegisterPayloadType(payloadType, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestNetwork_ExportSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cxt := createNetwork(t, ctrl)
+	transaction := dag.CreateTestTransactionWithJWK(1)
+	cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), dag.PageSize).Return([]dag.Transaction{transaction}, nil)
+	cxt.state.EXPECT().FindBetweenLC(gomock.Any(), dag.PageSize, 2*dag.PageSize).Return(nil, nil)
+	cxt.state.EXPECT().ReadPayload(gomock.Any(), transaction.PayloadHash()).Return([]byte{1}, nil)
+
+	count, err := cxt.network.ExportSnapshot(context.Background(), &bytes.Buffer{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestNetwork_ImportSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cxt := createNetwork(t, ctrl)
+	cxt.state.EXPECT().ImportSnapshot(gomock.Any(), gomock.Any()).Return(0, dag.ErrDAGNotEmpty)
+
+	_, err := cxt.network.ImportSnapshot(context.Background(), strings.NewReader(""))
+
+	assert.ErrorIs(t, err, dag.ErrDAGNotEmpty)
+}
+
 func TestNetwork_Name(t *testing.T) {
 	assert.Equal(t, "Network", (&Network{}).Name())
 }
@@ -420,6 +456,17 @@ func TestNetwork_CreateTransaction(t *testing.T) {
 		_, err = cxt.network.CreateTransaction(TransactionTemplate(payloadType, payload, key).WithAttachKey())
 		assert.NoError(t, err)
 	})
+	t.Run("error - payload rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cxt := createNetwork(t, ctrl)
+		state := dag.NewMockState(ctrl)
+		cxt.network.state = state
+		state.EXPECT().ValidatePayload(payloadType, []byte("garbage")).Return(dag.ErrPayloadRejected)
+
+		_, err := cxt.network.CreateTransaction(TransactionTemplate(payloadType, []byte("garbage"), key))
+
+		assert.ErrorIs(t, err, dag.ErrPayloadRejected)
+	})
 	t.Run("ok - detached key", func(t *testing.T) {
 		payload := []byte("Hello, World!")
 		ctrl := gomock.NewController(t)
@@ -1024,6 +1071,8 @@ func createNetwork(t *testing.T, ctrl *gomock.Controller, cfgFn ...func(config *
 	})
 	// required when starting the network, it searches for nodes to connect to
 	docFinder.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]did.Document{}, nil)
+	// payloads are accepted unless a test says otherwise
+	state.EXPECT().ValidatePayload(gomock.Any(), gomock.Any()).AnyTimes()
 	network := NewNetworkInstance(networkConfig, keyResolver, keyStore, decrypter, docResolver, docFinder, eventPublisher, storageEngine.GetProvider(ModuleName))
 	network.state = state
 	network.connectionManager = connectionManager
@@ -60,6 +60,24 @@ type ConnectionManager interface {
 	// Peers returns a slice containing the peers that are currently connected.
 	Peers() []Peer
 
+	// AddPeer connects to the peer at the given address, like Connect. The address is persisted,
+	// so the node reconnects to the peer after a restart.
+	AddPeer(peerAddress string) error
+
+	// Disconnect closes the connection to the peer with the given ID and stops connecting to it.
+	// If the peer was added using AddPeer, it's removed. It returns ErrPeerNotFound if there's no connection to the peer.
+	Disconnect(peerID PeerID) error
+
+	// PeerRules returns the rules that determine which peers the node may connect to.
+	PeerRules() []PeerRule
+
+	// AddPeerRule validates, persists and applies the given rule: connections to peers that are no longer allowed are closed.
+	// It returns the rule with its ID set.
+	AddPeerRule(rule PeerRule) (*PeerRule, error)
+
+	// RemovePeerRule removes the rule with the given ID. It returns ErrPeerRuleNotFound if the rule does not exist.
+	RemovePeerRule(id string) error
+
 	// RegisterObserver allows to register a callback function for stream state changes
 	RegisterObserver(callback StreamStateObserverFunc)
 
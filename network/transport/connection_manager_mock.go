@@ -34,6 +34,35 @@ func (m *MockConnectionManager) EXPECT() *MockConnectionManagerMockRecorder {
 	return m.recorder
 }
 
+// AddPeer mocks base method.
+func (m *MockConnectionManager) AddPeer(peerAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeer", peerAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPeer indicates an expected call of AddPeer.
+func (mr *MockConnectionManagerMockRecorder) AddPeer(peerAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeer", reflect.TypeOf((*MockConnectionManager)(nil).AddPeer), peerAddress)
+}
+
+// AddPeerRule mocks base method.
+func (m *MockConnectionManager) AddPeerRule(rule PeerRule) (*PeerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeerRule", rule)
+	ret0, _ := ret[0].(*PeerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPeerRule indicates an expected call of AddPeerRule.
+func (mr *MockConnectionManagerMockRecorder) AddPeerRule(rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeerRule", reflect.TypeOf((*MockConnectionManager)(nil).AddPeerRule), rule)
+}
+
 // Connect mocks base method.
 func (m *MockConnectionManager) Connect(peerAddress string, option ...ConnectionOption) {
 	m.ctrl.T.Helper()
@@ -65,6 +94,34 @@ func (mr *MockConnectionManagerMockRecorder) Diagnostics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diagnostics", reflect.TypeOf((*MockConnectionManager)(nil).Diagnostics))
 }
 
+// Disconnect mocks base method.
+func (m *MockConnectionManager) Disconnect(peerID PeerID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnect", peerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockConnectionManagerMockRecorder) Disconnect(peerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockConnectionManager)(nil).Disconnect), peerID)
+}
+
+// PeerRules mocks base method.
+func (m *MockConnectionManager) PeerRules() []PeerRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerRules")
+	ret0, _ := ret[0].([]PeerRule)
+	return ret0
+}
+
+// PeerRules indicates an expected call of PeerRules.
+func (mr *MockConnectionManagerMockRecorder) PeerRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerRules", reflect.TypeOf((*MockConnectionManager)(nil).PeerRules))
+}
+
 // Peers mocks base method.
 func (m *MockConnectionManager) Peers() []Peer {
 	m.ctrl.T.Helper()
@@ -91,6 +148,20 @@ func (mr *MockConnectionManagerMockRecorder) RegisterObserver(callback interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterObserver", reflect.TypeOf((*MockConnectionManager)(nil).RegisterObserver), callback)
 }
 
+// RemovePeerRule mocks base method.
+func (m *MockConnectionManager) RemovePeerRule(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePeerRule", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePeerRule indicates an expected call of RemovePeerRule.
+func (mr *MockConnectionManagerMockRecorder) RemovePeerRule(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeerRule", reflect.TypeOf((*MockConnectionManager)(nil).RemovePeerRule), id)
+}
+
 // Start mocks base method.
 func (m *MockConnectionManager) Start() error {
 	m.ctrl.T.Helper()
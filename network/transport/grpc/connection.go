@@ -148,6 +148,7 @@ func (mc *conn) disconnect() {
 	peer := mc.Peer()
 	peer.ID = ""
 	peer.NodeDID = did.DID{}
+	peer.CertificateSubject = ""
 	mc.peer.Store(peer)
 }
 
@@ -88,6 +88,7 @@ func NewGRPCConnectionManager(config Config, connectionStore stoabs.KVStore, nod
 		listenerCreator: config.listener,
 		dialer:          config.dialer,
 		connectionStore: connectionStore,
+		peerRules:       &peerRuleList{store: connectionStore},
 	}
 	cm.registerPrometheusMetrics()
 	cm.ctx, cm.ctxCancel = context.WithCancel(context.Background())
@@ -111,12 +112,20 @@ type grpcConnectionManager struct {
 	stopCRLValidator    func()
 	observers           []transport.StreamStateObserverFunc
 	connectionStore     stoabs.KVStore
+	peerRules           *peerRuleList
 	peersCounter        prometheus.Gauge
 	recvMessagesCounter *prometheus.CounterVec
 	sentMessagesCounter *prometheus.CounterVec
 }
 
 func (s *grpcConnectionManager) Start() error {
+	if err := s.peerRules.load(); err != nil {
+		return fmt.Errorf("unable to load peer rules: %w", err)
+	}
+	if err := s.connectToManualPeers(); err != nil {
+		return err
+	}
+
 	s.grpcServerMutex.Lock()
 	defer s.grpcServerMutex.Unlock()
 
@@ -230,6 +239,13 @@ func (s grpcConnectionManager) Connect(peerAddress string, options ...transport.
 	for _, o := range options {
 		o(&peer)
 	}
+	if err := s.peerRules.evaluate(peer, false); err != nil {
+		log.Logger().
+			WithError(err).
+			WithField(core.LogFieldPeerAddr, peer.Address).
+			Info("Not connecting to peer")
+		return
+	}
 	connection, isNew := s.connections.getOrRegister(s.ctx, peer, s.dialer)
 	if !isNew {
 		log.Logger().
@@ -240,6 +256,105 @@ func (s grpcConnectionManager) Connect(peerAddress string, options ...transport.
 	s.startTracking(peer.Address, connection)
 }
 
+func (s *grpcConnectionManager) AddPeer(peerAddress string) error {
+	if _, _, err := net.SplitHostPort(peerAddress); err != nil {
+		return fmt.Errorf("invalid peer address (expected <host>:<port>): %w", err)
+	}
+	if err := s.peerRules.evaluate(transport.Peer{Address: peerAddress}, false); err != nil {
+		return err
+	}
+	if err := writeManualPeer(s.connectionStore, peerAddress); err != nil {
+		return fmt.Errorf("unable to persist peer: %w", err)
+	}
+	s.Connect(peerAddress)
+	return nil
+}
+
+func (s *grpcConnectionManager) Disconnect(peerID transport.PeerID) error {
+	connection := s.connections.Get(ByPeerID(peerID))
+	if connection == nil {
+		return transport.ErrPeerNotFound
+	}
+	peer := connection.Peer()
+	log.Logger().
+		WithFields(peer.ToFields()).
+		Info("Disconnecting peer")
+	s.closeConnection(connection)
+	if err := deleteManualPeer(s.connectionStore, peer.Address); err != nil {
+		return fmt.Errorf("unable to remove persisted peer: %w", err)
+	}
+	return nil
+}
+
+func (s *grpcConnectionManager) PeerRules() []transport.PeerRule {
+	return s.peerRules.list()
+}
+
+func (s *grpcConnectionManager) AddPeerRule(rule transport.PeerRule) (*transport.PeerRule, error) {
+	result, err := s.peerRules.add(rule)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().Infof("Added peer rule: %s", result)
+	s.applyPeerRules()
+	return result, nil
+}
+
+func (s *grpcConnectionManager) RemovePeerRule(id string) error {
+	if err := s.peerRules.remove(id); err != nil {
+		return err
+	}
+	log.Logger().Infof("Removed peer rule (id=%s)", id)
+	return nil
+}
+
+// applyPeerRules closes the connections to peers that aren't allowed by the peer rules.
+// Allow rules are only enforced on active connections, since the properties of other peers (e.g. node DID) aren't known.
+func (s *grpcConnectionManager) applyPeerRules() {
+	for _, connection := range s.connections.All() {
+		peer := connection.Peer()
+		if err := s.peerRules.evaluate(peer, connection.IsConnected()); err != nil {
+			log.Logger().
+				WithError(err).
+				WithFields(peer.ToFields()).
+				Info("Closing connection to peer that is not allowed")
+			s.closeConnection(connection)
+		}
+	}
+}
+
+// checkPeerRules checks whether the given (authenticated) peer is allowed by the peer rules.
+// The returned error is sent to the peer, so it doesn't contain the details (e.g. the rule that denied the peer).
+func (s *grpcConnectionManager) checkPeerRules(peer transport.Peer) error {
+	if err := s.peerRules.evaluate(peer, true); err != nil {
+		log.Logger().
+			WithError(err).
+			WithFields(peer.ToFields()).
+			Info("Refusing connection to peer")
+		return transport.ErrPeerNotAllowed
+	}
+	return nil
+}
+
+// closeConnection disconnects the given connection, stops connecting to the peer and forgets the connection.
+func (s *grpcConnectionManager) closeConnection(connection Connection) {
+	connection.stopConnecting()
+	connection.disconnect()
+	s.connections.remove(connection)
+}
+
+// connectToManualPeers connects to the peers that were added using AddPeer.
+func (s *grpcConnectionManager) connectToManualPeers() error {
+	peers, err := readManualPeers(s.connectionStore)
+	if err != nil {
+		return fmt.Errorf("unable to read persisted peers: %w", err)
+	}
+	for _, peer := range peers {
+		s.Connect(peer.Address)
+	}
+	return nil
+}
+
 func (s *grpcConnectionManager) RegisterObserver(observer transport.StreamStateObserverFunc) {
 	s.observers = append(s.observers, observer)
 }
@@ -383,6 +498,9 @@ func (s *grpcConnectionManager) openOutboundStream(connection Connection, protoc
 	if err != nil {
 		return nil, fatalError{error: err}
 	}
+	if err = s.checkPeerRules(authenticatedPeer); err != nil {
+		return nil, fatalError{error: err}
+	}
 
 	connection.setPeer(authenticatedPeer)
 
@@ -399,6 +517,11 @@ func (s *grpcConnectionManager) openOutboundStream(connection Connection, protoc
 }
 
 func (s *grpcConnectionManager) authenticate(nodeDID did.DID, peer transport.Peer, peerFromCtx *grpcPeer.Peer) (transport.Peer, error) {
+	if peerFromCtx != nil {
+		if tlsInfo, isTLS := peerFromCtx.AuthInfo.(credentials.TLSInfo); isTLS && len(tlsInfo.State.PeerCertificates) > 0 {
+			peer.CertificateSubject = tlsInfo.State.PeerCertificates[0].Subject.String()
+		}
+	}
 	if !nodeDID.Empty() {
 		authenticatedPeer, err := s.authenticator.Authenticate(nodeDID, *peerFromCtx, peer)
 		if err != nil {
@@ -447,6 +570,7 @@ func (s *grpcConnectionManager) handleInboundStream(protocol Protocol, inboundSt
 	peer := transport.Peer{
 		ID:      peerID,
 		Address: peerFromCtx.Addr.String(),
+		Inbound: true,
 	}
 	log.Logger().
 		WithFields(peer.ToFields()).
@@ -456,6 +580,9 @@ func (s *grpcConnectionManager) handleInboundStream(protocol Protocol, inboundSt
 	if err != nil {
 		return err
 	}
+	if err = s.checkPeerRules(peer); err != nil {
+		return err
+	}
 
 	// TODO: Need to authenticate PeerID, to make sure a second stream with a known PeerID is from the same node (maybe even connection).
 	//       Use address from peer context?
@@ -515,6 +642,12 @@ func (s *grpcConnectionManager) startTracking(address string, connection Connect
 	}
 	connection.startConnecting(cfg, backoff, func(grpcConn *grpc.ClientConn) bool {
 		err := s.openOutboundStreams(connection, grpcConn, backoff)
+		if errors.Is(err, transport.ErrPeerNotAllowed) {
+			// Peer isn't allowed, so stop connecting to it
+			s.closeConnection(connection)
+			_ = grpcConn.Close()
+			return false
+		}
 		if err != nil {
 			log.Logger().
 				WithError(err).
@@ -221,7 +221,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 	serverCert, _ := tls.LoadX509KeyPair("../../test/certificate-and-key.pem", "../../test/certificate-and-key.pem")
 
 	t.Run("ok - gRPC server not bound", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(Config{}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		assert.NoError(t, cm.Start())
 		assert.Nil(t, cm.listener)
 	})
@@ -236,7 +236,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 			"foo",
 			WithTLS(serverCert, trustStore, 10),
 		)
-		cm := NewGRPCConnectionManager(cfg, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(cfg, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		err := cm.Start()
 		if !assert.NoError(t, err) {
 			return
@@ -257,7 +257,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 			WithTLS(serverCert, trustStore, 10),
 			WithTLSOffloading("client-cert"),
 		)
-		cm := NewGRPCConnectionManager(cfg, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(cfg, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		err := cm.Start()
 		if !assert.NoError(t, err) {
 			return
@@ -277,14 +277,14 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 			WithTLS(serverCert, trustStore, 10),
 			WithTLSOffloading(""),
 		)
-		cm := NewGRPCConnectionManager(cfg, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(cfg, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		err := cm.Start()
 
 		assert.EqualError(t, err, "tls.certheader must be configured to enable TLS offloading ")
 	})
 
 	t.Run("ok - gRPC server bound, TLS disabled", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(NewConfig(fmt.Sprintf("127.0.0.1:%d", test.FreeTCPPort()), "foo"), nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(NewConfig(fmt.Sprintf("127.0.0.1:%d", test.FreeTCPPort()), "foo"), createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		err := cm.Start()
 		if !assert.NoError(t, err) {
 			return
@@ -315,7 +315,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 			crlValidator:       validator,
 			maxCRLValidityDays: 10,
 			listener:           tcpListenerCreator,
-		}, nil, &stubNodeDIDReader{}, nil, p)
+		}, createKVStore(t), &stubNodeDIDReader{}, nil, p)
 
 		assert.NoError(t, cm.Start())
 		cm.Stop()
@@ -326,7 +326,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 
 func Test_grpcConnectionManager_Stop(t *testing.T) {
 	t.Run("closes open connections", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(Config{peerID: "12345"}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "12345"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 
 		go cm.handleInboundStream(&TestProtocol{}, newServerStream("1234", ""))
 		test.WaitFor(t, func() (bool, error) {
@@ -340,7 +340,7 @@ func Test_grpcConnectionManager_Stop(t *testing.T) {
 		// This test simulates a slow or unfortunately timed shutdown, where there's an new inbound stream while shutting down.
 		// This previously caused the Connection Manager to deadlock, being blocked by conn.waitUntilDisconnected() which blocks GRPCServer.GracefulStop().
 		// Solved by having the context conn.waitUntilDisconnected() waits for, derive from a parent context supplied by ConnectionManager, which is cancelled when Stop() is called.
-		cm := NewGRPCConnectionManager(Config{peerID: "12345"}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "12345"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 
 		wg := sync.WaitGroup{}
 		wg.Add(2)
@@ -366,12 +366,12 @@ func Test_grpcConnectionManager_Stop(t *testing.T) {
 func Test_grpcConnectionManager_Diagnostics(t *testing.T) {
 	const peerID = "server-peer-id"
 	t.Run("no peers", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(Config{peerID: peerID}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: peerID}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		defer cm.Stop()
 		assert.Equal(t, "0", cm.Diagnostics()[1].String()) // assert number_of_peers
 	})
 	t.Run("with peers", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(Config{peerID: peerID}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: peerID}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		defer cm.Stop()
 
 		go cm.handleInboundStream(&TestProtocol{}, newServerStream("peer1", ""))
@@ -389,14 +389,14 @@ func Test_grpcConnectionManager_Diagnostics(t *testing.T) {
 func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 	t.Run("server did not sent ID", func(t *testing.T) {
 		serverCfg, serverListener := newBufconnConfig("")
-		server := NewGRPCConnectionManager(serverCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		server := NewGRPCConnectionManager(serverCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		if err := server.Start(); err != nil {
 			t.Fatal(err)
 		}
 		defer server.Stop()
 
 		clientCfg, _ := newBufconnConfig("client", withBufconnDialer(serverListener))
-		client := NewGRPCConnectionManager(clientCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		client := NewGRPCConnectionManager(clientCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		c := createConnection(context.Background(), clientCfg.dialer, transport.Peer{})
 		grpcConn, err := clientCfg.dialer(context.Background(), "server")
 		if !assert.NoError(t, err) {
@@ -410,14 +410,14 @@ func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 	})
 	t.Run("second stream over same connection sends different peer ID", func(t *testing.T) {
 		serverCfg, serverListener := newBufconnConfig("server")
-		server := NewGRPCConnectionManager(serverCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		server := NewGRPCConnectionManager(serverCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		if err := server.Start(); err != nil {
 			t.Fatal(err)
 		}
 		defer server.Stop()
 
 		clientCfg, _ := newBufconnConfig("client", withBufconnDialer(serverListener))
-		client := NewGRPCConnectionManager(clientCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		client := NewGRPCConnectionManager(clientCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		c := createConnection(context.Background(), clientCfg.dialer, transport.Peer{})
 		grpcConn, err := clientCfg.dialer(context.Background(), "server")
 		if !assert.NoError(t, err) {
@@ -438,14 +438,14 @@ func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 	})
 	t.Run("client does not support gRPC protocol implementation", func(t *testing.T) {
 		serverCfg, serverListener := newBufconnConfig("server")
-		server := NewGRPCConnectionManager(serverCfg, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		server := NewGRPCConnectionManager(serverCfg, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		if err := server.Start(); err != nil {
 			t.Fatal(err)
 		}
 		defer server.Stop()
 
 		clientCfg, _ := newBufconnConfig("client", withBufconnDialer(serverListener))
-		client := NewGRPCConnectionManager(clientCfg, nil, &stubNodeDIDReader{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		client := NewGRPCConnectionManager(clientCfg, createKVStore(t), &stubNodeDIDReader{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 
 		var capturedError atomic.Value
 		var waiter sync.WaitGroup
@@ -466,14 +466,14 @@ func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 	})
 	t.Run("already connected (same peer ID)", func(t *testing.T) {
 		serverCfg, serverListener := newBufconnConfig("server")
-		server := NewGRPCConnectionManager(serverCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		server := NewGRPCConnectionManager(serverCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		if err := server.Start(); err != nil {
 			t.Fatal(err)
 		}
 		defer server.Stop()
 
 		clientCfg, _ := newBufconnConfig("client", withBufconnDialer(serverListener))
-		client := NewGRPCConnectionManager(clientCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		client := NewGRPCConnectionManager(clientCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		c := createConnection(context.Background(), clientCfg.dialer, transport.Peer{})
 		grpcConn, err := clientCfg.dialer(context.Background(), "server")
 		if !assert.NoError(t, err) {
@@ -494,7 +494,7 @@ func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 	})
 	t.Run("peer authentication fails", func(t *testing.T) {
 		serverCfg, serverListener := newBufconnConfig("server")
-		server := NewGRPCConnectionManager(serverCfg, nil, &transport.FixedNodeDIDResolver{NodeDID: *nodeDID}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		server := NewGRPCConnectionManager(serverCfg, createKVStore(t), &transport.FixedNodeDIDResolver{NodeDID: *nodeDID}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		if err := server.Start(); err != nil {
 			t.Fatal(err)
 		}
@@ -505,7 +505,7 @@ func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 		defer ctrl.Finish()
 		authenticator := NewMockAuthenticator(ctrl)
 		authenticator.EXPECT().Authenticate(*nodeDID, gomock.Any(), gomock.Any()).Return(transport.Peer{}, ErrNodeDIDAuthFailed)
-		client := NewGRPCConnectionManager(clientCfg, nil, &transport.FixedNodeDIDResolver{}, authenticator, &TestProtocol{}).(*grpcConnectionManager)
+		client := NewGRPCConnectionManager(clientCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, authenticator, &TestProtocol{}).(*grpcConnectionManager)
 		c := createConnection(context.Background(), clientCfg.dialer, transport.Peer{})
 		grpcConn, err := clientCfg.dialer(context.Background(), "server")
 		if !assert.NoError(t, err) {
@@ -521,14 +521,14 @@ func Test_grpcConnectionManager_openOutboundStreams(t *testing.T) {
 		// Bug: peer ID is empty when race condition with disconnect() and notify observers occurs.
 		// See https://github.com/nuts-foundation/nuts-node/issues/978
 		serverCfg, serverListener := newBufconnConfig("server")
-		server := NewGRPCConnectionManager(serverCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		server := NewGRPCConnectionManager(serverCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		if err := server.Start(); err != nil {
 			t.Fatal(err)
 		}
 		defer server.Stop()
 
 		clientCfg, _ := newBufconnConfig("client", withBufconnDialer(serverListener))
-		client := NewGRPCConnectionManager(clientCfg, nil, &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
+		client := NewGRPCConnectionManager(clientCfg, createKVStore(t), &transport.FixedNodeDIDResolver{}, nil, &TestProtocol{}).(*grpcConnectionManager)
 		c := createConnection(context.Background(), clientCfg.dialer, transport.Peer{})
 		grpcConn, err := clientCfg.dialer(context.Background(), "server")
 		if !assert.NoError(t, err) {
@@ -600,7 +600,7 @@ func Test_grpcConnectionManager_openOutboundStream(t *testing.T) {
 		authenticator := NewMockAuthenticator(ctrl)
 		authenticator.EXPECT().Authenticate(*nodeDID, *grpcPeer, transport.Peer{}).Return(peerInfo, nil)
 
-		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		cm.authenticator = authenticator
 
 		defer cm.Stop()
@@ -681,7 +681,7 @@ func Test_grpcConnectionManager_handleInboundStream(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		authenticator := NewMockAuthenticator(ctrl)
 		authenticator.EXPECT().Authenticate(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedPeer, nil)
-		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, &stubNodeDIDReader{}, authenticator).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, authenticator).(*grpcConnectionManager)
 		defer cm.Stop()
 
 		handlerExited := &sync.WaitGroup{}
@@ -725,7 +725,7 @@ func Test_grpcConnectionManager_handleInboundStream(t *testing.T) {
 			Address: "127.0.0.1:9522",
 		}
 		serverStream := newServerStream(expectedPeer.ID, expectedPeer.NodeDID.String())
-		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 
 		err := cm.handleInboundStream(protocol, serverStream)
 		assert.EqualError(t, err, "unable to read peer ID")
@@ -743,14 +743,14 @@ func Test_grpcConnectionManager_handleInboundStream(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		authenticator := NewMockAuthenticator(ctrl)
 		authenticator.EXPECT().Authenticate(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedPeer, errors.New("failed"))
-		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, &stubNodeDIDReader{}, authenticator).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, authenticator).(*grpcConnectionManager)
 
 		err := cm.handleInboundStream(protocol, serverStream)
 		assert.EqualError(t, err, "nodeDID authentication failed")
 		assert.Empty(t, cm.connections.list)
 	})
 	t.Run("already connected client", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		defer cm.Stop()
 
 		go cm.handleInboundStream(protocol, newServerStream("client-peer-id", ""))
@@ -766,7 +766,7 @@ func Test_grpcConnectionManager_handleInboundStream(t *testing.T) {
 		assert.Len(t, cm.connections.list, 1)
 	})
 	t.Run("closing connection removes it from list", func(t *testing.T) {
-		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
 		defer cm.Stop()
 
 		stream := newServerStream("client-peer-id", "")
@@ -871,3 +871,144 @@ func createKVStore(t *testing.T) stoabs.KVStore {
 	}
 	return db
 }
+
+func Test_grpcConnectionManager_PeerManagement(t *testing.T) {
+	protocol := &TestProtocol{}
+	createInboundConnection := func(t *testing.T, cm *grpcConnectionManager, peerID transport.PeerID, nodeDID string) {
+		go cm.handleInboundStream(protocol, newServerStream(peerID, nodeDID))
+		test.WaitFor(t, func() (bool, error) {
+			return len(cm.Peers()) == 1, nil
+		}, 5*time.Second, "time-out while waiting for peer")
+	}
+	waitForNoConnections := func(t *testing.T, cm *grpcConnectionManager) {
+		test.WaitFor(t, func() (bool, error) {
+			return len(cm.connections.All()) == 0, nil
+		}, 5*time.Second, "time-out while waiting for connection to be closed")
+	}
+
+	t.Run("AddPeer connects and reconnects after restart", func(t *testing.T) {
+		store := createKVStore(t)
+		peerAddress := fmt.Sprintf("127.0.0.1:%d", test.FreeTCPPort())
+		cm := NewGRPCConnectionManager(NewConfig("", "test"), store, &stubNodeDIDReader{}, nil, protocol).(*grpcConnectionManager)
+		defer cm.Stop()
+
+		err := cm.AddPeer(peerAddress)
+
+		assert.NoError(t, err)
+		assert.Len(t, cm.connections.All(), 1)
+
+		restarted := NewGRPCConnectionManager(NewConfig("", "test"), store, &stubNodeDIDReader{}, nil, protocol).(*grpcConnectionManager)
+		defer restarted.Stop()
+		assert.NoError(t, restarted.Start())
+		if assert.Len(t, restarted.connections.All(), 1) {
+			assert.Equal(t, peerAddress, restarted.connections.All()[0].Peer().Address)
+		}
+	})
+	t.Run("AddPeer with invalid address", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(NewConfig("", "test"), createKVStore(t), &stubNodeDIDReader{}, nil, protocol).(*grpcConnectionManager)
+
+		err := cm.AddPeer("foo")
+
+		assert.ErrorContains(t, err, "invalid peer address")
+		assert.Empty(t, cm.connections.All())
+	})
+	t.Run("AddPeer with denied address", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(NewConfig("", "test"), createKVStore(t), &stubNodeDIDReader{}, nil, protocol).(*grpcConnectionManager)
+		_, _ = cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, Address: "127.0.0.1"})
+
+		err := cm.AddPeer("127.0.0.1:5555")
+
+		assert.ErrorIs(t, err, transport.ErrPeerNotAllowed)
+		assert.Empty(t, cm.connections.All())
+	})
+	t.Run("Connect doesn't connect to denied address", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(NewConfig("", "test"), createKVStore(t), &stubNodeDIDReader{}, nil, protocol).(*grpcConnectionManager)
+		_, _ = cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, Address: "127.0.0.1:5555"})
+
+		cm.Connect("127.0.0.1:5555")
+
+		assert.Empty(t, cm.connections.All())
+	})
+	t.Run("Disconnect", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		defer cm.Stop()
+		createInboundConnection(t, cm, "client-peer-id", "")
+
+		err := cm.Disconnect("client-peer-id")
+
+		assert.NoError(t, err)
+		waitForNoConnections(t, cm)
+	})
+	t.Run("Disconnect unknown peer", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+
+		err := cm.Disconnect("client-peer-id")
+
+		assert.ErrorIs(t, err, transport.ErrPeerNotFound)
+	})
+	t.Run("AddPeerRule closes connections to denied peers", func(t *testing.T) {
+		clientDID, _ := did.ParseDID("did:nuts:client")
+		ctrl := gomock.NewController(t)
+		authenticator := NewMockAuthenticator(ctrl)
+		authenticator.EXPECT().Authenticate(*clientDID, gomock.Any(), gomock.Any()).DoAndReturn(func(nodeDID did.DID, _ peer.Peer, peer transport.Peer) (transport.Peer, error) {
+			peer.NodeDID = nodeDID
+			return peer, nil
+		})
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, authenticator).(*grpcConnectionManager)
+		defer cm.Stop()
+		createInboundConnection(t, cm, "client-peer-id", clientDID.String())
+
+		rule, err := cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, NodeDID: clientDID.String()})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, rule.ID)
+		assert.Equal(t, []transport.PeerRule{*rule}, cm.PeerRules())
+		waitForNoConnections(t, cm)
+	})
+	t.Run("AddPeerRule closes connections to peers not matching allow rules", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		defer cm.Stop()
+		createInboundConnection(t, cm, "client-peer-id", "")
+
+		_, err := cm.AddPeerRule(transport.PeerRule{Action: transport.AllowPeerAction, NodeDID: "did:nuts:partner"})
+
+		assert.NoError(t, err)
+		waitForNoConnections(t, cm)
+	})
+	t.Run("AddPeerRule with invalid rule", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+
+		_, err := cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction})
+
+		assert.Error(t, err)
+		assert.Empty(t, cm.PeerRules())
+	})
+	t.Run("inbound connection from denied peer is refused", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		_, _ = cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, Address: "127.0.0.1"})
+
+		err := cm.handleInboundStream(protocol, newServerStream("client-peer-id", ""))
+
+		assert.Equal(t, transport.ErrPeerNotAllowed, err)
+		assert.Empty(t, cm.connections.All())
+	})
+	t.Run("inbound connection from denied host is refused regardless of its source port", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		_, _ = cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, Address: "127.0.0.1:5555"})
+
+		err := cm.handleInboundStream(protocol, newServerStream("client-peer-id", ""))
+
+		assert.Equal(t, transport.ErrPeerNotAllowed, err)
+		assert.Empty(t, cm.connections.All())
+	})
+	t.Run("RemovePeerRule", func(t *testing.T) {
+		cm := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, createKVStore(t), &stubNodeDIDReader{}, nil).(*grpcConnectionManager)
+		rule, _ := cm.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerAction, Address: "127.0.0.1"})
+
+		err := cm.RemovePeerRule(rule.ID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, cm.PeerRules())
+		assert.ErrorIs(t, cm.RemovePeerRule(rule.ID), transport.ErrPeerRuleNotFound)
+	})
+}
@@ -0,0 +1,158 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/network/transport"
+)
+
+// peerRulesShelf is the shelf in the connection store that contains the peer rules, keyed by rule ID.
+const peerRulesShelf = "peerrules"
+
+// manualPeersShelf is the shelf in the connection store that contains the addresses of peers added using AddPeer.
+const manualPeersShelf = "peers"
+
+// peerRuleList holds the peer rules, which are persisted in the connection store and cached in memory.
+type peerRuleList struct {
+	store stoabs.KVStore
+	mux   sync.RWMutex
+	rules []transport.PeerRule
+}
+
+// load reads the rules from the store.
+func (l *peerRuleList) load() error {
+	var rules []transport.PeerRule
+	err := l.store.ReadShelf(context.Background(), peerRulesShelf, func(reader stoabs.Reader) error {
+		return reader.Iterate(func(_ stoabs.Key, value []byte) error {
+			var rule transport.PeerRule
+			if err := json.Unmarshal(value, &rule); err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.rules = rules
+	return nil
+}
+
+func (l *peerRuleList) list() []transport.PeerRule {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	return append([]transport.PeerRule{}, l.rules...)
+}
+
+func (l *peerRuleList) add(rule transport.PeerRule) (*transport.PeerRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = uuid.NewString()
+	rule.CreatedAt = nowFunc().UTC()
+	data, _ := json.Marshal(rule)
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	err := l.store.WriteShelf(context.Background(), peerRulesShelf, func(writer stoabs.Writer) error {
+		return writer.Put(stoabs.BytesKey(rule.ID), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.rules = append(l.rules, rule)
+	return &rule, nil
+}
+
+func (l *peerRuleList) remove(id string) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	index := -1
+	for i, rule := range l.rules {
+		if rule.ID == id {
+			index = i
+		}
+	}
+	if index == -1 {
+		return transport.ErrPeerRuleNotFound
+	}
+	err := l.store.WriteShelf(context.Background(), peerRulesShelf, func(writer stoabs.Writer) error {
+		return writer.Delete(stoabs.BytesKey(id))
+	})
+	if err != nil {
+		return err
+	}
+	l.rules = append(l.rules[:index], l.rules[index+1:]...)
+	return nil
+}
+
+// evaluate checks whether the given peer is allowed by the rules (see transport.EvaluatePeerRules).
+func (l *peerRuleList) evaluate(peer transport.Peer, enforceAllowRules bool) error {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	return transport.EvaluatePeerRules(l.rules, peer, enforceAllowRules)
+}
+
+// manualPeer is a peer added using AddPeer, persisted in the connection store.
+type manualPeer struct {
+	Address string    `json:"address"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+func readManualPeers(store stoabs.KVStore) ([]manualPeer, error) {
+	var result []manualPeer
+	err := store.ReadShelf(context.Background(), manualPeersShelf, func(reader stoabs.Reader) error {
+		return reader.Iterate(func(_ stoabs.Key, value []byte) error {
+			var peer manualPeer
+			if err := json.Unmarshal(value, &peer); err != nil {
+				return err
+			}
+			result = append(result, peer)
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	return result, err
+}
+
+func writeManualPeer(store stoabs.KVStore, address string) error {
+	data, _ := json.Marshal(manualPeer{Address: address, AddedAt: nowFunc().UTC()})
+	return store.WriteShelf(context.Background(), manualPeersShelf, func(writer stoabs.Writer) error {
+		return writer.Put(stoabs.BytesKey(address), data)
+	})
+}
+
+func deleteManualPeer(store stoabs.KVStore, address string) error {
+	return store.WriteShelf(context.Background(), manualPeersShelf, func(writer stoabs.Writer) error {
+		return writer.Delete(stoabs.BytesKey(address))
+	})
+}
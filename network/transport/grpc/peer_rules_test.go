@@ -0,0 +1,95 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peerRuleList(t *testing.T) {
+	t.Run("rules are persisted", func(t *testing.T) {
+		store := createKVStore(t)
+		list := &peerRuleList{store: store}
+
+		first, err := list.add(transport.PeerRule{Action: transport.DenyPeerAction, Address: "1.2.3.4"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		second, err := list.add(transport.PeerRule{Action: transport.AllowPeerAction, NodeDID: "did:nuts:123", Reason: "partner"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NotEmpty(t, first.ID)
+		assert.False(t, first.CreatedAt.IsZero())
+
+		loaded := &peerRuleList{store: store}
+		err = loaded.load()
+
+		assert.NoError(t, err)
+		assert.Len(t, loaded.list(), 2)
+		assert.Contains(t, loaded.list(), *first)
+		assert.Contains(t, loaded.list(), *second)
+	})
+	t.Run("invalid rule", func(t *testing.T) {
+		list := &peerRuleList{store: createKVStore(t)}
+
+		_, err := list.add(transport.PeerRule{Action: transport.DenyPeerAction})
+
+		assert.Error(t, err)
+		assert.Empty(t, list.list())
+	})
+	t.Run("remove", func(t *testing.T) {
+		store := createKVStore(t)
+		list := &peerRuleList{store: store}
+		rule, _ := list.add(transport.PeerRule{Action: transport.DenyPeerAction, Address: "1.2.3.4"})
+
+		err := list.remove(rule.ID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, list.list())
+		loaded := &peerRuleList{store: store}
+		_ = loaded.load()
+		assert.Empty(t, loaded.list())
+	})
+	t.Run("remove unknown rule", func(t *testing.T) {
+		list := &peerRuleList{store: createKVStore(t)}
+
+		err := list.remove("unknown")
+
+		assert.ErrorIs(t, err, transport.ErrPeerRuleNotFound)
+	})
+}
+
+func Test_manualPeers(t *testing.T) {
+	store := createKVStore(t)
+
+	assert.NoError(t, writeManualPeer(store, "foo:5555"))
+	assert.NoError(t, writeManualPeer(store, "bar:5555"))
+	assert.NoError(t, deleteManualPeer(store, "foo:5555"))
+	peers, err := readManualPeers(store)
+
+	assert.NoError(t, err)
+	if assert.Len(t, peers, 1) {
+		assert.Equal(t, "bar:5555", peers[0].Address)
+		assert.False(t, peers[0].AddedAt.IsZero())
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+)
+
+// ErrPeerNotFound is returned when a peer is not connected.
+var ErrPeerNotFound = errors.New("peer not found")
+
+// ErrPeerRuleNotFound is returned when a peer rule does not exist.
+var ErrPeerRuleNotFound = errors.New("peer rule not found")
+
+// ErrPeerNotAllowed is returned when a connection to a peer is refused because of the peer rules.
+var ErrPeerNotAllowed = errors.New("peer is not allowed")
+
+// PeerRuleAction defines the action of a PeerRule.
+type PeerRuleAction string
+
+const (
+	// AllowPeerAction specifies that matching peers are allowed. When there are allow rules, only peers matching one of them are allowed.
+	AllowPeerAction PeerRuleAction = "allow"
+	// DenyPeerAction specifies that matching peers are denied (blocked), regardless of allow rules.
+	DenyPeerAction PeerRuleAction = "deny"
+)
+
+// PeerRule determines whether the node may connect to a peer. It matches peers on address, node DID and/or TLS certificate subject.
+// When multiple criteria are specified, a peer must match all of them.
+type PeerRule struct {
+	// ID holds the unique identifier of the rule.
+	ID string `json:"id"`
+	// Action specifies whether matching peers are allowed or denied.
+	Action PeerRuleAction `json:"action"`
+	// Address matches the peer's address. If it doesn't contain a port, it matches any port on the host.
+	// Inbound peers are matched on host only, since their port is an ephemeral source port. Host names aren't resolved,
+	// so inbound peers are only matched by rules that specify their IP address.
+	Address string `json:"address,omitempty"`
+	// NodeDID matches the peer's authenticated node DID. Peers that don't present a node DID (which is optional for
+	// inbound connections) never match, so node DID deny rules can be evaded by omitting the node DID.
+	NodeDID string `json:"nodeDID,omitempty"`
+	// CertificateSubject matches the subject (distinguished name) of the peer's TLS certificate, e.g. "CN=node.example.com,O=Example".
+	CertificateSubject string `json:"certificateSubject,omitempty"`
+	// Reason holds an optional description of why the rule was added.
+	Reason string `json:"reason,omitempty"`
+	// CreatedAt holds the time the rule was added.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Validate checks whether the rule is valid.
+func (r PeerRule) Validate() error {
+	if r.Action != AllowPeerAction && r.Action != DenyPeerAction {
+		return fmt.Errorf("invalid peer rule action: %s", r.Action)
+	}
+	if r.Address == "" && r.NodeDID == "" && r.CertificateSubject == "" {
+		return errors.New("peer rule must specify an address, node DID or certificate subject")
+	}
+	if r.NodeDID != "" {
+		if _, err := did.ParseDID(r.NodeDID); err != nil {
+			return fmt.Errorf("invalid node DID in peer rule: %w", err)
+		}
+	}
+	return nil
+}
+
+// Matches checks whether the given peer matches all criteria of the rule.
+func (r PeerRule) Matches(peer Peer) bool {
+	if r.Address != "" && !addressMatches(r.Address, peer) {
+		return false
+	}
+	if r.NodeDID != "" && (peer.NodeDID.Empty() || peer.NodeDID.String() != r.NodeDID) {
+		return false
+	}
+	if r.CertificateSubject != "" && peer.CertificateSubject != r.CertificateSubject {
+		return false
+	}
+	return true
+}
+
+// String returns the rule as string, used for logging.
+func (r PeerRule) String() string {
+	result := fmt.Sprintf("%s (id=%s", r.Action, r.ID)
+	if r.Address != "" {
+		result += ", address=" + r.Address
+	}
+	if r.NodeDID != "" {
+		result += ", nodeDID=" + r.NodeDID
+	}
+	if r.CertificateSubject != "" {
+		result += ", certificateSubject=" + r.CertificateSubject
+	}
+	return result + ")"
+}
+
+// EvaluatePeerRules checks whether the given peer is allowed by the given rules. A peer is not allowed when it matches a deny rule,
+// or when there are allow rules and it matches none of them. Allow rules are only enforced when enforceAllowRules is true,
+// which allows evaluating peers of which not all properties are known yet (e.g. before connecting, when only the address is known).
+// If the peer is not allowed, an error wrapping ErrPeerNotAllowed is returned.
+func EvaluatePeerRules(rules []PeerRule, peer Peer, enforceAllowRules bool) error {
+	hasAllowRules := false
+	allowed := false
+	for _, rule := range rules {
+		switch rule.Action {
+		case DenyPeerAction:
+			if rule.Matches(peer) {
+				return fmt.Errorf("%w: denied by rule %s", ErrPeerNotAllowed, rule.ID)
+			}
+		case AllowPeerAction:
+			hasAllowRules = true
+			allowed = allowed || rule.Matches(peer)
+		}
+	}
+	if enforceAllowRules && hasAllowRules && !allowed {
+		return fmt.Errorf("%w: doesn't match any allow rule", ErrPeerNotAllowed)
+	}
+	return nil
+}
+
+func addressMatches(ruleAddress string, peer Peer) bool {
+	if peer.Address == "" {
+		return false
+	}
+	if ruleAddress == peer.Address {
+		return true
+	}
+	host, _, err := net.SplitHostPort(peer.Address)
+	if err != nil {
+		return false
+	}
+	if peer.Inbound {
+		// The port of an inbound peer is its source port, which is unrelated to the port the node listens on
+		if ruleHost, _, err := net.SplitHostPort(ruleAddress); err == nil {
+			ruleAddress = ruleHost
+		}
+	}
+	return host == ruleAddress
+}
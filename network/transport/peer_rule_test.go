@@ -0,0 +1,123 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package transport
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerRule_Validate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, PeerRule{Action: DenyPeerAction, Address: "1.2.3.4"}.Validate())
+		assert.NoError(t, PeerRule{Action: AllowPeerAction, NodeDID: "did:nuts:123"}.Validate())
+		assert.NoError(t, PeerRule{Action: DenyPeerAction, CertificateSubject: "CN=node"}.Validate())
+	})
+	t.Run("invalid action", func(t *testing.T) {
+		assert.EqualError(t, PeerRule{Action: "block", Address: "1.2.3.4"}.Validate(), "invalid peer rule action: block")
+	})
+	t.Run("no criteria", func(t *testing.T) {
+		assert.EqualError(t, PeerRule{Action: DenyPeerAction}.Validate(), "peer rule must specify an address, node DID or certificate subject")
+	})
+	t.Run("invalid node DID", func(t *testing.T) {
+		assert.ErrorContains(t, PeerRule{Action: DenyPeerAction, NodeDID: "nuts:123"}.Validate(), "invalid node DID in peer rule")
+	})
+}
+
+func TestPeerRule_Matches(t *testing.T) {
+	peer := Peer{
+		ID:                 "peer",
+		Address:            "1.2.3.4:5555",
+		NodeDID:            did.MustParseDID("did:nuts:123"),
+		CertificateSubject: "CN=node.example.com",
+	}
+
+	t.Run("address", func(t *testing.T) {
+		assert.True(t, PeerRule{Address: "1.2.3.4:5555"}.Matches(peer))
+		assert.True(t, PeerRule{Address: "1.2.3.4"}.Matches(peer))
+		assert.False(t, PeerRule{Address: "1.2.3.4:6666"}.Matches(peer))
+		assert.False(t, PeerRule{Address: "1.2.3"}.Matches(peer))
+	})
+	t.Run("address of inbound peer", func(t *testing.T) {
+		inbound := Peer{Address: "1.2.3.4:49152", Inbound: true}
+		assert.True(t, PeerRule{Address: "1.2.3.4:5555"}.Matches(inbound))
+		assert.True(t, PeerRule{Address: "1.2.3.4"}.Matches(inbound))
+		assert.False(t, PeerRule{Address: "1.2.3.5:5555"}.Matches(inbound))
+		assert.False(t, PeerRule{Address: "node.example.com:5555"}.Matches(inbound))
+		ipv6 := Peer{Address: "[::1]:49152", Inbound: true}
+		assert.True(t, PeerRule{Address: "[::1]:5555"}.Matches(ipv6))
+		assert.True(t, PeerRule{Address: "::1"}.Matches(ipv6))
+	})
+	t.Run("node DID", func(t *testing.T) {
+		assert.True(t, PeerRule{NodeDID: "did:nuts:123"}.Matches(peer))
+		assert.False(t, PeerRule{NodeDID: "did:nuts:456"}.Matches(peer))
+		assert.False(t, PeerRule{NodeDID: "did:nuts:123"}.Matches(Peer{Address: peer.Address}))
+	})
+	t.Run("certificate subject", func(t *testing.T) {
+		assert.True(t, PeerRule{CertificateSubject: "CN=node.example.com"}.Matches(peer))
+		assert.False(t, PeerRule{CertificateSubject: "CN=other.example.com"}.Matches(peer))
+	})
+	t.Run("all criteria must match", func(t *testing.T) {
+		assert.True(t, PeerRule{Address: "1.2.3.4", NodeDID: "did:nuts:123"}.Matches(peer))
+		assert.False(t, PeerRule{Address: "1.2.3.4", NodeDID: "did:nuts:456"}.Matches(peer))
+	})
+}
+
+func TestEvaluatePeerRules(t *testing.T) {
+	peer := Peer{Address: "1.2.3.4:5555", NodeDID: did.MustParseDID("did:nuts:123")}
+
+	t.Run("no rules", func(t *testing.T) {
+		assert.NoError(t, EvaluatePeerRules(nil, peer, true))
+	})
+	t.Run("denied", func(t *testing.T) {
+		rules := []PeerRule{{ID: "1", Action: DenyPeerAction, NodeDID: "did:nuts:123"}}
+
+		err := EvaluatePeerRules(rules, peer, true)
+
+		assert.ErrorIs(t, err, ErrPeerNotAllowed)
+		assert.EqualError(t, err, "peer is not allowed: denied by rule 1")
+	})
+	t.Run("deny takes precedence over allow", func(t *testing.T) {
+		rules := []PeerRule{
+			{ID: "1", Action: AllowPeerAction, Address: "1.2.3.4"},
+			{ID: "2", Action: DenyPeerAction, NodeDID: "did:nuts:123"},
+		}
+
+		assert.ErrorIs(t, EvaluatePeerRules(rules, peer, true), ErrPeerNotAllowed)
+	})
+	t.Run("allowed", func(t *testing.T) {
+		rules := []PeerRule{{ID: "1", Action: AllowPeerAction, NodeDID: "did:nuts:123"}}
+
+		assert.NoError(t, EvaluatePeerRules(rules, peer, true))
+	})
+	t.Run("not matching allow rules", func(t *testing.T) {
+		rules := []PeerRule{{ID: "1", Action: AllowPeerAction, NodeDID: "did:nuts:456"}}
+
+		err := EvaluatePeerRules(rules, peer, true)
+
+		assert.EqualError(t, err, "peer is not allowed: doesn't match any allow rule")
+	})
+	t.Run("allow rules not enforced", func(t *testing.T) {
+		rules := []PeerRule{{ID: "1", Action: AllowPeerAction, NodeDID: "did:nuts:456"}}
+
+		assert.NoError(t, EvaluatePeerRules(rules, Peer{Address: peer.Address}, false))
+	})
+}
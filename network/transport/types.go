@@ -58,8 +58,13 @@ type Peer struct {
 	// NodeDID holds the DID that the peer uses to identify its node on the network.
 	// It is only set when properly authenticated.
 	NodeDID did.DID
+	// CertificateSubject holds the subject (distinguished name) of the TLS certificate the peer presented.
+	// It is only set when TLS is enabled.
+	CertificateSubject string
 	// AcceptUnauthenticated indicates if a connection may be made with this Peer even if the NodeDID could not be authenticated.
 	AcceptUnauthenticated bool
+	// Inbound indicates the peer connected to this node. Address then holds the peer's IP address and (ephemeral) source port.
+	Inbound bool
 }
 
 // ToFields returns the peer as a map of fields, to be used when logging the peer details.
@@ -92,6 +97,22 @@ type Diagnostics struct {
 	// SoftwareID contains an indication of the vendor of the software of the node. For open source implementations it's recommended to specify URL to the public, open source repository.
 	// Proprietary implementations could specify the product's or vendor's name.
 	SoftwareID string `json:"softwareID"`
+	// Score contains the local node's accounting of the peer's behaviour. It's not shared with other nodes.
+	Score *PeerScore `json:"score,omitempty"`
+}
+
+// PeerScore holds the local node's accounting of a peer's behaviour, used to throttle or disconnect misbehaving peers.
+type PeerScore struct {
+	// Score holds the peer's misbehaviour score, which decays over time. The peer is disconnected when it reaches the configured threshold.
+	Score float64 `json:"score"`
+	// InvalidTransactions holds the number of invalid transactions the peer sent.
+	InvalidTransactions uint32 `json:"invalidTransactions"`
+	// OversizedRangeQueries holds the number of TransactionRangeQuery messages the peer sent that exceeded the maximum range.
+	OversizedRangeQueries uint32 `json:"oversizedRangeQueries"`
+	// ThrottledMessages holds the number of messages that were dropped because the peer exceeded the message rate limit.
+	ThrottledMessages uint32 `json:"throttledMessages"`
+	// MessageRate holds the number of messages per second the peer sent, measured over the last complete measurement window.
+	MessageRate float64 `json:"messageRate"`
 }
 
 // ConnectorStats holds statistics of an outbound connector.
@@ -189,6 +189,14 @@ func (cMan *conversationManager) hasActiveConversation(id transport.PeerID) bool
 	return false
 }
 
+// isActive reports whether the conversation was started by this node and hasn't expired yet.
+func (cMan *conversationManager) isActive(cid conversationID) bool {
+	cMan.mutex.RLock()
+	defer cMan.mutex.RUnlock()
+	_, ok := cMan.conversations[cid.String()]
+	return ok
+}
+
 func (cMan *conversationManager) check(envelope conversationable, data handlerData) (*conversation, error) {
 	cidBytes := envelope.conversationID()
 	cid := conversationID(cidBytes)
@@ -52,6 +52,14 @@ func (p *protocol) Handle(peer transport.Peer, raw interface{}) error {
 		WithField(core.LogFieldMessageType, fmt.Sprintf("%T", envelope.Message)).
 		Trace("Handling message from peer")
 
+	if !p.scoreMan.allowMessage(peer.ID, p.isSolicited(envelope)) {
+		log.Logger().
+			WithFields(peer.ToFields()).
+			WithField(core.LogFieldMessageType, fmt.Sprintf("%T", envelope.Message)).
+			Debug("Dropping message from peer: message rate limit exceeded")
+		return nil
+	}
+
 	err := p.handle(peer, envelope)
 	if err != nil {
 		log.Logger().
@@ -71,6 +79,18 @@ func (p *protocol) Handle(peer transport.Peer, raw interface{}) error {
 	return nil
 }
 
+// isSolicited reports whether the message is a response to a query this node sent to the peer,
+// e.g. a TransactionList answering a TransactionRangeQuery when catching up.
+func (p *protocol) isSolicited(envelope *Envelope) bool {
+	switch msg := envelope.Message.(type) {
+	case *Envelope_TransactionList:
+		return p.cMan.isActive(conversationID(msg.conversationID()))
+	case *Envelope_TransactionSet:
+		return p.cMan.isActive(conversationID(msg.conversationID()))
+	}
+	return false
+}
+
 type handleFunc func(peer transport.Peer, envelope *Envelope) error
 
 func handleASync(peer transport.Peer, envelope *Envelope, f handleFunc) error {
@@ -215,12 +235,16 @@ func (p *protocol) handleTransactionPayload(peer transport.Peer, envelope *Envel
 	}
 	if tx == nil {
 		// Weird case: transaction not present on DAG (might be attack attempt).
-		return fmt.Errorf("peer sent payload for non-existing transaction (tx=%s)", ref)
+		err = fmt.Errorf("peer sent payload for non-existing transaction (tx=%s)", ref)
+		p.scoreMan.invalidMessage(peer, err)
+		return err
 	}
 	payloadHash := hash.SHA256Sum(msg.Data)
 	if !tx.PayloadHash().Equals(payloadHash) {
 		// Possible attack: received payload does not match transaction payload hash.
-		return fmt.Errorf("peer sent payload that doesn't match payload hash (tx=%s)", ref)
+		err = fmt.Errorf("peer sent payload that doesn't match payload hash (tx=%s)", ref)
+		p.scoreMan.invalidTransaction(peer, err)
+		return err
 	}
 	if err = p.state.WritePayload(ctx, tx, payloadHash, msg.Data); err != nil {
 		return err
@@ -239,7 +263,15 @@ func (p *protocol) handleTransactionRangeQuery(peer transport.Peer, envelope *En
 		Trace("Handling TransactionRangeQuery")
 
 	if msg.Start >= msg.End {
-		return errors.New("invalid range query")
+		err := errors.New("invalid range query")
+		p.scoreMan.invalidMessage(peer, err)
+		return err
+	}
+
+	// Peers that are catching up legitimately query all transactions from a certain clock value (end = math.MaxUint32),
+	// so only bounded queries are checked against the maximum range.
+	if p.config.MaxRangeQuerySize > 0 && msg.End != math.MaxUint32 && uint64(msg.End-msg.Start) > uint64(p.config.MaxRangeQuerySize) {
+		p.scoreMan.oversizedRangeQuery(peer, msg.Start, msg.End)
 	}
 
 	ctx := context.Background()
@@ -388,7 +420,14 @@ func (p *protocol) collectTransactionList(ctx context.Context, txs []dag.Transac
 				return nil, err
 			}
 			if payload == nil {
-				return nil, fmt.Errorf("transaction is missing payload (ref=%s)", transaction.Ref())
+				// payloads of unknown types aren't stored, the transaction is sent without payload
+				rejected, err := p.state.IsPayloadRejected(ctx, transaction.Ref())
+				if err != nil {
+					return nil, err
+				}
+				if !rejected {
+					return nil, fmt.Errorf("transaction is missing payload (ref=%s)", transaction.Ref())
+				}
 			}
 			networkTX.Payload = payload
 		}
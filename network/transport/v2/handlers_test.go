@@ -58,6 +58,31 @@ func TestProtocol_handle(t *testing.T) {
 		err := p.Handle(peer, &Envelope{})
 		assert.EqualError(t, err, "message not supported")
 	})
+	t.Run("message is dropped when peer exceeds rate limit", func(t *testing.T) {
+		p, _ := newTestProtocol(t, nil)
+		p.scoreMan = newPeerScoreManager(1, 0, nil)
+		p.scoreMan.connected(peer.ID)
+
+		err := p.Handle(peer, &Envelope{})
+		assert.EqualError(t, err, "message not supported")
+		// Second message exceeds the rate limit and is not handled
+		err = p.Handle(peer, &Envelope{})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), p.scoreMan.get()[peer.ID].ThrottledMessages)
+	})
+	t.Run("solicited response is not dropped when peer exceeds rate limit", func(t *testing.T) {
+		p, _ := newTestProtocol(t, nil)
+		p.scoreMan = newPeerScoreManager(1, 0, nil)
+		p.scoreMan.connected(peer.ID)
+		query := &Envelope_TransactionRangeQuery{&TransactionRangeQuery{Start: 0, End: 5}}
+		conversation := p.cMan.startConversation(query, peer.ID)
+		response := &Envelope{Message: &Envelope_TransactionList{&TransactionList{ConversationID: conversation.conversationID.slice()}}}
+
+		_ = p.Handle(peer, &Envelope{})
+		_ = p.Handle(peer, response)
+
+		assert.Equal(t, uint32(0), p.scoreMan.get()[peer.ID].ThrottledMessages)
+	})
 }
 
 func TestProtocol_handleTransactionPayload(t *testing.T) {
@@ -76,6 +101,17 @@ func TestProtocol_handleTransactionPayload(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("error - failed to write payload", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+
+		mocks.State.EXPECT().GetTransaction(gomock.Any(), tx.Ref()).Return(tx, nil)
+		mocks.State.EXPECT().WritePayload(context.Background(), tx, tx.PayloadHash(), payload).Return(errors.New("failed"))
+
+		err := p.handleTransactionPayload(peer, &Envelope{Message: &Envelope_TransactionPayload{&TransactionPayload{TransactionRef: tx.Ref().Slice(), Data: payload}}})
+
+		assert.EqualError(t, err, "failed")
+	})
+
 	t.Run("error - no tx ref", func(t *testing.T) {
 		p, _ := newTestProtocol(t, nil)
 		envelope := &Envelope{Message: &Envelope_TransactionPayload{&TransactionPayload{}}}
@@ -99,6 +135,7 @@ func TestProtocol_handleTransactionPayload(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "peer sent payload that doesn't match payload hash")
+		assert.Equal(t, uint32(1), p.scoreMan.peers[peer.ID].InvalidTransactions)
 	})
 
 	t.Run("error - tx not present", func(t *testing.T) {
@@ -109,6 +146,7 @@ func TestProtocol_handleTransactionPayload(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "peer sent payload for non-existing transaction")
+		assert.Equal(t, float64(invalidMessagePenalty), p.scoreMan.peers[peer.ID].Score)
 	})
 }
 
@@ -449,6 +487,7 @@ func TestProtocol_handleTransactionListQuery(t *testing.T) {
 		mocks.State.EXPECT().GetTransaction(context.Background(), h1).Return(dagT1, nil)
 		mocks.State.EXPECT().GetTransaction(context.Background(), h2).Return(dagT2, nil)
 		mocks.State.EXPECT().ReadPayload(context.Background(), dagT1.PayloadHash()).Return(nil, nil)
+		mocks.State.EXPECT().IsPayloadRejected(context.Background(), dagT1.Ref()).Return(false, nil)
 
 		err := p.handleTransactionListQuery(peer, &Envelope{
 			Message: &Envelope_TransactionListQuery{&TransactionListQuery{
@@ -460,6 +499,23 @@ func TestProtocol_handleTransactionListQuery(t *testing.T) {
 		assert.ErrorContains(t, err, "transaction is missing payload")
 	})
 
+	t.Run("ok - rejected payload isn't sent", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		mocks.State.EXPECT().GetTransaction(context.Background(), h1).Return(dagT1, nil)
+		mocks.State.EXPECT().ReadPayload(context.Background(), dagT1.PayloadHash()).Return(nil, nil)
+		mocks.State.EXPECT().IsPayloadRejected(context.Background(), dagT1.Ref()).Return(true, nil)
+		mocks.Sender.EXPECT().sendTransactionList(peer.ID, conversationID, []*Transaction{{Data: dagT1.Data()}})
+
+		err := p.handleTransactionListQuery(peer, &Envelope{
+			Message: &Envelope_TransactionListQuery{&TransactionListQuery{
+				ConversationID: conversationID.slice(),
+				Refs:           [][]byte{h1.Slice()},
+			}},
+		})
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("ok - empty request", func(t *testing.T) {
 		p, _ := newTestProtocol(t, nil)
 
@@ -509,6 +565,42 @@ func TestProtocol_handleTransactionRangeQuery(t *testing.T) {
 		err := p.handleTransactionRangeQuery(peer, &Envelope{Message: msg})
 
 		assert.EqualError(t, err, "invalid range query")
+		assert.Equal(t, float64(invalidMessagePenalty), p.scoreMan.peers[peer.ID].Score)
+	})
+	t.Run("oversized range query", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		p.config.MaxRangeQuerySize = 1
+
+		mocks.State.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(2)).Return([]dag.Transaction{tx1, tx2}, nil)
+		mocks.State.EXPECT().ReadPayload(gomock.Any(), tx1.PayloadHash()).Return(payload, nil)
+		mocks.State.EXPECT().ReadPayload(gomock.Any(), tx2.PayloadHash()).Return(payload, nil)
+		mocks.Sender.EXPECT().sendTransactionList(peer.ID, gomock.Any(), gomock.Any())
+
+		msg := &Envelope_TransactionRangeQuery{&TransactionRangeQuery{
+			Start: 0,
+			End:   2,
+		}}
+		err := p.handleTransactionRangeQuery(peer, &Envelope{Message: msg})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), p.scoreMan.peers[peer.ID].OversizedRangeQueries)
+	})
+	t.Run("open-ended range query isn't oversized", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		p.config.MaxRangeQuerySize = 1
+
+		mocks.State.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(math.MaxUint32)).Return([]dag.Transaction{tx1, tx2}, nil)
+		mocks.State.EXPECT().ReadPayload(gomock.Any(), gomock.Any()).Return(payload, nil).Times(2)
+		mocks.Sender.EXPECT().sendTransactionList(peer.ID, gomock.Any(), gomock.Any())
+
+		msg := &Envelope_TransactionRangeQuery{&TransactionRangeQuery{
+			Start: 0,
+			End:   math.MaxUint32,
+		}}
+		err := p.handleTransactionRangeQuery(peer, &Envelope{Message: msg})
+
+		assert.NoError(t, err)
+		assert.Nil(t, p.scoreMan.peers[peer.ID])
 	})
 	t.Run("error - DAG reading error", func(t *testing.T) {
 		p, mocks := newTestProtocol(t, nil)
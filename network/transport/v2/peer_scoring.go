@@ -0,0 +1,234 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v2
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/network/log"
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// invalidTransactionPenalty is added to a peer's score when it sends an invalid transaction.
+	invalidTransactionPenalty = 25
+	// invalidMessagePenalty is added to a peer's score when it sends a malformed or unsolicited message.
+	invalidMessagePenalty = 10
+	// oversizedRangeQueryPenalty is added to a peer's score when it sends a TransactionRangeQuery exceeding the maximum range.
+	oversizedRangeQueryPenalty = 10
+	// throttledMessagePenalty is added to a peer's score for every message dropped because it exceeded the message rate limit.
+	throttledMessagePenalty = 1
+	// scoreHalfLife specifies how fast a peer's score decays: it halves every scoreHalfLife.
+	scoreHalfLife = 10 * time.Minute
+	// messageRateWindow specifies the window over which a peer's message rate is measured.
+	messageRateWindow = 10 * time.Second
+)
+
+// peerScoreManager keeps track of the behaviour of peers:
+// - throttling peers that send more messages than allowed by the message rate limit
+// - penalizing peers for misbehaviour (e.g. sending invalid transactions), disconnecting them when their score reaches the threshold
+// The score of a peer decays over time, so incidental misbehaviour (e.g. caused by a bug) doesn't lead to disconnection.
+type peerScoreManager struct {
+	messageRateLimit int
+	threshold        float64
+	disconnector     func(peerID transport.PeerID)
+	mux              *sync.Mutex
+	peers            map[transport.PeerID]*peerScore
+	nowFunc          func() time.Time
+}
+
+type peerScore struct {
+	transport.PeerScore
+	connected   bool
+	limiter     *rate.Limiter
+	updated     time.Time
+	windowStart time.Time
+	windowCount uint32
+	// disconnectPending indicates the peer has been disconnected for reaching the threshold, but the connection isn't closed yet.
+	// It's reset when the peer (re)connects.
+	disconnectPending bool
+}
+
+func newPeerScoreManager(messageRateLimit int, threshold int, disconnector func(peerID transport.PeerID)) *peerScoreManager {
+	return &peerScoreManager{
+		messageRateLimit: messageRateLimit,
+		threshold:        float64(threshold),
+		disconnector:     disconnector,
+		mux:              &sync.Mutex{},
+		peers:            make(map[transport.PeerID]*peerScore),
+		nowFunc:          time.Now,
+	}
+}
+
+// allowMessage registers a message received from the peer and reports whether it may be handled.
+// If the peer exceeded the message rate limit, the message should be dropped and the peer is penalized.
+// Solicited messages (responses to queries sent by this node) are counted, but never dropped:
+// the node asked for them itself, e.g. when catching up.
+func (m *peerScoreManager) allowMessage(peerID transport.PeerID, solicited bool) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	now := m.nowFunc()
+	score := m.getOrCreate(peerID, now)
+	if now.Sub(score.windowStart) >= messageRateWindow {
+		score.MessageRate = float64(score.windowCount) / now.Sub(score.windowStart).Seconds()
+		score.windowStart = now
+		score.windowCount = 0
+	}
+	score.windowCount++
+	if solicited || score.limiter == nil || score.limiter.AllowN(now, 1) {
+		return true
+	}
+	score.ThrottledMessages++
+	m.penalize(peerID, score, now, throttledMessagePenalty)
+	return false
+}
+
+// invalidTransaction penalizes the peer for sending an invalid transaction.
+func (m *peerScoreManager) invalidTransaction(peer transport.Peer, err error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	now := m.nowFunc()
+	score := m.getOrCreate(peer.ID, now)
+	score.InvalidTransactions++
+	m.logMisbehaviour(peer, err)
+	m.penalize(peer.ID, score, now, invalidTransactionPenalty)
+}
+
+// invalidMessage penalizes the peer for sending a malformed or unsolicited message.
+func (m *peerScoreManager) invalidMessage(peer transport.Peer, err error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	now := m.nowFunc()
+	m.logMisbehaviour(peer, err)
+	m.penalize(peer.ID, m.getOrCreate(peer.ID, now), now, invalidMessagePenalty)
+}
+
+// oversizedRangeQuery penalizes the peer for sending a TransactionRangeQuery that exceeds the maximum range.
+func (m *peerScoreManager) oversizedRangeQuery(peer transport.Peer, start uint32, end uint32) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	now := m.nowFunc()
+	score := m.getOrCreate(peer.ID, now)
+	score.OversizedRangeQueries++
+	log.Logger().
+		WithFields(peer.ToFields()).
+		Debugf("Peer sent oversized TransactionRangeQuery (start=%d, end=%d)", start, end)
+	m.penalize(peer.ID, score, now, oversizedRangeQueryPenalty)
+}
+
+// connected registers the peer as connected, which enables rate limiting its messages.
+// If the peer's retained score is still at or above the threshold, it is disconnected again.
+func (m *peerScoreManager) connected(peerID transport.PeerID) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	now := m.nowFunc()
+	score := m.getOrCreate(peerID, now)
+	score.connected = true
+	score.disconnectPending = false
+	if m.messageRateLimit > 0 {
+		score.limiter = rate.NewLimiter(rate.Limit(m.messageRateLimit), m.messageRateLimit)
+	}
+	m.checkThreshold(peerID, score, now)
+}
+
+// disconnected registers the peer as disconnected. The score of a disconnected peer is retained until it has decayed,
+// so a misbehaving peer can't reset its score by reconnecting.
+func (m *peerScoreManager) disconnected(peerID transport.PeerID) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if score, ok := m.peers[peerID]; ok {
+		score.connected = false
+		score.limiter = nil
+		score.MessageRate = 0
+	}
+	// Prune disconnected peers whose score has decayed
+	now := m.nowFunc()
+	for id, score := range m.peers {
+		if !score.connected && score.decay(now) < 1 {
+			delete(m.peers, id)
+		}
+	}
+}
+
+// get returns the scores of the connected peers.
+func (m *peerScoreManager) get() map[transport.PeerID]transport.PeerScore {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	now := m.nowFunc()
+	result := make(map[transport.PeerID]transport.PeerScore)
+	for id, score := range m.peers {
+		if score.connected {
+			score.decay(now)
+			result[id] = score.PeerScore
+		}
+	}
+	return result
+}
+
+func (m *peerScoreManager) getOrCreate(peerID transport.PeerID, now time.Time) *peerScore {
+	score, ok := m.peers[peerID]
+	if !ok {
+		score = &peerScore{updated: now, windowStart: now}
+		m.peers[peerID] = score
+	}
+	return score
+}
+
+// penalize adds the penalty to the peer's score, disconnecting it when it reaches the threshold.
+// Callers must hold the lock.
+func (m *peerScoreManager) penalize(peerID transport.PeerID, score *peerScore, now time.Time, penalty float64) {
+	score.Score = score.decay(now) + penalty
+	m.checkThreshold(peerID, score, now)
+}
+
+// checkThreshold disconnects the peer when its score is at or above the threshold.
+// A peer is disconnected only once per connection, to avoid repeatedly disconnecting it while the connection is being closed.
+// Callers must hold the lock.
+func (m *peerScoreManager) checkThreshold(peerID transport.PeerID, score *peerScore, now time.Time) {
+	if m.threshold <= 0 || score.disconnectPending || score.decay(now) < m.threshold {
+		return
+	}
+	score.disconnectPending = true
+	log.Logger().
+		WithField(core.LogFieldPeerID, peerID.String()).
+		Warnf("Disconnecting peer: misbehaviour score reached threshold (score=%.1f, threshold=%.0f)", score.Score, m.threshold)
+	// Disconnect asynchronously, since the caller might be the connection's receiving routine
+	go m.disconnector(peerID)
+}
+
+func (m *peerScoreManager) logMisbehaviour(peer transport.Peer, err error) {
+	log.Logger().
+		WithError(err).
+		WithFields(peer.ToFields()).
+		Debug("Peer misbehaved")
+}
+
+// decay applies the decay of the score since it was last updated and returns the resulting score.
+func (s *peerScore) decay(now time.Time) float64 {
+	elapsed := now.Sub(s.updated)
+	if elapsed > 0 {
+		s.Score = s.Score * math.Pow(0.5, float64(elapsed)/float64(scoreHalfLife))
+		s.updated = now
+	}
+	return s.Score
+}
@@ -0,0 +1,189 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v2
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/nuts-foundation/nuts-node/test"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func Test_peerScoreManager(t *testing.T) {
+	peer := transport.Peer{ID: "peer"}
+	newManager := func(messageRateLimit int, threshold int) (*peerScoreManager, *time.Time, *atomic.Int32) {
+		disconnects := atomic.NewInt32(0)
+		manager := newPeerScoreManager(messageRateLimit, threshold, func(peerID transport.PeerID) {
+			disconnects.Inc()
+		})
+		now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		manager.nowFunc = func() time.Time {
+			return now
+		}
+		manager.connected(peer.ID)
+		return manager, &now, disconnects
+	}
+
+	t.Run("penalties are accounted", func(t *testing.T) {
+		manager, _, disconnects := newManager(0, 100)
+
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		manager.invalidMessage(peer, errors.New("invalid"))
+		manager.oversizedRangeQuery(peer, 0, 100000)
+
+		score := manager.get()[peer.ID]
+		assert.Equal(t, float64(invalidTransactionPenalty+invalidMessagePenalty+oversizedRangeQueryPenalty), score.Score)
+		assert.Equal(t, uint32(1), score.InvalidTransactions)
+		assert.Equal(t, uint32(1), score.OversizedRangeQueries)
+		assert.Equal(t, int32(0), disconnects.Load())
+	})
+	t.Run("score decays", func(t *testing.T) {
+		manager, now, _ := newManager(0, 100)
+		manager.invalidTransaction(peer, errors.New("invalid"))
+
+		*now = now.Add(scoreHalfLife)
+
+		assert.InDelta(t, float64(invalidTransactionPenalty)/2, manager.get()[peer.ID].Score, 0.01)
+	})
+	t.Run("peer is disconnected when reaching threshold", func(t *testing.T) {
+		manager, _, disconnects := newManager(0, 50)
+
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		// Already over threshold, shouldn't trigger another disconnect
+		manager.invalidTransaction(peer, errors.New("invalid"))
+
+		test.WaitFor(t, func() (bool, error) {
+			return disconnects.Load() == 1, nil
+		}, time.Second, "time-out while waiting for peer to be disconnected")
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int32(1), disconnects.Load())
+	})
+	t.Run("peer is disconnected again when penalized after reconnecting", func(t *testing.T) {
+		manager, now, disconnects := newManager(0, 50)
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		test.WaitFor(t, func() (bool, error) {
+			return disconnects.Load() == 1, nil
+		}, time.Second, "time-out while waiting for peer to be disconnected")
+		manager.disconnected(peer.ID)
+
+		// Score decayed below the threshold, so the peer may reconnect
+		*now = now.Add(scoreHalfLife)
+		manager.connected(peer.ID)
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int32(1), disconnects.Load())
+
+		manager.invalidTransaction(peer, errors.New("invalid"))
+
+		test.WaitFor(t, func() (bool, error) {
+			return disconnects.Load() == 2, nil
+		}, time.Second, "time-out while waiting for peer to be disconnected again")
+	})
+	t.Run("peer is disconnected when reconnecting with score over threshold", func(t *testing.T) {
+		manager, _, disconnects := newManager(0, 50)
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		manager.invalidTransaction(peer, errors.New("invalid"))
+		test.WaitFor(t, func() (bool, error) {
+			return disconnects.Load() == 1, nil
+		}, time.Second, "time-out while waiting for peer to be disconnected")
+		manager.disconnected(peer.ID)
+
+		manager.connected(peer.ID)
+		manager.invalidTransaction(peer, errors.New("invalid"))
+
+		test.WaitFor(t, func() (bool, error) {
+			return disconnects.Load() == 2, nil
+		}, time.Second, "time-out while waiting for peer to be disconnected again")
+		// Only disconnected once per connection
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int32(2), disconnects.Load())
+	})
+	t.Run("peer is not disconnected when threshold is disabled", func(t *testing.T) {
+		manager, _, disconnects := newManager(0, 0)
+
+		for i := 0; i < 10; i++ {
+			manager.invalidTransaction(peer, errors.New("invalid"))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int32(0), disconnects.Load())
+	})
+	t.Run("messages exceeding the rate limit are throttled", func(t *testing.T) {
+		manager, now, _ := newManager(5, 100)
+
+		for i := 0; i < 5; i++ {
+			assert.True(t, manager.allowMessage(peer.ID, false))
+		}
+		assert.False(t, manager.allowMessage(peer.ID, false))
+		score := manager.get()[peer.ID]
+		assert.Equal(t, uint32(1), score.ThrottledMessages)
+		assert.Equal(t, float64(throttledMessagePenalty), score.Score)
+
+		// Tokens are replenished over time
+		*now = now.Add(time.Second)
+		assert.True(t, manager.allowMessage(peer.ID, false))
+	})
+	t.Run("solicited messages aren't throttled", func(t *testing.T) {
+		manager, _, _ := newManager(5, 100)
+
+		for i := 0; i < 5; i++ {
+			assert.True(t, manager.allowMessage(peer.ID, false))
+		}
+		assert.True(t, manager.allowMessage(peer.ID, true))
+		assert.Equal(t, uint32(0), manager.get()[peer.ID].ThrottledMessages)
+	})
+	t.Run("messages aren't throttled when rate limit is disabled", func(t *testing.T) {
+		manager, _, _ := newManager(0, 100)
+
+		for i := 0; i < 1000; i++ {
+			assert.True(t, manager.allowMessage(peer.ID, false))
+		}
+	})
+	t.Run("message rate is measured", func(t *testing.T) {
+		manager, now, _ := newManager(0, 100)
+
+		for i := 0; i < 20; i++ {
+			manager.allowMessage(peer.ID, false)
+		}
+		*now = now.Add(messageRateWindow)
+		manager.allowMessage(peer.ID, false)
+
+		assert.Equal(t, float64(2), manager.get()[peer.ID].MessageRate)
+	})
+	t.Run("score is retained after disconnect until it decayed", func(t *testing.T) {
+		manager, now, _ := newManager(0, 100)
+		manager.invalidTransaction(peer, errors.New("invalid"))
+
+		manager.disconnected(peer.ID)
+
+		assert.Empty(t, manager.get())
+		manager.connected(peer.ID)
+		assert.Equal(t, float64(invalidTransactionPenalty), manager.get()[peer.ID].Score)
+
+		manager.disconnected(peer.ID)
+		*now = now.Add(10 * scoreHalfLife)
+		manager.disconnected("other")
+		assert.Empty(t, manager.peers)
+	})
+}
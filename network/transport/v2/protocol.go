@@ -53,11 +53,22 @@ type Config struct {
 	GossipInterval int `koanf:"gossipinterval"`
 	// DiagnosticsInterval specifies how often (in milliseconds) the node should broadcast its diagnostics message.
 	DiagnosticsInterval int `koanf:"diagnosticsinterval"`
+	// MessageRateLimit specifies the maximum number of messages per second a peer may send.
+	// Messages exceeding the limit are dropped and count as misbehaviour (specify 0 to disable).
+	MessageRateLimit int `koanf:"messageratelimit"`
+	// MaxRangeQuerySize specifies the maximum number of Lamport clock values a TransactionRangeQuery from a peer may span.
+	// Larger queries count as misbehaviour.
+	MaxRangeQuerySize int `koanf:"maxrangequerysize"`
+	// PeerScoreThreshold specifies the misbehaviour score at which a peer is disconnected (specify 0 to disable).
+	PeerScoreThreshold int `koanf:"peerscorethreshold"`
 }
 
 const defaultPayloadRetryDelay = 5 * time.Second
 const defaultGossipInterval = 5000
 const defaultDiagnosticsInterval = 5000
+const defaultMessageRateLimit = 100
+const defaultMaxRangeQuerySize = 10 * int(dag.PageSize)
+const defaultPeerScoreThreshold = 100
 
 // DefaultConfig returns the default config for protocol v2
 func DefaultConfig() Config {
@@ -65,6 +76,9 @@ func DefaultConfig() Config {
 		PayloadRetryDelay:   defaultPayloadRetryDelay,
 		GossipInterval:      defaultGossipInterval,
 		DiagnosticsInterval: defaultDiagnosticsInterval,
+		MessageRateLimit:    defaultMessageRateLimit,
+		MaxRangeQuerySize:   defaultMaxRangeQuerySize,
+		PeerScoreThreshold:  defaultPeerScoreThreshold,
 	}
 }
 
@@ -91,6 +105,7 @@ func New(
 	}
 	p.sender = p
 	p.diagnosticsMan = newPeerDiagnosticsManager(diagnosticsProvider, p.sender.broadcastDiagnostics)
+	p.scoreMan = newPeerScoreManager(config.MessageRateLimit, config.PeerScoreThreshold, p.disconnectPeer)
 	return p
 }
 
@@ -108,6 +123,7 @@ type protocol struct {
 	cMan                   *conversationManager
 	gManager               gossip.Manager
 	diagnosticsMan         *peerDiagnosticsManager
+	scoreMan               *peerScoreManager
 	sender                 messageSender
 	listHandler            *transactionListHandler
 	dagStore               stoabs.KVStore
@@ -206,8 +222,10 @@ func (p *protocol) connectionStateCallback(peer transport.Peer, state transport.
 			xor, clock := p.state.XOR(context.Background(), math.MaxUint32)
 			p.gManager.PeerConnected(peer, xor, clock)
 			p.diagnosticsMan.add(peer.ID)
+			p.scoreMan.connected(peer.ID)
 		case transport.StateDisconnected:
 			p.diagnosticsMan.remove(peer.ID)
+			p.scoreMan.disconnected(peer.ID)
 			p.gManager.PeerDisconnected(peer)
 		}
 	}
@@ -320,7 +338,25 @@ func (p protocol) Diagnostics() []core.DiagnosticResult {
 }
 
 func (p protocol) PeerDiagnostics() map[transport.PeerID]transport.Diagnostics {
-	return p.diagnosticsMan.get()
+	result := p.diagnosticsMan.get()
+	for peerID, score := range p.scoreMan.get() {
+		if diagnostics, ok := result[peerID]; ok {
+			peerScore := score
+			diagnostics.Score = &peerScore
+			result[peerID] = diagnostics
+		}
+	}
+	return result
+}
+
+// disconnectPeer is called by the peerScoreManager to disconnect a misbehaving peer.
+func (p *protocol) disconnectPeer(peerID transport.PeerID) {
+	if err := p.connectionManager.Disconnect(peerID); err != nil {
+		log.Logger().
+			WithError(err).
+			WithField(core.LogFieldPeerID, peerID.String()).
+			Warn("Failed to disconnect misbehaving peer")
+	}
 }
 
 func (p *protocol) send(peer transport.Peer, message isEnvelope_Message) error {
@@ -141,12 +141,52 @@ func TestProtocol_Diagnostics(t *testing.T) {
 }
 
 func TestProtocol_PeerDiagnostics(t *testing.T) {
-	mgr := newPeerDiagnosticsManager(nil, nil)
-	expected := map[transport.PeerID]transport.Diagnostics{
-		transport.PeerID("1234"): {SoftwareID: "4321", Peers: []transport.PeerID{}},
-	}
-	mgr.received = expected
-	assert.Equal(t, expected, protocol{diagnosticsMan: mgr}.PeerDiagnostics())
+	t.Run("ok", func(t *testing.T) {
+		mgr := newPeerDiagnosticsManager(nil, nil)
+		expected := map[transport.PeerID]transport.Diagnostics{
+			transport.PeerID("1234"): {SoftwareID: "4321", Peers: []transport.PeerID{}},
+		}
+		mgr.received = expected
+		assert.Equal(t, expected, protocol{diagnosticsMan: mgr, scoreMan: newPeerScoreManager(0, 0, nil)}.PeerDiagnostics())
+	})
+	t.Run("includes peer scores", func(t *testing.T) {
+		mgr := newPeerDiagnosticsManager(nil, nil)
+		mgr.received = map[transport.PeerID]transport.Diagnostics{
+			transport.PeerID("1234"): {SoftwareID: "4321", Peers: []transport.PeerID{}},
+		}
+		scoreMan := newPeerScoreManager(0, 0, nil)
+		scoreMan.connected("1234")
+		scoreMan.connected("5678")
+		scoreMan.invalidTransaction(transport.Peer{ID: "1234"}, errors.New("invalid"))
+
+		actual := protocol{diagnosticsMan: mgr, scoreMan: scoreMan}.PeerDiagnostics()
+
+		assert.Len(t, actual, 1)
+		if !assert.NotNil(t, actual["1234"].Score) {
+			return
+		}
+		assert.Equal(t, uint32(1), actual["1234"].Score.InvalidTransactions)
+		assert.InDelta(t, invalidTransactionPenalty, actual["1234"].Score.Score, 0.1)
+	})
+}
+
+func TestProtocol_disconnectPeer(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		proto, mocks := newTestProtocol(t, nil)
+		connectionManager := transport.NewMockConnectionManager(mocks.Controller)
+		proto.connectionManager = connectionManager
+		connectionManager.EXPECT().Disconnect(peer.ID).Return(nil)
+
+		proto.disconnectPeer(peer.ID)
+	})
+	t.Run("error is logged", func(t *testing.T) {
+		proto, mocks := newTestProtocol(t, nil)
+		connectionManager := transport.NewMockConnectionManager(mocks.Controller)
+		proto.connectionManager = connectionManager
+		connectionManager.EXPECT().Disconnect(peer.ID).Return(transport.ErrPeerNotFound)
+
+		proto.disconnectPeer(peer.ID)
+	})
 }
 
 func TestProtocol_MethodName(t *testing.T) {
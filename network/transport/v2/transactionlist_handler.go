@@ -89,21 +89,26 @@ func (p *protocol) handleTransactionList(peer transport.Peer, envelope *Envelope
 		WithField(core.LogFieldConversationID, cid).
 		Tracef("Handling handleTransactionList from peer (message=%d/%d)", msg.MessageNumber, msg.TotalMessages)
 
-	// check if response matches earlier request
-	if _, err := p.cMan.check(subEnvelope, data); err != nil {
+	// parse transactions before checking the conversation, so invalid transactions are accounted to the peer
+	txs, err := subEnvelope.parseTransactions(data)
+	if err != nil {
+		p.scoreMan.invalidTransaction(peer, err)
 		return err
 	}
 
-	txs, err := subEnvelope.parseTransactions(data)
-	if err != nil {
+	// check if response matches earlier request
+	if _, err := p.cMan.check(subEnvelope, data); err != nil {
 		return err
 	}
 
 	ctx := context.Background()
 	for i, tx := range txs {
 		// TODO does this always trigger fetching missing payloads? (through observer on DAG) Prolly not for v2
-		if len(tx.PAL()) == 0 && len(msg.Transactions[i].Payload) == 0 {
-			return fmt.Errorf("peer did not provide payload for transaction (tx=%s)", tx.Ref())
+		// payloads of unknown types aren't stored (and thus not sent) by peers that reject them, as this node does
+		if len(tx.PAL()) == 0 && len(msg.Transactions[i].Payload) == 0 && p.state.IsPayloadTypeAccepted(tx.PayloadType()) {
+			err = fmt.Errorf("peer did not provide payload for transaction (tx=%s)", tx.Ref())
+			p.scoreMan.invalidTransaction(peer, err)
+			return err
 		}
 		if err = p.state.Add(ctx, tx, msg.Transactions[i].Payload); err != nil {
 			if errors.Is(err, dag.ErrPreviousTransactionMissing) {
@@ -116,6 +121,18 @@ func (p *protocol) handleTransactionList(peer transport.Peer, envelope *Envelope
 				xor, clock := p.state.XOR(ctx, math.MaxUint32)
 				return p.sender.sendState(peer.ID, xor, clock)
 			}
+			if errors.Is(err, dag.ErrImportInProgress) {
+				// Gossip is suspended while importing a snapshot, missing transactions are retrieved after the import.
+				p.cMan.done(cid)
+				log.Logger().
+					WithFields(peer.ToFields()).
+					WithField(core.LogFieldConversationID, cid).
+					Debug("Ignoring TransactionList while importing DAG snapshot")
+				return nil
+			}
+			if errors.As(err, &dag.VerificationError{}) {
+				p.scoreMan.invalidTransaction(peer, err)
+			}
 			return fmt.Errorf("unable to add received transaction to DAG (tx=%s): %w", tx.Ref(), err)
 		}
 	}
@@ -113,6 +113,19 @@ func TestProtocol_handleTransactionList(t *testing.T) {
 		assert.Nil(t, p.cMan.conversations[conversation.conversationID.String()])
 	})
 
+	t.Run("ok - snapshot import in progress", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		conversation := p.cMan.startConversation(request, peerID)
+		envelope := envelopeWithConversation(conversation)
+		mocks.State.EXPECT().Add(context.Background(), tx, payload).Return(dag.ErrImportInProgress)
+
+		err := p.handleTransactionList(peer, envelope)
+
+		assert.NoError(t, err)
+		assert.Nil(t, p.cMan.conversations[conversation.conversationID.String()])
+		assert.Empty(t, p.scoreMan.get()[peer.ID].Score)
+	})
+
 	t.Run("ok - conversation marked as done", func(t *testing.T) {
 		p, mocks := newTestProtocol(t, nil)
 		conversation := p.cMan.startConversation(request, peerID)
@@ -160,11 +173,42 @@ func TestProtocol_handleTransactionList(t *testing.T) {
 		err := p.handleTransactionList(peer, envelope)
 
 		assert.EqualError(t, err, fmt.Sprintf("unable to add received transaction to DAG (tx=%s): custom", tx.Ref().String()))
+		assert.Nil(t, p.scoreMan.peers[peer.ID], "peer shouldn't be penalized for local errors")
+	})
+
+	t.Run("error - transaction verification failed", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		conversation := p.cMan.startConversation(request, peerID)
+		envelope := envelopeWithConversation(conversation)
+		mocks.State.EXPECT().Add(context.Background(), tx, payload).Return(dag.VerificationError{Ref: tx.Ref(), Err: errors.New("invalid signature")})
+
+		err := p.handleTransactionList(peer, envelope)
+
+		assert.ErrorContains(t, err, "invalid signature")
+		assert.Equal(t, uint32(1), p.scoreMan.peers[peer.ID].InvalidTransactions)
+	})
+
+	t.Run("ok - no payload for TX of unknown payload type", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		conversation := p.cMan.startConversation(request, peerID)
+		mocks.State.EXPECT().IsPayloadTypeAccepted(tx.PayloadType()).Return(false)
+		mocks.State.EXPECT().Add(context.Background(), tx, nil).Return(nil)
+
+		err := p.handleTransactionList(peer, &Envelope{Message: &Envelope_TransactionList{
+			TransactionList: &TransactionList{
+				ConversationID: conversation.conversationID.slice(),
+				Transactions:   []*Transaction{{Data: data}},
+			},
+		}})
+
+		assert.NoError(t, err)
+		assert.Nil(t, p.scoreMan.peers[peer.ID])
 	})
 
 	t.Run("error - missing payload for TX without PAL", func(t *testing.T) {
-		p, _ := newTestProtocol(t, nil)
+		p, mocks := newTestProtocol(t, nil)
 		conversation := p.cMan.startConversation(request, peerID)
+		mocks.State.EXPECT().IsPayloadTypeAccepted(tx.PayloadType()).Return(true)
 
 		err := p.handleTransactionList(peer, &Envelope{Message: &Envelope_TransactionList{
 			TransactionList: &TransactionList{
@@ -174,6 +218,7 @@ func TestProtocol_handleTransactionList(t *testing.T) {
 		}})
 
 		assert.ErrorContains(t, err, "peer did not provide payload for transaction")
+		assert.Equal(t, uint32(1), p.scoreMan.peers[peer.ID].InvalidTransactions)
 	})
 
 	t.Run("error - invalid transaction", func(t *testing.T) {
@@ -188,6 +233,7 @@ func TestProtocol_handleTransactionList(t *testing.T) {
 		}})
 
 		assert.EqualError(t, err, "received transaction is invalid: unable to parse transaction: invalid compact serialization format: invalid number of segments")
+		assert.Equal(t, uint32(1), p.scoreMan.peers[peer.ID].InvalidTransactions)
 	})
 
 	t.Run("error - unknown conversationID", func(t *testing.T) {
@@ -64,6 +64,12 @@ func NewAmbassador(networkClient network.Transactions, writer Writer, verifier v
 
 // Configure instructs the ambassador to start receiving DID Documents from the network.
 func (n ambassador) Configure() error {
+	if err := n.networkClient.RegisterPayloadType(types.VcDocumentType, validateCredentialPayload); err != nil {
+		return err
+	}
+	if err := n.networkClient.RegisterPayloadType(types.RevocationLDDocumentType, validateRevocationPayload); err != nil {
+		return err
+	}
 	err := n.networkClient.Subscribe("vcr_vcs", n.handleNetworkVCs,
 		n.networkClient.WithPersistency(),
 		network.WithSelectionFilter(func(event dag.Event) bool {
@@ -178,6 +184,18 @@ func (n ambassador) vcCallback(tx dag.Transaction, payload []byte) error {
 	return n.writer.StoreCredential(target, &validAt)
 }
 
+// validateCredentialPayload checks whether the payload of a credential transaction can be parsed as Verifiable Credential,
+// before it's processed. The credential itself is verified later on.
+func validateCredentialPayload(payload []byte) error {
+	return json.Unmarshal(payload, &vc.VerifiableCredential{})
+}
+
+// validateRevocationPayload checks whether the payload of a revocation transaction can be parsed as revocation,
+// before it's processed. The revocation itself is verified later on.
+func validateRevocationPayload(payload []byte) error {
+	return json.Unmarshal(payload, &credential.Revocation{})
+}
+
 // jsonLDRevocationCallback gets called when new credential revocations are received by the network.
 // These revocations are in the form of a JSON-LD document.
 // All checks on the signature are already performed.
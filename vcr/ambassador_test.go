@@ -56,6 +56,8 @@ func TestAmbassador_Configure(t *testing.T) {
 		nMock := network.NewMockTransactions(ctrl)
 
 		a := NewAmbassador(nMock, nil, nil, nil)
+		nMock.EXPECT().RegisterPayloadType(types.VcDocumentType, gomock.Any())
+		nMock.EXPECT().RegisterPayloadType(types.RevocationLDDocumentType, gomock.Any())
 		nMock.EXPECT().WithPersistency().Times(2)
 		nMock.EXPECT().Subscribe("vcr_vcs", gomock.Any(), gomock.Any())
 		nMock.EXPECT().Subscribe("vcr_revocations", gomock.Any(), gomock.Any())
@@ -251,3 +253,23 @@ func Test_ambassador_jsonLDRevocationCallback(t *testing.T) {
 		assert.EqualError(t, err, "foo")
 	})
 }
+
+func Test_validateCredentialPayload(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, validateCredentialPayload([]byte(jsonld.TestCredential)))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, validateCredentialPayload([]byte("b00m")))
+	})
+}
+
+func Test_validateRevocationPayload(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		payload, _ := os.ReadFile("test/ld-revocation.json")
+
+		assert.NoError(t, validateRevocationPayload(payload))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, validateRevocationPayload([]byte("b00m")))
+	})
+}
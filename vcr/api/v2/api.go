@@ -25,6 +25,7 @@ import (
 
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
 	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	vdrTypes "github.com/nuts-foundation/nuts-node/vdr/types"
@@ -38,7 +39,9 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/vcr"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/nuts-foundation/nuts-node/vcr/trust"
 )
 
 var clockFn = func() time.Time {
@@ -61,11 +64,17 @@ func (w *Wrapper) Routes(router core.EchoRouter) {
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
 func (w *Wrapper) ResolveStatusCode(err error) int {
 	return core.ResolveStatusCode(err, map[error]int{
-		vcrTypes.ErrNotFound:        http.StatusNotFound,
-		vdrTypes.ErrServiceNotFound: http.StatusPreconditionFailed,
-		vcrTypes.ErrRevoked:         http.StatusConflict,
-		vdrTypes.ErrNotFound:        http.StatusBadRequest,
-		vdrTypes.ErrKeyNotFound:     http.StatusBadRequest,
+		vcrTypes.ErrNotFound:            http.StatusNotFound,
+		vdrTypes.ErrServiceNotFound:     http.StatusPreconditionFailed,
+		vcrTypes.ErrRevoked:             http.StatusConflict,
+		vdrTypes.ErrNotFound:            http.StatusBadRequest,
+		vdrTypes.ErrKeyNotFound:         http.StatusBadRequest,
+		vcr.ErrInvalidSearchCursor:      http.StatusBadRequest,
+		trust.ErrPolicyNotFound:         http.StatusNotFound,
+		trust.ErrInvalidPolicy:          http.StatusBadRequest,
+		trust.ErrInvalidTrustList:       http.StatusBadRequest,
+		trust.ErrUnknownTrustListSigner: http.StatusBadRequest,
+		trust.ErrStaleTrustList:         http.StatusBadRequest,
 	})
 }
 
@@ -94,6 +103,10 @@ func (w Wrapper) IssueVC(ctx echo.Context) error {
 	} else {
 		publish = true
 	}
+	issueSDJWT := issueRequest.Format != nil && *issueRequest.Format == IssueVCRequestFormatVcSdJwt
+	if issueSDJWT && publish {
+		return core.InvalidInputError("credentials in vc+sd-jwt format can't be published to the network")
+	}
 
 	// Check param constraints:
 	if issueRequest.Visibility == nil || *issueRequest.Visibility == "" {
@@ -134,7 +147,21 @@ func (w Wrapper) IssueVC(ctx echo.Context) error {
 		return err
 	}
 
-	vcCreated, err := w.VCR.Issuer().Issue(requestedVC, publish, public)
+	if issueSDJWT {
+		sdJWT, err := w.VCR.Issuer().IssueSDJWT(requestedVC)
+		if err != nil {
+			return err
+		}
+		return ctx.Blob(http.StatusOK, sdjwt.MediaType, []byte(sdJWT.String()))
+	}
+
+	var vcCreated *vc.VerifiableCredential
+	var err error
+	if issueRequest.Format != nil && *issueRequest.Format == IssueVCRequestFormatJwtVc {
+		vcCreated, err = w.VCR.Issuer().IssueJWT(requestedVC, publish, public)
+	} else {
+		vcCreated, err = w.VCR.Issuer().Issue(requestedVC, publish, public)
+	}
 	if err != nil {
 		return err
 	}
@@ -183,7 +210,7 @@ func (w *Wrapper) SearchIssuedVCs(ctx echo.Context, params SearchIssuedVCsParams
 	if err != nil {
 		return err
 	}
-	return ctx.JSON(http.StatusOK, SearchVCResults{result})
+	return ctx.JSON(http.StatusOK, SearchVCResults{VerifiableCredentials: result})
 }
 
 // VerifyVC handles API request to verify a  Verifiable Credential.
@@ -218,7 +245,11 @@ func (w *Wrapper) CreateVP(ctx echo.Context) error {
 		return err
 	}
 
-	if len(request.VerifiableCredentials) == 0 {
+	if request.Format != nil && *request.Format == CreateVPRequestFormatVcSdJwt {
+		return w.createSDJWTPresentation(ctx, *request)
+	}
+
+	if request.VerifiableCredentials == nil || len(*request.VerifiableCredentials) == 0 {
 		return core.InvalidInputError("verifiableCredentials needs at least 1 item")
 	}
 
@@ -251,13 +282,45 @@ func (w *Wrapper) CreateVP(ctx echo.Context) error {
 		Expires:   expires,
 	}
 
-	vp, err := w.VCR.Holder().BuildVP(request.VerifiableCredentials, proofOptions, signerDID, true)
+	var vp *vc.VerifiablePresentation
+	if request.Format != nil && *request.Format == CreateVPRequestFormatJwtVp {
+		vp, err = w.VCR.Holder().BuildJWTVP(*request.VerifiableCredentials, proofOptions, signerDID, true)
+	} else {
+		vp, err = w.VCR.Holder().BuildVP(*request.VerifiableCredentials, proofOptions, signerDID, true)
+	}
 	if err != nil {
 		return err
 	}
 	return ctx.JSON(http.StatusOK, vp)
 }
 
+func (w *Wrapper) createSDJWTPresentation(ctx echo.Context, request CreateVPRequest) error {
+	if request.SdJwtCredential == nil || *request.SdJwtCredential == "" {
+		return core.InvalidInputError("sdJwtCredential is required for format vc+sd-jwt")
+	}
+	credential, err := sdjwt.Parse(*request.SdJwtCredential)
+	if err != nil {
+		return core.InvalidInputError("invalid sdJwtCredential: %w", err)
+	}
+	var disclose []string
+	if request.Disclose != nil {
+		disclose = *request.Disclose
+	}
+	var audience, nonce string
+	if request.Domain != nil {
+		audience = *request.Domain
+	}
+	if request.Challenge != nil {
+		nonce = *request.Challenge
+	}
+
+	presentation, err := w.VCR.Holder().BuildSDJWTPresentation(*credential, disclose, audience, nonce)
+	if err != nil {
+		return err
+	}
+	return ctx.Blob(http.StatusOK, sdjwt.MediaType, []byte(presentation.String()))
+}
+
 // VerifyVP handles API request to verify a Verifiable Presentation.
 func (w *Wrapper) VerifyVP(ctx echo.Context) error {
 	request := &VPVerificationRequest{}
@@ -279,7 +342,33 @@ func (w *Wrapper) VerifyVP(ctx echo.Context) error {
 		validAt = &parsedTime
 	}
 
-	verifiedCredentials, err := w.VCR.Verifier().VerifyVP(request.VerifiablePresentation, verifyCredentials, validAt)
+	var verifiedCredentials []VerifiableCredential
+	var err error
+	if request.Format != nil && *request.Format == VcSdJwt {
+		if request.SdJwtPresentation == nil || *request.SdJwtPresentation == "" {
+			return core.InvalidInputError("sdJwtPresentation is required for format vc+sd-jwt")
+		}
+		if request.Domain == nil || *request.Domain == "" || request.Challenge == nil || *request.Challenge == "" {
+			return core.InvalidInputError("domain and challenge are required for format vc+sd-jwt")
+		}
+		presentation, parseErr := sdjwt.Parse(*request.SdJwtPresentation)
+		if parseErr != nil {
+			return core.InvalidInputError("invalid sdJwtPresentation: %w", parseErr)
+		}
+		verifiedCredentials, err = w.VCR.Verifier().VerifySDJWTPresentation(*presentation, *request.Domain, *request.Challenge, validAt)
+	} else {
+		presentation := request.VerifiablePresentation
+		if request.Format != nil && *request.Format == JwtVp && request.JwtPresentation != nil && *request.JwtPresentation != "" {
+			var parseErr error
+			if presentation, parseErr = jwtvc.ParsePresentation(*request.JwtPresentation); parseErr != nil {
+				return core.InvalidInputError("invalid jwtPresentation: %w", parseErr)
+			}
+		}
+		if presentation == nil {
+			return core.InvalidInputError("verifiablePresentation is required")
+		}
+		verifiedCredentials, err = w.VCR.Verifier().VerifyVP(*presentation, verifyCredentials, validAt)
+	}
 	if err != nil {
 		if errors.Is(err, verifier.VerificationError{}) {
 			msg := err.Error()
@@ -293,19 +382,70 @@ func (w *Wrapper) VerifyVP(ctx echo.Context) error {
 }
 
 // TrustIssuer handles API request to start trusting an issuer of a Verifiable Credential.
+// When an expiration date is given, the trust is added as policy with that expiration date.
 func (w *Wrapper) TrustIssuer(ctx echo.Context) error {
-	return changeTrust(ctx, func(cType ssi.URI, issuer ssi.URI) error {
-		return w.VCR.Trust(cType, issuer)
+	return changeTrust(ctx, func(cType ssi.URI, issuer ssi.URI, expires *time.Time) error {
+		if expires == nil {
+			return w.VCR.Trust(cType, issuer)
+		}
+		_, err := w.VCR.AddTrustPolicy(trust.Policy{CredentialType: cType.String(), Issuer: issuer.String(), Expires: expires})
+		return err
 	})
 }
 
 // UntrustIssuer handles API request to stop trusting an issuer of a Verifiable Credential.
 func (w *Wrapper) UntrustIssuer(ctx echo.Context) error {
-	return changeTrust(ctx, func(cType ssi.URI, issuer ssi.URI) error {
+	return changeTrust(ctx, func(cType ssi.URI, issuer ssi.URI, _ *time.Time) error {
 		return w.VCR.Untrust(cType, issuer)
 	})
 }
 
+// ListTrustPolicies handles API request to list all trust policies.
+func (w *Wrapper) ListTrustPolicies(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, w.VCR.TrustPolicies())
+}
+
+// AddTrustPolicy handles API request to add a trust policy.
+func (w *Wrapper) AddTrustPolicy(ctx echo.Context) error {
+	var policy TrustPolicy
+	if err := ctx.Bind(&policy); err != nil {
+		return err
+	}
+	// the source is only set for policies imported from trust lists
+	policy.Source = ""
+
+	added, err := w.VCR.AddTrustPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, added)
+}
+
+// RemoveTrustPolicy handles API request to remove a trust policy.
+func (w *Wrapper) RemoveTrustPolicy(ctx echo.Context, id string) error {
+	if err := w.VCR.RemoveTrustPolicy(id); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ImportTrustList handles API request to import a signed trust list.
+func (w *Wrapper) ImportTrustList(ctx echo.Context) error {
+	var request ImportTrustListRequest
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+	if request.TrustList == "" {
+		return core.InvalidInputError("missing trust list")
+	}
+
+	imported, err := w.VCR.ImportTrustList(request.TrustList)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, imported)
+}
+
 // ListTrusted handles API request list all trusted issuers.
 func (w *Wrapper) ListTrusted(ctx echo.Context, credentialType string) error {
 	uri, err := parseCredentialType(credentialType)
@@ -358,7 +498,7 @@ func (w *Wrapper) vcsWithRevocationsToSearchResults(foundVCs []vc.VerifiableCred
 	return result, nil
 }
 
-type trustChangeFunc func(ssi.URI, ssi.URI) error
+type trustChangeFunc func(ssi.URI, ssi.URI, *time.Time) error
 
 func changeTrust(ctx echo.Context, f trustChangeFunc) error {
 	var icc = new(CredentialIssuer)
@@ -377,7 +517,7 @@ func changeTrust(ctx echo.Context, f trustChangeFunc) error {
 		return err
 	}
 
-	if err = f(*cType, *d); err != nil {
+	if err = f(*cType, *d, icc.Expires); err != nil {
 		return err
 	}
 
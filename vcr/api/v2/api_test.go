@@ -37,11 +37,15 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/mock"
 	"github.com/nuts-foundation/nuts-node/vcr"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/nuts-foundation/nuts-node/vcr/trust"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -77,6 +81,63 @@ func TestWrapper_IssueVC(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("ok - SD-JWT", func(t *testing.T) {
+		testContext := newMockContext(t)
+		issued := &sdjwt.SDJWT{Token: "a.b.c"}
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			publish := false
+			format := IssueVCRequestFormatVcSdJwt
+			issueRequest := f.(*IssueVCRequest)
+			issueRequest.Type = expectedRequestedVC.Type[0].String()
+			issueRequest.Issuer = expectedRequestedVC.Issuer.String()
+			issueRequest.CredentialSubject = expectedRequestedVC.CredentialSubject
+			issueRequest.PublishToNetwork = &publish
+			issueRequest.Format = &format
+			return nil
+		})
+		testContext.mockIssuer.EXPECT().IssueSDJWT(gomock.Eq(expectedRequestedVC)).Return(issued, nil)
+		testContext.echo.EXPECT().Blob(http.StatusOK, sdjwt.MediaType, []byte("a.b.c~"))
+
+		err := testContext.client.IssueVC(testContext.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("ok - JWT", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			public := Public
+			format := IssueVCRequestFormatJwtVc
+			issueRequest := f.(*IssueVCRequest)
+			issueRequest.Type = expectedRequestedVC.Type[0].String()
+			issueRequest.Issuer = expectedRequestedVC.Issuer.String()
+			issueRequest.CredentialSubject = expectedRequestedVC.CredentialSubject
+			issueRequest.Visibility = &public
+			issueRequest.Format = &format
+			return nil
+		})
+		testContext.mockIssuer.EXPECT().IssueJWT(gomock.Eq(expectedRequestedVC), true, true)
+		testContext.echo.EXPECT().JSON(http.StatusOK, nil)
+
+		err := testContext.client.IssueVC(testContext.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("err - SD-JWT can't be published", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			format := IssueVCRequestFormatVcSdJwt
+			issueRequest := f.(*IssueVCRequest)
+			issueRequest.Format = &format
+			return nil
+		})
+
+		err := testContext.client.IssueVC(testContext.echo)
+
+		assert.EqualError(t, err, "credentials in vc+sd-jwt format can't be published to the network")
+	})
+
 	t.Run("checking request params", func(t *testing.T) {
 
 		t.Run("err - missing credential type", func(t *testing.T) {
@@ -518,7 +579,7 @@ func TestWrapper_CreateVP(t *testing.T) {
 	result := &vc.VerifiablePresentation{}
 
 	createRequest := func() CreateVPRequest {
-		return CreateVPRequest{VerifiableCredentials: []VerifiableCredential{verifiableCredential}}
+		return CreateVPRequest{VerifiableCredentials: &[]VerifiableCredential{verifiableCredential}}
 	}
 
 	created := time.Now()
@@ -625,6 +686,53 @@ func TestWrapper_CreateVP(t *testing.T) {
 
 		assert.EqualError(t, err, "verifiableCredentials needs at least 1 item")
 	})
+	t.Run("ok - JWT", func(t *testing.T) {
+		testContext := newMockContext(t)
+		request := createRequest()
+		format := CreateVPRequestFormatJwtVp
+		request.Format = &format
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*CreateVPRequest) = request
+			return nil
+		})
+		testContext.mockHolder.EXPECT().BuildJWTVP([]VerifiableCredential{verifiableCredential}, proof.ProofOptions{Created: created}, nil, true).Return(result, nil)
+		testContext.echo.EXPECT().JSON(http.StatusOK, result)
+
+		err := testContext.client.CreateVP(testContext.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - SD-JWT", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := CreateVPRequestFormatVcSdJwt
+		credential := "a.b.c~"
+		domain := "verifier"
+		challenge := "nonce"
+		request := CreateVPRequest{Format: &format, SdJwtCredential: &credential, Disclose: &[]string{"name"}, Domain: &domain, Challenge: &challenge}
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*CreateVPRequest) = request
+			return nil
+		})
+		testContext.mockHolder.EXPECT().BuildSDJWTPresentation(sdjwt.SDJWT{Token: "a.b.c"}, []string{"name"}, "verifier", "nonce").
+			Return(&sdjwt.SDJWT{Token: "a.b.c", KeyBinding: "d.e.f"}, nil)
+		testContext.echo.EXPECT().Blob(http.StatusOK, sdjwt.MediaType, []byte("a.b.c~d.e.f"))
+
+		err := testContext.client.CreateVP(testContext.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - SD-JWT credential missing", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := CreateVPRequestFormatVcSdJwt
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*CreateVPRequest) = CreateVPRequest{Format: &format}
+			return nil
+		})
+
+		err := testContext.client.CreateVP(testContext.echo)
+
+		assert.EqualError(t, err, "sdJwtCredential is required for format vc+sd-jwt")
+	})
 }
 
 func TestWrapper_VerifyVP(t *testing.T) {
@@ -641,7 +749,7 @@ func TestWrapper_VerifyVP(t *testing.T) {
 		testContext := newMockContext(t)
 		validAt, validAtStr := parsedTimeStr(time.Now())
 		request := VPVerificationRequest{
-			VerifiablePresentation: vp,
+			VerifiablePresentation: &vp,
 			ValidAt:                &validAtStr,
 		}
 		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
@@ -662,7 +770,7 @@ func TestWrapper_VerifyVP(t *testing.T) {
 	t.Run("ok - verifyCredentials set", func(t *testing.T) {
 		testContext := newMockContext(t)
 		verifyCredentials := false
-		request := VPVerificationRequest{VerifiablePresentation: vp, VerifyCredentials: &verifyCredentials}
+		request := VPVerificationRequest{VerifiablePresentation: &vp, VerifyCredentials: &verifyCredentials}
 		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
 			verifyRequest := f.(*VPVerificationRequest)
 			*verifyRequest = request
@@ -680,7 +788,7 @@ func TestWrapper_VerifyVP(t *testing.T) {
 	})
 	t.Run("error - verification failed (other error)", func(t *testing.T) {
 		testContext := newMockContext(t)
-		request := VPVerificationRequest{VerifiablePresentation: vp}
+		request := VPVerificationRequest{VerifiablePresentation: &vp}
 		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
 			verifyRequest := f.(*VPVerificationRequest)
 			*verifyRequest = request
@@ -696,7 +804,7 @@ func TestWrapper_VerifyVP(t *testing.T) {
 		testContext := newMockContext(t)
 		validAtStr := "a"
 		request := VPVerificationRequest{
-			VerifiablePresentation: vp,
+			VerifiablePresentation: &vp,
 			ValidAt:                &validAtStr,
 		}
 		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
@@ -711,7 +819,7 @@ func TestWrapper_VerifyVP(t *testing.T) {
 	})
 	t.Run("error - verification failed (verification error)", func(t *testing.T) {
 		testContext := newMockContext(t)
-		request := VPVerificationRequest{VerifiablePresentation: vp}
+		request := VPVerificationRequest{VerifiablePresentation: &vp}
 		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
 			verifyRequest := f.(*VPVerificationRequest)
 			*verifyRequest = request
@@ -728,6 +836,98 @@ func TestWrapper_VerifyVP(t *testing.T) {
 
 		assert.NoError(t, err)
 	})
+	t.Run("ok - SD-JWT", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := VcSdJwt
+		presentation := "a.b.c~d.e.f"
+		domain := "verifier"
+		challenge := "nonce"
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*VPVerificationRequest) = VPVerificationRequest{Format: &format, SdJwtPresentation: &presentation, Domain: &domain, Challenge: &challenge}
+			return nil
+		})
+		testContext.mockVerifier.EXPECT().VerifySDJWTPresentation(sdjwt.SDJWT{Token: "a.b.c", KeyBinding: "d.e.f"}, "verifier", "nonce", nil).Return(expectedVCs, nil)
+		testContext.echo.EXPECT().JSON(http.StatusOK, VPVerificationResult{
+			Credentials: &expectedVCs,
+			Validity:    true,
+		})
+
+		err := testContext.client.VerifyVP(testContext.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - JWT", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := JwtVp
+		presentation, _ := jwtvc.CreatePresentation(vc.VerifiablePresentation{
+			Context: []ssi.URI{vc.VCContextV1URI()},
+			Type:    []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+		}, proof.ProofOptions{Created: time.Now()}, crypto.NewTestKey("did:nuts:holder#key-1"))
+		token, _ := jwtvc.ExtractJWT(presentation.Proof)
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*VPVerificationRequest) = VPVerificationRequest{Format: &format, JwtPresentation: &token}
+			return nil
+		})
+		testContext.mockVerifier.EXPECT().VerifyVP(*presentation, true, nil).Return(expectedVCs, nil)
+		testContext.echo.EXPECT().JSON(http.StatusOK, VPVerificationResult{
+			Credentials: &expectedVCs,
+			Validity:    true,
+		})
+
+		err := testContext.client.VerifyVP(testContext.echo)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - invalid JWT presentation", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := JwtVp
+		token := "invalid"
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*VPVerificationRequest) = VPVerificationRequest{Format: &format, JwtPresentation: &token}
+			return nil
+		})
+
+		err := testContext.client.VerifyVP(testContext.echo)
+
+		assert.ErrorContains(t, err, "invalid jwtPresentation: ")
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("error - SD-JWT presentation missing", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := VcSdJwt
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*VPVerificationRequest) = VPVerificationRequest{Format: &format}
+			return nil
+		})
+
+		err := testContext.client.VerifyVP(testContext.echo)
+
+		assert.EqualError(t, err, "sdJwtPresentation is required for format vc+sd-jwt")
+	})
+	t.Run("error - SD-JWT domain and challenge missing", func(t *testing.T) {
+		testContext := newMockContext(t)
+		format := VcSdJwt
+		presentation := "a.b.c~d.e.f"
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*VPVerificationRequest) = VPVerificationRequest{Format: &format, SdJwtPresentation: &presentation}
+			return nil
+		})
+
+		err := testContext.client.VerifyVP(testContext.echo)
+
+		assert.EqualError(t, err, "domain and challenge are required for format vc+sd-jwt")
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("error - verifiablePresentation missing", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			return nil
+		})
+
+		err := testContext.client.VerifyVP(testContext.echo)
+
+		assert.EqualError(t, err, "verifiablePresentation is required")
+	})
 }
 
 func TestWrapper_TrustUntrust(t *testing.T) {
@@ -753,6 +953,25 @@ func TestWrapper_TrustUntrust(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("ok - add with expiration date", func(t *testing.T) {
+		ctx := newMockContext(t)
+		defer ctx.ctrl.Finish()
+		expires := time.Now().Add(time.Hour)
+
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			capturedCombination := f.(*CredentialIssuer)
+			capturedCombination.CredentialType = cType.String()
+			capturedCombination.Issuer = issuer.String()
+			capturedCombination.Expires = &expires
+			return nil
+		})
+		ctx.vcr.EXPECT().AddTrustPolicy(trust.Policy{CredentialType: cType.String(), Issuer: issuer.String(), Expires: &expires}).Return(&trust.Policy{}, nil)
+		ctx.echo.EXPECT().NoContent(http.StatusNoContent)
+
+		err := ctx.client.TrustIssuer(ctx.echo)
+		assert.NoError(t, err)
+	})
+
 	t.Run("ok - remove", func(t *testing.T) {
 		ctx := newMockContext(t)
 		defer ctx.ctrl.Finish()
@@ -835,6 +1054,111 @@ func TestWrapper_TrustUntrust(t *testing.T) {
 	})
 }
 
+func TestWrapper_TrustPolicies(t *testing.T) {
+	policy := trust.Policy{
+		CredentialType: "NutsOrganizationCredential",
+		Chain:          &trust.Chain{CredentialType: "MembershipCredential", Issuer: "did:nuts:operator"},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vcr.EXPECT().TrustPolicies().Return([]trust.Policy{policy})
+		ctx.echo.EXPECT().JSON(http.StatusOK, []trust.Policy{policy})
+
+		err := ctx.client.ListTrustPolicies(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*TrustPolicy) = policy
+			f.(*TrustPolicy).Source = "did:nuts:spoofed"
+			return nil
+		})
+		added := policy
+		added.ID = "1"
+		ctx.vcr.EXPECT().AddTrustPolicy(policy).Return(&added, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, &added)
+
+		err := ctx.client.AddTrustPolicy(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("add - error", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any())
+		ctx.vcr.EXPECT().AddTrustPolicy(gomock.Any()).Return(nil, trust.ErrInvalidPolicy)
+
+		err := ctx.client.AddTrustPolicy(ctx.echo)
+
+		assert.ErrorIs(t, err, trust.ErrInvalidPolicy)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vcr.EXPECT().RemoveTrustPolicy("1").Return(nil)
+		ctx.echo.EXPECT().NoContent(http.StatusNoContent)
+
+		err := ctx.client.RemoveTrustPolicy(ctx.echo, "1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove - not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vcr.EXPECT().RemoveTrustPolicy("1").Return(trust.ErrPolicyNotFound)
+
+		err := ctx.client.RemoveTrustPolicy(ctx.echo, "1")
+
+		assert.ErrorIs(t, err, trust.ErrPolicyNotFound)
+		assert.Equal(t, http.StatusNotFound, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ImportTrustList(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			f.(*ImportTrustListRequest).TrustList = "token"
+			return nil
+		})
+		imported := []trust.Policy{{ID: "1", CredentialType: "NutsOrganizationCredential", Issuer: "did:nuts:issuer", Source: "did:nuts:operator"}}
+		ctx.vcr.EXPECT().ImportTrustList("token").Return(imported, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, imported)
+
+		err := ctx.client.ImportTrustList(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - missing trust list", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any())
+
+		err := ctx.client.ImportTrustList(ctx.echo)
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - unknown signer", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			f.(*ImportTrustListRequest).TrustList = "token"
+			return nil
+		})
+		ctx.vcr.EXPECT().ImportTrustList("token").Return(nil, trust.ErrUnknownTrustListSigner)
+
+		err := ctx.client.ImportTrustList(ctx.echo)
+
+		assert.ErrorIs(t, err, trust.ErrUnknownTrustListSigner)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+}
+
 func TestWrapper_Trusted(t *testing.T) {
 	credentialType, _ := ssi.ParseURI("type")
 
@@ -89,6 +89,28 @@ func (hb HTTPClient) Untrusted(credentialType string) ([]string, error) {
 	return handleTrustedResponse(hb.client().ListUntrusted(ctx, credentialType))
 }
 
+// ImportTrustList sends a signed trust list to the node to import its trust policies. It returns the imported policies.
+func (hb HTTPClient) ImportTrustList(trustList string) ([]TrustPolicy, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().ImportTrustList(ctx, ImportTrustListJSONRequestBody{TrustList: trustList})
+	if err != nil {
+		return nil, err
+	}
+	if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response: %w", err)
+	}
+	policies := make([]TrustPolicy, 0)
+	if err = json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response: %w, %s", err, string(data))
+	}
+	return policies, nil
+}
+
 func handleTrustedResponse(response *http.Response, err error) ([]string, error) {
 	if err != nil {
 		return nil, err
@@ -92,6 +92,40 @@ func TestHttpClient_Trust(t *testing.T) {
 	})
 }
 
+func TestHttpClient_ImportTrustList(t *testing.T) {
+	imported := []TrustPolicy{{ID: "1", CredentialType: credentialType, Issuer: didString, Source: "did:nuts:operator"}}
+
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: imported})
+		c := &HTTPClient{ClientConfig: core.ClientConfig{Address: s.URL, Timeout: time.Second}}
+
+		policies, err := c.ImportTrustList("token")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, imported, policies)
+	})
+
+	t.Run("error - other status code", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusBadRequest})
+		c := &HTTPClient{ClientConfig: core.ClientConfig{Address: s.URL, Timeout: time.Second}}
+
+		_, err := c.ImportTrustList("token")
+
+		assert.ErrorContains(t, err, "server returned HTTP 400")
+	})
+
+	t.Run("error - invalid response", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: "not a list"})
+		c := &HTTPClient{ClientConfig: core.ClientConfig{Address: s.URL, Timeout: time.Second}}
+
+		_, err := c.ImportTrustList("token")
+
+		assert.ErrorContains(t, err, "unable to unmarshal response")
+	})
+}
+
 func TestHttpClient_Trusted(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
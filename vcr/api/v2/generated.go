@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/labstack/echo/v4"
@@ -22,17 +23,41 @@ const (
 	JwtBearerAuthScopes = "jwtBearerAuth.Scopes"
 )
 
+// Defines values for CreateVPRequestFormat.
+const (
+	CreateVPRequestFormatJwtVp   CreateVPRequestFormat = "jwt_vp"
+	CreateVPRequestFormatLdpVp   CreateVPRequestFormat = "ldp_vp"
+	CreateVPRequestFormatVcSdJwt CreateVPRequestFormat = "vc+sd-jwt"
+)
+
+// Defines values for IssueVCRequestFormat.
+const (
+	IssueVCRequestFormatJwtVc   IssueVCRequestFormat = "jwt_vc"
+	IssueVCRequestFormatLdpVc   IssueVCRequestFormat = "ldp_vc"
+	IssueVCRequestFormatVcSdJwt IssueVCRequestFormat = "vc+sd-jwt"
+)
+
 // Defines values for IssueVCRequestVisibility.
 const (
 	Private IssueVCRequestVisibility = "private"
 	Public  IssueVCRequestVisibility = "public"
 )
 
+// Defines values for VPVerificationRequestFormat.
+const (
+	JwtVp   VPVerificationRequestFormat = "jwt_vp"
+	LdpVp   VPVerificationRequestFormat = "ldp_vp"
+	VcSdJwt VPVerificationRequestFormat = "vc+sd-jwt"
+)
+
 // A request for creating a new Verifiable Presentation for a set of Verifiable Credentials.
 type CreateVPRequest struct {
 	// A random or pseudo-random value used by some authentication protocols to mitigate replay attacks.
 	Challenge *string `json:"challenge,omitempty"`
 
+	// The names of the claims of the SD-JWT VC to disclose. Only used when format is "vc+sd-jwt".
+	Disclose *[]string `json:"disclose,omitempty"`
+
 	// A string value that specifies the operational domain of a digital proof. This could be an Internet domain
 	// name like example.com, an ad-hoc value such as mycorp-level3-access, or a very specific transaction value
 	// like 8zF6T$mqP. A signer could include a domain in its digital proof to restrict its use to particular
@@ -42,26 +67,59 @@ type CreateVPRequest struct {
 	// Date and time at which proof will expire. If omitted, the proof does not have an end date.
 	Expires *string `json:"expires,omitempty"`
 
+	// The format of the presentation to create. It defaults to "ldp_vp": a JSON-LD presentation with a Linked Data proof.
+	// When set to "jwt_vp", the presentation is encoded as JWT. It is returned in its decoded form, containing the JWT as "JwtProof2020" proof.
+	// The domain and challenge are then encoded as the audience and nonce of the JWT.
+	// When set to "vc+sd-jwt", sdJwtCredential and disclose are used instead of verifiableCredentials.
+	Format *CreateVPRequestFormat `json:"format,omitempty"`
+
 	// The specific intent for the proof, the reason why an entity created it. Acts as a safeguard to prevent the
 	// proof from being misused for a purpose other than the one it was intended for.
 	ProofPurpose *string `json:"proofPurpose,omitempty"`
 
+	// The SD-JWT VC (in compact form) to present. Required when format is "vc+sd-jwt".
+	SdJwtCredential *string `json:"sdJwtCredential,omitempty"`
+
 	// Specifies the DID of the signing party that must be used to create the digital signature.
 	// If not specified, it is derived from the given Verifiable Credentials' subjectCredential ID.
 	// It can only be derived if all given Verifiable Credentials have the same, single subjectCredential.
-	SignerDID             *string                `json:"signerDID,omitempty"`
-	VerifiableCredentials []VerifiableCredential `json:"verifiableCredentials"`
+	SignerDID *string `json:"signerDID,omitempty"`
+
+	// The credentials to present. Required when format is "ldp_vp" or "jwt_vp".
+	VerifiableCredentials *[]VerifiableCredential `json:"verifiableCredentials,omitempty"`
 }
 
+// The format of the presentation to create. It defaults to "ldp_vp": a JSON-LD presentation with a Linked Data proof.
+// When set to "jwt_vp", the presentation is encoded as JWT. It is returned in its decoded form, containing the JWT as "JwtProof2020" proof.
+// The domain and challenge are then encoded as the audience and nonce of the JWT.
+// When set to "vc+sd-jwt", sdJwtCredential and disclose are used instead of verifiableCredentials.
+type CreateVPRequestFormat string
+
 // CredentialIssuer defines model for CredentialIssuer.
 type CredentialIssuer struct {
 	// a credential type
 	CredentialType string `json:"credentialType"`
 
+	// optional moment (RFC3339) after which the trust is no longer applied. Only used when adding trust.
+	Expires *time.Time `json:"expires,omitempty"`
+
 	// the DID of an issuer
 	Issuer string `json:"issuer"`
 }
 
+// Range on a date of a credential, both bounds are exclusive and rfc3339 formatted datetimes. Credentials without expirationDate match a range on expirationDate if it only specifies after.
+type DateRange struct {
+	After  *time.Time `json:"after,omitempty"`
+	Before *time.Time `json:"before,omitempty"`
+}
+
+// ImportTrustListRequest defines model for ImportTrustListRequest.
+type ImportTrustListRequest struct {
+	// The trust list as JWT, signed by one of the configured trust list signers.
+	// It contains the DID of the signer as 'iss' claim and an array of trust policies as 'policies' claim.
+	TrustList string `json:"trustList"`
+}
+
 // A request for issuing a new Verifiable Credential.
 type IssueVCRequest struct {
 	// The resolvable context of the credentialSubject as URI. If omitted, the "https://nuts.nl/credentials/v1" context is used.
@@ -74,6 +132,12 @@ type IssueVCRequest struct {
 	// rfc3339 time string until when the credential is valid.
 	ExpirationDate *string `json:"expirationDate,omitempty"`
 
+	// The format of the credential to issue. It defaults to "ldp_vc": a JSON-LD credential with a Linked Data proof.
+	// When set to "jwt_vc", the credential is encoded as JWT. It is returned in its decoded form, containing the JWT as "JwtProof2020" proof.
+	// When set to "vc+sd-jwt", an SD-JWT VC is issued which allows the holder to selectively disclose the claims of the credentialSubject.
+	// An SD-JWT VC can't be published to the network, so publishToNetwork must be false.
+	Format *IssueVCRequestFormat `json:"format,omitempty"`
+
 	// DID according to Nuts specification.
 	Issuer string `json:"issuer"`
 
@@ -91,6 +155,12 @@ type IssueVCRequest struct {
 	Visibility *IssueVCRequestVisibility `json:"visibility,omitempty"`
 }
 
+// The format of the credential to issue. It defaults to "ldp_vc": a JSON-LD credential with a Linked Data proof.
+// When set to "jwt_vc", the credential is encoded as JWT. It is returned in its decoded form, containing the JWT as "JwtProof2020" proof.
+// When set to "vc+sd-jwt", an SD-JWT VC is issued which allows the holder to selectively disclose the claims of the credentialSubject.
+// An SD-JWT VC can't be published to the network, so publishToNetwork must be false.
+type IssueVCRequestFormat string
+
 // When publishToNetwork is true, the credential can be published publicly or privately to the holder.
 // This field is mandatory if publishToNetwork is true to prevent accidents. It defaults to "private".
 type IssueVCRequestVisibility string
@@ -112,6 +182,8 @@ type SearchVCResult struct {
 
 // result of a Search operation.
 type SearchVCResults struct {
+	// Set if there are more results, pass it as cursor to retrieve the next page. Results are returned in a stable order, which is not related to their content.
+	NextCursor            *string          `json:"nextCursor,omitempty"`
 	VerifiableCredentials []SearchVCResult `json:"verifiableCredentials"`
 }
 
@@ -139,16 +211,43 @@ type VCVerificationResult struct {
 
 // VPVerificationRequest defines model for VPVerificationRequest.
 type VPVerificationRequest struct {
+	// The expected nonce of the Key Binding JWT of sdJwtPresentation, as provided by the verifier to the holder.
+	// Required when format is "vc+sd-jwt".
+	Challenge *string `json:"challenge,omitempty"`
+
+	// The expected audience of the Key Binding JWT of sdJwtPresentation: the verifier the presentation was created for.
+	// Required when format is "vc+sd-jwt".
+	Domain *string `json:"domain,omitempty"`
+
+	// The format of the presentation to verify. It defaults to "ldp_vp": a JSON-LD presentation with a Linked Data proof.
+	// When set to "jwt_vp", jwtPresentation is verified if given, otherwise verifiablePresentation must be a decoded JWT presentation.
+	// When set to "vc+sd-jwt", sdJwtPresentation is verified instead of verifiablePresentation.
+	// A decoded JWT presentation (containing a "JwtProof2020" proof) is also accepted as verifiablePresentation when the format is "ldp_vp".
+	Format *VPVerificationRequestFormat `json:"format,omitempty"`
+
+	// The JWT encoded presentation in compact form. Only used when format is "jwt_vp".
+	JwtPresentation *string `json:"jwtPresentation,omitempty"`
+
+	// The SD-JWT VC presentation in compact form. Required when format is "vc+sd-jwt".
+	// It must contain a Key Binding JWT signed by the credential's subject.
+	SdJwtPresentation *string `json:"sdJwtPresentation,omitempty"`
+
 	// Date and time at which the VP should be valid. If not supplied, the current date/time is used.
 	ValidAt *string `json:"validAt,omitempty"`
 
 	// Verifiable Presentation
-	VerifiablePresentation VerifiablePresentation `json:"verifiablePresentation"`
+	VerifiablePresentation *VerifiablePresentation `json:"verifiablePresentation,omitempty"`
 
 	// Indicates whether the Verifiable Credentials within the VP must be verified, default true.
 	VerifyCredentials *bool `json:"verifyCredentials,omitempty"`
 }
 
+// The format of the presentation to verify. It defaults to "ldp_vp": a JSON-LD presentation with a Linked Data proof.
+// When set to "jwt_vp", jwtPresentation is verified if given, otherwise verifiablePresentation must be a decoded JWT presentation.
+// When set to "vc+sd-jwt", sdJwtPresentation is verified instead of verifiablePresentation.
+// A decoded JWT presentation (containing a "JwtProof2020" proof) is also accepted as verifiablePresentation when the format is "ldp_vp".
+type VPVerificationRequestFormat string
+
 // Contains the verifiable presentation verification result.
 type VPVerificationResult struct {
 	// If the VP is valid, it will contain the credentials inside the VP.
@@ -188,6 +287,12 @@ type UntrustIssuerJSONBody = CredentialIssuer
 // TrustIssuerJSONBody defines parameters for TrustIssuer.
 type TrustIssuerJSONBody = CredentialIssuer
 
+// ImportTrustListJSONBody defines parameters for ImportTrustList.
+type ImportTrustListJSONBody = ImportTrustListRequest
+
+// AddTrustPolicyJSONBody defines parameters for AddTrustPolicy.
+type AddTrustPolicyJSONBody = TrustPolicy
+
 // VerifyVCJSONBody defines parameters for VerifyVC.
 type VerifyVCJSONBody = VCVerificationRequest
 
@@ -209,6 +314,12 @@ type UntrustIssuerJSONRequestBody = UntrustIssuerJSONBody
 // TrustIssuerJSONRequestBody defines body for TrustIssuer for application/json ContentType.
 type TrustIssuerJSONRequestBody = TrustIssuerJSONBody
 
+// ImportTrustListJSONRequestBody defines body for ImportTrustList for application/json ContentType.
+type ImportTrustListJSONRequestBody = ImportTrustListJSONBody
+
+// AddTrustPolicyJSONRequestBody defines body for AddTrustPolicy for application/json ContentType.
+type AddTrustPolicyJSONRequestBody = AddTrustPolicyJSONBody
+
 // VerifyVCJSONRequestBody defines body for VerifyVC for application/json ContentType.
 type VerifyVCJSONRequestBody = VerifyVCJSONBody
 
@@ -322,6 +433,22 @@ type ClientInterface interface {
 
 	TrustIssuer(ctx context.Context, body TrustIssuerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ImportTrustList request with any body
+	ImportTrustListWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportTrustList(ctx context.Context, body ImportTrustListJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTrustPolicies request
+	ListTrustPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddTrustPolicy request with any body
+	AddTrustPolicyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddTrustPolicy(ctx context.Context, body AddTrustPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveTrustPolicy request
+	RemoveTrustPolicy(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// VerifyVC request with any body
 	VerifyVCWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -495,6 +622,78 @@ func (c *Client) TrustIssuer(ctx context.Context, body TrustIssuerJSONRequestBod
 	return c.Client.Do(req)
 }
 
+func (c *Client) ImportTrustListWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportTrustListRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportTrustList(ctx context.Context, body ImportTrustListJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportTrustListRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTrustPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTrustPoliciesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddTrustPolicyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddTrustPolicyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddTrustPolicy(ctx context.Context, body AddTrustPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddTrustPolicyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveTrustPolicy(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveTrustPolicyRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) VerifyVCWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewVerifyVCRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -906,6 +1105,147 @@ func NewTrustIssuerRequestWithBody(server string, contentType string, body io.Re
 	return req, nil
 }
 
+// NewImportTrustListRequest calls the generic ImportTrustList builder with application/json body
+func NewImportTrustListRequest(server string, body ImportTrustListJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewImportTrustListRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewImportTrustListRequestWithBody generates requests for ImportTrustList with any type of body
+func NewImportTrustListRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/verifier/trust/list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTrustPoliciesRequest generates requests for ListTrustPolicies
+func NewListTrustPoliciesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/verifier/trust/policy")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddTrustPolicyRequest calls the generic AddTrustPolicy builder with application/json body
+func NewAddTrustPolicyRequest(server string, body AddTrustPolicyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddTrustPolicyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddTrustPolicyRequestWithBody generates requests for AddTrustPolicy with any type of body
+func NewAddTrustPolicyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/verifier/trust/policy")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveTrustPolicyRequest generates requests for RemoveTrustPolicy
+func NewRemoveTrustPolicyRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/verifier/trust/policy/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewVerifyVCRequest calls the generic VerifyVC builder with application/json body
 func NewVerifyVCRequest(server string, body VerifyVCJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -1131,6 +1471,22 @@ type ClientWithResponsesInterface interface {
 
 	TrustIssuerWithResponse(ctx context.Context, body TrustIssuerJSONRequestBody, reqEditors ...RequestEditorFn) (*TrustIssuerResponse, error)
 
+	// ImportTrustList request with any body
+	ImportTrustListWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportTrustListResponse, error)
+
+	ImportTrustListWithResponse(ctx context.Context, body ImportTrustListJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportTrustListResponse, error)
+
+	// ListTrustPolicies request
+	ListTrustPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustPoliciesResponse, error)
+
+	// AddTrustPolicy request with any body
+	AddTrustPolicyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddTrustPolicyResponse, error)
+
+	AddTrustPolicyWithResponse(ctx context.Context, body AddTrustPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*AddTrustPolicyResponse, error)
+
+	// RemoveTrustPolicy request
+	RemoveTrustPolicyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RemoveTrustPolicyResponse, error)
+
 	// VerifyVC request with any body
 	VerifyVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyVCResponse, error)
 
@@ -1322,6 +1678,93 @@ func (r TrustIssuerResponse) StatusCode() int {
 	return 0
 }
 
+type ImportTrustListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TrustPolicy
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportTrustListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportTrustListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTrustPoliciesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TrustPolicy
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTrustPoliciesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTrustPoliciesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddTrustPolicyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrustPolicy
+}
+
+// Status returns HTTPResponse.Status
+func (r AddTrustPolicyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddTrustPolicyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveTrustPolicyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveTrustPolicyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveTrustPolicyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type VerifyVCResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1522,6 +1965,58 @@ func (c *ClientWithResponses) TrustIssuerWithResponse(ctx context.Context, body
 	return ParseTrustIssuerResponse(rsp)
 }
 
+// ImportTrustListWithBodyWithResponse request with arbitrary body returning *ImportTrustListResponse
+func (c *ClientWithResponses) ImportTrustListWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportTrustListResponse, error) {
+	rsp, err := c.ImportTrustListWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportTrustListResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportTrustListWithResponse(ctx context.Context, body ImportTrustListJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportTrustListResponse, error) {
+	rsp, err := c.ImportTrustList(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportTrustListResponse(rsp)
+}
+
+// ListTrustPoliciesWithResponse request returning *ListTrustPoliciesResponse
+func (c *ClientWithResponses) ListTrustPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustPoliciesResponse, error) {
+	rsp, err := c.ListTrustPolicies(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTrustPoliciesResponse(rsp)
+}
+
+// AddTrustPolicyWithBodyWithResponse request with arbitrary body returning *AddTrustPolicyResponse
+func (c *ClientWithResponses) AddTrustPolicyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddTrustPolicyResponse, error) {
+	rsp, err := c.AddTrustPolicyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddTrustPolicyResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddTrustPolicyWithResponse(ctx context.Context, body AddTrustPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*AddTrustPolicyResponse, error) {
+	rsp, err := c.AddTrustPolicy(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddTrustPolicyResponse(rsp)
+}
+
+// RemoveTrustPolicyWithResponse request returning *RemoveTrustPolicyResponse
+func (c *ClientWithResponses) RemoveTrustPolicyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RemoveTrustPolicyResponse, error) {
+	rsp, err := c.RemoveTrustPolicy(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveTrustPolicyResponse(rsp)
+}
+
 // VerifyVCWithBodyWithResponse request with arbitrary body returning *VerifyVCResponse
 func (c *ClientWithResponses) VerifyVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyVCResponse, error) {
 	rsp, err := c.VerifyVCWithBody(ctx, contentType, body, reqEditors...)
@@ -1595,6 +2090,9 @@ func ParseCreateVPResponse(rsp *http.Response) (*CreateVPResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case rsp.StatusCode == 200:
+		// Content-type (application/vc+sd-jwt) unsupported
+
 	}
 
 	return response, nil
@@ -1621,6 +2119,9 @@ func ParseIssueVCResponse(rsp *http.Response) (*IssueVCResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case rsp.StatusCode == 200:
+		// Content-type (application/vc+sd-jwt) unsupported
+
 	}
 
 	return response, nil
@@ -1762,6 +2263,100 @@ func ParseTrustIssuerResponse(rsp *http.Response) (*TrustIssuerResponse, error)
 	return response, nil
 }
 
+// ParseImportTrustListResponse parses an HTTP response from a ImportTrustListWithResponse call
+func ParseImportTrustListResponse(rsp *http.Response) (*ImportTrustListResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportTrustListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []TrustPolicy
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTrustPoliciesResponse parses an HTTP response from a ListTrustPoliciesWithResponse call
+func ParseListTrustPoliciesResponse(rsp *http.Response) (*ListTrustPoliciesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTrustPoliciesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []TrustPolicy
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddTrustPolicyResponse parses an HTTP response from a AddTrustPolicyWithResponse call
+func ParseAddTrustPolicyResponse(rsp *http.Response) (*AddTrustPolicyResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddTrustPolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrustPolicy
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveTrustPolicyResponse parses an HTTP response from a RemoveTrustPolicyWithResponse call
+func ParseRemoveTrustPolicyResponse(rsp *http.Response) (*RemoveTrustPolicyResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveTrustPolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseVerifyVCResponse parses an HTTP response from a VerifyVCWithResponse call
 func ParseVerifyVCResponse(rsp *http.Response) (*VerifyVCResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -1892,6 +2487,18 @@ type ServerInterface interface {
 	// Mark all the VCs of given type and issuer as 'trusted'.
 	// (POST /internal/vcr/v2/verifier/trust)
 	TrustIssuer(ctx echo.Context) error
+	// Import a signed trust list
+	// (POST /internal/vcr/v2/verifier/trust/list)
+	ImportTrustList(ctx echo.Context) error
+	// List all trust policies
+	// (GET /internal/vcr/v2/verifier/trust/policy)
+	ListTrustPolicies(ctx echo.Context) error
+	// Add a trust policy
+	// (POST /internal/vcr/v2/verifier/trust/policy)
+	AddTrustPolicy(ctx echo.Context) error
+	// Remove a trust policy
+	// (DELETE /internal/vcr/v2/verifier/trust/policy/{id})
+	RemoveTrustPolicy(ctx echo.Context, id string) error
 	// Verifies a Verifiable Credential
 	// (POST /internal/vcr/v2/verifier/vc)
 	VerifyVC(ctx echo.Context) error
@@ -2036,6 +2643,57 @@ func (w *ServerInterfaceWrapper) TrustIssuer(ctx echo.Context) error {
 	return err
 }
 
+// ImportTrustList converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportTrustList(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ImportTrustList(ctx)
+	return err
+}
+
+// ListTrustPolicies converts echo context to params.
+func (w *ServerInterfaceWrapper) ListTrustPolicies(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListTrustPolicies(ctx)
+	return err
+}
+
+// AddTrustPolicy converts echo context to params.
+func (w *ServerInterfaceWrapper) AddTrustPolicy(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddTrustPolicy(ctx)
+	return err
+}
+
+// RemoveTrustPolicy converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveTrustPolicy(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RemoveTrustPolicy(ctx, id)
+	return err
+}
+
 // VerifyVC converts echo context to params.
 func (w *ServerInterfaceWrapper) VerifyVC(ctx echo.Context) error {
 	var err error
@@ -2166,6 +2824,22 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		si.(Preprocessor).Preprocess("TrustIssuer", context)
 		return wrapper.TrustIssuer(context)
 	})
+	router.POST(baseURL+"/internal/vcr/v2/verifier/trust/list", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ImportTrustList", context)
+		return wrapper.ImportTrustList(context)
+	})
+	router.GET(baseURL+"/internal/vcr/v2/verifier/trust/policy", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ListTrustPolicies", context)
+		return wrapper.ListTrustPolicies(context)
+	})
+	router.POST(baseURL+"/internal/vcr/v2/verifier/trust/policy", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("AddTrustPolicy", context)
+		return wrapper.AddTrustPolicy(context)
+	})
+	router.DELETE(baseURL+"/internal/vcr/v2/verifier/trust/policy/:id", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("RemoveTrustPolicy", context)
+		return wrapper.RemoveTrustPolicy(context)
+	})
 	router.POST(baseURL+"/internal/vcr/v2/verifier/vc", func(context echo.Context) error {
 		si.(Preprocessor).Preprocess("VerifyVC", context)
 		return wrapper.VerifyVC(context)
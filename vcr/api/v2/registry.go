@@ -50,8 +50,14 @@ func (w *Wrapper) ResolveVC(ctx echo.Context, id string) error {
 func (w *Wrapper) SearchVCs(ctx echo.Context) error {
 	// use different struct for unmarshalling, we don't want default values for required params
 	type searchVCRequest struct {
-		Query         map[string]interface{} `json:"query"`
-		SearchOptions *SearchOptions         `json:"searchOptions,omitempty"`
+		Query          map[string]interface{}     `json:"query"`
+		AnyOf          [][]map[string]interface{} `json:"anyOf,omitempty"`
+		Not            []map[string]interface{}   `json:"not,omitempty"`
+		IssuanceDate   *DateRange                 `json:"issuanceDate,omitempty"`
+		ExpirationDate *DateRange                 `json:"expirationDate,omitempty"`
+		Limit          *int                       `json:"limit,omitempty"`
+		Cursor         *string                    `json:"cursor,omitempty"`
+		SearchOptions  *SearchOptions             `json:"searchOptions,omitempty"`
 	}
 
 	var request searchVCRequest
@@ -65,14 +71,69 @@ func (w *Wrapper) SearchVCs(ctx echo.Context) error {
 		untrusted = *request.SearchOptions.AllowUntrustedIssuer
 	}
 
-	if credentials, ok := request.Query["credentialSubject"].([]interface{}); ok && len(credentials) > 1 {
-		return core.InvalidInputError("can't match on multiple VC subjects")
+	query := vcr.SearchQuery{}
+	if query.Terms, err = w.toSearchTerms(request.Query); err != nil {
+		return err
+	}
+	for _, group := range request.AnyOf {
+		alternatives := make([]vcr.SearchTerms, len(group))
+		for i, alternative := range group {
+			if alternatives[i], err = w.toSearchTerms(alternative); err != nil {
+				return err
+			}
+		}
+		query.AnyOf = append(query.AnyOf, alternatives)
+	}
+	for _, exclusion := range request.Not {
+		terms, err := w.toSearchTerms(exclusion)
+		if err != nil {
+			return err
+		}
+		query.Not = append(query.Not, terms)
+	}
+	if request.IssuanceDate != nil {
+		query.IssuedAfter = request.IssuanceDate.After
+		query.IssuedBefore = request.IssuanceDate.Before
+	}
+	if request.ExpirationDate != nil {
+		query.ExpiresAfter = request.ExpirationDate.After
+		query.ExpiresBefore = request.ExpirationDate.Before
+	}
+	if request.Limit != nil {
+		if *request.Limit < 1 {
+			return core.InvalidInputError("limit must be greater than 0")
+		}
+		query.Limit = *request.Limit
+	}
+	if request.Cursor != nil {
+		query.Cursor = *request.Cursor
+	}
+
+	results, err := w.VCR.SearchCredentials(ctx.Request().Context(), query, untrusted, nil)
+	if err != nil {
+		return err
+	}
+	searchResults, err := w.vcsWithRevocationsToSearchResults(results.Credentials)
+	if err != nil {
+		return err
+	}
+	response := SearchVCResults{VerifiableCredentials: searchResults}
+	if results.NextCursor != "" {
+		response.NextCursor = &results.NextCursor
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// toSearchTerms converts a partial VerifiableCredential in JSON-LD format to search terms.
+func (w *Wrapper) toSearchTerms(query map[string]interface{}) (vcr.SearchTerms, error) {
+	if credentials, ok := query["credentialSubject"].([]interface{}); ok && len(credentials) > 1 {
+		return nil, core.InvalidInputError("can't match on multiple VC subjects")
 	}
 
 	reader := jsonld.Reader{DocumentLoader: w.ContextManager.DocumentLoader()}
-	document, err := reader.Read(request.Query)
+	document, err := reader.Read(query)
 	if err != nil {
-		return core.InvalidInputError("failed to convert query to JSON-LD expanded form: %w", err)
+		return nil, core.InvalidInputError("failed to convert query to JSON-LD expanded form: %w", err)
 	}
 	searchTerms := flatten(document, nil)
 
@@ -84,15 +145,7 @@ func (w *Wrapper) SearchVCs(ctx echo.Context) error {
 		return strings.Compare(left, right) < 0
 	})
 
-	results, err := w.VCR.Search(ctx.Request().Context(), searchTerms, untrusted, nil)
-	if err != nil {
-		return err
-	}
-	searchResults, err := w.vcsWithRevocationsToSearchResults(results)
-	if err != nil {
-		return err
-	}
-	return ctx.JSON(http.StatusOK, SearchVCResults{searchResults})
+	return searchTerms, nil
 }
 
 func flatten(document interface{}, currentPath []string) []vcr.SearchTerm {
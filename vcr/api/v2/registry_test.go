@@ -30,6 +30,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +71,57 @@ const untrustedOrganizationQuery = `
 }
 `
 
+const extendedOrganizationQuery = `
+{
+	"query": {
+		"@context": ["https://www.w3.org/2018/credentials/v1","https://nuts.nl/credentials/v1"],
+		"type": ["VerifiableCredential", "NutsOrganizationCredential"],
+		"credentialSubject":{
+			"id":"did:nuts:123"
+		}
+	},
+	"anyOf": [[
+		{
+			"@context": ["https://www.w3.org/2018/credentials/v1","https://nuts.nl/credentials/v1"],
+			"type": ["VerifiableCredential", "NutsOrganizationCredential"],
+			"credentialSubject":{
+				"organization": {
+					"city": "Amandelmere"
+				}
+			}
+		},
+		{
+			"@context": ["https://www.w3.org/2018/credentials/v1","https://nuts.nl/credentials/v1"],
+			"type": ["VerifiableCredential", "NutsOrganizationCredential"],
+			"credentialSubject":{
+				"organization": {
+					"city": "Notendam"
+				}
+			}
+		}
+	]],
+	"not": [
+		{
+			"@context": ["https://www.w3.org/2018/credentials/v1","https://nuts.nl/credentials/v1"],
+			"type": ["VerifiableCredential", "NutsOrganizationCredential"],
+			"credentialSubject":{
+				"organization": {
+					"name": "Zorggroep de Nootjes"
+				}
+			}
+		}
+	],
+	"issuanceDate": {
+		"after": "2022-01-01T00:00:00Z"
+	},
+	"expirationDate": {
+		"before": "2023-01-01T00:00:00Z"
+	},
+	"limit": 2,
+	"cursor": "abc"
+}
+`
+
 const multiSubjectQuery = `
 {
 	"query": {
@@ -118,7 +170,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		})
 		// Not an organization VC, but doesn't matter
 		actualVC := *credential.ValidExplicitNutsAuthorizationCredential()
-		ctx.vcr.EXPECT().Search(context.Background(), searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{actualVC}, nil)
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), vcr.SearchQuery{Terms: searchTerms}, false, gomock.Any()).Return(&vcr.SearchResult{Credentials: []vc.VerifiableCredential{actualVC}}, nil)
 		ctx.mockVerifier.EXPECT().GetRevocation(*actualVC.ID).Return(nil, nil)
 		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any())
 
@@ -134,8 +186,8 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		ctx.echo.EXPECT().Bind(gomock.Any()).Do(func(f interface{}) {
 			_ = json.Unmarshal([]byte(organizationQuery), f)
 		})
-		ctx.vcr.EXPECT().Search(context.Background(), searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{}, nil)
-		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{[]SearchVCResult{}})
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), vcr.SearchQuery{Terms: searchTerms}, false, gomock.Any()).Return(&vcr.SearchResult{Credentials: []vc.VerifiableCredential{}}, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{VerifiableCredentials: []SearchVCResult{}})
 
 		err := ctx.client.SearchVCs(ctx.echo)
 
@@ -149,8 +201,8 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		ctx.echo.EXPECT().Bind(gomock.Any()).Do(func(f interface{}) {
 			_ = json.Unmarshal([]byte(customQuery), f)
 		})
-		ctx.vcr.EXPECT().Search(context.Background(), gomock.Any(), false, gomock.Any()).Return([]vc.VerifiableCredential{}, nil).Do(func(f1 interface{}, f2 interface{}, f3 interface{}, f4 interface{}) {
-			terms := f2.([]vcr.SearchTerm)
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), gomock.Any(), false, gomock.Any()).Return(&vcr.SearchResult{}, nil).Do(func(f1 interface{}, f2 interface{}, f3 interface{}, f4 interface{}) {
+			terms := f2.(vcr.SearchQuery).Terms
 			if assert.Len(t, terms, 9) {
 				count := 0
 
@@ -163,7 +215,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 				assert.Equal(t, 2, count)
 			}
 		})
-		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{[]SearchVCResult{}})
+		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{VerifiableCredentials: []SearchVCResult{}})
 
 		err := ctx.client.SearchVCs(ctx.echo)
 
@@ -177,14 +229,59 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		ctx.echo.EXPECT().Bind(gomock.Any()).Do(func(f interface{}) {
 			_ = json.Unmarshal([]byte(untrustedOrganizationQuery), f)
 		})
-		ctx.vcr.EXPECT().Search(context.Background(), searchTerms, true, gomock.Any()).Return([]vc.VerifiableCredential{}, nil)
-		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{[]SearchVCResult{}})
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), vcr.SearchQuery{Terms: searchTerms}, true, gomock.Any()).Return(&vcr.SearchResult{Credentials: []vc.VerifiableCredential{}}, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{VerifiableCredentials: []SearchVCResult{}})
 
 		err := ctx.client.SearchVCs(ctx.echo)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("ok - extended query", func(t *testing.T) {
+		ctx := newMockContext(t)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		ctx.echo.EXPECT().Request().Return(req)
+		ctx.echo.EXPECT().Bind(gomock.Any()).Do(func(f interface{}) {
+			_ = json.Unmarshal([]byte(extendedOrganizationQuery), f)
+		})
+		var query vcr.SearchQuery
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), gomock.Any(), false, gomock.Any()).DoAndReturn(func(_ context.Context, q vcr.SearchQuery, _ bool, _ *time.Time) (*vcr.SearchResult, error) {
+			query = q
+			return &vcr.SearchResult{Credentials: []vc.VerifiableCredential{}, NextCursor: "next"}, nil
+		})
+		nextCursor := "next"
+		ctx.echo.EXPECT().JSON(http.StatusOK, SearchVCResults{VerifiableCredentials: []SearchVCResult{}, NextCursor: &nextCursor})
+
+		err := ctx.client.SearchVCs(ctx.echo)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, vcr.SearchTerms{{IRIPath: jsonld.CredentialSubjectPath, Value: "did:nuts:123"}}, query.Terms)
+		if assert.Len(t, query.AnyOf, 1) && assert.Len(t, query.AnyOf[0], 2) {
+			assert.Equal(t, vcr.SearchTerms{{IRIPath: jsonld.OrganizationCityPath, Value: "Amandelmere"}}, query.AnyOf[0][0])
+			assert.Equal(t, vcr.SearchTerms{{IRIPath: jsonld.OrganizationCityPath, Value: "Notendam"}}, query.AnyOf[0][1])
+		}
+		assert.Equal(t, []vcr.SearchTerms{{{IRIPath: jsonld.OrganizationNamePath, Value: "Zorggroep de Nootjes"}}}, query.Not)
+		assert.Equal(t, "2022-01-01T00:00:00Z", query.IssuedAfter.Format(time.RFC3339))
+		assert.Nil(t, query.IssuedBefore)
+		assert.Nil(t, query.ExpiresAfter)
+		assert.Equal(t, "2023-01-01T00:00:00Z", query.ExpiresBefore.Format(time.RFC3339))
+		assert.Equal(t, 2, query.Limit)
+		assert.Equal(t, "abc", query.Cursor)
+	})
+
+	t.Run("error - invalid limit", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).Do(func(f interface{}) {
+			_ = json.Unmarshal([]byte(`{"query": {}, "limit": 0}`), f)
+		})
+
+		err := ctx.client.SearchVCs(ctx.echo)
+
+		assert.EqualError(t, err, "limit must be greater than 0")
+	})
+
 	t.Run("error - search returns error", func(t *testing.T) {
 		ctx := newMockContext(t)
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
@@ -192,7 +289,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		ctx.echo.EXPECT().Bind(gomock.Any()).Do(func(f interface{}) {
 			_ = json.Unmarshal([]byte(organizationQuery), f)
 		})
-		ctx.vcr.EXPECT().Search(context.Background(), searchTerms, false, gomock.Any()).Return(nil, errors.New("custom"))
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), vcr.SearchQuery{Terms: searchTerms}, false, gomock.Any()).Return(nil, errors.New("custom"))
 
 		err := ctx.client.SearchVCs(ctx.echo)
 
@@ -219,7 +316,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		})
 		// Not an organization VC, but doesn't matter
 		actualVC := *credential.ValidExplicitNutsAuthorizationCredential()
-		ctx.vcr.EXPECT().Search(context.Background(), searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{actualVC}, nil)
+		ctx.vcr.EXPECT().SearchCredentials(context.Background(), vcr.SearchQuery{Terms: searchTerms}, false, gomock.Any()).Return(&vcr.SearchResult{Credentials: []vc.VerifiableCredential{actualVC}}, nil)
 		ctx.mockVerifier.EXPECT().GetRevocation(*actualVC.ID).Return(nil, errors.New("failure"))
 
 		err := ctx.client.SearchVCs(ctx.echo)
@@ -26,6 +26,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/trust"
 )
 
 // VerifiableCredential is an alias to use from within the API
@@ -37,6 +38,9 @@ type CredentialSubject = interface{}
 // Revocation is an alias to use from within the API
 type Revocation = credential.Revocation
 
+// TrustPolicy is an alias to use from within the API
+type TrustPolicy = trust.Policy
+
 // VerifiablePresentation is an alias to use from within the API
 type VerifiablePresentation = vc.VerifiablePresentation
 
@@ -65,6 +69,16 @@ type SearchVCQuery struct {
 // SearchVCRequest is the request body for searching VCs
 type SearchVCRequest struct {
 	// A partial VerifiableCredential in JSON-LD format. Each field will be used to match credentials against. All fields MUST be present.
-	Query         SearchVCQuery  `json:"query"`
+	Query SearchVCQuery `json:"query"`
+	// Groups of partial VerifiableCredentials. For every group, a credential must match at least one of its partial VerifiableCredentials.
+	AnyOf [][]SearchVCQuery `json:"anyOf,omitempty"`
+	// Partial VerifiableCredentials of which none may match.
+	Not            []SearchVCQuery `json:"not,omitempty"`
+	IssuanceDate   *DateRange      `json:"issuanceDate,omitempty"`
+	ExpirationDate *DateRange      `json:"expirationDate,omitempty"`
+	// Maximum number of credentials to return.
+	Limit *int `json:"limit,omitempty"`
+	// Cursor to retrieve the next page of results, as returned by a previous search.
+	Cursor        *string        `json:"cursor,omitempty"`
 	SearchOptions *SearchOptions `json:"searchOptions,omitempty"`
 }
@@ -20,14 +20,30 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr"
 	api "github.com/nuts-foundation/nuts-node/vcr/api/v2"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// FlagSet contains flags relevant for the VCR
+func FlagSet() *pflag.FlagSet {
+	defs := vcr.DefaultConfig()
+	flagSet := pflag.NewFlagSet("vcr", pflag.ContinueOnError)
+	flagSet.StringToString("vcr.credentialschemas", defs.CredentialSchemas, "Maps custom credential types to JSON Schema files. "+
+		"Credentials of these types are validated against the schema, e.g. CareRelationshipCredential=/opt/nuts/schemas/care-relationship.json")
+	flagSet.Duration("vcr.expirynotificationperiod", defs.ExpiryNotificationPeriod, "Period before the expiration date of a credential issued by this node, "+
+		"at which an event is published on the CREDENTIALS.expiring subject of the events engine. Set to 0 to disable.")
+	flagSet.StringSlice("vcr.trustlistsigners", defs.TrustListSigners, "DIDs of the parties (e.g. care network operators) whose signed trust lists may be imported. "+
+		"Importing a trust list replaces the trust policies of earlier trust lists from the same signer.")
+	return flagSet
+}
+
 // Cmd contains sub-commands for the remote client
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -43,6 +59,8 @@ func Cmd() *cobra.Command {
 
 	cmd.AddCommand(listUntrustedCmd())
 
+	cmd.AddCommand(importTrustListCmd())
+
 	return cmd
 }
 
@@ -128,6 +146,29 @@ func listUntrustedCmd() *cobra.Command {
 	}
 }
 
+func importTrustListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-trust-list [file]",
+		Short: "Import the trust policies of a signed trust list (JWT). It must be signed by one of the configured trust list signers.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read trust list: %v", err)
+			}
+
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			policies, err := httpClient(clientConfig).ImportTrustList(strings.TrimSpace(string(data)))
+			if err != nil {
+				return fmt.Errorf("unable to import trust list: %v", err)
+			}
+
+			cmd.Println(fmt.Sprintf("Imported %d trust policies", len(policies)))
+			return nil
+		},
+	}
+}
+
 // httpClient creates a remote client
 func httpClient(config core.ClientConfig) api.HTTPClient {
 	return api.HTTPClient{
@@ -25,13 +25,35 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"testing"
+	"time"
 
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestFlagSet(t *testing.T) {
+	flagset := FlagSet()
+
+	value, err := flagset.GetStringToString("vcr.credentialschemas")
+
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+
+	period, err := flagset.GetDuration("vcr.expirynotificationperiod")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, period)
+
+	signers, err := flagset.GetStringSlice("vcr.trustlistsigners")
+
+	assert.NoError(t, err)
+	assert.Empty(t, signers)
+}
+
 // TestCmd test the nuts vcr * commands
 func TestCmd(t *testing.T) {
 	didString := "did:nuts:1"
@@ -164,6 +186,50 @@ func TestCmd(t *testing.T) {
 	}
 }
 
+func TestCmd_ImportTrustList(t *testing.T) {
+	buf := new(bytes.Buffer)
+	trustListFile := path.Join(io.TestDirectory(t), "trustlist.jwt")
+	_ = os.WriteFile(trustListFile, []byte("token\n"), os.ModePerm)
+
+	t.Run("ok", func(t *testing.T) {
+		buf.Reset()
+		cmd := Cmd()
+		cmd.SetOut(buf)
+		s := setupServer(cmd, http.StatusOK, []map[string]string{{"id": "1", "credentialType": "type", "issuer": "did:nuts:1"}})
+		defer reset(s)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+		cmd.SetArgs([]string{"import-trust-list", trustListFile})
+		err := cmd.Execute()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Contains(t, buf.String(), "Imported 1 trust policies")
+	})
+
+	t.Run("error - server error", func(t *testing.T) {
+		cmd := Cmd()
+		s := setupServer(cmd, http.StatusBadRequest, nil)
+		defer reset(s)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+		cmd.SetArgs([]string{"import-trust-list", trustListFile})
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to import trust list")
+	})
+
+	t.Run("error - file does not exist", func(t *testing.T) {
+		cmd := Cmd()
+
+		cmd.SetArgs([]string{"import-trust-list", path.Join(io.TestDirectory(t), "non-existing")})
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to read trust list")
+	})
+}
+
 func setupServer(cmd *cobra.Command, statusCode int, responseData interface{}) *httptest.Server {
 	s := httptest.NewServer(http2.Handler{StatusCode: statusCode, ResponseData: responseData})
 	os.Setenv("NUTS_ADDRESS", s.URL)
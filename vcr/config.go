@@ -19,6 +19,8 @@
 
 package vcr
 
+import "time"
+
 const moduleName = "VCR"
 
 // Config holds the config for the vcr engine
@@ -27,9 +29,18 @@ type Config struct {
 	strictMode bool
 	// datadir holds the location the VCR files are stored
 	datadir string
+	// CredentialSchemas maps custom credential types to JSON Schema files, against which credentials of that type are validated.
+	CredentialSchemas map[string]string `koanf:"credentialschemas"`
+	// ExpiryNotificationPeriod specifies how long before its expiration date an event is published for a credential issued by this node.
+	// Zero disables expiry notifications.
+	ExpiryNotificationPeriod time.Duration `koanf:"expirynotificationperiod"`
+	// TrustListSigners contains the DIDs of the parties whose signed trust lists may be imported.
+	TrustListSigners []string `koanf:"trustlistsigners"`
 }
 
 // DefaultConfig returns a fresh Config filled with default values
 func DefaultConfig() Config {
-	return Config{}
+	return Config{
+		ExpiryNotificationPeriod: 7 * 24 * time.Hour,
+	}
 }
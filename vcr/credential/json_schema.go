@@ -0,0 +1,75 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package credential
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// jsonSchemaValidator validates the default fields of a credential, after which the credential (in its JSON form) is validated against a JSON Schema.
+type jsonSchemaValidator struct {
+	credentialType string
+	schema         *jsonschema.Schema
+}
+
+// NewJSONSchemaValidator creates a Validator for the given credential type, which validates credentials against the JSON Schema in the given file.
+// The schema is applied to the whole credential, e.g. to require certain fields in the credentialSubject.
+func NewJSONSchemaValidator(credentialType string, schemaFile string) (Validator, error) {
+	schema, err := jsonschema.Compile(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+	return jsonSchemaValidator{credentialType: credentialType, schema: schema}, nil
+}
+
+func (d jsonSchemaValidator) Validate(credential vc.VerifiableCredential) error {
+	if err := Validate(credential); err != nil {
+		return err
+	}
+
+	if !containsType(credential, d.credentialType) {
+		return failure("type '%s' is required", d.credentialType)
+	}
+
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return fmt.Errorf("unable to marshal credential: %w", err)
+	}
+	var document interface{}
+	if err = json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("unable to unmarshal credential: %w", err)
+	}
+	if err = d.schema.Validate(document); err != nil {
+		return failure("%s", err)
+	}
+	return nil
+}
+
+func containsType(credential vc.VerifiableCredential, credentialType string) bool {
+	for _, t := range credential.Type {
+		if t.String() == credentialType {
+			return true
+		}
+	}
+	return false
+}
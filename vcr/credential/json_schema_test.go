@@ -0,0 +1,148 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package credential
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/stretchr/testify/assert"
+)
+
+const careRelationshipSchema = `
+{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["credentialSubject"],
+	"properties": {
+		"credentialSubject": {
+			"type": "object",
+			"required": ["id", "patient"],
+			"properties": {
+				"patient": {
+					"type": "string",
+					"pattern": "^urn:oid:"
+				}
+			}
+		}
+	}
+}
+`
+
+const careRelationshipCredential = `
+{
+	"@context": ["https://www.w3.org/2018/credentials/v1"],
+	"id": "did:nuts:B8PUHs2AUHbFF1xLLK4eZjgErEcMXHxs68FteY7NDtCY#1",
+	"issuer": "did:nuts:B8PUHs2AUHbFF1xLLK4eZjgErEcMXHxs68FteY7NDtCY",
+	"issuanceDate": "2022-01-01T12:00:00Z",
+	"type": ["VerifiableCredential", "CareRelationshipCredential"],
+	"credentialSubject": {
+		"id": "did:nuts:GvkzxsezHvEc8nGhgz6Xo3jbqkHwswLmWw3CYtCm7hAW",
+		"patient": "urn:oid:2.16.840.1.113883.2.4.6.3:123456780"
+	},
+	"proof": {}
+}
+`
+
+func writeSchema(t *testing.T, schema string) string {
+	schemaFile := path.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(schema), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	return schemaFile
+}
+
+func careRelationshipVC(modifier func(map[string]interface{})) vc.VerifiableCredential {
+	document := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(careRelationshipCredential), &document)
+	if modifier != nil {
+		modifier(document)
+	}
+	data, _ := json.Marshal(document)
+	result := vc.VerifiableCredential{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+func TestNewJSONSchemaValidator(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		validator, err := NewJSONSchemaValidator("CareRelationshipCredential", writeSchema(t, careRelationshipSchema))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, validator)
+	})
+	t.Run("error - file does not exist", func(t *testing.T) {
+		validator, err := NewJSONSchemaValidator("CareRelationshipCredential", path.Join(t.TempDir(), "schema.json"))
+
+		assert.Error(t, err)
+		assert.Nil(t, validator)
+	})
+	t.Run("error - invalid schema", func(t *testing.T) {
+		validator, err := NewJSONSchemaValidator("CareRelationshipCredential", writeSchema(t, `{"type": 1}`))
+
+		assert.Error(t, err)
+		assert.Nil(t, validator)
+	})
+}
+
+func TestJSONSchemaValidator_Validate(t *testing.T) {
+	validator, err := NewJSONSchemaValidator("CareRelationshipCredential", writeSchema(t, careRelationshipSchema))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		err := validator.Validate(careRelationshipVC(nil))
+
+		assert.NoError(t, err)
+	})
+	t.Run("failed - default fields are validated", func(t *testing.T) {
+		err := validator.Validate(careRelationshipVC(func(document map[string]interface{}) {
+			delete(document, "proof")
+		}))
+
+		assert.EqualError(t, err, "validation failed: 'proof' is required")
+	})
+	t.Run("failed - missing type", func(t *testing.T) {
+		err := validator.Validate(careRelationshipVC(func(document map[string]interface{}) {
+			document["type"] = []string{"VerifiableCredential", "OtherCredential"}
+		}))
+
+		assert.EqualError(t, err, "validation failed: type 'CareRelationshipCredential' is required")
+	})
+	t.Run("failed - missing field", func(t *testing.T) {
+		err := validator.Validate(careRelationshipVC(func(document map[string]interface{}) {
+			delete(document["credentialSubject"].(map[string]interface{}), "patient")
+		}))
+
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Contains(t, err.Error(), "missing properties: 'patient'")
+	})
+	t.Run("failed - invalid field", func(t *testing.T) {
+		err := validator.Validate(careRelationshipVC(func(document map[string]interface{}) {
+			document["credentialSubject"].(map[string]interface{})["patient"] = "123456780"
+		}))
+
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Contains(t, err.Error(), "does not match pattern")
+	})
+}
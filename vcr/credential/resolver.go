@@ -20,6 +20,9 @@
 package credential
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/nuts-foundation/go-did/vc"
 )
 
@@ -42,6 +45,67 @@ func FindValidatorAndBuilder(credential vc.VerifiableCredential) (Validator, Bui
 	return defaultCredentialValidator{}, nil
 }
 
+// ValidatorRegistry contains the validators for credential types other than the Nuts credential types
+// (e.g. validating against a configured JSON schema).
+type ValidatorRegistry struct {
+	validators map[string]Validator
+	mux        sync.RWMutex
+}
+
+// NewValidatorRegistry creates an empty ValidatorRegistry.
+func NewValidatorRegistry() *ValidatorRegistry {
+	return &ValidatorRegistry{validators: map[string]Validator{}}
+}
+
+// Register registers the Validator for the given (custom) credential type, replacing any previously registered validator.
+// The validators of the Nuts credential types can't be replaced.
+func (r *ValidatorRegistry) Register(credentialType string, validator Validator) error {
+	switch credentialType {
+	case NutsOrganizationCredentialType:
+		fallthrough
+	case NutsAuthorizationCredentialType:
+		return fmt.Errorf("validator for credential type %s can't be replaced", credentialType)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.validators[credentialType] = validator
+	return nil
+}
+
+// FindValidatorAndBuilder works like the package level FindValidatorAndBuilder,
+// but also applies the registered validators of all (custom) types of the credential.
+// A nil registry doesn't contain any validators.
+func (r *ValidatorRegistry) FindValidatorAndBuilder(credential vc.VerifiableCredential) (Validator, Builder) {
+	validator, builder := FindValidatorAndBuilder(credential)
+	if r == nil {
+		return validator, builder
+	}
+	chain := validatorChain{validator}
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	for _, vcType := range ExtractTypes(credential) {
+		if registered, ok := r.validators[vcType]; ok {
+			chain = append(chain, registered)
+		}
+	}
+	if len(chain) == 1 {
+		return validator, builder
+	}
+	return chain, builder
+}
+
+// validatorChain is a Validator that applies all of its validators, failing on the first one that fails.
+type validatorChain []Validator
+
+func (c validatorChain) Validate(credential vc.VerifiableCredential) error {
+	for _, validator := range c {
+		if err := validator.Validate(credential); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ExtractTypes extract additional VC types from the VC as strings
 // It removes the default `VerifiableCredential` type from the types, returns the rest.
 func ExtractTypes(credential vc.VerifiableCredential) []string {
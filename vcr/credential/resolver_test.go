@@ -52,6 +52,92 @@ func TestFindValidatorAndBuilder(t *testing.T) {
 	})
 }
 
+func TestValidatorRegistry_FindValidatorAndBuilder(t *testing.T) {
+	t.Run("registered validator found for custom type", func(t *testing.T) {
+		registry := NewValidatorRegistry()
+		validator, _ := NewJSONSchemaValidator("CareRelationshipCredential", writeSchema(t, careRelationshipSchema))
+		err := registry.Register("CareRelationshipCredential", validator)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		v, b := registry.FindValidatorAndBuilder(careRelationshipVC(nil))
+
+		assert.Equal(t, validatorChain{defaultCredentialValidator{}, validator}, v)
+		assert.Equal(t, defaultBuilder{vcType: "CareRelationshipCredential"}, b)
+		t.Run("not registered in other registries", func(t *testing.T) {
+			v, _ := NewValidatorRegistry().FindValidatorAndBuilder(careRelationshipVC(nil))
+
+			assert.Equal(t, defaultCredentialValidator{}, v)
+		})
+	})
+	t.Run("registered validator found for custom type that isn't listed first", func(t *testing.T) {
+		registry := NewValidatorRegistry()
+		validator, _ := NewJSONSchemaValidator("CareRelationshipCredential", writeSchema(t, careRelationshipSchema))
+		_ = registry.Register("CareRelationshipCredential", validator)
+		credential := careRelationshipVC(func(document map[string]interface{}) {
+			document["type"] = []interface{}{"VerifiableCredential", "OtherCredential", "CareRelationshipCredential"}
+			delete(document["credentialSubject"].(map[string]interface{}), "patient")
+		})
+
+		v, _ := registry.FindValidatorAndBuilder(credential)
+
+		assert.Equal(t, validatorChain{defaultCredentialValidator{}, validator}, v)
+		assert.ErrorIs(t, v.Validate(credential), ErrValidation)
+	})
+	t.Run("validators of all registered types are applied", func(t *testing.T) {
+		registry := NewValidatorRegistry()
+		first := &countingValidator{}
+		second := &countingValidator{}
+		_ = registry.Register("CareRelationshipCredential", first)
+		_ = registry.Register("OtherCredential", second)
+		credential := careRelationshipVC(func(document map[string]interface{}) {
+			document["type"] = []interface{}{"VerifiableCredential", "OtherCredential", "CareRelationshipCredential"}
+		})
+
+		v, _ := registry.FindValidatorAndBuilder(credential)
+		err := v.Validate(credential)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, first.calls)
+		assert.Equal(t, 1, second.calls)
+	})
+	t.Run("Nuts credential type", func(t *testing.T) {
+		v, b := NewValidatorRegistry().FindValidatorAndBuilder(*validNutsOrganizationCredential())
+
+		assert.Equal(t, nutsOrganizationCredentialValidator{}, v)
+		assert.NotNil(t, b)
+	})
+	t.Run("nil registry", func(t *testing.T) {
+		var registry *ValidatorRegistry
+
+		v, b := registry.FindValidatorAndBuilder(careRelationshipVC(nil))
+
+		assert.Equal(t, defaultCredentialValidator{}, v)
+		assert.NotNil(t, b)
+	})
+}
+
+type countingValidator struct {
+	calls int
+}
+
+func (c *countingValidator) Validate(_ vc.VerifiableCredential) error {
+	c.calls++
+	return nil
+}
+
+func TestValidatorRegistry_Register(t *testing.T) {
+	t.Run("validators of Nuts credential types can't be replaced", func(t *testing.T) {
+		registry := NewValidatorRegistry()
+
+		err := registry.Register(NutsOrganizationCredentialType, defaultCredentialValidator{})
+
+		assert.EqualError(t, err, "validator for credential type NutsOrganizationCredential can't be replaced")
+		assert.Empty(t, registry.validators)
+	})
+}
+
 func TestExtractTypes(t *testing.T) {
 	v := vc.VerifiableCredential{
 		Type: []ssi.URI{vc.VerifiableCredentialTypeV1URI(), *NutsOrganizationCredentialTypeURI},
@@ -0,0 +1,135 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vcr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/events"
+	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+)
+
+// expiryCheckInterval specifies how often the issued credentials are checked for upcoming expiry.
+const expiryCheckInterval = time.Hour
+
+// expiryNotifier periodically checks whether credentials issued by this node are about to expire,
+// and publishes an event on the events.CredentialExpiringSubject for each of them.
+// Every credential is notified once while the node is running, so it might be notified again after a restart.
+type expiryNotifier struct {
+	store        issuer.Store
+	eventManager events.Event
+	// period specifies how long before its expiration date a credential is notified about.
+	period   time.Duration
+	interval time.Duration
+	// notified contains the IDs of the credentials that have been notified about, mapped to their expiration date.
+	notified map[string]time.Time
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func newExpiryNotifier(store issuer.Store, eventManager events.Event, period time.Duration) *expiryNotifier {
+	return &expiryNotifier{
+		store:        store,
+		eventManager: eventManager,
+		period:       period,
+		interval:     expiryCheckInterval,
+		notified:     map[string]time.Time{},
+	}
+}
+
+// start checks the issued credentials right away, and then at every interval until stop is called.
+func (e *expiryNotifier) start() {
+	var ctx context.Context
+	ctx, e.cancel = context.WithCancel(context.Background())
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			e.check(time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (e *expiryNotifier) stop() {
+	if e.cancel != nil {
+		e.cancel()
+		e.wg.Wait()
+	}
+}
+
+// check publishes an event for every issued credential that expires within the configured period, and hasn't been revoked or notified about.
+func (e *expiryNotifier) check(now time.Time) {
+	// forget credentials that have expired, they won't be returned again
+	for id, expirationDate := range e.notified {
+		if expirationDate.Before(now) {
+			delete(e.notified, id)
+		}
+	}
+
+	credentials, err := e.store.SearchExpiringCredentials(now.Add(e.period))
+	if err != nil {
+		log.Logger().
+			WithError(err).
+			Error("Unable to search for expiring credentials")
+		return
+	}
+	for _, credential := range credentials {
+		credentialID := credential.ID.String()
+		if credential.ExpirationDate.Before(now) {
+			continue
+		}
+		if _, ok := e.notified[credentialID]; ok {
+			continue
+		}
+		if _, err := e.store.GetRevocation(*credential.ID); err == nil {
+			continue
+		} else if !errors.Is(err, issuer.ErrNotFound) {
+			log.Logger().
+				WithError(err).
+				WithField(core.LogFieldCredentialID, credentialID).
+				Error("Unable to check revocation of expiring credential")
+			continue
+		}
+		err = e.eventManager.Publish(events.CredentialsStream, events.CredentialExpiringSubject, events.CredentialExpiringEvent{
+			CredentialID:   credentialID,
+			Issuer:         credential.Issuer.String(),
+			ExpirationDate: *credential.ExpirationDate,
+		})
+		if err != nil {
+			log.Logger().
+				WithError(err).
+				WithField(core.LogFieldCredentialID, credentialID).
+				Warn("Failed to publish credential expiry event")
+			continue
+		}
+		e.notified[credentialID] = *credential.ExpirationDate
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vcr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/events"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expiryNotifier_check(t *testing.T) {
+	now := time.Now()
+	expirationDate := now.Add(time.Hour)
+	credentialID := ssi.MustParseURI("did:nuts:issuer#1")
+	expiring := vc.VerifiableCredential{
+		ID:             &credentialID,
+		Issuer:         ssi.MustParseURI("did:nuts:issuer"),
+		ExpirationDate: &expirationDate,
+	}
+	expectedEvent := events.CredentialExpiringEvent{
+		CredentialID:   credentialID.String(),
+		Issuer:         "did:nuts:issuer",
+		ExpirationDate: expirationDate,
+	}
+	setup := func(t *testing.T) (*expiryNotifier, *issuer.MockStore, *events.MockEvent) {
+		ctrl := gomock.NewController(t)
+		store := issuer.NewMockStore(ctrl)
+		eventManager := events.NewMockEvent(ctrl)
+		return newExpiryNotifier(store, eventManager, 24*time.Hour), store, eventManager
+	}
+
+	t.Run("notifies once", func(t *testing.T) {
+		notifier, store, eventManager := setup(t)
+		store.EXPECT().SearchExpiringCredentials(now.Add(24*time.Hour)).Return([]vc.VerifiableCredential{expiring}, nil).Times(2)
+		store.EXPECT().GetRevocation(credentialID).Return(nil, issuer.ErrNotFound)
+		eventManager.EXPECT().Publish(events.CredentialsStream, events.CredentialExpiringSubject, expectedEvent)
+
+		notifier.check(now)
+		notifier.check(now)
+	})
+	t.Run("retries when publishing fails", func(t *testing.T) {
+		notifier, store, eventManager := setup(t)
+		store.EXPECT().SearchExpiringCredentials(gomock.Any()).Return([]vc.VerifiableCredential{expiring}, nil).Times(2)
+		store.EXPECT().GetRevocation(credentialID).Return(nil, issuer.ErrNotFound).Times(2)
+		gomock.InOrder(
+			eventManager.EXPECT().Publish(events.CredentialsStream, events.CredentialExpiringSubject, expectedEvent).Return(errors.New("b00m!")),
+			eventManager.EXPECT().Publish(events.CredentialsStream, events.CredentialExpiringSubject, expectedEvent),
+		)
+
+		notifier.check(now)
+		notifier.check(now)
+	})
+	t.Run("skips revoked credentials", func(t *testing.T) {
+		notifier, store, _ := setup(t)
+		store.EXPECT().SearchExpiringCredentials(gomock.Any()).Return([]vc.VerifiableCredential{expiring}, nil)
+		store.EXPECT().GetRevocation(credentialID).Return(&credential.Revocation{}, nil)
+
+		notifier.check(now)
+	})
+	t.Run("skips expired credentials", func(t *testing.T) {
+		notifier, store, _ := setup(t)
+		store.EXPECT().SearchExpiringCredentials(gomock.Any()).Return([]vc.VerifiableCredential{expiring}, nil)
+
+		notifier.check(now.Add(2 * time.Hour))
+	})
+	t.Run("forgets expired credentials", func(t *testing.T) {
+		notifier, store, _ := setup(t)
+		notifier.notified[credentialID.String()] = expirationDate
+		store.EXPECT().SearchExpiringCredentials(gomock.Any()).Return(nil, nil)
+
+		notifier.check(now.Add(2 * time.Hour))
+
+		assert.Empty(t, notifier.notified)
+	})
+	t.Run("search fails", func(t *testing.T) {
+		notifier, store, _ := setup(t)
+		store.EXPECT().SearchExpiringCredentials(gomock.Any()).Return(nil, errors.New("b00m!"))
+
+		notifier.check(now)
+	})
+}
+
+func Test_expiryNotifier_start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := issuer.NewMockStore(ctrl)
+	notifier := newExpiryNotifier(store, events.NewMockEvent(ctrl), time.Hour)
+	notifier.interval = 10 * time.Millisecond
+	checked := make(chan struct{}, 10)
+	store.EXPECT().SearchExpiringCredentials(gomock.Any()).DoAndReturn(func(_ time.Time) ([]vc.VerifiableCredential, error) {
+		checked <- struct{}{}
+		return nil, nil
+	}).MinTimes(2)
+
+	notifier.start()
+	<-checked
+	<-checked
+	notifier.stop()
+}
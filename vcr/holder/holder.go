@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -29,6 +30,8 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
@@ -53,6 +56,65 @@ func New(keyResolver vdr.KeyResolver, keyStore crypto.KeyStore, verifier verifie
 }
 
 func (h vcHolder) BuildVP(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error) {
+	key, err := h.resolvePresentationKey(credentials, proofOptions, signerDID, validateVC)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedVP := &vc.VerifiablePresentation{
+		Context:              []ssi.URI{VerifiableCredentialLDContextV1, signature.JSONWebSignature2020Context},
+		Type:                 []ssi.URI{VerifiablePresentationLDType},
+		VerifiableCredential: credentials,
+	}
+
+	// Convert to map[string]interface{} for signing
+	documentBytes, err := unsignedVP.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var document proof.Document
+	err = json.Unmarshal(documentBytes, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	signingResult, err := proof.
+		NewLDProof(proofOptions).
+		Sign(document, signature.JSONWebSignature2020{ContextLoader: h.jsonldManager.DocumentLoader()}, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign VP with LD proof: %w", err)
+	}
+
+	var signedVP vc.VerifiablePresentation
+	signedVPData, _ := json.Marshal(signingResult)
+	err = json.Unmarshal(signedVPData, &signedVP)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signedVP, nil
+}
+
+func (h vcHolder) BuildJWTVP(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error) {
+	key, err := h.resolvePresentationKey(credentials, proofOptions, signerDID, validateVC)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedVP := vc.VerifiablePresentation{
+		Context:              []ssi.URI{VerifiableCredentialLDContextV1},
+		Type:                 []ssi.URI{VerifiablePresentationLDType},
+		VerifiableCredential: credentials,
+	}
+	signedVP, err := jwtvc.CreatePresentation(unsignedVP, proofOptions, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign VP as JWT: %w", err)
+	}
+	return signedVP, nil
+}
+
+// resolvePresentationKey resolves the key to sign a presentation of the given credentials with, and optionally validates the credentials.
+func (h vcHolder) resolvePresentationKey(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (crypto.Key, error) {
 	var err error
 	if signerDID == nil {
 		signerDID, err = h.resolveSubjectDID(credentials)
@@ -79,39 +141,32 @@ func (h vcHolder) BuildVP(credentials []vc.VerifiableCredential, proofOptions pr
 		}
 	}
 
-	unsignedVP := &vc.VerifiablePresentation{
-		Context:              []ssi.URI{VerifiableCredentialLDContextV1, signature.JSONWebSignature2020Context},
-		Type:                 []ssi.URI{VerifiablePresentationLDType},
-		VerifiableCredential: credentials,
-	}
+	return key, nil
+}
 
-	// Convert to map[string]interface{} for signing
-	documentBytes, err := unsignedVP.MarshalJSON()
+func (h vcHolder) BuildSDJWTPresentation(credential sdjwt.SDJWT, disclose []string, audience string, nonce string) (*sdjwt.SDJWT, error) {
+	subject, err := credential.Subject()
 	if err != nil {
-		return nil, err
+		return nil, core.InvalidInputError("invalid SD-JWT credential: %w", err)
 	}
-	var document proof.Document
-	err = json.Unmarshal(documentBytes, &document)
+	subjectDID, err := did.ParseDID(subject)
 	if err != nil {
-		return nil, err
+		return nil, core.InvalidInputError("invalid SD-JWT credential subject: %w", err)
 	}
-
-	// TODO: choose between different proof types (JWT or LD-Proof)
-	signingResult, err := proof.
-		NewLDProof(proofOptions).
-		Sign(document, signature.JSONWebSignature2020{ContextLoader: h.jsonldManager.DocumentLoader()}, key)
+	selected, err := credential.Select(disclose)
 	if err != nil {
-		return nil, fmt.Errorf("unable to sign VP with LD proof: %w", err)
+		return nil, core.InvalidInputError("%w", err)
 	}
 
-	var signedVP vc.VerifiablePresentation
-	signedVPData, _ := json.Marshal(signingResult)
-	err = json.Unmarshal(signedVPData, &signedVP)
+	kid, err := h.keyResolver.ResolveAssertionKeyID(*subjectDID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to resolve assertion key for signing SD-JWT presentation (did=%s): %w", *subjectDID, err)
 	}
-
-	return &signedVP, nil
+	key, err := h.keyStore.Resolve(kid.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve assertion key from key store for signing SD-JWT presentation (did=%s): %w", *subjectDID, err)
+	}
+	return selected.Bind(key, audience, nonce, time.Now())
 }
 
 func (h vcHolder) resolveSubjectDID(credentials []vc.VerifiableCredential) (*did.DID, error) {
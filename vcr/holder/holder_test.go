@@ -19,6 +19,7 @@
 package holder
 
 import (
+	crypto2 "crypto"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -29,6 +30,8 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/nuts-foundation/nuts-node/vdr"
@@ -211,3 +214,108 @@ func TestHolder_BuildVP(t *testing.T) {
 		})
 	})
 }
+
+func TestHolder_BuildJWTVP(t *testing.T) {
+	key := vdr.TestMethodDIDAPrivateKey()
+	credentialID := ssi.MustParseURI("did:nuts:issuer#1")
+	testCredential := vc.VerifiableCredential{
+		Context:           []ssi.URI{vc.VCContextV1URI()},
+		ID:                &credentialID,
+		Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("CompanyCredential")},
+		Issuer:            ssi.MustParseURI("did:nuts:issuer"),
+		IssuanceDate:      time.Now(),
+		CredentialSubject: []interface{}{map[string]interface{}{"id": vdr.TestDIDA.String()}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolver := types.NewMockKeyResolver(ctrl)
+		keyStore := crypto.NewMockKeyStore(ctrl)
+		keyResolver.EXPECT().ResolveAssertionKeyID(*vdr.TestDIDA).Return(vdr.TestMethodDIDA.URI(), nil)
+		keyStore.EXPECT().Resolve(vdr.TestMethodDIDA.URI().String()).Return(key, nil)
+		holder := New(keyResolver, keyStore, nil, nil)
+		domain := "verifier"
+
+		result, err := holder.BuildJWTVP([]vc.VerifiableCredential{testCredential}, proof.ProofOptions{Created: time.Now(), Domain: &domain}, nil, false)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, vdr.TestDIDA.String(), result.Holder.String())
+		assert.Len(t, result.VerifiableCredential, 1)
+		err = jwtvc.VerifyPresentation(*result, func(_ string) (crypto2.PublicKey, error) {
+			return key.Public(), nil
+		}, time.Now())
+		assert.NoError(t, err)
+	})
+	t.Run("error - unable to resolve key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolver := types.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveAssertionKeyID(*vdr.TestDIDA).Return(ssi.URI{}, types.ErrNotFound)
+		holder := New(keyResolver, nil, nil, nil)
+
+		result, err := holder.BuildJWTVP([]vc.VerifiableCredential{testCredential}, proof.ProofOptions{}, vdr.TestDIDA, false)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestHolder_BuildSDJWTPresentation(t *testing.T) {
+	issuerKey := crypto.NewTestKey("did:nuts:issuer#key-1")
+	holderKey := vdr.TestMethodDIDAPrivateKey()
+	credentialID := ssi.MustParseURI("did:nuts:issuer#1")
+	issued, _ := sdjwt.Issue(vc.VerifiableCredential{
+		ID:           &credentialID,
+		Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("NutsEmployeeCredential")},
+		Issuer:       ssi.MustParseURI("did:nuts:issuer"),
+		IssuanceDate: time.Now().Add(-time.Minute),
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":       vdr.TestDIDA.String(),
+			"name":     "John Doe",
+			"roleName": "Nurse",
+		}},
+	}, issuerKey)
+
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolver := types.NewMockKeyResolver(ctrl)
+		keyStore := crypto.NewMockKeyStore(ctrl)
+		keyResolver.EXPECT().ResolveAssertionKeyID(*vdr.TestDIDA).Return(vdr.TestMethodDIDA.URI(), nil)
+		keyStore.EXPECT().Resolve(vdr.TestMethodDIDA.URI().String()).Return(holderKey, nil)
+		holder := New(keyResolver, keyStore, nil, nil)
+
+		presentation, err := holder.BuildSDJWTPresentation(*issued, []string{"roleName"}, "verifier", "nonce")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		verified, err := sdjwt.Verify(*presentation, func(kid string) (crypto2.PublicKey, error) {
+			if kid == issuerKey.KID() {
+				return issuerKey.Public(), nil
+			}
+			return holderKey.Public(), nil
+		}, time.Now(), &sdjwt.KeyBindingRequirements{Audience: "verifier", Nonce: "nonce"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, map[string]interface{}{"id": vdr.TestDIDA.String(), "roleName": "Nurse"}, verified.CredentialSubject[0])
+	})
+	t.Run("error - claim can't be disclosed", func(t *testing.T) {
+		holder := New(nil, nil, nil, nil)
+
+		_, err := holder.BuildSDJWTPresentation(*issued, []string{"email"}, "", "")
+
+		assert.EqualError(t, err, "claim can't be disclosed: email")
+	})
+	t.Run("error - unknown key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolver := types.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveAssertionKeyID(*vdr.TestDIDA).Return(ssi.URI{}, types.ErrKeyNotFound)
+		holder := New(keyResolver, nil, nil, nil)
+
+		_, err := holder.BuildSDJWTPresentation(*issued, []string{"name"}, "", "")
+
+		assert.ErrorIs(t, err, types.ErrKeyNotFound)
+	})
+}
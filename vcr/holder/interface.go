@@ -22,6 +22,7 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 )
 
@@ -41,4 +42,11 @@ type Holder interface {
 	// The assertion key used for signing it is taken from signerDID's DID document.
 	// If signerDID is not provided, it will be derived from the credentials credentialSubject.id fields. But only if all provided credentials have the same (singular) credentialSubject.id field.
 	BuildVP(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error)
+	// BuildJWTVP builds a Verifiable Presentation like BuildVP, but encodes it as JWT (vp+jwt) instead of signing it with a Linked Data proof.
+	// Domain and challenge of the proof options are encoded as audience and nonce. JWT encoded credentials are included as JWT.
+	BuildJWTVP(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error)
+	// BuildSDJWTPresentation creates a presentation of an SD-JWT VC which only discloses the given claims.
+	// The presentation is bound to the given audience and nonce (both optional) using a Key Binding JWT,
+	// which is signed with an assertion key of the credential's subject.
+	BuildSDJWTPresentation(credential sdjwt.SDJWT, disclose []string, audience string, nonce string) (*sdjwt.SDJWT, error)
 }
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	did "github.com/nuts-foundation/go-did/did"
 	vc "github.com/nuts-foundation/go-did/vc"
+	sdjwt "github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	proof "github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 )
 
@@ -36,6 +37,36 @@ func (m *MockHolder) EXPECT() *MockHolderMockRecorder {
 	return m.recorder
 }
 
+// BuildJWTVP mocks base method.
+func (m *MockHolder) BuildJWTVP(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildJWTVP", credentials, proofOptions, signerDID, validateVC)
+	ret0, _ := ret[0].(*vc.VerifiablePresentation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildJWTVP indicates an expected call of BuildJWTVP.
+func (mr *MockHolderMockRecorder) BuildJWTVP(credentials, proofOptions, signerDID, validateVC interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildJWTVP", reflect.TypeOf((*MockHolder)(nil).BuildJWTVP), credentials, proofOptions, signerDID, validateVC)
+}
+
+// BuildSDJWTPresentation mocks base method.
+func (m *MockHolder) BuildSDJWTPresentation(credential sdjwt.SDJWT, disclose []string, audience, nonce string) (*sdjwt.SDJWT, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildSDJWTPresentation", credential, disclose, audience, nonce)
+	ret0, _ := ret[0].(*sdjwt.SDJWT)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildSDJWTPresentation indicates an expected call of BuildSDJWTPresentation.
+func (mr *MockHolderMockRecorder) BuildSDJWTPresentation(credential, disclose, audience, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildSDJWTPresentation", reflect.TypeOf((*MockHolder)(nil).BuildSDJWTPresentation), credential, disclose, audience, nonce)
+}
+
 // BuildVP mocks base method.
 func (m *MockHolder) BuildVP(credentials []vc.VerifiableCredential, proofOptions proof.ProofOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error) {
 	m.ctrl.T.Helper()
@@ -29,6 +29,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/trust"
 )
 
 // Finder is the VCR interface for searching VCs
@@ -37,6 +38,10 @@ type Finder interface {
 	// It also returns untrusted credentials when allowUntrusted == true
 	// a context must be passed to prevent long-running queries
 	Search(ctx context.Context, searchTerms []SearchTerm, allowUntrusted bool, resolveTime *time.Time) ([]vc.VerifiableCredential, error)
+	// SearchCredentials searches for VCs matching the given query, which supports 'OR' groups, negation, date ranges and pagination.
+	// Results are ordered by credential ID. It returns ErrInvalidSearchCursor if the query's cursor is invalid.
+	// It also returns untrusted credentials when allowUntrusted == true
+	SearchCredentials(ctx context.Context, query SearchQuery, allowUntrusted bool, resolveTime *time.Time) (*SearchResult, error)
 }
 
 // Writer is the interface that groups al the VC write methods
@@ -56,6 +61,15 @@ type TrustManager interface {
 	Trusted(credentialType ssi.URI) ([]ssi.URI, error)
 	// Untrusted returns a list of untrusted issuers based on known credentials
 	Untrusted(credentialType ssi.URI) ([]ssi.URI, error)
+	// TrustPolicies returns all trust policies, including trust chains, policies with an expiration date and policies imported from trust lists.
+	TrustPolicies() []trust.Policy
+	// AddTrustPolicy adds a trust policy. Adding a policy that already exists updates its expiration date.
+	AddTrustPolicy(policy trust.Policy) (*trust.Policy, error)
+	// RemoveTrustPolicy removes the trust policy with the given ID. It returns trust.ErrPolicyNotFound if it doesn't exist.
+	RemoveTrustPolicy(id string) error
+	// ImportTrustList imports the policies of a trust list, which must be signed by one of the configured trust list signers.
+	// The policies replace the policies of trust lists previously imported from the same signer.
+	ImportTrustList(token string) ([]trust.Policy, error)
 }
 
 // Resolver binds all read type of operations into an interface
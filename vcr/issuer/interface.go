@@ -21,12 +21,14 @@ package issuer
 import (
 	"errors"
 	"io"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 )
 
 // Publisher publishes new credentials and revocations to a channel. Used by a credential issuer.
@@ -49,6 +51,12 @@ type Issuer interface {
 	// The publish param indicates if the credendential should be published to the network.
 	// The public param instructs the Publisher to publish the param with a certain visibility.
 	Issue(unsignedCredential vc.VerifiableCredential, publish, public bool) (*vc.VerifiableCredential, error)
+	// IssueJWT issues a credential like Issue, but encodes it as JWT (vc+jwt) instead of signing it with a Linked Data proof.
+	// The returned credential contains the JWT as JwtProof2020 proof.
+	IssueJWT(unsignedCredential vc.VerifiableCredential, publish, public bool) (*vc.VerifiableCredential, error)
+	// IssueSDJWT issues a credential in SD-JWT VC format, which allows the holder to selectively disclose its claims.
+	// The credential is not published to the network, the caller is responsible for distributing it to the holder.
+	IssueSDJWT(unsignedCredential vc.VerifiableCredential) (*sdjwt.SDJWT, error)
 	// Revoke revokes a credential by the provided type.
 	// It requires access to the private key of the issuer which will be used to sign the revocation.
 	// It returns an error when the credential is not issued by this node or is already revoked.
@@ -78,6 +86,8 @@ type Store interface {
 	GetRevocation(id ssi.URI) (*credential.Revocation, error)
 	// StoreRevocation writes a revocation to storage.
 	StoreRevocation(r credential.Revocation) error
+	// SearchExpiringCredentials returns the issued credentials which expire before the given time (including already expired ones).
+	SearchExpiringCredentials(before time.Time) ([]vc.VerifiableCredential, error)
 	CredentialSearcher
 	// Closer closes and frees the underlying resources the store uses.
 	io.Closer
@@ -32,7 +32,9 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -41,7 +43,7 @@ import (
 )
 
 // NewIssuer creates a new issuer which implements the Issuer interface.
-func NewIssuer(store Store, publisher Publisher, docResolver vdr.DocResolver, keyStore crypto.KeyStore, jsonldManager jsonld.JSONLD, trustConfig *trust.Config) Issuer {
+func NewIssuer(store Store, publisher Publisher, docResolver vdr.DocResolver, keyStore crypto.KeyStore, jsonldManager jsonld.JSONLD, trustConfig *trust.Config, validators *credential.ValidatorRegistry) Issuer {
 	resolver := vdrKeyResolver{docResolver: docResolver, keyResolver: keyStore}
 	return &issuer{
 		store:         store,
@@ -49,6 +51,7 @@ func NewIssuer(store Store, publisher Publisher, docResolver vdr.DocResolver, ke
 		keyResolver:   resolver,
 		jsonldManager: jsonldManager,
 		trustConfig:   trustConfig,
+		validators:    validators,
 	}
 }
 
@@ -58,6 +61,7 @@ type issuer struct {
 	keyResolver   keyResolver
 	trustConfig   *trust.Config
 	jsonldManager jsonld.JSONLD
+	validators    *credential.ValidatorRegistry
 }
 
 // Issue creates a new credential, signs, stores it.
@@ -68,8 +72,25 @@ func (i issuer) Issue(credentialOptions vc.VerifiableCredential, publish, public
 	if err != nil {
 		return nil, err
 	}
+	return i.storeAndPublish(createdVC, publish, public)
+}
+
+// IssueJWT issues a credential like Issue, but encodes it as JWT instead of signing it with a Linked Data proof.
+func (i issuer) IssueJWT(credentialOptions vc.VerifiableCredential, publish, public bool) (*vc.VerifiableCredential, error) {
+	unsignedCredential, key, err := i.buildUnsignedVC(credentialOptions)
+	if err != nil {
+		return nil, err
+	}
+	createdVC, err := jwtvc.CreateCredential(*unsignedCredential, key)
+	if err != nil {
+		return nil, err
+	}
+	return i.storeAndPublish(createdVC, publish, public)
+}
 
-	validator, _ := credential.FindValidatorAndBuilder(*createdVC)
+// storeAndPublish validates and trusts the issued credential, after which it's stored and (optionally) published.
+func (i issuer) storeAndPublish(createdVC *vc.VerifiableCredential, publish, public bool) (*vc.VerifiableCredential, error) {
+	validator, _ := i.validators.FindValidatorAndBuilder(*createdVC)
 	if err := validator.Validate(*createdVC); err != nil {
 		return nil, err
 	}
@@ -84,7 +105,7 @@ func (i issuer) Issue(credentialOptions vc.VerifiableCredential, publish, public
 		}
 	}
 
-	if err = i.store.StoreCredential(*createdVC); err != nil {
+	if err := i.store.StoreCredential(*createdVC); err != nil {
 		return nil, fmt.Errorf("unable to store the issued credential: %w", err)
 	}
 
@@ -96,14 +117,50 @@ func (i issuer) Issue(credentialOptions vc.VerifiableCredential, publish, public
 	return createdVC, nil
 }
 
-func (i issuer) buildVC(credentialOptions vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+// IssueSDJWT issues a credential in SD-JWT VC format, which allows the holder to selectively disclose its claims.
+// The issued credential is stored without proof, so it can be found and revoked later on.
+func (i issuer) IssueSDJWT(credentialOptions vc.VerifiableCredential) (*sdjwt.SDJWT, error) {
+	unsignedCredential, key, err := i.buildUnsignedVC(credentialOptions)
+	if err != nil {
+		return nil, err
+	}
+	// Validate the credential like it's done for other formats, before signing it.
+	// The proof isn't embedded in the credential since it's signed as SD-JWT, so it's validated with an empty proof.
+	toValidate := *unsignedCredential
+	toValidate.Proof = []interface{}{}
+	validator, _ := i.validators.FindValidatorAndBuilder(toValidate)
+	if err := validator.Validate(toValidate); err != nil {
+		return nil, core.InvalidInputError("%w", err)
+	}
+	issued, err := sdjwt.Issue(*unsignedCredential, key)
+	if err != nil {
+		if errors.Is(err, sdjwt.ErrInvalidCredential) {
+			return nil, core.InvalidInputError("%w", err)
+		}
+		return nil, err
+	}
+
+	for _, credentialType := range credential.ExtractTypes(*unsignedCredential) {
+		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), unsignedCredential.Issuer); err != nil {
+			return nil, fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", unsignedCredential.Issuer, credentialType, err)
+		}
+	}
+
+	if err = i.store.StoreCredential(*unsignedCredential); err != nil {
+		return nil, fmt.Errorf("unable to store the issued credential: %w", err)
+	}
+	return issued, nil
+}
+
+// buildUnsignedVC builds the credential to issue from the given options, and resolves the key of the issuer to sign it with.
+func (i issuer) buildUnsignedVC(credentialOptions vc.VerifiableCredential) (*vc.VerifiableCredential, crypto.Key, error) {
 	if len(credentialOptions.Type) != 1 {
-		return nil, errors.New("can only issue credential with 1 type")
+		return nil, nil, errors.New("can only issue credential with 1 type")
 	}
 
 	issuerDID, err := did.ParseDID(credentialOptions.Issuer.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse issuer: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse issuer: %w", err)
 	}
 
 	key, err := i.keyResolver.ResolveAssertionKey(*issuerDID)
@@ -111,9 +168,9 @@ func (i issuer) buildVC(credentialOptions vc.VerifiableCredential) (*vc.Verifiab
 		const errString = "failed to sign credential: could not resolve an assertionKey for issuer: %w"
 		// Differentiate between a DID document not found and some other error:
 		if errors.Is(err, vdr.ErrNotFound) {
-			return nil, core.InvalidInputError(errString, err)
+			return nil, nil, core.InvalidInputError(errString, err)
 		}
-		return nil, fmt.Errorf(errString, err)
+		return nil, nil, fmt.Errorf(errString, err)
 	}
 
 	credentialID := ssi.MustParseURI(fmt.Sprintf("%s#%s", issuerDID.String(), uuid.New().String()))
@@ -131,9 +188,17 @@ func (i issuer) buildVC(credentialOptions vc.VerifiableCredential) (*vc.Verifiab
 	if !unsignedCredential.IsType(defaultType) {
 		unsignedCredential.Type = append(unsignedCredential.Type, defaultType)
 	}
+	return &unsignedCredential, key, nil
+}
+
+func (i issuer) buildVC(credentialOptions vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	unsignedCredential, key, err := i.buildUnsignedVC(credentialOptions)
+	if err != nil {
+		return nil, err
+	}
 
 	credentialAsMap := map[string]interface{}{}
-	b, _ := json.Marshal(unsignedCredential)
+	b, _ := json.Marshal(*unsignedCredential)
 	_ = json.Unmarshal(b, &credentialAsMap)
 
 	// Set created date to the issuanceDate if set
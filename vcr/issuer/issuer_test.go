@@ -22,7 +22,6 @@ import (
 	crypto2 "crypto"
 	"encoding/json"
 	"errors"
-	"path"
 	"testing"
 	"time"
 
@@ -33,10 +32,12 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
-	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
 	vcr "github.com/nuts-foundation/nuts-node/vcr/types"
@@ -181,7 +182,7 @@ func Test_issuer_Issue(t *testing.T) {
 		defer ctrl.Finish()
 		kid := "did:nuts:123#abc"
 
-		trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config"))
+		trustConfig := trust.NewTestConfig(t)
 		keyResolverMock := NewMockkeyResolver(ctrl)
 		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
 		mockStore := NewMockStore(ctrl)
@@ -206,7 +207,7 @@ func Test_issuer_Issue(t *testing.T) {
 			defer ctrl.Finish()
 			kid := "did:nuts:123#abc"
 
-			trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config"))
+			trustConfig := trust.NewTestConfig(t)
 			keyResolverMock := NewMockkeyResolver(ctrl)
 			keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
 			mockStore := NewMockStore(ctrl)
@@ -223,7 +224,7 @@ func Test_issuer_Issue(t *testing.T) {
 			defer ctrl.Finish()
 			kid := "did:nuts:123#abc"
 
-			trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config"))
+			trustConfig := trust.NewTestConfig(t)
 			keyResolverMock := NewMockkeyResolver(ctrl)
 			keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
 			mockPublisher := NewMockPublisher(ctrl)
@@ -256,8 +257,152 @@ func Test_issuer_Issue(t *testing.T) {
 	})
 }
 
+func Test_issuer_IssueJWT(t *testing.T) {
+	credentialType := ssi.MustParseURI("TestCredential")
+	issuerID := ssi.MustParseURI("did:nuts:123")
+	kid := "did:nuts:123#abc"
+	credentialOptions := vc.VerifiableCredential{
+		Type:   []ssi.URI{credentialType},
+		Issuer: issuerID,
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id": "did:nuts:456",
+		}},
+	}
+
+	t.Run("ok - published", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		key := crypto.NewTestKey(kid)
+		trustConfig := trust.NewTestConfig(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(key, nil)
+		mockStore := NewMockStore(ctrl)
+		mockStore.EXPECT().StoreCredential(gomock.Any())
+		mockPublisher := NewMockPublisher(ctrl)
+		mockPublisher.EXPECT().PublishCredential(gomock.Any(), true)
+		sut := issuer{keyResolver: keyResolverMock, store: mockStore, publisher: mockPublisher, trustConfig: trustConfig}
+
+		result, err := sut.IssueJWT(credentialOptions, true, true)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Contains(t, result.Type, credentialType)
+		assert.Equal(t, issuerID.String(), result.Issuer.String())
+		err = jwtvc.VerifyCredential(*result, func(_ string) (crypto2.PublicKey, error) {
+			return key.Public(), nil
+		}, time.Now())
+		assert.NoError(t, err)
+		// Assert issuing a credential makes it trusted
+		assert.True(t, trustConfig.IsTrusted(credentialType, issuerID))
+	})
+	t.Run("error - invalid credential type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
+		sut := issuer{keyResolver: keyResolverMock}
+		invalidOptions := credentialOptions
+		invalidOptions.Context = []ssi.URI{*credential.NutsContextURI}
+		invalidOptions.Type = []ssi.URI{*credential.NutsOrganizationCredentialTypeURI}
+
+		result, err := sut.IssueSDJWT(invalidOptions)
+
+		assert.ErrorIs(t, err, credential.ErrValidation)
+		assert.ErrorContains(t, err, "'credentialSubject.organization' is empty")
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+		assert.Nil(t, result)
+	})
+	t.Run("error - could not store credential", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trustConfig := trust.NewTestConfig(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
+		mockStore := NewMockStore(ctrl)
+		mockStore.EXPECT().StoreCredential(gomock.Any()).Return(errors.New("b00m!"))
+		sut := issuer{keyResolver: keyResolverMock, store: mockStore, trustConfig: trustConfig}
+
+		result, err := sut.IssueJWT(credentialOptions, false, false)
+
+		assert.EqualError(t, err, "unable to store the issued credential: b00m!")
+		assert.Nil(t, result)
+	})
+}
+
+func Test_issuer_IssueSDJWT(t *testing.T) {
+	credentialType := ssi.MustParseURI("TestCredential")
+	issuerID := ssi.MustParseURI("did:nuts:123")
+	kid := "did:nuts:123#abc"
+	credentialOptions := vc.VerifiableCredential{
+		Type:   []ssi.URI{credentialType},
+		Issuer: issuerID,
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":   "did:nuts:456",
+			"name": "John Doe",
+		}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		key := crypto.NewTestKey(kid)
+		trustConfig := trust.NewTestConfig(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(key, nil)
+		var stored vc.VerifiableCredential
+		mockStore := NewMockStore(ctrl)
+		mockStore.EXPECT().StoreCredential(gomock.Any()).DoAndReturn(func(credential vc.VerifiableCredential) error {
+			stored = credential
+			return nil
+		})
+		sut := issuer{keyResolver: keyResolverMock, store: mockStore, trustConfig: trustConfig}
+
+		result, err := sut.IssueSDJWT(credentialOptions)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, result.Disclosures, 1)
+		verified, err := sdjwt.Verify(*result, func(_ string) (crypto2.PublicKey, error) {
+			return key.Public(), nil
+		}, time.Now(), nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, stored.ID, verified.ID)
+		assert.Nil(t, stored.Proof)
+		// Assert issuing a credential makes it trusted
+		assert.True(t, trustConfig.IsTrusted(credentialType, issuerID))
+	})
+	t.Run("error - invalid credential", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
+		sut := issuer{keyResolver: keyResolverMock}
+		invalidOptions := credentialOptions
+		invalidOptions.CredentialSubject = nil
+
+		result, err := sut.IssueSDJWT(invalidOptions)
+
+		assert.EqualError(t, err, "invalid credential: must have exactly 1 credentialSubject")
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+		assert.Nil(t, result)
+	})
+	t.Run("error - could not store credential", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trustConfig := trust.NewTestConfig(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(gomock.Any()).Return(crypto.NewTestKey(kid), nil)
+		mockStore := NewMockStore(ctrl)
+		mockStore.EXPECT().StoreCredential(gomock.Any()).Return(errors.New("b00m!"))
+		sut := issuer{keyResolver: keyResolverMock, store: mockStore, trustConfig: trustConfig}
+
+		result, err := sut.IssueSDJWT(credentialOptions)
+
+		assert.EqualError(t, err, "unable to store the issued credential: b00m!")
+		assert.Nil(t, result)
+	})
+}
+
 func TestNewIssuer(t *testing.T) {
-	createdIssuer := NewIssuer(nil, nil, nil, nil, nil, nil)
+	createdIssuer := NewIssuer(nil, nil, nil, nil, nil, nil, nil)
 	assert.IsType(t, &issuer{}, createdIssuer)
 }
 
@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
@@ -121,6 +123,28 @@ func (s leiaIssuerStore) SearchCredential(jsonLDContext ssi.URI, credentialType
 	return result, nil
 }
 
+func (s leiaIssuerStore) SearchExpiringCredentials(before time.Time) ([]vc.VerifiableCredential, error) {
+	query := leia.New(leia.NotNil(leia.NewJSONPath("expirationDate")))
+
+	docs, err := s.issuedCredentials.Find(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []vc.VerifiableCredential
+	for _, doc := range docs {
+		credential := vc.VerifiableCredential{}
+		if err := json.Unmarshal(doc, &credential); err != nil {
+			return nil, err
+		}
+		// the expiration dates are stored with the time zone they were issued with, so they're compared after parsing
+		if credential.ExpirationDate != nil && credential.ExpirationDate.Before(before) {
+			result = append(result, credential)
+		}
+	}
+	return result, nil
+}
+
 func (s leiaIssuerStore) GetCredential(id ssi.URI) (*vc.VerifiableCredential, error) {
 	query := leia.New(leia.Eq(leia.NewJSONPath("id"), leia.MustParseScalar(id.String())))
 
@@ -296,7 +320,11 @@ func (s leiaIssuerStore) createIssuedIndices(collection leia.Collection) error {
 	// Index used for getting issued VCs by id
 	idIndex := collection.NewIndex("issuedVCByID",
 		leia.NewFieldIndexer(leia.NewJSONPath("id")))
-	return s.issuedCredentials.AddIndex(searchIndex, idIndex)
+
+	// Index used for finding expiring VCs
+	expirationIndex := collection.NewIndex("issuedVCByExpirationDate",
+		leia.NewFieldIndexer(leia.NewJSONPath("expirationDate")))
+	return s.issuedCredentials.AddIndex(searchIndex, idIndex, expirationIndex)
 }
 
 // createRevokedIndices creates the needed indices for the issued VC store
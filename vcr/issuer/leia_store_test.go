@@ -29,6 +29,7 @@ import (
 	"github.com/nuts-foundation/go-stoabs/bbolt"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -201,6 +202,41 @@ func Test_leiaStore_GetCredential(t *testing.T) {
 	})
 }
 
+func Test_leiaIssuerStore_SearchExpiringCredentials(t *testing.T) {
+	newCredential := func(id string, expirationDate *time.Time) vc.VerifiableCredential {
+		credential := vc.VerifiableCredential{}
+		_ = json.Unmarshal([]byte(jsonld.TestCredential), &credential)
+		credentialID := ssi.MustParseURI(id)
+		credential.ID = &credentialID
+		credential.ExpirationDate = expirationDate
+		return credential
+	}
+	now := time.Now()
+	expiresSoon := now.Add(time.Hour)
+	// stored in another time zone, to assert dates are compared correctly
+	expiresLater := now.Add(48 * time.Hour).In(time.FixedZone("UTC+12", 12*60*60))
+
+	store := newStore(t)
+	assert.NoError(t, store.StoreCredential(newCredential("did:nuts:123#soon", &expiresSoon)))
+	assert.NoError(t, store.StoreCredential(newCredential("did:nuts:123#later", &expiresLater)))
+	assert.NoError(t, store.StoreCredential(newCredential("did:nuts:123#never", nil)))
+
+	t.Run("ok", func(t *testing.T) {
+		result, err := store.SearchExpiringCredentials(now.Add(24 * time.Hour))
+
+		if !assert.NoError(t, err) || !assert.Len(t, result, 1) {
+			return
+		}
+		assert.Equal(t, "did:nuts:123#soon", result[0].ID.String())
+	})
+	t.Run("no results", func(t *testing.T) {
+		result, err := store.SearchExpiringCredentials(now)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
 func Test_leiaIssuerStore_StoreRevocation(t *testing.T) {
 	store := newStore(t)
 
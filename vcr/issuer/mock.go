@@ -6,6 +6,7 @@ package issuer
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	ssi "github.com/nuts-foundation/go-did"
@@ -13,6 +14,7 @@ import (
 	vc "github.com/nuts-foundation/go-did/vc"
 	crypto "github.com/nuts-foundation/nuts-node/crypto"
 	credential "github.com/nuts-foundation/nuts-node/vcr/credential"
+	sdjwt "github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 )
 
 // MockPublisher is a mock of Publisher interface.
@@ -142,6 +144,36 @@ func (mr *MockIssuerMockRecorder) Issue(unsignedCredential, publish, public inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockIssuer)(nil).Issue), unsignedCredential, publish, public)
 }
 
+// IssueJWT mocks base method.
+func (m *MockIssuer) IssueJWT(unsignedCredential vc.VerifiableCredential, publish, public bool) (*vc.VerifiableCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueJWT", unsignedCredential, publish, public)
+	ret0, _ := ret[0].(*vc.VerifiableCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueJWT indicates an expected call of IssueJWT.
+func (mr *MockIssuerMockRecorder) IssueJWT(unsignedCredential, publish, public interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueJWT", reflect.TypeOf((*MockIssuer)(nil).IssueJWT), unsignedCredential, publish, public)
+}
+
+// IssueSDJWT mocks base method.
+func (m *MockIssuer) IssueSDJWT(unsignedCredential vc.VerifiableCredential) (*sdjwt.SDJWT, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueSDJWT", unsignedCredential)
+	ret0, _ := ret[0].(*sdjwt.SDJWT)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueSDJWT indicates an expected call of IssueSDJWT.
+func (mr *MockIssuerMockRecorder) IssueSDJWT(unsignedCredential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueSDJWT", reflect.TypeOf((*MockIssuer)(nil).IssueSDJWT), unsignedCredential)
+}
+
 // Revoke mocks base method.
 func (m *MockIssuer) Revoke(credentialID ssi.URI) (*credential.Revocation, error) {
 	m.ctrl.T.Helper()
@@ -254,6 +286,21 @@ func (mr *MockStoreMockRecorder) SearchCredential(context, credentialType, issue
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCredential", reflect.TypeOf((*MockStore)(nil).SearchCredential), context, credentialType, issuer, subject)
 }
 
+// SearchExpiringCredentials mocks base method.
+func (m *MockStore) SearchExpiringCredentials(before time.Time) ([]vc.VerifiableCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchExpiringCredentials", before)
+	ret0, _ := ret[0].([]vc.VerifiableCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchExpiringCredentials indicates an expected call of SearchExpiringCredentials.
+func (mr *MockStoreMockRecorder) SearchExpiringCredentials(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchExpiringCredentials", reflect.TypeOf((*MockStore)(nil).SearchExpiringCredentials), before)
+}
+
 // StoreCredential mocks base method.
 func (m *MockStore) StoreCredential(vc vc.VerifiableCredential) error {
 	m.ctrl.T.Helper()
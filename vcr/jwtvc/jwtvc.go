@@ -0,0 +1,341 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package jwtvc implements the JWT encoding of Verifiable Credentials and Presentations, as specified by the VC Data Model:
+// https://www.w3.org/TR/vc-data-model/#json-web-token
+//
+// JWT encoded credentials and presentations are represented as their decoded form, containing a single JwtProof2020 proof
+// which holds the JWT itself. This allows them to be stored, searched and published like credentials with a Linked Data proof.
+package jwtvc
+
+import (
+	crypto2 "crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+)
+
+// ProofType is the type of the proof which holds the JWT of a JWT encoded credential or presentation.
+const ProofType = ssi.ProofType("JwtProof2020")
+
+// maxSkew specifies the allowed clock skew when validating the time related claims of a JWT.
+const maxSkew = 5 * time.Second
+
+const (
+	vcClaim    = "vc"
+	vpClaim    = "vp"
+	nonceClaim = "nonce"
+)
+
+// Proof is the proof of a JWT encoded credential or presentation.
+type Proof struct {
+	// Type is always JwtProof2020.
+	Type ssi.ProofType `json:"type"`
+	// JWT contains the JWT encoded credential or presentation.
+	JWT string `json:"jwt"`
+}
+
+// newProof creates the proof for the given JWT. It's represented as map (like proofs of credentials parsed from JSON),
+// so a credential or presentation remains equal to itself after being stored and loaded.
+func newProof(token string) map[string]interface{} {
+	return map[string]interface{}{"type": string(ProofType), "jwt": token}
+}
+
+// ExtractJWT returns the JWT of a JWT encoded credential or presentation, given its proofs.
+// The second return value is false if the proofs don't consist of a single JwtProof2020.
+func ExtractJWT(proofs []interface{}) (string, bool) {
+	if len(proofs) != 1 {
+		return "", false
+	}
+	data, _ := json.Marshal(proofs[0])
+	result := Proof{}
+	if err := json.Unmarshal(data, &result); err != nil || result.Type != ProofType || result.JWT == "" {
+		return "", false
+	}
+	return result.JWT, true
+}
+
+// CreateCredential encodes the given credential as JWT signed with the given (issuer's) key,
+// and returns its decoded form containing the JWT as proof.
+func CreateCredential(credential vc.VerifiableCredential, key crypto.Key) (*vc.VerifiableCredential, error) {
+	if credential.ID == nil {
+		return nil, errors.New("credential must have an ID")
+	}
+	credential.Proof = nil
+	payload, err := toMap(credential)
+	if err != nil {
+		return nil, err
+	}
+	// these properties are encoded as registered JWT claims
+	for _, property := range []string{"id", "issuer", "issuanceDate", "expirationDate", "proof"} {
+		delete(payload, property)
+	}
+	claims := map[string]interface{}{
+		jwt.IssuerKey:    credential.Issuer.String(),
+		jwt.JwtIDKey:     credential.ID.String(),
+		jwt.NotBeforeKey: credential.IssuanceDate,
+		vcClaim:          payload,
+	}
+	if credential.ExpirationDate != nil {
+		claims[jwt.ExpirationKey] = *credential.ExpirationDate
+	}
+	if subjectID := singleSubjectID(credential); subjectID != "" {
+		claims[jwt.SubjectKey] = subjectID
+	}
+	token, err := crypto.SignJWTWithSigner(key.Signer(), claims, map[string]interface{}{
+		jws.TypeKey:  "JWT",
+		jws.KeyIDKey: key.KID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign JWT credential: %w", err)
+	}
+	return ParseCredential(token)
+}
+
+// ParseCredential decodes a JWT encoded credential, without verifying it.
+func ParseCredential(token string) (*vc.VerifiableCredential, error) {
+	parsed, err := jwt.ParseString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT credential: %w", err)
+	}
+	payload, ok := parsed.PrivateClaims()[vcClaim].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid JWT credential: missing vc claim")
+	}
+	payload["id"] = parsed.JwtID()
+	payload["issuer"] = parsed.Issuer()
+	payload["issuanceDate"] = parsed.NotBefore().UTC()
+	if !parsed.Expiration().IsZero() {
+		payload["expirationDate"] = parsed.Expiration().UTC()
+	}
+	result := vc.VerifiableCredential{}
+	if err = fromMap(payload, &result); err != nil {
+		return nil, fmt.Errorf("invalid JWT credential: %w", err)
+	}
+	if subject := parsed.Subject(); subject != "" && singleSubjectID(result) != subject {
+		return nil, errors.New("invalid JWT credential: sub claim doesn't match credentialSubject.id")
+	}
+	result.Proof = []interface{}{newProof(token)}
+	return &result, nil
+}
+
+// VerifyCredential verifies the JWT of the given JWT encoded credential at the given time, and checks whether it matches the credential.
+// The JWT must be signed with a key of the issuer, which is resolved using the given function.
+func VerifyCredential(credential vc.VerifiableCredential, resolveKey crypto.PublicKeyFunc, validAt time.Time) error {
+	token, ok := ExtractJWT(credential.Proof)
+	if !ok {
+		return errors.New("credential is not JWT encoded")
+	}
+	if _, err := parseJWT(token, resolveKey, validAt); err != nil {
+		return fmt.Errorf("invalid JWT credential: %w", err)
+	}
+	decoded, err := ParseCredential(token)
+	if err != nil {
+		return err
+	}
+	return compare(credential, *decoded)
+}
+
+// CreatePresentation encodes the given presentation as JWT signed with the given (holder's) key, and returns its decoded form containing the JWT as proof.
+// The presentation's holder is set to the DID of the key. Domain and challenge of the given options are encoded as audience and nonce.
+// Credentials which are JWT encoded themselves are included as JWT.
+func CreatePresentation(presentation vc.VerifiablePresentation, options proof.ProofOptions, key crypto.Key) (*vc.VerifiablePresentation, error) {
+	holder, err := did.ParseDIDURL(key.KID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid key ID: %w", err)
+	}
+	holder.Fragment = ""
+	credentials := make([]interface{}, len(presentation.VerifiableCredential))
+	for i, credential := range presentation.VerifiableCredential {
+		if token, ok := ExtractJWT(credential.Proof); ok {
+			credentials[i] = token
+		} else {
+			credentials[i] = credential
+		}
+	}
+	payload := map[string]interface{}{
+		"@context":             presentation.Context,
+		"type":                 presentation.Type,
+		"verifiableCredential": credentials,
+	}
+	claims := map[string]interface{}{
+		jwt.IssuerKey:    holder.String(),
+		jwt.NotBeforeKey: options.Created,
+		vpClaim:          payload,
+	}
+	if presentation.ID != nil {
+		claims[jwt.JwtIDKey] = presentation.ID.String()
+	}
+	if options.Expires != nil {
+		claims[jwt.ExpirationKey] = *options.Expires
+	}
+	if options.Domain != nil {
+		claims[jwt.AudienceKey] = *options.Domain
+	}
+	if options.Challenge != nil {
+		claims[nonceClaim] = *options.Challenge
+	}
+	token, err := crypto.SignJWTWithSigner(key.Signer(), claims, map[string]interface{}{
+		jws.TypeKey:  "JWT",
+		jws.KeyIDKey: key.KID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign JWT presentation: %w", err)
+	}
+	return ParsePresentation(token)
+}
+
+// ParsePresentation decodes a JWT encoded presentation, without verifying it.
+func ParsePresentation(token string) (*vc.VerifiablePresentation, error) {
+	parsed, err := jwt.ParseString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT presentation: %w", err)
+	}
+	payload, ok := parsed.PrivateClaims()[vpClaim].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid JWT presentation: missing vp claim")
+	}
+	var credentials []interface{}
+	if list, ok := payload["verifiableCredential"].([]interface{}); ok {
+		credentials = list
+	} else if payload["verifiableCredential"] != nil {
+		credentials = []interface{}{payload["verifiableCredential"]}
+	}
+	delete(payload, "verifiableCredential")
+	if parsed.JwtID() != "" {
+		payload["id"] = parsed.JwtID()
+	}
+	payload["holder"] = parsed.Issuer()
+	result := vc.VerifiablePresentation{}
+	if err = fromMap(payload, &result); err != nil {
+		return nil, fmt.Errorf("invalid JWT presentation: %w", err)
+	}
+	for _, current := range credentials {
+		var credential *vc.VerifiableCredential
+		if credentialToken, isJWT := current.(string); isJWT {
+			if credential, err = ParseCredential(credentialToken); err != nil {
+				return nil, err
+			}
+		} else {
+			credential = &vc.VerifiableCredential{}
+			if err = fromMap(current, credential); err != nil {
+				return nil, fmt.Errorf("invalid JWT presentation: %w", err)
+			}
+		}
+		result.VerifiableCredential = append(result.VerifiableCredential, *credential)
+	}
+	result.Proof = []interface{}{newProof(token)}
+	return &result, nil
+}
+
+// VerifyPresentation verifies the JWT of the given JWT encoded presentation at the given time, and checks whether it matches the presentation.
+// The JWT must be signed with a key of the holder, which is resolved using the given function.
+// The credentials in the presentation are not verified.
+func VerifyPresentation(presentation vc.VerifiablePresentation, resolveKey crypto.PublicKeyFunc, validAt time.Time) error {
+	token, ok := ExtractJWT(presentation.Proof)
+	if !ok {
+		return errors.New("presentation is not JWT encoded")
+	}
+	if _, err := parseJWT(token, resolveKey, validAt); err != nil {
+		return fmt.Errorf("invalid JWT presentation: %w", err)
+	}
+	decoded, err := ParsePresentation(token)
+	if err != nil {
+		return err
+	}
+	return compare(presentation, *decoded)
+}
+
+// parseJWT verifies the signature and validity of the JWT, which must be signed with a key of the DID in its iss claim.
+func parseJWT(token string, resolveKey crypto.PublicKeyFunc, validAt time.Time) (jwt.Token, error) {
+	var kid string
+	parsed, err := crypto.ParseJWT(token, func(keyID string) (crypto2.PublicKey, error) {
+		kid = keyID
+		return resolveKey(keyID)
+	}, jwt.WithClock(jwt.ClockFunc(func() time.Time {
+		return validAt
+	})), jwt.WithAcceptableSkew(maxSkew))
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := did.ParseDIDURL(kid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key ID: %w", err)
+	}
+	keyID.Fragment = ""
+	if keyID.String() != parsed.Issuer() {
+		return nil, errors.New("JWT must be signed with a key of its issuer")
+	}
+	return parsed, nil
+}
+
+// compare checks whether the given credentials or presentations (excluding their proofs) are equal.
+func compare(actual interface{}, decoded interface{}) error {
+	actualMap, err := toMap(actual)
+	if err != nil {
+		return err
+	}
+	decodedMap, err := toMap(decoded)
+	if err != nil {
+		return err
+	}
+	delete(actualMap, "proof")
+	delete(decodedMap, "proof")
+	if !reflect.DeepEqual(actualMap, decodedMap) {
+		return errors.New("contents don't match the JWT")
+	}
+	return nil
+}
+
+func singleSubjectID(credential vc.VerifiableCredential) string {
+	if len(credential.CredentialSubject) != 1 {
+		return ""
+	}
+	subject := map[string]interface{}{}
+	data, _ := json.Marshal(credential.CredentialSubject[0])
+	_ = json.Unmarshal(data, &subject)
+	id, _ := subject["id"].(string)
+	return id
+}
+
+func toMap(input interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func fromMap(input interface{}, target interface{}) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
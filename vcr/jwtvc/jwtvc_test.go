@@ -0,0 +1,235 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jwtvc
+
+import (
+	crypto2 "crypto"
+	"errors"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/stretchr/testify/assert"
+)
+
+var issuerKey = crypto.NewTestKey("did:nuts:issuer#key-1")
+var holderKey = crypto.NewTestKey("did:nuts:holder#key-1")
+
+func resolveTestKey(kid string) (crypto2.PublicKey, error) {
+	switch kid {
+	case issuerKey.KID():
+		return issuerKey.Public(), nil
+	case holderKey.KID():
+		return holderKey.Public(), nil
+	}
+	return nil, errors.New("unknown key")
+}
+
+func testCredential() vc.VerifiableCredential {
+	id := ssi.MustParseURI("did:nuts:issuer#1")
+	expirationDate := time.Now().Add(time.Hour)
+	return vc.VerifiableCredential{
+		Context:        []ssi.URI{vc.VCContextV1URI()},
+		ID:             &id,
+		Type:           []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("NutsEmployeeCredential")},
+		Issuer:         ssi.MustParseURI("did:nuts:issuer"),
+		IssuanceDate:   time.Now().Add(-time.Minute),
+		ExpirationDate: &expirationDate,
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":   "did:nuts:holder",
+			"name": "John Doe",
+		}},
+	}
+}
+
+func TestCreateCredential(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		input := testCredential()
+
+		result, err := CreateCredential(input, issuerKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, input.ID.String(), result.ID.String())
+		assert.Equal(t, input.Issuer.String(), result.Issuer.String())
+		assert.Equal(t, input.Type, result.Type)
+		assert.Equal(t, input.IssuanceDate.Unix(), result.IssuanceDate.Unix())
+		assert.Equal(t, input.ExpirationDate.Unix(), result.ExpirationDate.Unix())
+		assert.Equal(t, input.CredentialSubject, result.CredentialSubject)
+		_, isJWT := ExtractJWT(result.Proof)
+		assert.True(t, isJWT)
+	})
+	t.Run("error - no ID", func(t *testing.T) {
+		input := testCredential()
+		input.ID = nil
+
+		_, err := CreateCredential(input, issuerKey)
+
+		assert.EqualError(t, err, "credential must have an ID")
+	})
+}
+
+func TestVerifyCredential(t *testing.T) {
+	credential, _ := CreateCredential(testCredential(), issuerKey)
+
+	t.Run("ok", func(t *testing.T) {
+		err := VerifyCredential(*credential, resolveTestKey, time.Now())
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - not JWT encoded", func(t *testing.T) {
+		input := testCredential()
+
+		err := VerifyCredential(input, resolveTestKey, time.Now())
+
+		assert.EqualError(t, err, "credential is not JWT encoded")
+	})
+	t.Run("error - expired", func(t *testing.T) {
+		err := VerifyCredential(*credential, resolveTestKey, time.Now().Add(2*time.Hour))
+
+		assert.ErrorContains(t, err, "invalid JWT credential: ")
+	})
+	t.Run("error - contents altered", func(t *testing.T) {
+		altered := *credential
+		altered.CredentialSubject = []interface{}{map[string]interface{}{
+			"id":   "did:nuts:holder",
+			"name": "Jane Doe",
+		}}
+
+		err := VerifyCredential(altered, resolveTestKey, time.Now())
+
+		assert.EqualError(t, err, "contents don't match the JWT")
+	})
+	t.Run("error - not signed by issuer", func(t *testing.T) {
+		input := testCredential()
+		input.Issuer = ssi.MustParseURI("did:nuts:holder")
+		signed, _ := CreateCredential(input, issuerKey)
+
+		err := VerifyCredential(*signed, resolveTestKey, time.Now())
+
+		assert.EqualError(t, err, "invalid JWT credential: JWT must be signed with a key of its issuer")
+	})
+}
+
+func TestCreatePresentation(t *testing.T) {
+	credential, _ := CreateCredential(testCredential(), issuerKey)
+	ldCredential := testCredential()
+	ldCredential.Proof = []interface{}{map[string]interface{}{"type": "JsonWebSignature2020"}}
+	domain := "verifier"
+	challenge := "nonce"
+	expires := time.Now().Add(time.Minute)
+	options := proof.ProofOptions{Created: time.Now(), Domain: &domain, Challenge: &challenge, Expires: &expires}
+	input := vc.VerifiablePresentation{
+		Context:              []ssi.URI{vc.VCContextV1URI()},
+		Type:                 []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+		VerifiableCredential: []vc.VerifiableCredential{*credential, ldCredential},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		result, err := CreatePresentation(input, options, holderKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "did:nuts:holder", result.Holder.String())
+		if !assert.Len(t, result.VerifiableCredential, 2) {
+			return
+		}
+		assert.Equal(t, credential.Proof, result.VerifiableCredential[0].Proof)
+		assert.Len(t, result.VerifiableCredential[1].Proof, 1)
+
+		err = VerifyPresentation(*result, resolveTestKey, time.Now())
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - invalid key ID", func(t *testing.T) {
+		_, err := CreatePresentation(input, options, crypto.NewTestKey("%%"))
+
+		assert.ErrorContains(t, err, "invalid key ID")
+	})
+}
+
+func TestVerifyPresentation(t *testing.T) {
+	credential, _ := CreateCredential(testCredential(), issuerKey)
+	expires := time.Now().Add(time.Minute)
+	input := vc.VerifiablePresentation{
+		Context:              []ssi.URI{vc.VCContextV1URI()},
+		Type:                 []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+		VerifiableCredential: []vc.VerifiableCredential{*credential},
+	}
+	presentation, _ := CreatePresentation(input, proof.ProofOptions{Created: time.Now(), Expires: &expires}, holderKey)
+
+	t.Run("ok", func(t *testing.T) {
+		err := VerifyPresentation(*presentation, resolveTestKey, time.Now())
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - parsed from JWT", func(t *testing.T) {
+		token, _ := ExtractJWT(presentation.Proof)
+		parsed, err := ParsePresentation(token)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		err = VerifyPresentation(*parsed, resolveTestKey, time.Now())
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - not JWT encoded", func(t *testing.T) {
+		err := VerifyPresentation(input, resolveTestKey, time.Now())
+
+		assert.EqualError(t, err, "presentation is not JWT encoded")
+	})
+	t.Run("error - expired", func(t *testing.T) {
+		err := VerifyPresentation(*presentation, resolveTestKey, time.Now().Add(time.Hour))
+
+		assert.ErrorContains(t, err, "invalid JWT presentation: ")
+	})
+	t.Run("error - credential removed", func(t *testing.T) {
+		altered := *presentation
+		altered.VerifiableCredential = nil
+
+		err := VerifyPresentation(altered, resolveTestKey, time.Now())
+
+		assert.EqualError(t, err, "contents don't match the JWT")
+	})
+}
+
+func TestExtractJWT(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		token, ok := ExtractJWT([]interface{}{map[string]interface{}{"type": "JwtProof2020", "jwt": "a.b.c"}})
+
+		assert.True(t, ok)
+		assert.Equal(t, "a.b.c", token)
+	})
+	t.Run("other proof type", func(t *testing.T) {
+		_, ok := ExtractJWT([]interface{}{map[string]interface{}{"type": "JsonWebSignature2020"}})
+
+		assert.False(t, ok)
+	})
+	t.Run("multiple proofs", func(t *testing.T) {
+		_, ok := ExtractJWT([]interface{}{Proof{Type: ProofType, JWT: "a"}, Proof{Type: ProofType, JWT: "b"}})
+
+		assert.False(t, ok)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	vc "github.com/nuts-foundation/go-did/vc"
 	holder "github.com/nuts-foundation/nuts-node/vcr/holder"
 	issuer "github.com/nuts-foundation/nuts-node/vcr/issuer"
+	trust "github.com/nuts-foundation/nuts-node/vcr/trust"
 	verifier "github.com/nuts-foundation/nuts-node/vcr/verifier"
 )
 
@@ -55,6 +56,21 @@ func (mr *MockFinderMockRecorder) Search(ctx, searchTerms, allowUntrusted, resol
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockFinder)(nil).Search), ctx, searchTerms, allowUntrusted, resolveTime)
 }
 
+// SearchCredentials mocks base method.
+func (m *MockFinder) SearchCredentials(ctx context.Context, query SearchQuery, allowUntrusted bool, resolveTime *time.Time) (*SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCredentials", ctx, query, allowUntrusted, resolveTime)
+	ret0, _ := ret[0].(*SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCredentials indicates an expected call of SearchCredentials.
+func (mr *MockFinderMockRecorder) SearchCredentials(ctx, query, allowUntrusted, resolveTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCredentials", reflect.TypeOf((*MockFinder)(nil).SearchCredentials), ctx, query, allowUntrusted, resolveTime)
+}
+
 // MockWriter is a mock of Writer interface.
 type MockWriter struct {
 	ctrl     *gomock.Controller
@@ -115,6 +131,50 @@ func (m *MockTrustManager) EXPECT() *MockTrustManagerMockRecorder {
 	return m.recorder
 }
 
+// AddTrustPolicy mocks base method.
+func (m *MockTrustManager) AddTrustPolicy(policy trust.Policy) (*trust.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrustPolicy", policy)
+	ret0, _ := ret[0].(*trust.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustPolicy indicates an expected call of AddTrustPolicy.
+func (mr *MockTrustManagerMockRecorder) AddTrustPolicy(policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustPolicy", reflect.TypeOf((*MockTrustManager)(nil).AddTrustPolicy), policy)
+}
+
+// ImportTrustList mocks base method.
+func (m *MockTrustManager) ImportTrustList(token string) ([]trust.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTrustList", token)
+	ret0, _ := ret[0].([]trust.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTrustList indicates an expected call of ImportTrustList.
+func (mr *MockTrustManagerMockRecorder) ImportTrustList(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTrustList", reflect.TypeOf((*MockTrustManager)(nil).ImportTrustList), token)
+}
+
+// RemoveTrustPolicy mocks base method.
+func (m *MockTrustManager) RemoveTrustPolicy(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrustPolicy", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrustPolicy indicates an expected call of RemoveTrustPolicy.
+func (mr *MockTrustManagerMockRecorder) RemoveTrustPolicy(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustPolicy", reflect.TypeOf((*MockTrustManager)(nil).RemoveTrustPolicy), id)
+}
+
 // Trust mocks base method.
 func (m *MockTrustManager) Trust(credentialType, issuer go_did.URI) error {
 	m.ctrl.T.Helper()
@@ -129,6 +189,20 @@ func (mr *MockTrustManagerMockRecorder) Trust(credentialType, issuer interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trust", reflect.TypeOf((*MockTrustManager)(nil).Trust), credentialType, issuer)
 }
 
+// TrustPolicies mocks base method.
+func (m *MockTrustManager) TrustPolicies() []trust.Policy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrustPolicies")
+	ret0, _ := ret[0].([]trust.Policy)
+	return ret0
+}
+
+// TrustPolicies indicates an expected call of TrustPolicies.
+func (mr *MockTrustManagerMockRecorder) TrustPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrustPolicies", reflect.TypeOf((*MockTrustManager)(nil).TrustPolicies))
+}
+
 // Trusted mocks base method.
 func (m *MockTrustManager) Trusted(credentialType go_did.URI) ([]go_did.URI, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +308,21 @@ func (m *MockVCR) EXPECT() *MockVCRMockRecorder {
 	return m.recorder
 }
 
+// AddTrustPolicy mocks base method.
+func (m *MockVCR) AddTrustPolicy(policy trust.Policy) (*trust.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrustPolicy", policy)
+	ret0, _ := ret[0].(*trust.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustPolicy indicates an expected call of AddTrustPolicy.
+func (mr *MockVCRMockRecorder) AddTrustPolicy(policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustPolicy", reflect.TypeOf((*MockVCR)(nil).AddTrustPolicy), policy)
+}
+
 // Holder mocks base method.
 func (m *MockVCR) Holder() holder.Holder {
 	m.ctrl.T.Helper()
@@ -248,6 +337,21 @@ func (mr *MockVCRMockRecorder) Holder() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Holder", reflect.TypeOf((*MockVCR)(nil).Holder))
 }
 
+// ImportTrustList mocks base method.
+func (m *MockVCR) ImportTrustList(token string) ([]trust.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTrustList", token)
+	ret0, _ := ret[0].([]trust.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTrustList indicates an expected call of ImportTrustList.
+func (mr *MockVCRMockRecorder) ImportTrustList(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTrustList", reflect.TypeOf((*MockVCR)(nil).ImportTrustList), token)
+}
+
 // Issuer mocks base method.
 func (m *MockVCR) Issuer() issuer.Issuer {
 	m.ctrl.T.Helper()
@@ -262,6 +366,20 @@ func (mr *MockVCRMockRecorder) Issuer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issuer", reflect.TypeOf((*MockVCR)(nil).Issuer))
 }
 
+// RemoveTrustPolicy mocks base method.
+func (m *MockVCR) RemoveTrustPolicy(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrustPolicy", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrustPolicy indicates an expected call of RemoveTrustPolicy.
+func (mr *MockVCRMockRecorder) RemoveTrustPolicy(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustPolicy", reflect.TypeOf((*MockVCR)(nil).RemoveTrustPolicy), id)
+}
+
 // Resolve mocks base method.
 func (m *MockVCR) Resolve(ID go_did.URI, resolveTime *time.Time) (*vc.VerifiableCredential, error) {
 	m.ctrl.T.Helper()
@@ -292,6 +410,21 @@ func (mr *MockVCRMockRecorder) Search(ctx, searchTerms, allowUntrusted, resolveT
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockVCR)(nil).Search), ctx, searchTerms, allowUntrusted, resolveTime)
 }
 
+// SearchCredentials mocks base method.
+func (m *MockVCR) SearchCredentials(ctx context.Context, query SearchQuery, allowUntrusted bool, resolveTime *time.Time) (*SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCredentials", ctx, query, allowUntrusted, resolveTime)
+	ret0, _ := ret[0].(*SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCredentials indicates an expected call of SearchCredentials.
+func (mr *MockVCRMockRecorder) SearchCredentials(ctx, query, allowUntrusted, resolveTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCredentials", reflect.TypeOf((*MockVCR)(nil).SearchCredentials), ctx, query, allowUntrusted, resolveTime)
+}
+
 // StoreCredential mocks base method.
 func (m *MockVCR) StoreCredential(vc vc.VerifiableCredential, validAt *time.Time) error {
 	m.ctrl.T.Helper()
@@ -320,6 +453,20 @@ func (mr *MockVCRMockRecorder) Trust(credentialType, issuer interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trust", reflect.TypeOf((*MockVCR)(nil).Trust), credentialType, issuer)
 }
 
+// TrustPolicies mocks base method.
+func (m *MockVCR) TrustPolicies() []trust.Policy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrustPolicies")
+	ret0, _ := ret[0].([]trust.Policy)
+	return ret0
+}
+
+// TrustPolicies indicates an expected call of TrustPolicies.
+func (mr *MockVCRMockRecorder) TrustPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrustPolicies", reflect.TypeOf((*MockVCR)(nil).TrustPolicies))
+}
+
 // Trusted mocks base method.
 func (m *MockVCR) Trusted(credentialType go_did.URI) ([]go_did.URI, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,474 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package sdjwt implements Selective Disclosure JWT (SD-JWT) based Verifiable Credentials (SD-JWT VC),
+// which allow a holder to disclose only some claims of a credential in a presentation.
+// See https://datatracker.ietf.org/doc/draft-ietf-oauth-selective-disclosure-jwt/ and https://datatracker.ietf.org/doc/draft-ietf-oauth-sd-jwt-vc/
+package sdjwt
+
+import (
+	crypto2 "crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+)
+
+// Format is the identifier of the SD-JWT VC credential format. It's also used as "typ" header of the issuer-signed JWT.
+const Format = "vc+sd-jwt"
+
+// MediaType is the media type of SD-JWT VCs.
+const MediaType = "application/vc+sd-jwt"
+
+// KeyBindingJWTType is the "typ" header of the Key Binding JWT, which a holder adds when presenting an SD-JWT.
+const KeyBindingJWTType = "kb+jwt"
+
+// ErrInvalidCredential is returned when a credential can't be issued as SD-JWT VC.
+var ErrInvalidCredential = errors.New("invalid credential")
+
+// KeyBindingMaxAge is the maximum age of a Key Binding JWT, which limits the time a presentation can be used.
+const KeyBindingMaxAge = 5 * time.Minute
+
+// keyBindingMaxClockSkew is the maximum time a Key Binding JWT may be issued in the future, to allow for clock skew.
+const keyBindingMaxClockSkew = 5 * time.Second
+
+// KeyBindingRequirements specifies the claims the Key Binding JWT of a presentation must contain,
+// which binds the presentation to the verifier and prevents it from being replayed.
+type KeyBindingRequirements struct {
+	// Audience is the expected "aud" claim: the verifier the presentation is meant for.
+	Audience string
+	// Nonce is the expected "nonce" claim: the (random) value the verifier provided to the holder.
+	Nonce string
+}
+
+const separator = "~"
+const hashAlgorithm = "sha-256"
+const saltSize = 16
+
+const (
+	sdClaim     = "_sd"
+	sdAlgClaim  = "_sd_alg"
+	vctClaim    = "vct"
+	sdHashClaim = "sd_hash"
+	nonceClaim  = "nonce"
+)
+
+// reservedClaims contains the claims of the issuer-signed JWT that can't be (selectively disclosed) credentialSubject claims.
+var reservedClaims = map[string]bool{
+	jwt.IssuerKey: true, jwt.SubjectKey: true, jwt.IssuedAtKey: true, jwt.ExpirationKey: true, jwt.NotBeforeKey: true,
+	jwt.JwtIDKey: true, jwt.AudienceKey: true, vctClaim: true, sdClaim: true, sdAlgClaim: true, "cnf": true, "status": true,
+}
+
+// Disclosure contains a selectively disclosable claim of an SD-JWT.
+type Disclosure struct {
+	// Salt makes sure the digest of the disclosure can't be guessed.
+	Salt string
+	// Name is the name of the claim.
+	Name string
+	// Value is the value of the claim.
+	Value   interface{}
+	encoded string
+}
+
+// NewDisclosure creates a new disclosure with a random salt for the given claim.
+func NewDisclosure(name string, value interface{}) (*Disclosure, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	result := Disclosure{Salt: base64.RawURLEncoding.EncodeToString(salt), Name: name, Value: value}
+	data, err := json.Marshal([]interface{}{result.Salt, result.Name, result.Value})
+	if err != nil {
+		return nil, err
+	}
+	result.encoded = base64.RawURLEncoding.EncodeToString(data)
+	return &result, nil
+}
+
+// ParseDisclosure parses a base64url encoded disclosure.
+func ParseDisclosure(encoded string) (*Disclosure, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid disclosure encoding: %w", err)
+	}
+	var elements []interface{}
+	if err = json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("invalid disclosure: %w", err)
+	}
+	if len(elements) != 3 {
+		return nil, errors.New("invalid disclosure: expected salt, claim name and value")
+	}
+	salt, saltOK := elements[0].(string)
+	name, nameOK := elements[1].(string)
+	if !saltOK || !nameOK {
+		return nil, errors.New("invalid disclosure: salt and claim name must be strings")
+	}
+	return &Disclosure{Salt: salt, Name: name, Value: elements[2], encoded: encoded}, nil
+}
+
+// String returns the disclosure in its encoded form.
+func (d Disclosure) String() string {
+	return d.encoded
+}
+
+// Digest returns the digest of the disclosure, which is listed in the issuer-signed JWT.
+func (d Disclosure) Digest() string {
+	return digest(d.encoded)
+}
+
+// SDJWT is an SD-JWT: an issuer-signed JWT, the disclosures of the claims that are disclosed
+// and (when presented by the holder) a Key Binding JWT.
+type SDJWT struct {
+	// Token is the issuer-signed JWT.
+	Token string
+	// Disclosures contains the disclosed claims.
+	Disclosures []Disclosure
+	// KeyBinding is the Key Binding JWT, signed by the holder. It's empty when the SD-JWT isn't presented.
+	KeyBinding string
+}
+
+// Parse parses an SD-JWT in its compact (tilde separated) form.
+func Parse(compact string) (*SDJWT, error) {
+	parts := strings.Split(compact, separator)
+	if len(parts) < 2 || parts[0] == "" {
+		return nil, errors.New("invalid SD-JWT: expected issuer-signed JWT followed by disclosures")
+	}
+	result := SDJWT{Token: parts[0], KeyBinding: parts[len(parts)-1]}
+	for _, part := range parts[1 : len(parts)-1] {
+		disclosure, err := ParseDisclosure(part)
+		if err != nil {
+			return nil, err
+		}
+		result.Disclosures = append(result.Disclosures, *disclosure)
+	}
+	return &result, nil
+}
+
+// String returns the SD-JWT in its compact (tilde separated) form.
+func (s SDJWT) String() string {
+	return s.withoutKeyBinding() + s.KeyBinding
+}
+
+func (s SDJWT) withoutKeyBinding() string {
+	builder := strings.Builder{}
+	builder.WriteString(s.Token)
+	builder.WriteString(separator)
+	for _, disclosure := range s.Disclosures {
+		builder.WriteString(disclosure.String())
+		builder.WriteString(separator)
+	}
+	return builder.String()
+}
+
+// Subject returns the subject (holder) of the SD-JWT, without verifying it.
+func (s SDJWT) Subject() (string, error) {
+	token, err := jwt.ParseString(s.Token)
+	if err != nil {
+		return "", fmt.Errorf("invalid issuer-signed JWT: %w", err)
+	}
+	if token.Subject() == "" {
+		return "", errors.New("SD-JWT has no subject")
+	}
+	return token.Subject(), nil
+}
+
+// Select returns a copy of the SD-JWT which only contains the disclosures of the given claims, without Key Binding JWT.
+// It returns an error if one of the claims can't be disclosed.
+func (s SDJWT) Select(claims []string) (*SDJWT, error) {
+	result := SDJWT{Token: s.Token}
+	for _, claim := range claims {
+		found := false
+		for _, disclosure := range s.Disclosures {
+			if disclosure.Name == claim {
+				result.Disclosures = append(result.Disclosures, disclosure)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("claim can't be disclosed: %s", claim)
+		}
+	}
+	return &result, nil
+}
+
+// Bind returns a copy of the SD-JWT with a Key Binding JWT signed by the given (holder's) key,
+// which binds the presentation to the given audience and nonce (both optional).
+func (s SDJWT) Bind(key crypto.Key, audience string, nonce string, issuedAt time.Time) (*SDJWT, error) {
+	claims := map[string]interface{}{
+		jwt.IssuedAtKey: issuedAt,
+		sdHashClaim:     digest(s.withoutKeyBinding()),
+	}
+	if audience != "" {
+		claims[jwt.AudienceKey] = audience
+	}
+	if nonce != "" {
+		claims[nonceClaim] = nonce
+	}
+	token, err := crypto.SignJWTWithSigner(key.Signer(), claims, map[string]interface{}{
+		jws.TypeKey:  KeyBindingJWTType,
+		jws.KeyIDKey: key.KID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign key binding JWT: %w", err)
+	}
+	result := s
+	result.KeyBinding = token
+	return &result, nil
+}
+
+// Issue creates an SD-JWT VC from the given credential, signed with the given (issuer's) key.
+// All claims of the credentialSubject (except its ID) are selectively disclosable.
+// The credential must have an ID, issuer, issuanceDate, a single type (besides VerifiableCredential) and a single credentialSubject.
+// The returned SD-JWT contains the disclosures of all claims.
+func Issue(credentialToIssue vc.VerifiableCredential, key crypto.Key) (*SDJWT, error) {
+	types := credential.ExtractTypes(credentialToIssue)
+	if len(types) != 1 {
+		return nil, fmt.Errorf("%w: must have exactly 1 type (besides VerifiableCredential)", ErrInvalidCredential)
+	}
+	if credentialToIssue.ID == nil {
+		return nil, fmt.Errorf("%w: missing ID", ErrInvalidCredential)
+	}
+	if len(credentialToIssue.CredentialSubject) != 1 {
+		return nil, fmt.Errorf("%w: must have exactly 1 credentialSubject", ErrInvalidCredential)
+	}
+	subject := map[string]interface{}{}
+	data, _ := json.Marshal(credentialToIssue.CredentialSubject[0])
+	if err := json.Unmarshal(data, &subject); err != nil {
+		return nil, fmt.Errorf("%w: invalid credentialSubject: %s", ErrInvalidCredential, err)
+	}
+
+	claims := map[string]interface{}{
+		jwt.IssuerKey:   credentialToIssue.Issuer.String(),
+		jwt.IssuedAtKey: credentialToIssue.IssuanceDate,
+		jwt.JwtIDKey:    credentialToIssue.ID.String(),
+		vctClaim:        types[0],
+		sdAlgClaim:      hashAlgorithm,
+	}
+	if credentialToIssue.ExpirationDate != nil {
+		claims[jwt.ExpirationKey] = *credentialToIssue.ExpirationDate
+	}
+	if subjectID, ok := subject["id"].(string); ok {
+		claims[jwt.SubjectKey] = subjectID
+		delete(subject, "id")
+	}
+
+	result := SDJWT{}
+	digests := make([]string, 0, len(subject))
+	for name, value := range subject {
+		if reservedClaims[name] {
+			return nil, fmt.Errorf("%w: credentialSubject contains reserved claim: %s", ErrInvalidCredential, name)
+		}
+		disclosure, err := NewDisclosure(name, value)
+		if err != nil {
+			return nil, err
+		}
+		result.Disclosures = append(result.Disclosures, *disclosure)
+		digests = append(digests, disclosure.Digest())
+	}
+	// sort digests, so their order doesn't reveal the order of the claims
+	sort.Strings(digests)
+	claims[sdClaim] = digests
+
+	token, err := crypto.SignJWTWithSigner(key.Signer(), claims, map[string]interface{}{
+		jws.TypeKey:  Format,
+		jws.KeyIDKey: key.KID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign SD-JWT: %w", err)
+	}
+	result.Token = token
+	return &result, nil
+}
+
+// Verify verifies the signature, disclosures and (if present or required) Key Binding JWT of the SD-JWT at the given time.
+// Keys are resolved using the given function: the issuer-signed JWT must be signed with a key of the issuer,
+// the Key Binding JWT with a key of the subject.
+// If keyBinding is given, the SD-JWT must contain a Key Binding JWT with the given audience and nonce,
+// which must have been issued at most KeyBindingMaxAge before validAt.
+// It returns the credential containing the disclosed claims. The returned credential has no proof, since it's not signed as such.
+func Verify(sdJWT SDJWT, resolveKey crypto.PublicKeyFunc, validAt time.Time, keyBinding *KeyBindingRequirements) (*vc.VerifiableCredential, error) {
+	token, err := parseJWT(sdJWT.Token, Format, resolveKey, validAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer-signed JWT: %w", err)
+	}
+	claims := token.PrivateClaims()
+	if claims[sdAlgClaim] != hashAlgorithm {
+		return nil, fmt.Errorf("unsupported %s: %v", sdAlgClaim, claims[sdAlgClaim])
+	}
+	digests := map[string]bool{}
+	if listed, ok := claims[sdClaim].([]interface{}); ok {
+		for _, curr := range listed {
+			if value, ok := curr.(string); ok {
+				digests[value] = true
+			}
+		}
+	}
+
+	subject := map[string]interface{}{}
+	for name, value := range claims {
+		if !reservedClaims[name] {
+			subject[name] = value
+		}
+	}
+	for _, disclosure := range sdJWT.Disclosures {
+		if !digests[disclosure.Digest()] {
+			return nil, fmt.Errorf("disclosure is not listed in the issuer-signed JWT: %s", disclosure.Name)
+		}
+		// prevent the same disclosure from being used twice
+		delete(digests, disclosure.Digest())
+		if _, exists := subject[disclosure.Name]; exists || reservedClaims[disclosure.Name] {
+			return nil, fmt.Errorf("disclosure overwrites existing claim: %s", disclosure.Name)
+		}
+		subject[disclosure.Name] = disclosure.Value
+	}
+
+	if sdJWT.KeyBinding != "" {
+		if err = verifyKeyBinding(sdJWT, token.Subject(), resolveKey, validAt, keyBinding); err != nil {
+			return nil, fmt.Errorf("invalid key binding JWT: %w", err)
+		}
+	} else if keyBinding != nil {
+		return nil, errors.New("key binding JWT is required")
+	}
+
+	return toCredential(token, subject)
+}
+
+func verifyKeyBinding(sdJWT SDJWT, subject string, resolveKey crypto.PublicKeyFunc, validAt time.Time, requirements *KeyBindingRequirements) error {
+	if subject == "" {
+		return errors.New("SD-JWT has no subject to bind to")
+	}
+	token, err := parseJWT(sdJWT.KeyBinding, KeyBindingJWTType, func(kid string) (crypto2.PublicKey, error) {
+		if !keyBelongsTo(kid, subject) {
+			return nil, errors.New("key binding JWT must be signed by the subject")
+		}
+		return resolveKey(kid)
+	}, validAt)
+	if err != nil {
+		return err
+	}
+	if sdHash, _ := token.Get(sdHashClaim); sdHash != digest(sdJWT.withoutKeyBinding()) {
+		return errors.New("SD-JWT hash mismatch")
+	}
+	if requirements == nil {
+		return nil
+	}
+	if audience := token.Audience(); len(audience) != 1 || audience[0] != requirements.Audience {
+		return fmt.Errorf("audience mismatch (expected=%s, actual=%v)", requirements.Audience, audience)
+	}
+	if nonce, _ := token.Get(nonceClaim); nonce != requirements.Nonce {
+		return errors.New("nonce mismatch")
+	}
+	issuedAt := token.IssuedAt()
+	if issuedAt.IsZero() {
+		return fmt.Errorf("missing %s claim", jwt.IssuedAtKey)
+	}
+	if issuedAt.After(validAt.Add(keyBindingMaxClockSkew)) {
+		return errors.New("issued in the future")
+	}
+	if validAt.Sub(issuedAt) > KeyBindingMaxAge {
+		return fmt.Errorf("issued more than %s ago", KeyBindingMaxAge)
+	}
+	return nil
+}
+
+func parseJWT(tokenString string, expectedType string, resolveKey crypto.PublicKeyFunc, validAt time.Time) (jwt.Token, error) {
+	message, err := jws.ParseString(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if len(message.Signatures()) != 1 {
+		return nil, errors.New("incorrect number of signatures in JWT")
+	}
+	if actualType := message.Signatures()[0].ProtectedHeaders().Type(); actualType != expectedType {
+		return nil, fmt.Errorf("invalid typ header: %s", actualType)
+	}
+	var kid string
+	token, err := crypto.ParseJWT(tokenString, func(keyID string) (crypto2.PublicKey, error) {
+		kid = keyID
+		return resolveKey(keyID)
+	}, jwt.WithClock(jwt.ClockFunc(func() time.Time {
+		return validAt
+	})))
+	if err != nil {
+		return nil, err
+	}
+	if expectedType == Format && !keyBelongsTo(kid, token.Issuer()) {
+		return nil, errors.New("JWT must be signed by the issuer")
+	}
+	return token, nil
+}
+
+// keyBelongsTo checks whether the key ID refers to a key of the given DID.
+func keyBelongsTo(kid string, subject string) bool {
+	keyID, err := did.ParseDIDURL(kid)
+	if err != nil {
+		return false
+	}
+	keyID.Fragment = ""
+	return keyID.String() == subject
+}
+
+func toCredential(token jwt.Token, subject map[string]interface{}) (*vc.VerifiableCredential, error) {
+	credentialType, ok := token.PrivateClaims()[vctClaim].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing %s claim", vctClaim)
+	}
+	issuer, err := ssi.ParseURI(token.Issuer())
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer: %w", err)
+	}
+	id, err := ssi.ParseURI(token.JwtID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential ID: %w", err)
+	}
+	if token.Subject() != "" {
+		subject["id"] = token.Subject()
+	}
+	result := vc.VerifiableCredential{
+		Context:           []ssi.URI{vc.VCContextV1URI()},
+		ID:                id,
+		Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI(credentialType)},
+		Issuer:            *issuer,
+		IssuanceDate:      token.IssuedAt(),
+		CredentialSubject: []interface{}{subject},
+	}
+	if !token.Expiration().IsZero() {
+		expiration := token.Expiration()
+		result.ExpirationDate = &expiration
+	}
+	return &result, nil
+}
+
+func digest(input string) string {
+	hash := sha256.Sum256([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
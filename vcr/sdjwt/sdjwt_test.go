@@ -0,0 +1,284 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sdjwt
+
+import (
+	crypto2 "crypto"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+var issuerKey = crypto.NewTestKey("did:nuts:issuer#key-1")
+var holderKey = crypto.NewTestKey("did:nuts:holder#key-1")
+
+func resolveTestKey(kid string) (crypto2.PublicKey, error) {
+	switch kid {
+	case issuerKey.KID():
+		return issuerKey.Public(), nil
+	case holderKey.KID():
+		return holderKey.Public(), nil
+	}
+	return nil, errors.New("unknown key")
+}
+
+func testCredential() vc.VerifiableCredential {
+	id := ssi.MustParseURI("did:nuts:issuer#1")
+	expirationDate := time.Now().Add(time.Hour).Truncate(time.Second)
+	return vc.VerifiableCredential{
+		Context:        []ssi.URI{vc.VCContextV1URI()},
+		ID:             &id,
+		Type:           []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("NutsEmployeeCredential")},
+		Issuer:         ssi.MustParseURI("did:nuts:issuer"),
+		IssuanceDate:   time.Now().Add(-time.Minute).Truncate(time.Second),
+		ExpirationDate: &expirationDate,
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":         "did:nuts:holder",
+			"name":       "John Doe",
+			"roleName":   "Nurse",
+			"identifier": "123456",
+		}},
+	}
+}
+
+func TestIssue(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		result, err := Issue(testCredential(), issuerKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, result.Disclosures, 3)
+		assert.Empty(t, result.KeyBinding)
+		assert.NotContains(t, result.Token, "John", "claims must not be readable from the issuer-signed JWT")
+	})
+	t.Run("error - multiple types", func(t *testing.T) {
+		input := testCredential()
+		input.Type = append(input.Type, ssi.MustParseURI("Other"))
+
+		_, err := Issue(input, issuerKey)
+
+		assert.EqualError(t, err, "invalid credential: must have exactly 1 type (besides VerifiableCredential)")
+	})
+	t.Run("error - reserved claim", func(t *testing.T) {
+		input := testCredential()
+		input.CredentialSubject = []interface{}{map[string]interface{}{"iss": "did:nuts:other"}}
+
+		_, err := Issue(input, issuerKey)
+
+		assert.EqualError(t, err, "invalid credential: credentialSubject contains reserved claim: iss")
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("roundtrip", func(t *testing.T) {
+		issued, _ := Issue(testCredential(), issuerKey)
+		presented, _ := issued.Bind(holderKey, "verifier", "nonce", time.Now())
+
+		parsed, err := Parse(presented.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, presented.String(), parsed.String())
+		assert.Equal(t, issued.Disclosures[0].Name, parsed.Disclosures[0].Name)
+	})
+	t.Run("without key binding", func(t *testing.T) {
+		issued, _ := Issue(testCredential(), issuerKey)
+
+		parsed, err := Parse(issued.String())
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(issued.String(), "~"))
+		assert.Empty(t, parsed.KeyBinding)
+		assert.Len(t, parsed.Disclosures, 3)
+	})
+	t.Run("error - no separator", func(t *testing.T) {
+		_, err := Parse("a.b.c")
+
+		assert.EqualError(t, err, "invalid SD-JWT: expected issuer-signed JWT followed by disclosures")
+	})
+	t.Run("error - invalid disclosure", func(t *testing.T) {
+		_, err := Parse("a.b.c~WyJhIl0~")
+
+		assert.EqualError(t, err, "invalid disclosure: expected salt, claim name and value")
+	})
+}
+
+func TestSDJWT_Subject(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		issued, _ := Issue(testCredential(), issuerKey)
+
+		subject, err := issued.Subject()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "did:nuts:holder", subject)
+	})
+	t.Run("error - no subject", func(t *testing.T) {
+		input := testCredential()
+		input.CredentialSubject = []interface{}{map[string]interface{}{"name": "John Doe"}}
+		issued, _ := Issue(input, issuerKey)
+
+		_, err := issued.Subject()
+
+		assert.EqualError(t, err, "SD-JWT has no subject")
+	})
+	t.Run("error - invalid JWT", func(t *testing.T) {
+		_, err := SDJWT{Token: "invalid"}.Subject()
+
+		assert.ErrorContains(t, err, "invalid issuer-signed JWT")
+	})
+}
+
+func TestSDJWT_Select(t *testing.T) {
+	issued, _ := Issue(testCredential(), issuerKey)
+
+	t.Run("ok", func(t *testing.T) {
+		selected, err := issued.Select([]string{"roleName"})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, selected.Disclosures, 1)
+		assert.Equal(t, "roleName", selected.Disclosures[0].Name)
+	})
+	t.Run("error - unknown claim", func(t *testing.T) {
+		_, err := issued.Select([]string{"email"})
+
+		assert.EqualError(t, err, "claim can't be disclosed: email")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	issued, _ := Issue(testCredential(), issuerKey)
+	selected, _ := issued.Select([]string{"name", "roleName"})
+	presented, _ := selected.Bind(holderKey, "verifier", "nonce", time.Now())
+	expectedKeyBinding := &KeyBindingRequirements{Audience: "verifier", Nonce: "nonce"}
+
+	t.Run("ok", func(t *testing.T) {
+		result, err := Verify(*presented, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		expected := testCredential()
+		assert.Equal(t, expected.ID, result.ID)
+		assert.Equal(t, expected.Issuer, result.Issuer)
+		assert.Equal(t, expected.Type, result.Type)
+		assert.Equal(t, expected.IssuanceDate.Unix(), result.IssuanceDate.Unix())
+		assert.Equal(t, expected.ExpirationDate.Unix(), result.ExpirationDate.Unix())
+		assert.Equal(t, map[string]interface{}{"id": "did:nuts:holder", "name": "John Doe", "roleName": "Nurse"}, result.CredentialSubject[0])
+	})
+	t.Run("ok - key binding not required", func(t *testing.T) {
+		_, err := Verify(*selected, resolveTestKey, time.Now(), nil)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - key binding required", func(t *testing.T) {
+		_, err := Verify(*selected, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		assert.EqualError(t, err, "key binding JWT is required")
+	})
+	t.Run("error - expired", func(t *testing.T) {
+		_, err := Verify(*presented, resolveTestKey, time.Now().Add(2*time.Hour), expectedKeyBinding)
+
+		assert.ErrorContains(t, err, "invalid issuer-signed JWT")
+	})
+	t.Run("error - disclosure not issued", func(t *testing.T) {
+		disclosure, _ := NewDisclosure("roleName", "Doctor")
+		tampered := *selected
+		tampered.Disclosures = []Disclosure{*disclosure}
+
+		_, err := Verify(tampered, resolveTestKey, time.Now(), nil)
+
+		assert.EqualError(t, err, "disclosure is not listed in the issuer-signed JWT: roleName")
+	})
+	t.Run("error - duplicate disclosure", func(t *testing.T) {
+		tampered := *selected
+		tampered.Disclosures = []Disclosure{selected.Disclosures[0], selected.Disclosures[0]}
+
+		_, err := Verify(tampered, resolveTestKey, time.Now(), nil)
+
+		assert.ErrorContains(t, err, "disclosure is not listed in the issuer-signed JWT")
+	})
+	t.Run("error - disclosures changed after binding", func(t *testing.T) {
+		tampered := *presented
+		tampered.Disclosures = issued.Disclosures
+
+		_, err := Verify(tampered, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		assert.EqualError(t, err, "invalid key binding JWT: SD-JWT hash mismatch")
+	})
+	t.Run("error - audience mismatch", func(t *testing.T) {
+		_, err := Verify(*presented, resolveTestKey, time.Now(), &KeyBindingRequirements{Audience: "other", Nonce: "nonce"})
+
+		assert.EqualError(t, err, "invalid key binding JWT: audience mismatch (expected=other, actual=[verifier])")
+	})
+	t.Run("error - nonce mismatch", func(t *testing.T) {
+		_, err := Verify(*presented, resolveTestKey, time.Now(), &KeyBindingRequirements{Audience: "verifier", Nonce: "other"})
+
+		assert.EqualError(t, err, "invalid key binding JWT: nonce mismatch")
+	})
+	t.Run("error - no audience", func(t *testing.T) {
+		bound, _ := selected.Bind(holderKey, "", "nonce", time.Now())
+
+		_, err := Verify(*bound, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		assert.ErrorContains(t, err, "invalid key binding JWT: audience mismatch")
+	})
+	t.Run("error - key binding too old", func(t *testing.T) {
+		bound, _ := selected.Bind(holderKey, "verifier", "nonce", time.Now().Add(-KeyBindingMaxAge-time.Second))
+
+		_, err := Verify(*bound, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		assert.EqualError(t, err, "invalid key binding JWT: issued more than 5m0s ago")
+	})
+	t.Run("error - key binding issued in the future", func(t *testing.T) {
+		bound, _ := selected.Bind(holderKey, "verifier", "nonce", time.Now().Add(time.Minute))
+
+		_, err := Verify(*bound, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		assert.ErrorContains(t, err, "invalid key binding JWT")
+	})
+	t.Run("error - key binding not signed by subject", func(t *testing.T) {
+		bound, _ := selected.Bind(issuerKey, "verifier", "nonce", time.Now())
+
+		_, err := Verify(*bound, resolveTestKey, time.Now(), expectedKeyBinding)
+
+		assert.EqualError(t, err, "invalid key binding JWT: key binding JWT must be signed by the subject")
+	})
+	t.Run("error - not signed by issuer", func(t *testing.T) {
+		input := testCredential()
+		input.Issuer = ssi.MustParseURI("did:nuts:other")
+		forged, _ := Issue(input, issuerKey)
+
+		_, err := Verify(*forged, resolveTestKey, time.Now(), nil)
+
+		assert.EqualError(t, err, "invalid issuer-signed JWT: JWT must be signed by the issuer")
+	})
+	t.Run("error - key binding JWT as issuer-signed JWT", func(t *testing.T) {
+		_, err := Verify(SDJWT{Token: presented.KeyBinding}, resolveTestKey, time.Now(), nil)
+
+		assert.EqualError(t, err, "invalid issuer-signed JWT: invalid typ header: kb+jwt")
+	})
+}
@@ -20,10 +20,14 @@
 package vcr
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -40,6 +44,9 @@ const (
 	Prefix = "prefix"
 )
 
+// ErrInvalidSearchCursor is returned when the cursor of a SearchQuery can't be decoded.
+var ErrInvalidSearchCursor = errors.New("invalid search cursor")
+
 // SearchTerm is part of a JSON-LD query. Multiple terms are combined in an 'AND' manner.
 type SearchTerm struct {
 	IRIPath []string
@@ -47,45 +54,181 @@ type SearchTerm struct {
 	Type    string
 }
 
+// SearchTerms is a set of SearchTerm which are combined in an 'AND' manner.
+type SearchTerms []SearchTerm
+
+// SearchQuery is an extended credential query which supports 'OR' groups, negation, date ranges and pagination.
+type SearchQuery struct {
+	// Terms must all match.
+	Terms SearchTerms
+	// AnyOf contains groups of alternatives. For every group at least one of its alternatives must match.
+	AnyOf [][]SearchTerms
+	// Not contains sets of terms of which none may match.
+	Not []SearchTerms
+	// IssuedAfter only matches credentials issued after the given time.
+	IssuedAfter *time.Time
+	// IssuedBefore only matches credentials issued before the given time.
+	IssuedBefore *time.Time
+	// ExpiresAfter only matches credentials expiring after the given time, or credentials that don't expire.
+	ExpiresAfter *time.Time
+	// ExpiresBefore only matches credentials expiring before the given time.
+	ExpiresBefore *time.Time
+	// Limit specifies the maximum number of credentials to return. If 0, all matching credentials are returned.
+	Limit int
+	// Cursor is the NextCursor of the previous SearchResult, used to retrieve the next page.
+	Cursor string
+}
+
+// SearchResult is the result of a SearchQuery.
+type SearchResult struct {
+	// Credentials contains the matching credentials, ordered by their reference in the credential store.
+	Credentials []vc.VerifiableCredential
+	// NextCursor is set when there are more results, it can be used to retrieve the next page.
+	NextCursor string
+}
+
 func (c *vcr) Search(ctx context.Context, searchTerms []SearchTerm, allowUntrusted bool, resolveTime *time.Time) ([]vc.VerifiableCredential, error) {
-	query := leia.Query{}
-	var VCs = make([]vc.VerifiableCredential, 0)
+	result, err := c.SearchCredentials(ctx, SearchQuery{Terms: searchTerms}, allowUntrusted, resolveTime)
+	if err != nil {
+		return nil, err
+	}
+	return result.Credentials, nil
+}
 
-	for _, searchTerm := range searchTerms {
-		var scalar leia.Scalar
-		var err error
-		if searchTerm.Type != NotNil {
-			scalar, err = leia.ParseScalar(searchTerm.Value)
+func (c *vcr) SearchCredentials(ctx context.Context, query SearchQuery, allowUntrusted bool, resolveTime *time.Time) (*SearchResult, error) {
+	var after leia.Reference
+	if query.Cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+		if err != nil || len(decoded) == 0 {
+			return nil, ErrInvalidSearchCursor
+		}
+		after = decoded
+	}
+
+	// leia only supports 'AND' queries, so every 'OR' alternative and negation is resolved by a separate query
+	// (combined with the 'AND' terms to narrow it down) of which the results are intersected or subtracted.
+	// Only the references are kept, the documents of the requested page are read afterwards.
+	candidates := make(map[string]bool)
+	err := c.iterateCredentials(ctx, func(ref leia.Reference, _ []byte) {
+		if after == nil || bytes.Compare(ref, after) > 0 {
+			candidates[string(ref)] = true
+		}
+	}, query.Terms)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range query.AnyOf {
+		matches := make(map[string]bool)
+		for _, alternative := range group {
+			err = c.iterateCredentials(ctx, func(ref leia.Reference, _ []byte) {
+				matches[string(ref)] = true
+			}, query.Terms, alternative)
 			if err != nil {
-				return nil, fmt.Errorf("value type (value=%v, type=%s) not supported at %s", searchTerm.Value, reflect.TypeOf(searchTerm.Value), strings.Join(searchTerm.IRIPath, ", "))
+				return nil, err
 			}
 		}
-
-		switch searchTerm.Type {
-		case Exact:
-			query = query.And(leia.Eq(leia.NewIRIPath(searchTerm.IRIPath...), scalar))
-		case NotNil:
-			query = query.And(leia.NotNil(leia.NewIRIPath(searchTerm.IRIPath...)))
-		default:
-			query = query.And(leia.Prefix(leia.NewIRIPath(searchTerm.IRIPath...), scalar))
+		for ref := range candidates {
+			if !matches[ref] {
+				delete(candidates, ref)
+			}
+		}
+	}
+	for _, terms := range query.Not {
+		err = c.iterateCredentials(ctx, func(ref leia.Reference, _ []byte) {
+			delete(candidates, string(ref))
+		}, query.Terms, terms)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	docs, err := c.credentialCollection().Find(ctx, query)
-	if err != nil {
-		return nil, err
+	refs := make([]string, 0, len(candidates))
+	for ref := range candidates {
+		refs = append(refs, ref)
 	}
-	for _, doc := range docs {
-		foundCredential := vc.VerifiableCredential{}
-		err = json.Unmarshal(doc, &foundCredential)
+	sort.Strings(refs)
+
+	result := SearchResult{Credentials: make([]vc.VerifiableCredential, 0)}
+	collection := c.credentialCollection()
+	for i, ref := range refs {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		doc, err := collection.Get(leia.Reference(ref))
 		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			// removed since it was found
+			continue
+		}
+		foundCredential := vc.VerifiableCredential{}
+		if err = json.Unmarshal(doc, &foundCredential); err != nil {
 			return nil, fmt.Errorf("unable to parse credential from db: %w", err)
 		}
-
+		if !query.matchesDates(foundCredential) {
+			continue
+		}
+		if query.Limit > 0 && len(result.Credentials) == query.Limit {
+			// there are more results, although they might not all pass verification
+			result.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(refs[i-1]))
+			break
+		}
 		if err = c.verifier.Verify(foundCredential, allowUntrusted, false, resolveTime); err == nil {
-			VCs = append(VCs, foundCredential)
+			result.Credentials = append(result.Credentials, foundCredential)
 		}
 	}
 
-	return VCs, nil
+	return &result, nil
+}
+
+// iterateCredentials calls the given function for every stored credential matching all given terms.
+func (c *vcr) iterateCredentials(ctx context.Context, fn func(ref leia.Reference, doc []byte), terms ...SearchTerms) error {
+	query := leia.Query{}
+	for _, curr := range terms {
+		for _, searchTerm := range curr {
+			var scalar leia.Scalar
+			var err error
+			if searchTerm.Type != NotNil {
+				scalar, err = leia.ParseScalar(searchTerm.Value)
+				if err != nil {
+					return fmt.Errorf("value type (value=%v, type=%s) not supported at %s", searchTerm.Value, reflect.TypeOf(searchTerm.Value), strings.Join(searchTerm.IRIPath, ", "))
+				}
+			}
+
+			switch searchTerm.Type {
+			case Exact:
+				query = query.And(leia.Eq(leia.NewIRIPath(searchTerm.IRIPath...), scalar))
+			case NotNil:
+				query = query.And(leia.NotNil(leia.NewIRIPath(searchTerm.IRIPath...)))
+			default:
+				query = query.And(leia.Prefix(leia.NewIRIPath(searchTerm.IRIPath...), scalar))
+			}
+		}
+	}
+
+	return c.credentialCollection().Iterate(query, func(ref leia.Reference, doc []byte) error {
+		// stop iteration when needed
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fn(ref, doc)
+		return nil
+	})
+}
+
+func (q SearchQuery) matchesDates(credential vc.VerifiableCredential) bool {
+	if q.IssuedAfter != nil && !credential.IssuanceDate.After(*q.IssuedAfter) {
+		return false
+	}
+	if q.IssuedBefore != nil && !credential.IssuanceDate.Before(*q.IssuedBefore) {
+		return false
+	}
+	if q.ExpiresAfter != nil && credential.ExpirationDate != nil && !credential.ExpirationDate.After(*q.ExpiresAfter) {
+		return false
+	}
+	if q.ExpiresBefore != nil && (credential.ExpirationDate == nil || !credential.ExpirationDate.Before(*q.ExpiresBefore)) {
+		return false
+	}
+	return true
 }
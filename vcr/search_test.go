@@ -21,12 +21,16 @@ package vcr
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/nuts-foundation/go-leia/v3"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/stretchr/testify/assert"
@@ -126,6 +130,27 @@ func TestVCR_Search(t *testing.T) {
 		assert.Len(t, searchResult, 1, "expected 1 results since the allowUntrusted flag is set")
 	})
 
+	t.Run("ok - JWT encoded credential", func(t *testing.T) {
+		ctx := newMockContext(t)
+		unsigned := jsonld.TestVC()
+		unsigned.Proof = nil
+		jwtVC, err := jwtvc.CreateCredential(unsigned, crypto.NewTestKey(unsigned.Issuer.String()+"#key-1"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.NoError(t, ctx.vcr.writeCredential(*jwtVC)) {
+			return
+		}
+		_, searchTerms := testInstance(t)
+
+		searchResult, err := ctx.vcr.Search(reqCtx, searchTerms, true, &now)
+
+		if !assert.NoError(t, err) || !assert.Len(t, searchResult, 1) {
+			return
+		}
+		assert.Equal(t, jwtVC.Proof, searchResult[0].Proof)
+	})
+
 	// Todo: use ldproof revocation and issuer store after switch
 	t.Run("ok - revoked", func(t *testing.T) {
 		ctx, searchTerms := testInstance(t)
@@ -143,3 +168,183 @@ func TestVCR_Search(t *testing.T) {
 		assert.Len(t, creds, 0)
 	})
 }
+
+func TestVCR_SearchCredentials(t *testing.T) {
+	eyeColourPath := []string{"https://www.w3.org/2018/credentials#credentialSubject", "http://example.org/human", "http://example.org/eyeColour"}
+	eyeColour := func(value string) SearchTerms {
+		return SearchTerms{{IRIPath: eyeColourPath, Value: value, Type: Exact}}
+	}
+	parseTime := func(value string) *time.Time {
+		result, _ := time.Parse(time.RFC3339, value)
+		return &result
+	}
+	testInstance := func(t2 *testing.T) mockContext {
+		ctx := newMockContext(t2)
+		mockVerifier := verifier.NewMockVerifier(ctx.ctrl)
+		mockVerifier.EXPECT().Verify(gomock.Any(), true, false, gomock.Any()).AnyTimes()
+		ctx.vcr.verifier = mockVerifier
+
+		// add credentials #1 (blue, 2020), #2 (brown, 2021) and #3 (green, 2022)
+		for i, colour := range []string{"blue", "brown", "green"} {
+			doc := strings.Replace(jsonld.TestCredential, "#123", fmt.Sprintf("#%d", i+1), 1)
+			doc = strings.Replace(doc, "blue/grey", colour, 1)
+			doc = strings.Replace(doc, "1970-01-01", fmt.Sprintf("%d-01-01", 2020+i), 1)
+			err := ctx.vcr.credentialCollection().Add([]leia.Document{[]byte(doc)})
+			if !assert.NoError(t2, err) {
+				t2.Fatal(err)
+			}
+		}
+		return ctx
+	}
+	ids := func(result *SearchResult) []string {
+		var ids []string
+		for _, credential := range result.Credentials {
+			ids = append(ids, credential.ID.Fragment)
+		}
+		return ids
+	}
+	reqCtx := context.Background()
+
+	t.Run("ok - all", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.ElementsMatch(t, []string{"1", "2", "3"}, ids(result))
+		assert.Empty(t, result.NextCursor)
+	})
+	t.Run("ok - any of", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{
+			AnyOf: [][]SearchTerms{{eyeColour("blue"), eyeColour("green")}},
+		}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.ElementsMatch(t, []string{"1", "3"}, ids(result))
+	})
+	t.Run("ok - multiple any of groups", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{
+			AnyOf: [][]SearchTerms{
+				{eyeColour("blue"), eyeColour("green")},
+				{eyeColour("green"), eyeColour("brown")},
+			},
+		}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.ElementsMatch(t, []string{"3"}, ids(result))
+	})
+	t.Run("ok - not", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{
+			Not: []SearchTerms{eyeColour("brown")},
+		}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.ElementsMatch(t, []string{"1", "3"}, ids(result))
+	})
+	t.Run("ok - terms combined with not", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{
+			Terms: eyeColour("blue"),
+			Not:   []SearchTerms{eyeColour("brown")},
+		}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.ElementsMatch(t, []string{"1"}, ids(result))
+	})
+	t.Run("ok - issuance date range", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{
+			IssuedAfter:  parseTime("2020-06-01T00:00:00Z"),
+			IssuedBefore: parseTime("2022-01-01T00:00:00Z"),
+		}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.ElementsMatch(t, []string{"2"}, ids(result))
+	})
+	t.Run("ok - expiration date range", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{ExpiresBefore: parseTime("2029-01-01T00:00:00Z")}, true, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Empty(t, result.Credentials)
+
+		result, err = ctx.vcr.SearchCredentials(reqCtx, SearchQuery{ExpiresAfter: parseTime("2029-01-01T00:00:00Z")}, true, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, result.Credentials, 3)
+	})
+	t.Run("ok - paginated", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		first, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{Limit: 2}, true, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, first.Credentials, 2)
+		if !assert.NotEmpty(t, first.NextCursor) {
+			return
+		}
+
+		second, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{Limit: 2, Cursor: first.NextCursor}, true, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, second.Credentials, 1)
+		assert.Empty(t, second.NextCursor)
+		assert.ElementsMatch(t, []string{"1", "2", "3"}, append(ids(first), ids(second)...))
+	})
+	t.Run("ok - credential without ID", func(t *testing.T) {
+		ctx := testInstance(t)
+		doc := strings.Replace(jsonld.TestCredential, `"id": "did:nuts:B8PUHs2AUHbFF1xLLK4eZjgErEcMXHxs68FteY7NDtCY#123",`, "", 1)
+		if !assert.NotEqual(t, jsonld.TestCredential, doc) {
+			return
+		}
+		_ = ctx.vcr.credentialCollection().Add([]leia.Document{[]byte(doc)})
+
+		result, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{}, true, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, result.Credentials, 4)
+	})
+	t.Run("error - invalid cursor", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		_, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{Cursor: "%%%"}, true, nil)
+
+		assert.ErrorIs(t, err, ErrInvalidSearchCursor)
+	})
+	t.Run("error - unsupported value type", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		_, err := ctx.vcr.SearchCredentials(reqCtx, SearchQuery{
+			Not: []SearchTerms{{{IRIPath: eyeColourPath, Value: []string{"blue"}, Type: Exact}}},
+		}, true, nil)
+
+		assert.EqualError(t, err, "value type (value=[blue], type=[]string) not supported at "+strings.Join(eyeColourPath, ", "))
+	})
+}
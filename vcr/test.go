@@ -31,7 +31,6 @@ import (
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/test/io"
-	"github.com/nuts-foundation/nuts-node/vcr/trust"
 	"github.com/nuts-foundation/nuts-node/vdr/doc"
 	"github.com/nuts-foundation/nuts-node/vdr/types"
 )
@@ -83,6 +82,7 @@ func newMockContext(t *testing.T) mockContext {
 	crypto := crypto.NewMockKeyStore(ctrl)
 	tx := network.NewMockTransactions(ctrl)
 	tx.EXPECT().WithPersistency().AnyTimes()
+	tx.EXPECT().RegisterPayloadType(gomock.Any(), gomock.Any()).Times(2)
 	tx.EXPECT().Subscribe("vcr_vcs", gomock.Any(), gomock.Any())
 	tx.EXPECT().Subscribe("vcr_revocations", gomock.Any(), gomock.Any())
 	keyResolver := types.NewMockKeyResolver(ctrl)
@@ -93,7 +93,6 @@ func newMockContext(t *testing.T) mockContext {
 	storageClient := storage.NewTestStorageEngine(testDir)
 	vcr := NewVCRInstance(crypto, docResolver, keyResolver, tx, jsonldManager, eventManager, storageClient).(*vcr)
 	vcr.serviceResolver = serviceResolver
-	vcr.trustConfig = trust.NewConfig(path.Join(testDir, "trust.yaml"))
 	if err := vcr.Configure(core.TestServerConfig(core.ServerConfig{Datadir: testDir})); err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,99 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package trust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// ErrInvalidPolicy is returned when a trust policy is incomplete or ambiguous.
+var ErrInvalidPolicy = errors.New("invalid trust policy")
+
+// ErrPolicyNotFound is returned when a trust policy with the given ID does not exist.
+var ErrPolicyNotFound = errors.New("trust policy not found")
+
+// Policy describes which issuers are trusted for a credential type.
+// A policy either trusts a single issuer directly, or trusts all issuers that hold a credential issued by a trusted party (a trust chain).
+type Policy struct {
+	// ID uniquely identifies the policy. It is derived from the other fields (except Expires) when the policy is added.
+	ID string `json:"id"`
+	// CredentialType is the credential type the policy applies to.
+	CredentialType string `json:"credentialType"`
+	// Issuer is the DID of the issuer that is trusted directly. It is mutually exclusive with Chain.
+	Issuer string `json:"issuer,omitempty"`
+	// Chain trusts all issuers that hold a credential as described by the chain. It is mutually exclusive with Issuer.
+	Chain *Chain `json:"chain,omitempty"`
+	// Expires is the optional moment after which the policy is no longer applied.
+	Expires *time.Time `json:"expires,omitempty"`
+	// Source is the DID of the signer of the trust list the policy was imported from. It is empty for policies added on this node.
+	Source string `json:"source,omitempty"`
+}
+
+// Chain describes the credential an issuer must hold to be trusted by a policy:
+// a credential of CredentialType, issued by Issuer, with the issuer as credential subject.
+type Chain struct {
+	// CredentialType is the type of the credential the issuer must hold.
+	CredentialType string `json:"credentialType"`
+	// Issuer is the DID of the party that must have issued the credential.
+	Issuer string `json:"issuer"`
+}
+
+// ChainResolver checks whether the subject holds a valid credential of the given type, issued by the given issuer.
+type ChainResolver func(credentialType ssi.URI, issuer ssi.URI, subject ssi.URI) bool
+
+// Validate checks whether the policy is complete.
+func (p Policy) Validate() error {
+	if p.CredentialType == "" {
+		return fmt.Errorf("%w: missing credential type", ErrInvalidPolicy)
+	}
+	if (p.Issuer == "") == (p.Chain == nil) {
+		return fmt.Errorf("%w: exactly one of issuer or chain must be given", ErrInvalidPolicy)
+	}
+	if p.Chain != nil && (p.Chain.CredentialType == "" || p.Chain.Issuer == "") {
+		return fmt.Errorf("%w: chain requires a credential type and issuer", ErrInvalidPolicy)
+	}
+	for _, value := range []string{p.CredentialType, p.Issuer} {
+		if _, err := ssi.ParseURI(value); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPolicy, err)
+		}
+	}
+	return nil
+}
+
+// IsExpired returns true when the policy has an expiration date before the given moment.
+func (p Policy) IsExpired(at time.Time) bool {
+	return p.Expires != nil && p.Expires.Before(at)
+}
+
+// deriveID calculates the ID of the policy, so adding the same policy twice results in a single policy.
+func (p Policy) deriveID() string {
+	parts := []string{p.Source, p.CredentialType, p.Issuer}
+	if p.Chain != nil {
+		parts = append(parts, p.Chain.CredentialType, p.Chain.Issuer)
+	}
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(hash[:16])
+}
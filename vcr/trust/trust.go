@@ -1,6 +1,6 @@
 /*
  * Nuts node
- * Copyright (C) 2021 Nuts community
+ * Copyright (C) 2022 Nuts community
  *
  * This program is free software: you can redistribute it and/or modify
  * it under the terms of the GNU General Public License as published by
@@ -14,136 +14,374 @@
  *
  * You should have received a copy of the GNU General Public License
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
- *
  */
 
 package trust
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"sort"
 	"sync"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"gopkg.in/yaml.v2"
 )
 
-// ErrNoFilename is returned when trust actions are performed but no file for storing those is specified.
-var ErrNoFilename = errors.New("no filename specified")
+const policyShelf = "policies"
+
+// trustListShelf contains the moment the last imported trust list was signed, per trust list signer.
+const trustListShelf = "trustlists"
 
-// Config holds the trusted issuers per credential type
+// Config holds the trust policies which determine the trusted issuers per credential type.
+// The policies are persisted in a key-value store and cached in memory.
 type Config struct {
-	filename       string
-	issuersPerType map[string][]string
-	mutex          sync.Mutex
+	store         stoabs.KVStore
+	policies      map[string]Policy
+	listIssuedAt  map[string]time.Time
+	chainResolver ChainResolver
+	mutex         sync.RWMutex
 }
 
-// NewConfig returns a fully configured Config
-func NewConfig(filename string) *Config {
+// NewConfig returns a fully configured Config, which persists its policies in the given store.
+func NewConfig(store stoabs.KVStore) *Config {
 	return &Config{
-		filename:       filename,
-		issuersPerType: map[string][]string{},
-		mutex:          sync.Mutex{},
+		store:        store,
+		policies:     map[string]Policy{},
+		listIssuedAt: map[string]time.Time{},
+		mutex:        sync.RWMutex{},
 	}
 }
 
-// Load the trusted issuers per credential type from file
-func (tc *Config) Load() error {
+// SetChainResolver sets the function used to evaluate trust chain policies.
+// When no resolver is set, trust chain policies never match.
+func (tc *Config) SetChainResolver(resolver ChainResolver) {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
-	if tc.filename == "" {
-		return ErrNoFilename
-	}
+	tc.chainResolver = resolver
+}
 
-	// ignore if not exists
-	_, err := os.Stat(tc.filename)
+// Load the trust policies from the store
+func (tc *Config) Load() error {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	policies := map[string]Policy{}
+	err := tc.store.ReadShelf(context.Background(), policyShelf, func(reader stoabs.Reader) error {
+		return reader.Iterate(func(key stoabs.Key, value []byte) error {
+			var policy Policy
+			if err := json.Unmarshal(value, &policy); err != nil {
+				return fmt.Errorf("unable to parse trust policy (id=%s): %w", key, err)
+			}
+			policies[policy.ID] = policy
+			return nil
+		}, stoabs.BytesKey{})
+	})
 	if err != nil {
-		return nil
+		return err
 	}
-
-	data, err := os.ReadFile(tc.filename)
+	listIssuedAt := map[string]time.Time{}
+	err = tc.store.ReadShelf(context.Background(), trustListShelf, func(reader stoabs.Reader) error {
+		return reader.Iterate(func(key stoabs.Key, value []byte) error {
+			var issuedAt time.Time
+			if err := json.Unmarshal(value, &issuedAt); err != nil {
+				return fmt.Errorf("unable to parse trust list issuance time (signer=%s): %w", key, err)
+			}
+			listIssuedAt[string(key.Bytes())] = issuedAt
+			return nil
+		}, stoabs.BytesKey{})
+	})
 	if err != nil {
 		return err
 	}
-
-	return yaml.Unmarshal(data, &tc.issuersPerType)
+	tc.policies = policies
+	tc.listIssuedAt = listIssuedAt
+	return nil
 }
 
-// Save the list of trusted issuers per credential type to file
-func (tc *Config) save() error {
-	if tc.filename == "" {
-		return ErrNoFilename
+// Migrate imports the trusted issuers per credential type from a YAML file, as used by previous versions.
+// After a successful import the file is renamed, so it's only imported once. It's ignored if it doesn't exist.
+func (tc *Config) Migrate(filename string) error {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
 	}
 
-	data, err := yaml.Marshal(tc.issuersPerType)
-	if err != nil {
+	issuersPerType := map[string][]string{}
+	if err = yaml.Unmarshal(data, &issuersPerType); err != nil {
+		return fmt.Errorf("unable to parse trusted issuers file (file=%s): %w", filename, err)
+	}
+	policies := make([]Policy, 0)
+	for credentialType, issuers := range issuersPerType {
+		for _, issuer := range issuers {
+			policies = append(policies, Policy{CredentialType: credentialType, Issuer: issuer})
+		}
+	}
+	if err = tc.addPolicies(policies...); err != nil {
 		return err
 	}
+	log.Logger().
+		WithField("file", filename).
+		Infof("Migrated %d trusted issuers to the trust policy store", len(policies))
 
-	return os.WriteFile(tc.filename, data, 0644)
+	return os.Rename(filename, filename+".migrated")
 }
 
-// List returns all trusted issuers for the given type
+// List returns all directly trusted issuers for the given type, excluding expired policies
 func (tc *Config) List(credentialType ssi.URI) []ssi.URI {
-	stringList := tc.issuersPerType[credentialType.String()]
-	uriList := make([]ssi.URI, len(stringList))
-	for i, e := range stringList {
-		uriList[i] = ssi.MustParseURI(e)
+	tc.mutex.RLock()
+	defer tc.mutex.RUnlock()
+
+	now := time.Now()
+	issuers := map[string]bool{}
+	for _, policy := range tc.policies {
+		if policy.Issuer != "" && policy.CredentialType == credentialType.String() && !policy.IsExpired(now) {
+			issuers[policy.Issuer] = true
+		}
+	}
+	uriList := make([]ssi.URI, 0, len(issuers))
+	for issuer := range issuers {
+		uriList = append(uriList, ssi.MustParseURI(issuer))
 	}
+	sort.Slice(uriList, func(i, j int) bool {
+		return uriList[i].String() < uriList[j].String()
+	})
 	return uriList
 }
 
-// IsTrusted returns true when the given issuer is in the trusted issuers list of the given credentialType
+// Policies returns all trust policies, including expired ones, ordered by credential type and ID.
+func (tc *Config) Policies() []Policy {
+	tc.mutex.RLock()
+	defer tc.mutex.RUnlock()
+
+	result := make([]Policy, 0, len(tc.policies))
+	for _, policy := range tc.policies {
+		result = append(result, policy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CredentialType != result[j].CredentialType {
+			return result[i].CredentialType < result[j].CredentialType
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// IsTrusted returns true when the given issuer is trusted for the given credentialType by a policy that hasn't expired:
+// either directly or because it holds a credential as described by a trust chain policy.
 func (tc *Config) IsTrusted(credentialType ssi.URI, issuer ssi.URI) bool {
+	tc.mutex.RLock()
+	now := time.Now()
 	issuerString := issuer.String()
-	for _, i := range tc.issuersPerType[credentialType.String()] {
-		if i == issuerString {
+	chains := make([]Chain, 0)
+	for _, policy := range tc.policies {
+		if policy.CredentialType != credentialType.String() || policy.IsExpired(now) {
+			continue
+		}
+		if policy.Issuer == issuerString {
+			tc.mutex.RUnlock()
 			return true
 		}
+		if policy.Chain != nil {
+			chains = append(chains, *policy.Chain)
+		}
 	}
+	resolver := tc.chainResolver
+	// release the lock before resolving chains, since resolving might require verifying (and thus trust checks of) other credentials
+	tc.mutex.RUnlock()
 
+	if resolver == nil {
+		return false
+	}
+	for _, chain := range chains {
+		chainType, err := ssi.ParseURI(chain.CredentialType)
+		if err != nil {
+			continue
+		}
+		chainIssuer, err := ssi.ParseURI(chain.Issuer)
+		if err != nil {
+			continue
+		}
+		if resolver(*chainType, *chainIssuer, issuer) {
+			return true
+		}
+	}
 	return false
 }
 
 // AddTrust adds trust in a specific Issuer for a credential type.
-// It returns an error if the Save fails
+// It returns an error if persisting the policy fails
 func (tc *Config) AddTrust(credentialType ssi.URI, issuer ssi.URI) error {
+	_, err := tc.AddPolicy(Policy{CredentialType: credentialType.String(), Issuer: issuer.String()})
+	return err
+}
+
+// AddPolicy validates and adds the given policy. Adding a policy that already exists updates its expiration date.
+// The ID of the policy is derived from its contents, any given ID is ignored.
+func (tc *Config) AddPolicy(policy Policy) (*Policy, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	policy.ID = policy.deriveID()
+	if err := tc.addPolicies(policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// RemoveTrust removes all policies that directly trust a specific Issuer for a credential type.
+// It returns an error if persisting the change fails
+func (tc *Config) RemoveTrust(credentialType ssi.URI, issuer ssi.URI) error {
+	return tc.removePolicies(func(policy Policy) bool {
+		return policy.CredentialType == credentialType.String() && policy.Issuer == issuer.String()
+	})
+}
+
+// RemovePolicy removes the policy with the given ID. It returns ErrPolicyNotFound if it doesn't exist.
+func (tc *Config) RemovePolicy(id string) error {
+	tc.mutex.RLock()
+	_, exists := tc.policies[id]
+	tc.mutex.RUnlock()
+	if !exists {
+		return ErrPolicyNotFound
+	}
+	return tc.removePolicies(func(policy Policy) bool {
+		return policy.ID == id
+	})
+}
+
+// ReplacePolicies replaces all policies from the signer of the given trust list with the policies of the list.
+// The trust list must be signed after the previously imported list of the same signer, otherwise ErrStaleTrustList is returned.
+// This prevents an older list (e.g. one that still trusts a since removed issuer) from being replayed.
+// It returns the policies as stored.
+func (tc *Config) ReplacePolicies(list TrustList) ([]Policy, error) {
+	policies := make([]Policy, len(list.Policies))
+	for i, policy := range list.Policies {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+		policy.Source = list.Signer
+		policy.ID = policy.deriveID()
+		policies[i] = policy
+	}
+	issuedAt, err := json.Marshal(list.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
-	tString := credentialType.String()
+	if previous, ok := tc.listIssuedAt[list.Signer]; ok && !list.IssuedAt.After(previous) {
+		return nil, fmt.Errorf("%w (signer=%s, issuedAt=%s, previous=%s)", ErrStaleTrustList, list.Signer, list.IssuedAt, previous)
+	}
 
-	if tc.IsTrusted(credentialType, issuer) {
-		return nil
+	replaced := make([]string, 0)
+	for id, policy := range tc.policies {
+		if policy.Source == list.Signer {
+			replaced = append(replaced, id)
+		}
+	}
+	err = tc.store.Write(context.Background(), func(tx stoabs.WriteTx) error {
+		writer, err := tx.GetShelfWriter(policyShelf)
+		if err != nil {
+			return err
+		}
+		for _, id := range replaced {
+			if err := writer.Delete(stoabs.BytesKey(id)); err != nil {
+				return err
+			}
+		}
+		if err = writePolicies(writer, policies); err != nil {
+			return err
+		}
+		writer, err = tx.GetShelfWriter(trustListShelf)
+		if err != nil {
+			return err
+		}
+		return writer.Put(stoabs.BytesKey(list.Signer), issuedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	tc.listIssuedAt[list.Signer] = list.IssuedAt
+	for _, id := range replaced {
+		delete(tc.policies, id)
+	}
+	for _, policy := range policies {
+		tc.policies[policy.ID] = policy
+	}
+	return policies, nil
+}
+
+func (tc *Config) addPolicies(policies ...Policy) error {
+	for i := range policies {
+		policies[i].ID = policies[i].deriveID()
 	}
 
-	tc.issuersPerType[tString] = append(tc.issuersPerType[tString], issuer.String())
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
 
-	return tc.save()
+	err := tc.store.WriteShelf(context.Background(), policyShelf, func(writer stoabs.Writer) error {
+		return writePolicies(writer, policies)
+	})
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		tc.policies[policy.ID] = policy
+	}
+	return nil
 }
 
-// RemoveTrust removes trust in a specific Issuer for a credential type.
-// It returns an error if the Save fails
-func (tc *Config) RemoveTrust(credentialType ssi.URI, issuer ssi.URI) error {
+func (tc *Config) removePolicies(match func(policy Policy) bool) error {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
-	tString := credentialType.String()
 
-	if !tc.IsTrusted(credentialType, issuer) {
+	removed := make([]string, 0)
+	for id, policy := range tc.policies {
+		if match(policy) {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
 		return nil
 	}
-
-	var issuerList = make([]string, len(tc.issuersPerType[tString])-1)
-	j := 0
-	for _, i := range tc.issuersPerType[tString] {
-		if i != issuer.String() {
-			issuerList[j] = i
-			j++
+	err := tc.store.WriteShelf(context.Background(), policyShelf, func(writer stoabs.Writer) error {
+		for _, id := range removed {
+			if err := writer.Delete(stoabs.BytesKey(id)); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	for _, id := range removed {
+		delete(tc.policies, id)
+	}
+	return nil
+}
 
-	tc.issuersPerType[tString] = issuerList
-
-	return tc.save()
+func writePolicies(writer stoabs.Writer, policies []Policy) error {
+	for _, policy := range policies {
+		data, err := json.Marshal(policy)
+		if err != nil {
+			return err
+		}
+		if err = writer.Put(stoabs.BytesKey(policy.ID), data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
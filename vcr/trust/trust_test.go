@@ -1,6 +1,6 @@
 /*
  * Nuts node
- * Copyright (C) 2021 Nuts community
+ * Copyright (C) 2022 Nuts community
  *
  * This program is free software: you can redistribute it and/or modify
  * it under the terms of the GNU General Public License as published by
@@ -14,115 +14,163 @@
  *
  * You should have received a copy of the GNU General Public License
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
- *
  */
 
 package trust
 
 import (
+	"context"
+	"os"
 	"path"
 	"testing"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/stretchr/testify/assert"
 )
 
 const nutsTestCredential = "NutsOrganizationCredential"
 
-func TestTrustConfig_save(t *testing.T) {
+func TestConfig_Load(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		testDir := io.TestDirectory(t)
-		tc := NewConfig(path.Join(testDir, "test.yaml"))
-
-		tc.issuersPerType[nutsTestCredential] = []string{"did:nuts:1"}
-
-		err := tc.save()
+		store, err := storage.CreateTestBBoltStore(path.Join(io.TestDirectory(t), "trust.db"))
 		if !assert.NoError(t, err) {
 			return
 		}
-
-		tc2 := Config{
-			filename:       path.Join(testDir, "test.yaml"),
-			issuersPerType: map[string][]string{},
+		defer store.Close(context.Background())
+		tc := NewConfig(store)
+		err = tc.AddTrust(ssi.MustParseURI(nutsTestCredential), ssi.MustParseURI("did:nuts:1"))
+		if !assert.NoError(t, err) {
+			return
 		}
 
+		tc2 := NewConfig(store)
 		err = tc2.Load()
 		if !assert.NoError(t, err) {
 			return
 		}
 
-		assert.Equal(t, []string{"did:nuts:1"}, tc2.issuersPerType[nutsTestCredential])
+		assert.Equal(t, tc.Policies(), tc2.Policies())
+		assert.True(t, tc2.IsTrusted(ssi.MustParseURI(nutsTestCredential), ssi.MustParseURI("did:nuts:1")))
 	})
 
-	t.Run("error - no filename", func(t *testing.T) {
-		err := NewConfig("").save()
+	t.Run("ok - empty store", func(t *testing.T) {
+		tc := NewTestConfig(t)
 
-		assert.Error(t, err)
+		err := tc.Load()
+
+		assert.NoError(t, err)
+		assert.Empty(t, tc.Policies())
 	})
 }
 
-func TestTrustConfig_Load(t *testing.T) {
+func TestConfig_Migrate(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		tc := NewConfig("../test/issuers.yaml")
+		data, _ := os.ReadFile("../test/issuers.yaml")
+		filename := path.Join(io.TestDirectory(t), "trusted_issuers.yaml")
+		_ = os.WriteFile(filename, data, 0644)
+		tc := NewTestConfig(t)
 
-		err := tc.Load()
+		err := tc.Migrate(filename)
 		if !assert.NoError(t, err) {
 			return
 		}
 
-		assert.Equal(t, []string{"did:nuts:CuE3qeFGGLhEAS3gKzhMCeqd1dGa9at5JCbmCfyMU2Ey"}, tc.issuersPerType[nutsTestCredential])
+		assert.True(t, tc.IsTrusted(ssi.MustParseURI(nutsTestCredential), ssi.MustParseURI("did:nuts:CuE3qeFGGLhEAS3gKzhMCeqd1dGa9at5JCbmCfyMU2Ey")))
+		assert.NoFileExists(t, filename)
+		assert.FileExists(t, filename+".migrated")
 	})
 
-	t.Run("error - no filename", func(t *testing.T) {
-		err := NewConfig("").Load()
+	t.Run("ok - file does not exist", func(t *testing.T) {
+		tc := NewTestConfig(t)
+
+		err := tc.Migrate(path.Join(io.TestDirectory(t), "trusted_issuers.yaml"))
 
-		assert.Error(t, err)
+		assert.NoError(t, err)
+		assert.Empty(t, tc.Policies())
 	})
-}
 
-func TestTrustConfig_IsTrusted(t *testing.T) {
-	tc := NewConfig("../test/issuers.yaml")
+	t.Run("error - invalid file", func(t *testing.T) {
+		filename := path.Join(io.TestDirectory(t), "trusted_issuers.yaml")
+		_ = os.WriteFile(filename, []byte("not a map"), 0644)
+		tc := NewTestConfig(t)
 
-	err := tc.Load()
-	if !assert.NoError(t, err) {
-		return
-	}
+		err := tc.Migrate(filename)
 
-	c := ssi.MustParseURI(nutsTestCredential)
+		assert.ErrorContains(t, err, "unable to parse trusted issuers file")
+		assert.FileExists(t, filename)
+	})
+}
+
+func TestConfig_IsTrusted(t *testing.T) {
+	credentialType := ssi.MustParseURI(nutsTestCredential)
+	issuer := ssi.MustParseURI("did:nuts:1")
 
 	t.Run("true", func(t *testing.T) {
-		d := ssi.MustParseURI("did:nuts:CuE3qeFGGLhEAS3gKzhMCeqd1dGa9at5JCbmCfyMU2Ey")
+		tc := NewTestConfig(t)
+		_ = tc.AddTrust(credentialType, issuer)
 
-		assert.True(t, tc.IsTrusted(c, d))
+		assert.True(t, tc.IsTrusted(credentialType, issuer))
 	})
 
 	t.Run("false", func(t *testing.T) {
-		d := ssi.MustParseURI("did:nuts:1")
+		tc := NewTestConfig(t)
+		_ = tc.AddTrust(credentialType, issuer)
+
+		assert.False(t, tc.IsTrusted(credentialType, ssi.MustParseURI("did:nuts:2")))
+		assert.False(t, tc.IsTrusted(vc.VerifiableCredentialTypeV1URI(), issuer))
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		future := time.Now().Add(time.Hour)
+		past := time.Now().Add(-time.Hour)
+		_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1", Expires: &future})
+		_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:2", Expires: &past})
 
-		assert.False(t, tc.IsTrusted(c, d))
+		assert.True(t, tc.IsTrusted(credentialType, issuer))
+		assert.False(t, tc.IsTrusted(credentialType, ssi.MustParseURI("did:nuts:2")))
+	})
+
+	t.Run("trust chain", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Chain: &Chain{CredentialType: "MembershipCredential", Issuer: "did:nuts:operator"}})
+		tc.SetChainResolver(func(chainType ssi.URI, chainIssuer ssi.URI, subject ssi.URI) bool {
+			return chainType.String() == "MembershipCredential" && chainIssuer.String() == "did:nuts:operator" && subject.String() == "did:nuts:member"
+		})
+
+		assert.True(t, tc.IsTrusted(credentialType, ssi.MustParseURI("did:nuts:member")))
+		assert.False(t, tc.IsTrusted(credentialType, issuer))
+	})
+
+	t.Run("trust chain - no resolver", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Chain: &Chain{CredentialType: "MembershipCredential", Issuer: "did:nuts:operator"}})
+
+		assert.False(t, tc.IsTrusted(credentialType, ssi.MustParseURI("did:nuts:member")))
 	})
 }
 
-func TestTrustConfig_List(t *testing.T) {
-	tc := NewConfig("../test/issuers.yaml")
-	d := ssi.MustParseURI("did:nuts:CuE3qeFGGLhEAS3gKzhMCeqd1dGa9at5JCbmCfyMU2Ey")
+func TestConfig_List(t *testing.T) {
+	tc := NewTestConfig(t)
 	c := ssi.MustParseURI(nutsTestCredential)
+	past := time.Now().Add(-time.Hour)
+	_ = tc.AddTrust(c, ssi.MustParseURI("did:nuts:2"))
+	_ = tc.AddTrust(c, ssi.MustParseURI("did:nuts:1"))
+	_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1", Source: "did:nuts:operator"})
+	_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:3", Expires: &past})
+	_, _ = tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Chain: &Chain{CredentialType: "MembershipCredential", Issuer: "did:nuts:operator"}})
 
-	err := tc.Load()
-	if !assert.NoError(t, err) {
-		return
-	}
 	trusted := tc.List(c)
 
-	assert.Len(t, trusted, 1)
-	assert.Equal(t, d, trusted[0])
+	assert.Equal(t, []ssi.URI{ssi.MustParseURI("did:nuts:1"), ssi.MustParseURI("did:nuts:2")}, trusted)
 }
 
 func TestConfig_AddTrust(t *testing.T) {
-	testDir := io.TestDirectory(t)
-	tc := NewConfig(path.Join(testDir, "test.yaml"))
+	tc := NewTestConfig(t)
 	issuer := ssi.MustParseURI("did:nuts:1")
 
 	t.Run("ok - already present", func(t *testing.T) {
@@ -133,12 +181,50 @@ func TestConfig_AddTrust(t *testing.T) {
 		err = tc.AddTrust(vc.VerifiableCredentialTypeV1URI(), issuer)
 
 		assert.NoError(t, err)
+		assert.Len(t, tc.Policies(), 1)
+	})
+}
+
+func TestConfig_AddPolicy(t *testing.T) {
+	t.Run("ok - updates expiry of existing policy", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		expires := time.Now().Add(time.Hour).Truncate(time.Second)
+		first, _ := tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"})
+
+		second, err := tc.AddPolicy(Policy{ID: "ignored", CredentialType: nutsTestCredential, Issuer: "did:nuts:1", Expires: &expires})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, first.ID, second.ID)
+		if assert.Len(t, tc.Policies(), 1) {
+			assert.Equal(t, expires, *tc.Policies()[0].Expires)
+		}
+	})
+
+	t.Run("error - invalid policy", func(t *testing.T) {
+		testCases := map[string]Policy{
+			"no credential type":   {Issuer: "did:nuts:1"},
+			"no issuer or chain":   {CredentialType: nutsTestCredential},
+			"both issuer or chain": {CredentialType: nutsTestCredential, Issuer: "did:nuts:1", Chain: &Chain{CredentialType: "a", Issuer: "b"}},
+			"incomplete chain":     {CredentialType: nutsTestCredential, Chain: &Chain{CredentialType: "a"}},
+			"invalid issuer":       {CredentialType: nutsTestCredential, Issuer: ":"},
+		}
+		for name, policy := range testCases {
+			t.Run(name, func(t *testing.T) {
+				tc := NewTestConfig(t)
+
+				_, err := tc.AddPolicy(policy)
+
+				assert.ErrorIs(t, err, ErrInvalidPolicy)
+				assert.Empty(t, tc.Policies())
+			})
+		}
 	})
 }
 
 func TestConfig_RemoveTrust(t *testing.T) {
-	testDir := io.TestDirectory(t)
-	tc := NewConfig(path.Join(testDir, "test.yaml"))
+	tc := NewTestConfig(t)
 	issuer := ssi.MustParseURI("did:nuts:1")
 
 	t.Run("ok - not present", func(t *testing.T) {
@@ -167,8 +253,7 @@ func TestConfig_RemoveTrust(t *testing.T) {
 	})
 
 	t.Run("ok - with multiple entries", func(t *testing.T) {
-		testDir := io.TestDirectory(t)
-		tc := NewConfig(path.Join(testDir, "test.yaml"))
+		tc := NewTestConfig(t)
 
 		issuer2 := ssi.MustParseURI("did:nuts:2")
 		issuer3 := ssi.MustParseURI("did:nuts:3")
@@ -184,4 +269,113 @@ func TestConfig_RemoveTrust(t *testing.T) {
 		}
 		assert.True(t, tc.IsTrusted(vc.VerifiableCredentialTypeV1URI(), issuer3))
 	})
+
+	t.Run("ok - removes policies from trust lists", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_ = tc.AddTrust(vc.VerifiableCredentialTypeV1URI(), issuer)
+		_, _ = tc.AddPolicy(Policy{CredentialType: vc.VerifiableCredentialTypeV1URI().String(), Issuer: issuer.String(), Source: "did:nuts:operator"})
+
+		err := tc.RemoveTrust(vc.VerifiableCredentialTypeV1URI(), issuer)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tc.Policies())
+	})
+}
+
+func TestConfig_RemovePolicy(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		policy, _ := tc.AddPolicy(Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"})
+
+		err := tc.RemovePolicy(policy.ID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tc.Policies())
+		// check it's removed from the store as well
+		_ = tc.Load()
+		assert.Empty(t, tc.Policies())
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		tc := NewTestConfig(t)
+
+		err := tc.RemovePolicy("unknown")
+
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+	})
+}
+
+func TestConfig_ReplacePolicies(t *testing.T) {
+	issuedAt := time.Now().Truncate(time.Second)
+	trustList := func(issuedAt time.Time, policies ...Policy) TrustList {
+		return TrustList{Signer: "did:nuts:operator", IssuedAt: issuedAt, Policies: policies}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_ = tc.AddTrust(ssi.MustParseURI(nutsTestCredential), ssi.MustParseURI("did:nuts:local"))
+		_, _ = tc.ReplacePolicies(trustList(issuedAt, Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"}))
+
+		replaced, err := tc.ReplacePolicies(trustList(issuedAt.Add(time.Second), Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:2"}))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if assert.Len(t, replaced, 1) {
+			assert.Equal(t, "did:nuts:operator", replaced[0].Source)
+			assert.NotEmpty(t, replaced[0].ID)
+		}
+		_ = tc.Load()
+		policies := tc.Policies()
+		assert.Len(t, policies, 2)
+		assert.Equal(t, []ssi.URI{ssi.MustParseURI("did:nuts:2"), ssi.MustParseURI("did:nuts:local")}, tc.List(ssi.MustParseURI(nutsTestCredential)))
+		for _, policy := range policies {
+			if policy.Issuer == "did:nuts:2" {
+				assert.Equal(t, "did:nuts:operator", policy.Source)
+			}
+		}
+	})
+	t.Run("ok - lists of different signers are independent", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_, _ = tc.ReplacePolicies(trustList(issuedAt, Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"}))
+		other := trustList(issuedAt.Add(-time.Hour), Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:2"})
+		other.Signer = "did:nuts:other-operator"
+
+		_, err := tc.ReplacePolicies(other)
+
+		assert.NoError(t, err)
+		assert.Len(t, tc.Policies(), 2)
+	})
+
+	t.Run("error - invalid policy", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_, _ = tc.ReplacePolicies(trustList(issuedAt, Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"}))
+
+		_, err := tc.ReplacePolicies(trustList(issuedAt.Add(time.Second), Policy{CredentialType: nutsTestCredential}))
+
+		assert.ErrorIs(t, err, ErrInvalidPolicy)
+		assert.Len(t, tc.Policies(), 1)
+	})
+	t.Run("error - same list imported again", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		list := trustList(issuedAt, Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"})
+		_, _ = tc.ReplacePolicies(list)
+
+		_, err := tc.ReplacePolicies(list)
+
+		assert.ErrorIs(t, err, ErrStaleTrustList)
+	})
+	t.Run("error - older list (after restart)", func(t *testing.T) {
+		tc := NewTestConfig(t)
+		_, _ = tc.ReplacePolicies(trustList(issuedAt, Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:2"}))
+		tc.listIssuedAt = map[string]time.Time{}
+		if !assert.NoError(t, tc.Load()) {
+			return
+		}
+
+		_, err := tc.ReplacePolicies(trustList(issuedAt.Add(-time.Hour), Policy{CredentialType: nutsTestCredential, Issuer: "did:nuts:1"}))
+
+		assert.ErrorIs(t, err, ErrStaleTrustList)
+		assert.Equal(t, []ssi.URI{ssi.MustParseURI("did:nuts:2")}, tc.List(ssi.MustParseURI(nutsTestCredential)))
+	})
 }
@@ -0,0 +1,115 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package trust
+
+import (
+	crypto2 "crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/crypto"
+)
+
+// ErrInvalidTrustList is returned when a trust list can't be parsed or its signature is invalid.
+var ErrInvalidTrustList = errors.New("invalid trust list")
+
+// ErrUnknownTrustListSigner is returned when a trust list is imported that isn't signed by one of the configured trust list signers.
+var ErrUnknownTrustListSigner = errors.New("trust list signer is not configured as trusted")
+
+// ErrStaleTrustList is returned when a trust list is imported that isn't newer than the last imported trust list of its signer.
+var ErrStaleTrustList = errors.New("trust list is not newer than the previously imported trust list")
+
+const policiesClaim = "policies"
+
+// TrustList is a set of trust policies, distributed as a JWT signed by the party that maintains the list (e.g. a care network operator).
+// The JWT contains the DID of the signer as 'iss' claim and the policies as 'policies' claim.
+type TrustList struct {
+	// Signer is the DID of the party that signed the trust list.
+	Signer string
+	// IssuedAt is the moment the trust list was signed.
+	IssuedAt time.Time
+	// Policies contains the trust policies of the list.
+	Policies []Policy
+}
+
+// ParseTrustList parses the given JWT and verifies its signature using the given key resolver.
+// The JWT must be signed with a key of the DID in its 'iss' claim.
+func ParseTrustList(token string, resolveKey func(kid string) (crypto2.PublicKey, error)) (*TrustList, error) {
+	var kid string
+	parsed, err := crypto.ParseJWT(token, func(keyID string) (crypto2.PublicKey, error) {
+		kid = keyID
+		return resolveKey(keyID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTrustList, err)
+	}
+	keyID, err := did.ParseDIDURL(kid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid key ID: %s", ErrInvalidTrustList, err)
+	}
+	keyID.Fragment = ""
+	if parsed.Issuer() == "" || keyID.String() != parsed.Issuer() {
+		return nil, fmt.Errorf("%w: must be signed with a key of its issuer", ErrInvalidTrustList)
+	}
+	if parsed.IssuedAt().IsZero() {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidTrustList, jwt.IssuedAtKey)
+	}
+
+	result := TrustList{Signer: parsed.Issuer(), IssuedAt: parsed.IssuedAt(), Policies: []Policy{}}
+	if claim, ok := parsed.Get(policiesClaim); ok {
+		// claim is decoded as generic JSON, re-encode it to parse it into policies
+		data, _ := json.Marshal(claim)
+		if err = json.Unmarshal(data, &result.Policies); err != nil {
+			return nil, fmt.Errorf("%w: invalid policies: %s", ErrInvalidTrustList, err)
+		}
+	}
+	for _, policy := range result.Policies {
+		if err = policy.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustList, err)
+		}
+	}
+	return &result, nil
+}
+
+// CreateTrustList creates a trust list JWT containing the given policies, signed with the given key.
+// The DID of the key is used as signer.
+func CreateTrustList(policies []Policy, key crypto.Key) (string, error) {
+	keyID, err := did.ParseDIDURL(key.KID())
+	if err != nil {
+		return "", fmt.Errorf("invalid key ID: %w", err)
+	}
+	keyID.Fragment = ""
+	listPolicies := make([]Policy, len(policies))
+	for i, policy := range policies {
+		// ID and source are assigned on import
+		policy.ID = ""
+		policy.Source = ""
+		listPolicies[i] = policy
+	}
+	claims := map[string]interface{}{
+		jwt.IssuerKey:   keyID.String(),
+		jwt.IssuedAtKey: time.Now(),
+		policiesClaim:   listPolicies,
+	}
+	return crypto.SignJWTWithSigner(key.Signer(), claims, map[string]interface{}{"kid": key.KID()})
+}
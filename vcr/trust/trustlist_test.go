@@ -0,0 +1,110 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package trust
+
+import (
+	crypto2 "crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrustList(t *testing.T) {
+	signerKey := crypto.NewTestKey("did:nuts:operator#key-1")
+	resolveKey := func(kid string) (crypto2.PublicKey, error) {
+		if kid != signerKey.KID() {
+			return nil, errors.New("key not found")
+		}
+		return signerKey.Public(), nil
+	}
+	expires := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	policies := []Policy{
+		{ID: "ignored", CredentialType: nutsTestCredential, Issuer: "did:nuts:1", Expires: &expires},
+		{CredentialType: nutsTestCredential, Chain: &Chain{CredentialType: "MembershipCredential", Issuer: "did:nuts:operator"}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		token, err := CreateTrustList(policies, signerKey)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		list, err := ParseTrustList(token, resolveKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "did:nuts:operator", list.Signer)
+		assert.False(t, list.IssuedAt.IsZero())
+		if assert.Len(t, list.Policies, 2) {
+			assert.Empty(t, list.Policies[0].ID)
+			assert.Equal(t, "did:nuts:1", list.Policies[0].Issuer)
+			assert.Equal(t, expires, list.Policies[0].Expires.UTC())
+			assert.Equal(t, *policies[1].Chain, *list.Policies[1].Chain)
+		}
+	})
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		token, _ := CreateTrustList(policies, crypto.NewTestKey("did:nuts:operator#key-2"))
+
+		_, err := ParseTrustList(token, resolveKey)
+
+		assert.ErrorIs(t, err, ErrInvalidTrustList)
+		assert.ErrorContains(t, err, "key not found")
+	})
+
+	t.Run("error - signed by other party than issuer", func(t *testing.T) {
+		attackerKey := crypto.NewTestKey("did:nuts:attacker#key-1")
+		claims := map[string]interface{}{"iss": "did:nuts:operator", policiesClaim: policies}
+		token, _ := crypto.SignJWTWithSigner(attackerKey.Signer(), claims, map[string]interface{}{"kid": attackerKey.KID()})
+
+		_, err := ParseTrustList(token, func(kid string) (crypto2.PublicKey, error) {
+			return attackerKey.Public(), nil
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidTrustList)
+		assert.ErrorContains(t, err, "must be signed with a key of its issuer")
+	})
+
+	t.Run("error - invalid policy", func(t *testing.T) {
+		token, _ := CreateTrustList([]Policy{{CredentialType: nutsTestCredential}}, signerKey)
+
+		_, err := ParseTrustList(token, resolveKey)
+
+		assert.ErrorIs(t, err, ErrInvalidTrustList)
+		assert.ErrorContains(t, err, "exactly one of issuer or chain must be given")
+	})
+
+	t.Run("error - missing iat", func(t *testing.T) {
+		token, _ := crypto.SignJWTWithSigner(signerKey.Signer(), map[string]interface{}{jwt.IssuerKey: "did:nuts:operator"}, map[string]interface{}{"kid": signerKey.KID()})
+
+		_, err := ParseTrustList(token, resolveKey)
+
+		assert.EqualError(t, err, "invalid trust list: missing iat claim")
+	})
+	t.Run("error - not a JWT", func(t *testing.T) {
+		_, err := ParseTrustList("not a JWT", resolveKey)
+
+		assert.ErrorIs(t, err, ErrInvalidTrustList)
+	})
+}
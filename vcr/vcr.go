@@ -21,6 +21,7 @@ package vcr
 
 import (
 	"context"
+	crypto2 "crypto"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -45,6 +46,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/vcr/assets"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -86,6 +88,7 @@ type vcr struct {
 	ambassador      Ambassador
 	network         network.Transactions
 	trustConfig     *trust.Config
+	validators      *credential.ValidatorRegistry
 	issuer          issuer.Issuer
 	verifier        verifier.Verifier
 	holder          holder.Holder
@@ -94,6 +97,7 @@ type vcr struct {
 	jsonldManager   jsonld.JSONLD
 	eventManager    events.Event
 	storageClient   storage.Engine
+	expiryNotifier  *expiryNotifier
 }
 
 func (c vcr) Issuer() issuer.Issuer {
@@ -131,19 +135,46 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 		return err
 	}
 
-	// create trust config
-	tcPath := path.Join(config.Datadir, "vcr", "trusted_issuers.yaml")
-	c.trustConfig = trust.NewConfig(tcPath)
+	c.validators = credential.NewValidatorRegistry()
+	if err = c.registerCredentialValidators(); err != nil {
+		return err
+	}
+
+	// create trust config, backed by the storage engine
+	trustStore, err := c.storageClient.GetProvider(moduleName).GetKVStore("trust", storage.PersistentStorageClass)
+	if err != nil {
+		return err
+	}
+	c.trustConfig = trust.NewConfig(trustStore)
+	c.trustConfig.SetChainResolver(c.holdsCredential)
 
 	publisher := issuer.NewNetworkPublisher(c.network, c.docResolver, c.keyStore)
-	c.issuer = issuer.NewIssuer(c.issuerStore, publisher, c.docResolver, c.keyStore, c.jsonldManager, c.trustConfig)
-	c.verifier = verifier.NewVerifier(c.verifierStore, c.docResolver, c.keyResolver, c.jsonldManager, c.trustConfig)
+	c.issuer = issuer.NewIssuer(c.issuerStore, publisher, c.docResolver, c.keyStore, c.jsonldManager, c.trustConfig, c.validators)
+	c.verifier = verifier.NewVerifier(c.verifierStore, c.docResolver, c.keyResolver, c.jsonldManager, c.trustConfig, c.eventManager, c.validators)
 
 	c.ambassador = NewAmbassador(c.network, c, c.verifier, c.eventManager)
 
 	c.holder = holder.New(c.keyResolver, c.keyStore, c.verifier, c.jsonldManager)
 
-	return c.trustConfig.Load()
+	if err = c.trustConfig.Load(); err != nil {
+		return err
+	}
+	// previous versions stored trusted issuers in a YAML file, import it into the store
+	return c.trustConfig.Migrate(path.Join(config.Datadir, "vcr", "trusted_issuers.yaml"))
+}
+
+// registerCredentialValidators registers a JSON Schema validator for every configured custom credential type.
+func (c *vcr) registerCredentialValidators() error {
+	for credentialType, schemaFile := range c.config.CredentialSchemas {
+		validator, err := credential.NewJSONSchemaValidator(credentialType, schemaFile)
+		if err != nil {
+			return fmt.Errorf("unable to load JSON schema for credential type %s: %w", credentialType, err)
+		}
+		if err = c.validators.Register(credentialType, validator); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *vcr) credentialsDBPath() string {
@@ -166,10 +197,21 @@ func (c *vcr) Start() error {
 	// start listening for new credentials
 	c.ambassador.Configure()
 
-	return c.ambassador.Start()
+	if err = c.ambassador.Start(); err != nil {
+		return err
+	}
+
+	if c.config.ExpiryNotificationPeriod > 0 {
+		c.expiryNotifier = newExpiryNotifier(c.issuerStore, c.eventManager, c.config.ExpiryNotificationPeriod)
+		c.expiryNotifier.start()
+	}
+	return nil
 }
 
 func (c *vcr) Shutdown() error {
+	if c.expiryNotifier != nil {
+		c.expiryNotifier.stop()
+	}
 	err := c.issuerStore.Close()
 	if err != nil {
 		log.Logger().
@@ -327,24 +369,142 @@ func (c *vcr) find(ID ssi.URI) (vc.VerifiableCredential, error) {
 
 func (c *vcr) Trust(credentialType ssi.URI, issuer ssi.URI) error {
 	err := c.trustConfig.AddTrust(credentialType, issuer)
+	if err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialType, credentialType).
+		WithField(core.LogFieldCredentialIssuer, issuer).
+		Info("Added trust for Verifiable Credential issuer")
+	c.publishTrustChanged(credentialType, issuer, true)
+	return nil
+}
+
+func (c *vcr) Untrust(credentialType ssi.URI, issuer ssi.URI) error {
+	err := c.trustConfig.RemoveTrust(credentialType, issuer)
+	if err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialType, credentialType).
+		WithField(core.LogFieldCredentialIssuer, issuer).
+		Info("Untrusted for Verifiable Credential issuer")
+	c.publishTrustChanged(credentialType, issuer, false)
+	return nil
+}
+
+// publishTrustChanged publishes a TrustChangedEvent. The trust change has already been persisted, so failure is only logged.
+func (c *vcr) publishTrustChanged(credentialType ssi.URI, issuer ssi.URI, trusted bool) {
+	err := c.eventManager.Publish(events.CredentialsStream, events.TrustChangedSubject, events.TrustChangedEvent{
+		CredentialType: credentialType.String(),
+		Issuer:         issuer.String(),
+		Trusted:        trusted,
+	})
 	if err != nil {
 		log.Logger().
+			WithError(err).
 			WithField(core.LogFieldCredentialType, credentialType).
 			WithField(core.LogFieldCredentialIssuer, issuer).
-			Info("Added trust for Verifiable Credential issuer")
+			Warn("Failed to publish trust changed event")
 	}
-	return err
 }
 
-func (c *vcr) Untrust(credentialType ssi.URI, issuer ssi.URI) error {
-	err := c.trustConfig.RemoveTrust(credentialType, issuer)
+func (c *vcr) TrustPolicies() []trust.Policy {
+	return c.trustConfig.Policies()
+}
+
+func (c *vcr) AddTrustPolicy(policy trust.Policy) (*trust.Policy, error) {
+	added, err := c.trustConfig.AddPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialType, added.CredentialType).
+		WithField("policyID", added.ID).
+		Info("Added trust policy")
+	if issuer, err := ssi.ParseURI(added.Issuer); err == nil && added.Issuer != "" {
+		c.publishTrustChanged(ssi.MustParseURI(added.CredentialType), *issuer, true)
+	}
+	return added, nil
+}
+
+func (c *vcr) RemoveTrustPolicy(id string) error {
+	var removed *trust.Policy
+	for _, policy := range c.trustConfig.Policies() {
+		if policy.ID == id {
+			removed = &policy
+			break
+		}
+	}
+	if err := c.trustConfig.RemovePolicy(id); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField("policyID", id).
+		Info("Removed trust policy")
+	if removed != nil && removed.Issuer != "" {
+		if issuer, err := ssi.ParseURI(removed.Issuer); err == nil {
+			c.publishTrustChanged(ssi.MustParseURI(removed.CredentialType), *issuer, false)
+		}
+	}
+	return nil
+}
+
+func (c *vcr) ImportTrustList(token string) ([]trust.Policy, error) {
+	list, err := trust.ParseTrustList(token, func(kid string) (crypto2.PublicKey, error) {
+		return c.keyResolver.ResolveSigningKey(kid, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !c.isTrustListSigner(list.Signer) {
+		return nil, fmt.Errorf("%w: %s", trust.ErrUnknownTrustListSigner, list.Signer)
+	}
+	policies, err := c.trustConfig.ReplacePolicies(*list)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().
+		WithField("signer", list.Signer).
+		Infof("Imported trust list with %d policies", len(policies))
+	return policies, nil
+}
+
+func (c *vcr) isTrustListSigner(signer string) bool {
+	for _, curr := range c.config.TrustListSigners {
+		if curr == signer {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsCredential checks whether a valid credential of the given type, issued by the given issuer to the given subject is stored.
+// It's used to evaluate trust chain policies: trust in the issuer of the credential is implied by the policy, so it isn't checked.
+func (c *vcr) holdsCredential(credentialType ssi.URI, issuer ssi.URI, subject ssi.URI) bool {
+	found := false
+	err := c.iterateCredentials(context.Background(), func(_ leia.Reference, doc []byte) {
+		if found {
+			return
+		}
+		held := vc.VerifiableCredential{}
+		if json.Unmarshal(doc, &held) != nil {
+			return
+		}
+		if !held.IsType(credentialType) || held.Issuer.String() != issuer.String() {
+			return
+		}
+		found = c.verifier.Verify(held, true, false, nil) == nil
+	}, SearchTerms{{IRIPath: jsonld.CredentialSubjectPath, Value: subject.String(), Type: Exact}})
 	if err != nil {
 		log.Logger().
+			WithError(err).
 			WithField(core.LogFieldCredentialType, credentialType).
 			WithField(core.LogFieldCredentialIssuer, issuer).
-			Info("Untrusted for Verifiable Credential issuer")
+			Warn("Unable to evaluate trust chain policy")
+		return false
 	}
-	return err
+	return found
 }
 
 func (c *vcr) Trusted(credentialType ssi.URI) ([]ssi.URI, error) {
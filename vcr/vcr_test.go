@@ -21,6 +21,7 @@ package vcr
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"os"
 	"path"
@@ -30,6 +31,8 @@ import (
 
 	"github.com/nuts-foundation/nuts-node/events"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/trust"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"go.etcd.io/bbolt"
 
@@ -39,6 +42,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/go-leia/v3"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
@@ -111,6 +115,53 @@ func TestVCR_Start(t *testing.T) {
 	})
 }
 
+func TestVCR_Configure(t *testing.T) {
+	t.Run("registers validators for credential schemas", func(t *testing.T) {
+		testDirectory := io.TestDirectory(t)
+		schemaFile := path.Join(testDirectory, "schema.json")
+		_ = os.WriteFile(schemaFile, []byte(`{"required": ["credentialSubject"], "properties": {"credentialSubject": {"required": ["patient"]}}}`), os.ModePerm)
+		instance := NewVCRInstance(nil, nil, nil, nil, jsonld.NewTestJSONLDManager(t), nil, storage.NewTestStorageEngine(testDirectory)).(*vcr)
+		instance.config.CredentialSchemas = map[string]string{"ConfiguredSchemaCredential": schemaFile}
+
+		err := instance.Configure(core.TestServerConfig(core.ServerConfig{Datadir: testDirectory}))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		testVC := jsonld.TestVC()
+		testVC.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("ConfiguredSchemaCredential")}
+		validator, _ := instance.validators.FindValidatorAndBuilder(testVC)
+		err = validator.Validate(testVC)
+		assert.ErrorIs(t, err, credential.ErrValidation)
+		assert.Contains(t, err.Error(), "missing properties: 'patient'")
+	})
+	t.Run("migrates trusted issuers file", func(t *testing.T) {
+		testDirectory := io.TestDirectory(t)
+		_ = os.MkdirAll(path.Join(testDirectory, "vcr"), os.ModePerm)
+		data, _ := os.ReadFile("test/issuers.yaml")
+		_ = os.WriteFile(path.Join(testDirectory, "vcr", "trusted_issuers.yaml"), data, os.ModePerm)
+		instance := NewVCRInstance(nil, nil, nil, nil, jsonld.NewTestJSONLDManager(t), nil, storage.NewTestStorageEngine(testDirectory)).(*vcr)
+
+		err := instance.Configure(core.TestServerConfig(core.ServerConfig{Datadir: testDirectory}))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		trusted, _ := instance.Trusted(ssi.MustParseURI("NutsOrganizationCredential"))
+		assert.Equal(t, []ssi.URI{ssi.MustParseURI("did:nuts:CuE3qeFGGLhEAS3gKzhMCeqd1dGa9at5JCbmCfyMU2Ey")}, trusted)
+		assert.NoFileExists(t, path.Join(testDirectory, "vcr", "trusted_issuers.yaml"))
+	})
+	t.Run("error - invalid credential schema", func(t *testing.T) {
+		testDirectory := io.TestDirectory(t)
+		instance := NewVCRInstance(nil, nil, nil, nil, jsonld.NewTestJSONLDManager(t), nil, storage.NewTestStorageEngine(testDirectory)).(*vcr)
+		instance.config.CredentialSchemas = map[string]string{"ConfiguredSchemaCredential": path.Join(testDirectory, "non-existing.json")}
+
+		err := instance.Configure(core.TestServerConfig(core.ServerConfig{Datadir: testDirectory}))
+
+		assert.ErrorContains(t, err, "unable to load JSON schema for credential type ConfiguredSchemaCredential")
+	})
+}
+
 func TestVCR_Resolve(t *testing.T) {
 
 	testInstance := func(t2 *testing.T) mockContext {
@@ -265,6 +316,164 @@ func confirmUntrustedStatus(t *testing.T, fn func(issuer ssi.URI) ([]ssi.URI, er
 	assert.Len(t, trusted, numUntrusted)
 }
 
+func TestVcr_Trust(t *testing.T) {
+	credentialType := ssi.MustParseURI("NutsOrganizationCredential")
+	issuer := ssi.MustParseURI("did:nuts:issuer")
+
+	t.Run("publishes trust changes", func(t *testing.T) {
+		instance := NewTestVCRInstance(t)
+		eventManager := events.NewMockEvent(gomock.NewController(t))
+		instance.eventManager = eventManager
+		gomock.InOrder(
+			eventManager.EXPECT().Publish(events.CredentialsStream, events.TrustChangedSubject, events.TrustChangedEvent{
+				CredentialType: credentialType.String(),
+				Issuer:         issuer.String(),
+				Trusted:        true,
+			}),
+			eventManager.EXPECT().Publish(events.CredentialsStream, events.TrustChangedSubject, events.TrustChangedEvent{
+				CredentialType: credentialType.String(),
+				Issuer:         issuer.String(),
+				Trusted:        false,
+			}),
+		)
+
+		assert.NoError(t, instance.Trust(credentialType, issuer))
+		assert.NoError(t, instance.Untrust(credentialType, issuer))
+	})
+	t.Run("failing to publish doesn't fail trust change", func(t *testing.T) {
+		instance := NewTestVCRInstance(t)
+		eventManager := events.NewMockEvent(gomock.NewController(t))
+		instance.eventManager = eventManager
+		eventManager.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("b00m!"))
+
+		err := instance.Trust(credentialType, issuer)
+
+		assert.NoError(t, err)
+		assert.True(t, instance.trustConfig.IsTrusted(credentialType, issuer))
+	})
+}
+
+func TestVcr_TrustPolicies(t *testing.T) {
+	credentialType := ssi.MustParseURI("NutsOrganizationCredential")
+
+	t.Run("add and remove policy with expiry", func(t *testing.T) {
+		instance := NewTestVCRInstance(t)
+		expires := time.Now().Add(time.Hour)
+
+		policy, err := instance.AddTrustPolicy(trust.Policy{CredentialType: credentialType.String(), Issuer: "did:nuts:issuer", Expires: &expires})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NotEmpty(t, policy.ID)
+		assert.Len(t, instance.TrustPolicies(), 1)
+		assert.True(t, instance.trustConfig.IsTrusted(credentialType, ssi.MustParseURI("did:nuts:issuer")))
+
+		err = instance.RemoveTrustPolicy(policy.ID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, instance.TrustPolicies())
+	})
+	t.Run("error - invalid policy", func(t *testing.T) {
+		instance := NewTestVCRInstance(t)
+
+		_, err := instance.AddTrustPolicy(trust.Policy{CredentialType: credentialType.String()})
+
+		assert.ErrorIs(t, err, trust.ErrInvalidPolicy)
+	})
+	t.Run("error - remove unknown policy", func(t *testing.T) {
+		instance := NewTestVCRInstance(t)
+
+		err := instance.RemoveTrustPolicy("unknown")
+
+		assert.ErrorIs(t, err, trust.ErrPolicyNotFound)
+	})
+	t.Run("trust chain", func(t *testing.T) {
+		ctx := newMockContext(t)
+		_ = ctx.vcr.credentialCollection().Add([]leia.Document{[]byte(jsonld.TestOrganizationCredential)})
+		heldVC := vc.VerifiableCredential{}
+		_ = json.Unmarshal([]byte(jsonld.TestOrganizationCredential), &heldVC)
+		holder := ssi.MustParseURI("did:nuts:B8PUHs2AUHbFF1xLLK4eZjgErEcMXHxs68FteY7NDtCY")
+		trustedType := ssi.MustParseURI("CareRelationshipCredential")
+		_, err := ctx.vcr.AddTrustPolicy(trust.Policy{
+			CredentialType: trustedType.String(),
+			Chain:          &trust.Chain{CredentialType: credentialType.String(), Issuer: heldVC.Issuer.String()},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		t.Run("ok - holds credential", func(t *testing.T) {
+			assert.True(t, ctx.vcr.trustConfig.IsTrusted(trustedType, holder))
+		})
+		t.Run("not trusted - credential issued to other subject", func(t *testing.T) {
+			assert.False(t, ctx.vcr.trustConfig.IsTrusted(trustedType, heldVC.Issuer))
+		})
+		t.Run("not trusted - credential revoked", func(t *testing.T) {
+			mockVerifier := verifier.NewMockVerifier(ctx.ctrl)
+			ctx.vcr.verifier = mockVerifier
+			mockVerifier.EXPECT().Verify(heldVC, true, false, nil).Return(vcrTypes.ErrRevoked)
+
+			assert.False(t, ctx.vcr.trustConfig.IsTrusted(trustedType, holder))
+		})
+	})
+}
+
+func TestVcr_ImportTrustList(t *testing.T) {
+	signerKey := crypto.NewTestKey("did:nuts:operator#key-1")
+	policies := []trust.Policy{{CredentialType: "NutsOrganizationCredential", Issuer: "did:nuts:issuer"}}
+	token, _ := trust.CreateTrustList(policies, signerKey)
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vcr.config.TrustListSigners = []string{"did:nuts:operator"}
+		ctx.keyResolver.EXPECT().ResolveSigningKey(signerKey.KID(), nil).Return(signerKey.Public(), nil)
+
+		imported, err := ctx.vcr.ImportTrustList(token)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if assert.Len(t, imported, 1) {
+			assert.Equal(t, "did:nuts:operator", imported[0].Source)
+		}
+		assert.True(t, ctx.vcr.trustConfig.IsTrusted(ssi.MustParseURI("NutsOrganizationCredential"), ssi.MustParseURI("did:nuts:issuer")))
+	})
+	t.Run("error - signer not configured", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyResolver.EXPECT().ResolveSigningKey(signerKey.KID(), nil).Return(signerKey.Public(), nil)
+
+		_, err := ctx.vcr.ImportTrustList(token)
+
+		assert.ErrorIs(t, err, trust.ErrUnknownTrustListSigner)
+		assert.Empty(t, ctx.vcr.TrustPolicies())
+	})
+	t.Run("error - invalid signature", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vcr.config.TrustListSigners = []string{"did:nuts:operator"}
+		ctx.keyResolver.EXPECT().ResolveSigningKey(signerKey.KID(), nil).Return(crypto.NewTestKey("did:nuts:operator#key-2").Public(), nil)
+
+		_, err := ctx.vcr.ImportTrustList(token)
+
+		assert.ErrorIs(t, err, trust.ErrInvalidTrustList)
+	})
+	t.Run("error - replayed", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vcr.config.TrustListSigners = []string{"did:nuts:operator"}
+		ctx.keyResolver.EXPECT().ResolveSigningKey(signerKey.KID(), nil).Return(signerKey.Public(), nil).Times(2)
+		_, err := ctx.vcr.ImportTrustList(token)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_ = ctx.vcr.RemoveTrustPolicy(ctx.vcr.TrustPolicies()[0].ID)
+
+		_, err = ctx.vcr.ImportTrustList(token)
+
+		assert.ErrorIs(t, err, trust.ErrStaleTrustList)
+		assert.Empty(t, ctx.vcr.TrustPolicies())
+	})
+}
+
 func confirmTrustedStatus(t *testing.T, trustManager TrustManager, issuer ssi.URI, fn func(issuer ssi.URI) ([]ssi.URI, error), numTrusted int) {
 	trustManager.Trust(ssi.MustParseURI("NutsOrganizationCredential"), issuer)
 	defer func() {
@@ -26,6 +26,7 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 )
 
 // Verifier defines the interface for verifying verifiable credentials.
@@ -49,6 +50,10 @@ type Verifier interface {
 	// VerifyVP verifies the given Verifiable Presentation. If successful, it returns the credentials within the presentation.
 	// If verifyVCs is true, it will also verify the credentials inside the VP, checking their correctness, signature and trust status.
 	VerifyVP(presentation vc.VerifiablePresentation, verifyVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error)
+	// VerifySDJWTPresentation verifies the given SD-JWT VC presentation, which must contain a Key Binding JWT signed by the credential's subject.
+	// The Key Binding JWT must be issued for the given audience and nonce, and must not be older than sdjwt.KeyBindingMaxAge.
+	// If successful, it returns the credential containing the disclosed claims.
+	VerifySDJWTPresentation(presentation sdjwt.SDJWT, audience string, nonce string, validAt *time.Time) ([]vc.VerifiableCredential, error)
 }
 
 // ErrNotFound is returned when a credential or revocation can not be found based on its ID.
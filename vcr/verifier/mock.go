@@ -12,6 +12,7 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	vc "github.com/nuts-foundation/go-did/vc"
 	credential "github.com/nuts-foundation/nuts-node/vcr/credential"
+	sdjwt "github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 )
 
 // MockVerifier is a mock of Verifier interface.
@@ -109,6 +110,21 @@ func (mr *MockVerifierMockRecorder) Verify(credential, allowUntrusted, checkSign
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockVerifier)(nil).Verify), credential, allowUntrusted, checkSignature, validAt)
 }
 
+// VerifySDJWTPresentation mocks base method.
+func (m *MockVerifier) VerifySDJWTPresentation(presentation sdjwt.SDJWT, audience, nonce string, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySDJWTPresentation", presentation, audience, nonce, validAt)
+	ret0, _ := ret[0].([]vc.VerifiableCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySDJWTPresentation indicates an expected call of VerifySDJWTPresentation.
+func (mr *MockVerifierMockRecorder) VerifySDJWTPresentation(presentation, audience, nonce, validAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySDJWTPresentation", reflect.TypeOf((*MockVerifier)(nil).VerifySDJWTPresentation), presentation, audience, nonce, validAt)
+}
+
 // VerifyVP mocks base method.
 func (m *MockVerifier) VerifyVP(presentation vc.VerifiablePresentation, verifyVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
 	m.ctrl.T.Helper()
@@ -19,6 +19,7 @@
 package verifier
 
 import (
+	"crypto"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,8 +29,13 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/events"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -52,6 +58,8 @@ type verifier struct {
 	jsonldManager jsonld.JSONLD
 	store         Store
 	trustConfig   *trust.Config
+	eventManager  events.Event
+	validators    *credential.ValidatorRegistry
 }
 
 // VerificationError is used to describe a VC/VP verification failure.
@@ -79,8 +87,10 @@ func (e VerificationError) Error() string {
 }
 
 // NewVerifier creates a new instance of the verifier. It needs a key resolver for validating signatures.
-func NewVerifier(store Store, docResolver vdr.DocResolver, keyResolver vdr.KeyResolver, jsonldManager jsonld.JSONLD, trustConfig *trust.Config) Verifier {
-	return &verifier{store: store, docResolver: docResolver, keyResolver: keyResolver, jsonldManager: jsonldManager, trustConfig: trustConfig}
+// Registered revocations are published on the events.CredentialRevokedSubject of the given event manager.
+// The validators registry contains the validators for custom credential types, it may be nil.
+func NewVerifier(store Store, docResolver vdr.DocResolver, keyResolver vdr.KeyResolver, jsonldManager jsonld.JSONLD, trustConfig *trust.Config, eventManager events.Event, validators *credential.ValidatorRegistry) Verifier {
+	return &verifier{store: store, docResolver: docResolver, keyResolver: keyResolver, jsonldManager: jsonldManager, trustConfig: trustConfig, eventManager: eventManager, validators: validators}
 }
 
 // validateAtTime is a helper method which checks if a credentia/presentation is valid at a certain given time.
@@ -112,6 +122,10 @@ func (v *verifier) Validate(credentialToVerify vc.VerifiableCredential, at *time
 		return err
 	}
 
+	if _, isJWT := jwtvc.ExtractJWT(credentialToVerify.Proof); isJWT {
+		return jwtvc.VerifyCredential(credentialToVerify, v.signingKeyResolver(at), v.validationTime(at))
+	}
+
 	signedDocument, err := proof.NewSignedDocument(credentialToVerify)
 	if err != nil {
 		return fmt.Errorf("unable to build signed document from verifiable credential: %w", err)
@@ -145,7 +159,7 @@ func (v *verifier) Validate(credentialToVerify vc.VerifiableCredential, at *time
 // It currently checks if the credential has the required fields and values, if it is valid at the given time and optional the signature.
 func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error {
 	// it must have valid content
-	validator, _ := credential.FindValidatorAndBuilder(credentialToVerify)
+	validator, _ := v.validators.FindValidatorAndBuilder(credentialToVerify)
 	if err := validator.Validate(credentialToVerify); err != nil {
 		return err
 	}
@@ -161,14 +175,8 @@ func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrus
 
 	// Check trust status
 	if !allowUntrusted {
-		for _, t := range credentialToVerify.Type {
-			// Don't need to check type "VerifiableCredential"
-			if t.String() == verifiableCredentialType {
-				continue
-			}
-			if !v.trustConfig.IsTrusted(t, credentialToVerify.Issuer) {
-				return types.ErrUntrusted
-			}
+		if err := v.validateTrust(credentialToVerify); err != nil {
+			return err
 		}
 	}
 
@@ -191,6 +199,20 @@ func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrus
 	return nil
 }
 
+// validateTrust checks whether the issuer of the credential is trusted for all of its types.
+func (v verifier) validateTrust(credentialToVerify vc.VerifiableCredential) error {
+	for _, t := range credentialToVerify.Type {
+		// Don't need to check type "VerifiableCredential"
+		if t.String() == verifiableCredentialType {
+			continue
+		}
+		if !v.trustConfig.IsTrusted(t, credentialToVerify.Issuer) {
+			return types.ErrUntrusted
+		}
+	}
+	return nil
+}
+
 func (v *verifier) IsRevoked(credentialID ssi.URI) (bool, error) {
 	_, err := v.store.GetRevocations(credentialID)
 	if err != nil {
@@ -257,6 +279,19 @@ func (v *verifier) RegisterRevocation(revocation credential.Revocation) error {
 	if err := v.store.StoreRevocation(revocation); err != nil {
 		return fmt.Errorf("unable to store revocation: %w", err)
 	}
+
+	// The revocation has been stored, so failing to notify about it shouldn't fail its registration.
+	if err := v.eventManager.Publish(events.CredentialsStream, events.CredentialRevokedSubject, events.CredentialRevokedEvent{
+		CredentialID: revocation.Subject.String(),
+		Issuer:       revocation.Issuer.String(),
+		Reason:       revocation.Reason,
+		Date:         revocation.Date,
+	}); err != nil {
+		log.Logger().
+			WithError(err).
+			WithField(core.LogFieldCredentialID, revocation.Subject).
+			Warn("Failed to publish credential revocation event")
+	}
 	return nil
 }
 
@@ -270,44 +305,92 @@ func (v verifier) doVerifyVP(vcVerifier Verifier, vp vc.VerifiablePresentation,
 	if len(vp.Proof) != 1 {
 		return nil, newVerificationError("exactly 1 proof is expected")
 	}
+	if _, isJWT := jwtvc.ExtractJWT(vp.Proof); isJWT {
+		// JWT encoded presentation: signature and signing time are validated by the JWT
+		if err := jwtvc.VerifyPresentation(vp, v.signingKeyResolver(validAt), v.validationTime(validAt)); err != nil {
+			return nil, newVerificationError("%w", err)
+		}
+	} else if err := v.verifyLDProof(vp, validAt); err != nil {
+		return nil, err
+	}
+
+	if verifyVCs {
+		for _, current := range vp.VerifiableCredential {
+			err := vcVerifier.Verify(current, false, true, validAt)
+			if err != nil {
+				return nil, newVerificationError("invalid VC (id=%s): %w", current.ID, err)
+			}
+		}
+	}
+
+	return vp.VerifiableCredential, nil
+}
+
+// verifyLDProof verifies the Linked Data proof of the given presentation.
+func (v verifier) verifyLDProof(vp vc.VerifiablePresentation, validAt *time.Time) error {
 	// Make sure the proofs are LD-proofs
 	var ldProofs []proof.LDProof
 	err := vp.UnmarshalProofValue(&ldProofs)
 	if err != nil {
-		return nil, newVerificationError("unsupported proof type: %w", err)
+		return newVerificationError("unsupported proof type: %w", err)
 	}
 	ldProof := ldProofs[0]
 
 	// Validate signing time
 	err = v.validateAtTime(ldProof.Created, ldProof.Expires, validAt)
 	if err != nil {
-		return nil, toVerificationError(err)
+		return toVerificationError(err)
 	}
 
 	// Validate signature
 	signingKey, err := v.keyResolver.ResolveSigningKey(ldProof.VerificationMethod.String(), validAt)
 	if err != nil {
-		return nil, fmt.Errorf("unable to resolve valid signing key: %w", err)
+		return fmt.Errorf("unable to resolve valid signing key: %w", err)
 	}
 	signedDocument, err := proof.NewSignedDocument(vp)
 	if err != nil {
-		return nil, newVerificationError("invalid LD-JSON document: %w", err)
+		return newVerificationError("invalid LD-JSON document: %w", err)
 	}
 	err = ldProof.Verify(signedDocument.DocumentWithoutProof(), signature.JSONWebSignature2020{ContextLoader: v.jsonldManager.DocumentLoader()}, signingKey)
 	if err != nil {
-		return nil, newVerificationError("invalid signature: %w", err)
+		return newVerificationError("invalid signature: %w", err)
 	}
+	return nil
+}
 
-	if verifyVCs {
-		for _, current := range vp.VerifiableCredential {
-			err := vcVerifier.Verify(current, false, true, validAt)
-			if err != nil {
-				return nil, newVerificationError("invalid VC (id=%s): %w", current.ID, err)
-			}
-		}
+// signingKeyResolver returns a function which resolves signing keys (valid at the given time) of JWT encoded credentials and presentations.
+func (v verifier) signingKeyResolver(validAt *time.Time) func(kid string) (crypto.PublicKey, error) {
+	return func(kid string) (crypto.PublicKey, error) {
+		return v.keyResolver.ResolveSigningKey(kid, validAt)
 	}
+}
 
-	return vp.VerifiableCredential, nil
+// validationTime returns the given time, or the current time if not set.
+func (v verifier) validationTime(validAt *time.Time) time.Time {
+	if validAt != nil {
+		return *validAt
+	}
+	return timeFunc()
+}
+
+func (v verifier) VerifySDJWTPresentation(presentation sdjwt.SDJWT, audience string, nonce string, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	keyBinding := &sdjwt.KeyBindingRequirements{Audience: audience, Nonce: nonce}
+	verifiedCredential, err := sdjwt.Verify(presentation, v.signingKeyResolver(validAt), v.validationTime(validAt), keyBinding)
+	if err != nil {
+		return nil, newVerificationError("invalid SD-JWT presentation: %w", err)
+	}
+
+	revoked, err := v.IsRevoked(*verifiedCredential.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, newVerificationError("invalid VC (id=%s): %w", verifiedCredential.ID, types.ErrRevoked)
+	}
+	if err = v.validateTrust(*verifiedCredential); err != nil {
+		return nil, newVerificationError("invalid VC (id=%s): %w", verifiedCredential.ID, err)
+	}
+	return []vc.VerifiableCredential{*verifiedCredential}, nil
 }
 
 func (v *verifier) validateType(credential vc.VerifiableCredential) error {
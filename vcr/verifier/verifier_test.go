@@ -20,10 +20,11 @@ package verifier
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"os"
-	"path"
 	"testing"
 	"time"
 
@@ -33,13 +34,18 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage"
+	"github.com/nuts-foundation/nuts-node/events"
 	"github.com/nuts-foundation/nuts-node/jsonld"
-	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/jwtvc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vdr"
+	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
 	vdrTypes "github.com/nuts-foundation/nuts-node/vdr/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -244,6 +250,19 @@ func TestVerifier_Verify(t *testing.T) {
 		assert.EqualError(t, err, "unknown credential type")
 	})
 
+	t.Run("error - rejected by validator registered for custom credential type", func(t *testing.T) {
+		ctx := newMockContext(t)
+		registry := credential.NewValidatorRegistry()
+		_ = registry.Register("CustomCredential", rejectingValidator{})
+		ctx.verifier.validators = registry
+		subject := testCredential(t)
+		subject.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("CustomCredential")}
+
+		err := ctx.verifier.Verify(subject, true, false, nil)
+
+		assert.EqualError(t, err, "rejected")
+	})
+
 	t.Run("error - not valid yet", func(t *testing.T) {
 		ctx := newMockContext(t)
 		instance := ctx.verifier
@@ -446,10 +465,27 @@ func Test_verifier_CheckAndStoreRevocation(t *testing.T) {
 		sut := newMockContext(t)
 		sut.keyResolver.EXPECT().ResolveSigningKey(revocation.Proof.VerificationMethod.String(), &revocation.Date).Return(key, nil)
 		sut.store.EXPECT().StoreRevocation(revocation)
+		sut.eventManager.EXPECT().Publish(events.CredentialsStream, events.CredentialRevokedSubject, events.CredentialRevokedEvent{
+			CredentialID: revocation.Subject.String(),
+			Issuer:       revocation.Issuer.String(),
+			Reason:       revocation.Reason,
+			Date:         revocation.Date,
+		})
 		err := sut.verifier.RegisterRevocation(revocation)
 		assert.NoError(t, err)
 	})
 
+	t.Run("publishing the revocation event fails", func(t *testing.T) {
+		sut := newMockContext(t)
+		sut.keyResolver.EXPECT().ResolveSigningKey(revocation.Proof.VerificationMethod.String(), &revocation.Date).Return(key, nil)
+		sut.store.EXPECT().StoreRevocation(revocation)
+		sut.eventManager.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("b00m!"))
+
+		err := sut.verifier.RegisterRevocation(revocation)
+
+		assert.NoError(t, err, "the revocation is stored, so it must not fail")
+	})
+
 	t.Run("it fails when there are fields missing", func(t *testing.T) {
 		sut := newMockContext(t)
 		revocation := credential.Revocation{}
@@ -673,6 +709,192 @@ func TestVerifier_VerifyVP(t *testing.T) {
 	})
 }
 
+func TestVerifier_JWT(t *testing.T) {
+	issuerKey := crypto.NewTestKey("did:nuts:issuer#key-1")
+	holderKey := crypto.NewTestKey("did:nuts:holder#key-1")
+	credentialID := ssi.MustParseURI("did:nuts:issuer#1")
+	credentialType := ssi.MustParseURI("NutsEmployeeCredential")
+	issued, _ := jwtvc.CreateCredential(vc.VerifiableCredential{
+		Context:      []ssi.URI{vc.VCContextV1URI()},
+		ID:           &credentialID,
+		Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), credentialType},
+		Issuer:       ssi.MustParseURI("did:nuts:issuer"),
+		IssuanceDate: time.Now().Add(-time.Minute),
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":   "did:nuts:holder",
+			"name": "John Doe",
+		}},
+	}, issuerKey)
+	presentation, _ := jwtvc.CreatePresentation(vc.VerifiablePresentation{
+		Context:              []ssi.URI{vc.VCContextV1URI()},
+		Type:                 []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+		VerifiableCredential: []vc.VerifiableCredential{*issued},
+	}, proof.ProofOptions{Created: time.Now()}, holderKey)
+
+	t.Run("Verify", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.store.EXPECT().GetRevocations(credentialID).Return(nil, ErrNotFound)
+			ctx.docResolver.EXPECT().Resolve(did.MustParseDID("did:nuts:issuer"), gomock.Any()).Return(nil, nil, nil)
+			ctx.keyResolver.EXPECT().ResolveSigningKey(issuerKey.KID(), nil).Return(issuerKey.Public(), nil)
+
+			err := ctx.verifier.Verify(*issued, true, true, nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("ok - issued by did:jwk", func(t *testing.T) {
+			privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			issuerDID, _ := didjwk.NewDID(privateKey.Public())
+			methods := doc.NewMethodResolvers()
+			methods.Register(didjwk.MethodName, didjwk.NewResolver())
+			credentialID := ssi.MustParseURI(issuerDID.String() + "#1")
+			credential, err := jwtvc.CreateCredential(vc.VerifiableCredential{
+				Context:      []ssi.URI{vc.VCContextV1URI()},
+				ID:           &credentialID,
+				Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), credentialType},
+				Issuer:       issuerDID.URI(),
+				IssuanceDate: time.Now().Add(-time.Minute),
+				CredentialSubject: []interface{}{map[string]interface{}{
+					"id": "did:nuts:holder",
+				}},
+			}, crypto.TestKey{PrivateKey: privateKey, Kid: issuerDID.String() + "#0"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			ctx := newMockContext(t)
+			ctx.store.EXPECT().GetRevocations(credentialID).Return(nil, ErrNotFound)
+			didStore := store.NewMemoryStore()
+			ctx.verifier.docResolver = doc.Resolver{Store: didStore, Methods: methods}
+			ctx.verifier.keyResolver = doc.KeyResolver{Store: didStore, Methods: methods}
+
+			err = ctx.verifier.Verify(*credential, true, true, nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("error - altered", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveSigningKey(issuerKey.KID(), nil).Return(issuerKey.Public(), nil)
+			altered := *issued
+			altered.CredentialSubject = []interface{}{map[string]interface{}{"id": "did:nuts:holder", "name": "Jane Doe"}}
+
+			err := ctx.verifier.Validate(altered, nil)
+
+			assert.EqualError(t, err, "contents don't match the JWT")
+		})
+		t.Run("error - signed by other party", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveSigningKey(issuerKey.KID(), nil).Return(holderKey.Public(), nil)
+
+			err := ctx.verifier.Validate(*issued, nil)
+
+			assert.ErrorContains(t, err, "invalid JWT credential: ")
+		})
+	})
+	t.Run("VerifyVP", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveSigningKey(holderKey.KID(), nil).Return(holderKey.Public(), nil)
+			mockVerifier := NewMockVerifier(ctx.ctrl)
+			mockVerifier.EXPECT().Verify(presentation.VerifiableCredential[0], false, true, nil)
+
+			vcs, err := ctx.verifier.doVerifyVP(mockVerifier, *presentation, true, nil)
+
+			assert.NoError(t, err)
+			assert.Len(t, vcs, 1)
+		})
+		t.Run("error - expired", func(t *testing.T) {
+			ctx := newMockContext(t)
+			expires := time.Now().Add(-time.Minute)
+			expired, _ := jwtvc.CreatePresentation(vc.VerifiablePresentation{
+				Context: []ssi.URI{vc.VCContextV1URI()},
+				Type:    []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+			}, proof.ProofOptions{Created: time.Now().Add(-time.Hour), Expires: &expires}, holderKey)
+			ctx.keyResolver.EXPECT().ResolveSigningKey(holderKey.KID(), nil).Return(holderKey.Public(), nil)
+
+			_, err := ctx.verifier.VerifyVP(*expired, false, nil)
+
+			assert.ErrorContains(t, err, "verification error: invalid JWT presentation: ")
+			assert.ErrorIs(t, err, VerificationError{})
+		})
+	})
+}
+
+func TestVerifier_VerifySDJWTPresentation(t *testing.T) {
+	issuerKey := crypto.NewTestKey("did:nuts:issuer#key-1")
+	holderKey := crypto.NewTestKey("did:nuts:holder#key-1")
+	credentialID := ssi.MustParseURI("did:nuts:issuer#1")
+	credentialType := ssi.MustParseURI("NutsEmployeeCredential")
+	issued, _ := sdjwt.Issue(vc.VerifiableCredential{
+		ID:           &credentialID,
+		Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), credentialType},
+		Issuer:       ssi.MustParseURI("did:nuts:issuer"),
+		IssuanceDate: time.Now().Add(-time.Minute),
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":       "did:nuts:holder",
+			"name":     "John Doe",
+			"roleName": "Nurse",
+		}},
+	}, issuerKey)
+	selected, _ := issued.Select([]string{"roleName"})
+	presentation, _ := selected.Bind(holderKey, "verifier", "nonce", time.Now())
+	expectKeys := func(ctx mockContext) {
+		ctx.keyResolver.EXPECT().ResolveSigningKey(issuerKey.KID(), nil).Return(issuerKey.Public(), nil)
+		ctx.keyResolver.EXPECT().ResolveSigningKey(holderKey.KID(), nil).Return(holderKey.Public(), nil)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		expectKeys(ctx)
+		ctx.store.EXPECT().GetRevocations(credentialID).Return(nil, ErrNotFound)
+		_ = ctx.trustConfig.AddTrust(credentialType, ssi.MustParseURI("did:nuts:issuer"))
+
+		credentials, err := ctx.verifier.VerifySDJWTPresentation(*presentation, "verifier", "nonce", nil)
+
+		if !assert.NoError(t, err) || !assert.Len(t, credentials, 1) {
+			return
+		}
+		assert.Equal(t, credentialID, *credentials[0].ID)
+		assert.Equal(t, map[string]interface{}{"id": "did:nuts:holder", "roleName": "Nurse"}, credentials[0].CredentialSubject[0])
+	})
+	t.Run("error - no key binding", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyResolver.EXPECT().ResolveSigningKey(issuerKey.KID(), nil).Return(issuerKey.Public(), nil)
+
+		_, err := ctx.verifier.VerifySDJWTPresentation(*selected, "verifier", "nonce", nil)
+
+		assert.EqualError(t, err, "verification error: invalid SD-JWT presentation: key binding JWT is required")
+		assert.ErrorIs(t, err, VerificationError{})
+	})
+	t.Run("error - nonce mismatch", func(t *testing.T) {
+		ctx := newMockContext(t)
+		expectKeys(ctx)
+
+		_, err := ctx.verifier.VerifySDJWTPresentation(*presentation, "verifier", "other", nil)
+
+		assert.EqualError(t, err, "verification error: invalid SD-JWT presentation: invalid key binding JWT: nonce mismatch")
+		assert.ErrorIs(t, err, VerificationError{})
+	})
+	t.Run("error - revoked", func(t *testing.T) {
+		ctx := newMockContext(t)
+		expectKeys(ctx)
+		ctx.store.EXPECT().GetRevocations(credentialID).Return([]*credential.Revocation{{}}, nil)
+
+		_, err := ctx.verifier.VerifySDJWTPresentation(*presentation, "verifier", "nonce", nil)
+
+		assert.EqualError(t, err, "verification error: invalid VC (id=did:nuts:issuer#1): credential is revoked")
+		assert.ErrorIs(t, err, VerificationError{})
+	})
+	t.Run("error - untrusted", func(t *testing.T) {
+		ctx := newMockContext(t)
+		expectKeys(ctx)
+		ctx.store.EXPECT().GetRevocations(credentialID).Return(nil, ErrNotFound)
+
+		_, err := ctx.verifier.VerifySDJWTPresentation(*presentation, "verifier", "nonce", nil)
+
+		assert.EqualError(t, err, "verification error: invalid VC (id=did:nuts:issuer#1): credential issuer is untrusted")
+	})
+}
+
 func Test_verifier_IsRevoked(t *testing.T) {
 	rawRevocation, _ := os.ReadFile("../test/ld-revocation.json")
 	revocation := credential.Revocation{}
@@ -735,12 +957,13 @@ func TestVerificationError_Is(t *testing.T) {
 }
 
 type mockContext struct {
-	ctrl        *gomock.Controller
-	docResolver *vdrTypes.MockDocResolver
-	keyResolver *vdrTypes.MockKeyResolver
-	store       *MockStore
-	trustConfig *trust.Config
-	verifier    *verifier
+	ctrl         *gomock.Controller
+	docResolver  *vdrTypes.MockDocResolver
+	keyResolver  *vdrTypes.MockKeyResolver
+	store        *MockStore
+	trustConfig  *trust.Config
+	eventManager *events.MockEvent
+	verifier     *verifier
 }
 
 func newMockContext(t *testing.T) mockContext {
@@ -750,14 +973,22 @@ func newMockContext(t *testing.T) mockContext {
 	keyResolver := vdrTypes.NewMockKeyResolver(ctrl)
 	jsonldManager := jsonld.NewTestJSONLDManager(t)
 	verifierStore := NewMockStore(ctrl)
-	trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.yaml"))
-	verifier := NewVerifier(verifierStore, docResolver, keyResolver, jsonldManager, trustConfig).(*verifier)
+	trustConfig := trust.NewTestConfig(t)
+	eventManager := events.NewMockEvent(ctrl)
+	verifier := NewVerifier(verifierStore, docResolver, keyResolver, jsonldManager, trustConfig, eventManager, nil).(*verifier)
 	return mockContext{
-		ctrl:        ctrl,
-		verifier:    verifier,
-		docResolver: docResolver,
-		keyResolver: keyResolver,
-		store:       verifierStore,
-		trustConfig: trustConfig,
+		ctrl:         ctrl,
+		verifier:     verifier,
+		docResolver:  docResolver,
+		keyResolver:  keyResolver,
+		store:        verifierStore,
+		trustConfig:  trustConfig,
+		eventManager: eventManager,
 	}
 }
+
+type rejectingValidator struct{}
+
+func (r rejectingValidator) Validate(_ vc.VerifiableCredential) error {
+	return errors.New("rejected")
+}
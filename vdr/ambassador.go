@@ -80,24 +80,34 @@ func NewAmbassador(networkClient network.Transactions, didStore types.Store, eve
 
 // Configure instructs the ambassador to start receiving DID Documents from the network.
 func (n *ambassador) Configure() error {
+	if err := n.networkClient.RegisterPayloadType(didDocumentType, validateDIDDocumentPayload); err != nil {
+		return err
+	}
+	if err := n.networkClient.RegisterPayloadType(didDocumentProposalType, validateDIDDocumentProposalPayload); err != nil {
+		return err
+	}
 	return n.networkClient.Subscribe("vdr", n.handleNetworkEvent,
 		n.networkClient.WithPersistency(),
 		network.WithSelectionFilter(func(event dag.Event) bool {
-			return event.Type == dag.PayloadEventType && event.Transaction.PayloadType() == didDocumentType
+			payloadType := event.Transaction.PayloadType()
+			return event.Type == dag.PayloadEventType && (payloadType == didDocumentType || payloadType == didDocumentProposalType)
 		}))
 }
 
 func (n *ambassador) Start() error {
 	stream := events.NewDisposableStream(
 		fmt.Sprintf("%s_%s", events.ReprocessStream, "VDR"),
-		[]string{fmt.Sprintf("%s.%s", events.ReprocessStream, didDocumentType)},
+		[]string{
+			fmt.Sprintf("%s.%s", events.ReprocessStream, didDocumentType),
+			fmt.Sprintf("%s.%s", events.ReprocessStream, didDocumentProposalType),
+		},
 		network.MaxReprocessBufferSize)
 	conn, _, err := n.eventManager.Pool().Acquire(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to REPROCESS event stream: %v", err)
 	}
 
-	err = stream.Subscribe(conn, "VDR", fmt.Sprintf("%s.%s", events.ReprocessStream, "application/did+json"), n.handleReprocessEvent)
+	err = stream.Subscribe(conn, "VDR", fmt.Sprintf("%s.*", events.ReprocessStream), n.handleReprocessEvent)
 
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to REPROCESS event stream: %v", err)
@@ -146,7 +156,7 @@ var thumbprintAlg = crypto.SHA256
 // callback gets called when new DIDDocuments are received by the network. All checks on the signature are already performed.
 // This method will check the integrity of the DID document related to the public key used to sign the network TX.
 // The rules are based on the Nuts RFC006
-// payload should be a json encoded did.document
+// payload should be a json encoded did.document, or a json encoded types.UpdateProposal for updates that must be signed by multiple controllers.
 // Duplicates are handled as updates and will be merged. Merging two exactly the same DID Documents results in the original document.
 func (n *ambassador) callback(tx dag.Transaction, payload []byte) error {
 	log.Logger().
@@ -170,7 +180,14 @@ func (n *ambassador) callback(tx dag.Transaction, payload []byte) error {
 
 	// Unmarshal the next/new proposed version of the DID Document
 	var nextDIDDocument did.Document
-	if err := json.Unmarshal(payload, &nextDIDDocument); err != nil {
+	var proposal *types.UpdateProposal
+	if tx.PayloadType() == didDocumentProposalType {
+		proposal = &types.UpdateProposal{}
+		if err := json.Unmarshal(payload, proposal); err != nil {
+			return fmt.Errorf("unable to unmarshal DID document update proposal from network payload: %w", err)
+		}
+		nextDIDDocument = proposal.Document
+	} else if err := json.Unmarshal(payload, &nextDIDDocument); err != nil {
 		return fmt.Errorf("unable to unmarshal DID document from network payload: %w", err)
 	}
 
@@ -179,11 +196,26 @@ func (n *ambassador) callback(tx dag.Transaction, payload []byte) error {
 	}
 
 	if n.isUpdate(tx) {
-		return n.handleUpdateDIDDocument(tx, nextDIDDocument)
+		return n.handleUpdateDIDDocument(tx, nextDIDDocument, proposal)
+	}
+	if proposal != nil {
+		return errors.New("callback could not process new DID Document: update proposals can't be used to create DID documents")
 	}
 	return n.handleCreateDIDDocument(tx, nextDIDDocument)
 }
 
+// validateDIDDocumentPayload checks whether the payload of a DID document transaction can be parsed as DID document.
+// The DID document itself is validated when it's processed.
+func validateDIDDocumentPayload(payload []byte) error {
+	return json.Unmarshal(payload, &did.Document{})
+}
+
+// validateDIDDocumentProposalPayload checks whether the payload of a DID document update proposal transaction
+// can be parsed as update proposal. The proposed DID document itself is validated when it's processed.
+func validateDIDDocumentProposalPayload(payload []byte) error {
+	return json.Unmarshal(payload, &types.UpdateProposal{})
+}
+
 func (n *ambassador) handleCreateDIDDocument(transaction dag.Transaction, proposedDIDDocument did.Document) error {
 	log.Logger().
 		WithField(core.LogFieldTransactionRef, transaction.Ref()).
@@ -255,7 +287,9 @@ func (n *ambassador) handleCreateDIDDocument(transaction dag.Transaction, propos
 	return nil
 }
 
-func (n *ambassador) handleUpdateDIDDocument(transaction dag.Transaction, proposedDIDDocument did.Document) error {
+// handleUpdateDIDDocument processes an update of a DID document. If the update was published as proposal, it contains the signatures
+// of the controllers that approved the update. Otherwise, proposal is nil.
+func (n *ambassador) handleUpdateDIDDocument(transaction dag.Transaction, proposedDIDDocument did.Document, proposal *types.UpdateProposal) error {
 	log.Logger().
 		WithField(core.LogFieldTransactionRef, transaction.Ref()).
 		WithField(core.LogFieldDID, proposedDIDDocument.ID).
@@ -306,6 +340,11 @@ func (n *ambassador) handleUpdateDIDDocument(transaction dag.Transaction, propos
 		return fmt.Errorf("network document not signed by one of its controllers")
 	}
 
+	// Check if the update is approved by enough controllers
+	if err = checkControllerThreshold(*currentDIDDocument, currentDIDMeta.Hash, didControllers, proposal); err != nil {
+		return fmt.Errorf("unable to update DID document: %w", err)
+	}
+
 	// check if the transactions contains all SourceTransactions
 	missedTransactions := missingTransactions(currentDIDMeta.SourceTransactions, transaction.Previous())
 	sourceTransactions := uniqueTransactions(missedTransactions, transaction.Ref())
@@ -366,6 +405,25 @@ func (n *ambassador) resolveControllers(document did.Document, transaction dag.T
 	return controllers, nil
 }
 
+// checkControllerThreshold checks whether the update of the current DID document is signed by enough controllers.
+// Updates that aren't published as proposal are only signed by the controller that signed the transaction.
+func checkControllerThreshold(currentDocument did.Document, currentHash hash.SHA256Hash, controllers []did.Document, proposal *types.UpdateProposal) error {
+	threshold, err := controllerThreshold(currentDocument)
+	if err != nil {
+		return err
+	}
+	if proposal == nil {
+		if threshold > 1 {
+			return fmt.Errorf("%w (signed=1, threshold=%d)", types.ErrControllerThresholdNotMet, threshold)
+		}
+		return nil
+	}
+	if !proposal.Current.Equals(currentHash) {
+		return fmt.Errorf("update proposal does not apply to the current version of the DID document (proposal=%s, current=%s)", proposal.Current, currentHash)
+	}
+	return verifyProposal(*proposal, controllers, threshold)
+}
+
 func sortHashes(input []hash.SHA256Hash) {
 	sort.Slice(input, func(i, j int) bool {
 		return bytes.Compare(input[i].Slice(), input[j].Slice()) < 0
@@ -413,8 +471,8 @@ func uniqueTransactions(current []hash.SHA256Hash, incoming hash.SHA256Hash) []h
 // responsibility to ensure integrity, while the other may have not.
 func checkTransactionIntegrity(transaction dag.Transaction) error {
 	// check the payload type:
-	if transaction.PayloadType() != didDocumentType {
-		return fmt.Errorf("wrong payload type for this subscriber. Can handle: %s, got: %s", didDocumentType, transaction.PayloadType())
+	if transaction.PayloadType() != didDocumentType && transaction.PayloadType() != didDocumentProposalType {
+		return fmt.Errorf("wrong payload type for this subscriber. Can handle: %s, %s, got: %s", didDocumentType, didDocumentProposalType, transaction.PayloadType())
 	}
 
 	// PayloadHash must be set
@@ -181,7 +181,7 @@ func TestAmbassador_handleNetworkEvent(t *testing.T) {
 		am := ambassador{}
 		value, err := am.handleNetworkEvent(dag.Event{Transaction: tx})
 		assert.False(t, value)
-		assert.EqualError(t, err, "could not process new DID Document: wrong payload type for this subscriber. Can handle: application/did+json, got: ")
+		assert.EqualError(t, err, "could not process new DID Document: wrong payload type for this subscriber. Can handle: application/did+json, application/did-proposal+json, got: ")
 	})
 }
 
@@ -234,7 +234,32 @@ func TestAmbassador_callback(t *testing.T) {
 		tx.payloadType = ""
 		am := ambassador{}
 		err := am.callback(tx, []byte{})
-		assert.EqualError(t, err, "could not process new DID Document: wrong payload type for this subscriber. Can handle: application/did+json, got: ")
+		assert.EqualError(t, err, "could not process new DID Document: wrong payload type for this subscriber. Can handle: application/did+json, application/did-proposal+json, got: ")
+	})
+
+	t.Run("nok - update proposal used to create DID document", func(t *testing.T) {
+		didDocument, signingKey, _ := newDidDoc()
+		tx := newTX()
+		tx.signingKey = signingKey
+		tx.payloadType = didDocumentProposalType
+		ctx := newMockContext(t)
+		ctx.didStore.EXPECT().Processed(tx.ref).Return(false, nil)
+		payload, _ := json.Marshal(types.UpdateProposal{Document: didDocument})
+
+		err := ctx.ambassador.callback(tx, payload)
+
+		assert.EqualError(t, err, "callback could not process new DID Document: update proposals can't be used to create DID documents")
+	})
+
+	t.Run("nok - invalid update proposal", func(t *testing.T) {
+		tx := newTX()
+		tx.payloadType = didDocumentProposalType
+		ctx := newMockContext(t)
+		ctx.didStore.EXPECT().Processed(tx.ref).Return(false, nil)
+
+		err := ctx.ambassador.callback(tx, []byte("}"))
+
+		assert.EqualError(t, err, "unable to unmarshal DID document update proposal from network payload: invalid character '}' looking for beginning of value")
 	})
 
 	t.Run("nok - DID document invalid according to W3C spec", func(t *testing.T) {
@@ -472,7 +497,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 		ctx.keyStore.EXPECT().ResolvePublicKey(storedDocument.CapabilityInvocation[0].ID.String(), gomock.Any()).Return(pKey, nil)
 		ctx.didStore.EXPECT().Update(storedDocument.ID, currentMetadata.Hash, deactivatedDocument, &expectedNextMetadata)
 
-		err = ctx.ambassador.handleUpdateDIDDocument(tx, deactivatedDocument)
+		err = ctx.ambassador.handleUpdateDIDDocument(tx, deactivatedDocument, nil)
 		assert.NoError(t, err)
 	})
 
@@ -519,7 +544,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 		ctx.keyStore.EXPECT().ResolvePublicKey(didDocument.CapabilityInvocation[0].ID.String(), gomock.Any()).Return(pKey, nil)
 		ctx.didStore.EXPECT().Update(didDocument.ID, currentMetadata.Hash, expectedDocument, &expectedNextMetadata)
 
-		err = ctx.ambassador.handleUpdateDIDDocument(tx, expectedDocument)
+		err = ctx.ambassador.handleUpdateDIDDocument(tx, expectedDocument, nil)
 		assert.NoError(t, err)
 	})
 
@@ -563,7 +588,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 
 		ctx.didStore.EXPECT().Update(didDocument.ID, currentMetadata.Hash, expectedDocument, &expectedNextMetadata)
 
-		err := ctx.ambassador.handleUpdateDIDDocument(tx, expectedDocument)
+		err := ctx.ambassador.handleUpdateDIDDocument(tx, expectedDocument, nil)
 		assert.NoError(t, err)
 	})
 
@@ -605,7 +630,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 		ctx.keyStore.EXPECT().ResolvePublicKey(currentDoc.CapabilityInvocation[0].ID.String(), gomock.Any()).Return(pKey, nil)
 		ctx.didStore.EXPECT().Update(currentDoc.ID, currentMetadata.Hash, newDoc, &expectedNextMetadata)
 
-		err := ctx.ambassador.handleUpdateDIDDocument(tx, newDoc)
+		err := ctx.ambassador.handleUpdateDIDDocument(tx, newDoc, nil)
 		assert.NoError(t, err)
 	})
 
@@ -673,7 +698,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 		ctx.keyStore.EXPECT().ResolvePublicKey(didDocumentController.CapabilityInvocation[0].ID.String(), gomock.Any()).Return(pKey, nil)
 		ctx.didStore.EXPECT().Update(didDocument.ID, currentMetadata.Hash, expectedDocument, &expectedNextMetadata)
 
-		err = ctx.ambassador.handleUpdateDIDDocument(tx, expectedDocument)
+		err = ctx.ambassador.handleUpdateDIDDocument(tx, expectedDocument, nil)
 		assert.NoError(t, err)
 	})
 
@@ -737,7 +762,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 		ctx.resolver.EXPECT().ResolveControllers(expectedDocument, &types.ResolveMetadata{ResolveTime: &tx.signingTime}).Return([]did.Document{didDocumentController}, nil)
 		ctx.keyStore.EXPECT().ResolvePublicKey(keyID, gomock.Any()).Return(pKey, nil)
 
-		err = ctx.ambassador.handleUpdateDIDDocument(tx, didDocument)
+		err = ctx.ambassador.handleUpdateDIDDocument(tx, didDocument, nil)
 		assert.EqualError(t, err, "network document not signed by one of its controllers")
 	})
 
@@ -789,11 +814,43 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 		keyStoreMock.EXPECT().ResolvePublicKey(signingKey.KeyID(), gomock.Any()).Return(pKey, nil)
 		didStoreMock.EXPECT().Update(didDocument.ID, hash.EmptyHash(), expectedDocument, &expectedMetadata).Return(nil)
 
-		err = am.handleUpdateDIDDocument(tx, expectedDocument)
+		err = am.handleUpdateDIDDocument(tx, expectedDocument, nil)
 		assert.NoError(t, err)
 	})
 }
 
+func Test_validateDIDDocumentPayload(t *testing.T) {
+	didDocument, _, _ := newDidDoc()
+
+	t.Run("ok", func(t *testing.T) {
+		payload, _ := json.Marshal(didDocument)
+
+		assert.NoError(t, validateDIDDocumentPayload(payload))
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		assert.Error(t, validateDIDDocumentPayload([]byte("b00m")))
+	})
+	t.Run("not a DID document", func(t *testing.T) {
+		assert.Error(t, validateDIDDocumentPayload([]byte(`{"id":1}`)))
+	})
+}
+
+func Test_validateDIDDocumentProposalPayload(t *testing.T) {
+	didDocument, _, _ := newDidDoc()
+
+	t.Run("ok", func(t *testing.T) {
+		payload, _ := json.Marshal(types.UpdateProposal{Document: didDocument})
+
+		assert.NoError(t, validateDIDDocumentProposalPayload(payload))
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		assert.Error(t, validateDIDDocumentProposalPayload([]byte("b00m")))
+	})
+	t.Run("not an update proposal", func(t *testing.T) {
+		assert.Error(t, validateDIDDocumentProposalPayload([]byte(`[]`)))
+	})
+}
+
 func Test_sortHashes(t *testing.T) {
 	h0 := hash.SHA256Hash{}
 	h1 := hash.SHA256Hash{1}
@@ -824,7 +881,7 @@ func Test_handleUpdateDIDDocument(t *testing.T) {
 		didStoreMock.EXPECT().Resolve(didDocument.ID, &types.ResolveMetadata{AllowDeactivated: true}).Return(&didDocument, &types.DocumentMetadata{}, nil)
 		docResolverMock.EXPECT().ResolveControllers(didDocument, &types.ResolveMetadata{ResolveTime: &tx.signingTime}).Return(nil, errors.New("failed"))
 
-		err := am.handleUpdateDIDDocument(&tx, didDocument)
+		err := am.handleUpdateDIDDocument(&tx, didDocument, nil)
 		assert.EqualError(t, err, "unable to resolve DID document's controllers: failed")
 	})
 }
@@ -871,7 +928,7 @@ func Test_checkTransactionIntegrity(t *testing.T) {
 				payloadHash:  payloadHash,
 				payloadType:  "application/xml",
 			},
-			errors.New("wrong payload type for this subscriber. Can handle: application/did+json, got: application/xml"),
+			errors.New("wrong payload type for this subscriber. Can handle: application/did+json, application/did-proposal+json, got: application/xml"),
 		},
 		{"nok - missing payload hash",
 			testTransaction{
@@ -1038,3 +1095,46 @@ func newMockContext(t *testing.T) mockContext {
 		eventManager: eventManager,
 	}
 }
+
+func Test_checkControllerThreshold(t *testing.T) {
+	t.Run("ok - single controller", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 1)
+
+		err := checkControllerThreshold(ctx.document, ctx.currentHash, []did.Document{ctx.document, ctx.vendor}, nil)
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - proposal signed by enough controllers", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+		proposal, _ = ctx.vdrB.SignProposal(*proposal)
+
+		err := checkControllerThreshold(ctx.document, ctx.currentHash, []did.Document{ctx.document, ctx.vendor}, proposal)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - update not signed by enough controllers", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+
+		err := checkControllerThreshold(ctx.document, ctx.currentHash, []did.Document{ctx.document, ctx.vendor}, nil)
+
+		assert.ErrorIs(t, err, types.ErrControllerThresholdNotMet)
+	})
+	t.Run("error - proposal not signed by enough controllers", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+
+		err := checkControllerThreshold(ctx.document, ctx.currentHash, []did.Document{ctx.document, ctx.vendor}, proposal)
+
+		assert.ErrorIs(t, err, types.ErrControllerThresholdNotMet)
+	})
+	t.Run("error - proposal for other version", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+		proposal, _ = ctx.vdrB.SignProposal(*proposal)
+
+		err := checkControllerThreshold(ctx.document, hash.SHA256Sum([]byte("other")), []did.Document{ctx.document, ctx.vendor}, proposal)
+
+		assert.ErrorContains(t, err, "update proposal does not apply to the current version of the DID document")
+	})
+}
@@ -20,17 +20,22 @@
 package v1
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
 	vdrDoc "github.com/nuts-foundation/nuts-node/vdr/doc"
 
 	"github.com/labstack/echo/v4"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
 var _ ServerInterface = (*Wrapper)(nil)
@@ -38,21 +43,30 @@ var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
 
 // Wrapper is needed to connect the implementation to the echo ServiceWrapper
 type Wrapper struct {
-	VDR            types.VDR
-	DocManipulator types.DocManipulator
-	DocResolver    types.DocResolver
+	VDR             types.VDR
+	DocManipulator  types.DocManipulator
+	DocResolver     types.DocResolver
+	DocFinder       types.DocFinder
+	ExpiryScheduler types.ExpiryScheduler
 }
 
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
 func (a *Wrapper) ResolveStatusCode(err error) int {
 	return core.ResolveStatusCode(err, map[error]int{
-		types.ErrNotFound:                http.StatusNotFound,
-		types.ErrDIDNotManagedByThisNode: http.StatusForbidden,
-		types.ErrDeactivated:             http.StatusConflict,
-		types.ErrNoActiveController:      http.StatusConflict,
-		types.ErrDuplicateService:        http.StatusBadRequest,
-		vdrDoc.ErrInvalidOptions:         http.StatusBadRequest,
-		did.ErrInvalidDID:                http.StatusBadRequest,
+		types.ErrNotFound:                  http.StatusNotFound,
+		types.ErrDIDNotManagedByThisNode:   http.StatusForbidden,
+		types.ErrDeactivated:               http.StatusConflict,
+		types.ErrNoActiveController:        http.StatusConflict,
+		types.ErrDuplicateService:          http.StatusBadRequest,
+		vdrDoc.ErrInvalidOptions:           http.StatusBadRequest,
+		did.ErrInvalidDID:                  http.StatusBadRequest,
+		types.ErrUnsupportedDIDMethod:      http.StatusBadRequest,
+		didweb.ErrHostingNotConfigured:     http.StatusBadRequest,
+		types.ErrNotConflicted:             http.StatusBadRequest,
+		types.ErrControllerThresholdNotMet: http.StatusBadRequest,
+		types.ErrInvalidProposal:           http.StatusBadRequest,
+		types.ErrKeyNotFound:               http.StatusNotFound,
+		types.ErrExpiryNotScheduled:        http.StatusNotFound,
 	})
 }
 
@@ -109,6 +123,9 @@ func (a Wrapper) CreateDID(ctx echo.Context) error {
 	}
 
 	options := vdrDoc.DefaultCreationOptions()
+	if req.Method != nil {
+		options.Method = string(*req.Method)
+	}
 	if req.Controllers != nil {
 		for _, c := range *req.Controllers {
 			id, err := did.ParseDID(c)
@@ -188,6 +205,146 @@ func (a *Wrapper) GetDID(ctx echo.Context, targetDID string, params GetDIDParams
 	return ctx.JSON(http.StatusOK, resolutionResult)
 }
 
+// SearchDIDs searches for active DID documents on their controllers, verification methods and services.
+func (a *Wrapper) SearchDIDs(ctx echo.Context, params SearchDIDsParams) error {
+	predicates := []types.Predicate{vdrDoc.IsActive()}
+	if params.Controller != nil {
+		controller, err := did.ParseDID(*params.Controller)
+		if err != nil {
+			return core.InvalidInputError("given controller is not valid: %w", err)
+		}
+		predicates = append(predicates, vdrDoc.ByController(*controller))
+	}
+	if params.VerificationMethod != nil {
+		methodID, err := did.ParseDIDURL(*params.VerificationMethod)
+		if err != nil {
+			return core.InvalidInputError("given verificationMethod is not valid: %w", err)
+		}
+		predicates = append(predicates, vdrDoc.ByVerificationMethod(*methodID))
+	}
+	if params.ServiceType != nil {
+		predicates = append(predicates, vdrDoc.ByAnyServiceType(*params.ServiceType))
+	}
+	if params.ServiceEndpoint != nil {
+		predicates = append(predicates, vdrDoc.ByServiceEndpoint(*params.ServiceEndpoint))
+	}
+	if params.ServiceEndpointHost != nil {
+		predicates = append(predicates, vdrDoc.ByServiceEndpointHost(*params.ServiceEndpointHost))
+	}
+	if params.Limit != nil && *params.Limit < 1 {
+		return core.InvalidInputError("limit must be greater than 0")
+	}
+	var after string
+	if params.Cursor != nil {
+		decoded, err := base64.RawURLEncoding.DecodeString(*params.Cursor)
+		if err != nil {
+			return core.InvalidInputError("invalid cursor")
+		}
+		after = string(decoded)
+	}
+
+	documents, err := a.DocFinder.Find(predicates...)
+	if err != nil {
+		return err
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].ID.String() < documents[j].ID.String()
+	})
+	result := SearchDIDResults{Documents: make([]DIDDocument, 0)}
+	for _, document := range documents {
+		if document.ID.String() <= after {
+			continue
+		}
+		if params.Limit != nil && len(result.Documents) == *params.Limit {
+			// there are more results
+			nextCursor := base64.RawURLEncoding.EncodeToString([]byte(result.Documents[len(result.Documents)-1].ID.String()))
+			result.NextCursor = &nextCursor
+			break
+		}
+		result.Documents = append(result.Documents, document)
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// GetDIDHistory lists all versions of a DID document.
+func (a *Wrapper) GetDIDHistory(ctx echo.Context, targetDID string) error {
+	d, err := did.ParseDID(targetDID)
+	if err != nil {
+		return core.InvalidInputError("given did is not valid: %w", err)
+	}
+	docs, metas, err := a.VDR.History(*d)
+	if err != nil {
+		return err
+	}
+	versions := make([]DIDDocumentVersion, len(docs))
+	for i := range docs {
+		versions[i] = DIDDocumentVersion{
+			Version:          i,
+			Document:         docs[i],
+			DocumentMetadata: metas[i],
+		}
+	}
+	return ctx.JSON(http.StatusOK, versions)
+}
+
+// GetDIDDiff lists the differences between two versions of a DID document.
+func (a *Wrapper) GetDIDDiff(ctx echo.Context, targetDID string, params GetDIDDiffParams) error {
+	d, err := did.ParseDID(targetDID)
+	if err != nil {
+		return core.InvalidInputError("given did is not valid: %w", err)
+	}
+	fromHash, err := hash.ParseHex(params.From)
+	if err != nil {
+		return core.InvalidInputError("given from hash is not valid: %w", err)
+	}
+	toMetadata := &types.ResolveMetadata{AllowDeactivated: true}
+	if params.To != nil {
+		toHash, err := hash.ParseHex(*params.To)
+		if err != nil {
+			return core.InvalidInputError("given to hash is not valid: %w", err)
+		}
+		toMetadata.Hash = &toHash
+	}
+
+	from, fromMeta, err := a.DocResolver.Resolve(*d, &types.ResolveMetadata{Hash: &fromHash, AllowDeactivated: true})
+	if err != nil {
+		return err
+	}
+	to, toMeta, err := a.DocResolver.Resolve(*d, toMetadata)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toDIDDocumentDiff(fromMeta.Hash, toMeta.Hash, vdrDoc.DiffDocuments(*from, *to)))
+}
+
+func toDIDDocumentDiff(from hash.SHA256Hash, to hash.SHA256Hash, diff vdrDoc.DocumentDiff) DIDDocumentDiff {
+	result := DIDDocumentDiff{
+		From:                       from.String(),
+		To:                         to.String(),
+		AddedVerificationMethods:   make([]VerificationMethod, len(diff.AddedVerificationMethods)),
+		RemovedVerificationMethods: make([]VerificationMethod, len(diff.RemovedVerificationMethods)),
+		AddedServices:              append([]Service{}, diff.AddedServices...),
+		RemovedServices:            append([]Service{}, diff.RemovedServices...),
+		ChangedServices:            append([]Service{}, diff.ChangedServices...),
+		AddedControllers:           make([]string, len(diff.AddedControllers)),
+		RemovedControllers:         make([]string, len(diff.RemovedControllers)),
+	}
+	for i, method := range diff.AddedVerificationMethods {
+		result.AddedVerificationMethods[i] = *method
+	}
+	for i, method := range diff.RemovedVerificationMethods {
+		result.RemovedVerificationMethods[i] = *method
+	}
+	for i, controller := range diff.AddedControllers {
+		result.AddedControllers[i] = controller.String()
+	}
+	for i, controller := range diff.RemovedControllers {
+		result.RemovedControllers[i] = controller.String()
+	}
+	return result
+}
+
 func (a *Wrapper) ConflictedDIDs(ctx echo.Context) error {
 	docs, metas, err := a.VDR.ConflictedDocuments()
 	if err != nil {
@@ -207,6 +364,70 @@ func (a *Wrapper) ConflictedDIDs(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, returnValues)
 }
 
+// ResolveConflictedDIDs resolves the conflicts of all conflicted DID documents controlled by this node.
+func (a *Wrapper) ResolveConflictedDIDs(ctx echo.Context, params ResolveConflictedDIDsParams) error {
+	resolutions, err := a.VDR.ResolveConflicts(params.DryRun != nil && *params.DryRun)
+	if err != nil {
+		return err
+	}
+	results := make([]ConflictResolution, len(resolutions))
+	for i, resolution := range resolutions {
+		if results[i], err = toConflictResolution(resolution); err != nil {
+			return err
+		}
+	}
+	return ctx.JSON(http.StatusOK, results)
+}
+
+// ResolveConflictedDID resolves the conflict of a conflicted DID document by publishing a merged version.
+func (a *Wrapper) ResolveConflictedDID(ctx echo.Context, targetDID string, params ResolveConflictedDIDParams) error {
+	id, err := did.ParseDID(targetDID)
+	if err != nil {
+		return err
+	}
+	resolution, err := a.VDR.ResolveConflict(*id, params.DryRun != nil && *params.DryRun)
+	if err != nil {
+		return err
+	}
+	result, err := toConflictResolution(*resolution)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func toConflictResolution(resolution types.ConflictResolution) (ConflictResolution, error) {
+	current, err := json.MarshalIndent(resolution.Current, "", "  ")
+	if err != nil {
+		return ConflictResolution{}, err
+	}
+	merged, err := json.MarshalIndent(resolution.Merged, "", "  ")
+	if err != nil {
+		return ConflictResolution{}, err
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(merged)),
+		FromFile: "current",
+		ToFile:   "merged",
+		Context:  3,
+	})
+	if err != nil {
+		return ConflictResolution{}, err
+	}
+	heads := make([]string, len(resolution.Heads))
+	for i, head := range resolution.Heads {
+		heads[i] = head.String()
+	}
+	return ConflictResolution{
+		Did:       resolution.Current.ID.String(),
+		Document:  resolution.Merged,
+		Diff:      diff,
+		Heads:     heads,
+		Published: resolution.Published,
+	}, nil
+}
+
 // UpdateDID updates a DID Document given a DID and DID Document body. It returns the updated DID Document.
 func (a Wrapper) UpdateDID(ctx echo.Context, targetDID string) error {
 	d, err := did.ParseDID(targetDID)
@@ -231,6 +452,81 @@ func (a Wrapper) UpdateDID(ctx echo.Context, targetDID string) error {
 	return ctx.JSON(http.StatusOK, req.Document)
 }
 
+// ProposeDIDUpdate prepares an update of a DID Document that must be signed by multiple controllers.
+// It returns the proposal, signed by the controllers managed by this node.
+func (a *Wrapper) ProposeDIDUpdate(ctx echo.Context, targetDID string) error {
+	id, err := did.ParseDID(targetDID)
+	if err != nil {
+		return err
+	}
+	req := DIDUpdateRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+	current, err := hash.ParseHex(req.CurrentHash)
+	if err != nil {
+		return core.InvalidInputError("given hash is not valid: %w", err)
+	}
+	proposal, err := a.VDR.ProposeUpdate(*id, current, req.Document)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, toUpdateProposal(*proposal))
+}
+
+// SignDIDUpdateProposal adds the signatures of the controllers managed by this node to an update proposal.
+func (a *Wrapper) SignDIDUpdateProposal(ctx echo.Context) error {
+	proposal, err := bindUpdateProposal(ctx)
+	if err != nil {
+		return err
+	}
+	signed, err := a.VDR.SignProposal(*proposal)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, toUpdateProposal(*signed))
+}
+
+// PublishDIDUpdateProposal publishes an update proposal that's signed by enough controllers.
+func (a *Wrapper) PublishDIDUpdateProposal(ctx echo.Context) error {
+	proposal, err := bindUpdateProposal(ctx)
+	if err != nil {
+		return err
+	}
+	if err = a.VDR.PublishProposal(*proposal); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func bindUpdateProposal(ctx echo.Context) (*types.UpdateProposal, error) {
+	req := UpdateProposal{}
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	current, err := hash.ParseHex(req.Current)
+	if err != nil {
+		return nil, core.InvalidInputError("given hash is not valid: %w", err)
+	}
+	return &types.UpdateProposal{
+		Current:    current,
+		Document:   req.Document,
+		Signatures: req.Signatures,
+	}, nil
+}
+
+func toUpdateProposal(proposal types.UpdateProposal) UpdateProposal {
+	signatures := proposal.Signatures
+	if signatures == nil {
+		signatures = []string{}
+	}
+	return UpdateProposal{
+		Current:    proposal.Current.String(),
+		Document:   proposal.Document,
+		Signatures: signatures,
+	}
+}
+
 // DeactivateDID deactivates a DID Document given a DID.
 // It returns a 200 and an empty body if the deactivation was successful.
 func (a *Wrapper) DeactivateDID(ctx echo.Context, targetDID string) error {
@@ -244,3 +540,90 @@ func (a *Wrapper) DeactivateDID(ctx echo.Context, targetDID string) error {
 	}
 	return ctx.NoContent(http.StatusOK)
 }
+
+// ListExpiries lists the scheduled expiries of DID documents and verification methods.
+func (a *Wrapper) ListExpiries(ctx echo.Context) error {
+	expiries, err := a.ExpiryScheduler.List()
+	if err != nil {
+		return err
+	}
+	results := make([]Expiry, len(expiries))
+	for i, expiry := range expiries {
+		results[i] = toExpiry(expiry)
+	}
+	return ctx.JSON(http.StatusOK, results)
+}
+
+// ScheduleDIDExpiry schedules the deactivation of a DID document.
+func (a *Wrapper) ScheduleDIDExpiry(ctx echo.Context, targetDID string) error {
+	id, err := did.ParseDID(targetDID)
+	if err != nil {
+		return err
+	}
+	return a.scheduleExpiry(ctx, *id, nil)
+}
+
+// CancelDIDExpiry cancels the scheduled deactivation of a DID document.
+func (a *Wrapper) CancelDIDExpiry(ctx echo.Context, targetDID string) error {
+	id, err := did.ParseDID(targetDID)
+	if err != nil {
+		return err
+	}
+	if err = a.ExpiryScheduler.Cancel(*id, nil); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ScheduleVerificationMethodExpiry schedules the removal of a verification method from a DID document.
+func (a *Wrapper) ScheduleVerificationMethodExpiry(ctx echo.Context, targetDID string, kidStr string) error {
+	id, err := did.ParseDID(targetDID)
+	if err != nil {
+		return err
+	}
+	kid, err := did.ParseDIDURL(kidStr)
+	if err != nil {
+		return core.InvalidInputError("given kid could not be parsed: %w", err)
+	}
+	return a.scheduleExpiry(ctx, *id, kid)
+}
+
+// CancelVerificationMethodExpiry cancels the scheduled removal of a verification method.
+func (a *Wrapper) CancelVerificationMethodExpiry(ctx echo.Context, targetDID string, kidStr string) error {
+	id, err := did.ParseDID(targetDID)
+	if err != nil {
+		return err
+	}
+	kid, err := did.ParseDIDURL(kidStr)
+	if err != nil {
+		return core.InvalidInputError("given kid could not be parsed: %w", err)
+	}
+	if err = a.ExpiryScheduler.Cancel(*id, kid); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (a *Wrapper) scheduleExpiry(ctx echo.Context, id did.DID, keyID *did.DID) error {
+	req := ScheduleExpiryRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+	if req.ExpiresAt.IsZero() {
+		return core.InvalidInputError("expiresAt is required")
+	}
+	expiry := types.Expiry{DID: id, KeyID: keyID, ExpiresAt: req.ExpiresAt}
+	if err := a.ExpiryScheduler.Schedule(expiry); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, toExpiry(expiry))
+}
+
+func toExpiry(expiry types.Expiry) Expiry {
+	result := Expiry{Did: expiry.DID.String(), ExpiresAt: expiry.ExpiresAt}
+	if expiry.KeyID != nil {
+		keyID := expiry.KeyID.String()
+		result.KeyID = &keyID
+	}
+	return result
+}
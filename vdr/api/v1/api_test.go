@@ -22,12 +22,15 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/mock"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
 	"github.com/nuts-foundation/nuts-node/vdr/types"
 )
 
@@ -73,6 +76,25 @@ func TestWrapper_CreateDID(t *testing.T) {
 		assert.Equal(t, *id, didDocReturn.ID)
 	})
 
+	t.Run("ok - did:web", func(t *testing.T) {
+		ctx := newMockContext(t)
+		method := Web
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			p := f.(*DIDCreateRequest)
+			*p = DIDCreateRequest{Method: &method}
+			return nil
+		})
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any())
+		ctx.vdr.EXPECT().Create(gomock.Any()).DoAndReturn(func(options types.DIDCreationOptions) (*did.Document, crypto.Key, error) {
+			assert.Equal(t, "web", options.Method)
+			return didDoc, nil, nil
+		})
+
+		err := ctx.client.CreateDID(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("ok - non defaults", func(t *testing.T) {
 		ctx := newMockContext(t)
 
@@ -280,6 +302,258 @@ func TestWrapper_GetDID(t *testing.T) {
 	})
 }
 
+func TestWrapper_SearchDIDs(t *testing.T) {
+	id1, _ := did.ParseDID("did:nuts:1")
+	id2, _ := did.ParseDID("did:nuts:2")
+	id3, _ := did.ParseDID("did:nuts:3")
+	documents := []did.Document{{ID: *id3}, {ID: *id1}, {ID: *id2}}
+	controller := "did:nuts:4"
+	host := "example.com"
+	search := func(t *testing.T, ctx mockContext, params SearchDIDsParams) SearchDIDResults {
+		var result SearchDIDResults
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.(SearchDIDResults)
+			return nil
+		})
+		err := ctx.client.SearchDIDs(ctx.echo, params)
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("ok - all parameters", func(t *testing.T) {
+		ctx := newMockContext(t)
+		controllerDID, _ := did.ParseDID(controller)
+		keyID, _ := did.ParseDIDURL("did:nuts:1#key-1")
+		serviceType := "NutsComm"
+		endpoint := "grpc://example.com:5555"
+		ctx.docFinder.EXPECT().Find(
+			doc.IsActive(),
+			doc.ByController(*controllerDID),
+			doc.ByVerificationMethod(*keyID),
+			doc.ByAnyServiceType(serviceType),
+			doc.ByServiceEndpoint(endpoint),
+			doc.ByServiceEndpointHost(host),
+		).Return(documents, nil)
+
+		keyIDStr := keyID.String()
+		result := search(t, ctx, SearchDIDsParams{
+			Controller:          &controller,
+			VerificationMethod:  &keyIDStr,
+			ServiceType:         &serviceType,
+			ServiceEndpoint:     &endpoint,
+			ServiceEndpointHost: &host,
+		})
+
+		assert.Len(t, result.Documents, 3)
+		assert.Nil(t, result.NextCursor)
+	})
+
+	t.Run("ok - no results", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.docFinder.EXPECT().Find(doc.IsActive(), doc.ByServiceEndpointHost(host)).Return([]did.Document{}, nil)
+
+		result := search(t, ctx, SearchDIDsParams{ServiceEndpointHost: &host})
+
+		assert.NotNil(t, result.Documents)
+		assert.Empty(t, result.Documents)
+	})
+
+	t.Run("ok - paginated", func(t *testing.T) {
+		limit := 2
+		ctx := newMockContext(t)
+		ctx.docFinder.EXPECT().Find(gomock.Any()).Return(documents, nil).Times(2)
+
+		firstPage := search(t, ctx, SearchDIDsParams{Limit: &limit})
+		if !assert.NotNil(t, firstPage.NextCursor) {
+			return
+		}
+		secondPage := search(t, ctx, SearchDIDsParams{Limit: &limit, Cursor: firstPage.NextCursor})
+
+		assert.Equal(t, []did.Document{{ID: *id1}, {ID: *id2}}, firstPage.Documents)
+		assert.Equal(t, []did.Document{{ID: *id3}}, secondPage.Documents)
+		assert.Nil(t, secondPage.NextCursor)
+	})
+
+	t.Run("error - invalid controller", func(t *testing.T) {
+		ctx := newMockContext(t)
+		invalid := "invalid"
+
+		err := ctx.client.SearchDIDs(ctx.echo, SearchDIDsParams{Controller: &invalid})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - invalid verification method", func(t *testing.T) {
+		ctx := newMockContext(t)
+		invalid := "invalid"
+
+		err := ctx.client.SearchDIDs(ctx.echo, SearchDIDsParams{VerificationMethod: &invalid})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - invalid limit", func(t *testing.T) {
+		ctx := newMockContext(t)
+		limit := 0
+
+		err := ctx.client.SearchDIDs(ctx.echo, SearchDIDsParams{Limit: &limit})
+
+		assert.EqualError(t, err, "limit must be greater than 0")
+	})
+
+	t.Run("error - invalid cursor", func(t *testing.T) {
+		ctx := newMockContext(t)
+		cursor := "%%%"
+
+		err := ctx.client.SearchDIDs(ctx.echo, SearchDIDsParams{Cursor: &cursor})
+
+		assert.EqualError(t, err, "invalid cursor")
+	})
+
+	t.Run("error - find fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.docFinder.EXPECT().Find(gomock.Any()).Return(nil, errors.New("b00m!"))
+
+		err := ctx.client.SearchDIDs(ctx.echo, SearchDIDsParams{})
+
+		assert.EqualError(t, err, "b00m!")
+	})
+}
+
+func TestWrapper_GetDIDHistory(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	firstHash := hash.SHA256Sum([]byte("first"))
+	latestHash := hash.SHA256Sum([]byte("latest"))
+	docs := []did.Document{{ID: *id}, {ID: *id}}
+	metas := []types.DocumentMetadata{{Hash: firstHash}, {Hash: latestHash, PreviousHash: &firstHash}}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var versions []DIDDocumentVersion
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			versions = f2.([]DIDDocumentVersion)
+			return nil
+		})
+		ctx.vdr.EXPECT().History(*id).Return(docs, metas, nil)
+
+		err := ctx.client.GetDIDHistory(ctx.echo, id.String())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, versions, 2) {
+			return
+		}
+		assert.Equal(t, 0, versions[0].Version)
+		assert.Equal(t, firstHash, versions[0].DocumentMetadata.Hash)
+		assert.Equal(t, 1, versions[1].Version)
+		assert.Equal(t, latestHash, versions[1].DocumentMetadata.Hash)
+	})
+
+	t.Run("error - invalid DID format", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.GetDIDHistory(ctx.echo, "invalidFormattedDID")
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().History(*id).Return(nil, nil, types.ErrNotFound)
+
+		err := ctx.client.GetDIDHistory(ctx.echo, id.String())
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_GetDIDDiff(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	controller, _ := did.ParseDID("did:nuts:2")
+	firstHash := hash.SHA256Sum([]byte("first"))
+	latestHash := hash.SHA256Sum([]byte("latest"))
+	serviceID, _ := ssi.ParseURI("did:nuts:1#service")
+	service := did.Service{ID: *serviceID, Type: "type", ServiceEndpoint: "https://example.com"}
+	from := &did.Document{ID: *id, Service: []did.Service{service}}
+	to := &did.Document{ID: *id, Controller: []did.DID{*controller}}
+
+	t.Run("ok - latest version", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var diff DIDDocumentDiff
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			diff = f2.(DIDDocumentDiff)
+			return nil
+		})
+		ctx.docResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{Hash: &firstHash, AllowDeactivated: true}).Return(from, &types.DocumentMetadata{Hash: firstHash}, nil)
+		ctx.docResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(to, &types.DocumentMetadata{Hash: latestHash}, nil)
+
+		err := ctx.client.GetDIDDiff(ctx.echo, id.String(), GetDIDDiffParams{From: firstHash.String()})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, firstHash.String(), diff.From)
+		assert.Equal(t, latestHash.String(), diff.To)
+		assert.Equal(t, []string{controller.String()}, diff.AddedControllers)
+		assert.Empty(t, diff.RemovedControllers)
+		assert.Equal(t, []Service{service}, diff.RemovedServices)
+		assert.NotNil(t, diff.AddedServices)
+		assert.NotNil(t, diff.AddedVerificationMethods)
+	})
+
+	t.Run("ok - specific version", func(t *testing.T) {
+		ctx := newMockContext(t)
+		to := latestHash.String()
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any())
+		ctx.docResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{Hash: &firstHash, AllowDeactivated: true}).Return(from, &types.DocumentMetadata{Hash: firstHash}, nil)
+		ctx.docResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{Hash: &latestHash, AllowDeactivated: true}).Return(from, &types.DocumentMetadata{Hash: latestHash}, nil)
+
+		err := ctx.client.GetDIDDiff(ctx.echo, id.String(), GetDIDDiffParams{From: firstHash.String(), To: &to})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - invalid DID format", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.GetDIDDiff(ctx.echo, "invalidFormattedDID", GetDIDDiffParams{From: firstHash.String()})
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+
+	t.Run("error - invalid from hash", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.GetDIDDiff(ctx.echo, id.String(), GetDIDDiffParams{From: "invalid"})
+
+		assert.EqualError(t, err, "given from hash is not valid: encoding/hex: invalid byte: U+0069 'i'")
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - invalid to hash", func(t *testing.T) {
+		ctx := newMockContext(t)
+		to := "invalid"
+
+		err := ctx.client.GetDIDDiff(ctx.echo, id.String(), GetDIDDiffParams{From: firstHash.String(), To: &to})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - version not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.docResolver.EXPECT().Resolve(*id, gomock.Any()).Return(nil, nil, types.ErrNotFound)
+
+		err := ctx.client.GetDIDDiff(ctx.echo, id.String(), GetDIDDiffParams{From: firstHash.String()})
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, ctx.client.ResolveStatusCode(err))
+	})
+}
+
 func TestWrapper_ConflictedDIDs(t *testing.T) {
 	id, _ := did.ParseDID("did:nuts:1")
 	didDoc := &did.Document{
@@ -315,6 +589,120 @@ func TestWrapper_ConflictedDIDs(t *testing.T) {
 	})
 }
 
+func TestWrapper_ResolveConflictedDID(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	serviceID, _ := ssi.ParseURI("did:nuts:1#service")
+	head := hash.SHA256Sum([]byte("head"))
+	resolution := &types.ConflictResolution{
+		Current: did.Document{ID: *id},
+		Merged:  did.Document{ID: *id, Service: []did.Service{{ID: *serviceID, Type: "type"}}},
+		Heads:   []hash.SHA256Hash{head},
+	}
+	dryRun := true
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var result ConflictResolution
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.(ConflictResolution)
+			return nil
+		})
+		ctx.vdr.EXPECT().ResolveConflict(*id, true).Return(resolution, nil)
+
+		err := ctx.client.ResolveConflictedDID(ctx.echo, id.String(), ResolveConflictedDIDParams{DryRun: &dryRun})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, id.String(), result.Did)
+		assert.Equal(t, resolution.Merged, result.Document)
+		assert.Equal(t, []string{head.String()}, result.Heads)
+		assert.False(t, result.Published)
+		assert.Contains(t, result.Diff, "--- current")
+		assert.Contains(t, result.Diff, "+++ merged")
+		assert.Contains(t, result.Diff, "+      \"id\": \"did:nuts:1#service\"")
+	})
+
+	t.Run("ok - publishes by default", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any())
+		ctx.vdr.EXPECT().ResolveConflict(*id, false).Return(resolution, nil)
+
+		err := ctx.client.ResolveConflictedDID(ctx.echo, id.String(), ResolveConflictedDIDParams{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - invalid DID format", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.ResolveConflictedDID(ctx.echo, "invalidFormattedDID", ResolveConflictedDIDParams{})
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+
+	t.Run("error - not conflicted", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().ResolveConflict(*id, false).Return(nil, types.ErrNotConflicted)
+
+		err := ctx.client.ResolveConflictedDID(ctx.echo, id.String(), ResolveConflictedDIDParams{})
+
+		assert.ErrorIs(t, err, types.ErrNotConflicted)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+
+	t.Run("error - did not managed by this node", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().ResolveConflict(*id, false).Return(nil, types.ErrDIDNotManagedByThisNode)
+
+		err := ctx.client.ResolveConflictedDID(ctx.echo, id.String(), ResolveConflictedDIDParams{})
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+		assert.Equal(t, http.StatusForbidden, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ResolveConflictedDIDs(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	resolution := types.ConflictResolution{
+		Current:   did.Document{ID: *id},
+		Merged:    did.Document{ID: *id},
+		Published: true,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var results []ConflictResolution
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			results = f2.([]ConflictResolution)
+			return nil
+		})
+		ctx.vdr.EXPECT().ResolveConflicts(false).Return([]types.ConflictResolution{resolution}, nil)
+
+		err := ctx.client.ResolveConflictedDIDs(ctx.echo, ResolveConflictedDIDsParams{})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, results, 1) {
+			return
+		}
+		assert.Equal(t, id.String(), results[0].Did)
+		assert.True(t, results[0].Published)
+		assert.Empty(t, results[0].Diff)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().ResolveConflicts(false).Return(nil, errors.New("b00m!"))
+
+		err := ctx.client.ResolveConflictedDIDs(ctx.echo, ResolveConflictedDIDsParams{})
+
+		assert.Error(t, err)
+	})
+}
+
 func TestWrapper_UpdateDID(t *testing.T) {
 	id, _ := did.ParseDID("did:nuts:1")
 	didDoc := &did.Document{
@@ -447,6 +835,143 @@ func TestWrapper_UpdateDID(t *testing.T) {
 	})
 }
 
+func TestWrapper_ProposeDIDUpdate(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	currentHash := hash.SHA256Sum([]byte("current"))
+	request := DIDUpdateRequest{
+		Document:    did.Document{ID: *id},
+		CurrentHash: currentHash.String(),
+	}
+	proposal := &types.UpdateProposal{Current: currentHash, Document: did.Document{ID: *id}, Signatures: []string{"signature"}}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var result UpdateProposal
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*DIDUpdateRequest) = request
+			return nil
+		})
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.(UpdateProposal)
+			return nil
+		})
+		ctx.vdr.EXPECT().ProposeUpdate(*id, currentHash, request.Document).Return(proposal, nil)
+
+		err := ctx.client.ProposeDIDUpdate(ctx.echo, id.String())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, currentHash.String(), result.Current)
+		assert.Equal(t, []string{"signature"}, result.Signatures)
+	})
+
+	t.Run("error - invalid hash", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*DIDUpdateRequest) = DIDUpdateRequest{CurrentHash: "invalid"}
+			return nil
+		})
+
+		err := ctx.client.ProposeDIDUpdate(ctx.echo, id.String())
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - not managed by this node", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*DIDUpdateRequest) = request
+			return nil
+		})
+		ctx.vdr.EXPECT().ProposeUpdate(*id, currentHash, request.Document).Return(nil, types.ErrDIDNotManagedByThisNode)
+
+		err := ctx.client.ProposeDIDUpdate(ctx.echo, id.String())
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+		assert.Equal(t, http.StatusForbidden, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_SignDIDUpdateProposal(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	currentHash := hash.SHA256Sum([]byte("current"))
+	request := UpdateProposal{Current: currentHash.String(), Document: did.Document{ID: *id}, Signatures: []string{"a"}}
+	proposal := types.UpdateProposal{Current: currentHash, Document: did.Document{ID: *id}, Signatures: []string{"a"}}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var result UpdateProposal
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*UpdateProposal) = request
+			return nil
+		})
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.(UpdateProposal)
+			return nil
+		})
+		signed := proposal
+		signed.Signatures = []string{"a", "b"}
+		ctx.vdr.EXPECT().SignProposal(proposal).Return(&signed, nil)
+
+		err := ctx.client.SignDIDUpdateProposal(ctx.echo)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []string{"a", "b"}, result.Signatures)
+	})
+
+	t.Run("error - invalid proposal", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*UpdateProposal) = request
+			return nil
+		})
+		ctx.vdr.EXPECT().SignProposal(proposal).Return(nil, types.ErrInvalidProposal)
+
+		err := ctx.client.SignDIDUpdateProposal(ctx.echo)
+
+		assert.ErrorIs(t, err, types.ErrInvalidProposal)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_PublishDIDUpdateProposal(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	currentHash := hash.SHA256Sum([]byte("current"))
+	request := UpdateProposal{Current: currentHash.String(), Document: did.Document{ID: *id}, Signatures: []string{"a"}}
+	proposal := types.UpdateProposal{Current: currentHash, Document: did.Document{ID: *id}, Signatures: []string{"a"}}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*UpdateProposal) = request
+			return nil
+		})
+		ctx.echo.EXPECT().NoContent(http.StatusOK)
+		ctx.vdr.EXPECT().PublishProposal(proposal).Return(nil)
+
+		err := ctx.client.PublishDIDUpdateProposal(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - threshold not met", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*UpdateProposal) = request
+			return nil
+		})
+		ctx.vdr.EXPECT().PublishProposal(proposal).Return(types.ErrControllerThresholdNotMet)
+
+		err := ctx.client.PublishDIDUpdateProposal(ctx.echo)
+
+		assert.ErrorIs(t, err, types.ErrControllerThresholdNotMet)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+	})
+}
+
 func TestWrapper_DeactivateDID(t *testing.T) {
 	did123, _ := did.ParseDID("did:nuts:123")
 	t.Run("ok", func(t *testing.T) {
@@ -577,6 +1102,219 @@ func TestWrapper_DeleteVerificationMethod(t *testing.T) {
 	})
 }
 
+func TestWrapper_ListExpiries(t *testing.T) {
+	did123, _ := did.ParseDID("did:nuts:123")
+	did123Method, _ := did.ParseDIDURL("did:nuts:123#abc-method-1")
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var result []Expiry
+		ctx.expiry.EXPECT().List().Return([]types.Expiry{
+			{DID: *did123, ExpiresAt: expiresAt},
+			{DID: *did123, KeyID: did123Method, ExpiresAt: expiresAt},
+		}, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.([]Expiry)
+			return nil
+		})
+
+		err := ctx.client.ListExpiries(ctx.echo)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, result, 2) {
+			return
+		}
+		assert.Equal(t, did123.String(), result[0].Did)
+		assert.Nil(t, result[0].KeyID)
+		assert.Equal(t, did123Method.String(), *result[1].KeyID)
+		assert.Equal(t, expiresAt, result[1].ExpiresAt)
+	})
+
+	t.Run("ok - empty", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.expiry.EXPECT().List().Return(nil, nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, []Expiry{})
+
+		err := ctx.client.ListExpiries(ctx.echo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.expiry.EXPECT().List().Return(nil, errors.New("b00m!"))
+
+		err := ctx.client.ListExpiries(ctx.echo)
+
+		assert.EqualError(t, err, "b00m!")
+	})
+}
+
+func TestWrapper_ScheduleDIDExpiry(t *testing.T) {
+	did123, _ := did.ParseDID("did:nuts:123")
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var result Expiry
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*ScheduleExpiryRequest) = ScheduleExpiryRequest{ExpiresAt: expiresAt}
+			return nil
+		})
+		ctx.expiry.EXPECT().Schedule(types.Expiry{DID: *did123, ExpiresAt: expiresAt}).Return(nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.(Expiry)
+			return nil
+		})
+
+		err := ctx.client.ScheduleDIDExpiry(ctx.echo, did123.String())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, did123.String(), result.Did)
+		assert.Equal(t, expiresAt, result.ExpiresAt)
+	})
+
+	t.Run("error - invalid did", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.ScheduleDIDExpiry(ctx.echo, "invalid did")
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+	})
+
+	t.Run("error - missing expiresAt", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any())
+
+		err := ctx.client.ScheduleDIDExpiry(ctx.echo, did123.String())
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - not managed by this node", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*ScheduleExpiryRequest) = ScheduleExpiryRequest{ExpiresAt: expiresAt}
+			return nil
+		})
+		ctx.expiry.EXPECT().Schedule(gomock.Any()).Return(types.ErrDIDNotManagedByThisNode)
+
+		err := ctx.client.ScheduleDIDExpiry(ctx.echo, did123.String())
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+		assert.Equal(t, http.StatusForbidden, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_CancelDIDExpiry(t *testing.T) {
+	did123, _ := did.ParseDID("did:nuts:123")
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.expiry.EXPECT().Cancel(*did123, nil).Return(nil)
+		ctx.echo.EXPECT().NoContent(http.StatusNoContent)
+
+		err := ctx.client.CancelDIDExpiry(ctx.echo, did123.String())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - invalid did", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.CancelDIDExpiry(ctx.echo, "invalid did")
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+	})
+
+	t.Run("error - not scheduled", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.expiry.EXPECT().Cancel(*did123, nil).Return(types.ErrExpiryNotScheduled)
+
+		err := ctx.client.CancelDIDExpiry(ctx.echo, did123.String())
+
+		assert.ErrorIs(t, err, types.ErrExpiryNotScheduled)
+		assert.Equal(t, http.StatusNotFound, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ScheduleVerificationMethodExpiry(t *testing.T) {
+	did123, _ := did.ParseDID("did:nuts:123")
+	did123Method, _ := did.ParseDIDURL("did:nuts:123#abc-method-1")
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		var result Expiry
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*ScheduleExpiryRequest) = ScheduleExpiryRequest{ExpiresAt: expiresAt}
+			return nil
+		})
+		ctx.expiry.EXPECT().Schedule(types.Expiry{DID: *did123, KeyID: did123Method, ExpiresAt: expiresAt}).Return(nil)
+		ctx.echo.EXPECT().JSON(http.StatusOK, gomock.Any()).DoAndReturn(func(f interface{}, f2 interface{}) error {
+			result = f2.(Expiry)
+			return nil
+		})
+
+		err := ctx.client.ScheduleVerificationMethodExpiry(ctx.echo, did123.String(), did123Method.String())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, did123Method.String(), *result.KeyID)
+	})
+
+	t.Run("error - invalid kid", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.ScheduleVerificationMethodExpiry(ctx.echo, did123.String(), "invalid kid")
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+
+	t.Run("error - key not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.echo.EXPECT().Bind(gomock.Any()).DoAndReturn(func(f interface{}) error {
+			*f.(*ScheduleExpiryRequest) = ScheduleExpiryRequest{ExpiresAt: expiresAt}
+			return nil
+		})
+		ctx.expiry.EXPECT().Schedule(gomock.Any()).Return(types.ErrKeyNotFound)
+
+		err := ctx.client.ScheduleVerificationMethodExpiry(ctx.echo, did123.String(), did123Method.String())
+
+		assert.ErrorIs(t, err, types.ErrKeyNotFound)
+		assert.Equal(t, http.StatusNotFound, ctx.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_CancelVerificationMethodExpiry(t *testing.T) {
+	did123, _ := did.ParseDID("did:nuts:123")
+	did123Method, _ := did.ParseDIDURL("did:nuts:123#abc-method-1")
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.expiry.EXPECT().Cancel(*did123, did123Method).Return(nil)
+		ctx.echo.EXPECT().NoContent(http.StatusNoContent)
+
+		err := ctx.client.CancelVerificationMethodExpiry(ctx.echo, did123.String(), did123Method.String())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - invalid kid", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.client.CancelVerificationMethodExpiry(ctx.echo, did123.String(), "invalid kid")
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+}
+
 func Test_ErrorStatusCodes(t *testing.T) {
 	assert.NotNil(t, (&Wrapper{}).ResolveStatusCode(nil))
 }
@@ -587,6 +1325,8 @@ type mockContext struct {
 	vdr         *types.MockVDR
 	docResolver *types.MockDocResolver
 	docUpdater  *types.MockDocManipulator
+	docFinder   *types.MockDocFinder
+	expiry      *types.MockExpiryScheduler
 	client      *Wrapper
 }
 
@@ -596,7 +1336,9 @@ func newMockContext(t *testing.T) mockContext {
 	vdr := types.NewMockVDR(ctrl)
 	docManipulator := types.NewMockDocManipulator(ctrl)
 	docResolver := types.NewMockDocResolver(ctrl)
-	client := &Wrapper{VDR: vdr, DocManipulator: docManipulator, DocResolver: docResolver}
+	docFinder := types.NewMockDocFinder(ctrl)
+	expiryScheduler := types.NewMockExpiryScheduler(ctrl)
+	client := &Wrapper{VDR: vdr, DocManipulator: docManipulator, DocResolver: docResolver, DocFinder: docFinder, ExpiryScheduler: expiryScheduler}
 
 	t.Cleanup(func() {
 		ctrl.Finish()
@@ -608,5 +1350,7 @@ func newMockContext(t *testing.T) mockContext {
 		client:      client,
 		docResolver: docResolver,
 		docUpdater:  docManipulator,
+		docFinder:   docFinder,
+		expiry:      expiryScheduler,
 	}
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"io"
 	"net/http"
+	"time"
 )
 
 // HTTPClient holds the server address and other basic settings for the http client
@@ -93,6 +94,42 @@ func (hb HTTPClient) ConflictedDIDs() ([]DIDResolutionResult, error) {
 	return resolutionResults, nil
 }
 
+// ResolveConflict merges the conflicting versions of the given DID Document and publishes the result, unless dryRun is set.
+func (hb HTTPClient) ResolveConflict(DID string, dryRun bool) (*ConflictResolution, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().ResolveConflictedDID(ctx, DID, &ResolveConflictedDIDParams{DryRun: &dryRun})
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var result ConflictResolution
+	if err = readJSON(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResolveConflicts resolves all conflicted DID Documents controlled by the node, unless dryRun is set.
+func (hb HTTPClient) ResolveConflicts(dryRun bool) ([]ConflictResolution, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().ResolveConflictedDIDs(ctx, &ResolveConflictedDIDsParams{DryRun: &dryRun})
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var results []ConflictResolution
+	if err = readJSON(response.Body, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // Update a DID Document given a DID and its current hash.
 func (hb HTTPClient) Update(DID string, current string, next did.Document) (*did.Document, error) {
 	ctx := context.Background()
@@ -112,6 +149,55 @@ func (hb HTTPClient) Update(DID string, current string, next did.Document) (*did
 	return readDIDDocument(response.Body)
 }
 
+// ProposeUpdate prepares an update of a DID Document that must be signed by multiple controllers.
+// It returns the proposal, signed by the controllers managed by the node.
+func (hb HTTPClient) ProposeUpdate(DID string, current string, next did.Document) (*UpdateProposal, error) {
+	ctx := context.Background()
+
+	requestBody := ProposeDIDUpdateJSONRequestBody{
+		Document:    next,
+		CurrentHash: current,
+	}
+	response, err := hb.client().ProposeDIDUpdate(ctx, DID, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	return readUpdateProposal(response)
+}
+
+// SignProposal adds the signatures of the controllers managed by the node to the update proposal.
+func (hb HTTPClient) SignProposal(proposal UpdateProposal) (*UpdateProposal, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().SignDIDUpdateProposal(ctx, SignDIDUpdateProposalJSONRequestBody(proposal))
+	if err != nil {
+		return nil, err
+	}
+	return readUpdateProposal(response)
+}
+
+// PublishProposal publishes the update proposal, which must be signed by enough controllers.
+func (hb HTTPClient) PublishProposal(proposal UpdateProposal) error {
+	ctx := context.Background()
+
+	response, err := hb.client().PublishDIDUpdateProposal(ctx, PublishDIDUpdateProposalJSONRequestBody(proposal))
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusOK, response)
+}
+
+func readUpdateProposal(response *http.Response) (*UpdateProposal, error) {
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var result UpdateProposal
+	if err := readJSON(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // Deactivate a DID Document given a DID.
 // It expects a status 200 response from the server, returns an error otherwise.
 func (hb HTTPClient) Deactivate(DID string) error {
@@ -154,6 +240,65 @@ func (hb HTTPClient) DeleteVerificationMethod(DID, kid string) error {
 	return core.TestResponseCode(http.StatusNoContent, response)
 }
 
+// ListExpiries returns the scheduled expiries of DID documents and verification methods.
+func (hb HTTPClient) ListExpiries() ([]Expiry, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().ListExpiries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var results []Expiry
+	if err = readJSON(response.Body, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ScheduleExpiry schedules the expiry of a DID document, or of one of its verification methods if kid is not empty.
+func (hb HTTPClient) ScheduleExpiry(DID, kid string, expiresAt time.Time) (*Expiry, error) {
+	ctx := context.Background()
+
+	var response *http.Response
+	var err error
+	if kid == "" {
+		response, err = hb.client().ScheduleDIDExpiry(ctx, DID, ScheduleDIDExpiryJSONRequestBody{ExpiresAt: expiresAt})
+	} else {
+		response, err = hb.client().ScheduleVerificationMethodExpiry(ctx, DID, kid, ScheduleVerificationMethodExpiryJSONRequestBody{ExpiresAt: expiresAt})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	var result Expiry
+	if err = readJSON(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelExpiry cancels the scheduled expiry of a DID document, or of one of its verification methods if kid is not empty.
+func (hb HTTPClient) CancelExpiry(DID, kid string) error {
+	ctx := context.Background()
+
+	var response *http.Response
+	var err error
+	if kid == "" {
+		response, err = hb.client().CancelDIDExpiry(ctx, DID)
+	} else {
+		response, err = hb.client().CancelVerificationMethodExpiry(ctx, DID, kid)
+	}
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
 func readDIDDocument(reader io.Reader) (*did.Document, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -201,3 +346,14 @@ func readVerificationMethod(reader io.Reader) (*did.VerificationMethod, error) {
 	}
 	return &verificationMethod, nil
 }
+
+func readJSON(reader io.Reader, target interface{}) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	if err = json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w", err)
+	}
+	return nil
+}
@@ -141,6 +141,118 @@ func TestHTTPClient_ConflictedDIDs(t *testing.T) {
 	})
 }
 
+func TestHTTPClient_ResolveConflict(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		resolution := ConflictResolution{Did: vdr.TestDIDA.String(), Document: did.Document{ID: *vdr.TestDIDA}, Diff: "diff", Published: true}
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: resolution})
+		c := getClient(s.URL)
+		result, err := c.ResolveConflict(vdr.TestDIDA.String(), false)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, resolution, *result)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: problem.Problem{}})
+		c := getClient(s.URL)
+
+		_, err := c.ResolveConflict(vdr.TestDIDA.String(), true)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_ProposeUpdate(t *testing.T) {
+	proposal := UpdateProposal{Current: "current", Document: did.Document{ID: *vdr.TestDIDA}, Signatures: []string{"signature"}}
+
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: proposal})
+		c := getClient(s.URL)
+		result, err := c.ProposeUpdate(vdr.TestDIDA.String(), "current", did.Document{ID: *vdr.TestDIDA})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, proposal, *result)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusForbidden, ResponseData: problem.Problem{}})
+		c := getClient(s.URL)
+
+		_, err := c.ProposeUpdate(vdr.TestDIDA.String(), "current", did.Document{ID: *vdr.TestDIDA})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_SignProposal(t *testing.T) {
+	proposal := UpdateProposal{Current: "current", Document: did.Document{ID: *vdr.TestDIDA}, Signatures: []string{"a", "b"}}
+
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: proposal})
+		c := getClient(s.URL)
+		result, err := c.SignProposal(proposal)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, proposal, *result)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: problem.Problem{}})
+		c := getClient(s.URL)
+
+		_, err := c.SignProposal(proposal)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_PublishProposal(t *testing.T) {
+	proposal := UpdateProposal{Current: "current", Document: did.Document{ID: *vdr.TestDIDA}, Signatures: []string{"a", "b"}}
+
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK})
+		c := getClient(s.URL)
+
+		err := c.PublishProposal(proposal)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: problem.Problem{}})
+		c := getClient(s.URL)
+
+		err := c.PublishProposal(proposal)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_ResolveConflicts(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		resolutions := []ConflictResolution{{Did: vdr.TestDIDA.String(), Document: did.Document{ID: *vdr.TestDIDA}, Diff: "diff"}}
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: resolutions})
+		c := getClient(s.URL)
+		results, err := c.ResolveConflicts(true)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: problem.Problem{}})
+		c := getClient(s.URL)
+
+		_, err := c.ResolveConflicts(false)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestHTTPClient_Update(t *testing.T) {
 	didDoc := did.Document{
 		ID: *vdr.TestDIDA,
@@ -256,6 +368,95 @@ func TestHTTPClient_DeleteVerificationMethod(t *testing.T) {
 	})
 }
 
+func TestHTTPClient_ListExpiries(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		expiries := []Expiry{{Did: vdr.TestDIDA.String(), ExpiresAt: time.Now()}}
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: expiries})
+		c := getClient(s.URL)
+		results, err := c.ListExpiries()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: problem.Problem{}})
+		c := getClient(s.URL)
+
+		_, err := c.ListExpiries()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_ScheduleExpiry(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok - DID", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: Expiry{Did: vdr.TestDIDA.String(), ExpiresAt: expiresAt}})
+		c := getClient(s.URL)
+		result, err := c.ScheduleExpiry(vdr.TestDIDA.String(), "", expiresAt)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expiresAt, result.ExpiresAt)
+	})
+
+	t.Run("ok - verification method", func(t *testing.T) {
+		kid := vdr.TestMethodDIDA.String()
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusOK, ResponseData: Expiry{Did: vdr.TestDIDA.String(), KeyID: &kid, ExpiresAt: expiresAt}})
+		c := getClient(s.URL)
+		result, err := c.ScheduleExpiry(vdr.TestDIDA.String(), kid, expiresAt)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, kid, *result.KeyID)
+	})
+
+	t.Run("error - not managed by this node", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusForbidden})
+		c := getClient(s.URL)
+
+		_, err := c.ScheduleExpiry(vdr.TestDIDA.String(), "", expiresAt)
+
+		assert.EqualError(t, err, "server returned HTTP 403 (expected: 200), response: null")
+	})
+
+	t.Run("error - server problems", func(t *testing.T) {
+		c := getClient("not_an_address")
+
+		_, err := c.ScheduleExpiry(vdr.TestDIDA.String(), "", expiresAt)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_CancelExpiry(t *testing.T) {
+	t.Run("ok - DID", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusNoContent})
+		c := getClient(s.URL)
+		err := c.CancelExpiry(vdr.TestDIDA.String(), "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("ok - verification method", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusNoContent})
+		c := getClient(s.URL)
+		err := c.CancelExpiry(vdr.TestDIDA.String(), vdr.TestMethodDIDA.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - not scheduled", func(t *testing.T) {
+		s := httptest.NewServer(http2.Handler{StatusCode: http.StatusNotFound})
+		c := getClient(s.URL)
+
+		err := c.CancelExpiry(vdr.TestDIDA.String(), "")
+
+		assert.EqualError(t, err, "server returned HTTP 404 (expected: 204), response: null")
+	})
+}
+
 func TestReadDIDDocument(t *testing.T) {
 	t.Run("error - faulty stream", func(t *testing.T) {
 		_, err := readDIDDocument(errReader{})
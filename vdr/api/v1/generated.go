@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/labstack/echo/v4"
@@ -22,6 +23,30 @@ const (
 	JwtBearerAuthScopes = "jwtBearerAuth.Scopes"
 )
 
+// Defines values for DIDCreateRequestMethod.
+const (
+	Nuts DIDCreateRequestMethod = "nuts"
+	Web  DIDCreateRequestMethod = "web"
+)
+
+// ConflictResolution defines model for ConflictResolution.
+type ConflictResolution struct {
+	// The DID of the conflicted DID document.
+	Did string `json:"did"`
+
+	// Unified diff between the DID document as currently resolved and the merged DID document (document).
+	Diff string `json:"diff"`
+
+	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
+	Document DIDDocument `json:"document"`
+
+	// The conflicting transactions the update refers to.
+	Heads []string `json:"heads"`
+
+	// Whether the merged DID document has been published as update (false in case of a dry run).
+	Published bool `json:"published"`
+}
+
 // DIDCreateRequest defines model for DIDCreateRequest.
 type DIDCreateRequest struct {
 	// indicates if the generated key pair can be used for assertions.
@@ -46,10 +71,49 @@ type DIDCreateRequest struct {
 	// indicates if the generated key pair can be used for Key agreements.
 	KeyAgreement *bool `json:"keyAgreement,omitempty"`
 
+	// DID method of the new DID. Only `web` DIDs can be created if the node is configured to host did:web documents (vdr.web.url).
+	// did:web DIDs can't have controllers and are always controlled by this node.
+	Method *DIDCreateRequestMethod `json:"method,omitempty"`
+
 	// whether the generated DID Document can be altered with its own capabilityInvocation key.
 	SelfControl *bool `json:"selfControl,omitempty"`
 }
 
+// DID method of the new DID. Only `web` DIDs can be created if the node is configured to host did:web documents (vdr.web.url).
+// did:web DIDs can't have controllers and are always controlled by this node.
+type DIDCreateRequestMethod string
+
+// DIDDocumentDiff defines model for DIDDocumentDiff.
+type DIDDocumentDiff struct {
+	AddedControllers         []string             `json:"addedControllers"`
+	AddedServices            []Service            `json:"addedServices"`
+	AddedVerificationMethods []VerificationMethod `json:"addedVerificationMethods"`
+
+	// Services that are present in both versions, but with different contents. Contains the services as they are in the newer version.
+	ChangedServices []Service `json:"changedServices"`
+
+	// The version (Sha256 hash of the document) compared from.
+	From                       string               `json:"from"`
+	RemovedControllers         []string             `json:"removedControllers"`
+	RemovedServices            []Service            `json:"removedServices"`
+	RemovedVerificationMethods []VerificationMethod `json:"removedVerificationMethods"`
+
+	// The version (Sha256 hash of the document) compared to.
+	To string `json:"to"`
+}
+
+// DIDDocumentVersion defines model for DIDDocumentVersion.
+type DIDDocumentVersion struct {
+	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
+	Document DIDDocument `json:"document"`
+
+	// The DID document metadata.
+	DocumentMetadata DIDDocumentMetadata `json:"documentMetadata"`
+
+	// The version number of the DID document, starting at 0.
+	Version int `json:"version"`
+}
+
 // DIDResolutionResult defines model for DIDResolutionResult.
 type DIDResolutionResult struct {
 	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
@@ -68,9 +132,83 @@ type DIDUpdateRequest struct {
 	Document DIDDocument `json:"document"`
 }
 
+// The scheduled expiry of a DID document or verification method.
+type Expiry struct {
+	// The DID of the DID document.
+	Did string `json:"did"`
+
+	// The time at which the DID document or verification method expires, in RFC3339 format.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// The ID of the verification method that expires. If absent, the DID document itself expires.
+	KeyID *string `json:"keyID,omitempty"`
+}
+
+// ScheduleExpiryRequest defines model for ScheduleExpiryRequest.
+type ScheduleExpiryRequest struct {
+	// The time at which the DID document or verification method expires, in RFC3339 format.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Result of a DID document search.
+type SearchDIDResults struct {
+	Documents []DIDDocument `json:"documents"`
+
+	// Set if there are more results, pass it as cursor to retrieve the next page.
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// A proposed update of a DID document, co-signed by (some of) its controllers.
+type UpdateProposal struct {
+	// The hash of the DID document version the update applies to, in hex format.
+	Current string `json:"current"`
+
+	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
+	Document DIDDocument `json:"document"`
+
+	// Compact JWS signatures (with detached payload) of the controllers that approved the update.
+	Signatures []string `json:"signatures"`
+}
+
+// SearchDIDsParams defines parameters for SearchDIDs.
+type SearchDIDsParams struct {
+	// DID of a controller of the DID document.
+	Controller *string `form:"controller,omitempty" json:"controller,omitempty"`
+
+	// ID of a verification method of the DID document.
+	VerificationMethod *string `form:"verificationMethod,omitempty" json:"verificationMethod,omitempty"`
+
+	// Type of a service of the DID document.
+	ServiceType *string `form:"serviceType,omitempty" json:"serviceType,omitempty"`
+
+	// Endpoint of a service of the DID document (exact match). For compound services, each of its endpoints is matched.
+	ServiceEndpoint *string `form:"serviceEndpoint,omitempty" json:"serviceEndpoint,omitempty"`
+
+	// Host (without port, case-insensitive) of a service endpoint URL of the DID document.
+	ServiceEndpointHost *string `form:"serviceEndpointHost,omitempty" json:"serviceEndpointHost,omitempty"`
+
+	// Maximum number of DID documents to return. If not set, all matching DID documents are returned.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor to retrieve the next page of results, as returned by a previous search (nextCursor).
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
 // CreateDIDJSONBody defines parameters for CreateDID.
 type CreateDIDJSONBody = DIDCreateRequest
 
+// ResolveConflictedDIDsParams defines parameters for ResolveConflictedDIDs.
+type ResolveConflictedDIDsParams struct {
+	// If true, the merged DID document is returned but not published.
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// PublishDIDUpdateProposalJSONBody defines parameters for PublishDIDUpdateProposal.
+type PublishDIDUpdateProposalJSONBody = UpdateProposal
+
+// SignDIDUpdateProposalJSONBody defines parameters for SignDIDUpdateProposal.
+type SignDIDUpdateProposalJSONBody = UpdateProposal
+
 // GetDIDParams defines parameters for GetDID.
 type GetDIDParams struct {
 	// If a versionId parameter is provided, the DID resolution algorithm returns a specific version of the DID document.
@@ -90,12 +228,51 @@ type GetDIDParams struct {
 // UpdateDIDJSONBody defines parameters for UpdateDID.
 type UpdateDIDJSONBody = DIDUpdateRequest
 
+// GetDIDDiffParams defines parameters for GetDIDDiff.
+type GetDIDDiffParams struct {
+	// The version (Sha256 hash of the document) to compare from.
+	From string `form:"from" json:"from"`
+
+	// The version (Sha256 hash of the document) to compare to. If not provided, the latest version is used.
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ScheduleDIDExpiryJSONBody defines parameters for ScheduleDIDExpiry.
+type ScheduleDIDExpiryJSONBody = ScheduleExpiryRequest
+
+// ProposeDIDUpdateJSONBody defines parameters for ProposeDIDUpdate.
+type ProposeDIDUpdateJSONBody = DIDUpdateRequest
+
+// ResolveConflictedDIDParams defines parameters for ResolveConflictedDID.
+type ResolveConflictedDIDParams struct {
+	// If true, the merged DID document is returned but not published.
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// ScheduleVerificationMethodExpiryJSONBody defines parameters for ScheduleVerificationMethodExpiry.
+type ScheduleVerificationMethodExpiryJSONBody = ScheduleExpiryRequest
+
 // CreateDIDJSONRequestBody defines body for CreateDID for application/json ContentType.
 type CreateDIDJSONRequestBody = CreateDIDJSONBody
 
+// PublishDIDUpdateProposalJSONRequestBody defines body for PublishDIDUpdateProposal for application/json ContentType.
+type PublishDIDUpdateProposalJSONRequestBody = PublishDIDUpdateProposalJSONBody
+
+// SignDIDUpdateProposalJSONRequestBody defines body for SignDIDUpdateProposal for application/json ContentType.
+type SignDIDUpdateProposalJSONRequestBody = SignDIDUpdateProposalJSONBody
+
 // UpdateDIDJSONRequestBody defines body for UpdateDID for application/json ContentType.
 type UpdateDIDJSONRequestBody = UpdateDIDJSONBody
 
+// ScheduleDIDExpiryJSONRequestBody defines body for ScheduleDIDExpiry for application/json ContentType.
+type ScheduleDIDExpiryJSONRequestBody = ScheduleDIDExpiryJSONBody
+
+// ProposeDIDUpdateJSONRequestBody defines body for ProposeDIDUpdate for application/json ContentType.
+type ProposeDIDUpdateJSONRequestBody = ProposeDIDUpdateJSONBody
+
+// ScheduleVerificationMethodExpiryJSONRequestBody defines body for ScheduleVerificationMethodExpiry for application/json ContentType.
+type ScheduleVerificationMethodExpiryJSONRequestBody = ScheduleVerificationMethodExpiryJSONBody
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -169,6 +346,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// SearchDIDs request
+	SearchDIDs(ctx context.Context, params *SearchDIDsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// CreateDID request with any body
 	CreateDIDWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -177,6 +357,22 @@ type ClientInterface interface {
 	// ConflictedDIDs request
 	ConflictedDIDs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ResolveConflictedDIDs request
+	ResolveConflictedDIDs(ctx context.Context, params *ResolveConflictedDIDsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListExpiries request
+	ListExpiries(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PublishDIDUpdateProposal request with any body
+	PublishDIDUpdateProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PublishDIDUpdateProposal(ctx context.Context, body PublishDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SignDIDUpdateProposal request with any body
+	SignDIDUpdateProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SignDIDUpdateProposal(ctx context.Context, body SignDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeactivateDID request
 	DeactivateDID(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -188,11 +384,53 @@ type ClientInterface interface {
 
 	UpdateDID(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetDIDDiff request
+	GetDIDDiff(ctx context.Context, did string, params *GetDIDDiffParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelDIDExpiry request
+	CancelDIDExpiry(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScheduleDIDExpiry request with any body
+	ScheduleDIDExpiryWithBody(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScheduleDIDExpiry(ctx context.Context, did string, body ScheduleDIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDIDHistory request
+	GetDIDHistory(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ProposeDIDUpdate request with any body
+	ProposeDIDUpdateWithBody(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ProposeDIDUpdate(ctx context.Context, did string, body ProposeDIDUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResolveConflictedDID request
+	ResolveConflictedDID(ctx context.Context, did string, params *ResolveConflictedDIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// AddNewVerificationMethod request
 	AddNewVerificationMethod(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteVerificationMethod request
 	DeleteVerificationMethod(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelVerificationMethodExpiry request
+	CancelVerificationMethodExpiry(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScheduleVerificationMethodExpiry request with any body
+	ScheduleVerificationMethodExpiryWithBody(ctx context.Context, did string, kid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScheduleVerificationMethodExpiry(ctx context.Context, did string, kid string, body ScheduleVerificationMethodExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) SearchDIDs(ctx context.Context, params *SearchDIDsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchDIDsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) CreateDIDWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -231,8 +469,8 @@ func (c *Client) ConflictedDIDs(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeactivateDID(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeactivateDIDRequest(c.Server, did)
+func (c *Client) ResolveConflictedDIDs(ctx context.Context, params *ResolveConflictedDIDsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResolveConflictedDIDsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -243,8 +481,8 @@ func (c *Client) DeactivateDID(ctx context.Context, did string, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetDID(ctx context.Context, did string, params *GetDIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetDIDRequest(c.Server, did, params)
+func (c *Client) ListExpiries(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListExpiriesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -255,8 +493,8 @@ func (c *Client) GetDID(ctx context.Context, did string, params *GetDIDParams, r
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateDIDWithBody(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateDIDRequestWithBody(c.Server, did, contentType, body)
+func (c *Client) PublishDIDUpdateProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPublishDIDUpdateProposalRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -267,8 +505,8 @@ func (c *Client) UpdateDIDWithBody(ctx context.Context, did string, contentType
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateDID(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateDIDRequest(c.Server, did, body)
+func (c *Client) PublishDIDUpdateProposal(ctx context.Context, body PublishDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPublishDIDUpdateProposalRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -279,8 +517,8 @@ func (c *Client) UpdateDID(ctx context.Context, did string, body UpdateDIDJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) AddNewVerificationMethod(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewAddNewVerificationMethodRequest(c.Server, did)
+func (c *Client) SignDIDUpdateProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSignDIDUpdateProposalRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -291,8 +529,8 @@ func (c *Client) AddNewVerificationMethod(ctx context.Context, did string, reqEd
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteVerificationMethod(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteVerificationMethodRequest(c.Server, did, kid)
+func (c *Client) SignDIDUpdateProposal(ctx context.Context, body SignDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSignDIDUpdateProposalRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -303,138 +541,234 @@ func (c *Client) DeleteVerificationMethod(ctx context.Context, did string, kid s
 	return c.Client.Do(req)
 }
 
-// NewCreateDIDRequest calls the generic CreateDID builder with application/json body
-func NewCreateDIDRequest(server string, body CreateDIDJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) DeactivateDID(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeactivateDIDRequest(c.Server, did)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateDIDRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewCreateDIDRequestWithBody generates requests for CreateDID with any type of body
-func NewCreateDIDRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetDID(ctx context.Context, did string, params *GetDIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDIDRequest(c.Server, did, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) UpdateDIDWithBody(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateDIDRequestWithBody(c.Server, did, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) UpdateDID(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateDIDRequest(c.Server, did, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewConflictedDIDsRequest generates requests for ConflictedDIDs
-func NewConflictedDIDsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetDIDDiff(ctx context.Context, did string, params *GetDIDDiffParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDIDDiffRequest(c.Server, did, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did/conflicted")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) CancelDIDExpiry(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelDIDExpiryRequest(c.Server, did)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) ScheduleDIDExpiryWithBody(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScheduleDIDExpiryRequestWithBody(c.Server, did, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewDeactivateDIDRequest generates requests for DeactivateDID
-func NewDeactivateDIDRequest(server string, did string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+func (c *Client) ScheduleDIDExpiry(ctx context.Context, did string, body ScheduleDIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScheduleDIDExpiryRequest(c.Server, did, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetDIDHistory(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDIDHistoryRequest(c.Server, did)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ProposeDIDUpdateWithBody(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProposeDIDUpdateRequestWithBody(c.Server, did, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+func (c *Client) ProposeDIDUpdate(ctx context.Context, did string, body ProposeDIDUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProposeDIDUpdateRequest(c.Server, did, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetDIDRequest generates requests for GetDID
-func NewGetDIDRequest(server string, did string, params *GetDIDParams) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+func (c *Client) ResolveConflictedDID(ctx context.Context, did string, params *ResolveConflictedDIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResolveConflictedDIDRequest(c.Server, did, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) AddNewVerificationMethod(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddNewVerificationMethodRequest(c.Server, did)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) DeleteVerificationMethod(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteVerificationMethodRequest(c.Server, did, kid)
 	if err != nil {
 		return nil, err
 	}
-
-	queryValues := queryURL.Query()
-
-	if params.VersionId != nil {
-
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "versionId", runtime.ParamLocationQuery, *params.VersionId); err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelVerificationMethodExpiry(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelVerificationMethodExpiryRequest(c.Server, did, kid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScheduleVerificationMethodExpiryWithBody(ctx context.Context, did string, kid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScheduleVerificationMethodExpiryRequestWithBody(c.Server, did, kid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScheduleVerificationMethodExpiry(ctx context.Context, did string, kid string, body ScheduleVerificationMethodExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScheduleVerificationMethodExpiryRequest(c.Server, did, kid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewSearchDIDsRequest generates requests for SearchDIDs
+func NewSearchDIDsRequest(server string, params *SearchDIDsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Controller != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "controller", runtime.ParamLocationQuery, *params.Controller); err != nil {
 			return nil, err
 		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
@@ -448,9 +782,89 @@ func NewGetDIDRequest(server string, did string, params *GetDIDParams) (*http.Re
 
 	}
 
-	if params.VersionTime != nil {
+	if params.VerificationMethod != nil {
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "versionTime", runtime.ParamLocationQuery, *params.VersionTime); err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "verificationMethod", runtime.ParamLocationQuery, *params.VerificationMethod); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.ServiceType != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "serviceType", runtime.ParamLocationQuery, *params.ServiceType); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.ServiceEndpoint != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "serviceEndpoint", runtime.ParamLocationQuery, *params.ServiceEndpoint); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.ServiceEndpointHost != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "serviceEndpointHost", runtime.ParamLocationQuery, *params.ServiceEndpointHost); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
 			return nil, err
 		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
@@ -474,34 +888,27 @@ func NewGetDIDRequest(server string, did string, params *GetDIDParams) (*http.Re
 	return req, nil
 }
 
-// NewUpdateDIDRequest calls the generic UpdateDID builder with application/json body
-func NewUpdateDIDRequest(server string, did string, body UpdateDIDJSONRequestBody) (*http.Request, error) {
+// NewCreateDIDRequest calls the generic CreateDID builder with application/json body
+func NewCreateDIDRequest(server string, body CreateDIDJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewUpdateDIDRequestWithBody(server, did, "application/json", bodyReader)
+	return NewCreateDIDRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewUpdateDIDRequestWithBody generates requests for UpdateDID with any type of body
-func NewUpdateDIDRequestWithBody(server string, did string, contentType string, body io.Reader) (*http.Request, error) {
+// NewCreateDIDRequestWithBody generates requests for CreateDID with any type of body
+func NewCreateDIDRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s", pathParam0)
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -511,7 +918,7 @@ func NewUpdateDIDRequestWithBody(server string, did string, contentType string,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -521,23 +928,43 @@ func NewUpdateDIDRequestWithBody(server string, did string, contentType string,
 	return req, nil
 }
 
-// NewAddNewVerificationMethodRequest generates requests for AddNewVerificationMethod
-func NewAddNewVerificationMethodRequest(server string, did string) (*http.Request, error) {
+// NewConflictedDIDsRequest generates requests for ConflictedDIDs
+func NewConflictedDIDsRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/conflicted")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return req, nil
+}
+
+// NewResolveConflictedDIDsRequest generates requests for ResolveConflictedDIDs
+func NewResolveConflictedDIDsRequest(server string, params *ResolveConflictedDIDsParams) (*http.Request, error) {
+	var err error
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/verificationmethod", pathParam0)
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/conflicted/resolve")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -547,6 +974,26 @@ func NewAddNewVerificationMethodRequest(server string, did string) (*http.Reques
 		return nil, err
 	}
 
+	queryValues := queryURL.Query()
+
+	if params.DryRun != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dryRun", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
 	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -555,30 +1002,54 @@ func NewAddNewVerificationMethodRequest(server string, did string) (*http.Reques
 	return req, nil
 }
 
-// NewDeleteVerificationMethodRequest generates requests for DeleteVerificationMethod
-func NewDeleteVerificationMethodRequest(server string, did string, kid string) (*http.Request, error) {
+// NewListExpiriesRequest generates requests for ListExpiries
+func NewListExpiriesRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/expiry")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam1 string
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "kid", runtime.ParamLocationPath, kid)
+	return req, nil
+}
+
+// NewPublishDIDUpdateProposalRequest calls the generic PublishDIDUpdateProposal builder with application/json body
+func NewPublishDIDUpdateProposalRequest(server string, body PublishDIDUpdateProposalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPublishDIDUpdateProposalRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPublishDIDUpdateProposalRequestWithBody generates requests for PublishDIDUpdateProposal with any type of body
+func NewPublishDIDUpdateProposalRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/verificationmethod/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/proposal/publish")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -588,90 +1059,1110 @@ func NewDeleteVerificationMethodRequest(server string, did string, kid string) (
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewSignDIDUpdateProposalRequest calls the generic SignDIDUpdateProposal builder with application/json body
+func NewSignDIDUpdateProposalRequest(server string, body SignDIDUpdateProposalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewSignDIDUpdateProposalRequestWithBody(server, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewSignDIDUpdateProposalRequestWithBody generates requests for SignDIDUpdateProposal with any type of body
+func NewSignDIDUpdateProposalRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/proposal/sign")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
+// NewDeactivateDIDRequest generates requests for DeactivateDID
+func NewDeactivateDIDRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDIDRequest generates requests for GetDID
+func NewGetDIDRequest(server string, did string, params *GetDIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.VersionId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "versionId", runtime.ParamLocationQuery, *params.VersionId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
-		c.Server = newBaseURL.String()
-		return nil
+
+	}
+
+	if params.VersionTime != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "versionTime", runtime.ParamLocationQuery, *params.VersionTime); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
+
+	return req, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// CreateDID request with any body
-	CreateDIDWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error)
+// NewUpdateDIDRequest calls the generic UpdateDID builder with application/json body
+func NewUpdateDIDRequest(server string, did string, body UpdateDIDJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateDIDRequestWithBody(server, did, "application/json", bodyReader)
+}
 
-	CreateDIDWithResponse(ctx context.Context, body CreateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error)
+// NewUpdateDIDRequestWithBody generates requests for UpdateDID with any type of body
+func NewUpdateDIDRequestWithBody(server string, did string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	// ConflictedDIDs request
-	ConflictedDIDsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConflictedDIDsResponse, error)
+	var pathParam0 string
 
-	// DeactivateDID request
-	DeactivateDIDWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*DeactivateDIDResponse, error)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetDID request
-	GetDIDWithResponse(ctx context.Context, did string, params *GetDIDParams, reqEditors ...RequestEditorFn) (*GetDIDResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// UpdateDID request with any body
-	UpdateDIDWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error)
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	UpdateDIDWithResponse(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// AddNewVerificationMethod request
-	AddNewVerificationMethodWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*AddNewVerificationMethodResponse, error)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// DeleteVerificationMethod request
-	DeleteVerificationMethodWithResponse(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*DeleteVerificationMethodResponse, error)
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetDIDDiffRequest generates requests for GetDIDDiff
+func NewGetDIDDiffRequest(server string, did string, params *GetDIDDiffParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/diff", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, params.From); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if params.To != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCancelDIDExpiryRequest generates requests for CancelDIDExpiry
+func NewCancelDIDExpiryRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/expiry", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewScheduleDIDExpiryRequest calls the generic ScheduleDIDExpiry builder with application/json body
+func NewScheduleDIDExpiryRequest(server string, did string, body ScheduleDIDExpiryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScheduleDIDExpiryRequestWithBody(server, did, "application/json", bodyReader)
+}
+
+// NewScheduleDIDExpiryRequestWithBody generates requests for ScheduleDIDExpiry with any type of body
+func NewScheduleDIDExpiryRequestWithBody(server string, did string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/expiry", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetDIDHistoryRequest generates requests for GetDIDHistory
+func NewGetDIDHistoryRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewProposeDIDUpdateRequest calls the generic ProposeDIDUpdate builder with application/json body
+func NewProposeDIDUpdateRequest(server string, did string, body ProposeDIDUpdateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewProposeDIDUpdateRequestWithBody(server, did, "application/json", bodyReader)
+}
+
+// NewProposeDIDUpdateRequestWithBody generates requests for ProposeDIDUpdate with any type of body
+func NewProposeDIDUpdateRequestWithBody(server string, did string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/proposal", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewResolveConflictedDIDRequest generates requests for ResolveConflictedDID
+func NewResolveConflictedDIDRequest(server string, did string, params *ResolveConflictedDIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/resolve-conflict", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.DryRun != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dryRun", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddNewVerificationMethodRequest generates requests for AddNewVerificationMethod
+func NewAddNewVerificationMethodRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/verificationmethod", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteVerificationMethodRequest generates requests for DeleteVerificationMethod
+func NewDeleteVerificationMethodRequest(server string, did string, kid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "kid", runtime.ParamLocationPath, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/verificationmethod/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCancelVerificationMethodExpiryRequest generates requests for CancelVerificationMethodExpiry
+func NewCancelVerificationMethodExpiryRequest(server string, did string, kid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "kid", runtime.ParamLocationPath, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/verificationmethod/%s/expiry", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewScheduleVerificationMethodExpiryRequest calls the generic ScheduleVerificationMethodExpiry builder with application/json body
+func NewScheduleVerificationMethodExpiryRequest(server string, did string, kid string, body ScheduleVerificationMethodExpiryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScheduleVerificationMethodExpiryRequestWithBody(server, did, kid, "application/json", bodyReader)
+}
+
+// NewScheduleVerificationMethodExpiryRequestWithBody generates requests for ScheduleVerificationMethodExpiry with any type of body
+func NewScheduleVerificationMethodExpiryRequestWithBody(server string, did string, kid string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "kid", runtime.ParamLocationPath, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v1/did/%s/verificationmethod/%s/expiry", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// SearchDIDs request
+	SearchDIDsWithResponse(ctx context.Context, params *SearchDIDsParams, reqEditors ...RequestEditorFn) (*SearchDIDsResponse, error)
+
+	// CreateDID request with any body
+	CreateDIDWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error)
+
+	CreateDIDWithResponse(ctx context.Context, body CreateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error)
+
+	// ConflictedDIDs request
+	ConflictedDIDsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConflictedDIDsResponse, error)
+
+	// ResolveConflictedDIDs request
+	ResolveConflictedDIDsWithResponse(ctx context.Context, params *ResolveConflictedDIDsParams, reqEditors ...RequestEditorFn) (*ResolveConflictedDIDsResponse, error)
+
+	// ListExpiries request
+	ListExpiriesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListExpiriesResponse, error)
+
+	// PublishDIDUpdateProposal request with any body
+	PublishDIDUpdateProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PublishDIDUpdateProposalResponse, error)
+
+	PublishDIDUpdateProposalWithResponse(ctx context.Context, body PublishDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*PublishDIDUpdateProposalResponse, error)
+
+	// SignDIDUpdateProposal request with any body
+	SignDIDUpdateProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SignDIDUpdateProposalResponse, error)
+
+	SignDIDUpdateProposalWithResponse(ctx context.Context, body SignDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*SignDIDUpdateProposalResponse, error)
+
+	// DeactivateDID request
+	DeactivateDIDWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*DeactivateDIDResponse, error)
+
+	// GetDID request
+	GetDIDWithResponse(ctx context.Context, did string, params *GetDIDParams, reqEditors ...RequestEditorFn) (*GetDIDResponse, error)
+
+	// UpdateDID request with any body
+	UpdateDIDWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error)
+
+	UpdateDIDWithResponse(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error)
+
+	// GetDIDDiff request
+	GetDIDDiffWithResponse(ctx context.Context, did string, params *GetDIDDiffParams, reqEditors ...RequestEditorFn) (*GetDIDDiffResponse, error)
+
+	// CancelDIDExpiry request
+	CancelDIDExpiryWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*CancelDIDExpiryResponse, error)
+
+	// ScheduleDIDExpiry request with any body
+	ScheduleDIDExpiryWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleDIDExpiryResponse, error)
+
+	ScheduleDIDExpiryWithResponse(ctx context.Context, did string, body ScheduleDIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*ScheduleDIDExpiryResponse, error)
+
+	// GetDIDHistory request
+	GetDIDHistoryWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*GetDIDHistoryResponse, error)
+
+	// ProposeDIDUpdate request with any body
+	ProposeDIDUpdateWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ProposeDIDUpdateResponse, error)
+
+	ProposeDIDUpdateWithResponse(ctx context.Context, did string, body ProposeDIDUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*ProposeDIDUpdateResponse, error)
+
+	// ResolveConflictedDID request
+	ResolveConflictedDIDWithResponse(ctx context.Context, did string, params *ResolveConflictedDIDParams, reqEditors ...RequestEditorFn) (*ResolveConflictedDIDResponse, error)
+
+	// AddNewVerificationMethod request
+	AddNewVerificationMethodWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*AddNewVerificationMethodResponse, error)
+
+	// DeleteVerificationMethod request
+	DeleteVerificationMethodWithResponse(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*DeleteVerificationMethodResponse, error)
+
+	// CancelVerificationMethodExpiry request
+	CancelVerificationMethodExpiryWithResponse(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*CancelVerificationMethodExpiryResponse, error)
+
+	// ScheduleVerificationMethodExpiry request with any body
+	ScheduleVerificationMethodExpiryWithBodyWithResponse(ctx context.Context, did string, kid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleVerificationMethodExpiryResponse, error)
+
+	ScheduleVerificationMethodExpiryWithResponse(ctx context.Context, did string, kid string, body ScheduleVerificationMethodExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*ScheduleVerificationMethodExpiryResponse, error)
+}
+
+type SearchDIDsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchDIDResults
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchDIDsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchDIDsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateDIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateDIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateDIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConflictedDIDsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]DIDResolutionResult
+}
+
+// Status returns HTTPResponse.Status
+func (r ConflictedDIDsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConflictedDIDsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResolveConflictedDIDsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ConflictResolution
+}
+
+// Status returns HTTPResponse.Status
+func (r ResolveConflictedDIDsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResolveConflictedDIDsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListExpiriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Expiry
+}
+
+// Status returns HTTPResponse.Status
+func (r ListExpiriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListExpiriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PublishDIDUpdateProposalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PublishDIDUpdateProposalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PublishDIDUpdateProposalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SignDIDUpdateProposalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UpdateProposal
+}
+
+// Status returns HTTPResponse.Status
+func (r SignDIDUpdateProposalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SignDIDUpdateProposalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeactivateDIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeactivateDIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeactivateDIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DIDResolutionResult
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateDIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateDIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateDIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDIDDiffResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DIDDocumentDiff
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDIDDiffResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDIDDiffResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelDIDExpiryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelDIDExpiryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelDIDExpiryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ScheduleDIDExpiryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Expiry
+}
+
+// Status returns HTTPResponse.Status
+func (r ScheduleDIDExpiryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScheduleDIDExpiryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDIDHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]DIDDocumentVersion
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDIDHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDIDHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ProposeDIDUpdateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UpdateProposal
+}
+
+// Status returns HTTPResponse.Status
+func (r ProposeDIDUpdateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ProposeDIDUpdateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type CreateDIDResponse struct {
+type ResolveConflictedDIDResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *ConflictResolution
 }
 
 // Status returns HTTPResponse.Status
-func (r CreateDIDResponse) Status() string {
+func (r ResolveConflictedDIDResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -679,21 +2170,20 @@ func (r CreateDIDResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r CreateDIDResponse) StatusCode() int {
+func (r ResolveConflictedDIDResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ConflictedDIDsResponse struct {
+type AddNewVerificationMethodResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *[]DIDResolutionResult
 }
 
 // Status returns HTTPResponse.Status
-func (r ConflictedDIDsResponse) Status() string {
+func (r AddNewVerificationMethodResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -701,20 +2191,20 @@ func (r ConflictedDIDsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ConflictedDIDsResponse) StatusCode() int {
+func (r AddNewVerificationMethodResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeactivateDIDResponse struct {
+type DeleteVerificationMethodResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 }
 
 // Status returns HTTPResponse.Status
-func (r DeactivateDIDResponse) Status() string {
+func (r DeleteVerificationMethodResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -722,21 +2212,20 @@ func (r DeactivateDIDResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r DeactivateDIDResponse) StatusCode() int {
+func (r DeleteVerificationMethodResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetDIDResponse struct {
+type CancelVerificationMethodExpiryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *DIDResolutionResult
 }
 
 // Status returns HTTPResponse.Status
-func (r GetDIDResponse) Status() string {
+func (r CancelVerificationMethodExpiryResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -744,187 +2233,575 @@ func (r GetDIDResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetDIDResponse) StatusCode() int {
+func (r CancelVerificationMethodExpiryResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type UpdateDIDResponse struct {
+type ScheduleVerificationMethodExpiryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *Expiry
 }
 
 // Status returns HTTPResponse.Status
-func (r UpdateDIDResponse) Status() string {
+func (r ScheduleVerificationMethodExpiryResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScheduleVerificationMethodExpiryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// SearchDIDsWithResponse request returning *SearchDIDsResponse
+func (c *ClientWithResponses) SearchDIDsWithResponse(ctx context.Context, params *SearchDIDsParams, reqEditors ...RequestEditorFn) (*SearchDIDsResponse, error) {
+	rsp, err := c.SearchDIDs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchDIDsResponse(rsp)
+}
+
+// CreateDIDWithBodyWithResponse request with arbitrary body returning *CreateDIDResponse
+func (c *ClientWithResponses) CreateDIDWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error) {
+	rsp, err := c.CreateDIDWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateDIDResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateDIDWithResponse(ctx context.Context, body CreateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error) {
+	rsp, err := c.CreateDID(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateDIDResponse(rsp)
+}
+
+// ConflictedDIDsWithResponse request returning *ConflictedDIDsResponse
+func (c *ClientWithResponses) ConflictedDIDsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConflictedDIDsResponse, error) {
+	rsp, err := c.ConflictedDIDs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConflictedDIDsResponse(rsp)
+}
+
+// ResolveConflictedDIDsWithResponse request returning *ResolveConflictedDIDsResponse
+func (c *ClientWithResponses) ResolveConflictedDIDsWithResponse(ctx context.Context, params *ResolveConflictedDIDsParams, reqEditors ...RequestEditorFn) (*ResolveConflictedDIDsResponse, error) {
+	rsp, err := c.ResolveConflictedDIDs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveConflictedDIDsResponse(rsp)
+}
+
+// ListExpiriesWithResponse request returning *ListExpiriesResponse
+func (c *ClientWithResponses) ListExpiriesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListExpiriesResponse, error) {
+	rsp, err := c.ListExpiries(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListExpiriesResponse(rsp)
+}
+
+// PublishDIDUpdateProposalWithBodyWithResponse request with arbitrary body returning *PublishDIDUpdateProposalResponse
+func (c *ClientWithResponses) PublishDIDUpdateProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PublishDIDUpdateProposalResponse, error) {
+	rsp, err := c.PublishDIDUpdateProposalWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishDIDUpdateProposalResponse(rsp)
+}
+
+func (c *ClientWithResponses) PublishDIDUpdateProposalWithResponse(ctx context.Context, body PublishDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*PublishDIDUpdateProposalResponse, error) {
+	rsp, err := c.PublishDIDUpdateProposal(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishDIDUpdateProposalResponse(rsp)
+}
+
+// SignDIDUpdateProposalWithBodyWithResponse request with arbitrary body returning *SignDIDUpdateProposalResponse
+func (c *ClientWithResponses) SignDIDUpdateProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SignDIDUpdateProposalResponse, error) {
+	rsp, err := c.SignDIDUpdateProposalWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSignDIDUpdateProposalResponse(rsp)
+}
+
+func (c *ClientWithResponses) SignDIDUpdateProposalWithResponse(ctx context.Context, body SignDIDUpdateProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*SignDIDUpdateProposalResponse, error) {
+	rsp, err := c.SignDIDUpdateProposal(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSignDIDUpdateProposalResponse(rsp)
+}
+
+// DeactivateDIDWithResponse request returning *DeactivateDIDResponse
+func (c *ClientWithResponses) DeactivateDIDWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*DeactivateDIDResponse, error) {
+	rsp, err := c.DeactivateDID(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeactivateDIDResponse(rsp)
+}
+
+// GetDIDWithResponse request returning *GetDIDResponse
+func (c *ClientWithResponses) GetDIDWithResponse(ctx context.Context, did string, params *GetDIDParams, reqEditors ...RequestEditorFn) (*GetDIDResponse, error) {
+	rsp, err := c.GetDID(ctx, did, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDIDResponse(rsp)
+}
+
+// UpdateDIDWithBodyWithResponse request with arbitrary body returning *UpdateDIDResponse
+func (c *ClientWithResponses) UpdateDIDWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error) {
+	rsp, err := c.UpdateDIDWithBody(ctx, did, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateDIDResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateDIDWithResponse(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error) {
+	rsp, err := c.UpdateDID(ctx, did, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateDIDResponse(rsp)
+}
+
+// GetDIDDiffWithResponse request returning *GetDIDDiffResponse
+func (c *ClientWithResponses) GetDIDDiffWithResponse(ctx context.Context, did string, params *GetDIDDiffParams, reqEditors ...RequestEditorFn) (*GetDIDDiffResponse, error) {
+	rsp, err := c.GetDIDDiff(ctx, did, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDIDDiffResponse(rsp)
+}
+
+// CancelDIDExpiryWithResponse request returning *CancelDIDExpiryResponse
+func (c *ClientWithResponses) CancelDIDExpiryWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*CancelDIDExpiryResponse, error) {
+	rsp, err := c.CancelDIDExpiry(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelDIDExpiryResponse(rsp)
+}
+
+// ScheduleDIDExpiryWithBodyWithResponse request with arbitrary body returning *ScheduleDIDExpiryResponse
+func (c *ClientWithResponses) ScheduleDIDExpiryWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleDIDExpiryResponse, error) {
+	rsp, err := c.ScheduleDIDExpiryWithBody(ctx, did, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScheduleDIDExpiryResponse(rsp)
+}
+
+func (c *ClientWithResponses) ScheduleDIDExpiryWithResponse(ctx context.Context, did string, body ScheduleDIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*ScheduleDIDExpiryResponse, error) {
+	rsp, err := c.ScheduleDIDExpiry(ctx, did, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScheduleDIDExpiryResponse(rsp)
+}
+
+// GetDIDHistoryWithResponse request returning *GetDIDHistoryResponse
+func (c *ClientWithResponses) GetDIDHistoryWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*GetDIDHistoryResponse, error) {
+	rsp, err := c.GetDIDHistory(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDIDHistoryResponse(rsp)
+}
+
+// ProposeDIDUpdateWithBodyWithResponse request with arbitrary body returning *ProposeDIDUpdateResponse
+func (c *ClientWithResponses) ProposeDIDUpdateWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ProposeDIDUpdateResponse, error) {
+	rsp, err := c.ProposeDIDUpdateWithBody(ctx, did, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProposeDIDUpdateResponse(rsp)
+}
+
+func (c *ClientWithResponses) ProposeDIDUpdateWithResponse(ctx context.Context, did string, body ProposeDIDUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*ProposeDIDUpdateResponse, error) {
+	rsp, err := c.ProposeDIDUpdate(ctx, did, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProposeDIDUpdateResponse(rsp)
+}
+
+// ResolveConflictedDIDWithResponse request returning *ResolveConflictedDIDResponse
+func (c *ClientWithResponses) ResolveConflictedDIDWithResponse(ctx context.Context, did string, params *ResolveConflictedDIDParams, reqEditors ...RequestEditorFn) (*ResolveConflictedDIDResponse, error) {
+	rsp, err := c.ResolveConflictedDID(ctx, did, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveConflictedDIDResponse(rsp)
+}
+
+// AddNewVerificationMethodWithResponse request returning *AddNewVerificationMethodResponse
+func (c *ClientWithResponses) AddNewVerificationMethodWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*AddNewVerificationMethodResponse, error) {
+	rsp, err := c.AddNewVerificationMethod(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddNewVerificationMethodResponse(rsp)
+}
+
+// DeleteVerificationMethodWithResponse request returning *DeleteVerificationMethodResponse
+func (c *ClientWithResponses) DeleteVerificationMethodWithResponse(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*DeleteVerificationMethodResponse, error) {
+	rsp, err := c.DeleteVerificationMethod(ctx, did, kid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteVerificationMethodResponse(rsp)
+}
+
+// CancelVerificationMethodExpiryWithResponse request returning *CancelVerificationMethodExpiryResponse
+func (c *ClientWithResponses) CancelVerificationMethodExpiryWithResponse(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*CancelVerificationMethodExpiryResponse, error) {
+	rsp, err := c.CancelVerificationMethodExpiry(ctx, did, kid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelVerificationMethodExpiryResponse(rsp)
+}
+
+// ScheduleVerificationMethodExpiryWithBodyWithResponse request with arbitrary body returning *ScheduleVerificationMethodExpiryResponse
+func (c *ClientWithResponses) ScheduleVerificationMethodExpiryWithBodyWithResponse(ctx context.Context, did string, kid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleVerificationMethodExpiryResponse, error) {
+	rsp, err := c.ScheduleVerificationMethodExpiryWithBody(ctx, did, kid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScheduleVerificationMethodExpiryResponse(rsp)
+}
+
+func (c *ClientWithResponses) ScheduleVerificationMethodExpiryWithResponse(ctx context.Context, did string, kid string, body ScheduleVerificationMethodExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*ScheduleVerificationMethodExpiryResponse, error) {
+	rsp, err := c.ScheduleVerificationMethodExpiry(ctx, did, kid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScheduleVerificationMethodExpiryResponse(rsp)
+}
+
+// ParseSearchDIDsResponse parses an HTTP response from a SearchDIDsWithResponse call
+func ParseSearchDIDsResponse(rsp *http.Response) (*SearchDIDsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchDIDsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchDIDResults
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateDIDResponse parses an HTTP response from a CreateDIDWithResponse call
+func ParseCreateDIDResponse(rsp *http.Response) (*CreateDIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateDIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseConflictedDIDsResponse parses an HTTP response from a ConflictedDIDsWithResponse call
+func ParseConflictedDIDsResponse(rsp *http.Response) (*ConflictedDIDsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ConflictedDIDsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []DIDResolutionResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r UpdateDIDResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type AddNewVerificationMethodResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r AddNewVerificationMethodResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseResolveConflictedDIDsResponse parses an HTTP response from a ResolveConflictedDIDsWithResponse call
+func ParseResolveConflictedDIDsResponse(rsp *http.Response) (*ResolveConflictedDIDsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r AddNewVerificationMethodResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &ResolveConflictedDIDsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type DeleteVerificationMethodResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ConflictResolution
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r DeleteVerificationMethodResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteVerificationMethodResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// CreateDIDWithBodyWithResponse request with arbitrary body returning *CreateDIDResponse
-func (c *ClientWithResponses) CreateDIDWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error) {
-	rsp, err := c.CreateDIDWithBody(ctx, contentType, body, reqEditors...)
+// ParseListExpiriesResponse parses an HTTP response from a ListExpiriesWithResponse call
+func ParseListExpiriesResponse(rsp *http.Response) (*ListExpiriesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateDIDResponse(rsp)
-}
 
-func (c *ClientWithResponses) CreateDIDWithResponse(ctx context.Context, body CreateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDIDResponse, error) {
-	rsp, err := c.CreateDID(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ListExpiriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseCreateDIDResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Expiry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ConflictedDIDsWithResponse request returning *ConflictedDIDsResponse
-func (c *ClientWithResponses) ConflictedDIDsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConflictedDIDsResponse, error) {
-	rsp, err := c.ConflictedDIDs(ctx, reqEditors...)
+// ParsePublishDIDUpdateProposalResponse parses an HTTP response from a PublishDIDUpdateProposalWithResponse call
+func ParsePublishDIDUpdateProposalResponse(rsp *http.Response) (*PublishDIDUpdateProposalResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseConflictedDIDsResponse(rsp)
+
+	response := &PublishDIDUpdateProposalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
 }
 
-// DeactivateDIDWithResponse request returning *DeactivateDIDResponse
-func (c *ClientWithResponses) DeactivateDIDWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*DeactivateDIDResponse, error) {
-	rsp, err := c.DeactivateDID(ctx, did, reqEditors...)
+// ParseSignDIDUpdateProposalResponse parses an HTTP response from a SignDIDUpdateProposalWithResponse call
+func ParseSignDIDUpdateProposalResponse(rsp *http.Response) (*SignDIDUpdateProposalResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeactivateDIDResponse(rsp)
+
+	response := &SignDIDUpdateProposalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UpdateProposal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// GetDIDWithResponse request returning *GetDIDResponse
-func (c *ClientWithResponses) GetDIDWithResponse(ctx context.Context, did string, params *GetDIDParams, reqEditors ...RequestEditorFn) (*GetDIDResponse, error) {
-	rsp, err := c.GetDID(ctx, did, params, reqEditors...)
+// ParseDeactivateDIDResponse parses an HTTP response from a DeactivateDIDWithResponse call
+func ParseDeactivateDIDResponse(rsp *http.Response) (*DeactivateDIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetDIDResponse(rsp)
+
+	response := &DeactivateDIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
 }
 
-// UpdateDIDWithBodyWithResponse request with arbitrary body returning *UpdateDIDResponse
-func (c *ClientWithResponses) UpdateDIDWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error) {
-	rsp, err := c.UpdateDIDWithBody(ctx, did, contentType, body, reqEditors...)
+// ParseGetDIDResponse parses an HTTP response from a GetDIDWithResponse call
+func ParseGetDIDResponse(rsp *http.Response) (*GetDIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseUpdateDIDResponse(rsp)
+
+	response := &GetDIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DIDResolutionResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) UpdateDIDWithResponse(ctx context.Context, did string, body UpdateDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateDIDResponse, error) {
-	rsp, err := c.UpdateDID(ctx, did, body, reqEditors...)
+// ParseUpdateDIDResponse parses an HTTP response from a UpdateDIDWithResponse call
+func ParseUpdateDIDResponse(rsp *http.Response) (*UpdateDIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseUpdateDIDResponse(rsp)
+
+	response := &UpdateDIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
 }
 
-// AddNewVerificationMethodWithResponse request returning *AddNewVerificationMethodResponse
-func (c *ClientWithResponses) AddNewVerificationMethodWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*AddNewVerificationMethodResponse, error) {
-	rsp, err := c.AddNewVerificationMethod(ctx, did, reqEditors...)
+// ParseGetDIDDiffResponse parses an HTTP response from a GetDIDDiffWithResponse call
+func ParseGetDIDDiffResponse(rsp *http.Response) (*GetDIDDiffResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseAddNewVerificationMethodResponse(rsp)
+
+	response := &GetDIDDiffResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DIDDocumentDiff
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// DeleteVerificationMethodWithResponse request returning *DeleteVerificationMethodResponse
-func (c *ClientWithResponses) DeleteVerificationMethodWithResponse(ctx context.Context, did string, kid string, reqEditors ...RequestEditorFn) (*DeleteVerificationMethodResponse, error) {
-	rsp, err := c.DeleteVerificationMethod(ctx, did, kid, reqEditors...)
+// ParseCancelDIDExpiryResponse parses an HTTP response from a CancelDIDExpiryWithResponse call
+func ParseCancelDIDExpiryResponse(rsp *http.Response) (*CancelDIDExpiryResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteVerificationMethodResponse(rsp)
+
+	response := &CancelDIDExpiryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
 }
 
-// ParseCreateDIDResponse parses an HTTP response from a CreateDIDWithResponse call
-func ParseCreateDIDResponse(rsp *http.Response) (*CreateDIDResponse, error) {
+// ParseScheduleDIDExpiryResponse parses an HTTP response from a ScheduleDIDExpiryWithResponse call
+func ParseScheduleDIDExpiryResponse(rsp *http.Response) (*ScheduleDIDExpiryResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateDIDResponse{
+	response := &ScheduleDIDExpiryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Expiry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
 	return response, nil
 }
 
-// ParseConflictedDIDsResponse parses an HTTP response from a ConflictedDIDsWithResponse call
-func ParseConflictedDIDsResponse(rsp *http.Response) (*ConflictedDIDsResponse, error) {
+// ParseGetDIDHistoryResponse parses an HTTP response from a GetDIDHistoryWithResponse call
+func ParseGetDIDHistoryResponse(rsp *http.Response) (*GetDIDHistoryResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ConflictedDIDsResponse{
+	response := &GetDIDHistoryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []DIDResolutionResult
+		var dest []DIDDocumentVersion
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -935,38 +2812,48 @@ func ParseConflictedDIDsResponse(rsp *http.Response) (*ConflictedDIDsResponse, e
 	return response, nil
 }
 
-// ParseDeactivateDIDResponse parses an HTTP response from a DeactivateDIDWithResponse call
-func ParseDeactivateDIDResponse(rsp *http.Response) (*DeactivateDIDResponse, error) {
+// ParseProposeDIDUpdateResponse parses an HTTP response from a ProposeDIDUpdateWithResponse call
+func ParseProposeDIDUpdateResponse(rsp *http.Response) (*ProposeDIDUpdateResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeactivateDIDResponse{
+	response := &ProposeDIDUpdateResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UpdateProposal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
 	return response, nil
 }
 
-// ParseGetDIDResponse parses an HTTP response from a GetDIDWithResponse call
-func ParseGetDIDResponse(rsp *http.Response) (*GetDIDResponse, error) {
+// ParseResolveConflictedDIDResponse parses an HTTP response from a ResolveConflictedDIDWithResponse call
+func ParseResolveConflictedDIDResponse(rsp *http.Response) (*ResolveConflictedDIDResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetDIDResponse{
+	response := &ResolveConflictedDIDResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest DIDResolutionResult
+		var dest ConflictResolution
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -977,15 +2864,31 @@ func ParseGetDIDResponse(rsp *http.Response) (*GetDIDResponse, error) {
 	return response, nil
 }
 
-// ParseUpdateDIDResponse parses an HTTP response from a UpdateDIDWithResponse call
-func ParseUpdateDIDResponse(rsp *http.Response) (*UpdateDIDResponse, error) {
+// ParseAddNewVerificationMethodResponse parses an HTTP response from a AddNewVerificationMethodWithResponse call
+func ParseAddNewVerificationMethodResponse(rsp *http.Response) (*AddNewVerificationMethodResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddNewVerificationMethodResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDeleteVerificationMethodResponse parses an HTTP response from a DeleteVerificationMethodWithResponse call
+func ParseDeleteVerificationMethodResponse(rsp *http.Response) (*DeleteVerificationMethodResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &UpdateDIDResponse{
+	response := &DeleteVerificationMethodResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -993,15 +2896,15 @@ func ParseUpdateDIDResponse(rsp *http.Response) (*UpdateDIDResponse, error) {
 	return response, nil
 }
 
-// ParseAddNewVerificationMethodResponse parses an HTTP response from a AddNewVerificationMethodWithResponse call
-func ParseAddNewVerificationMethodResponse(rsp *http.Response) (*AddNewVerificationMethodResponse, error) {
+// ParseCancelVerificationMethodExpiryResponse parses an HTTP response from a CancelVerificationMethodExpiryWithResponse call
+func ParseCancelVerificationMethodExpiryResponse(rsp *http.Response) (*CancelVerificationMethodExpiryResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &AddNewVerificationMethodResponse{
+	response := &CancelVerificationMethodExpiryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1009,30 +2912,55 @@ func ParseAddNewVerificationMethodResponse(rsp *http.Response) (*AddNewVerificat
 	return response, nil
 }
 
-// ParseDeleteVerificationMethodResponse parses an HTTP response from a DeleteVerificationMethodWithResponse call
-func ParseDeleteVerificationMethodResponse(rsp *http.Response) (*DeleteVerificationMethodResponse, error) {
+// ParseScheduleVerificationMethodExpiryResponse parses an HTTP response from a ScheduleVerificationMethodExpiryWithResponse call
+func ParseScheduleVerificationMethodExpiryResponse(rsp *http.Response) (*ScheduleVerificationMethodExpiryResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteVerificationMethodResponse{
+	response := &ScheduleVerificationMethodExpiryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Expiry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
 	return response, nil
 }
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Searches for Nuts DID documents
+	// (GET /internal/vdr/v1/did)
+	SearchDIDs(ctx echo.Context, params SearchDIDsParams) error
 	// Creates a new Nuts DID
 	// (POST /internal/vdr/v1/did)
 	CreateDID(ctx echo.Context) error
 	// Retrieve the list of conflicted DID documents
 	// (GET /internal/vdr/v1/did/conflicted)
 	ConflictedDIDs(ctx echo.Context) error
+	// Resolves the conflicts of all conflicted DID documents controlled by this node
+	// (POST /internal/vdr/v1/did/conflicted/resolve)
+	ResolveConflictedDIDs(ctx echo.Context, params ResolveConflictedDIDsParams) error
+	// Lists the scheduled expiries of DID documents and verification methods
+	// (GET /internal/vdr/v1/did/expiry)
+	ListExpiries(ctx echo.Context) error
+	// Publishes a DID document update proposal
+	// (POST /internal/vdr/v1/did/proposal/publish)
+	PublishDIDUpdateProposal(ctx echo.Context) error
+	// Signs a DID document update proposal
+	// (POST /internal/vdr/v1/did/proposal/sign)
+	SignDIDUpdateProposal(ctx echo.Context) error
 	// Deactivates a Nuts DID document according to the specification.
 	// (DELETE /internal/vdr/v1/did/{did})
 	DeactivateDID(ctx echo.Context, did string) error
@@ -1042,12 +2970,36 @@ type ServerInterface interface {
 	// Updates a Nuts DID document.
 	// (PUT /internal/vdr/v1/did/{did})
 	UpdateDID(ctx echo.Context, did string) error
+	// Compares two versions of a Nuts DID document
+	// (GET /internal/vdr/v1/did/{did}/diff)
+	GetDIDDiff(ctx echo.Context, did string, params GetDIDDiffParams) error
+	// Cancels the expiry of a DID document
+	// (DELETE /internal/vdr/v1/did/{did}/expiry)
+	CancelDIDExpiry(ctx echo.Context, did string) error
+	// Schedules the expiry of a DID document
+	// (PUT /internal/vdr/v1/did/{did}/expiry)
+	ScheduleDIDExpiry(ctx echo.Context, did string) error
+	// Lists all versions of a Nuts DID document
+	// (GET /internal/vdr/v1/did/{did}/history)
+	GetDIDHistory(ctx echo.Context, did string) error
+	// Proposes an update of a DID document that must be signed by multiple controllers
+	// (POST /internal/vdr/v1/did/{did}/proposal)
+	ProposeDIDUpdate(ctx echo.Context, did string) error
+	// Resolves the conflict of a conflicted DID document
+	// (POST /internal/vdr/v1/did/{did}/resolve-conflict)
+	ResolveConflictedDID(ctx echo.Context, did string, params ResolveConflictedDIDParams) error
 	// Creates and adds a new verificationMethod to the DID document.
 	// (POST /internal/vdr/v1/did/{did}/verificationmethod)
 	AddNewVerificationMethod(ctx echo.Context, did string) error
 	// Delete a specific verification method
 	// (DELETE /internal/vdr/v1/did/{did}/verificationmethod/{kid})
 	DeleteVerificationMethod(ctx echo.Context, did string, kid string) error
+	// Cancels the expiry of a verification method
+	// (DELETE /internal/vdr/v1/did/{did}/verificationmethod/{kid}/expiry)
+	CancelVerificationMethodExpiry(ctx echo.Context, did string, kid string) error
+	// Schedules the expiry of a verification method
+	// (PUT /internal/vdr/v1/did/{did}/verificationmethod/{kid}/expiry)
+	ScheduleVerificationMethodExpiry(ctx echo.Context, did string, kid string) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -1055,6 +3007,68 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// SearchDIDs converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchDIDs(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchDIDsParams
+	// ------------- Optional query parameter "controller" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "controller", ctx.QueryParams(), &params.Controller)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter controller: %s", err))
+	}
+
+	// ------------- Optional query parameter "verificationMethod" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verificationMethod", ctx.QueryParams(), &params.VerificationMethod)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter verificationMethod: %s", err))
+	}
+
+	// ------------- Optional query parameter "serviceType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "serviceType", ctx.QueryParams(), &params.ServiceType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceType: %s", err))
+	}
+
+	// ------------- Optional query parameter "serviceEndpoint" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "serviceEndpoint", ctx.QueryParams(), &params.ServiceEndpoint)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceEndpoint: %s", err))
+	}
+
+	// ------------- Optional query parameter "serviceEndpointHost" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "serviceEndpointHost", ctx.QueryParams(), &params.ServiceEndpointHost)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceEndpointHost: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchDIDs(ctx, params)
+	return err
+}
+
 // CreateDID converts echo context to params.
 func (w *ServerInterfaceWrapper) CreateDID(ctx echo.Context) error {
 	var err error
@@ -1077,6 +3091,59 @@ func (w *ServerInterfaceWrapper) ConflictedDIDs(ctx echo.Context) error {
 	return err
 }
 
+// ResolveConflictedDIDs converts echo context to params.
+func (w *ServerInterfaceWrapper) ResolveConflictedDIDs(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResolveConflictedDIDsParams
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", ctx.QueryParams(), &params.DryRun)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dryRun: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ResolveConflictedDIDs(ctx, params)
+	return err
+}
+
+// ListExpiries converts echo context to params.
+func (w *ServerInterfaceWrapper) ListExpiries(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListExpiries(ctx)
+	return err
+}
+
+// PublishDIDUpdateProposal converts echo context to params.
+func (w *ServerInterfaceWrapper) PublishDIDUpdateProposal(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PublishDIDUpdateProposal(ctx)
+	return err
+}
+
+// SignDIDUpdateProposal converts echo context to params.
+func (w *ServerInterfaceWrapper) SignDIDUpdateProposal(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SignDIDUpdateProposal(ctx)
+	return err
+}
+
 // DeactivateDID converts echo context to params.
 func (w *ServerInterfaceWrapper) DeactivateDID(ctx echo.Context) error {
 	var err error
@@ -1147,6 +3214,139 @@ func (w *ServerInterfaceWrapper) UpdateDID(ctx echo.Context) error {
 	return err
 }
 
+// GetDIDDiff converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDIDDiff(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDIDDiffParams
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetDIDDiff(ctx, did, params)
+	return err
+}
+
+// CancelDIDExpiry converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelDIDExpiry(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CancelDIDExpiry(ctx, did)
+	return err
+}
+
+// ScheduleDIDExpiry converts echo context to params.
+func (w *ServerInterfaceWrapper) ScheduleDIDExpiry(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ScheduleDIDExpiry(ctx, did)
+	return err
+}
+
+// GetDIDHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDIDHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetDIDHistory(ctx, did)
+	return err
+}
+
+// ProposeDIDUpdate converts echo context to params.
+func (w *ServerInterfaceWrapper) ProposeDIDUpdate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ProposeDIDUpdate(ctx, did)
+	return err
+}
+
+// ResolveConflictedDID converts echo context to params.
+func (w *ServerInterfaceWrapper) ResolveConflictedDID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResolveConflictedDIDParams
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", ctx.QueryParams(), &params.DryRun)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dryRun: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ResolveConflictedDID(ctx, did, params)
+	return err
+}
+
 // AddNewVerificationMethod converts echo context to params.
 func (w *ServerInterfaceWrapper) AddNewVerificationMethod(ctx echo.Context) error {
 	var err error
@@ -1191,6 +3391,58 @@ func (w *ServerInterfaceWrapper) DeleteVerificationMethod(ctx echo.Context) erro
 	return err
 }
 
+// CancelVerificationMethodExpiry converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelVerificationMethodExpiry(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "kid" -------------
+	var kid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "kid", runtime.ParamLocationPath, ctx.Param("kid"), &kid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter kid: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CancelVerificationMethodExpiry(ctx, did, kid)
+	return err
+}
+
+// ScheduleVerificationMethodExpiry converts echo context to params.
+func (w *ServerInterfaceWrapper) ScheduleVerificationMethodExpiry(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "kid" -------------
+	var kid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "kid", runtime.ParamLocationPath, ctx.Param("kid"), &kid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter kid: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ScheduleVerificationMethodExpiry(ctx, did, kid)
+	return err
+}
+
 // PATCH: This template file was taken from pkg/codegen/templates/echo/echo-register.tmpl
 
 // This is a simple interface which specifies echo.Route addition functions which
@@ -1231,6 +3483,10 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 
 	// PATCH: This alteration wraps the call to the implementation in a function that sets the "OperationId" context parameter,
 	// so it can be used in error reporting middleware.
+	router.GET(baseURL+"/internal/vdr/v1/did", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("SearchDIDs", context)
+		return wrapper.SearchDIDs(context)
+	})
 	router.POST(baseURL+"/internal/vdr/v1/did", func(context echo.Context) error {
 		si.(Preprocessor).Preprocess("CreateDID", context)
 		return wrapper.CreateDID(context)
@@ -1239,6 +3495,22 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		si.(Preprocessor).Preprocess("ConflictedDIDs", context)
 		return wrapper.ConflictedDIDs(context)
 	})
+	router.POST(baseURL+"/internal/vdr/v1/did/conflicted/resolve", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ResolveConflictedDIDs", context)
+		return wrapper.ResolveConflictedDIDs(context)
+	})
+	router.GET(baseURL+"/internal/vdr/v1/did/expiry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ListExpiries", context)
+		return wrapper.ListExpiries(context)
+	})
+	router.POST(baseURL+"/internal/vdr/v1/did/proposal/publish", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("PublishDIDUpdateProposal", context)
+		return wrapper.PublishDIDUpdateProposal(context)
+	})
+	router.POST(baseURL+"/internal/vdr/v1/did/proposal/sign", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("SignDIDUpdateProposal", context)
+		return wrapper.SignDIDUpdateProposal(context)
+	})
 	router.DELETE(baseURL+"/internal/vdr/v1/did/:did", func(context echo.Context) error {
 		si.(Preprocessor).Preprocess("DeactivateDID", context)
 		return wrapper.DeactivateDID(context)
@@ -1251,6 +3523,30 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		si.(Preprocessor).Preprocess("UpdateDID", context)
 		return wrapper.UpdateDID(context)
 	})
+	router.GET(baseURL+"/internal/vdr/v1/did/:did/diff", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("GetDIDDiff", context)
+		return wrapper.GetDIDDiff(context)
+	})
+	router.DELETE(baseURL+"/internal/vdr/v1/did/:did/expiry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("CancelDIDExpiry", context)
+		return wrapper.CancelDIDExpiry(context)
+	})
+	router.PUT(baseURL+"/internal/vdr/v1/did/:did/expiry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ScheduleDIDExpiry", context)
+		return wrapper.ScheduleDIDExpiry(context)
+	})
+	router.GET(baseURL+"/internal/vdr/v1/did/:did/history", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("GetDIDHistory", context)
+		return wrapper.GetDIDHistory(context)
+	})
+	router.POST(baseURL+"/internal/vdr/v1/did/:did/proposal", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ProposeDIDUpdate", context)
+		return wrapper.ProposeDIDUpdate(context)
+	})
+	router.POST(baseURL+"/internal/vdr/v1/did/:did/resolve-conflict", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ResolveConflictedDID", context)
+		return wrapper.ResolveConflictedDID(context)
+	})
 	router.POST(baseURL+"/internal/vdr/v1/did/:did/verificationmethod", func(context echo.Context) error {
 		si.(Preprocessor).Preprocess("AddNewVerificationMethod", context)
 		return wrapper.AddNewVerificationMethod(context)
@@ -1259,5 +3555,13 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		si.(Preprocessor).Preprocess("DeleteVerificationMethod", context)
 		return wrapper.DeleteVerificationMethod(context)
 	})
+	router.DELETE(baseURL+"/internal/vdr/v1/did/:did/verificationmethod/:kid/expiry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("CancelVerificationMethodExpiry", context)
+		return wrapper.CancelVerificationMethodExpiry(context)
+	})
+	router.PUT(baseURL+"/internal/vdr/v1/did/:did/verificationmethod/:kid/expiry", func(context echo.Context) error {
+		si.(Preprocessor).Preprocess("ScheduleVerificationMethodExpiry", context)
+		return wrapper.ScheduleVerificationMethodExpiry(context)
+	})
 
 }
@@ -3,10 +3,38 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.11.0 DO NOT EDIT.
 package v1
 
+import (
+	"time"
+)
+
 const (
 	JwtBearerAuthScopes = "jwtBearerAuth.Scopes"
 )
 
+// Defines values for DIDCreateRequestMethod.
+const (
+	Nuts DIDCreateRequestMethod = "nuts"
+	Web  DIDCreateRequestMethod = "web"
+)
+
+// ConflictResolution defines model for ConflictResolution.
+type ConflictResolution struct {
+	// The DID of the conflicted DID document.
+	Did string `json:"did"`
+
+	// Unified diff between the DID document as currently resolved and the merged DID document (document).
+	Diff string `json:"diff"`
+
+	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
+	Document DIDDocument `json:"document"`
+
+	// The conflicting transactions the update refers to.
+	Heads []string `json:"heads"`
+
+	// Whether the merged DID document has been published as update (false in case of a dry run).
+	Published bool `json:"published"`
+}
+
 // DIDCreateRequest defines model for DIDCreateRequest.
 type DIDCreateRequest struct {
 	// indicates if the generated key pair can be used for assertions.
@@ -31,10 +59,18 @@ type DIDCreateRequest struct {
 	// indicates if the generated key pair can be used for Key agreements.
 	KeyAgreement *bool `json:"keyAgreement,omitempty"`
 
+	// DID method of the new DID. Only `web` DIDs can be created if the node is configured to host did:web documents (vdr.web.url).
+	// did:web DIDs can't have controllers and are always controlled by this node.
+	Method *DIDCreateRequestMethod `json:"method,omitempty"`
+
 	// whether the generated DID Document can be altered with its own capabilityInvocation key.
 	SelfControl *bool `json:"selfControl,omitempty"`
 }
 
+// DID method of the new DID. Only `web` DIDs can be created if the node is configured to host did:web documents (vdr.web.url).
+// did:web DIDs can't have controllers and are always controlled by this node.
+type DIDCreateRequestMethod string
+
 // A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
 type DIDDocument struct {
 	// The JSON-LD contexts that define the types used in this document. Can be a single string, or a list of strings.
@@ -68,6 +104,25 @@ type DIDDocument struct {
 	VerificationMethod *[]VerificationMethod `json:"verificationMethod,omitempty"`
 }
 
+// DIDDocumentDiff defines model for DIDDocumentDiff.
+type DIDDocumentDiff struct {
+	AddedControllers         []string             `json:"addedControllers"`
+	AddedServices            []Service            `json:"addedServices"`
+	AddedVerificationMethods []VerificationMethod `json:"addedVerificationMethods"`
+
+	// Services that are present in both versions, but with different contents. Contains the services as they are in the newer version.
+	ChangedServices []Service `json:"changedServices"`
+
+	// The version (Sha256 hash of the document) compared from.
+	From                       string               `json:"from"`
+	RemovedControllers         []string             `json:"removedControllers"`
+	RemovedServices            []Service            `json:"removedServices"`
+	RemovedVerificationMethods []VerificationMethod `json:"removedVerificationMethods"`
+
+	// The version (Sha256 hash of the document) compared to.
+	To string `json:"to"`
+}
+
 // The DID document metadata.
 type DIDDocumentMetadata struct {
 	// Time when DID document was created in rfc3339 form.
@@ -90,6 +145,18 @@ type DIDDocumentMetadata struct {
 	Updated *string `json:"updated,omitempty"`
 }
 
+// DIDDocumentVersion defines model for DIDDocumentVersion.
+type DIDDocumentVersion struct {
+	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
+	Document DIDDocument `json:"document"`
+
+	// The DID document metadata.
+	DocumentMetadata DIDDocumentMetadata `json:"documentMetadata"`
+
+	// The version number of the DID document, starting at 0.
+	Version int `json:"version"`
+}
+
 // DIDResolutionResult defines model for DIDResolutionResult.
 type DIDResolutionResult struct {
 	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
@@ -108,6 +175,32 @@ type DIDUpdateRequest struct {
 	Document DIDDocument `json:"document"`
 }
 
+// The scheduled expiry of a DID document or verification method.
+type Expiry struct {
+	// The DID of the DID document.
+	Did string `json:"did"`
+
+	// The time at which the DID document or verification method expires, in RFC3339 format.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// The ID of the verification method that expires. If absent, the DID document itself expires.
+	KeyID *string `json:"keyID,omitempty"`
+}
+
+// ScheduleExpiryRequest defines model for ScheduleExpiryRequest.
+type ScheduleExpiryRequest struct {
+	// The time at which the DID document or verification method expires, in RFC3339 format.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Result of a DID document search.
+type SearchDIDResults struct {
+	Documents []DIDDocument `json:"documents"`
+
+	// Set if there are more results, pass it as cursor to retrieve the next page.
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
 // A service supported by a DID subject.
 type Service struct {
 	// ID of the service.
@@ -120,6 +213,18 @@ type Service struct {
 	Type string `json:"type"`
 }
 
+// A proposed update of a DID document, co-signed by (some of) its controllers.
+type UpdateProposal struct {
+	// The hash of the DID document version the update applies to, in hex format.
+	Current string `json:"current"`
+
+	// A DID document according to the W3C spec following the Nuts Method rules as defined in [Nuts RFC006]
+	Document DIDDocument `json:"document"`
+
+	// Compact JWS signatures (with detached payload) of the controllers that approved the update.
+	Signatures []string `json:"signatures"`
+}
+
 // A public key in JWK form.
 type VerificationMethod struct {
 	// The DID subject this key belongs to.
@@ -135,9 +240,45 @@ type VerificationMethod struct {
 	Type string `json:"type"`
 }
 
+// SearchDIDsParams defines parameters for SearchDIDs.
+type SearchDIDsParams struct {
+	// DID of a controller of the DID document.
+	Controller *string `form:"controller,omitempty" json:"controller,omitempty"`
+
+	// ID of a verification method of the DID document.
+	VerificationMethod *string `form:"verificationMethod,omitempty" json:"verificationMethod,omitempty"`
+
+	// Type of a service of the DID document.
+	ServiceType *string `form:"serviceType,omitempty" json:"serviceType,omitempty"`
+
+	// Endpoint of a service of the DID document (exact match). For compound services, each of its endpoints is matched.
+	ServiceEndpoint *string `form:"serviceEndpoint,omitempty" json:"serviceEndpoint,omitempty"`
+
+	// Host (without port, case-insensitive) of a service endpoint URL of the DID document.
+	ServiceEndpointHost *string `form:"serviceEndpointHost,omitempty" json:"serviceEndpointHost,omitempty"`
+
+	// Maximum number of DID documents to return. If not set, all matching DID documents are returned.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor to retrieve the next page of results, as returned by a previous search (nextCursor).
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
 // CreateDIDJSONBody defines parameters for CreateDID.
 type CreateDIDJSONBody = DIDCreateRequest
 
+// ResolveConflictedDIDsParams defines parameters for ResolveConflictedDIDs.
+type ResolveConflictedDIDsParams struct {
+	// If true, the merged DID document is returned but not published.
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// PublishDIDUpdateProposalJSONBody defines parameters for PublishDIDUpdateProposal.
+type PublishDIDUpdateProposalJSONBody = UpdateProposal
+
+// SignDIDUpdateProposalJSONBody defines parameters for SignDIDUpdateProposal.
+type SignDIDUpdateProposalJSONBody = UpdateProposal
+
 // GetDIDParams defines parameters for GetDID.
 type GetDIDParams struct {
 	// If a versionId parameter is provided, the DID resolution algorithm returns a specific version of the DID document.
@@ -157,8 +298,47 @@ type GetDIDParams struct {
 // UpdateDIDJSONBody defines parameters for UpdateDID.
 type UpdateDIDJSONBody = DIDUpdateRequest
 
+// GetDIDDiffParams defines parameters for GetDIDDiff.
+type GetDIDDiffParams struct {
+	// The version (Sha256 hash of the document) to compare from.
+	From string `form:"from" json:"from"`
+
+	// The version (Sha256 hash of the document) to compare to. If not provided, the latest version is used.
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ScheduleDIDExpiryJSONBody defines parameters for ScheduleDIDExpiry.
+type ScheduleDIDExpiryJSONBody = ScheduleExpiryRequest
+
+// ProposeDIDUpdateJSONBody defines parameters for ProposeDIDUpdate.
+type ProposeDIDUpdateJSONBody = DIDUpdateRequest
+
+// ResolveConflictedDIDParams defines parameters for ResolveConflictedDID.
+type ResolveConflictedDIDParams struct {
+	// If true, the merged DID document is returned but not published.
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// ScheduleVerificationMethodExpiryJSONBody defines parameters for ScheduleVerificationMethodExpiry.
+type ScheduleVerificationMethodExpiryJSONBody = ScheduleExpiryRequest
+
 // CreateDIDJSONRequestBody defines body for CreateDID for application/json ContentType.
 type CreateDIDJSONRequestBody = CreateDIDJSONBody
 
+// PublishDIDUpdateProposalJSONRequestBody defines body for PublishDIDUpdateProposal for application/json ContentType.
+type PublishDIDUpdateProposalJSONRequestBody = PublishDIDUpdateProposalJSONBody
+
+// SignDIDUpdateProposalJSONRequestBody defines body for SignDIDUpdateProposal for application/json ContentType.
+type SignDIDUpdateProposalJSONRequestBody = SignDIDUpdateProposalJSONBody
+
 // UpdateDIDJSONRequestBody defines body for UpdateDID for application/json ContentType.
 type UpdateDIDJSONRequestBody = UpdateDIDJSONBody
+
+// ScheduleDIDExpiryJSONRequestBody defines body for ScheduleDIDExpiry for application/json ContentType.
+type ScheduleDIDExpiryJSONRequestBody = ScheduleDIDExpiryJSONBody
+
+// ProposeDIDUpdateJSONRequestBody defines body for ProposeDIDUpdate for application/json ContentType.
+type ProposeDIDUpdateJSONRequestBody = ProposeDIDUpdateJSONBody
+
+// ScheduleVerificationMethodExpiryJSONRequestBody defines body for ScheduleVerificationMethodExpiry for application/json ContentType.
+type ScheduleVerificationMethodExpiryJSONRequestBody = ScheduleVerificationMethodExpiryJSONBody
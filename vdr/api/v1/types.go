@@ -25,3 +25,9 @@ type DIDDocument = did.Document
 
 // DIDDocumentMetadata is an alias
 type DIDDocumentMetadata = types.DocumentMetadata
+
+// VerificationMethod is an alias
+type VerificationMethod = did.VerificationMethod
+
+// Service is an alias
+type Service = did.Service
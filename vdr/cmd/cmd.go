@@ -26,18 +26,25 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vdr"
 	api "github.com/nuts-foundation/nuts-node/vdr/api/v1"
 )
 
 // FlagSet contains flags relevant for the VDR instance
 func FlagSet() *pflag.FlagSet {
+	defs := vdr.DefaultConfig()
 	flagSet := pflag.NewFlagSet("vdr", pflag.ContinueOnError)
+	flagSet.String("vdr.web.url", defs.Web.URL, "Public HTTPS URL (without port) on which the node's HTTP interface is reachable. "+
+		"When set, the node can create did:web DIDs, whose documents are served on /iam/<id>/did.json.")
+	flagSet.Bool("vdr.web.resolve", defs.Web.Resolve, "When set, did:web DIDs of other parties (e.g. credential issuers) are resolved by downloading their DID document. "+
+		"Hosts on loopback, private and link-local addresses are refused.")
 	return flagSet
 }
 
@@ -51,11 +58,18 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(createCmd())
 	cmd.AddCommand(resolveCmd())
 	cmd.AddCommand(conflictedCmd())
+	cmd.AddCommand(resolveConflictCmd())
 	cmd.AddCommand(updateCmd())
+	cmd.AddCommand(proposeUpdateCmd())
+	cmd.AddCommand(signProposalCmd())
+	cmd.AddCommand(publishProposalCmd())
 	cmd.AddCommand(deactivateCmd())
 	cmd.AddCommand(addVerificationMethodCmd())
 	cmd.AddCommand(deleteVerificationMethodCmd())
 	cmd.AddCommand(addKeyAgreementKeyCmd())
+	cmd.AddCommand(setExpiryCmd())
+	cmd.AddCommand(cancelExpiryCmd())
+	cmd.AddCommand(listExpiriesCmd())
 
 	return cmd
 }
@@ -71,12 +85,14 @@ func createCmd() *cobra.Command {
 		KeyAgreement:         new(bool),
 		SelfControl:          new(bool),
 	}
+	var method string
 
 	result := &cobra.Command{
 		Use:   "create-did",
 		Short: "Registers a new DID",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			createRequest.Method = (*api.DIDCreateRequestMethod)(&method)
 			clientConfig := core.NewClientConfigForCommand(cmd)
 			doc, err := httpClient(clientConfig).Create(createRequest)
 			if err != nil {
@@ -94,6 +110,7 @@ func createCmd() *cobra.Command {
 	result.Flags().BoolVar(createRequest.CapabilityInvocation, "capabilityInvocation", true, "Pass 'false' to disable capabilityInvocation capabilities.")
 	result.Flags().BoolVar(createRequest.KeyAgreement, "keyAgreement", false, "Pass 'true' to enable keyAgreement capabilities.")
 	result.Flags().BoolVar(createRequest.SelfControl, "selfControl", true, "Pass 'false' to disable DID Document control.")
+	result.Flags().StringVar(&method, "method", string(api.Nuts), "DID method of the new DID: 'nuts' or 'web' (requires vdr.web.url to be configured).")
 	result.Flags().StringSliceVar(createRequest.Controllers, "controllers", []string{}, "Comma-separated list of DIDs that can control the generated DID Document.")
 
 	return result
@@ -142,6 +159,94 @@ func updateCmd() *cobra.Command {
 	}
 }
 
+func proposeUpdateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "propose-update [DID] [hash] [file]",
+		Short: "Propose an update of a DID document that must be signed by multiple controllers. " +
+			"The proposal is signed by the controllers managed by this node and printed, so it can be signed by the other controllers. " +
+			"If no file is given, a pipe is assumed. The hash is needed to prevent concurrent updates.",
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bytes, err := readInput(args, 2)
+			if err != nil {
+				return err
+			}
+			var didDoc did.Document
+			if err = json.Unmarshal(bytes, &didDoc); err != nil {
+				return fmt.Errorf("failed to parse DID document: %w", err)
+			}
+
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			proposal, err := httpClient(clientConfig).ProposeUpdate(args[0], args[1], didDoc)
+			if err != nil {
+				return fmt.Errorf("failed to propose DID document update: %w", err)
+			}
+			printProposal(cmd, *proposal)
+			return nil
+		},
+	}
+}
+
+func signProposalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "sign-proposal [file]",
+		Short: "Sign a DID document update proposal with the keys of the controllers managed by this node, and print the signed proposal. " +
+			"If no file is given, a pipe is assumed.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proposal, err := readProposal(args)
+			if err != nil {
+				return err
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			signed, err := httpClient(clientConfig).SignProposal(*proposal)
+			if err != nil {
+				return fmt.Errorf("failed to sign DID document update proposal: %w", err)
+			}
+			printProposal(cmd, *signed)
+			return nil
+		},
+	}
+}
+
+func publishProposalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "publish-proposal [file]",
+		Short: "Publish a DID document update proposal that's signed by enough controllers. " +
+			"If no file is given, a pipe is assumed.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proposal, err := readProposal(args)
+			if err != nil {
+				return err
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err = httpClient(clientConfig).PublishProposal(*proposal); err != nil {
+				return fmt.Errorf("failed to publish DID document update proposal: %w", err)
+			}
+			cmd.Println("DID document update proposal published")
+			return nil
+		},
+	}
+}
+
+func readProposal(args []string) (*api.UpdateProposal, error) {
+	bytes, err := readInput(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	var proposal api.UpdateProposal
+	if err = json.Unmarshal(bytes, &proposal); err != nil {
+		return nil, fmt.Errorf("failed to parse DID document update proposal: %w", err)
+	}
+	return &proposal, nil
+}
+
+func printProposal(cmd *cobra.Command, proposal api.UpdateProposal) {
+	bytes, _ := json.MarshalIndent(proposal, "", "  ")
+	cmd.Println(string(bytes))
+}
+
 func resolveCmd() *cobra.Command {
 	var printMetadata bool
 	var printDocument bool
@@ -218,6 +323,60 @@ func conflictedCmd() *cobra.Command {
 	return result
 }
 
+func resolveConflictCmd() *cobra.Command {
+	var all bool
+	var dryRun bool
+	result := &cobra.Command{
+		Use:   "resolve-conflict [DID]",
+		Short: "Resolve a conflicted DID document by merging its conflicting versions and publishing the result",
+		Long: "Resolve a conflicted DID document by merging its conflicting versions and publishing the result. " +
+			"The difference between the current and merged document is printed. " +
+			"Pass --all (without DID) to resolve all conflicted DID documents controlled by this node.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) == 1) {
+				return errors.New("either specify a DID or pass --all")
+			}
+			client := httpClient(core.NewClientConfigForCommand(cmd))
+			var resolutions []api.ConflictResolution
+			if all {
+				results, err := client.ResolveConflicts(dryRun)
+				if err != nil {
+					return fmt.Errorf("failed to resolve conflicted DID documents: %v", err)
+				}
+				resolutions = results
+			} else {
+				resolution, err := client.ResolveConflict(args[0], dryRun)
+				if err != nil {
+					return fmt.Errorf("failed to resolve conflicted DID document: %v", err)
+				}
+				resolutions = append(resolutions, *resolution)
+			}
+
+			if len(resolutions) == 0 {
+				cmd.Println("No conflicted DID documents found")
+			}
+			for _, resolution := range resolutions {
+				cmd.Printf("%s\n", resolution.Did)
+				if resolution.Diff == "" {
+					cmd.Println("Merged document is equal to the current document")
+				} else {
+					cmd.Print(resolution.Diff)
+				}
+				if resolution.Published {
+					cmd.Println("Merged document published")
+				} else {
+					cmd.Println("Merged document not published (dry run)")
+				}
+			}
+			return nil
+		},
+	}
+	result.Flags().BoolVar(&all, "all", false, "Resolve all conflicted DID documents controlled by this node.")
+	result.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the merged document's difference, don't publish it.")
+	return result
+}
+
 func deactivateCmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "deactivate [DID]",
@@ -336,6 +495,92 @@ func deleteVerificationMethodCmd() *cobra.Command {
 	return result
 }
 
+func setExpiryCmd() *cobra.Command {
+	var kid string
+	result := &cobra.Command{
+		Use:   "set-expiry [DID] [time]",
+		Short: "Schedules the expiry of a DID document or one of its verification methods.",
+		Long: "Schedules the expiry of a DID document or one of its verification methods. " +
+			"The time is either in RFC3339 format (e.g. 2023-01-01T12:00:00Z) or a duration relative to now (e.g. 720h). " +
+			"When it passes, the node deactivates the DID document or, when --key is given, removes the verification method from it. " +
+			"An existing expiry is replaced.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expiresAt, err := parseExpiryTime(args[1], time.Now())
+			if err != nil {
+				return err
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			expiry, err := httpClient(clientConfig).ScheduleExpiry(args[0], kid, expiresAt)
+			if err != nil {
+				return fmt.Errorf("failed to schedule expiry: %v", err)
+			}
+			cmd.Printf("Expiry scheduled at %s\n", expiry.ExpiresAt.Format(time.RFC3339))
+			return nil
+		},
+	}
+	result.Flags().StringVar(&kid, "key", "", "ID of the verification method that expires, instead of the DID document.")
+	return result
+}
+
+func cancelExpiryCmd() *cobra.Command {
+	var kid string
+	result := &cobra.Command{
+		Use:   "cancel-expiry [DID]",
+		Short: "Cancels the scheduled expiry of a DID document or one of its verification methods.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			err := httpClient(clientConfig).CancelExpiry(args[0], kid)
+			if err != nil {
+				return fmt.Errorf("failed to cancel expiry: %v", err)
+			}
+			cmd.Println("Expiry cancelled")
+			return nil
+		},
+	}
+	result.Flags().StringVar(&kid, "key", "", "ID of the verification method of which the expiry is cancelled, instead of the DID document.")
+	return result
+}
+
+func listExpiriesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-expiries",
+		Short: "Lists the scheduled expiries of DID documents and verification methods.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			expiries, err := httpClient(clientConfig).ListExpiries()
+			if err != nil {
+				return fmt.Errorf("failed to list expiries: %v", err)
+			}
+			if len(expiries) == 0 {
+				cmd.Println("No expiries scheduled")
+			}
+			for _, expiry := range expiries {
+				subject := expiry.Did
+				if expiry.KeyID != nil {
+					subject = *expiry.KeyID
+				}
+				cmd.Printf("%s  %s\n", expiry.ExpiresAt.Format(time.RFC3339), subject)
+			}
+			return nil
+		},
+	}
+}
+
+// parseExpiryTime parses the given input as RFC3339 timestamp or as duration relative to now.
+func parseExpiryTime(input string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t, nil
+	}
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time (expected RFC3339 or duration): %s", input)
+	}
+	return now.Add(duration), nil
+}
+
 func askYesNo(question string, cmd *cobra.Command) (answer bool) {
 	reader := bufio.NewReader(cmd.InOrStdin())
 	question += "[yes/no]: "
@@ -359,6 +604,22 @@ func askYesNo(question string, cmd *cobra.Command) (answer bool) {
 	return
 }
 
+// readInput reads the file given as argument at the given index, or from stdin if there's no such argument.
+func readInput(args []string, index int) ([]byte, error) {
+	if len(args) > index {
+		bytes, err := os.ReadFile(args[index])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", args[index], err)
+		}
+		return bytes, nil
+	}
+	bytes, err := readFromStdin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from pipe: %w", err)
+	}
+	return bytes, nil
+}
+
 func readFromStdin() ([]byte, error) {
 	fi, err := os.Stdin.Stat()
 	if err != nil {
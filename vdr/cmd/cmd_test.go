@@ -27,7 +27,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"testing"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -215,6 +217,78 @@ func TestEngine_Command(t *testing.T) {
 		})
 	})
 
+	t.Run("resolve-conflict", func(t *testing.T) {
+		exampleResolution := v1.ConflictResolution{
+			Did:       exampleID.String(),
+			Document:  exampleDIDDocument,
+			Diff:      "--- current\n+++ merged\n",
+			Published: true,
+		}
+
+		t.Run("ok - single DID", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: exampleResolution})
+			cmd.SetArgs([]string{"resolve-conflict", exampleID.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), exampleID.String())
+			assert.Contains(t, buf.String(), "+++ merged")
+			assert.Contains(t, buf.String(), "Merged document published")
+			assert.Empty(t, errBuf.Bytes())
+		})
+
+		t.Run("ok - all, dry run", func(t *testing.T) {
+			resolution := exampleResolution
+			resolution.Published = false
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: []v1.ConflictResolution{resolution}})
+			cmd.SetArgs([]string{"resolve-conflict", "--all", "--dry-run"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), exampleID.String())
+			assert.Contains(t, buf.String(), "Merged document not published (dry run)")
+		})
+
+		t.Run("ok - all, nothing conflicted", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: []v1.ConflictResolution{}})
+			cmd.SetArgs([]string{"resolve-conflict", "--all"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "No conflicted DID documents found")
+		})
+
+		t.Run("error - both DID and --all", func(t *testing.T) {
+			cmd := newCmd(t)
+			cmd.SetArgs([]string{"resolve-conflict", exampleID.String(), "--all"})
+
+			err := cmd.Execute()
+
+			assert.EqualError(t, err, "either specify a DID or pass --all")
+		})
+
+		t.Run("error - server error", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: "not conflicted"})
+			cmd.SetArgs([]string{"resolve-conflict", exampleID.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+			if !assert.Error(t, err) {
+				return
+			}
+			assert.Contains(t, errBuf.String(), "failed to resolve conflicted DID document")
+		})
+	})
+
 	t.Run("update", func(t *testing.T) {
 		t.Run("ok - write to stdout", func(t *testing.T) {
 			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: exampleDIDDocument})
@@ -254,6 +328,103 @@ func TestEngine_Command(t *testing.T) {
 		})
 	})
 
+	proposal := v1.UpdateProposal{Current: "hash", Document: exampleDIDDocument, Signatures: []string{"signature"}}
+	writeProposal := func(t *testing.T) string {
+		file := path.Join(t.TempDir(), "proposal.json")
+		data, _ := json.Marshal(proposal)
+		_ = os.WriteFile(file, data, os.ModePerm)
+		return file
+	}
+
+	t.Run("propose-update", func(t *testing.T) {
+		t.Run("ok - write to stdout", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: proposal})
+			cmd.SetArgs([]string{"propose-update", "did", "hash", "../test/diddocument.json"})
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			var result v1.UpdateProposal
+			_ = json.Unmarshal(buf.Bytes(), &result)
+			assert.Equal(t, proposal, result)
+			assert.Empty(t, errBuf.Bytes())
+		})
+
+		t.Run("error - incorrect input", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: proposal})
+			cmd.SetArgs([]string{"propose-update", "did", "hash", "../test/syntax_error.json"})
+
+			err := cmd.Execute()
+			if !assert.Error(t, err) {
+				return
+			}
+			assert.Contains(t, errBuf.String(), "failed to parse DID document")
+		})
+
+		t.Run("error - server error", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusForbidden, ResponseData: "not managed"})
+			cmd.SetArgs([]string{"propose-update", "did", "hash", "../test/diddocument.json"})
+
+			err := cmd.Execute()
+			if !assert.Error(t, err) {
+				return
+			}
+			assert.Contains(t, errBuf.String(), "failed to propose DID document update")
+		})
+	})
+
+	t.Run("sign-proposal", func(t *testing.T) {
+		t.Run("ok - write to stdout", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: proposal})
+			cmd.SetArgs([]string{"sign-proposal", writeProposal(t)})
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			var result v1.UpdateProposal
+			_ = json.Unmarshal(buf.Bytes(), &result)
+			assert.Equal(t, proposal, result)
+		})
+
+		t.Run("error - incorrect input", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: proposal})
+			cmd.SetArgs([]string{"sign-proposal", "../test/syntax_error.json"})
+
+			err := cmd.Execute()
+			if !assert.Error(t, err) {
+				return
+			}
+			assert.Contains(t, errBuf.String(), "failed to parse DID document update proposal")
+		})
+	})
+
+	t.Run("publish-proposal", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK})
+			cmd.SetArgs([]string{"publish-proposal", writeProposal(t)})
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "DID document update proposal published")
+		})
+
+		t.Run("error - threshold not met", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: "threshold not met"})
+			cmd.SetArgs([]string{"publish-proposal", writeProposal(t)})
+
+			err := cmd.Execute()
+			if !assert.Error(t, err) {
+				return
+			}
+			assert.Contains(t, errBuf.String(), "failed to publish DID document update proposal")
+			assert.Contains(t, errBuf.String(), "threshold not met")
+		})
+	})
+
 	t.Run("deactivate", func(t *testing.T) {
 		t.Run("ok", func(t *testing.T) {
 			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK})
@@ -365,6 +536,98 @@ func TestEngine_Command(t *testing.T) {
 		})
 	})
 
+	t.Run("setExpiry", func(t *testing.T) {
+		expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		t.Run("ok", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: v1.Expiry{Did: vdr.TestDIDA.String(), ExpiresAt: expiresAt}})
+			cmd.SetArgs([]string{"set-expiry", vdr.TestDIDA.String(), "2030-01-01T12:00:00Z", "--key", vdr.TestMethodDIDA.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "Expiry scheduled at 2030-01-01T12:00:00Z")
+		})
+
+		t.Run("error - invalid time", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK})
+			cmd.SetArgs([]string{"set-expiry", vdr.TestDIDA.String(), "tomorrow"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			assert.Error(t, err)
+			assert.Contains(t, errBuf.String(), "invalid time (expected RFC3339 or duration): tomorrow")
+		})
+
+		t.Run("error - server error", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusForbidden})
+			cmd.SetArgs([]string{"set-expiry", vdr.TestDIDA.String(), "24h"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			assert.Error(t, err)
+			assert.Contains(t, errBuf.String(), "failed to schedule expiry: server returned HTTP 403 (expected: 200), response: null")
+		})
+	})
+
+	t.Run("cancelExpiry", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusNoContent})
+			cmd.SetArgs([]string{"cancel-expiry", vdr.TestDIDA.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "Expiry cancelled")
+		})
+
+		t.Run("error - not scheduled", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusNotFound})
+			cmd.SetArgs([]string{"cancel-expiry", vdr.TestDIDA.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			assert.Error(t, err)
+			assert.Contains(t, errBuf.String(), "failed to cancel expiry: server returned HTTP 404 (expected: 204), response: null")
+		})
+	})
+
+	t.Run("listExpiries", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			kid := vdr.TestMethodDIDA.String()
+			expiries := []v1.Expiry{
+				{Did: vdr.TestDIDA.String(), ExpiresAt: time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)},
+				{Did: vdr.TestDIDA.String(), KeyID: &kid, ExpiresAt: time.Date(2031, 1, 1, 12, 0, 0, 0, time.UTC)},
+			}
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: expiries})
+			cmd.SetArgs([]string{"list-expiries"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "2030-01-01T12:00:00Z  "+vdr.TestDIDA.String())
+			assert.Contains(t, buf.String(), "2031-01-01T12:00:00Z  "+kid)
+		})
+
+		t.Run("ok - none", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http2.Handler{StatusCode: http.StatusOK, ResponseData: []v1.Expiry{}})
+			cmd.SetArgs([]string{"list-expiries"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), "No expiries scheduled")
+		})
+	})
+
 	t.Run("addKeyAgreement", func(t *testing.T) {
 		pair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		verificationMethod, _ := did.NewVerificationMethod(*vdr.TestMethodDIDA, ssi.JsonWebKey2020, *vdr.TestDIDA, pair.PublicKey)
@@ -437,6 +700,28 @@ func TestEngine_Command(t *testing.T) {
 	})
 }
 
+func Test_parseExpiryTime(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("RFC3339", func(t *testing.T) {
+		result, err := parseExpiryTime("2031-02-03T04:05:06Z", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2031, 2, 3, 4, 5, 6, 0, time.UTC), result)
+	})
+	t.Run("duration", func(t *testing.T) {
+		result, err := parseExpiryTime("48h", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(48*time.Hour), result)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parseExpiryTime("next week", now)
+
+		assert.EqualError(t, err, "invalid time (expected RFC3339 or duration): next week")
+	})
+}
+
 func Test_askYesNo(t *testing.T) {
 	question := "do you believe that the earth is a convex sphere?"
 	cmd := Cmd()
@@ -22,7 +22,17 @@ package vdr
 const ModuleName = "VDR"
 
 // Config holds the config for the VDR engine
-type Config struct{}
+type Config struct {
+	Web WebConfig `koanf:"web"`
+}
+
+// WebConfig holds the config for hosting did:web DID documents
+type WebConfig struct {
+	// URL is the public HTTPS URL on which the node's HTTP interface is reachable, which is used to derive did:web DIDs.
+	URL string `koanf:"url"`
+	// Resolve specifies whether did:web DIDs not hosted by this node are resolved by downloading their DID document.
+	Resolve bool `koanf:"resolve"`
+}
 
 // DefaultConfig returns a fresh Config filled with default values
 func DefaultConfig() Config {
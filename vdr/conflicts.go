@@ -0,0 +1,118 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vdr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+// ResolveConflict merges the conflicting versions of a conflicted DID document and publishes the result as update,
+// which refers to all conflicting versions (heads), so the DID document has a single head again.
+func (r VDR) ResolveConflict(id did.DID, dryRun bool) (*types.ConflictResolution, error) {
+	currentDocument, currentMeta, err := r.store.Resolve(id, &types.ResolveMetadata{AllowDeactivated: true})
+	if err != nil {
+		return nil, err
+	}
+	if !currentMeta.IsConflicted() {
+		return nil, types.ErrNotConflicted
+	}
+	if store.IsDeactivated(*currentDocument) {
+		return nil, types.ErrDeactivated
+	}
+	if err = requireSingleControllerUpdate(*currentDocument); err != nil {
+		return nil, err
+	}
+	// Only documents controlled by this node can be updated
+	controller, key, err := r.resolveControllerWithKey(*currentDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *currentDocument
+	for _, head := range currentMeta.SourceTransactions {
+		head := head
+		headDocument, _, err := r.store.Resolve(id, &types.ResolveMetadata{SourceTransaction: &head, AllowDeactivated: true})
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve conflicting version (tx=%s): %w", head, err)
+		}
+		result, err := doc.MergeDocuments(merged, *headDocument)
+		if err != nil {
+			return nil, fmt.Errorf("unable to merge conflicting version (tx=%s): %w", head, err)
+		}
+		merged = *result
+	}
+	resolution := types.ConflictResolution{
+		Current: *currentDocument,
+		Merged:  merged,
+		Heads:   currentMeta.Copy().SourceTransactions,
+	}
+	if dryRun {
+		return &resolution, nil
+	}
+
+	if err = CreateDocumentValidator().Validate(merged); err != nil {
+		return nil, fmt.Errorf("merged DID document is invalid: %w", err)
+	}
+	payload, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	// The update must be published even if nothing changed, since it's the update that resolves the conflict
+	if err = r.publishUpdate(id, *currentMeta, controller, key, didDocumentType, payload); err != nil {
+		return nil, err
+	}
+	resolution.Published = true
+	log.Logger().
+		WithField(core.LogFieldDID, id).
+		Infof("Resolved conflicted DID Document (heads=%d)", len(resolution.Heads))
+	return &resolution, nil
+}
+
+// ResolveConflicts resolves the conflicts of all conflicted DID documents that are controlled by this node.
+// Conflicted DID documents that aren't controlled by this node, are deactivated or require multiple controllers to sign updates are skipped.
+func (r VDR) ResolveConflicts(dryRun bool) ([]types.ConflictResolution, error) {
+	conflicted, _, err := r.ConflictedDocuments()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]types.ConflictResolution, 0)
+	for _, document := range conflicted {
+		resolution, err := r.ResolveConflict(document.ID, dryRun)
+		if errors.Is(err, types.ErrDIDNotManagedByThisNode) || errors.Is(err, types.ErrDeactivated) || errors.Is(err, types.ErrControllerThresholdNotMet) {
+			log.Logger().
+				WithField(core.LogFieldDID, document.ID).
+				Debugf("Skipping conflicted DID Document: %s", err)
+			continue
+		}
+		if err != nil {
+			return results, fmt.Errorf("unable to resolve conflicted DID Document (did=%s): %w", document.ID, err)
+		}
+		results = append(results, *resolution)
+	}
+	return results, nil
+}
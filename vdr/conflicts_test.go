@@ -0,0 +1,149 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vdr
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/network"
+	"github.com/nuts-foundation/nuts-node/network/dag"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVDR_ResolveConflict(t *testing.T) {
+	heads := []hash.SHA256Hash{hash.SHA256Sum([]byte("head A")), hash.SHA256Sum([]byte("head B"))}
+	keyStore := crypto.NewTestCryptoInstance()
+	document, _, _ := doc.Creator{KeyStore: keyStore}.Create(doc.DefaultCreationOptions())
+	// document that is not controlled by this node
+	otherDocument, _, _ := doc.Creator{KeyStore: crypto.NewTestCryptoInstance()}.Create(doc.DefaultCreationOptions())
+	newContext := func(t *testing.T) (*VDR, *network.MockTransactions) {
+		ctrl := gomock.NewController(t)
+		networkMock := network.NewMockTransactions(ctrl)
+		didStore := store.NewMemoryStore()
+		_ = didStore.Write(*document, types.DocumentMetadata{SourceTransactions: heads})
+		_ = didStore.Write(*otherDocument, types.DocumentMetadata{SourceTransactions: heads})
+		return NewVDR(DefaultConfig(), keyStore, networkMock, didStore, nil, nil, nil), networkMock
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		vdr, networkMock := newContext(t)
+		var template network.Template
+		networkMock.EXPECT().CreateTransaction(gomock.Any()).DoAndReturn(func(tx network.Template) (dag.Transaction, error) {
+			template = tx
+			return nil, nil
+		})
+
+		resolution, err := vdr.ResolveConflict(document.ID, false)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, resolution.Published)
+		assert.Equal(t, heads, resolution.Heads)
+		assert.Equal(t, document.ID, resolution.Merged.ID)
+		assert.Len(t, resolution.Merged.VerificationMethod, 1)
+		// update must refer to all heads
+		for _, head := range heads {
+			assert.Contains(t, template.AdditionalPrevs, head)
+		}
+		assert.Equal(t, document.CapabilityInvocation[0].ID.String(), template.Key.KID())
+	})
+	t.Run("ok - dry run", func(t *testing.T) {
+		vdr, _ := newContext(t)
+
+		resolution, err := vdr.ResolveConflict(document.ID, true)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.False(t, resolution.Published)
+		assert.Equal(t, resolution.Current, resolution.Merged)
+	})
+	t.Run("error - not conflicted", func(t *testing.T) {
+		vdr, _ := newContext(t)
+		vdr.store = store.NewMemoryStore()
+		_ = vdr.store.Write(*document, types.DocumentMetadata{SourceTransactions: heads[:1]})
+
+		_, err := vdr.ResolveConflict(document.ID, false)
+
+		assert.ErrorIs(t, err, types.ErrNotConflicted)
+	})
+	t.Run("error - not controlled by this node", func(t *testing.T) {
+		vdr, _ := newContext(t)
+
+		_, err := vdr.ResolveConflict(otherDocument.ID, false)
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		vdr, _ := newContext(t)
+
+		_, err := vdr.ResolveConflict(did.MustParseDID("did:nuts:unknown"), false)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+}
+
+func TestVDR_ResolveConflicts(t *testing.T) {
+	heads := []hash.SHA256Hash{hash.SHA256Sum([]byte("head A")), hash.SHA256Sum([]byte("head B"))}
+	keyStore := crypto.NewTestCryptoInstance()
+	document, _, _ := doc.Creator{KeyStore: keyStore}.Create(doc.DefaultCreationOptions())
+	otherDocument, _, _ := doc.Creator{KeyStore: crypto.NewTestCryptoInstance()}.Create(doc.DefaultCreationOptions())
+	notConflicted, _, _ := doc.Creator{KeyStore: keyStore}.Create(doc.DefaultCreationOptions())
+	ctrl := gomock.NewController(t)
+	networkMock := network.NewMockTransactions(ctrl)
+	didStore := store.NewMemoryStore()
+	_ = didStore.Write(*document, types.DocumentMetadata{SourceTransactions: heads})
+	_ = didStore.Write(*otherDocument, types.DocumentMetadata{SourceTransactions: heads})
+	_ = didStore.Write(*notConflicted, types.DocumentMetadata{SourceTransactions: heads[:1]})
+	vdr := NewVDR(DefaultConfig(), keyStore, networkMock, didStore, nil, nil, nil)
+
+	t.Run("dry run", func(t *testing.T) {
+		resolutions, err := vdr.ResolveConflicts(true)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, resolutions, 1) {
+			return
+		}
+		assert.Equal(t, document.ID, resolutions[0].Current.ID)
+		assert.False(t, resolutions[0].Published)
+	})
+	t.Run("publish", func(t *testing.T) {
+		networkMock.EXPECT().CreateTransaction(gomock.Any())
+
+		resolutions, err := vdr.ResolveConflicts(false)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, resolutions, 1) {
+			return
+		}
+		assert.True(t, resolutions[0].Published)
+	})
+}
@@ -0,0 +1,118 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package didjwk implements resolving of did:jwk DIDs (https://github.com/quartzjer/did-jwk),
+// which contain a base64url encoded public JSON Web Key.
+package didjwk
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+// MethodName is the DID method name of did:jwk
+const MethodName = "jwk"
+
+// keyFragment is the fragment of the ID of the only verification method of a did:jwk document
+const keyFragment = "0"
+
+// Resolver resolves did:jwk DIDs.
+type Resolver struct{}
+
+// NewResolver creates a new did:jwk resolver.
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// Resolve decodes the JWK from the DID and returns a DID document containing it.
+// Depending on the "use" parameter of the JWK, the key is added to keyAgreement ("enc"),
+// all other verification relationships ("sig") or all verification relationships (not specified).
+func (r Resolver) Resolve(id did.DID, _ *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
+	if id.Method != MethodName {
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	key, err := decodeJWK(id.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid did:jwk: %w", err)
+	}
+	var publicKey crypto.PublicKey
+	if err = key.Raw(&publicKey); err != nil {
+		return nil, nil, fmt.Errorf("invalid did:jwk: %w", err)
+	}
+	keyID := id
+	keyID.Fragment = keyFragment
+	verificationMethod, err := did.NewVerificationMethod(keyID, ssi.JsonWebKey2020, id, publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	document := did.Document{
+		Context: []ssi.URI{did.DIDContextV1URI()},
+		ID:      id,
+	}
+	use := key.KeyUsage()
+	if use != jwk.ForSignature.String() {
+		document.AddKeyAgreement(verificationMethod)
+	}
+	if use != jwk.ForEncryption.String() {
+		document.AddAuthenticationMethod(verificationMethod)
+		document.AddAssertionMethod(verificationMethod)
+		document.AddCapabilityInvocation(verificationMethod)
+		document.AddCapabilityDelegation(verificationMethod)
+	}
+	return &document, &types.DocumentMetadata{}, nil
+}
+
+// NewDID creates a did:jwk DID for the given public key.
+func NewDID(publicKey crypto.PublicKey) (*did.DID, error) {
+	key, err := jwk.New(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	return did.ParseDID("did:jwk:" + base64.RawURLEncoding.EncodeToString(data))
+}
+
+func decodeJWK(encoded string) (jwk.Key, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwk.ParseKey(data)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case jwk.RSAPublicKey, jwk.ECDSAPublicKey, jwk.OKPPublicKey:
+		return key, nil
+	case jwk.RSAPrivateKey, jwk.ECDSAPrivateKey, jwk.OKPPrivateKey:
+		return nil, errors.New("JWK must not contain private key material")
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", key.KeyType())
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package didjwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	resolver := NewResolver()
+
+	t.Run("ok - P-256", func(t *testing.T) {
+		id := did.MustParseDID("did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9")
+
+		document, metadata, err := resolver.Resolve(id, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NotNil(t, metadata)
+		assert.Equal(t, id, document.ID)
+		if !assert.Len(t, document.VerificationMethod, 1) {
+			return
+		}
+		assert.Equal(t, id.String()+"#0", document.VerificationMethod[0].ID.String())
+		assert.Len(t, document.AssertionMethod, 1)
+		assert.Len(t, document.Authentication, 1)
+		assert.Len(t, document.CapabilityInvocation, 1)
+		assert.Len(t, document.CapabilityDelegation, 1)
+		assert.Len(t, document.KeyAgreement, 1)
+		publicKey, err := document.AssertionMethod[0].PublicKey()
+		if assert.NoError(t, err) {
+			assert.IsType(t, &ecdsa.PublicKey{}, publicKey)
+		}
+	})
+
+	t.Run("ok - encryption key", func(t *testing.T) {
+		id := did.MustParseDID("did:jwk:eyJrdHkiOiJPS1AiLCJjcnYiOiJYMjU1MTkiLCJ1c2UiOiJlbmMiLCJ4IjoiM3A3YmZYdDl3YlRUVzJIQzdPUTFOei1EUThoYmVHZE5yZngtRkctSUswOCJ9")
+
+		document, _, err := resolver.Resolve(id, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, document.KeyAgreement, 1)
+		assert.Empty(t, document.AssertionMethod)
+		assert.Empty(t, document.Authentication)
+		assert.Empty(t, document.CapabilityInvocation)
+	})
+
+	t.Run("ok - created DID", func(t *testing.T) {
+		key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		id, err := NewDID(key.Public())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		document, _, err := resolver.Resolve(*id, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		publicKey, _ := document.AssertionMethod[0].PublicKey()
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+
+	t.Run("error - other DID method", func(t *testing.T) {
+		_, _, err := resolver.Resolve(did.MustParseDID("did:nuts:123"), nil)
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+
+	t.Run("error - invalid DIDs", func(t *testing.T) {
+		privateKey := `{"kty":"EC","crv":"P-256","x":"acbIQiuMs3i8_uszEjJ2tpTtRM4EU3yz91PH6CdH2V0","y":"_KcyLj9vWMptnmKtm46GqDz8wf74I5LKgrl2GzH3nSE","d":"qfkUmNV9xKGvJzMqNGkPS76K0rjMUSDMeFWHQiN9yCE"}`
+		testCases := map[string]string{
+			"not base64url": "did:jwk:a.b",
+			"not a JWK":     "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"foo":"bar"}`)),
+			"private key":   "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(privateKey)),
+			"symmetric key": "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"kty":"oct","k":"c2VjcmV0"}`)),
+		}
+		for name, id := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := resolver.Resolve(did.MustParseDID(id), nil)
+
+				assert.ErrorContains(t, err, "invalid did:jwk")
+			})
+		}
+	})
+}
@@ -0,0 +1,147 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package didkey implements resolving of did:key DIDs (https://w3c-ccg.github.io/did-method-key/),
+// which encode a public key in the DID itself.
+package didkey
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/shengdoushi/base58"
+)
+
+// MethodName is the DID method name of did:key
+const MethodName = "key"
+
+// base58BTCPrefix is the multibase prefix for base58-btc encoded values
+const base58BTCPrefix = 'z'
+
+// multicodec identifiers of the supported public key types
+const (
+	ed25519Codec = 0xed
+	p256Codec    = 0x1200
+	p384Codec    = 0x1201
+	p521Codec    = 0x1202
+)
+
+var curvesPerCodec = map[uint64]elliptic.Curve{
+	p256Codec: elliptic.P256(),
+	p384Codec: elliptic.P384(),
+	p521Codec: elliptic.P521(),
+}
+
+// Resolver resolves did:key DIDs. Supported key types are Ed25519, P-256, P-384 and P-521.
+type Resolver struct{}
+
+// NewResolver creates a new did:key resolver.
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// Resolve decodes the public key from the DID and returns a DID document containing it.
+// The key is added to all verification relationships, except keyAgreement.
+func (r Resolver) Resolve(id did.DID, _ *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
+	if id.Method != MethodName {
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	publicKey, err := decodePublicKey(id.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid did:key: %w", err)
+	}
+	keyID := id
+	keyID.Fragment = id.ID
+	verificationMethod, err := did.NewVerificationMethod(keyID, ssi.JsonWebKey2020, id, publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	document := did.Document{
+		Context: []ssi.URI{did.DIDContextV1URI()},
+		ID:      id,
+	}
+	document.AddAuthenticationMethod(verificationMethod)
+	document.AddAssertionMethod(verificationMethod)
+	document.AddCapabilityInvocation(verificationMethod)
+	document.AddCapabilityDelegation(verificationMethod)
+	return &document, &types.DocumentMetadata{}, nil
+}
+
+// NewDID creates a did:key DID for the given public key.
+func NewDID(publicKey crypto.PublicKey) (*did.DID, error) {
+	var codec uint64
+	var keyBytes []byte
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		codec = ed25519Codec
+		keyBytes = key
+	case *ecdsa.PublicKey:
+		for curr, curve := range curvesPerCodec {
+			if curve == key.Curve {
+				codec = curr
+			}
+		}
+		if codec == 0 {
+			return nil, errors.New("unsupported curve")
+		}
+		keyBytes = elliptic.MarshalCompressed(key.Curve, key.X, key.Y)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", publicKey)
+	}
+	data := make([]byte, binary.MaxVarintLen64)
+	data = append(data[:binary.PutUvarint(data, codec)], keyBytes...)
+	return did.ParseDID("did:key:" + string(base58BTCPrefix) + base58.Encode(data, base58.BitcoinAlphabet))
+}
+
+func decodePublicKey(encoded string) (crypto.PublicKey, error) {
+	if len(encoded) < 2 || encoded[0] != base58BTCPrefix {
+		return nil, errors.New("only base58-btc multibase encoding is supported")
+	}
+	data, err := base58.Decode(encoded[1:], base58.BitcoinAlphabet)
+	if err != nil {
+		return nil, err
+	}
+	codec, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("invalid multicodec prefix")
+	}
+	keyBytes := data[n:]
+	if codec == ed25519Codec {
+		if len(keyBytes) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key size")
+		}
+		return ed25519.PublicKey(keyBytes), nil
+	}
+	curve, ok := curvesPerCodec[codec]
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type (multicodec=0x%x)", codec)
+	}
+	x, y := elliptic.UnmarshalCompressed(curve, keyBytes)
+	if x == nil {
+		return nil, fmt.Errorf("invalid %s public key", curve.Params().Name)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
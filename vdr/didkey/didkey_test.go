@@ -0,0 +1,131 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package didkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	resolver := NewResolver()
+
+	t.Run("ok - Ed25519", func(t *testing.T) {
+		id := did.MustParseDID("did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")
+
+		document, metadata, err := resolver.Resolve(id, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NotNil(t, metadata)
+		assert.Equal(t, id, document.ID)
+		if !assert.Len(t, document.VerificationMethod, 1) {
+			return
+		}
+		assert.Equal(t, "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK#z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK", document.VerificationMethod[0].ID.String())
+		assert.Len(t, document.AssertionMethod, 1)
+		assert.Len(t, document.Authentication, 1)
+		assert.Len(t, document.CapabilityInvocation, 1)
+		assert.Len(t, document.CapabilityDelegation, 1)
+		assert.Empty(t, document.KeyAgreement)
+		publicKey, err := document.AssertionMethod[0].PublicKey()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.IsType(t, ed25519.PublicKey{}, publicKey)
+		// re-encoding the key must result in the same DID
+		encoded, _ := NewDID(publicKey)
+		assert.Equal(t, id, *encoded)
+	})
+
+	t.Run("ok - P-256", func(t *testing.T) {
+		id := did.MustParseDID("did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169")
+
+		document, _, err := resolver.Resolve(id, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		publicKey, _ := document.AssertionMethod[0].PublicKey()
+		if assert.IsType(t, &ecdsa.PublicKey{}, publicKey) {
+			assert.Equal(t, elliptic.P256(), publicKey.(*ecdsa.PublicKey).Curve)
+		}
+		encoded, _ := NewDID(publicKey)
+		assert.Equal(t, id, *encoded)
+	})
+
+	t.Run("error - other DID method", func(t *testing.T) {
+		_, _, err := resolver.Resolve(did.MustParseDID("did:nuts:123"), nil)
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+
+	t.Run("error - invalid DIDs", func(t *testing.T) {
+		testCases := map[string]string{
+			"not base58-btc":           "did:key:f1234",
+			"invalid base58":           "did:key:z0OIl",
+			"unsupported key type":     "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme",
+			"invalid Ed25519 key":      "did:key:z6Mkha",
+			"invalid P-256 key":        "did:key:zDnaer",
+			"empty method-specific ID": "did:key:z",
+		}
+		for name, id := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := resolver.Resolve(did.MustParseDID(id), nil)
+
+				assert.ErrorContains(t, err, "invalid did:key")
+			})
+		}
+	})
+}
+
+func TestNewDID(t *testing.T) {
+	t.Run("ok - P-384", func(t *testing.T) {
+		key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+		id, err := NewDID(key.Public())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		document, _, err := NewResolver().Resolve(*id, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		publicKey, _ := document.AssertionMethod[0].PublicKey()
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+
+	t.Run("error - unsupported key type", func(t *testing.T) {
+		key, _ := rsa.GenerateKey(rand.Reader, 1024)
+
+		_, err := NewDID(key.Public())
+
+		assert.EqualError(t, err, "unsupported key type: *rsa.PublicKey")
+	})
+}
@@ -0,0 +1,266 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package didweb implements resolving of did:web DIDs (https://w3c-ccg.github.io/did-method-web/),
+// which are resolved by downloading the DID document from the web server the DID refers to.
+package didweb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+// MethodName is the DID method name of did:web
+const MethodName = "web"
+
+// DefaultCacheTTL is the default duration resolved DID documents are cached.
+const DefaultCacheTTL = 5 * time.Minute
+
+// DefaultTimeout is the default timeout for downloading a DID document.
+const DefaultTimeout = 10 * time.Second
+
+// maxCacheEntries bounds the number of cached DID documents, to protect against unbounded memory usage.
+const maxCacheEntries = 1000
+
+// maxDocumentSize is the maximum size of a DID document that will be downloaded.
+const maxDocumentSize = 1024 * 1024
+
+// ErrAddressNotAllowed is returned when a did:web DID refers to a host on a loopback, private or link-local address.
+var ErrAddressNotAllowed = errors.New("did:web host address not allowed")
+
+// Resolver resolves did:web DIDs by downloading the DID document over HTTPS.
+// Resolved documents are cached for the configured TTL.
+type Resolver struct {
+	// Enabled specifies whether DID documents may be downloaded. If not, only DID documents hosted by this node (see Local) are resolved.
+	Enabled bool
+	// HTTPClient is used to download DID documents.
+	HTTPClient *http.Client
+	// CacheTTL specifies how long resolved DID documents are cached. A zero value disables caching.
+	CacheTTL time.Duration
+	// Local resolves the DID documents hosted by this node, so they don't need to be downloaded. Optional.
+	Local types.DIDResolver
+
+	cache map[string]cacheEntry
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+type cacheEntry struct {
+	document *did.Document
+	expires  time.Time
+}
+
+// NewResolver creates a new did:web resolver with default timeout and cache TTL. Downloading DID documents is disabled.
+// Its HTTP client refuses to connect to loopback, private and link-local addresses,
+// since DIDs (e.g. of credential issuers) are supplied by other parties.
+func NewResolver() *Resolver {
+	dialer := &net.Dialer{Timeout: DefaultTimeout, Control: restrictAddress}
+	return &Resolver{
+		HTTPClient: &http.Client{
+			Timeout: DefaultTimeout,
+			Transport: &http.Transport{
+				// No proxy, since then the node would connect to the proxy instead of the host being checked.
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: DefaultTimeout,
+				ForceAttemptHTTP2:   true,
+			},
+		},
+		CacheTTL: DefaultCacheTTL,
+	}
+}
+
+// restrictAddress is called by the dialer after the host name has been resolved, so it checks the actual address
+// being connected to. Checking it before resolving the host name would be circumvented by DNS rebinding.
+func restrictAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, host)
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// Resolve downloads the DID document from the location the DID refers to.
+// It returns types.ErrNotFound if the web server reports the document does not exist.
+func (r *Resolver) Resolve(id did.DID, _ *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
+	if id.Method != MethodName {
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	// Cache by DID without path/query/fragment, since that's what identifies the document
+	baseID, err := did.ParseDID("did:" + MethodName + ":" + id.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.Local != nil {
+		document, metadata, err := r.Local.Resolve(*baseID, &types.ResolveMetadata{AllowDeactivated: true})
+		if err == nil {
+			if metadata.Deactivated {
+				return nil, nil, types.ErrNotFound
+			}
+			return document, &types.DocumentMetadata{}, nil
+		}
+		if !errors.Is(err, types.ErrNotFound) {
+			return nil, nil, err
+		}
+	}
+	if !r.Enabled {
+		return nil, nil, fmt.Errorf("%w: resolving did:web DIDs not hosted by this node is disabled", types.ErrUnsupportedDIDMethod)
+	}
+	if document := r.getCached(baseID.String()); document != nil {
+		return document, &types.DocumentMetadata{}, nil
+	}
+	documentURL, err := URL(*baseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := r.download(documentURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !document.ID.Equals(*baseID) {
+		return nil, nil, fmt.Errorf("did:web document ID mismatch (expected=%s, actual=%s)", baseID, document.ID)
+	}
+	r.putCached(baseID.String(), document)
+	return document, &types.DocumentMetadata{}, nil
+}
+
+// URL returns the URL of the DID document the given did:web DID refers to.
+// A DID without path resolves to https://<host>/.well-known/did.json, otherwise the path segments are appended to the host,
+// e.g. did:web:example.com%3A8443:user:alice resolves to https://example.com:8443/user/alice/did.json.
+func URL(id did.DID) (*url.URL, error) {
+	if id.Method != MethodName {
+		return nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	parts := strings.Split(id.ID, ":")
+	host, err := url.PathUnescape(parts[0])
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("invalid did:web: invalid host: %s", parts[0])
+	}
+	path := "/.well-known"
+	if len(parts) > 1 {
+		path = ""
+		for _, part := range parts[1:] {
+			segment, err := url.PathUnescape(part)
+			if err != nil || segment == "" || strings.Contains(segment, "/") {
+				return nil, fmt.Errorf("invalid did:web: invalid path segment: %s", part)
+			}
+			path += "/" + segment
+		}
+	}
+	result := &url.URL{Scheme: "https", Host: host, Path: path + "/did.json"}
+	if result.Hostname() == "" || strings.ContainsAny(host, "/?#@") {
+		return nil, fmt.Errorf("invalid did:web: invalid host: %s", host)
+	}
+	return result, nil
+}
+
+func (r *Resolver) download(documentURL *url.URL) (*did.Document, error) {
+	response, err := r.HTTPClient.Get(documentURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to download did:web document (url=%s): %w", documentURL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone {
+		return nil, types.ErrNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download did:web document (url=%s): server returned status %d", documentURL, response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to download did:web document (url=%s): %w", documentURL, err)
+	}
+	if len(data) > maxDocumentSize {
+		return nil, errors.New("did:web document exceeds maximum size")
+	}
+	document := did.Document{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid did:web document (url=%s): %w", documentURL, err)
+	}
+	return &document, nil
+}
+
+func (r *Resolver) getCached(id string) *did.Document {
+	if r.CacheTTL <= 0 {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.cache[id]
+	if !ok {
+		return nil
+	}
+	if !r.currentTime().Before(entry.expires) {
+		delete(r.cache, id)
+		return nil
+	}
+	return entry.document
+}
+
+func (r *Resolver) putCached(id string, document *did.Document) {
+	if r.CacheTTL <= 0 {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.currentTime()
+	if r.cache == nil {
+		r.cache = map[string]cacheEntry{}
+	}
+	if len(r.cache) >= maxCacheEntries {
+		// Evict expired entries first, if the cache is still full evict everything
+		for key, entry := range r.cache {
+			if !now.Before(entry.expires) {
+				delete(r.cache, key)
+			}
+		}
+		if len(r.cache) >= maxCacheEntries {
+			r.cache = map[string]cacheEntry{}
+		}
+	}
+	r.cache[id] = cacheEntry{document: document, expires: now.Add(r.CacheTTL)}
+}
+
+func (r *Resolver) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,215 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package didweb
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURL(t *testing.T) {
+	testCases := map[string]string{
+		"did:web:example.com":            "https://example.com/.well-known/did.json",
+		"did:web:example.com:user:alice": "https://example.com/user/alice/did.json",
+	}
+	for id, expected := range testCases {
+		t.Run(id, func(t *testing.T) {
+			actual, err := URL(did.MustParseDID(id))
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, expected, actual.String())
+		})
+	}
+	t.Run("error - other DID method", func(t *testing.T) {
+		_, err := URL(did.MustParseDID("did:nuts:123"))
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	var requests int
+	var documentJSON string
+	handler := http.NewServeMux()
+	handler.HandleFunc("/.well-known/did.json", func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		_, _ = writer.Write([]byte(documentJSON))
+	})
+	handler.HandleFunc("/error/did.json", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	})
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	// The test server's certificate is valid for example.com, so route all connections to the test server.
+	// This is required since the DID parser doesn't support percent-encoded ports in the DID.
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	baseDID := "did:web:example.com"
+	documentJSON = `{"@context":"https://www.w3.org/ns/did/v1","id":"` + baseDID + `"}`
+
+	newResolver := func() *Resolver {
+		resolver := NewResolver()
+		resolver.Enabled = true
+		resolver.HTTPClient = &http.Client{Transport: transport}
+		return resolver
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		requests = 0
+		resolver := newResolver()
+
+		document, metadata, err := resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NotNil(t, metadata)
+		assert.Equal(t, baseDID, document.ID.String())
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("ok - cached", func(t *testing.T) {
+		requests = 0
+		resolver := newResolver()
+		now := time.Now()
+		resolver.now = func() time.Time {
+			return now
+		}
+
+		_, _, _ = resolver.Resolve(did.MustParseDID(baseDID), nil)
+		_, _, err := resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, requests)
+
+		t.Run("expired", func(t *testing.T) {
+			now = now.Add(DefaultCacheTTL)
+
+			_, _, err := resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 2, requests)
+		})
+	})
+	t.Run("ok - caching disabled", func(t *testing.T) {
+		requests = 0
+		resolver := newResolver()
+		resolver.CacheTTL = 0
+
+		_, _, _ = resolver.Resolve(did.MustParseDID(baseDID), nil)
+		_, _, _ = resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		_, _, err := newResolver().Resolve(did.MustParseDID(baseDID+":unknown"), nil)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		_, _, err := newResolver().Resolve(did.MustParseDID(baseDID+":error"), nil)
+
+		assert.ErrorContains(t, err, "server returned status 500")
+	})
+	t.Run("error - document ID mismatch", func(t *testing.T) {
+		resolver := newResolver()
+		documentJSON = `{"@context":"https://www.w3.org/ns/did/v1","id":"did:web:example.org"}`
+		defer func() {
+			documentJSON = `{"@context":"https://www.w3.org/ns/did/v1","id":"` + baseDID + `"}`
+		}()
+
+		_, _, err := resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+		assert.ErrorContains(t, err, "document ID mismatch")
+	})
+	t.Run("error - untrusted TLS certificate", func(t *testing.T) {
+		resolver := NewResolver()
+		resolver.Enabled = true
+		resolver.HTTPClient = &http.Client{Transport: &http.Transport{DialContext: transport.DialContext}}
+
+		_, _, err := resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+		assert.ErrorContains(t, err, "unable to download did:web document")
+	})
+	t.Run("error - other DID method", func(t *testing.T) {
+		_, _, err := newResolver().Resolve(did.MustParseDID("did:nuts:123"), nil)
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+	t.Run("error - disabled", func(t *testing.T) {
+		requests = 0
+		resolver := newResolver()
+		resolver.Enabled = false
+
+		_, _, err := resolver.Resolve(did.MustParseDID(baseDID), nil)
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+		assert.Equal(t, 0, requests)
+	})
+	t.Run("error - host on loopback address", func(t *testing.T) {
+		// The default HTTP client refuses to connect to the test server, which listens on a loopback address
+		resolver := NewResolver()
+		resolver.Enabled = true
+		id := "did:web:" + server.Listener.Addr().(*net.TCPAddr).IP.String()
+
+		_, _, err := resolver.Resolve(did.MustParseDID(id), nil)
+
+		assert.ErrorIs(t, err, ErrAddressNotAllowed)
+	})
+}
+
+func Test_restrictAddress(t *testing.T) {
+	testCases := map[string]bool{
+		"93.184.216.34:443":     true,
+		"[2606:2800::1]:443":    true,
+		"127.0.0.1:443":         false,
+		"[::1]:443":             false,
+		"10.0.0.1:443":          false,
+		"172.16.0.1:443":        false,
+		"192.168.1.1:443":       false,
+		"169.254.169.254:80":    false,
+		"[fe80::1]:443":         false,
+		"[fd00::1]:443":         false,
+		"0.0.0.0:443":           false,
+		"224.0.0.1:443":         false,
+		"[::ffff:127.0.0.1]:80": false,
+	}
+	for address, allowed := range testCases {
+		t.Run(address, func(t *testing.T) {
+			err := restrictAddress("tcp", address, nil)
+
+			if allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrAddressNotAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package didweb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+// HostingPath is the HTTP path under which the did:web documents hosted by this node are served.
+// A hosted DID document is served on <HostingPath>/<id>/did.json.
+const HostingPath = "/iam"
+
+// ErrHostingNotConfigured is returned when a did:web DID is created while no base URL has been configured.
+var ErrHostingNotConfigured = errors.New("did:web hosting is not configured")
+
+const (
+	// hostStoreName contains the name of the KV store in which the hosted documents are stored
+	hostStoreName = "didweb"
+	// documentShelf has the DID as key and the documentRecord as value
+	documentShelf = "documents"
+)
+
+// Host manages the did:web DID documents hosted by this node.
+// The private keys of these documents are stored in the crypto KeyStore, and the documents are served over HTTP
+// so other parties can resolve them.
+type Host struct {
+	keyStore      crypto.KeyStore
+	storeProvider storage.Provider
+	db            stoabs.KVStore
+	baseURL       *url.URL
+}
+
+type documentRecord struct {
+	Document did.Document           `json:"document"`
+	Metadata types.DocumentMetadata `json:"metadata"`
+}
+
+// NewHost creates a new Host, which stores the keys of the documents it creates in the given KeyStore.
+func NewHost(keyStore crypto.KeyStore, storeProvider storage.Provider) *Host {
+	return &Host{keyStore: keyStore, storeProvider: storeProvider}
+}
+
+// Configure opens the document store. The baseURL is the public (HTTPS) URL on which the node's HTTP interface
+// is reachable, which determines the DIDs of the documents. If empty, no new did:web DIDs can be created.
+func (h *Host) Configure(baseURL string) error {
+	if baseURL != "" {
+		parsed, err := parseBaseURL(baseURL)
+		if err != nil {
+			return err
+		}
+		h.baseURL = parsed
+		// Make sure the base URL yields valid DIDs
+		if _, err = h.hostedDID(uuid.NewString()); err != nil {
+			return fmt.Errorf("invalid did:web base URL: %w", err)
+		}
+	}
+	var err error
+	h.db, err = h.storeProvider.GetKVStore(hostStoreName, storage.PersistentStorageClass)
+	return err
+}
+
+// Shutdown closes the document store.
+func (h *Host) Shutdown() error {
+	if h.db != nil {
+		return h.db.Close(context.Background())
+	}
+	return nil
+}
+
+func parseBaseURL(input string) (*url.URL, error) {
+	result, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid did:web base URL: %w", err)
+	}
+	if result.Scheme != "https" {
+		return nil, errors.New("invalid did:web base URL: scheme must be https")
+	}
+	if result.Port() != "" {
+		return nil, errors.New("invalid did:web base URL: port is not supported")
+	}
+	if result.Hostname() == "" || result.User != nil || result.RawQuery != "" || result.Fragment != "" {
+		return nil, errors.New("invalid did:web base URL: must only contain host and path")
+	}
+	return result, nil
+}
+
+// Create creates a new did:web DID document, of which the key is stored in the crypto KeyStore.
+// Since the document is hosted by this node, it can't be controlled by other DIDs.
+func (h *Host) Create(options types.DIDCreationOptions) (*did.Document, crypto.Key, error) {
+	if h.baseURL == nil {
+		return nil, nil, ErrHostingNotConfigured
+	}
+	if len(options.Controllers) > 0 {
+		return nil, nil, core.InvalidInputError("controllers are not supported for did:web DIDs")
+	}
+	id, err := h.hostedDID(uuid.NewString())
+	if err != nil {
+		return nil, nil, err
+	}
+	verificationMethod, err := doc.CreateNewVerificationMethodForDID(*id, h.keyStore)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := h.keyStore.Resolve(verificationMethod.ID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document := doc.CreateDocument()
+	document.ID = *id
+	document.VerificationMethod.Add(verificationMethod)
+	if options.CapabilityDelegation {
+		document.AddCapabilityDelegation(verificationMethod)
+	}
+	if options.CapabilityInvocation {
+		document.AddCapabilityInvocation(verificationMethod)
+	}
+	if options.Authentication {
+		document.AddAuthenticationMethod(verificationMethod)
+	}
+	if options.AssertionMethod {
+		document.AddAssertionMethod(verificationMethod)
+	}
+	if options.KeyAgreement {
+		document.AddKeyAgreement(verificationMethod)
+	}
+
+	record, err := newRecord(document, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = h.write(*record); err != nil {
+		return nil, nil, err
+	}
+	return &document, key, nil
+}
+
+// hostedDID returns the DID of the hosted document with the given ID, derived from the base URL.
+// E.g. for base URL https://example.com/nuts it returns did:web:example.com:nuts:iam:<id>
+func (h *Host) hostedDID(id string) (*did.DID, error) {
+	idParts := []string{url.PathEscape(h.baseURL.Hostname())}
+	for _, segment := range strings.Split(strings.Trim(h.baseURL.Path, "/"), "/") {
+		if segment != "" {
+			idParts = append(idParts, url.PathEscape(segment))
+		}
+	}
+	idParts = append(idParts, strings.Trim(HostingPath, "/"), id)
+	return did.ParseDID("did:" + MethodName + ":" + strings.Join(idParts, ":"))
+}
+
+// Update replaces the hosted DID document with the next version.
+// It returns types.ErrUpdateOnOutdatedData if current isn't the hash of the current version of the document,
+// and types.ErrDIDNotManagedByThisNode if the document isn't hosted by this node.
+// The current version is checked and replaced in a single write transaction, so concurrent updates based on the same version
+// can't overwrite each other: only the first one succeeds.
+func (h *Host) Update(id did.DID, current hash.SHA256Hash, next did.Document) error {
+	return h.db.WriteShelf(context.Background(), documentShelf, func(writer stoabs.Writer) error {
+		existing, err := readRecord(writer, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return types.ErrDIDNotManagedByThisNode
+		}
+		if existing.Metadata.Deactivated {
+			return types.ErrDeactivated
+		}
+		if !existing.Metadata.Hash.Equals(current) {
+			return types.ErrUpdateOnOutdatedData
+		}
+		if !next.ID.Equals(id) {
+			return core.InvalidInputError("DID document ID (%s) does not match the DID being updated (%s)", next.ID, id)
+		}
+		record, err := newRecord(next, existing)
+		if err != nil {
+			return err
+		}
+		// Nothing changed, so we don't need to update the DID document
+		if record.Metadata.Hash.Equals(current) {
+			return nil
+		}
+		return writeRecord(writer, *record)
+	})
+}
+
+// Resolve resolves a DID document hosted by this node. It returns types.ErrNotFound if the document isn't hosted by this node.
+// Hosted documents have no history, so the latest version is always returned.
+func (h *Host) Resolve(id did.DID, metadata *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
+	if id.Method != MethodName {
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	record, err := h.read(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if record == nil {
+		return nil, nil, types.ErrNotFound
+	}
+	if record.Metadata.Deactivated && (metadata == nil || !metadata.AllowDeactivated) {
+		return nil, nil, types.ErrDeactivated
+	}
+	return &record.Document, &record.Metadata, nil
+}
+
+// Routes registers the HTTP endpoint on which the hosted DID documents are served.
+func (h *Host) Routes(router core.EchoRouter) {
+	router.Add(http.MethodGet, HostingPath+"/:id/did.json", h.serveDocument)
+}
+
+func (h *Host) serveDocument(ctx echo.Context) error {
+	if h.baseURL == nil {
+		return ctx.NoContent(http.StatusNotFound)
+	}
+	id, err := h.hostedDID(ctx.Param("id"))
+	if err != nil {
+		return ctx.NoContent(http.StatusNotFound)
+	}
+	document, _, err := h.Resolve(*id, nil)
+	if errors.Is(err, types.ErrNotFound) || errors.Is(err, types.ErrDeactivated) {
+		return ctx.NoContent(http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, document)
+}
+
+func newRecord(document did.Document, previous *documentRecord) (*documentRecord, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result := documentRecord{
+		Document: document,
+		Metadata: types.DocumentMetadata{
+			Created:     now,
+			Hash:        hash.SHA256Sum(data),
+			Deactivated: store.IsDeactivated(document),
+		},
+	}
+	if previous != nil {
+		previousHash := previous.Metadata.Hash
+		result.Metadata.Created = previous.Metadata.Created
+		result.Metadata.Updated = &now
+		result.Metadata.PreviousHash = &previousHash
+	}
+	return &result, nil
+}
+
+func (h *Host) read(id did.DID) (*documentRecord, error) {
+	var result *documentRecord
+	err := h.db.ReadShelf(context.Background(), documentShelf, func(reader stoabs.Reader) error {
+		var err error
+		result, err = readRecord(reader, id)
+		return err
+	})
+	return result, err
+}
+
+func (h *Host) write(record documentRecord) error {
+	return h.db.WriteShelf(context.Background(), documentShelf, func(writer stoabs.Writer) error {
+		return writeRecord(writer, record)
+	})
+}
+
+func readRecord(reader stoabs.Reader, id did.DID) (*documentRecord, error) {
+	data, err := reader.Get(stoabs.BytesKey(id.String()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read did:web document (did=%s): %w", id, err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	result := &documentRecord{}
+	if err = json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("unable to read did:web document (did=%s): %w", id, err)
+	}
+	return result, nil
+}
+
+func writeRecord(writer stoabs.Writer, record documentRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return writer.Put(stoabs.BytesKey(record.Document.ID.String()), data)
+}
@@ -0,0 +1,277 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package didweb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHost(t *testing.T, baseURL string) *Host {
+	storageEngine := storage.NewTestStorageEngine(io.TestDirectory(t))
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	host := NewHost(crypto.NewTestCryptoInstance(), storageEngine.GetProvider("VDR"))
+	if err := host.Configure(baseURL); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = host.Shutdown()
+	})
+	return host
+}
+
+func TestHost_Configure(t *testing.T) {
+	t.Run("ok - hosting disabled", func(t *testing.T) {
+		host := newTestHost(t, "")
+
+		_, _, err := host.Create(doc.DefaultCreationOptions())
+
+		assert.ErrorIs(t, err, ErrHostingNotConfigured)
+	})
+	t.Run("error - invalid base URL", func(t *testing.T) {
+		testCases := map[string]string{
+			"http://example.com":       "scheme must be https",
+			"https://example.com:8443": "port is not supported",
+			"https://example.com?a=b":  "must only contain host and path",
+			"https://example.com/a_b":  "invalid did:web base URL",
+		}
+		for baseURL, expected := range testCases {
+			t.Run(baseURL, func(t *testing.T) {
+				host := NewHost(nil, nil)
+
+				err := host.Configure(baseURL)
+
+				assert.ErrorContains(t, err, expected)
+			})
+		}
+	})
+}
+
+func TestHost_Create(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		host := newTestHost(t, "https://example.com/nuts/")
+
+		document, key, err := host.Create(doc.DefaultCreationOptions())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, strings.HasPrefix(document.ID.String(), "did:web:example.com:nuts:iam:"))
+		assert.Len(t, document.VerificationMethod, 1)
+		assert.Equal(t, document.VerificationMethod[0].ID.String(), key.KID())
+		assert.Len(t, document.AssertionMethod, 1)
+		assert.Len(t, document.CapabilityInvocation, 1)
+
+		resolved, metadata, err := host.Resolve(document.ID, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, document.ID, resolved.ID)
+		assert.False(t, metadata.Hash.Empty())
+		assert.Nil(t, metadata.Updated)
+	})
+	t.Run("error - controllers", func(t *testing.T) {
+		host := newTestHost(t, "https://example.com")
+		options := doc.DefaultCreationOptions()
+		options.Controllers = []did.DID{did.MustParseDID("did:nuts:123")}
+
+		_, _, err := host.Create(options)
+
+		assert.ErrorContains(t, err, "controllers are not supported")
+	})
+}
+
+func TestHost_Update(t *testing.T) {
+	host := newTestHost(t, "https://example.com")
+	document, _, _ := host.Create(doc.DefaultCreationOptions())
+	_, metadata, _ := host.Resolve(document.ID, nil)
+
+	t.Run("error - outdated", func(t *testing.T) {
+		err := host.Update(document.ID, [32]byte{1}, *document)
+
+		assert.ErrorIs(t, err, types.ErrUpdateOnOutdatedData)
+	})
+	t.Run("error - not hosted by this node", func(t *testing.T) {
+		other := did.MustParseDID("did:web:example.com:iam:other")
+
+		err := host.Update(other, metadata.Hash, *document)
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+	})
+	t.Run("error - ID mismatch", func(t *testing.T) {
+		next := *document
+		next.ID = did.MustParseDID("did:web:example.com:iam:other")
+
+		err := host.Update(document.ID, metadata.Hash, next)
+
+		assert.ErrorContains(t, err, "does not match the DID being updated")
+	})
+	t.Run("ok", func(t *testing.T) {
+		next := *document
+		next.AssertionMethod = nil
+
+		err := host.Update(document.ID, metadata.Hash, next)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		resolved, newMetadata, _ := host.Resolve(document.ID, nil)
+		assert.Empty(t, resolved.AssertionMethod)
+		assert.NotNil(t, newMetadata.Updated)
+		assert.Equal(t, metadata.Hash, *newMetadata.PreviousHash)
+		assert.Equal(t, metadata.Created.Unix(), newMetadata.Created.Unix())
+	})
+	t.Run("ok - deactivate", func(t *testing.T) {
+		_, metadata, _ := host.Resolve(document.ID, nil)
+		deactivated := doc.CreateDocument()
+		deactivated.ID = document.ID
+
+		err := host.Update(document.ID, metadata.Hash, deactivated)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, _, err = host.Resolve(document.ID, nil)
+		assert.ErrorIs(t, err, types.ErrDeactivated)
+		_, newMetadata, err := host.Resolve(document.ID, &types.ResolveMetadata{AllowDeactivated: true})
+		assert.NoError(t, err)
+		assert.True(t, newMetadata.Deactivated)
+
+		t.Run("error - can't update deactivated document", func(t *testing.T) {
+			err := host.Update(document.ID, newMetadata.Hash, *document)
+
+			assert.ErrorIs(t, err, types.ErrDeactivated)
+		})
+	})
+	t.Run("concurrent updates of the same version", func(t *testing.T) {
+		document, _, _ := host.Create(doc.DefaultCreationOptions())
+		_, metadata, _ := host.Resolve(document.ID, nil)
+		const updates = 50
+		errs := make(chan error, updates)
+		start := make(chan struct{})
+		wg := sync.WaitGroup{}
+		for i := 0; i < updates; i++ {
+			next := *document
+			next.Service = []did.Service{{ID: ssi.MustParseURI(fmt.Sprintf("%s#service-%d", document.ID, i)), Type: "test"}}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				errs <- host.Update(document.ID, metadata.Hash, next)
+			}()
+		}
+		close(start)
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			if err == nil {
+				succeeded++
+			} else {
+				assert.ErrorIs(t, err, types.ErrUpdateOnOutdatedData)
+			}
+		}
+		assert.Equal(t, 1, succeeded)
+		resolved, newMetadata, _ := host.Resolve(document.ID, nil)
+		assert.Len(t, resolved.Service, 1)
+		assert.Equal(t, metadata.Hash, *newMetadata.PreviousHash)
+	})
+}
+
+func TestHost_Resolve(t *testing.T) {
+	host := newTestHost(t, "https://example.com")
+
+	t.Run("error - not found", func(t *testing.T) {
+		_, _, err := host.Resolve(did.MustParseDID("did:web:example.com:iam:unknown"), nil)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+	t.Run("error - other DID method", func(t *testing.T) {
+		_, _, err := host.Resolve(did.MustParseDID("did:nuts:123"), nil)
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+}
+
+func TestHost_Routes(t *testing.T) {
+	host := newTestHost(t, "https://example.com")
+	document, _, _ := host.Create(doc.DefaultCreationOptions())
+	router := echo.New()
+	host.Routes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	id := document.ID.String()[strings.LastIndex(document.ID.String(), ":")+1:]
+
+	t.Run("ok", func(t *testing.T) {
+		response, err := http.Get(server.URL + "/iam/" + id + "/did.json")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		served := did.Document{}
+		_ = json.NewDecoder(response.Body).Decode(&served)
+		assert.Equal(t, document.ID, served.ID)
+	})
+	t.Run("unknown DID", func(t *testing.T) {
+		response, err := http.Get(server.URL + "/iam/unknown/did.json")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func TestResolver_Resolve_Local(t *testing.T) {
+	host := newTestHost(t, "https://example.com")
+	document, _, _ := host.Create(doc.DefaultCreationOptions())
+	resolver := NewResolver()
+	resolver.HTTPClient = nil // hosted documents must be resolved without HTTP request
+	resolver.Local = host
+
+	resolved, _, err := resolver.Resolve(document.ID, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, document.ID, resolved.ID)
+}
+
+var _ core.Routable = (*Host)(nil)
+var _ types.DIDResolver = (*Host)(nil)
@@ -61,7 +61,7 @@ func DefaultCreationOptions() vdr.DIDCreationOptions {
 
 // didKIDNamingFunc is a function used to name a key used in newly generated DID Documents.
 func didKIDNamingFunc(pKey crypto.PublicKey) (string, error) {
-	return getKIDName(pKey, nutsCrypto.Thumbprint)
+	return getKIDName(pKey, NutsDIDMethodName, nutsCrypto.Thumbprint)
 }
 
 // didSubKIDNamingFunc returns a KIDNamingFunc that can be used as param in the KeyStore.New function.
@@ -70,13 +70,13 @@ func didKIDNamingFunc(pKey crypto.PublicKey) (string, error) {
 // E.g. for a assertionMethod key that differs from the key the DID document was created with.
 func didSubKIDNamingFunc(owningDID did.DID) nutsCrypto.KIDNamingFunc {
 	return func(pKey crypto.PublicKey) (string, error) {
-		return getKIDName(pKey, func(_ jwk.Key) (string, error) {
+		return getKIDName(pKey, owningDID.Method, func(_ jwk.Key) (string, error) {
 			return owningDID.ID, nil
 		})
 	}
 }
 
-func getKIDName(pKey crypto.PublicKey, idFunc func(key jwk.Key) (string, error)) (string, error) {
+func getKIDName(pKey crypto.PublicKey, method string, idFunc func(key jwk.Key) (string, error)) (string, error) {
 	// according to RFC006:
 	// --------------------
 
@@ -99,7 +99,7 @@ func getKIDName(pKey crypto.PublicKey, idFunc func(key jwk.Key) (string, error))
 
 	// assemble
 	kid := &did.DID{}
-	kid.Method = NutsDIDMethodName
+	kid.Method = method
 	kid.ID = idString
 	kid.Fragment = jwKey.KeyID()
 
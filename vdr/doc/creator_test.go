@@ -25,6 +25,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -261,6 +262,17 @@ func Test_didSubKIDNamingFunc(t *testing.T) {
 		assert.Equal(t, parsedKeyID.ID, owningDID.ID)
 		assert.NotEmpty(t, parsedKeyID.Fragment)
 	})
+	t.Run("ok - other DID method", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		owningDID, _ := did.ParseDID("did:web:example.com:iam:123")
+
+		keyID, err := didSubKIDNamingFunc(*owningDID)(privateKey.PublicKey)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, strings.HasPrefix(keyID, "did:web:example.com:iam:123#"))
+	})
 }
 
 type unknownPublicKey struct{}
@@ -0,0 +1,118 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package doc
+
+import (
+	"encoding/json"
+
+	"github.com/nuts-foundation/go-did/did"
+)
+
+// DocumentDiff describes the changes between two versions of a DID Document.
+type DocumentDiff struct {
+	// AddedVerificationMethods contains the verification methods that are only present in the newer version.
+	AddedVerificationMethods []*did.VerificationMethod
+	// RemovedVerificationMethods contains the verification methods that are only present in the older version.
+	RemovedVerificationMethods []*did.VerificationMethod
+	// AddedServices contains the services that are only present in the newer version.
+	AddedServices []did.Service
+	// RemovedServices contains the services that are only present in the older version.
+	RemovedServices []did.Service
+	// ChangedServices contains the services that are present in both versions, but with different contents.
+	// It contains the services as they are in the newer version.
+	ChangedServices []did.Service
+	// AddedControllers contains the controllers that are only present in the newer version.
+	AddedControllers []did.DID
+	// RemovedControllers contains the controllers that are only present in the older version.
+	RemovedControllers []did.DID
+}
+
+// DiffDocuments compares two versions of a DID Document and returns the added, removed and changed
+// verification methods, services and controllers. Entries are matched by their ID.
+func DiffDocuments(from did.Document, to did.Document) DocumentDiff {
+	result := DocumentDiff{}
+
+	// verification methods
+	fromMethods := map[string]*did.VerificationMethod{}
+	for _, method := range from.VerificationMethod {
+		fromMethods[method.ID.String()] = method
+	}
+	toMethods := map[string]*did.VerificationMethod{}
+	for _, method := range to.VerificationMethod {
+		toMethods[method.ID.String()] = method
+		if _, ok := fromMethods[method.ID.String()]; !ok {
+			result.AddedVerificationMethods = append(result.AddedVerificationMethods, method)
+		}
+	}
+	for _, method := range from.VerificationMethod {
+		if _, ok := toMethods[method.ID.String()]; !ok {
+			result.RemovedVerificationMethods = append(result.RemovedVerificationMethods, method)
+		}
+	}
+
+	// services
+	fromServices := map[string]did.Service{}
+	for _, service := range from.Service {
+		fromServices[service.ID.String()] = service
+	}
+	toServices := map[string]did.Service{}
+	for _, service := range to.Service {
+		toServices[service.ID.String()] = service
+		if previous, ok := fromServices[service.ID.String()]; !ok {
+			result.AddedServices = append(result.AddedServices, service)
+		} else if !servicesEqual(previous, service) {
+			result.ChangedServices = append(result.ChangedServices, service)
+		}
+	}
+	for _, service := range from.Service {
+		if _, ok := toServices[service.ID.String()]; !ok {
+			result.RemovedServices = append(result.RemovedServices, service)
+		}
+	}
+
+	// controllers
+	for _, controller := range to.Controller {
+		if !containsDID(from.Controller, controller) {
+			result.AddedControllers = append(result.AddedControllers, controller)
+		}
+	}
+	for _, controller := range from.Controller {
+		if !containsDID(to.Controller, controller) {
+			result.RemovedControllers = append(result.RemovedControllers, controller)
+		}
+	}
+
+	return result
+}
+
+// servicesEqual compares services by their JSON representation, since the service endpoint can be of any type.
+func servicesEqual(a did.Service, b did.Service) bool {
+	aBytes, _ := json.Marshal(a)
+	bBytes, _ := json.Marshal(b)
+	return string(aBytes) == string(bBytes)
+}
+
+func containsDID(list []did.DID, id did.DID) bool {
+	for _, curr := range list {
+		if curr.Equals(id) {
+			return true
+		}
+	}
+	return false
+}
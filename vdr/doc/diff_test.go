@@ -0,0 +1,79 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package doc
+
+import (
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:A")
+	controllerA, _ := did.ParseDID("did:nuts:B")
+	controllerB, _ := did.ParseDID("did:nuts:C")
+	keyA, _ := did.ParseDIDURL("did:nuts:A#key-1")
+	keyB, _ := did.ParseDIDURL("did:nuts:A#key-2")
+	vmA := &did.VerificationMethod{ID: *keyA, Type: ssi.JsonWebKey2020}
+	vmB := &did.VerificationMethod{ID: *keyB, Type: ssi.JsonWebKey2020}
+	serviceA := did.Service{ID: ssi.MustParseURI("did:nuts:A#service-1"), Type: "type A", ServiceEndpoint: "https://a.example.com"}
+	serviceAChanged := did.Service{ID: serviceA.ID, Type: "type A", ServiceEndpoint: "https://other.example.com"}
+	serviceB := did.Service{ID: ssi.MustParseURI("did:nuts:A#service-2"), Type: "type B", ServiceEndpoint: "https://b.example.com"}
+	serviceC := did.Service{ID: ssi.MustParseURI("did:nuts:A#service-3"), Type: "type C", ServiceEndpoint: "https://c.example.com"}
+
+	t.Run("no changes", func(t *testing.T) {
+		document := did.Document{
+			ID:                 *id,
+			Controller:         []did.DID{*controllerA},
+			VerificationMethod: did.VerificationMethods{vmA},
+			Service:            []did.Service{serviceA},
+		}
+
+		diff := DiffDocuments(document, document)
+
+		assert.Equal(t, DocumentDiff{}, diff)
+	})
+
+	t.Run("added, removed and changed", func(t *testing.T) {
+		from := did.Document{
+			ID:                 *id,
+			Controller:         []did.DID{*controllerA},
+			VerificationMethod: did.VerificationMethods{vmA},
+			Service:            []did.Service{serviceA, serviceB},
+		}
+		to := did.Document{
+			ID:                 *id,
+			Controller:         []did.DID{*controllerB},
+			VerificationMethod: did.VerificationMethods{vmB},
+			Service:            []did.Service{serviceAChanged, serviceC},
+		}
+
+		diff := DiffDocuments(from, to)
+
+		assert.Equal(t, []*did.VerificationMethod{vmB}, diff.AddedVerificationMethods)
+		assert.Equal(t, []*did.VerificationMethod{vmA}, diff.RemovedVerificationMethods)
+		assert.Equal(t, []did.Service{serviceC}, diff.AddedServices)
+		assert.Equal(t, []did.Service{serviceB}, diff.RemovedServices)
+		assert.Equal(t, []did.Service{serviceAChanged}, diff.ChangedServices)
+		assert.Equal(t, []did.DID{*controllerB}, diff.AddedControllers)
+		assert.Equal(t, []did.DID{*controllerA}, diff.RemovedControllers)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
 	"github.com/nuts-foundation/nuts-node/vdr/types"
 )
 
@@ -37,6 +38,11 @@ type servicePredicate struct {
 	serviceType string
 }
 
+// Index returns the service type index, so candidates can be looked up using the index.
+func (s servicePredicate) Index() (types.DocumentIndex, string) {
+	return types.ServiceTypeIndex, s.serviceType
+}
+
 func (s servicePredicate) Match(document did.Document, _ types.DocumentMetadata) bool {
 	for _, service := range document.Service {
 		if service.Type == s.serviceType {
@@ -85,12 +91,66 @@ func (d deactivatedPredicate) Match(_ did.Document, metadata types.DocumentMetad
 	return d.deactivated == metadata.Deactivated
 }
 
+// ByController returns a predicate that matches DID Documents controlled by the given DID.
+func ByController(controller did.DID) types.Predicate {
+	return indexedPredicate{index: types.ControllerIndex, value: controller.String()}
+}
+
+// ByVerificationMethod returns a predicate that matches DID Documents containing a verification method with the given ID.
+func ByVerificationMethod(id did.DID) types.Predicate {
+	return indexedPredicate{index: types.VerificationMethodIndex, value: id.String()}
+}
+
+// ByAnyServiceType returns a predicate that matches DID Documents with a service of the given type.
+// In contrast to ByServiceType, it also matches services that refer to other services or have compound endpoints.
+func ByAnyServiceType(serviceType string) types.Predicate {
+	return indexedPredicate{index: types.ServiceTypeIndex, value: serviceType}
+}
+
+// ByServiceEndpoint returns a predicate that matches DID Documents with a service that has the given endpoint (e.g. a URL).
+// Compound services match when one of their endpoints is equal to the given endpoint.
+func ByServiceEndpoint(endpoint string) types.Predicate {
+	return indexedPredicate{index: types.ServiceEndpointIndex, value: endpoint}
+}
+
+// ByServiceEndpointHost returns a predicate that matches DID Documents with a service endpoint URL on the given host.
+// The host is matched case-insensitive and without port.
+func ByServiceEndpointHost(host string) types.Predicate {
+	return indexedPredicate{index: types.ServiceEndpointHostIndex, value: strings.ToLower(host)}
+}
+
+type indexedPredicate struct {
+	index types.DocumentIndex
+	value string
+}
+
+func (i indexedPredicate) Index() (types.DocumentIndex, string) {
+	return i.index, i.value
+}
+
+func (i indexedPredicate) Match(document did.Document, _ types.DocumentMetadata) bool {
+	for _, value := range store.IndexValues(document)[i.index] {
+		if value == i.value {
+			return true
+		}
+	}
+	return false
+}
+
 // Finder is a helper that implements the DocFinder interface
 type Finder struct {
 	Store types.Store
 }
 
+// Find returns the latest versions of all DID Documents that match all predicates.
+// If one of the predicates is an IndexedPredicate, the index of the Store is used to find candidates instead of iterating all DID Documents.
 func (f Finder) Find(predicate ...types.Predicate) ([]did.Document, error) {
+	for _, p := range predicate {
+		if indexed, ok := p.(types.IndexedPredicate); ok {
+			return f.findIndexed(indexed, predicate)
+		}
+	}
+
 	matches := make([]did.Document, 0)
 
 	err := f.Store.Iterate(func(doc did.Document, metadata types.DocumentMetadata) error {
@@ -109,3 +169,26 @@ func (f Finder) Find(predicate ...types.Predicate) ([]did.Document, error) {
 
 	return matches, err
 }
+
+func (f Finder) findIndexed(indexed types.IndexedPredicate, predicates []types.Predicate) ([]did.Document, error) {
+	candidates, err := f.Store.FindByIndex(indexed.Index())
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]did.Document, 0)
+candidates:
+	for _, candidate := range candidates {
+		// AllowDeactivated makes sure the latest version is returned, like Iterate does
+		document, metadata, err := f.Store.Resolve(candidate, &types.ResolveMetadata{AllowDeactivated: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range predicates {
+			if !p.Match(*document, *metadata) {
+				continue candidates
+			}
+		}
+		matches = append(matches, *document)
+	}
+	return matches, nil
+}
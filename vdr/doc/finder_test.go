@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/vdr/store"
 	"github.com/nuts-foundation/nuts-node/vdr/types"
@@ -99,6 +100,46 @@ func TestByServiceType(t *testing.T) {
 	})
 }
 
+func TestIndexedPredicates(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	controller, _ := did.ParseDID("did:nuts:2")
+	keyID, _ := did.ParseDIDURL("did:nuts:1#key-1")
+	document := did.Document{
+		ID:                 *id,
+		Controller:         []did.DID{*controller},
+		VerificationMethod: did.VerificationMethods{{ID: *keyID}},
+		Service: []did.Service{{
+			ID:              ssi.MustParseURI("did:nuts:1#service"),
+			Type:            "eOverdracht",
+			ServiceEndpoint: map[string]interface{}{"fhir": "https://fhir.example.com/fhir", "oauth": "did:nuts:2/serviceEndpoint?type=oauth"},
+		}},
+	}
+	other, _ := did.ParseDID("did:nuts:3")
+
+	testCases := []struct {
+		name      string
+		predicate types.Predicate
+		match     bool
+	}{
+		{"controller", ByController(*controller), true},
+		{"other controller", ByController(*other), false},
+		{"verification method", ByVerificationMethod(*keyID), true},
+		{"other verification method", ByVerificationMethod(*other), false},
+		{"service type (compound endpoint)", ByAnyServiceType("eOverdracht"), true},
+		{"other service type", ByAnyServiceType("NutsComm"), false},
+		{"service endpoint", ByServiceEndpoint("https://fhir.example.com/fhir"), true},
+		{"other service endpoint", ByServiceEndpoint("https://fhir.example.com"), false},
+		{"service endpoint host (case-insensitive)", ByServiceEndpointHost("FHIR.example.com"), true},
+		{"other service endpoint host", ByServiceEndpointHost("example.com"), false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.match, testCase.predicate.Match(document, types.DocumentMetadata{}))
+			assert.Implements(t, (*types.IndexedPredicate)(nil), testCase.predicate)
+		})
+	}
+}
+
 func TestVDR_Find(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		didStore := store.NewMemoryStore()
@@ -122,6 +163,55 @@ func TestVDR_Find(t *testing.T) {
 
 		_, err := finder.Find(IsActive())
 
+		assert.Error(t, err)
+	})
+	t.Run("indexed", func(t *testing.T) {
+		didStore := store.NewMemoryStore()
+		finder := Finder{Store: didStore}
+		id1, _ := did.ParseDID("did:nuts:1")
+		id2, _ := did.ParseDID("did:nuts:2")
+		id3, _ := did.ParseDID("did:nuts:3")
+		controller, _ := did.ParseDID("did:nuts:4")
+		_ = didStore.Write(did.Document{ID: *id1, Controller: []did.DID{*controller}}, types.DocumentMetadata{})
+		_ = didStore.Write(did.Document{ID: *id2, Controller: []did.DID{*controller}}, types.DocumentMetadata{Deactivated: true})
+		_ = didStore.Write(did.Document{ID: *id3}, types.DocumentMetadata{})
+
+		docs, err := finder.Find(IsActive(), ByController(*controller))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, docs, 1) {
+			return
+		}
+		assert.Equal(t, *id1, docs[0].ID)
+	})
+
+	t.Run("indexed - uses index instead of iterating", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := types.NewMockStore(ctrl)
+		finder := Finder{Store: store}
+		id, _ := did.ParseDID("did:nuts:1")
+		document := did.Document{ID: *id, Service: []did.Service{{Type: "NutsComm", ServiceEndpoint: "grpc://example.com:5555"}}}
+		store.EXPECT().FindByIndex(types.ServiceTypeIndex, "NutsComm").Return([]did.DID{*id}, nil)
+		store.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(&document, &types.DocumentMetadata{}, nil)
+
+		docs, err := finder.Find(IsActive(), ByServiceType("NutsComm"))
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, docs, 1)
+	})
+
+	t.Run("indexed - error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := types.NewMockStore(ctrl)
+		finder := Finder{Store: store}
+		store.EXPECT().FindByIndex(types.ServiceTypeIndex, "NutsComm").Return(nil, errors.New("b00m!"))
+
+		_, err := finder.Find(ByServiceType("NutsComm"))
+
 		assert.Error(t, err)
 	})
 }
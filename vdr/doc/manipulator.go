@@ -20,6 +20,7 @@ package doc
 
 import (
 	"errors"
+	"fmt"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -35,6 +36,8 @@ type Manipulator struct {
 	Updater types.DocUpdater
 	// Resolver is used for resolving DID Documents
 	Resolver types.DocResolver
+	// KeyLifecycle is used for retiring keys that have been rotated
+	KeyLifecycle nutsCrypto.KeyLifecycle
 }
 
 // Deactivate updates the DID Document so it can no longer be updated
@@ -94,6 +97,47 @@ func (u Manipulator) RemoveVerificationMethod(id, keyID did.DID) error {
 	return u.Updater.Update(id, meta.Hash, *doc, nil)
 }
 
+// RotateVerificationMethod replaces a verificationMethod of a DID Document with a freshly generated key.
+// The new verificationMethod is added to the same verification relationships as the replaced one, after which the
+// private key of the replaced verificationMethod is retired: it can't be used for signing anymore.
+func (u Manipulator) RotateVerificationMethod(id, keyID did.DID) (*did.VerificationMethod, error) {
+	doc, meta, err := u.Resolver.Resolve(id, &types.ResolveMetadata{AllowDeactivated: true})
+	if err != nil {
+		return nil, err
+	}
+	if meta.Deactivated {
+		return nil, types.ErrDeactivated
+	}
+	if doc.VerificationMethod.FindByID(keyID) == nil {
+		return nil, types.ErrKeyNotFound
+	}
+	method, err := CreateNewVerificationMethodForDID(doc.ID, u.KeyCreator)
+	if err != nil {
+		return nil, err
+	}
+	method.Controller = doc.ID
+	doc.VerificationMethod.Remove(keyID)
+	doc.VerificationMethod.Add(method)
+	replaceVerificationRelationship(&doc.Authentication, keyID, method)
+	replaceVerificationRelationship(&doc.AssertionMethod, keyID, method)
+	replaceVerificationRelationship(&doc.KeyAgreement, keyID, method)
+	replaceVerificationRelationship(&doc.CapabilityInvocation, keyID, method)
+	replaceVerificationRelationship(&doc.CapabilityDelegation, keyID, method)
+	if err = u.Updater.Update(id, meta.Hash, *doc, nil); err != nil {
+		return nil, err
+	}
+	if err = u.KeyLifecycle.Retire(keyID.String(), method.ID.String()); err != nil {
+		return nil, fmt.Errorf("verificationMethod rotated, but unable to retire old key: %w", err)
+	}
+	return method, nil
+}
+
+func replaceVerificationRelationship(relationships *did.VerificationRelationships, oldID did.DID, method *did.VerificationMethod) {
+	if relationships.Remove(oldID) != nil {
+		relationships.Add(method)
+	}
+}
+
 // CreateNewVerificationMethodForDID creates a new VerificationMethod of type JsonWebKey2020
 // with a freshly generated key for a given DID.
 func CreateNewVerificationMethodForDID(id did.DID, keyCreator nutsCrypto.KeyCreator) (*did.VerificationMethod, error) {
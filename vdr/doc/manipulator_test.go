@@ -39,6 +39,7 @@ type manipulatorTestContext struct {
 	mockUpdater    *types.MockDocUpdater
 	mockResolver   *types.MockDocResolver
 	mockKeyCreator *mockKeyCreator
+	mockLifecycle  *crypto.MockKeyLifecycle
 	manipulator    *Manipulator
 }
 
@@ -51,12 +52,14 @@ func newManipulatorTestContext(t *testing.T) manipulatorTestContext {
 		ctrl.Finish()
 	})
 	keyCreator := newMockKeyCreator()
+	lifecycle := crypto.NewMockKeyLifecycle(ctrl)
 	return manipulatorTestContext{
 		ctrl:           ctrl,
 		mockUpdater:    updater,
 		mockResolver:   resolver,
 		mockKeyCreator: keyCreator,
-		manipulator:    &Manipulator{Updater: updater, KeyCreator: keyCreator, Resolver: resolver},
+		mockLifecycle:  lifecycle,
+		manipulator:    &Manipulator{Updater: updater, KeyCreator: keyCreator, Resolver: resolver, KeyLifecycle: lifecycle},
 	}
 }
 
@@ -180,6 +183,86 @@ func TestManipulator_AddKey(t *testing.T) {
 	})
 }
 
+func TestManipulator_RotateVerificationMethod(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:123")
+	keyID, _ := did.ParseDIDURL("did:nuts:123#key-1")
+	otherKeyID, _ := did.ParseDIDURL("did:nuts:123#key-2")
+	currentHash := hash.SHA256Sum([]byte("currentHash"))
+	createDocument := func() *did.Document {
+		document := &did.Document{ID: *id, Controller: []did.DID{*id}}
+		document.AddCapabilityInvocation(&did.VerificationMethod{ID: *keyID})
+		document.AddAssertionMethod(&did.VerificationMethod{ID: *keyID})
+		document.AddKeyAgreement(&did.VerificationMethod{ID: *keyID})
+		document.AddAuthenticationMethod(&did.VerificationMethod{ID: *otherKeyID})
+		return document
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+		var updatedDocument did.Document
+		ctx.mockResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(createDocument(), &types.DocumentMetadata{Hash: currentHash}, nil)
+		ctx.mockUpdater.EXPECT().Update(*id, currentHash, gomock.Any(), nil).Do(func(_ did.DID, _ hash.SHA256Hash, doc did.Document, _ *types.DocumentMetadata) {
+			updatedDocument = doc
+		})
+		ctx.mockLifecycle.EXPECT().Retire(keyID.String(), kid)
+
+		method, err := ctx.manipulator.RotateVerificationMethod(*id, *keyID)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, kid, method.ID.String())
+		assert.Equal(t, *id, method.Controller)
+		assert.Len(t, updatedDocument.VerificationMethod, 2)
+		assert.Nil(t, updatedDocument.VerificationMethod.FindByID(*keyID))
+		assert.Equal(t, method, updatedDocument.CapabilityInvocation.FindByID(method.ID))
+		assert.Equal(t, method, updatedDocument.AssertionMethod.FindByID(method.ID))
+		assert.Equal(t, method, updatedDocument.KeyAgreement.FindByID(method.ID))
+		assert.Nil(t, updatedDocument.Authentication.FindByID(method.ID), "should not be added to relationships the old key wasn't in")
+		assert.Nil(t, updatedDocument.CapabilityDelegation.FindByID(method.ID), "should not be added to relationships the old key wasn't in")
+	})
+	t.Run("error - verificationMethod not found", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+		unknownKeyID, _ := did.ParseDIDURL("did:nuts:123#unknown")
+		ctx.mockResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(createDocument(), &types.DocumentMetadata{Hash: currentHash}, nil)
+
+		method, err := ctx.manipulator.RotateVerificationMethod(*id, *unknownKeyID)
+
+		assert.ErrorIs(t, err, types.ErrKeyNotFound)
+		assert.Nil(t, method)
+	})
+	t.Run("error - did is deactivated", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+		ctx.mockResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(createDocument(), &types.DocumentMetadata{Hash: currentHash, Deactivated: true}, nil)
+
+		method, err := ctx.manipulator.RotateVerificationMethod(*id, *keyID)
+
+		assert.ErrorIs(t, err, types.ErrDeactivated)
+		assert.Nil(t, method)
+	})
+	t.Run("error - update failed", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+		ctx.mockResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(createDocument(), &types.DocumentMetadata{Hash: currentHash}, nil)
+		ctx.mockUpdater.EXPECT().Update(*id, currentHash, gomock.Any(), nil).Return(types.ErrNotFound)
+
+		method, err := ctx.manipulator.RotateVerificationMethod(*id, *keyID)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Nil(t, method)
+	})
+	t.Run("error - retire failed", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+		ctx.mockResolver.EXPECT().Resolve(*id, &types.ResolveMetadata{AllowDeactivated: true}).Return(createDocument(), &types.DocumentMetadata{Hash: currentHash}, nil)
+		ctx.mockUpdater.EXPECT().Update(*id, currentHash, gomock.Any(), nil)
+		ctx.mockLifecycle.EXPECT().Retire(keyID.String(), kid).Return(crypto.ErrPrivateKeyNotActive)
+
+		method, err := ctx.manipulator.RotateVerificationMethod(*id, *keyID)
+
+		assert.ErrorIs(t, err, crypto.ErrPrivateKeyNotActive)
+		assert.Nil(t, method)
+	})
+}
+
 func TestManipulator_Deactivate(t *testing.T) {
 	id, _ := did.ParseDID("did:nuts:123")
 	keyID, _ := did.ParseDIDURL("did:nuts:123#key-1")
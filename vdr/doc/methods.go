@@ -0,0 +1,84 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package doc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+// MethodResolvers is a registry of DID resolvers per DID method, used to resolve DIDs of other methods than did:nuts.
+// Documents of these methods aren't published on the Nuts network, so they have no history:
+// ResolveMetadata.ResolveTime is ignored and resolving by hash or source transaction results in types.ErrNotFound.
+type MethodResolvers struct {
+	resolvers map[string]types.DIDResolver
+	mutex     sync.RWMutex
+}
+
+// NewMethodResolvers creates an empty MethodResolvers registry.
+func NewMethodResolvers() *MethodResolvers {
+	return &MethodResolvers{resolvers: map[string]types.DIDResolver{}}
+}
+
+// Register registers the resolver for the given DID method (e.g. "web"), replacing any resolver previously registered for it.
+func (m *MethodResolvers) Register(method string, resolver types.DIDResolver) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.resolvers[method] = resolver
+}
+
+// Methods returns the DID methods for which a resolver is registered.
+func (m *MethodResolvers) Methods() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := make([]string, 0, len(m.resolvers))
+	for method := range m.resolvers {
+		result = append(result, method)
+	}
+	return result
+}
+
+// Resolve resolves the DID using the resolver registered for its method.
+// It returns types.ErrUnsupportedDIDMethod if no resolver is registered for the method.
+func (m *MethodResolvers) Resolve(id did.DID, metadata *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
+	m.mutex.RLock()
+	resolver, ok := m.resolvers[id.Method]
+	m.mutex.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	if metadata != nil && (metadata.Hash != nil || metadata.SourceTransaction != nil) {
+		return nil, nil, types.ErrNotFound
+	}
+	return resolver.Resolve(id, metadata)
+}
+
+// resolveDocument resolves did:nuts documents from the store and documents of other DID methods using the given method resolvers.
+// If no method resolvers are given, all DIDs are resolved from the store.
+func resolveDocument(store types.Store, methods *MethodResolvers, id did.DID, metadata *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
+	if methods != nil && id.Method != NutsDIDMethodName {
+		return methods.Resolve(id, metadata)
+	}
+	return store.Resolve(id, metadata)
+}
@@ -0,0 +1,104 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package doc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodResolvers_Resolve(t *testing.T) {
+	id := did.MustParseDID("did:web:example.com")
+
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		resolver := types.NewMockDIDResolver(ctrl)
+		methods := NewMethodResolvers()
+		methods.Register("web", resolver)
+		expected := &did.Document{ID: id}
+		resolver.EXPECT().Resolve(id, nil).Return(expected, &types.DocumentMetadata{}, nil)
+
+		document, _, err := methods.Resolve(id, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, document)
+		assert.Equal(t, []string{"web"}, methods.Methods())
+	})
+	t.Run("error - unsupported method", func(t *testing.T) {
+		_, _, err := NewMethodResolvers().Resolve(id, nil)
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+	t.Run("error - resolve by hash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		methods := NewMethodResolvers()
+		methods.Register("web", types.NewMockDIDResolver(ctrl))
+		h := hash.EmptyHash()
+
+		_, _, err := methods.Resolve(id, &types.ResolveMetadata{Hash: &h})
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+}
+
+func TestKeyResolver_Methods(t *testing.T) {
+	methods := NewMethodResolvers()
+	methods.Register(didjwk.MethodName, didjwk.NewResolver())
+	keyResolver := KeyResolver{Store: store.NewMemoryStore(), Methods: methods}
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	id, _ := didjwk.NewDID(privateKey.Public())
+	kid := id.String() + "#0"
+
+	t.Run("ok - resolve signing key of did:jwk", func(t *testing.T) {
+		publicKey, err := keyResolver.ResolveSigningKey(kid, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, privateKey.PublicKey.Equal(publicKey))
+	})
+	t.Run("ok - resolve by time", func(t *testing.T) {
+		now := time.Now()
+
+		_, err := keyResolver.ResolveSigningKey(kid, &now)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - did:nuts is still resolved from the store", func(t *testing.T) {
+		_, err := keyResolver.ResolveSigningKey("did:nuts:123#abc", nil)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+	t.Run("error - method not supported without method resolvers", func(t *testing.T) {
+		_, err := KeyResolver{Store: store.NewMemoryStore()}.ResolveSigningKey(kid, nil)
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+}
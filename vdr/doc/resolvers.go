@@ -41,9 +41,11 @@ const DefaultMaxServiceReferenceDepth = 5
 
 const maxControllerDepth = 5
 
-// Resolver implements the DocResolver interface with a types.Store as backend
+// Resolver implements the DocResolver interface with a types.Store as backend.
+// DIDs of other methods than did:nuts are resolved using Methods, if set.
 type Resolver struct {
-	Store types.Store
+	Store   types.Store
+	Methods *MethodResolvers
 }
 
 func (d Resolver) Resolve(id did.DID, metadata *types.ResolveMetadata) (*did.Document, *types.DocumentMetadata, error) {
@@ -56,7 +58,7 @@ func (d Resolver) resolve(id did.DID, metadata *types.ResolveMetadata, depth int
 		return nil, nil, ErrNestedDocumentsTooDeep
 	}
 
-	doc, meta, err := d.Store.Resolve(id, metadata)
+	doc, meta, err := resolveDocument(d.Store, d.Methods, id, metadata)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -136,14 +138,16 @@ func isDeactivated(document did.Document) bool {
 	return len(document.Controller) == 0 && len(document.CapabilityInvocation) == 0
 }
 
-// KeyResolver implements the KeyResolver interface with a types.Store as backend
+// KeyResolver implements the KeyResolver interface with a types.Store as backend.
+// Keys of DIDs of other methods than did:nuts are resolved using Methods, if set.
 type KeyResolver struct {
-	Store types.Store
+	Store   types.Store
+	Methods *MethodResolvers
 }
 
 // ResolveSigningKeyID resolves the ID of the first valid AssertionMethod for a indicated DID document at a given time.
 func (r KeyResolver) ResolveSigningKeyID(holder did.DID, validAt *time.Time) (string, error) {
-	doc, _, err := r.Store.Resolve(holder, &types.ResolveMetadata{
+	doc, _, err := resolveDocument(r.Store, r.Methods, holder, &types.ResolveMetadata{
 		ResolveTime: validAt,
 	})
 	if err != nil {
@@ -164,7 +168,7 @@ func (r KeyResolver) ResolveSigningKey(keyID string, validAt *time.Time) (crypto
 	}
 	holder := *kid
 	holder.Fragment = ""
-	doc, _, err := r.Store.Resolve(holder, &types.ResolveMetadata{
+	doc, _, err := resolveDocument(r.Store, r.Methods, holder, &types.ResolveMetadata{
 		ResolveTime: validAt,
 	})
 	if err != nil {
@@ -184,7 +188,7 @@ func (r KeyResolver) ResolveSigningKey(keyID string, validAt *time.Time) (crypto
 
 // ResolveAssertionKeyID resolves the id of the first valid AssertionMethod of an indicated DID document in the current state.
 func (r KeyResolver) ResolveAssertionKeyID(id did.DID) (ssi.URI, error) {
-	doc, _, err := r.Store.Resolve(id, nil)
+	doc, _, err := resolveDocument(r.Store, r.Methods, id, nil)
 	if err != nil {
 		return ssi.URI{}, err
 	}
@@ -195,7 +199,7 @@ func (r KeyResolver) ResolveAssertionKeyID(id did.DID) (ssi.URI, error) {
 // ResolveKeyAgreementKey resolves the public key of the first valid KeyAgreement of an indicated DID document in the current state.
 // If the document has no KeyAgreements, types.ErrKeyNotFound is returned.
 func (r KeyResolver) ResolveKeyAgreementKey(id did.DID) (crypto.PublicKey, error) {
-	doc, _, err := r.Store.Resolve(id, nil)
+	doc, _, err := resolveDocument(r.Store, r.Methods, id, nil)
 	if err != nil {
 		return ssi.URI{}, err
 	}
@@ -242,7 +246,7 @@ func (r KeyResolver) resolvePublicKey(kid string, metadata types.ResolveMetadata
 	}
 	didCopy := *did
 	didCopy.Fragment = ""
-	doc, _, err := r.Store.Resolve(didCopy, &metadata)
+	doc, _, err := resolveDocument(r.Store, r.Methods, didCopy, &metadata)
 	if err != nil {
 		return nil, err
 	}
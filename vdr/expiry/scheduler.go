@@ -0,0 +1,322 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package expiry schedules the automatic deactivation of DID documents and removal of verification methods managed by this node.
+package expiry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+const (
+	// storeName contains the name of the KV store in which the schedule is stored
+	storeName = "expiry"
+	// scheduleShelf has the DID or verification method ID as key and the types.Expiry as value
+	scheduleShelf = "schedule"
+	// checkInterval specifies how often the schedule is checked for expired DID documents and verification methods.
+	checkInterval = time.Minute
+)
+
+var _ types.ExpiryScheduler = (*Scheduler)(nil)
+
+// Scheduler deactivates DID documents and removes verification methods when they expire.
+// The schedule is persisted, so expiries aren't lost when the node restarts. Expiries that passed while the node
+// wasn't running are processed when it's started.
+type Scheduler struct {
+	storeProvider storage.Provider
+	db            stoabs.KVStore
+	resolver      types.DocResolver
+	manipulator   types.DocManipulator
+	keyResolver   crypto.KeyResolver
+	auditRecorder audit.Recorder
+	interval      time.Duration
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler. The resolver is used to resolve the DID documents managed by this node,
+// the manipulator to deactivate them or remove their verification methods. Processed expiries are recorded in the audit log.
+func NewScheduler(storeProvider storage.Provider, resolver types.DocResolver, manipulator types.DocManipulator, keyResolver crypto.KeyResolver, auditRecorder audit.Recorder) *Scheduler {
+	return &Scheduler{
+		storeProvider: storeProvider,
+		resolver:      resolver,
+		manipulator:   manipulator,
+		keyResolver:   keyResolver,
+		auditRecorder: auditRecorder,
+		interval:      checkInterval,
+	}
+}
+
+// Name returns the name of the engine.
+func (s *Scheduler) Name() string {
+	return "DID Document Expiry Scheduler"
+}
+
+// Configure opens the store containing the schedule.
+func (s *Scheduler) Configure(_ core.ServerConfig) error {
+	var err error
+	s.db, err = s.storeProvider.GetKVStore(storeName, storage.PersistentStorageClass)
+	return err
+}
+
+// Start checks the schedule right away, and then at every interval until Shutdown is called.
+func (s *Scheduler) Start() error {
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.check(time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops checking the schedule and closes its store.
+func (s *Scheduler) Shutdown() error {
+	if s.cancel != nil {
+		s.cancel()
+		s.wg.Wait()
+	}
+	if s.db != nil {
+		return s.db.Close(context.Background())
+	}
+	return nil
+}
+
+// Schedule schedules the expiry of a DID document or one of its verification methods.
+func (s *Scheduler) Schedule(expiry types.Expiry) error {
+	if !expiry.ExpiresAt.After(time.Now()) {
+		return core.InvalidInputError("expiry must be in the future")
+	}
+	document, _, err := s.resolver.Resolve(expiry.DID, nil)
+	if err != nil {
+		return err
+	}
+	if expiry.KeyID != nil {
+		keyDID := *expiry.KeyID
+		keyDID.Fragment = ""
+		if !keyDID.Equals(expiry.DID) {
+			return core.InvalidInputError("verification method (%s) does not belong to DID (%s)", expiry.KeyID, expiry.DID)
+		}
+		if document.VerificationMethod.FindByID(*expiry.KeyID) == nil {
+			return types.ErrKeyNotFound
+		}
+	}
+	managed, err := s.isManaged(*document)
+	if err != nil {
+		return err
+	}
+	if !managed {
+		return types.ErrDIDNotManagedByThisNode
+	}
+	data, err := json.Marshal(expiry)
+	if err != nil {
+		return err
+	}
+	err = s.db.WriteShelf(context.Background(), scheduleShelf, func(writer stoabs.Writer) error {
+		return writer.Put(expiryKey(expiry.DID, expiry.KeyID), data)
+	})
+	if err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, expiry.DID).
+		Infof("Scheduled expiry (key=%s, expiresAt=%s)", keyString(expiry.KeyID), expiry.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+// Cancel cancels the expiry of a DID document or one of its verification methods.
+func (s *Scheduler) Cancel(id did.DID, keyID *did.DID) error {
+	return s.db.WriteShelf(context.Background(), scheduleShelf, func(writer stoabs.Writer) error {
+		key := expiryKey(id, keyID)
+		data, err := writer.Get(key)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			return types.ErrExpiryNotScheduled
+		}
+		return writer.Delete(key)
+	})
+}
+
+// List returns all scheduled expiries, ordered by expiry time.
+func (s *Scheduler) List() ([]types.Expiry, error) {
+	result := make([]types.Expiry, 0)
+	err := s.db.ReadShelf(context.Background(), scheduleShelf, func(reader stoabs.Reader) error {
+		return reader.Iterate(func(_ stoabs.Key, value []byte) error {
+			var expiry types.Expiry
+			if err := json.Unmarshal(value, &expiry); err != nil {
+				return err
+			}
+			result = append(result, expiry)
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].ExpiresAt.Equal(result[j].ExpiresAt) {
+			return expiryKey(result[i].DID, result[i].KeyID).String() < expiryKey(result[j].DID, result[j].KeyID).String()
+		}
+		return result[i].ExpiresAt.Before(result[j].ExpiresAt)
+	})
+	return result, nil
+}
+
+// check processes all expiries that passed the given time.
+// Expiries that fail due to a temporary error (e.g. the network being unavailable) are retried at the next check.
+func (s *Scheduler) check(now time.Time) {
+	expiries, err := s.List()
+	if err != nil {
+		log.Logger().
+			WithError(err).
+			Error("Unable to read DID document expiry schedule")
+		return
+	}
+	for _, expiry := range expiries {
+		if expiry.ExpiresAt.After(now) {
+			// ordered by expiry time, so the remaining ones haven't expired either
+			return
+		}
+		err := s.expire(expiry)
+		logger := log.Logger().
+			WithField(core.LogFieldDID, expiry.DID).
+			WithField("key", keyString(expiry.KeyID))
+		switch {
+		case err == nil:
+			logger.Info("DID document or verification method expired")
+			s.recordAudit(expiry)
+		case errors.Is(err, types.ErrNotFound) || errors.Is(err, types.ErrDeactivated):
+			logger.WithError(err).Info("DID document or verification method expired, but it was already removed")
+		case errors.Is(err, types.ErrDIDNotManagedByThisNode) || errors.Is(err, types.ErrControllerThresholdNotMet):
+			logger.WithError(err).Error("Unable to expire DID document or verification method, removing it from the schedule")
+		default:
+			logger.WithError(err).Warn("Unable to expire DID document or verification method, will retry")
+			continue
+		}
+		if err := s.remove(expiry); err != nil {
+			logger.WithError(err).Error("Unable to remove expiry from the schedule")
+		}
+	}
+}
+
+// recordAudit records the expiry in the audit log, since it changes the DID document without an API call.
+func (s *Scheduler) recordAudit(expiry types.Expiry) {
+	entry := audit.Entry{Actor: audit.SystemActor, Module: s.Name()}
+	if expiry.KeyID == nil {
+		entry.Operation = "deactivateExpiredDID"
+		entry.Subject = expiry.DID.String()
+	} else {
+		entry.Operation = "removeExpiredVerificationMethod"
+		entry.Subject = expiry.KeyID.String()
+	}
+	if err := s.auditRecorder.Record(entry); err != nil {
+		log.Logger().
+			WithError(err).
+			WithField(core.LogFieldDID, expiry.DID).
+			Error("Unable to record expiry in audit log")
+	}
+}
+
+func (s *Scheduler) expire(expiry types.Expiry) error {
+	if expiry.KeyID == nil {
+		return s.manipulator.Deactivate(expiry.DID)
+	}
+	document, _, err := s.resolver.Resolve(expiry.DID, nil)
+	if err != nil {
+		return err
+	}
+	if document.VerificationMethod.FindByID(*expiry.KeyID) == nil {
+		return types.ErrNotFound
+	}
+	return s.manipulator.RemoveVerificationMethod(expiry.DID, *expiry.KeyID)
+}
+
+// remove removes the expiry from the schedule, unless it has been rescheduled in the meantime.
+func (s *Scheduler) remove(expiry types.Expiry) error {
+	return s.db.WriteShelf(context.Background(), scheduleShelf, func(writer stoabs.Writer) error {
+		key := expiryKey(expiry.DID, expiry.KeyID)
+		data, err := writer.Get(key)
+		if err != nil || data == nil {
+			return err
+		}
+		var current types.Expiry
+		if err = json.Unmarshal(data, &current); err != nil {
+			return err
+		}
+		if !current.ExpiresAt.Equal(expiry.ExpiresAt) {
+			return nil
+		}
+		return writer.Delete(key)
+	})
+}
+
+// isManaged checks whether the private key of one of the verification methods of the DID document's controllers is present.
+func (s *Scheduler) isManaged(document did.Document) (bool, error) {
+	controllers, err := s.resolver.ResolveControllers(document, nil)
+	if err != nil {
+		return false, err
+	}
+	for _, controller := range controllers {
+		for _, method := range controller.VerificationMethod {
+			if s.keyResolver.Exists(method.ID.String()) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func expiryKey(id did.DID, keyID *did.DID) stoabs.Key {
+	if keyID != nil {
+		return stoabs.BytesKey(keyID.String())
+	}
+	return stoabs.BytesKey(id.String())
+}
+
+func keyString(keyID *did.DID) string {
+	if keyID == nil {
+		return ""
+	}
+	return keyID.String()
+}
@@ -0,0 +1,330 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package expiry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testContext struct {
+	scheduler   *Scheduler
+	manipulator *types.MockDocManipulator
+	audit       *audit.MockRecorder
+	document    did.Document
+	// other is a DID document that's not managed by this node
+	other did.Document
+}
+
+func newTestContext(t *testing.T) testContext {
+	ctrl := gomock.NewController(t)
+	keyStore := crypto.NewTestCryptoInstance()
+	document, _, _ := doc.Creator{KeyStore: keyStore}.Create(doc.DefaultCreationOptions())
+	other, _, _ := doc.Creator{KeyStore: crypto.NewTestCryptoInstance()}.Create(doc.DefaultCreationOptions())
+	didStore := store.NewMemoryStore()
+	_ = didStore.Write(*document, types.DocumentMetadata{Hash: hash.SHA256Sum([]byte("document"))})
+	_ = didStore.Write(*other, types.DocumentMetadata{Hash: hash.SHA256Sum([]byte("other"))})
+	storageEngine := storage.NewTestStorageEngine(io.TestDirectory(t))
+	manipulator := types.NewMockDocManipulator(ctrl)
+	auditRecorder := audit.NewMockRecorder(ctrl)
+	scheduler := NewScheduler(storageEngine.GetProvider("VDR"), doc.Resolver{Store: didStore}, manipulator, keyStore, auditRecorder)
+	if err := scheduler.Configure(core.ServerConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = scheduler.Shutdown()
+		_ = storageEngine.Shutdown()
+	})
+	return testContext{
+		scheduler:   scheduler,
+		manipulator: manipulator,
+		audit:       auditRecorder,
+		document:    *document,
+		other:       *other,
+	}
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("ok - DID document", func(t *testing.T) {
+		ctx := newTestContext(t)
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: expiresAt})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		expiries, _ := ctx.scheduler.List()
+		if !assert.Len(t, expiries, 1) {
+			return
+		}
+		assert.Equal(t, ctx.document.ID, expiries[0].DID)
+		assert.Nil(t, expiries[0].KeyID)
+		assert.True(t, expiresAt.Equal(expiries[0].ExpiresAt))
+	})
+	t.Run("ok - verification method", func(t *testing.T) {
+		ctx := newTestContext(t)
+		keyID := ctx.document.VerificationMethod[0].ID
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, KeyID: &keyID, ExpiresAt: expiresAt})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		expiries, _ := ctx.scheduler.List()
+		if !assert.Len(t, expiries, 1) {
+			return
+		}
+		assert.Equal(t, keyID, *expiries[0].KeyID)
+	})
+	t.Run("ok - replaces existing expiry", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: expiresAt})
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: expiresAt.Add(time.Hour)})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		expiries, _ := ctx.scheduler.List()
+		if !assert.Len(t, expiries, 1) {
+			return
+		}
+		assert.True(t, expiresAt.Add(time.Hour).Equal(expiries[0].ExpiresAt))
+	})
+	t.Run("error - in the past", func(t *testing.T) {
+		ctx := newTestContext(t)
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: time.Now().Add(-time.Minute)})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+		assert.EqualError(t, err, "expiry must be in the future")
+	})
+	t.Run("error - unknown verification method", func(t *testing.T) {
+		ctx := newTestContext(t)
+		keyID := ctx.document.ID
+		keyID.Fragment = "unknown"
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, KeyID: &keyID, ExpiresAt: expiresAt})
+
+		assert.ErrorIs(t, err, types.ErrKeyNotFound)
+	})
+	t.Run("error - verification method of other DID", func(t *testing.T) {
+		ctx := newTestContext(t)
+		keyID := ctx.other.VerificationMethod[0].ID
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, KeyID: &keyID, ExpiresAt: expiresAt})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("error - not managed by this node", func(t *testing.T) {
+		ctx := newTestContext(t)
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: ctx.other.ID, ExpiresAt: expiresAt})
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		ctx := newTestContext(t)
+
+		err := ctx.scheduler.Schedule(types.Expiry{DID: did.MustParseDID("did:nuts:unknown"), ExpiresAt: expiresAt})
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: time.Now().Add(time.Hour)})
+
+		err := ctx.scheduler.Cancel(ctx.document.ID, nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		expiries, _ := ctx.scheduler.List()
+		assert.Empty(t, expiries)
+	})
+	t.Run("error - not scheduled", func(t *testing.T) {
+		ctx := newTestContext(t)
+		keyID := ctx.document.VerificationMethod[0].ID
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: time.Now().Add(time.Hour)})
+
+		err := ctx.scheduler.Cancel(ctx.document.ID, &keyID)
+
+		assert.ErrorIs(t, err, types.ErrExpiryNotScheduled)
+	})
+}
+
+func TestScheduler_List(t *testing.T) {
+	ctx := newTestContext(t)
+	keyID := ctx.document.VerificationMethod[0].ID
+	now := time.Now()
+	_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(2 * time.Hour)})
+	_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, KeyID: &keyID, ExpiresAt: now.Add(time.Hour)})
+
+	expiries, err := ctx.scheduler.List()
+
+	if !assert.NoError(t, err) || !assert.Len(t, expiries, 2) {
+		return
+	}
+	// ordered by expiry time
+	assert.NotNil(t, expiries[0].KeyID)
+	assert.Nil(t, expiries[1].KeyID)
+}
+
+func TestScheduler_check(t *testing.T) {
+	now := time.Now()
+
+	t.Run("deactivates expired DID document", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(time.Minute)})
+		ctx.manipulator.EXPECT().Deactivate(ctx.document.ID).Return(nil)
+		ctx.audit.EXPECT().Record(audit.Entry{
+			Actor:     audit.SystemActor,
+			Module:    "DID Document Expiry Scheduler",
+			Operation: "deactivateExpiredDID",
+			Subject:   ctx.document.ID.String(),
+		}).Return(nil)
+
+		ctx.scheduler.check(now.Add(2 * time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Empty(t, expiries)
+	})
+	t.Run("removes expired verification method", func(t *testing.T) {
+		ctx := newTestContext(t)
+		keyID := ctx.document.VerificationMethod[0].ID
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, KeyID: &keyID, ExpiresAt: now.Add(time.Minute)})
+		ctx.manipulator.EXPECT().RemoveVerificationMethod(ctx.document.ID, keyID).Return(nil)
+		ctx.audit.EXPECT().Record(audit.Entry{
+			Actor:     audit.SystemActor,
+			Module:    "DID Document Expiry Scheduler",
+			Operation: "removeExpiredVerificationMethod",
+			Subject:   keyID.String(),
+		}).Return(nil)
+
+		ctx.scheduler.check(now.Add(2 * time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Empty(t, expiries)
+	})
+	t.Run("removes expiry when recording it in the audit log fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(time.Minute)})
+		ctx.manipulator.EXPECT().Deactivate(ctx.document.ID).Return(nil)
+		ctx.audit.EXPECT().Record(gomock.Any()).Return(errors.New("failed"))
+
+		ctx.scheduler.check(now.Add(2 * time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Empty(t, expiries)
+	})
+	t.Run("skips expiries that haven't passed", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(time.Hour)})
+
+		ctx.scheduler.check(now.Add(time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Len(t, expiries, 1)
+	})
+	t.Run("retries on temporary error", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(time.Minute)})
+		ctx.manipulator.EXPECT().Deactivate(ctx.document.ID).Return(errors.New("network unavailable"))
+
+		ctx.scheduler.check(now.Add(2 * time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Len(t, expiries, 1)
+	})
+	t.Run("removes expiry of DID document that's already deactivated", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(time.Minute)})
+		ctx.manipulator.EXPECT().Deactivate(ctx.document.ID).Return(types.ErrDeactivated)
+
+		ctx.scheduler.check(now.Add(2 * time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Empty(t, expiries)
+	})
+	t.Run("removes expiry that can't be processed automatically", func(t *testing.T) {
+		ctx := newTestContext(t)
+		_ = ctx.scheduler.Schedule(types.Expiry{DID: ctx.document.ID, ExpiresAt: now.Add(time.Minute)})
+		ctx.manipulator.EXPECT().Deactivate(ctx.document.ID).Return(types.ErrControllerThresholdNotMet)
+
+		ctx.scheduler.check(now.Add(2 * time.Minute))
+
+		expiries, _ := ctx.scheduler.List()
+		assert.Empty(t, expiries)
+	})
+}
+
+func TestScheduler_survivesRestart(t *testing.T) {
+	testDirectory := io.TestDirectory(t)
+	keyStore := crypto.NewTestCryptoInstance()
+	document, _, _ := doc.Creator{KeyStore: keyStore}.Create(doc.DefaultCreationOptions())
+	didStore := store.NewMemoryStore()
+	_ = didStore.Write(*document, types.DocumentMetadata{})
+	newScheduler := func() (*Scheduler, storage.Engine) {
+		storageEngine := storage.NewTestStorageEngine(testDirectory)
+		scheduler := NewScheduler(storageEngine.GetProvider("VDR"), doc.Resolver{Store: didStore}, nil, keyStore, nil)
+		if err := scheduler.Configure(core.ServerConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		return scheduler, storageEngine
+	}
+	scheduler, storageEngine := newScheduler()
+	_ = scheduler.Schedule(types.Expiry{DID: document.ID, ExpiresAt: time.Now().Add(time.Hour)})
+	_ = scheduler.Shutdown()
+	_ = storageEngine.Shutdown()
+
+	scheduler, storageEngine = newScheduler()
+	defer storageEngine.Shutdown()
+	defer scheduler.Shutdown()
+	expiries, err := scheduler.List()
+
+	assert.NoError(t, err)
+	assert.Len(t, expiries, 1)
+}
+
+func TestScheduler_Start(t *testing.T) {
+	ctx := newTestContext(t)
+
+	err := ctx.scheduler.Start()
+
+	assert.NoError(t, err)
+}
@@ -318,7 +318,7 @@ func setup(t *testing.T) testContext {
 	})
 
 	// Init the VDR
-	vdr := NewVDR(DefaultConfig(), cryptoInstance, nutsNetwork, didStore, eventPublisher)
+	vdr := NewVDR(DefaultConfig(), cryptoInstance, nutsNetwork, didStore, eventPublisher, nil, nil)
 	vdr.Configure(nutsConfig)
 	err = vdr.Start()
 	if err != nil {
@@ -0,0 +1,260 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vdr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+// didDocumentProposalType contains network transaction mime-type to identify a DID Document update proposal
+// (co-signed by multiple controllers) in the network.
+const didDocumentProposalType = "application/did-proposal+json"
+
+// controllerThreshold returns the number of controllers that must sign an update of the given DID document.
+// It's specified by the NutsControllerThreshold service and defaults to 1.
+func controllerThreshold(document did.Document) (int, error) {
+	for _, service := range document.Service {
+		if service.Type != types.ControllerThresholdServiceType {
+			continue
+		}
+		var endpoint struct {
+			Threshold int `json:"threshold"`
+		}
+		if err := service.UnmarshalServiceEndpoint(&endpoint); err != nil {
+			return 0, fmt.Errorf("invalid controller threshold: %w", err)
+		}
+		if endpoint.Threshold < 1 {
+			return 0, errors.New("invalid controller threshold: must be greater than 0")
+		}
+		return endpoint.Threshold, nil
+	}
+	return 1, nil
+}
+
+// signProposal adds signatures to the proposal for every controller that has a capabilityInvocation key in the key store,
+// and hasn't signed the proposal yet. It returns the number of signatures added.
+func signProposal(proposal *types.UpdateProposal, controllers []did.Document, keyResolver crypto.KeyResolver) (int, error) {
+	signed, err := proposalSigners(*proposal, controllers)
+	if err != nil {
+		return 0, err
+	}
+	signingInput, err := proposal.SigningInput()
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, controller := range controllers {
+		if signed[controller.ID.String()] {
+			continue
+		}
+		for _, cik := range controller.CapabilityInvocation {
+			key, err := keyResolver.Resolve(cik.ID.String())
+			if errors.Is(err, crypto.ErrPrivateKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			signature, err := crypto.SignDetachedJWS(signingInput, map[string]interface{}{jws.KeyIDKey: key.KID()}, key.Signer())
+			if err != nil {
+				return 0, fmt.Errorf("unable to sign proposal (kid=%s): %w", key.KID(), err)
+			}
+			proposal.Signatures = append(proposal.Signatures, signature)
+			signed[controller.ID.String()] = true
+			added++
+			break
+		}
+	}
+	return added, nil
+}
+
+// proposalSigners verifies the signatures of the proposal against the capabilityInvocation keys of the given controllers.
+// It returns the DIDs of the controllers that signed the proposal. An error is returned if a signature is invalid,
+// or signed with a key that isn't a capabilityInvocation key of one of the controllers.
+func proposalSigners(proposal types.UpdateProposal, controllers []did.Document) (map[string]bool, error) {
+	signingInput, err := proposal.SigningInput()
+	if err != nil {
+		return nil, err
+	}
+	signers := make(map[string]bool, 0)
+	for _, signature := range proposal.Signatures {
+		kid, alg, err := crypto.JWTKidAlg(signature)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid signature: %s", types.ErrInvalidProposal, err)
+		}
+		controller, method := findCapabilityInvocationKey(kid, controllers)
+		if method == nil {
+			return nil, fmt.Errorf("%w: signed with key which isn't a capabilityInvocation key of one of the controllers (kid=%s)", types.ErrInvalidProposal, kid)
+		}
+		publicKey, err := method.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid signing key (kid=%s): %s", types.ErrInvalidProposal, kid, err)
+		}
+		if _, err = jws.Verify([]byte(signature), alg, publicKey, jws.WithDetachedPayload(signingInput)); err != nil {
+			return nil, fmt.Errorf("%w: invalid signature (kid=%s): %s", types.ErrInvalidProposal, kid, err)
+		}
+		signers[controller.String()] = true
+	}
+	return signers, nil
+}
+
+// verifyProposal checks that the proposal is signed by at least the required number of controllers.
+func verifyProposal(proposal types.UpdateProposal, controllers []did.Document, threshold int) error {
+	signers, err := proposalSigners(proposal, controllers)
+	if err != nil {
+		return err
+	}
+	if len(signers) < threshold {
+		return fmt.Errorf("%w (signed=%d, threshold=%d)", types.ErrControllerThresholdNotMet, len(signers), threshold)
+	}
+	return nil
+}
+
+func findCapabilityInvocationKey(kid string, controllers []did.Document) (did.DID, *did.VerificationMethod) {
+	for _, controller := range controllers {
+		for _, cik := range controller.CapabilityInvocation {
+			if cik.ID.String() == kid {
+				return controller.ID, cik.VerificationMethod
+			}
+		}
+	}
+	return did.DID{}, nil
+}
+
+// ProposeUpdate prepares an update of a DID document that must be signed by multiple controllers, signed by the controllers managed by this node.
+func (r VDR) ProposeUpdate(id did.DID, current hash.SHA256Hash, next did.Document) (*types.UpdateProposal, error) {
+	if id.Method != doc.NutsDIDMethodName {
+		return nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, id.Method)
+	}
+	if !next.ID.Equals(id) {
+		return nil, core.InvalidInputError("DID document ID (%s) does not match the DID being updated (%s)", next.ID, id)
+	}
+	currentDocument, _, err := r.resolveProposalBase(id, current)
+	if err != nil {
+		return nil, err
+	}
+	if err = CreateDocumentValidator().Validate(next); err != nil {
+		return nil, err
+	}
+	proposal := types.UpdateProposal{Current: current, Document: next}
+	controllers, err := r.didDocResolver.ResolveControllers(*currentDocument, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while finding controllers for document: %w", err)
+	}
+	added, err := signProposal(&proposal, controllers, r.keyStore)
+	if err != nil {
+		return nil, err
+	}
+	if added == 0 {
+		return nil, types.ErrDIDNotManagedByThisNode
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, id).
+		Infof("DID Document update proposed (signatures=%d)", added)
+	return &proposal, nil
+}
+
+// SignProposal adds the signatures of the controllers managed by this node to the proposal.
+func (r VDR) SignProposal(proposal types.UpdateProposal) (*types.UpdateProposal, error) {
+	id := proposal.Document.ID
+	currentDocument, _, err := r.resolveProposalBase(id, proposal.Current)
+	if err != nil {
+		return nil, err
+	}
+	controllers, err := r.didDocResolver.ResolveControllers(*currentDocument, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while finding controllers for document: %w", err)
+	}
+	added, err := signProposal(&proposal, controllers, r.keyStore)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, id).
+		Infof("DID Document update proposal signed (signatures=%d)", added)
+	return &proposal, nil
+}
+
+// PublishProposal publishes the proposed update on the network, if it's signed by enough controllers.
+func (r VDR) PublishProposal(proposal types.UpdateProposal) error {
+	id := proposal.Document.ID
+	currentDocument, currentMeta, err := r.resolveProposalBase(id, proposal.Current)
+	if err != nil {
+		return err
+	}
+	if err = CreateDocumentValidator().Validate(proposal.Document); err != nil {
+		return err
+	}
+	threshold, err := controllerThreshold(*currentDocument)
+	if err != nil {
+		return err
+	}
+	controllers, err := r.didDocResolver.ResolveControllers(*currentDocument, nil)
+	if err != nil {
+		return fmt.Errorf("error while finding controllers for document: %w", err)
+	}
+	if err = verifyProposal(proposal, controllers, threshold); err != nil {
+		return err
+	}
+	controller, key, err := r.resolveControllerWithKey(*currentDocument)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+	return r.publishUpdate(id, *currentMeta, controller, key, didDocumentProposalType, payload)
+}
+
+// resolveProposalBase resolves the version of the DID document the proposal applies to, which may not be deactivated.
+func (r VDR) resolveProposalBase(id did.DID, current hash.SHA256Hash) (*did.Document, *types.DocumentMetadata, error) {
+	currentDocument, currentMeta, err := r.store.Resolve(id, &types.ResolveMetadata{Hash: &current, AllowDeactivated: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	if store.IsDeactivated(*currentDocument) {
+		return nil, nil, types.ErrDeactivated
+	}
+	return currentDocument, currentMeta, nil
+}
+
+// requireSingleControllerUpdate returns ErrControllerThresholdNotMet if updates of the DID document must be signed by multiple controllers.
+func requireSingleControllerUpdate(document did.Document) error {
+	threshold, err := controllerThreshold(document)
+	if err != nil {
+		return err
+	}
+	if threshold > 1 {
+		return fmt.Errorf("%w: update must be proposed and signed by %d controllers", types.ErrControllerThresholdNotMet, threshold)
+	}
+	return nil
+}
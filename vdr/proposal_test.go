@@ -0,0 +1,245 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vdr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/network"
+	"github.com/nuts-foundation/nuts-node/network/dag"
+	"github.com/nuts-foundation/nuts-node/vdr/doc"
+	"github.com/nuts-foundation/nuts-node/vdr/store"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// proposalTestCtx contains a DID document that's jointly controlled by 2 nodes (care organisation and vendor),
+// which both must sign updates.
+type proposalTestCtx struct {
+	document    did.Document
+	currentHash hash.SHA256Hash
+	vendor      did.Document
+	vdrA        *VDR
+	vdrB        *VDR
+	networkMock *network.MockTransactions
+}
+
+func newProposalTestCtx(t *testing.T, threshold int) proposalTestCtx {
+	keyStoreA := crypto.NewTestCryptoInstance()
+	keyStoreB := crypto.NewTestCryptoInstance()
+	document, _, _ := doc.Creator{KeyStore: keyStoreA}.Create(doc.DefaultCreationOptions())
+	vendor, _, _ := doc.Creator{KeyStore: keyStoreB}.Create(doc.DefaultCreationOptions())
+	document.Controller = []did.DID{document.ID, vendor.ID}
+	document.Service = []did.Service{thresholdService(document.ID, threshold)}
+	currentHash := hash.SHA256Sum([]byte("current"))
+
+	ctrl := gomock.NewController(t)
+	networkMock := network.NewMockTransactions(ctrl)
+	didStore := store.NewMemoryStore()
+	_ = didStore.Write(*document, types.DocumentMetadata{Hash: currentHash, SourceTransactions: []hash.SHA256Hash{currentHash}})
+	vendorHash := hash.SHA256Sum([]byte("vendor"))
+	_ = didStore.Write(*vendor, types.DocumentMetadata{Hash: vendorHash, SourceTransactions: []hash.SHA256Hash{vendorHash}})
+	return proposalTestCtx{
+		document:    *document,
+		currentHash: currentHash,
+		vendor:      *vendor,
+		vdrA:        NewVDR(DefaultConfig(), keyStoreA, networkMock, didStore, nil, nil, nil),
+		vdrB:        NewVDR(DefaultConfig(), keyStoreB, networkMock, didStore, nil, nil, nil),
+		networkMock: networkMock,
+	}
+}
+
+func thresholdService(id did.DID, threshold int) did.Service {
+	serviceID := id
+	serviceID.Fragment = "threshold"
+	return did.Service{
+		ID:              serviceID.URI(),
+		Type:            types.ControllerThresholdServiceType,
+		ServiceEndpoint: map[string]interface{}{"threshold": threshold},
+	}
+}
+
+func (ctx proposalTestCtx) nextDocument() did.Document {
+	next := ctx.document
+	serviceID := next.ID
+	serviceID.Fragment = "service"
+	next.Service = append(next.Service, did.Service{ID: serviceID.URI(), Type: "test", ServiceEndpoint: "https://nuts.nl"})
+	return next
+}
+
+func TestVDR_ProposeUpdate(t *testing.T) {
+	t.Run("ok - signed by controller managed by this node", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+
+		proposal, err := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, ctx.currentHash, proposal.Current)
+		assert.Len(t, proposal.Signatures, 1)
+		assert.Len(t, proposal.Document.Service, 2)
+	})
+	t.Run("error - not managed by this node", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		ctx.vdrA.keyStore = crypto.NewTestCryptoInstance()
+
+		_, err := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+
+		assert.ErrorIs(t, err, types.ErrDIDNotManagedByThisNode)
+	})
+	t.Run("error - invalid document", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		next := ctx.nextDocument()
+		next.Controller = next.Controller[:1]
+
+		_, err := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, next)
+
+		assert.EqualError(t, err, "invalid controller threshold: exceeds number of controllers (threshold=2, controllers=1)")
+	})
+	t.Run("error - outdated version", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+
+		_, err := ctx.vdrA.ProposeUpdate(ctx.document.ID, hash.SHA256Sum([]byte("other")), ctx.nextDocument())
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+	t.Run("error - DID document ID differs", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+
+		_, err := ctx.vdrA.ProposeUpdate(ctx.vendor.ID, ctx.currentHash, ctx.nextDocument())
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+	})
+	t.Run("error - unsupported DID method", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+
+		_, err := ctx.vdrA.ProposeUpdate(did.MustParseDID("did:web:example.com"), ctx.currentHash, ctx.nextDocument())
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+}
+
+func TestVDR_SignProposal(t *testing.T) {
+	t.Run("ok - signed by other controller", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+
+		signed, err := ctx.vdrB.SignProposal(*proposal)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, signed.Signatures, 2)
+	})
+	t.Run("ok - already signed", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+
+		signed, err := ctx.vdrA.SignProposal(*proposal)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, signed.Signatures, 1)
+	})
+	t.Run("error - tampered document", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+		proposal.Document = ctx.document
+
+		_, err := ctx.vdrB.SignProposal(*proposal)
+
+		assert.ErrorIs(t, err, types.ErrInvalidProposal)
+	})
+}
+
+func TestVDR_PublishProposal(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+		proposal, _ = ctx.vdrB.SignProposal(*proposal)
+		var template network.Template
+		ctx.networkMock.EXPECT().CreateTransaction(gomock.Any()).DoAndReturn(func(tx network.Template) (dag.Transaction, error) {
+			template = tx
+			return nil, nil
+		})
+
+		err := ctx.vdrB.PublishProposal(*proposal)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, didDocumentProposalType, template.Type)
+		assert.Contains(t, template.AdditionalPrevs, ctx.currentHash)
+		var published types.UpdateProposal
+		_ = json.Unmarshal(template.Payload, &published)
+		assert.Len(t, published.Signatures, 2)
+	})
+	t.Run("error - threshold not met", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+
+		err := ctx.vdrA.PublishProposal(*proposal)
+
+		assert.ErrorIs(t, err, types.ErrControllerThresholdNotMet)
+	})
+	t.Run("error - signed by key of other DID", func(t *testing.T) {
+		ctx := newProposalTestCtx(t, 2)
+		proposal, _ := ctx.vdrA.ProposeUpdate(ctx.document.ID, ctx.currentHash, ctx.nextDocument())
+		other := newProposalTestCtx(t, 2)
+		otherProposal, _ := other.vdrA.ProposeUpdate(other.document.ID, other.currentHash, other.nextDocument())
+		proposal.Signatures = append(proposal.Signatures, otherProposal.Signatures...)
+
+		err := ctx.vdrA.PublishProposal(*proposal)
+
+		assert.ErrorIs(t, err, types.ErrInvalidProposal)
+		assert.ErrorContains(t, err, "signed with key which isn't a capabilityInvocation key of one of the controllers")
+	})
+}
+
+func TestVDR_Update_ControllerThreshold(t *testing.T) {
+	ctx := newProposalTestCtx(t, 2)
+
+	err := ctx.vdrA.Update(ctx.document.ID, ctx.currentHash, ctx.nextDocument(), nil)
+
+	assert.ErrorIs(t, err, types.ErrControllerThresholdNotMet)
+}
+
+func Test_controllerThreshold(t *testing.T) {
+	id := did.MustParseDID("did:nuts:123")
+	t.Run("default", func(t *testing.T) {
+		threshold, err := controllerThreshold(did.Document{ID: id})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, threshold)
+	})
+	t.Run("from service", func(t *testing.T) {
+		threshold, err := controllerThreshold(did.Document{ID: id, Service: []did.Service{thresholdService(id, 3)}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, threshold)
+	})
+}
@@ -0,0 +1,153 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-stoabs"
+	vdr "github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+const (
+	// indexShelf has the index, value and DID (separated by indexSeparator) as key and the DID as value.
+	// It only contains entries for the latest version of each DID Document.
+	indexShelf = "index"
+	// indexMetadataShelf holds the version of the index, used to (re)build the index when needed.
+	indexMetadataShelf = "indexMetadata"
+	indexVersionKey    = "version"
+	// indexVersion must be incremented when the indexed values change, so the index is rebuilt on startup.
+	indexVersion   = "1"
+	indexSeparator = "\x00"
+)
+
+// IndexValues returns the values of the DID Document for each index.
+func IndexValues(document did.Document) map[vdr.DocumentIndex][]string {
+	result := map[vdr.DocumentIndex][]string{}
+	for _, controller := range document.Controller {
+		result[vdr.ControllerIndex] = append(result[vdr.ControllerIndex], controller.String())
+	}
+	for _, verificationMethod := range document.VerificationMethod {
+		result[vdr.VerificationMethodIndex] = append(result[vdr.VerificationMethodIndex], verificationMethod.ID.String())
+	}
+	for _, service := range document.Service {
+		result[vdr.ServiceTypeIndex] = append(result[vdr.ServiceTypeIndex], service.Type)
+		for _, endpoint := range serviceEndpoints(service) {
+			result[vdr.ServiceEndpointIndex] = append(result[vdr.ServiceEndpointIndex], endpoint)
+			if parsed, err := url.Parse(endpoint); err == nil && parsed.Host != "" {
+				result[vdr.ServiceEndpointHostIndex] = append(result[vdr.ServiceEndpointHostIndex], strings.ToLower(parsed.Hostname()))
+			}
+		}
+	}
+	for index, values := range result {
+		result[index] = unique(values)
+	}
+	return result
+}
+
+// serviceEndpoints returns all string values of the service endpoint, which can be a string, list or map.
+func serviceEndpoints(service did.Service) []string {
+	// marshal to JSON and back to get a generic representation
+	data, err := json.Marshal(service.ServiceEndpoint)
+	if err != nil {
+		return nil
+	}
+	var endpoint interface{}
+	if err := json.Unmarshal(data, &endpoint); err != nil {
+		return nil
+	}
+	var result []string
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			result = append(result, v)
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		case map[string]interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		}
+	}
+	collect(endpoint)
+	return result
+}
+
+func unique(values []string) []string {
+	set := map[string]bool{}
+	var result []string
+	for _, value := range values {
+		if !set[value] {
+			set[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func indexKey(index vdr.DocumentIndex, value string, id string) stoabs.BytesKey {
+	return stoabs.BytesKey(string(index) + indexSeparator + value + indexSeparator + id)
+}
+
+// updateIndex removes the index entries of the previous version of a DID Document (if any) and adds the entries of the new version.
+func updateIndex(writer stoabs.Writer, previous *did.Document, next did.Document) error {
+	id := next.ID.String()
+	if previous != nil {
+		for index, values := range IndexValues(*previous) {
+			for _, value := range values {
+				if err := writer.Delete(indexKey(index, value, id)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for index, values := range IndexValues(next) {
+		for _, value := range values {
+			if err := writer.Put(indexKey(index, value, id), []byte(id)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findByIndex returns the DIDs that have the given value for the given index, ordered by DID.
+func findByIndex(reader stoabs.Reader, index vdr.DocumentIndex, value string) ([]did.DID, error) {
+	prefix := string(index) + indexSeparator + value + indexSeparator
+	// the separator is the lowest possible byte, so incrementing it gives the first key after the prefix
+	end := string(index) + indexSeparator + value + "\x01"
+	var result []did.DID
+	err := reader.Range(stoabs.BytesKey(prefix), stoabs.BytesKey(end), func(_ stoabs.Key, value []byte) error {
+		id, err := did.ParseDID(string(value))
+		if err != nil {
+			return err
+		}
+		result = append(result, *id)
+		return nil
+	}, false)
+	return result, err
+}
@@ -0,0 +1,73 @@
+/*
+ * Nuts node
+ * Copyright (C) 2022 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+)
+
+func TestIndexValues(t *testing.T) {
+	id, _ := did.ParseDID("did:nuts:1")
+	controller, _ := did.ParseDID("did:nuts:2")
+	keyID, _ := did.ParseDIDURL("did:nuts:1#key-1")
+	document := did.Document{
+		ID:                 *id,
+		Controller:         []did.DID{*controller},
+		VerificationMethod: did.VerificationMethods{{ID: *keyID}},
+		Service: []did.Service{
+			{
+				ID:              ssi.MustParseURI("did:nuts:1#service-1"),
+				Type:            "NutsComm",
+				ServiceEndpoint: "grpc://Nuts.Example.com:5555",
+			},
+			{
+				ID:   ssi.MustParseURI("did:nuts:1#service-2"),
+				Type: "eOverdracht",
+				ServiceEndpoint: map[string]interface{}{
+					"fhir":  "https://fhir.example.com/fhir",
+					"oauth": "did:nuts:2/serviceEndpoint?type=oauth",
+				},
+			},
+			{
+				ID:              ssi.MustParseURI("did:nuts:1#service-3"),
+				Type:            "list",
+				ServiceEndpoint: []string{"https://fhir.example.com/other"},
+			},
+		},
+	}
+
+	values := IndexValues(document)
+
+	assert.Equal(t, []string{"did:nuts:2"}, values[types.ControllerIndex])
+	assert.Equal(t, []string{"did:nuts:1#key-1"}, values[types.VerificationMethodIndex])
+	assert.Equal(t, []string{"NutsComm", "eOverdracht", "list"}, values[types.ServiceTypeIndex])
+	assert.Equal(t, []string{
+		"did:nuts:2/serviceEndpoint?type=oauth",
+		"grpc://Nuts.Example.com:5555",
+		"https://fhir.example.com/fhir",
+		"https://fhir.example.com/other",
+	}, values[types.ServiceEndpointIndex])
+	assert.Equal(t, []string{"fhir.example.com", "nuts.example.com"}, values[types.ServiceEndpointHostIndex])
+}
@@ -17,6 +17,7 @@ package store
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
 
 	"github.com/nuts-foundation/go-did/did"
@@ -129,6 +130,54 @@ func (m *memory) Resolve(id did.DID, metadata *vdr.ResolveMetadata) (*did.Docume
 	return &copyEntry.document, &copyEntry.metadata, nil
 }
 
+// History returns deep copies of all versions of the DID Document for the provided DID, oldest version first.
+func (m *memory) History(id did.DID) ([]did.Document, []vdr.DocumentMetadata, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	entries, ok := m.store[id.String()]
+	if !ok {
+		return nil, nil, vdr.ErrNotFound
+	}
+
+	documents := make([]did.Document, len(entries))
+	metadata := make([]vdr.DocumentMetadata, len(entries))
+	for i, entry := range entries {
+		copyEntry, err := deepCopy(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		documents[i] = copyEntry.document
+		metadata[i] = copyEntry.metadata
+	}
+	return documents, metadata, nil
+}
+
+// FindByIndex returns the DIDs of the DID Documents of which the latest version contains the given value for the given index.
+// The in-memory store has no index, so it checks every DID Document.
+func (m *memory) FindByIndex(index vdr.DocumentIndex, value string) ([]did.DID, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var result []did.DID
+	for _, entries := range m.store {
+		entry, err := entries.last()
+		if err != nil {
+			return nil, err
+		}
+		for _, curr := range IndexValues(entry.document)[index] {
+			if curr == value {
+				result = append(result, entry.document.ID)
+				break
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
 // deepCopy returns a deep copy of a memoryEntry
 func deepCopy(entry *memoryEntry) (*memoryEntry, error) {
 	// deep copy document
@@ -137,6 +137,68 @@ func TestMemory_Resolve(t *testing.T) {
 	})
 }
 
+func TestMemory_History(t *testing.T) {
+	store := NewMemoryStore()
+	did1, _ := did.ParseDID("did:nuts:1")
+	doc := did.Document{
+		ID:         *did1,
+		Controller: []did.DID{*did1},
+	}
+	firstHash, _ := hash.ParseHex("452d9e89d5bd5d9225fb6daecd579e7388a166c7661ca04e47fd3cd8446e4619")
+	latestHash, _ := hash.ParseHex("452d9e89d5bd5d9225fb6daecd579e7388a166c7661ca04e47fd3cd8446e4620")
+	deactivated := did.Document{ID: *did1}
+
+	_ = store.Write(doc, types.DocumentMetadata{Hash: firstHash})
+	_ = store.Update(*did1, firstHash, deactivated, &types.DocumentMetadata{Hash: latestHash, PreviousHash: &firstHash})
+
+	t.Run("returns all versions, oldest first", func(t *testing.T) {
+		docs, metas, err := store.History(*did1)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []did.Document{doc, deactivated}, docs)
+		if !assert.Len(t, metas, 2) {
+			return
+		}
+		assert.Equal(t, firstHash, metas[0].Hash)
+		assert.Equal(t, latestHash, metas[1].Hash)
+	})
+
+	t.Run("returns ErrNotFound on unknown did", func(t *testing.T) {
+		did2, _ := did.ParseDID("did:nuts:2")
+
+		_, _, err := store.History(*did2)
+
+		assert.Equal(t, types.ErrNotFound, err)
+	})
+}
+
+func TestMemory_FindByIndex(t *testing.T) {
+	store := NewMemoryStore()
+	did1, _ := did.ParseDID("did:nuts:1")
+	did2, _ := did.ParseDID("did:nuts:2")
+	controller, _ := did.ParseDID("did:nuts:3")
+	_ = store.Write(did.Document{ID: *did2, Controller: []did.DID{*controller}}, types.DocumentMetadata{})
+	_ = store.Write(did.Document{ID: *did1, Controller: []did.DID{*controller}}, types.DocumentMetadata{})
+
+	t.Run("ordered results", func(t *testing.T) {
+		ids, err := store.FindByIndex(types.ControllerIndex, controller.String())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []did.DID{*did1, *did2}, ids)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		ids, err := store.FindByIndex(types.ControllerIndex, did1.String())
+
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
+
 func TestTimeSelectionFilter(t *testing.T) {
 	earlier := time.Now().Add(time.Hour * -24)
 	now := time.Now()
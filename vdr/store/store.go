@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
 	vdr "github.com/nuts-foundation/nuts-node/vdr/types"
 )
 
@@ -64,6 +65,53 @@ func (s *store) Configure(_ core.ServerConfig) error {
 	return err
 }
 
+// Migrate builds the index of DID Documents if it doesn't exist (e.g. when upgrading) or is outdated.
+func (s *store) Migrate() error {
+	return s.db.Write(context.Background(), func(tx stoabs.WriteTx) error {
+		indexMetadataWriter, err := tx.GetShelfWriter(indexMetadataShelf)
+		if err != nil {
+			return err
+		}
+		currentVersion, err := indexMetadataWriter.Get(stoabs.BytesKey(indexVersionKey))
+		if err != nil {
+			return err
+		}
+		if string(currentVersion) == indexVersion {
+			return nil
+		}
+		log.Logger().Info("DID Document index missing or outdated, rebuilding...")
+		indexWriter, err := tx.GetShelfWriter(indexShelf)
+		if err != nil {
+			return err
+		}
+		// remove all existing entries
+		var keys []stoabs.Key
+		err = indexWriter.Iterate(func(key stoabs.Key, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		}, stoabs.BytesKey{})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := indexWriter.Delete(key); err != nil {
+				return err
+			}
+		}
+		// add entries for the latest version of every DID Document
+		count := 0
+		err = s.iterate(tx, func(document did.Document, _ vdr.DocumentMetadata) error {
+			count++
+			return updateIndex(indexWriter, nil, document)
+		})
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("Indexed %d DID Documents", count)
+		return indexMetadataWriter.Put(stoabs.BytesKey(indexVersionKey), []byte(indexVersion))
+	})
+}
+
 func (s *store) Start() error {
 	return nil
 }
@@ -115,7 +163,7 @@ func (s *store) Write(document did.Document, metadata vdr.DocumentMetadata) erro
 			Version:     0,
 		}
 
-		return s.writeDocument(tx, document, newMetadataRecord)
+		return s.writeDocument(tx, document, newMetadataRecord, nil)
 	})
 }
 
@@ -158,6 +206,20 @@ func (s *store) Update(id did.DID, current hash.SHA256Hash, next did.Document, m
 			return vdr.ErrUpdateOnOutdatedData
 		}
 
+		// previous version is needed to update the index
+		documentWriter, err := tx.GetShelfWriter(documentShelf)
+		if err != nil {
+			return err
+		}
+		latestDocumentBytes, err := documentWriter.Get(stoabs.NewHashKey(latestMetadata.Metadata.Hash))
+		if err != nil {
+			return err
+		}
+		var latestDocument did.Document
+		if err := json.Unmarshal(latestDocumentBytes, &latestDocument); err != nil {
+			return err
+		}
+
 		// add new metadata record pointing to latest
 		prevMetaRef = latestMetadata.ref()
 		version = latestMetadata.Version + 1
@@ -169,11 +231,12 @@ func (s *store) Update(id did.DID, current hash.SHA256Hash, next did.Document, m
 			Version:     version,
 		}
 
-		return s.writeDocument(tx, next, newMetadataRecord)
+		return s.writeDocument(tx, next, newMetadataRecord, &latestDocument)
 	})
 }
 
-func (s *store) writeDocument(tx stoabs.WriteTx, document did.Document, metadataRecord metadataRecord) error {
+// writeDocument writes a new version of a DID Document. previous is the current latest version of the document, if any.
+func (s *store) writeDocument(tx stoabs.WriteTx, document did.Document, metadataRecord metadataRecord, previous *did.Document) error {
 	// get shelf writers
 	latestWriter, err := tx.GetShelfWriter(latestShelf)
 	if err != nil {
@@ -191,6 +254,10 @@ func (s *store) writeDocument(tx stoabs.WriteTx, document did.Document, metadata
 	if err != nil {
 		return err
 	}
+	indexWriter, err := tx.GetShelfWriter(indexShelf)
+	if err != nil {
+		return err
+	}
 
 	// store in metadataShelf
 	newRefBytes := metadataRecord.ref()
@@ -211,6 +278,11 @@ func (s *store) writeDocument(tx stoabs.WriteTx, document did.Document, metadata
 		}
 	}
 
+	// update the index to reflect the new latest version
+	if err := updateIndex(indexWriter, previous, document); err != nil {
+		return err
+	}
+
 	// add payload to documentShelf
 	documentBytes, _ := json.Marshal(document)
 	return documentWriter.Put(stoabs.NewHashKey(metadataRecord.Metadata.Hash), documentBytes)
@@ -236,32 +308,36 @@ func (s *store) Processed(hash hash.SHA256Hash) (processed bool, txErr error) {
 // Iterate loops over all the latest versions of the stored DID Documents and applies fn
 func (s *store) Iterate(fn vdr.DocIterator) error {
 	return s.db.Read(context.Background(), func(tx stoabs.ReadTx) error {
-		// get shelf readers
-		latestReader := tx.GetShelfReader(latestShelf)
-		metadataReader := tx.GetShelfReader(metadataShelf)
-		documentReader := tx.GetShelfReader(documentShelf)
+		return s.iterate(tx, fn)
+	})
+}
 
-		return latestReader.Iterate(func(didKey stoabs.Key, metadataRecordRef []byte) error {
-			metadataRecordBytes, err := metadataReader.Get(stoabs.BytesKey(metadataRecordRef))
-			if err != nil {
-				return err
-			}
-			var metadataRecord metadataRecord
-			if err := json.Unmarshal(metadataRecordBytes, &metadataRecord); err != nil {
-				return err
-			}
-			documentBytes, err := documentReader.Get(stoabs.NewHashKey(metadataRecord.Metadata.Hash))
-			if err != nil {
-				return err
-			}
-			var document did.Document
-			if err := json.Unmarshal(documentBytes, &document); err != nil {
-				return err
-			}
+func (s *store) iterate(tx stoabs.ReadTx, fn vdr.DocIterator) error {
+	// get shelf readers
+	latestReader := tx.GetShelfReader(latestShelf)
+	metadataReader := tx.GetShelfReader(metadataShelf)
+	documentReader := tx.GetShelfReader(documentShelf)
 
-			return fn(document, metadataRecord.Metadata)
-		}, stoabs.BytesKey{})
-	})
+	return latestReader.Iterate(func(didKey stoabs.Key, metadataRecordRef []byte) error {
+		metadataRecordBytes, err := metadataReader.Get(stoabs.BytesKey(metadataRecordRef))
+		if err != nil {
+			return err
+		}
+		var metadataRecord metadataRecord
+		if err := json.Unmarshal(metadataRecordBytes, &metadataRecord); err != nil {
+			return err
+		}
+		documentBytes, err := documentReader.Get(stoabs.NewHashKey(metadataRecord.Metadata.Hash))
+		if err != nil {
+			return err
+		}
+		var document did.Document
+		if err := json.Unmarshal(documentBytes, &document); err != nil {
+			return err
+		}
+
+		return fn(document, metadataRecord.Metadata)
+	}, stoabs.BytesKey{})
 }
 
 func (s *store) Resolve(id did.DID, metadata *vdr.ResolveMetadata) (returnDocument *did.Document, returnMetadata *vdr.DocumentMetadata, txErr error) {
@@ -309,6 +385,59 @@ func (s *store) Resolve(id did.DID, metadata *vdr.ResolveMetadata) (returnDocume
 	return
 }
 
+// History returns all versions of the DID Document for the provided DID, oldest version first.
+func (s *store) History(id did.DID) (documents []did.Document, metadata []vdr.DocumentMetadata, txErr error) {
+	txErr = s.db.Read(context.Background(), func(tx stoabs.ReadTx) error {
+		latestReader := tx.GetShelfReader(latestShelf)
+		metadataRef, _ := latestReader.Get(stoabs.BytesKey(id.String()))
+		if metadataRef == nil {
+			return vdr.ErrNotFound
+		}
+
+		metadataReader := tx.GetShelfReader(metadataShelf)
+		documentReader := tx.GetShelfReader(documentShelf)
+
+		// versions are linked from newest to oldest
+		for metadataRef != nil {
+			var metadataRecord metadataRecord
+			metadataBytes, err := metadataReader.Get(stoabs.BytesKey(metadataRef))
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(metadataBytes, &metadataRecord); err != nil {
+				return err
+			}
+			docBytes, err := documentReader.Get(stoabs.NewHashKey(metadataRecord.Metadata.Hash))
+			if err != nil {
+				return err
+			}
+			var document did.Document
+			if err := json.Unmarshal(docBytes, &document); err != nil {
+				return err
+			}
+			documents = append([]did.Document{document}, documents...)
+			metadata = append([]vdr.DocumentMetadata{metadataRecord.Metadata}, metadata...)
+
+			metadataRef = metadataRecord.PrevMetaRef
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, nil, txErr
+	}
+	return
+}
+
+// FindByIndex returns the DIDs of the DID Documents of which the latest version contains the given value for the given index.
+func (s *store) FindByIndex(index vdr.DocumentIndex, value string) (result []did.DID, txErr error) {
+	txErr = s.db.Read(context.Background(), func(tx stoabs.ReadTx) error {
+		var err error
+		result, err = findByIndex(tx.GetShelfReader(indexShelf), index, value)
+		return err
+	})
+	return
+}
+
 func matches(metadataRecord metadataRecord, metadata *vdr.ResolveMetadata) bool {
 	if metadataRecord.Deactivated && (metadata == nil || !metadata.AllowDeactivated) {
 		return false
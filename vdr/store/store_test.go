@@ -16,6 +16,7 @@
 package store
 
 import (
+	"context"
 	"errors"
 	"path"
 	"sync"
@@ -25,7 +26,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/storage"
@@ -326,6 +329,48 @@ func TestStore_Update(t *testing.T) {
 	})
 }
 
+func TestStore_History(t *testing.T) {
+	store := newTestStore(t)
+	did1, _ := did.ParseDID("did:nuts:1")
+	doc := did.Document{
+		ID:         *did1,
+		Controller: []did.DID{*did1},
+	}
+	firstHash, _ := hash.ParseHex("452d9e89d5bd5d9225fb6daecd579e7388a166c7661ca04e47fd3cd8446e4619")
+	firstMeta := types.DocumentMetadata{Hash: firstHash}
+	latestHash, _ := hash.ParseHex("452d9e89d5bd5d9225fb6daecd579e7388a166c7661ca04e47fd3cd8446e4620")
+	latestMeta := types.DocumentMetadata{Hash: latestHash, PreviousHash: &firstHash}
+	deactivated := did.Document{ID: *did1}
+
+	_ = store.Write(doc, firstMeta)
+	_ = store.Update(*did1, firstHash, deactivated, &latestMeta)
+
+	t.Run("returns all versions, oldest first", func(t *testing.T) {
+		docs, metas, err := store.History(*did1)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []did.Document{doc, deactivated}, docs)
+		if !assert.Len(t, metas, 2) {
+			return
+		}
+		assert.Equal(t, firstHash, metas[0].Hash)
+		assert.Equal(t, latestHash, metas[1].Hash)
+		assert.Equal(t, firstHash, *metas[1].PreviousHash)
+	})
+
+	t.Run("returns ErrNotFound on unknown did", func(t *testing.T) {
+		did2, _ := did.ParseDID("did:nuts:2")
+
+		docs, metas, err := store.History(*did2)
+
+		assert.Equal(t, types.ErrNotFound, err)
+		assert.Nil(t, docs)
+		assert.Nil(t, metas)
+	})
+}
+
 func TestStore_Parallelism(t *testing.T) {
 	// This test, when run with -race, assures access to internals is synchronized
 	store := newTestStore(t)
@@ -422,6 +467,96 @@ func TestStore_Iterate(t *testing.T) {
 	})
 }
 
+func TestStore_FindByIndex(t *testing.T) {
+	did1, _ := did.ParseDID("did:nuts:1")
+	did2, _ := did.ParseDID("did:nuts:2")
+	controller, _ := did.ParseDID("did:nuts:3")
+	serviceV1 := did.Service{ID: ssi.MustParseURI("did:nuts:1#1"), Type: "api", ServiceEndpoint: "https://old.example.com/api"}
+	serviceV2 := did.Service{ID: ssi.MustParseURI("did:nuts:1#2"), Type: "api", ServiceEndpoint: "https://new.example.com/api"}
+	firstHash := hash.SHA256Sum([]byte("1"))
+	secondHash := hash.SHA256Sum([]byte("2"))
+
+	store := newTestStore(t)
+	_ = store.Write(did.Document{ID: *did1, Controller: []did.DID{*controller}, Service: []did.Service{serviceV1}}, types.DocumentMetadata{Hash: firstHash})
+	_ = store.Write(did.Document{ID: *did2, Controller: []did.DID{*controller}}, types.DocumentMetadata{Hash: hash.SHA256Sum([]byte("3"))})
+
+	t.Run("multiple results, ordered", func(t *testing.T) {
+		ids, err := store.FindByIndex(types.ControllerIndex, controller.String())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []did.DID{*did1, *did2}, ids)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		ids, err := store.FindByIndex(types.ControllerIndex, "did:nuts:4")
+
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("value is not matched as prefix", func(t *testing.T) {
+		ids, err := store.FindByIndex(types.ControllerIndex, "did:nuts:")
+
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("only latest version is indexed", func(t *testing.T) {
+		err := store.Update(*did1, firstHash, did.Document{ID: *did1, Controller: []did.DID{*controller}, Service: []did.Service{serviceV2}}, &types.DocumentMetadata{Hash: secondHash})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		ids, _ := store.FindByIndex(types.ServiceEndpointHostIndex, "old.example.com")
+		assert.Empty(t, ids)
+		ids, _ = store.FindByIndex(types.ServiceEndpointHostIndex, "new.example.com")
+		assert.Equal(t, []did.DID{*did1}, ids)
+		ids, _ = store.FindByIndex(types.ControllerIndex, controller.String())
+		assert.Equal(t, []did.DID{*did1, *did2}, ids)
+	})
+}
+
+func TestStore_Migrate(t *testing.T) {
+	did1, _ := did.ParseDID("did:nuts:1")
+	controller, _ := did.ParseDID("did:nuts:2")
+	s := newTestStore(t)
+	_ = s.Write(did.Document{ID: *did1, Controller: []did.DID{*controller}}, types.DocumentMetadata{Hash: hash.SHA256Sum([]byte("1"))})
+	// clear the index, as if the store was created by an older version
+	db := s.(*store).db
+	err := db.WriteShelf(context.Background(), indexShelf, func(writer stoabs.Writer) error {
+		return writer.Delete(indexKey(types.ControllerIndex, controller.String(), did1.String()))
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	ids, _ := s.FindByIndex(types.ControllerIndex, controller.String())
+	if !assert.Empty(t, ids) {
+		return
+	}
+
+	t.Run("builds index", func(t *testing.T) {
+		err := s.(*store).Migrate()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids, _ := s.FindByIndex(types.ControllerIndex, controller.String())
+		assert.Equal(t, []did.DID{*did1}, ids)
+	})
+
+	t.Run("index is up to date", func(t *testing.T) {
+		err := s.(*store).Migrate()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids, _ := s.FindByIndex(types.ControllerIndex, controller.String())
+		assert.Equal(t, []did.DID{*did1}, ids)
+	})
+}
+
 func TestStore_DeactivatedFilter(t *testing.T) {
 	store := newTestStore(t)
 	did1, _ := did.ParseDID("did:nuts:1")
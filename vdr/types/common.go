@@ -19,6 +19,7 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -45,6 +46,21 @@ var ErrDeactivated = deactivatedError{msg: "the DID document has been deactivate
 // ErrNoActiveController The DID supplied to the DID resolution does not have any active controllers.
 var ErrNoActiveController = deactivatedError{msg: "no active controllers for DID Document"}
 
+// ErrUnsupportedDIDMethod is returned when a DID can't be resolved because its DID method isn't supported.
+var ErrUnsupportedDIDMethod = errors.New("unsupported DID method")
+
+// ErrNotConflicted is returned when a conflict of a DID document is resolved, while the DID document is not conflicted.
+var ErrNotConflicted = errors.New("DID document is not conflicted")
+
+// ErrControllerThresholdNotMet is returned when a DID document update isn't signed by enough of its controllers.
+var ErrControllerThresholdNotMet = errors.New("DID document update is not signed by enough controllers")
+
+// ErrInvalidProposal is returned when a DID document update proposal is invalid, e.g. because it contains an invalid signature.
+var ErrInvalidProposal = errors.New("invalid DID document update proposal")
+
+// ErrExpiryNotScheduled is returned when an expiry is cancelled that hasn't been scheduled.
+var ErrExpiryNotScheduled = errors.New("no expiry scheduled")
+
 // ErrDIDAlreadyExists is returned when a DID already exists.
 var ErrDIDAlreadyExists = errors.New("DID document already exists in the store")
 
@@ -85,6 +101,22 @@ func (d deactivatedError) Is(other error) bool {
 	return result
 }
 
+// DocumentIndex identifies an attribute of DID Documents that is indexed by the Store.
+type DocumentIndex string
+
+const (
+	// ControllerIndex indexes DID Documents on the DIDs of their controllers.
+	ControllerIndex DocumentIndex = "controller"
+	// VerificationMethodIndex indexes DID Documents on the IDs of their verification methods.
+	VerificationMethodIndex DocumentIndex = "verificationMethod"
+	// ServiceTypeIndex indexes DID Documents on the types of their services.
+	ServiceTypeIndex DocumentIndex = "serviceType"
+	// ServiceEndpointIndex indexes DID Documents on the endpoints of their services.
+	ServiceEndpointIndex DocumentIndex = "serviceEndpoint"
+	// ServiceEndpointHostIndex indexes DID Documents on the (lowercase) hosts of the URLs their services point to.
+	ServiceEndpointHostIndex DocumentIndex = "serviceEndpointHost"
+)
+
 // DocumentMetadata holds the metadata of a DID document
 type DocumentMetadata struct {
 	Created time.Time  `json:"created"`
@@ -121,6 +153,57 @@ func (m DocumentMetadata) IsConflicted() bool {
 	return len(m.SourceTransactions) > 1
 }
 
+// ConflictResolution describes how a conflicted DID document is resolved.
+type ConflictResolution struct {
+	// Current contains the conflicted DID document as it is currently resolved.
+	Current did.Document
+	// Merged contains the merged DID document that resolves the conflict.
+	Merged did.Document
+	// Heads contains the references of the conflicting transactions.
+	Heads []hash.SHA256Hash
+	// Published indicates whether the merged DID document has been published as update.
+	Published bool
+}
+
+// ControllerThresholdServiceType is the type of the service that specifies how many controllers must sign an update of the DID document.
+// Its serviceEndpoint is an object containing the threshold, e.g.: {"threshold": 2}.
+// If the DID document does not contain the service, a single controller suffices.
+const ControllerThresholdServiceType = "NutsControllerThreshold"
+
+// UpdateProposal contains a proposed update of a DID document, co-signed by (some of) its controllers.
+// Each signature is a compact JWS with detached payload (see SigningInput), where the kid header refers to
+// a capabilityInvocation key of one of the controllers.
+type UpdateProposal struct {
+	// Current contains the hash of the DID document version the update applies to.
+	Current hash.SHA256Hash `json:"current"`
+	// Document contains the proposed next version of the DID document.
+	Document did.Document `json:"document"`
+	// Signatures contains the signatures of the controllers that approved the update.
+	Signatures []string `json:"signatures"`
+}
+
+// SigningInput returns the data that's signed by the controllers: the JSON encoded current hash and proposed DID document.
+func (p UpdateProposal) SigningInput() ([]byte, error) {
+	return json.Marshal(struct {
+		Current  hash.SHA256Hash `json:"current"`
+		Document did.Document    `json:"document"`
+	}{
+		Current:  p.Current,
+		Document: p.Document,
+	})
+}
+
+// Expiry describes the scheduled expiry of a DID document or one of its verification methods.
+// When expired, the DID document is deactivated or the verification method is removed from it.
+type Expiry struct {
+	// DID contains the DID of the DID document.
+	DID did.DID `json:"did"`
+	// KeyID contains the ID of the verification method that expires. If nil, the DID document itself expires.
+	KeyID *did.DID `json:"keyID,omitempty"`
+	// ExpiresAt contains the time at which the DID document or verification method expires.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 // ResolveMetadata contains metadata for the resolver.
 type ResolveMetadata struct {
 	// Resolve the version which is valid at this time
@@ -138,6 +221,8 @@ type CompoundService map[string]string
 
 // DIDCreationOptions defines options for creating a DID Document.
 type DIDCreationOptions struct {
+	// Method is the DID method of the new DID: "nuts" (default when not given) or "web".
+	Method string
 
 	// Controllers lists the DIDs that can control the new DID Document. If selfControl = true and controllers is not empty,
 	// the newly generated DID will be added to the list of controllers.
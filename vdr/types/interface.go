@@ -41,12 +41,27 @@ type DocResolver interface {
 	ResolveControllers(input did.Document, metadata *ResolveMetadata) ([]did.Document, error)
 }
 
+// DIDResolver is the interface for resolving DID documents of a single DID method.
+type DIDResolver interface {
+	// Resolve returns the DID Document for the provided DID.
+	// It returns ErrNotFound if the DID Document can't be found.
+	Resolve(id did.DID, metadata *ResolveMetadata) (*did.Document, *DocumentMetadata, error)
+}
+
 // Predicate is an interface for abstracting search options on DID documents
 type Predicate interface {
 	// Match returns true if the given DID Document passes the predicate condition
 	Match(did.Document, DocumentMetadata) bool
 }
 
+// IndexedPredicate is a Predicate that can be evaluated using an index of the Store.
+// The index is used to find candidate DID documents, which are then matched using Match.
+type IndexedPredicate interface {
+	Predicate
+	// Index returns the index and the value to look up in the index.
+	Index() (DocumentIndex, string)
+}
+
 // DocFinder is the interface that groups all methods for finding DID documents based on search conditions
 type DocFinder interface {
 	Find(...Predicate) ([]did.Document, error)
@@ -117,6 +132,12 @@ type Store interface {
 	// Iterate loops over all the latest versions of the stored DID Documents and applies fn.
 	// Calling any of the Store's functions from the given fn might cause a deadlock.
 	Iterate(fn DocIterator) error
+	// History returns all versions of the DID Document for the provided DID (including deactivated ones), oldest version first.
+	// It returns ErrNotFound if the DID Document doesn't exist.
+	History(id did.DID) ([]did.Document, []DocumentMetadata, error)
+	// FindByIndex returns the DIDs of the DID Documents of which the latest version contains the given value for the given index.
+	// The DIDs are ordered by their string representation.
+	FindByIndex(index DocumentIndex, value string) ([]did.DID, error)
 
 	DocWriter
 	DocUpdater
@@ -129,6 +150,41 @@ type VDR interface {
 
 	// ConflictedDocuments returns the DID Document and metadata of all documents with a conflict.
 	ConflictedDocuments() ([]did.Document, []DocumentMetadata, error)
+	// History returns all versions of the DID Document for the provided DID (including deactivated ones), oldest version first.
+	// It returns ErrNotFound if the DID Document doesn't exist.
+	History(id did.DID) ([]did.Document, []DocumentMetadata, error)
+	// ResolveConflict merges the conflicting versions of a conflicted DID document and publishes the result as update,
+	// which refers to all conflicting versions. If dryRun is true the update is not published.
+	// It returns ErrNotConflicted if the DID document is not conflicted
+	// and ErrDIDNotManagedByThisNode if the DID document is not controlled by this node.
+	ResolveConflict(id did.DID, dryRun bool) (*ConflictResolution, error)
+	// ResolveConflicts resolves the conflicts of all conflicted DID documents that are controlled by this node, see ResolveConflict.
+	// Conflicted DID documents that aren't controlled by this node, are deactivated or require multiple controllers to sign updates are skipped.
+	ResolveConflicts(dryRun bool) ([]ConflictResolution, error)
+	// ProposeUpdate prepares an update of a DID document that must be signed by multiple controllers (see ControllerThresholdServiceType),
+	// since Update returns ErrControllerThresholdNotMet for such DID documents. The proposal is signed by the controllers managed by this node.
+	// It returns ErrDIDNotManagedByThisNode if none of the controllers is managed by this node.
+	ProposeUpdate(id did.DID, current hash.SHA256Hash, next did.Document) (*UpdateProposal, error)
+	// SignProposal adds the signatures of the controllers managed by this node to the proposal, if they didn't sign it yet.
+	SignProposal(proposal UpdateProposal) (*UpdateProposal, error)
+	// PublishProposal publishes the proposed update on the network. It returns ErrControllerThresholdNotMet
+	// if the proposal isn't signed by enough controllers and ErrDIDNotManagedByThisNode if none of the controllers is managed by this node.
+	PublishProposal(proposal UpdateProposal) error
+}
+
+// ExpiryScheduler schedules the expiry of DID documents and verification methods managed by this node.
+// Expired DID documents are deactivated, expired verification methods are removed from their DID document.
+type ExpiryScheduler interface {
+	// Schedule schedules the expiry of a DID document, or of one of its verification methods if expiry.KeyID is set.
+	// An existing expiry of the same DID document or verification method is replaced.
+	// It returns ErrNotFound if the DID document doesn't exist, ErrKeyNotFound if the verification method doesn't exist,
+	// ErrDeactivated if the DID document is deactivated and ErrDIDNotManagedByThisNode if it isn't managed by this node.
+	Schedule(expiry Expiry) error
+	// Cancel cancels the expiry of the DID document, or of one of its verification methods if keyID is not nil.
+	// It returns ErrExpiryNotScheduled if no expiry was scheduled.
+	Cancel(id did.DID, keyID *did.DID) error
+	// List returns all scheduled expiries, ordered by expiry time.
+	List() ([]Expiry, error)
 }
 
 // DocManipulator groups several higher level methods to alter the state of a DID document.
@@ -156,4 +212,14 @@ type DocManipulator interface {
 	// It returns an ErrDeactivated when the DID document has the deactivated state.
 	// It returns an ErrDIDNotManagedByThisNode if the DID document is not managed by this node.
 	AddVerificationMethod(id did.DID) (*did.VerificationMethod, error)
+
+	// RotateVerificationMethod replaces a VerificationMethod of a DID document with a newly generated key,
+	// which is added to the same verification relationships. The private key of the replaced VerificationMethod is retired.
+	// It accepts the id DID as identifier for the DID document.
+	// It accepts the keyID DID as identifier for the VerificationMethod to replace.
+	// It returns an ErrNotFound when the DID document could not be found.
+	// It returns an ErrKeyNotFound when there is no VerificationMethod with the provided keyID in the document.
+	// It returns an ErrDeactivated when the DID document has the deactivated state.
+	// It returns an ErrDIDNotManagedByThisNode if the DID document is not managed by this node.
+	RotateVerificationMethod(id, keyID did.DID) (*did.VerificationMethod, error)
 }
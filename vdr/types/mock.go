@@ -70,6 +70,45 @@ func (mr *MockDocResolverMockRecorder) ResolveControllers(input, metadata interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveControllers", reflect.TypeOf((*MockDocResolver)(nil).ResolveControllers), input, metadata)
 }
 
+// MockDIDResolver is a mock of DIDResolver interface.
+type MockDIDResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockDIDResolverMockRecorder
+}
+
+// MockDIDResolverMockRecorder is the mock recorder for MockDIDResolver.
+type MockDIDResolverMockRecorder struct {
+	mock *MockDIDResolver
+}
+
+// NewMockDIDResolver creates a new mock instance.
+func NewMockDIDResolver(ctrl *gomock.Controller) *MockDIDResolver {
+	mock := &MockDIDResolver{ctrl: ctrl}
+	mock.recorder = &MockDIDResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDIDResolver) EXPECT() *MockDIDResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockDIDResolver) Resolve(id did.DID, metadata *ResolveMetadata) (*did.Document, *DocumentMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", id, metadata)
+	ret0, _ := ret[0].(*did.Document)
+	ret1, _ := ret[1].(*DocumentMetadata)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockDIDResolverMockRecorder) Resolve(id, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockDIDResolver)(nil).Resolve), id, metadata)
+}
+
 // MockPredicate is a mock of Predicate interface.
 type MockPredicate struct {
 	ctrl     *gomock.Controller
@@ -107,6 +146,58 @@ func (mr *MockPredicateMockRecorder) Match(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockPredicate)(nil).Match), arg0, arg1)
 }
 
+// MockIndexedPredicate is a mock of IndexedPredicate interface.
+type MockIndexedPredicate struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexedPredicateMockRecorder
+}
+
+// MockIndexedPredicateMockRecorder is the mock recorder for MockIndexedPredicate.
+type MockIndexedPredicateMockRecorder struct {
+	mock *MockIndexedPredicate
+}
+
+// NewMockIndexedPredicate creates a new mock instance.
+func NewMockIndexedPredicate(ctrl *gomock.Controller) *MockIndexedPredicate {
+	mock := &MockIndexedPredicate{ctrl: ctrl}
+	mock.recorder = &MockIndexedPredicateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexedPredicate) EXPECT() *MockIndexedPredicateMockRecorder {
+	return m.recorder
+}
+
+// Index mocks base method.
+func (m *MockIndexedPredicate) Index() (DocumentIndex, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index")
+	ret0, _ := ret[0].(DocumentIndex)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockIndexedPredicateMockRecorder) Index() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockIndexedPredicate)(nil).Index))
+}
+
+// Match mocks base method.
+func (m *MockIndexedPredicate) Match(arg0 did.Document, arg1 DocumentMetadata) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Match", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Match indicates an expected call of Match.
+func (mr *MockIndexedPredicateMockRecorder) Match(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockIndexedPredicate)(nil).Match), arg0, arg1)
+}
+
 // MockDocFinder is a mock of DocFinder interface.
 type MockDocFinder struct {
 	ctrl     *gomock.Controller
@@ -383,6 +474,37 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// FindByIndex mocks base method.
+func (m *MockStore) FindByIndex(index DocumentIndex, value string) ([]did.DID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIndex", index, value)
+	ret0, _ := ret[0].([]did.DID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIndex indicates an expected call of FindByIndex.
+func (mr *MockStoreMockRecorder) FindByIndex(index, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIndex", reflect.TypeOf((*MockStore)(nil).FindByIndex), index, value)
+}
+
+// History mocks base method.
+func (m *MockStore) History(id did.DID) ([]did.Document, []DocumentMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", id)
+	ret0, _ := ret[0].([]did.Document)
+	ret1, _ := ret[1].([]DocumentMetadata)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// History indicates an expected call of History.
+func (mr *MockStoreMockRecorder) History(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockStore)(nil).History), id)
+}
+
 // Iterate mocks base method.
 func (m *MockStore) Iterate(fn DocIterator) error {
 	m.ctrl.T.Helper()
@@ -511,6 +633,96 @@ func (mr *MockVDRMockRecorder) Create(options interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVDR)(nil).Create), options)
 }
 
+// History mocks base method.
+func (m *MockVDR) History(id did.DID) ([]did.Document, []DocumentMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", id)
+	ret0, _ := ret[0].([]did.Document)
+	ret1, _ := ret[1].([]DocumentMetadata)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// History indicates an expected call of History.
+func (mr *MockVDRMockRecorder) History(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockVDR)(nil).History), id)
+}
+
+// ProposeUpdate mocks base method.
+func (m *MockVDR) ProposeUpdate(id did.DID, current hash.SHA256Hash, next did.Document) (*UpdateProposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeUpdate", id, current, next)
+	ret0, _ := ret[0].(*UpdateProposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeUpdate indicates an expected call of ProposeUpdate.
+func (mr *MockVDRMockRecorder) ProposeUpdate(id, current, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeUpdate", reflect.TypeOf((*MockVDR)(nil).ProposeUpdate), id, current, next)
+}
+
+// PublishProposal mocks base method.
+func (m *MockVDR) PublishProposal(proposal UpdateProposal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishProposal", proposal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishProposal indicates an expected call of PublishProposal.
+func (mr *MockVDRMockRecorder) PublishProposal(proposal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishProposal", reflect.TypeOf((*MockVDR)(nil).PublishProposal), proposal)
+}
+
+// ResolveConflict mocks base method.
+func (m *MockVDR) ResolveConflict(id did.DID, dryRun bool) (*ConflictResolution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveConflict", id, dryRun)
+	ret0, _ := ret[0].(*ConflictResolution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveConflict indicates an expected call of ResolveConflict.
+func (mr *MockVDRMockRecorder) ResolveConflict(id, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveConflict", reflect.TypeOf((*MockVDR)(nil).ResolveConflict), id, dryRun)
+}
+
+// ResolveConflicts mocks base method.
+func (m *MockVDR) ResolveConflicts(dryRun bool) ([]ConflictResolution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveConflicts", dryRun)
+	ret0, _ := ret[0].([]ConflictResolution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveConflicts indicates an expected call of ResolveConflicts.
+func (mr *MockVDRMockRecorder) ResolveConflicts(dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveConflicts", reflect.TypeOf((*MockVDR)(nil).ResolveConflicts), dryRun)
+}
+
+// SignProposal mocks base method.
+func (m *MockVDR) SignProposal(proposal UpdateProposal) (*UpdateProposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignProposal", proposal)
+	ret0, _ := ret[0].(*UpdateProposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignProposal indicates an expected call of SignProposal.
+func (mr *MockVDRMockRecorder) SignProposal(proposal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignProposal", reflect.TypeOf((*MockVDR)(nil).SignProposal), proposal)
+}
+
 // Update mocks base method.
 func (m *MockVDR) Update(id did.DID, current hash.SHA256Hash, next did.Document, metadata *DocumentMetadata) error {
 	m.ctrl.T.Helper()
@@ -525,6 +737,72 @@ func (mr *MockVDRMockRecorder) Update(id, current, next, metadata interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVDR)(nil).Update), id, current, next, metadata)
 }
 
+// MockExpiryScheduler is a mock of ExpiryScheduler interface.
+type MockExpiryScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpirySchedulerMockRecorder
+}
+
+// MockExpirySchedulerMockRecorder is the mock recorder for MockExpiryScheduler.
+type MockExpirySchedulerMockRecorder struct {
+	mock *MockExpiryScheduler
+}
+
+// NewMockExpiryScheduler creates a new mock instance.
+func NewMockExpiryScheduler(ctrl *gomock.Controller) *MockExpiryScheduler {
+	mock := &MockExpiryScheduler{ctrl: ctrl}
+	mock.recorder = &MockExpirySchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExpiryScheduler) EXPECT() *MockExpirySchedulerMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockExpiryScheduler) Cancel(id did.DID, keyID *did.DID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", id, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockExpirySchedulerMockRecorder) Cancel(id, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockExpiryScheduler)(nil).Cancel), id, keyID)
+}
+
+// List mocks base method.
+func (m *MockExpiryScheduler) List() ([]Expiry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]Expiry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockExpirySchedulerMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockExpiryScheduler)(nil).List))
+}
+
+// Schedule mocks base method.
+func (m *MockExpiryScheduler) Schedule(expiry Expiry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", expiry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockExpirySchedulerMockRecorder) Schedule(expiry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockExpiryScheduler)(nil).Schedule), expiry)
+}
+
 // MockDocManipulator is a mock of DocManipulator interface.
 type MockDocManipulator struct {
 	ctrl     *gomock.Controller
@@ -590,3 +868,18 @@ func (mr *MockDocManipulatorMockRecorder) RemoveVerificationMethod(id, keyID int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVerificationMethod", reflect.TypeOf((*MockDocManipulator)(nil).RemoveVerificationMethod), id, keyID)
 }
+
+// RotateVerificationMethod mocks base method.
+func (m *MockDocManipulator) RotateVerificationMethod(id, keyID did.DID) (*did.VerificationMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateVerificationMethod", id, keyID)
+	ret0, _ := ret[0].(*did.VerificationMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateVerificationMethod indicates an expected call of RotateVerificationMethod.
+func (mr *MockDocManipulatorMockRecorder) RotateVerificationMethod(id, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateVerificationMethod", reflect.TypeOf((*MockDocManipulator)(nil).RotateVerificationMethod), id, keyID)
+}
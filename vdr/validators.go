@@ -38,11 +38,13 @@ import (
 //   - every service id must have a fragment
 //   - every service id should have the DID prefix
 //   - every service id must be unique
+//  - it checks the controller threshold (if specified) is valid and can be met by the controllers
 func CreateDocumentValidator() did.Validator {
 	return &did.MultiValidator{Validators: []did.Validator{
 		did.W3CSpecValidator{},
 		verificationMethodValidator{},
 		serviceValidator{},
+		controllerThresholdValidator{},
 	}}
 }
 
@@ -95,6 +97,25 @@ func (s serviceValidator) Validate(document did.Document) error {
 	return nil
 }
 
+// controllerThresholdValidator validates the controller threshold of a Nuts DID Document.
+type controllerThresholdValidator struct{}
+
+func (c controllerThresholdValidator) Validate(document did.Document) error {
+	threshold, err := controllerThreshold(document)
+	if err != nil {
+		return err
+	}
+	// A DID document without controllers is controlled by itself
+	controllers := len(document.Controller)
+	if controllers == 0 {
+		controllers = 1
+	}
+	if threshold > controllers {
+		return fmt.Errorf("invalid controller threshold: exceeds number of controllers (threshold=%d, controllers=%d)", threshold, controllers)
+	}
+	return nil
+}
+
 func verifyDocumentEntryID(owner did.DID, entryID ssi.URI, knownIDs map[string]bool) error {
 	// Check theID has a fragment
 	if len(entryID.Fragment) == 0 {
@@ -25,6 +25,8 @@ import (
 	"errors"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/types"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -154,3 +156,58 @@ func Test_serviceValidator(t *testing.T) {
 		})
 	}
 }
+
+func Test_controllerThresholdValidator(t *testing.T) {
+	thresholdService := func(didDoc did.Document, endpoint interface{}) did.Service {
+		serviceID := didDoc.ID
+		serviceID.Fragment = "threshold"
+		return did.Service{ID: serviceID.URI(), Type: types.ControllerThresholdServiceType, ServiceEndpoint: endpoint}
+	}
+	type args struct {
+		doc did.Document
+	}
+	tests := []struct {
+		name      string
+		beforeFn  func(t *testing.T, a *args)
+		wantedErr error
+	}{
+		{"ok - no threshold", func(t *testing.T, a *args) {
+			didDoc, _, _ := newDidDoc()
+			a.doc = didDoc
+		}, nil},
+		{"ok - threshold equals number of controllers", func(t *testing.T, a *args) {
+			didDoc, _, _ := newDidDoc()
+			otherDoc, _, _ := newDidDoc()
+			didDoc.Controller = []did.DID{didDoc.ID, otherDoc.ID}
+			didDoc.Service = append(didDoc.Service, thresholdService(didDoc, map[string]interface{}{"threshold": 2}))
+			a.doc = didDoc
+		}, nil},
+		{"nok - threshold exceeds number of controllers", func(t *testing.T, a *args) {
+			didDoc, _, _ := newDidDoc()
+			didDoc.Service = append(didDoc.Service, thresholdService(didDoc, map[string]interface{}{"threshold": 2}))
+			a.doc = didDoc
+		}, errors.New("invalid controller threshold: exceeds number of controllers (threshold=2, controllers=1)")},
+		{"nok - threshold is zero", func(t *testing.T, a *args) {
+			didDoc, _, _ := newDidDoc()
+			didDoc.Service = append(didDoc.Service, thresholdService(didDoc, map[string]interface{}{"threshold": 0}))
+			a.doc = didDoc
+		}, errors.New("invalid controller threshold: must be greater than 0")},
+		{"nok - invalid endpoint", func(t *testing.T, a *args) {
+			didDoc, _, _ := newDidDoc()
+			didDoc.Service = append(didDoc.Service, thresholdService(didDoc, "https://nuts.nl"))
+			a.doc = didDoc
+		}, errors.New("invalid controller threshold: json: cannot unmarshal string into Go value of type struct { Threshold int \"json:\\\"threshold\\\"\" }")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := args{}
+			tt.beforeFn(t, &a)
+			err := (controllerThresholdValidator{}).Validate(a.doc)
+			if tt.wantedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantedErr.Error())
+			}
+		})
+	}
+}
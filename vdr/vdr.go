@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/events"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
 	"github.com/nuts-foundation/nuts-node/vdr/doc"
 	"github.com/nuts-foundation/nuts-node/vdr/store"
 	"github.com/sirupsen/logrus"
@@ -56,10 +57,14 @@ type VDR struct {
 	didDocCreator     types.DocCreator
 	didDocResolver    types.DocResolver
 	keyStore          crypto.KeyStore
+	webHost           *didweb.Host
+	webResolver       *didweb.Resolver
 }
 
-// NewVDR creates a new VDR with provided params
-func NewVDR(config Config, cryptoClient crypto.KeyStore, networkClient network.Transactions, store types.Store, eventManager events.Event) *VDR {
+// NewVDR creates a new VDR with provided params.
+// The webHost is used for creating and updating did:web DID documents hosted by this node, it may be nil if not supported.
+// The webResolver is enabled when configured to resolve did:web DIDs of other parties, it may be nil as well.
+func NewVDR(config Config, cryptoClient crypto.KeyStore, networkClient network.Transactions, store types.Store, eventManager events.Event, webHost *didweb.Host, webResolver *didweb.Resolver) *VDR {
 	return &VDR{
 		config:            config,
 		network:           networkClient,
@@ -69,6 +74,8 @@ func NewVDR(config Config, cryptoClient crypto.KeyStore, networkClient network.T
 		didDocResolver:    doc.Resolver{Store: store},
 		networkAmbassador: NewAmbassador(networkClient, store, eventManager),
 		keyStore:          cryptoClient,
+		webHost:           webHost,
+		webResolver:       webResolver,
 	}
 }
 
@@ -84,6 +91,12 @@ func (r *VDR) Config() interface{} {
 func (r *VDR) Configure(_ core.ServerConfig) error {
 	// Initiate the routines for auto-updating the data.
 	r.networkAmbassador.Configure()
+	if r.webResolver != nil {
+		r.webResolver.Enabled = r.config.Web.Resolve
+	}
+	if r.webHost != nil {
+		return r.webHost.Configure(r.config.Web.URL)
+	}
 	return nil
 }
 
@@ -92,6 +105,9 @@ func (r *VDR) Start() error {
 }
 
 func (r *VDR) Shutdown() error {
+	if r.webHost != nil {
+		return r.webHost.Shutdown()
+	}
 	return nil
 }
 
@@ -109,6 +125,11 @@ func (r *VDR) ConflictedDocuments() ([]did.Document, []types.DocumentMetadata, e
 	return conflictedDocs, conflictedMeta, err
 }
 
+// History returns all versions of the DID Document for the provided DID, oldest version first.
+func (r *VDR) History(id did.DID) ([]did.Document, []types.DocumentMetadata, error) {
+	return r.store.History(id)
+}
+
 // Diagnostics returns the diagnostics for this engine
 func (r *VDR) Diagnostics() []core.DiagnosticResult {
 	// return # conflicted docs
@@ -130,6 +151,14 @@ func (r *VDR) Diagnostics() []core.DiagnosticResult {
 
 // Create generates a new DID Document
 func (r VDR) Create(options types.DIDCreationOptions) (*did.Document, crypto.Key, error) {
+	switch options.Method {
+	case "", doc.NutsDIDMethodName:
+		// did:nuts, published on the network below
+	case didweb.MethodName:
+		return r.createWebDocument(options)
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, options.Method)
+	}
 	log.Logger().Debug("Creating new DID Document.")
 	doc, key, err := r.didDocCreator.Create(options)
 	if err != nil {
@@ -159,6 +188,9 @@ func (r VDR) Update(id did.DID, current hash.SHA256Hash, next did.Document, _ *t
 	log.Logger().
 		WithField(core.LogFieldDID, id).
 		Debug("Updating DID Document")
+	if id.Method == didweb.MethodName {
+		return r.updateWebDocument(id, current, next)
+	}
 	resolverMetadata := &types.ResolveMetadata{
 		Hash:             &current,
 		AllowDeactivated: true,
@@ -171,6 +203,9 @@ func (r VDR) Update(id did.DID, current hash.SHA256Hash, next did.Document, _ *t
 	if store.IsDeactivated(*currentDIDDocument) {
 		return types.ErrDeactivated
 	}
+	if err = requireSingleControllerUpdate(*currentDIDDocument); err != nil {
+		return err
+	}
 
 	if err = CreateDocumentValidator().Validate(next); err != nil {
 		return err
@@ -192,7 +227,12 @@ func (r VDR) Update(id did.DID, current hash.SHA256Hash, next did.Document, _ *t
 	if err != nil {
 		return err
 	}
+	return r.publishUpdate(id, *currentMeta, controller, key, didDocumentType, payload)
+}
 
+// publishUpdate publishes the payload as the next version of the DID document on the network, signed with the given key of the controller.
+// The payloadType is either a DID document or an update proposal.
+func (r VDR) publishUpdate(id did.DID, currentMeta types.DocumentMetadata, controller did.Document, key crypto.Key, payloadType string, payload []byte) error {
 	// for the metadata
 	_, controllerMeta, err := r.didDocResolver.Resolve(controller.ID, nil)
 	if err != nil {
@@ -202,7 +242,7 @@ func (r VDR) Update(id did.DID, current hash.SHA256Hash, next did.Document, _ *t
 	// a DIDDocument update must point to its previous version, current heads and the controller TX (for signing key transaction ordering)
 	previousTransactions := append(currentMeta.SourceTransactions, controllerMeta.SourceTransactions...)
 
-	tx := network.TransactionTemplate(didDocumentType, payload, key).WithAdditionalPrevs(previousTransactions)
+	tx := network.TransactionTemplate(payloadType, payload, key).WithAdditionalPrevs(previousTransactions)
 	_, err = r.network.CreateTransaction(tx)
 	if err == nil {
 		log.Logger().
@@ -218,6 +258,38 @@ func (r VDR) Update(id did.DID, current hash.SHA256Hash, next did.Document, _ *t
 	return err
 }
 
+func (r VDR) createWebDocument(options types.DIDCreationOptions) (*did.Document, crypto.Key, error) {
+	if r.webHost == nil {
+		return nil, nil, fmt.Errorf("%w: %s", types.ErrUnsupportedDIDMethod, options.Method)
+	}
+	doc, key, err := r.webHost.Create(options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create DID document: %w", err)
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, doc.ID).
+		Info("New did:web DID Document created")
+	return doc, key, nil
+}
+
+// updateWebDocument updates a did:web DID document hosted by this node. Since it isn't published on the network,
+// it doesn't need to be signed by a controller.
+func (r VDR) updateWebDocument(id did.DID, current hash.SHA256Hash, next did.Document) error {
+	if r.webHost == nil {
+		return types.ErrDIDNotManagedByThisNode
+	}
+	if err := CreateDocumentValidator().Validate(next); err != nil {
+		return err
+	}
+	if err := r.webHost.Update(id, current, next); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, id).
+		Info("DID Document updated")
+	return nil
+}
+
 func (r VDR) resolveControllerWithKey(doc did.Document) (did.Document, crypto.Key, error) {
 	controllers, err := r.didDocResolver.ResolveControllers(doc, nil)
 	if err != nil {
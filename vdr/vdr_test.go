@@ -24,6 +24,9 @@ import (
 	"testing"
 
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
 	"github.com/nuts-foundation/nuts-node/vdr/doc"
 	"github.com/nuts-foundation/nuts-node/vdr/store"
 
@@ -244,7 +247,7 @@ func TestVDR_Create(t *testing.T) {
 
 func TestNewVDR(t *testing.T) {
 	cfg := Config{}
-	vdr := NewVDR(cfg, nil, nil, nil, nil)
+	vdr := NewVDR(cfg, nil, nil, nil, nil, nil, nil)
 	assert.IsType(t, &VDR{}, vdr)
 	assert.Equal(t, vdr.config, cfg)
 }
@@ -253,19 +256,23 @@ func TestVDR_Configure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	tx := network.NewMockTransactions(ctrl)
 	// Make sure configuring VDR subscribes to network
+	tx.EXPECT().RegisterPayloadType(didDocumentType, gomock.Any())
+	tx.EXPECT().RegisterPayloadType(didDocumentProposalType, gomock.Any())
 	tx.EXPECT().WithPersistency()
 	tx.EXPECT().Subscribe("vdr", gomock.Any(), gomock.Any())
-	cfg := Config{}
-	vdr := NewVDR(cfg, nil, tx, nil, nil)
+	cfg := Config{Web: WebConfig{Resolve: true}}
+	webResolver := didweb.NewResolver()
+	vdr := NewVDR(cfg, nil, tx, nil, nil, nil, webResolver)
 	err := vdr.Configure(*core.NewServerConfig())
 	assert.NoError(t, err)
+	assert.True(t, webResolver.Enabled)
 }
 
 func TestVDR_ConflictingDocuments(t *testing.T) {
 	t.Run("diagnostics", func(t *testing.T) {
 		t.Run("ok - no conflicts", func(t *testing.T) {
 			s := store.NewMemoryStore()
-			vdr := NewVDR(Config{}, nil, nil, s, nil)
+			vdr := NewVDR(Config{}, nil, nil, s, nil, nil, nil)
 			results := vdr.Diagnostics()
 
 			if !assert.Len(t, results, 1) {
@@ -276,7 +283,7 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 
 		t.Run("ok - 1 conflict", func(t *testing.T) {
 			s := store.NewMemoryStore()
-			vdr := NewVDR(Config{}, nil, nil, s, nil)
+			vdr := NewVDR(Config{}, nil, nil, s, nil, nil, nil)
 			doc := did.Document{ID: *TestDIDA}
 			metadata := types.DocumentMetadata{SourceTransactions: []hash.SHA256Hash{hash.EmptyHash(), hash.EmptyHash()}}
 			s.Write(doc, metadata)
@@ -291,7 +298,7 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 	t.Run("list", func(t *testing.T) {
 		t.Run("ok - no conflicts", func(t *testing.T) {
 			s := store.NewMemoryStore()
-			vdr := NewVDR(Config{}, nil, nil, s, nil)
+			vdr := NewVDR(Config{}, nil, nil, s, nil, nil, nil)
 			docs, meta, err := vdr.ConflictedDocuments()
 
 			if !assert.NoError(t, err) {
@@ -303,7 +310,7 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 
 		t.Run("ok - 1 conflict", func(t *testing.T) {
 			s := store.NewMemoryStore()
-			vdr := NewVDR(Config{}, nil, nil, s, nil)
+			vdr := NewVDR(Config{}, nil, nil, s, nil, nil, nil)
 			doc := did.Document{ID: *TestDIDA}
 			metadata := types.DocumentMetadata{SourceTransactions: []hash.SHA256Hash{hash.EmptyHash(), hash.EmptyHash()}}
 			s.Write(doc, metadata)
@@ -318,6 +325,21 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 	})
 }
 
+func TestVDR_History(t *testing.T) {
+	s := store.NewMemoryStore()
+	vdr := NewVDR(Config{}, nil, nil, s, nil, nil, nil)
+	doc := did.Document{ID: *TestDIDA}
+	_ = s.Write(doc, types.DocumentMetadata{Hash: hash.EmptyHash()})
+
+	docs, meta, err := vdr.History(*TestDIDA)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, docs, 1)
+	assert.Len(t, meta, 1)
+}
+
 func TestVDR_resolveControllerKey(t *testing.T) {
 	id, _ := did.ParseDID("did:nuts:123")
 	controllerId, _ := did.ParseDID("did:nuts:1234")
@@ -388,3 +410,67 @@ func TestVDR_resolveControllerKey(t *testing.T) {
 		assert.Equal(t, types.ErrDIDNotManagedByThisNode, err)
 	})
 }
+
+func TestVDR_WebDocuments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tx := network.NewMockTransactions(ctrl)
+	tx.EXPECT().WithPersistency().AnyTimes()
+	tx.EXPECT().RegisterPayloadType(gomock.Any(), gomock.Any()).AnyTimes()
+	tx.EXPECT().Subscribe("vdr", gomock.Any(), gomock.Any()).AnyTimes()
+	keyStore := crypto.NewTestCryptoInstance()
+	storageEngine := storage.NewTestStorageEngine(io.TestDirectory(t))
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	webHost := didweb.NewHost(keyStore, storageEngine.GetProvider(ModuleName))
+	vdr := NewVDR(Config{Web: WebConfig{URL: "https://example.com"}}, keyStore, tx, store.NewMemoryStore(), nil, webHost, nil)
+	if !assert.NoError(t, vdr.Configure(*core.NewServerConfig())) {
+		return
+	}
+	t.Cleanup(func() {
+		_ = vdr.Shutdown()
+	})
+	methods := doc.NewMethodResolvers()
+	methods.Register(didweb.MethodName, webHost)
+	manipulator := doc.Manipulator{
+		KeyCreator:   keyStore,
+		Updater:      vdr,
+		Resolver:     doc.Resolver{Store: store.NewMemoryStore(), Methods: methods},
+		KeyLifecycle: keyStore,
+	}
+	options := doc.DefaultCreationOptions()
+	options.Method = didweb.MethodName
+
+	document, _, err := vdr.Create(options)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("add and remove verification method", func(t *testing.T) {
+		method, err := manipulator.AddVerificationMethod(document.ID)
+		if !assert.NoError(t, err) {
+			return
+		}
+		resolved, _, _ := webHost.Resolve(document.ID, nil)
+		assert.Len(t, resolved.VerificationMethod, 2)
+		assert.True(t, keyStore.Exists(method.ID.String()))
+
+		err = manipulator.RemoveVerificationMethod(document.ID, method.ID)
+
+		assert.NoError(t, err)
+		resolved, _, _ = webHost.Resolve(document.ID, nil)
+		assert.Len(t, resolved.VerificationMethod, 1)
+	})
+	t.Run("deactivate", func(t *testing.T) {
+		err := manipulator.Deactivate(document.ID)
+
+		assert.NoError(t, err)
+		_, _, err = webHost.Resolve(document.ID, nil)
+		assert.ErrorIs(t, err, types.ErrDeactivated)
+	})
+	t.Run("error - unsupported DID method", func(t *testing.T) {
+		_, _, err := vdr.Create(types.DIDCreationOptions{Method: "example"})
+
+		assert.ErrorIs(t, err, types.ErrUnsupportedDIDMethod)
+	})
+}